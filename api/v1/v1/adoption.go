@@ -0,0 +1,18 @@
+package v1
+
+// AdoptionPolicyAnnotation defines how a custom resource handles an object which already exists in keycloak
+// and is not yet owned by the custom resource. Only the KeycloakClient, KeycloakClientRole, KeycloakClientScope,
+// KeycloakRealmGroup and KeycloakRealmRole support it.
+const AdoptionPolicyAnnotation = "edp.epam.com/adoption-policy"
+
+const (
+	// AdoptionPolicyAdopt takes ownership of the existing object and overwrites it with the spec.
+	AdoptionPolicyAdopt = "adopt"
+	// AdoptionPolicyFail sets the failure status and leaves the existing object untouched.
+	AdoptionPolicyFail = "fail"
+	// AdoptionPolicySkip leaves the existing object untouched and sets the skipped status.
+	AdoptionPolicySkip = "skip"
+)
+
+// StatusSkipped is a status of the custom resource which was not applied because of the skip adoption policy.
+const StatusSkipped = "skipped"
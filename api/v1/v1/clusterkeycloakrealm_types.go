@@ -0,0 +1,151 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ClusterKeycloakRealmSpec defines the desired state of ClusterKeycloakRealm.
+type ClusterKeycloakRealmSpec struct {
+	// RealmName is a name of the realm in keycloak.
+	// +kubebuilder:validation:MinLength=1
+	RealmName string `json:"realmName"`
+
+	// KeycloakRef is a reference to the Keycloak custom resource the realm is created in, the namespace is required.
+	KeycloakRef KeycloakRef `json:"keycloakRef"`
+
+	// AllowedNamespaces is a list of the namespaces whose resources can reference the realm.
+	// The resources from all namespaces can reference the realm if it is not set.
+	// +nullable
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// +nullable
+	// +optional
+	Themes *RealmThemes `json:"themes,omitempty"`
+
+	// +nullable
+	// +optional
+	RealmEventConfig *RealmEventConfig `json:"realmEventConfig,omitempty"`
+
+	// PasswordPolicies is a list of the realm password policies, the type is a keycloak policy id, e.g. length.
+	// +nullable
+	// +optional
+	PasswordPolicies []PasswordPolicy `json:"passwordPolicy,omitempty"`
+
+	// BruteForceProtection is the configuration of the realm brute force detection.
+	// +nullable
+	// +optional
+	BruteForceProtection *RealmBruteForceProtection `json:"bruteForceProtection,omitempty"`
+
+	// TokenSettings is the configuration of the realm token and session lifetimes.
+	// +nullable
+	// +optional
+	TokenSettings *RealmTokenSettings `json:"tokenSettings,omitempty"`
+
+	// FrontendURL is the URL of the realm used for the token issuer and the links sent to the users.
+	// The empty value removes the override, the frontend URL is left unchanged if it is not set.
+	// +kubebuilder:validation:Pattern=`^(https?://.*)?$`
+	// +optional
+	FrontendURL *string `json:"frontendUrl,omitempty"`
+
+	// AdminURL is the URL the realm admin console is served from behind a reverse proxy.
+	// The empty value restores the keycloak admin URL, the admin URL is left unchanged if it is not set.
+	// +kubebuilder:validation:Pattern=`^(https?://.*)?$`
+	// +optional
+	AdminURL *string `json:"adminUrl,omitempty"`
+}
+
+// ClusterKeycloakRealmStatus defines the observed state of ClusterKeycloakRealm.
+type ClusterKeycloakRealmStatus struct {
+	// +optional
+	Available bool `json:"available,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+
+// ClusterKeycloakRealm is the Schema for the cluster scoped realm API.
+// It allows the namespaced realm children to reference a realm owned by the platform team.
+type ClusterKeycloakRealm struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterKeycloakRealmSpec   `json:"spec,omitempty"`
+	Status ClusterKeycloakRealmStatus `json:"status,omitempty"`
+}
+
+func (in *ClusterKeycloakRealm) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *ClusterKeycloakRealm) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *ClusterKeycloakRealm) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *ClusterKeycloakRealm) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+// IsNamespaceAllowed checks whether the resources from the namespace can reference the realm.
+func (in *ClusterKeycloakRealm) IsNamespaceAllowed(namespace string) bool {
+	if len(in.Spec.AllowedNamespaces) == 0 {
+		return true
+	}
+
+	for _, ns := range in.Spec.AllowedNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ToKeycloakRealm converts the cluster realm to the KeycloakRealm in the namespace of the referenced Keycloak,
+// so it can be used by the realm handlers and the realm children. The result is not stored in k8s.
+func (in *ClusterKeycloakRealm) ToKeycloakRealm() *KeycloakRealm {
+	ref := in.Spec.KeycloakRef
+
+	return &KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      in.Name,
+			Namespace: in.Spec.KeycloakRef.Namespace,
+		},
+		Spec: KeycloakRealmSpec{
+			RealmName:            in.Spec.RealmName,
+			KeycloakOwner:        in.Spec.KeycloakRef.Name,
+			KeycloakRef:          &ref,
+			Themes:               in.Spec.Themes,
+			RealmEventConfig:     in.Spec.RealmEventConfig,
+			PasswordPolicies:     in.Spec.PasswordPolicies,
+			BruteForceProtection: in.Spec.BruteForceProtection,
+			TokenSettings:        in.Spec.TokenSettings,
+			FrontendURL:          in.Spec.FrontendURL,
+			AdminURL:             in.Spec.AdminURL,
+		},
+	}
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterKeycloakRealmList contains a list of ClusterKeycloakRealm.
+type ClusterKeycloakRealmList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterKeycloakRealm `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterKeycloakRealm{}, &ClusterKeycloakRealmList{})
+}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	identityProviderClientSecretKey = "clientSecret"
+	ldapBindCredentialKey           = "bindCredential"
+)
+
+// vaultReferencePrefix is a prefix of the keycloak vault expression, such values are not plaintext credentials.
+const vaultReferencePrefix = "${vault."
+
+// credentialField is a spec field that must not contain a plaintext credential.
+// +kubebuilder:object:generate=false
+type credentialField struct {
+	path  *field.Path
+	value string
+	// hint points to the field which should be used instead.
+	hint string
+}
+
+func isPlaintextCredential(value string) bool {
+	return value != "" && !strings.HasPrefix(value, vaultReferencePrefix)
+}
+
+// configCredentialFields returns credential fields for the given keys of the raw component config.
+func configCredentialFields(path *field.Path, config map[string][]string, hint string, keys ...string) []credentialField {
+	fields := make([]credentialField, 0, len(keys))
+
+	for _, key := range keys {
+		for i, v := range config[key] {
+			fields = append(fields, credentialField{path: path.Key(key).Index(i), value: v, hint: hint})
+		}
+	}
+
+	return fields
+}
+
+// validateCredentials rejects plaintext credentials. Values that did not change in the update are accepted,
+// so already existing resources can still be updated by the operator, e.g. to remove the finalizer.
+func validateCredentials(kind, name string, fields, oldFields []credentialField) error {
+	unchanged := make(map[string]string, len(oldFields))
+	for _, f := range oldFields {
+		unchanged[f.path.String()] = f.value
+	}
+
+	var errs field.ErrorList
+
+	for _, f := range fields {
+		if !isPlaintextCredential(f.value) {
+			continue
+		}
+
+		if old, ok := unchanged[f.path.String()]; ok && old == f.value {
+			continue
+		}
+
+		errs = append(errs, field.Forbidden(f.path, "plaintext credentials are not allowed, "+f.hint))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{Group: SchemeGroupVersion.Group, Kind: kind}, name, errs)
+}
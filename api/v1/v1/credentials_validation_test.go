@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeycloakRealmUser_ValidateCreate(t *testing.T) {
+	user := KeycloakRealmUser{ObjectMeta: metav1.ObjectMeta{Name: "user"}}
+	assert.NoError(t, user.ValidateCreate())
+
+	user.Spec.PasswordSecret = &SecretKeyRef{Name: "user-secret", Key: "password"}
+	assert.NoError(t, user.ValidateCreate())
+
+	user.Spec.Password = "${vault.user_password}"
+	assert.NoError(t, user.ValidateCreate())
+
+	user.Spec.Password = "pass123"
+	err := user.ValidateCreate()
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.password")
+	assert.Contains(t, err.Error(), "spec.passwordSecret")
+	assert.NotContains(t, err.Error(), "pass123")
+}
+
+func TestKeycloakRealmUser_ValidateUpdate(t *testing.T) {
+	old := KeycloakRealmUser{Spec: KeycloakRealmUserSpec{Password: "pass123"}}
+	user := old.DeepCopy()
+	user.Finalizers = []string{"finalizer"}
+
+	assert.NoError(t, user.ValidateUpdate(&old), "unchanged password should be accepted")
+
+	user.Spec.Password = "pass321"
+	assert.Error(t, user.ValidateUpdate(&old))
+	assert.NoError(t, user.ValidateDelete())
+}
+
+func TestKeycloakRealmIdentityProvider_ValidateCreate(t *testing.T) {
+	idp := KeycloakRealmIdentityProvider{Spec: KeycloakRealmIdentityProviderSpec{
+		Config: map[string]string{"clientId": "client"},
+	}}
+	assert.NoError(t, idp.ValidateCreate())
+
+	idp.Spec.Config["clientSecret"] = "secret"
+	err := idp.ValidateCreate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.clientSecretRef")
+}
+
+func TestKeycloakLDAPFederation_ValidateCreate(t *testing.T) {
+	federation := KeycloakLDAPFederation{Spec: KeycloakLDAPFederationSpec{
+		Config: map[string][]string{"bindCredential": {"${vault.ldap}"}},
+	}}
+	assert.NoError(t, federation.ValidateCreate())
+
+	federation.Spec.Config["bindCredential"] = []string{"secret"}
+	err := federation.ValidateCreate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.config[bindCredential][0]")
+	assert.Contains(t, err.Error(), "spec.bindCredential")
+}
+
+func TestKeycloakRealmComponent_ValidateUpdate(t *testing.T) {
+	old := KeycloakRealmComponent{Spec: KeycloakComponentSpec{
+		Config: map[string][]string{"priority": {"0"}},
+	}}
+	component := old.DeepCopy()
+	assert.NoError(t, component.ValidateUpdate(&old))
+
+	component.Spec.Config["clientSecret"] = []string{"secret"}
+	err := component.ValidateUpdate(&old)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vault")
+}
@@ -0,0 +1,25 @@
+package v1
+
+// DriftPolicyAnnotation defines what the drift detector does when keycloak differs from the custom resource.
+const DriftPolicyAnnotation = "edp.epam.com/drift-policy"
+
+const (
+	// DriftPolicyAlert only sets the Drifted condition and the drift metric.
+	DriftPolicyAlert = "alert"
+	// DriftPolicyHeal also triggers the reconciliation which overwrites the keycloak changes.
+	DriftPolicyHeal = "heal"
+)
+
+// DriftReportConfigMapAnnotation is a name of the config map in the namespace of the KeycloakRealm
+// the drift detector stores the verification report of the realm in.
+const DriftReportConfigMapAnnotation = "edp.epam.com/drift-report-configmap"
+
+// ConditionDrifted is a type of the condition which shows whether keycloak differs from the custom resource.
+const ConditionDrifted = "Drifted"
+
+// Reasons of the Drifted condition.
+const (
+	ReasonInSync        = "InSync"
+	ReasonDriftDetected = "DriftDetected"
+	ReasonMissing       = "Missing"
+)
@@ -0,0 +1,5 @@
+package v1
+
+// DryRunAnnotation disables applying the custom resource to keycloak when set to "true",
+// the changes which would be made are published in the status instead.
+const DryRunAnnotation = "edp.epam.com/dry-run"
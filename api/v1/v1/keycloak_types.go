@@ -9,20 +9,213 @@ type KeycloakSpec struct {
 	// URL of keycloak service
 	Url string `json:"url"`
 
-	// Secret is the name of the k8s object Secret related to keycloak
-	Secret string `json:"secret"`
+	// FailoverURLs are the additional admin URLs of the same keycloak, e.g. the per-site endpoints of a HA deployment.
+	// The operator checks the health of the active URL and fails over to the first healthy URL in the order
+	// of url and failoverUrls.
+	// +nullable
+	// +optional
+	FailoverURLs []string `json:"failoverUrls,omitempty"`
+
+	// Secret is the name of the k8s object Secret related to keycloak.
+	// It is not used with the serviceAccountToken admin type.
+	// +optional
+	Secret string `json:"secret,omitempty"`
+
+	// Vault is the HashiCorp Vault secret with the admin credentials, it is used instead of the secret.
+	// +nullable
+	// +optional
+	Vault *KeycloakVaultCredentials `json:"vault,omitempty"`
 
-	// AdminType can be user or serviceAccount, if serviceAccount was specified, then client_credentials grant type should be used for getting admin realm token
+	// AdminType can be user or serviceAccount, if serviceAccount was specified, then client_credentials grant type should be used for getting admin realm token.
+	// If serviceAccountToken was specified, then the operator authenticates the client with its Kubernetes ServiceAccount token
+	// instead of the client secret.
 	// +optional
-	// +kubebuilder:validation:Enum=serviceAccount;user
+	// +kubebuilder:validation:Enum=serviceAccount;user;serviceAccountToken
 	AdminType string `json:"adminType,omitempty"`
+
+	// ServiceAccountToken is the configuration of the serviceAccountToken admin type.
+	// +nullable
+	// +optional
+	ServiceAccountToken *KeycloakServiceAccountToken `json:"serviceAccountToken,omitempty"`
+
+	// InsecureSkipVerify disables the verification of the keycloak TLS certificate.
+	// It is intended only for the lab environments with the self-signed certificates and is shown in the status conditions.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// ProxyURL is a URL of the HTTP or HTTPS proxy the operator connects to keycloak through,
+	// e.g. http://proxy.example.com:3128. The proxy from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables of the operator is used if it is not set.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	ProxyURL string `json:"proxyUrl,omitempty"`
+
+	// ContextPath is the context path of the keycloak API, e.g. /auth for the keycloak versions before 17
+	// or / for the root path. If it is not set, the operator detects whether the API is served
+	// with or without the /auth context path.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	ContextPath string `json:"contextPath,omitempty"`
+
+	// TokenEndpointPath is the path of the token endpoint relative to the realm URL,
+	// it can be changed if the token endpoint is exposed by the reverse proxy at another path.
+	// The protocol/openid-connect/token path is used if it is not set.
+	// +optional
+	TokenEndpointPath string `json:"tokenEndpointPath,omitempty"`
+
+	// ProxyAuth is the basic authentication of the authenticating reverse proxy in front of keycloak.
+	// +nullable
+	// +optional
+	ProxyAuth *KeycloakProxyAuth `json:"proxyAuth,omitempty"`
+
+	// HeadersFrom is a list of the config maps and secrets with the HTTP headers attached to every request
+	// to keycloak, e.g. X-Forwarded-Host or a WAF token. Each key of the source is a header name.
+	// The headers of the later sources override the earlier ones.
+	// +nullable
+	// +optional
+	HeadersFrom []KeycloakHeadersSource `json:"headersFrom,omitempty"`
+
+	// RateLimit limits the rate of the requests the operator sends to keycloak.
+	// It is applied on top of the global rate limit set with the keycloak-rate-limit flag of the operator.
+	// +nullable
+	// +optional
+	RateLimit *KeycloakRateLimit `json:"rateLimit,omitempty"`
+}
+
+// KeycloakVaultCredentials is the HashiCorp Vault secret with the username and the password, or the client id
+// and the client secret for the serviceAccount admin type. The Vault address and the Kubernetes auth role
+// the operator logs in with are the operator flags, the secret path must be under the allowed prefix of the operator.
+type KeycloakVaultCredentials struct {
+	// Path is the API path of the secret, e.g. secret/data/keycloak for the KV version 2 secrets engine.
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// UsernameKey is the key of the username in the secret.
+	// +kubebuilder:default=username
+	// +optional
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey is the key of the password in the secret.
+	// +kubebuilder:default=password
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// GetUsernameKey returns the key of the username or the default one if it is not set.
+func (in *KeycloakVaultCredentials) GetUsernameKey() string {
+	if in.UsernameKey == "" {
+		return "username"
+	}
+
+	return in.UsernameKey
+}
+
+// GetPasswordKey returns the key of the password or the default one if it is not set.
+func (in *KeycloakVaultCredentials) GetPasswordKey() string {
+	if in.PasswordKey == "" {
+		return "password"
+	}
+
+	return in.PasswordKey
+}
+
+// KeycloakServiceAccountToken is the client of the master realm the operator authenticates as
+// with the client_credentials grant and its projected Kubernetes ServiceAccount token as the JWT client assertion.
+// The client must trust the Kubernetes issuer, e.g. with the Kubernetes identity provider of keycloak.
+// The token is sent only to the keycloaks in the namespace of the operator or to the keycloak URLs allowed by the operator.
+type KeycloakServiceAccountToken struct {
+	// ClientID is a client id of the master realm client.
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientId"`
+}
+
+// KeycloakProxyAuth is the basic authentication credentials of the reverse proxy in front of keycloak.
+type KeycloakProxyAuth struct {
+	// Secret is a name of the secret with the username and password keys in the namespace of the keycloak.
+	// +kubebuilder:validation:MinLength=1
+	Secret string `json:"secret"`
+
+	// Header is the header the basic credentials are sent in. The Authorization header carries
+	// the keycloak token, so the proxy must read the credentials from another header.
+	// +kubebuilder:default=Proxy-Authorization
+	// +optional
+	Header string `json:"header,omitempty"`
+}
+
+// GetHeader returns the header of the credentials or the default one if it is not set.
+func (in *KeycloakProxyAuth) GetHeader() string {
+	if in.Header == "" {
+		return "Proxy-Authorization"
+	}
+
+	return in.Header
+}
+
+// KeycloakRateLimit is the client side limit of the rate of the requests to keycloak.
+type KeycloakRateLimit struct {
+	// RequestsPerSecond is a number of the requests sent to keycloak per second.
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int `json:"requestsPerSecond"`
+
+	// Burst is a number of the requests which can be sent at once above the rate,
+	// the requests per second are used if it is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst int `json:"burst,omitempty"`
+}
+
+// KeycloakHeadersSource is a reference to the config map or secret with the HTTP headers
+// in the namespace of the keycloak. Exactly one of the references must be set.
+type KeycloakHeadersSource struct {
+	// ConfigMapName is a name of the config map with the headers.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// SecretName is a name of the secret with the headers.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
 }
 
 const (
 	KeycloakAdminTypeUser           = "user"
 	KeycloakAdminTypeServiceAccount = "serviceAccount"
+	// KeycloakAdminTypeServiceAccountToken authenticates the client with the Kubernetes ServiceAccount token.
+	KeycloakAdminTypeServiceAccountToken = "serviceAccountToken"
 )
 
+// GetURLs returns the url and the failover urls of the keycloak without the duplicates.
+func (in *Keycloak) GetURLs() []string {
+	urls := []string{in.Spec.Url}
+
+	for _, u := range in.Spec.FailoverURLs {
+		if !containsString(urls, u) {
+			urls = append(urls, u)
+		}
+	}
+
+	return urls
+}
+
+// GetActiveURL returns the URL the operator connects to keycloak with,
+// it is the active URL from the status if it is still configured or the url from the spec otherwise.
+func (in *Keycloak) GetActiveURL() string {
+	if in.Status.ActiveURL != "" && containsString(in.GetURLs(), in.Status.ActiveURL) {
+		return in.Status.ActiveURL
+	}
+
+	return in.Spec.Url
+}
+
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (in *Keycloak) GetAdminType() string {
 	if in.Spec.AdminType == "" {
 		in.Spec.AdminType = KeycloakAdminTypeUser
@@ -31,12 +224,62 @@ func (in *Keycloak) GetAdminType() string {
 	return in.Spec.AdminType
 }
 
+// KeycloakKind is a kind of the Keycloak custom resource.
+const KeycloakKind = "Keycloak"
+
+// KeycloakRef is a reference to the Keycloak custom resource.
+type KeycloakRef struct {
+	// Kind is a kind of the referenced resource.
+	// +kubebuilder:validation:Enum=Keycloak
+	// +kubebuilder:default=Keycloak
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name is a name of the Keycloak custom resource.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is a namespace of the Keycloak custom resource.
+	// The namespace of the referencing resource is used if it is not set, it is required for the cluster resources.
+	// The realm children can reference only the Keycloak in their own namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // KeycloakStatus defines the observed state of Keycloak.
 type KeycloakStatus struct {
 	// Connected shows if keycloak service is up and running
 	Connected bool `json:"connected"`
+
+	// ActiveURL is the URL the operator is connected to keycloak with.
+	// +optional
+	ActiveURL string `json:"activeUrl,omitempty"`
+
+	// +nullable
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ConditionInsecureSkipVerify is a type of the condition which shows whether
+// the verification of the keycloak TLS certificate is disabled.
+const ConditionInsecureSkipVerify = "InsecureSkipVerify"
+
+// Reasons of the InsecureSkipVerify condition.
+const (
+	ReasonTLSVerificationDisabled = "TLSVerificationDisabled"
+	ReasonTLSVerificationEnabled  = "TLSVerificationEnabled"
+)
+
+// ConditionDegraded is a type of the condition which shows whether the requests to keycloak are suspended
+// because it is unavailable.
+const ConditionDegraded = "Degraded"
+
+// Reasons of the Degraded condition.
+const (
+	ReasonKeycloakUnavailable = "KeycloakUnavailable"
+	ReasonKeycloakAvailable   = "KeycloakAvailable"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
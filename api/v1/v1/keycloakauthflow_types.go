@@ -5,7 +5,25 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 // KeycloakAuthFlowSpec defines the desired state of KeycloakAuthFlow.
 type KeycloakAuthFlowSpec struct {
 	// Realm is name of keycloak realm
-	Realm string `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
 
 	// Alias is display name for authentication flow
 	Alias string `json:"alias"`
@@ -30,13 +48,25 @@ type KeycloakAuthFlowSpec struct {
 	// ChildType is type for auth flow if it has a parent, available options: basic-flow, form-flow
 	// +optional
 	ChildType string `json:"childType,omitempty"`
+
+	// FallbackFlow is an alias of the flow the realm, identity provider and client bindings of this flow
+	// are moved to before it is deleted.
+	// If it is not set, the realm and the first broker login bindings are moved to the built-in flows, e.g. browser,
+	// and the post broker login flows and the client flow overrides are removed.
+	// +optional
+	FallbackFlow string `json:"fallbackFlow,omitempty"`
 }
 
 // AuthenticationExecution defines keycloak authentication execution.
+// Executions are updated in place, they are matched with the existing ones by the authenticator
+// and child flows by the alias.
 type AuthenticationExecution struct {
+	// Authenticator is a provider id of the authenticator, e.g. auth-cookie.
+	// Conditions, e.g. conditional-user-role or conditional-user-configured, can be used only in child flows.
 	// +optional
 	Authenticator string `json:"authenticator,omitempty"`
 
+	// AuthenticatorConfig is a config of the authenticator, e.g. condUserRole for conditional-user-role.
 	// +nullable
 	// +optional
 	AuthenticatorConfig *AuthenticatorConfig `json:"authenticatorConfig,omitempty"`
@@ -44,9 +74,15 @@ type AuthenticationExecution struct {
 	// +optional
 	AuthenticatorFlow bool `json:"authenticatorFlow,omitempty"`
 
+	// Priority defines the order of the execution in the flow, executions with lower priority go first.
+	// The live order is checked after the sync, the order which can not be applied is reported in the status
+	// as the ExecutionOrderDrift reason of the Reconciled condition.
 	// +optional
 	Priority int `json:"priority,omitempty"`
 
+	// Requirement defines the requirement of the execution.
+	// CONDITIONAL is available only for child flows, the flow is executed if all its conditions are met.
+	// +kubebuilder:validation:Enum=REQUIRED;ALTERNATIVE;DISABLED;CONDITIONAL
 	// +optional
 	Requirement string `json:"requirement,omitempty"`
 
@@ -55,6 +91,7 @@ type AuthenticationExecution struct {
 }
 
 type AuthenticatorConfig struct {
+	// Alias is a unique name of the config in the realm, it is required by keycloak if the config is set.
 	// +optional
 	Alias string `json:"alias,omitempty"`
 
@@ -70,6 +107,16 @@ type KeycloakAuthFlowStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// AppliedGeneration is the generation of the spec applied by the last successful reconciliation.
+	// The flow is checked for the drift only while its spec is applied.
+	// +optional
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+
+	// Conditions show whether the flow is synced and whether it or its executions were changed in Keycloak.
+	// +nullable
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -86,9 +133,25 @@ type KeycloakAuthFlow struct {
 }
 
 func (in *KeycloakAuthFlow) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakAuthFlow) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakAuthFlow) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakAuthFlow) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
 func (in *KeycloakAuthFlow) GetFailureCount() int64 {
 	return in.Status.FailureCount
 }
@@ -97,6 +160,22 @@ func (in *KeycloakAuthFlow) SetFailureCount(count int64) {
 	in.Status.FailureCount = count
 }
 
+func (in *KeycloakAuthFlow) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakAuthFlow) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakAuthFlow) GetAppliedGeneration() int64 {
+	return in.Status.AppliedGeneration
+}
+
+func (in *KeycloakAuthFlow) SetAppliedGeneration(generation int64) {
+	in.Status.AppliedGeneration = generation
+}
+
 func (in *KeycloakAuthFlow) GetStatus() string {
 	return in.Status.Value
 }
@@ -0,0 +1,231 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	requirementConditional       = "CONDITIONAL"
+	conditionAuthenticatorPrefix = "conditional-"
+)
+
+// SetupWebhookWithManager registers the validating webhook of KeycloakAuthFlow.
+func (in *KeycloakAuthFlow) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(in).
+		WithValidator(&keycloakAuthFlowValidator{reader: mgr.GetAPIReader()}).Complete(); err != nil {
+		return fmt.Errorf("failed to setup KeycloakAuthFlow webhook: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-v1-edp-epam-com-v1-keycloakauthflow,mutating=false,failurePolicy=fail,sideEffects=None,groups=v1.edp.epam.com,resources=keycloakauthflows,verbs=create;update,versions=v1,name=vkeycloakauthflow.kb.io,admissionReviewVersions=v1
+
+// keycloakAuthFlowValidator rejects KeycloakAuthFlow whose executions keycloak rejects or can not order
+// and whose child flow executions reference the flows which are not declared.
+// +kubebuilder:object:generate=false
+type keycloakAuthFlowValidator struct {
+	reader client.Reader
+}
+
+var _ admission.CustomValidator = &keycloakAuthFlowValidator{}
+
+// ValidateCreate rejects KeycloakAuthFlow with invalid authentication executions.
+func (v *keycloakAuthFlowValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	flow, ok := obj.(*KeycloakAuthFlow)
+	if !ok {
+		return fmt.Errorf("expected KeycloakAuthFlow, got %T", obj)
+	}
+
+	return v.validate(ctx, flow)
+}
+
+// ValidateUpdate rejects KeycloakAuthFlow with invalid authentication executions,
+// the unchanged spec is accepted so the existing flows can still be deleted.
+func (v *keycloakAuthFlowValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	flow, ok := newObj.(*KeycloakAuthFlow)
+	if !ok {
+		return fmt.Errorf("expected KeycloakAuthFlow, got %T", newObj)
+	}
+
+	if o, ok := oldObj.(*KeycloakAuthFlow); ok && reflect.DeepEqual(o.Spec, flow.Spec) {
+		return nil
+	}
+
+	return v.validate(ctx, flow)
+}
+
+// ValidateDelete does nothing, deletion is always allowed.
+func (v *keycloakAuthFlowValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *keycloakAuthFlowValidator) validate(ctx context.Context, flow *KeycloakAuthFlow) error {
+	errs := flow.Spec.ValidateAuthenticationExecutions()
+	errs = append(errs, flow.Spec.validateExecutionPriorities()...)
+
+	childErrs, err := v.validateChildFlowsDeclared(ctx, flow)
+	if err != nil {
+		return err
+	}
+
+	errs = append(errs, childErrs...)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{Group: SchemeGroupVersion.Group, Kind: "KeycloakAuthFlow"}, flow.Name,
+		errs)
+}
+
+// validateChildFlowsDeclared checks that the child flow executions reference the KeycloakAuthFlow resources
+// of the namespace whose parent is the flow, so the child flows must be created before their parent.
+func (v *keycloakAuthFlowValidator) validateChildFlowsDeclared(ctx context.Context,
+	flow *KeycloakAuthFlow) (field.ErrorList, error) {
+	var children map[string]struct{}
+
+	var errs field.ErrorList
+
+	for i := range flow.Spec.AuthenticationExecutions {
+		ae := &flow.Spec.AuthenticationExecutions[i]
+		if !ae.AuthenticatorFlow || ae.Alias == "" {
+			continue
+		}
+
+		if children == nil {
+			var flows KeycloakAuthFlowList
+			if err := v.reader.List(ctx, &flows, client.InNamespace(flow.Namespace)); err != nil {
+				return nil, fmt.Errorf("unable to list auth flows: %w", err)
+			}
+
+			children = make(map[string]struct{}, len(flows.Items))
+
+			for j := range flows.Items {
+				if flows.Items[j].Spec.ParentName == flow.Spec.Alias {
+					children[flows.Items[j].Spec.Alias] = struct{}{}
+				}
+			}
+		}
+
+		if _, ok := children[ae.Alias]; !ok {
+			errs = append(errs, field.NotFound(
+				field.NewPath("spec", "authenticationExecutions").Index(i).Child("alias"), ae.Alias))
+		}
+	}
+
+	return errs, nil
+}
+
+// ValidateAuthenticationExecutions checks the executions which keycloak rejects:
+// the requirements and the child flow and the config aliases.
+func (in *KeycloakAuthFlowSpec) ValidateAuthenticationExecutions() field.ErrorList {
+	var errs field.ErrorList
+
+	basePath := field.NewPath("spec", "authenticationExecutions")
+	flowAliases := make(map[string]struct{})
+	configAliases := make(map[string]struct{})
+
+	for i := range in.AuthenticationExecutions {
+		ae := &in.AuthenticationExecutions[i]
+		path := basePath.Index(i)
+
+		if ae.AuthenticatorFlow {
+			errs = append(errs, in.validateChildFlowExecution(ae, path, flowAliases)...)
+		} else {
+			errs = append(errs, in.validateAuthenticatorExecution(ae, path)...)
+		}
+
+		if ae.AuthenticatorConfig == nil {
+			continue
+		}
+
+		aliasPath := path.Child("authenticatorConfig", "alias")
+
+		if ae.AuthenticatorConfig.Alias == "" {
+			errs = append(errs, field.Required(aliasPath, "keycloak requires the alias of the authenticator config"))
+			continue
+		}
+
+		if _, ok := configAliases[ae.AuthenticatorConfig.Alias]; ok {
+			errs = append(errs, field.Duplicate(aliasPath, ae.AuthenticatorConfig.Alias))
+		}
+
+		configAliases[ae.AuthenticatorConfig.Alias] = struct{}{}
+	}
+
+	return errs
+}
+
+// validateExecutionPriorities checks that the priorities are unique, keycloak can not order the executions
+// with the same priority. It is checked only on admission, so the existing flows with the same priorities
+// are still synced.
+func (in *KeycloakAuthFlowSpec) validateExecutionPriorities() field.ErrorList {
+	var errs field.ErrorList
+
+	priorities := make(map[int]struct{}, len(in.AuthenticationExecutions))
+
+	for i := range in.AuthenticationExecutions {
+		priority := in.AuthenticationExecutions[i].Priority
+
+		if _, ok := priorities[priority]; ok {
+			errs = append(errs, field.Duplicate(
+				field.NewPath("spec", "authenticationExecutions").Index(i).Child("priority"), priority))
+		}
+
+		priorities[priority] = struct{}{}
+	}
+
+	return errs
+}
+
+func (in *KeycloakAuthFlowSpec) validateChildFlowExecution(ae *AuthenticationExecution, path *field.Path,
+	declared map[string]struct{}) field.ErrorList {
+	aliasPath := path.Child("alias")
+
+	switch {
+	case ae.Alias == "":
+		return field.ErrorList{field.Required(aliasPath, "alias of the child flow is required")}
+	case ae.Alias == in.Alias:
+		return field.ErrorList{field.Invalid(aliasPath, ae.Alias, "flow can not be a child of itself")}
+	}
+
+	if _, ok := declared[ae.Alias]; ok {
+		return field.ErrorList{field.Duplicate(aliasPath, ae.Alias)}
+	}
+
+	declared[ae.Alias] = struct{}{}
+
+	return nil
+}
+
+func (in *KeycloakAuthFlowSpec) validateAuthenticatorExecution(ae *AuthenticationExecution,
+	path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if ae.Authenticator == "" {
+		errs = append(errs, field.Required(path.Child("authenticator"), "authenticator or authenticatorFlow is required"))
+	}
+
+	if ae.Requirement == requirementConditional {
+		errs = append(errs, field.Invalid(path.Child("requirement"), ae.Requirement,
+			"requirement CONDITIONAL is available only for child flows"))
+	}
+
+	if strings.HasPrefix(ae.Authenticator, conditionAuthenticatorPrefix) && in.ParentName == "" {
+		errs = append(errs, field.Invalid(path.Child("authenticator"), ae.Authenticator,
+			"conditions can be used only in child flows"))
+	}
+
+	return errs
+}
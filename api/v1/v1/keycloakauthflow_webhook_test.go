@@ -0,0 +1,188 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKeycloakAuthFlowSpec_ValidateAuthenticationExecutions(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     KeycloakAuthFlowSpec
+		wantErrs []string
+	}{
+		{
+			name: "conditional child flow with conditions",
+			spec: KeycloakAuthFlowSpec{
+				Alias:      "cond-otp",
+				ParentName: "browser-forms",
+				AuthenticationExecutions: []AuthenticationExecution{
+					{Authenticator: "conditional-user-configured", Requirement: "REQUIRED"},
+					{
+						Authenticator: "conditional-user-role",
+						Priority:      1,
+						Requirement:   "REQUIRED",
+						AuthenticatorConfig: &AuthenticatorConfig{
+							Alias:  "otp-role",
+							Config: map[string]string{"condUserRole": "otp-users"},
+						},
+					},
+					{AuthenticatorFlow: true, Alias: "sub", Priority: 2, Requirement: "CONDITIONAL"},
+				},
+			},
+		},
+		{
+			name: "conditional authenticator",
+			spec: KeycloakAuthFlowSpec{
+				AuthenticationExecutions: []AuthenticationExecution{
+					{Authenticator: "auth-otp-form", Requirement: "CONDITIONAL"},
+				},
+			},
+			wantErrs: []string{"spec.authenticationExecutions[0].requirement"},
+		},
+		{
+			name: "condition in top level flow",
+			spec: KeycloakAuthFlowSpec{
+				AuthenticationExecutions: []AuthenticationExecution{
+					{Authenticator: "conditional-user-role", Requirement: "REQUIRED"},
+				},
+			},
+			wantErrs: []string{"spec.authenticationExecutions[0].authenticator"},
+		},
+		{
+			name: "duplicated priorities and aliases",
+			spec: KeycloakAuthFlowSpec{
+				Alias: "browser",
+				AuthenticationExecutions: []AuthenticationExecution{
+					{
+						Authenticator:       "auth-cookie",
+						AuthenticatorConfig: &AuthenticatorConfig{Alias: "cfg"},
+					},
+					{
+						Authenticator:       "identity-provider-redirector",
+						Priority:            1,
+						AuthenticatorConfig: &AuthenticatorConfig{Alias: "cfg"},
+					},
+					{AuthenticatorFlow: true, Alias: "forms", Priority: 1},
+					{AuthenticatorFlow: true, Alias: "forms", Priority: 2},
+				},
+			},
+			wantErrs: []string{
+				"spec.authenticationExecutions[1].authenticatorConfig.alias",
+				"spec.authenticationExecutions[3].alias",
+			},
+		},
+		{
+			name: "child flow without alias and config without alias",
+			spec: KeycloakAuthFlowSpec{
+				Alias: "browser",
+				AuthenticationExecutions: []AuthenticationExecution{
+					{AuthenticatorFlow: true},
+					{AuthenticatorFlow: true, Alias: "browser", Priority: 1},
+					{Priority: 2, AuthenticatorConfig: &AuthenticatorConfig{}},
+				},
+			},
+			wantErrs: []string{
+				"spec.authenticationExecutions[0].alias",
+				"spec.authenticationExecutions[1].alias",
+				"spec.authenticationExecutions[2].authenticator",
+				"spec.authenticationExecutions[2].authenticatorConfig.alias",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.spec.ValidateAuthenticationExecutions()
+
+			fields := make([]string, 0, len(errs))
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+
+			assert.ElementsMatch(t, tt.wantErrs, fields)
+		})
+	}
+}
+
+func TestKeycloakAuthFlowSpec_validateExecutionPriorities(t *testing.T) {
+	spec := KeycloakAuthFlowSpec{AuthenticationExecutions: []AuthenticationExecution{
+		{Authenticator: "auth-cookie"},
+		{AuthenticatorFlow: true, Alias: "forms", Priority: 1},
+		{Authenticator: "auth-otp-form", Priority: 1},
+	}}
+
+	errs := spec.validateExecutionPriorities()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.authenticationExecutions[2].priority", errs[0].Field)
+}
+
+func TestKeycloakAuthFlowValidator(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(AddToScheme(s))
+
+	child := &KeycloakAuthFlow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "forms"},
+		Spec:       KeycloakAuthFlowSpec{Alias: "forms", ParentName: "browser"},
+	}
+	otherParent := &KeycloakAuthFlow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "otp"},
+		Spec:       KeycloakAuthFlowSpec{Alias: "otp", ParentName: "direct-grant"},
+	}
+	v := &keycloakAuthFlowValidator{reader: fake.NewClientBuilder().WithScheme(s).WithObjects(child, otherParent).Build()}
+
+	old := KeycloakAuthFlow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "browser"},
+		Spec: KeycloakAuthFlowSpec{Alias: "browser", AuthenticationExecutions: []AuthenticationExecution{
+			{Authenticator: "auth-cookie"},
+			{Authenticator: "auth-otp-form"},
+		}},
+	}
+	flow := old.DeepCopy()
+	flow.Finalizers = []string{"finalizer"}
+
+	assert.NoError(t, v.ValidateUpdate(context.Background(), &old, flow), "unchanged spec should be accepted")
+
+	err := v.ValidateCreate(context.Background(), flow)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.authenticationExecutions[1].priority")
+
+	flow.Spec.AuthenticationExecutions[1].Priority = 1
+	assert.NoError(t, v.ValidateUpdate(context.Background(), &old, flow))
+
+	flow.Spec.AuthenticationExecutions = append(flow.Spec.AuthenticationExecutions,
+		AuthenticationExecution{AuthenticatorFlow: true, Alias: "forms", Priority: 2},
+		AuthenticationExecution{AuthenticatorFlow: true, Alias: "otp", Priority: 3})
+
+	err = v.ValidateUpdate(context.Background(), &old, flow)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "spec.authenticationExecutions[3].alias")
+	assert.NotContains(t, err.Error(), "spec.authenticationExecutions[2].alias")
+
+	assert.NoError(t, v.ValidateDelete(context.Background(), flow))
+}
+
+func TestKeycloakAuthFlowValidator_ReaderFailure(t *testing.T) {
+	v := &keycloakAuthFlowValidator{reader: failingReader{}}
+	flow := &KeycloakAuthFlow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "browser"},
+		Spec: KeycloakAuthFlowSpec{Alias: "browser", AuthenticationExecutions: []AuthenticationExecution{
+			{AuthenticatorFlow: true, Alias: "forms"},
+		}},
+	}
+
+	err := v.ValidateCreate(context.Background(), flow)
+	require.Error(t, err)
+	assert.False(t, apierrors.IsInvalid(err))
+}
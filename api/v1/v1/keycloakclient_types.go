@@ -7,40 +7,182 @@ import (
 const (
 	ReconciliationStrategyFull    = "full"
 	ReconciliationStrategyAddOnly = "addOnly"
+
+	// ClientAuthenticatorSignedJWT is the authenticator of the clients which use JWT signed by the client private key.
+	ClientAuthenticatorSignedJWT = "client-jwt"
 )
 
 // KeycloakClientSpec defines the desired state of KeycloakClient.
+// Optional fields of the client representation which are not set are left untouched in keycloak,
+// so they can be managed outside of the operator.
 type KeycloakClientSpec struct {
 	// ClientId is a unique keycloak client ID referenced in URI and tokens.
 	ClientId string `json:"clientId"`
 
+	// Name is the display name of the client.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Description is the description of the client.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Enabled defines whether the client is allowed to initiate a login or obtain access tokens.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
 	// +optional
 	TargetRealm string `json:"targetRealm,omitempty"`
 
+	// ClusterRealm is a name of the ClusterKeycloakRealm custom resource the client belongs to.
+	// It is used instead of the realm found by the targetRealm if it is set.
+	// +optional
+	ClusterRealm string `json:"clusterRealm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the targetRealm, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// Secret is the name of the Secret with the clientSecret key in the namespace of the resource.
+	// The secret is generated if it is empty. The admission webhook rejects the name of the Secret which does not exist.
 	// +optional
 	Secret string `json:"secret,omitempty"`
 
+	// SecretRotation is a policy of the periodic client secret rotation.
+	// The secret referenced by the secret field is updated with the new value on rotation.
+	// +nullable
+	// +optional
+	SecretRotation *SecretRotationPolicy `json:"secretRotation,omitempty"`
+
+	// OutputSecret is a secret which is kept up to date with the client credentials,
+	// so applications can mount them directly.
+	// +nullable
+	// +optional
+	OutputSecret *ClientOutputSecret `json:"outputSecret,omitempty"`
+
 	// +nullable
 	// +optional
 	RealmRoles *[]RealmRole `json:"realmRoles,omitempty"`
 
+	// Public defines whether the client is public, the client access type is left unchanged if it is not set.
 	// +optional
-	Public bool `json:"public,omitempty"`
+	Public *bool `json:"public,omitempty"`
 
+	// WebUrl is the root URL of the client, it is also used as the admin URL and added to the redirect URIs
+	// and web origins. The URLs are left unchanged if it is not set.
 	// +optional
 	WebUrl string `json:"webUrl,omitempty"`
 
+	// BaseURL is the default URL to use when keycloak needs to redirect or link back to the client.
+	// +optional
+	BaseURL *string `json:"baseUrl,omitempty"`
+
+	// AdminURL is the URL of the client admin interface, webUrl is used if it is not set.
+	// +optional
+	AdminURL *string `json:"adminUrl,omitempty"`
+
+	// ConsentRequired defines whether users have to consent to the client access.
+	// +optional
+	ConsentRequired *bool `json:"consentRequired,omitempty"`
+
+	// AlwaysDisplayInConsole defines whether the client is listed in the account console
+	// even if the user does not have an active session.
+	// +optional
+	AlwaysDisplayInConsole *bool `json:"alwaysDisplayInConsole,omitempty"`
+
+	// StandardFlowEnabled enables the OpenID Connect authorization code flow.
+	// +optional
+	StandardFlowEnabled *bool `json:"standardFlowEnabled,omitempty"`
+
+	// ImplicitFlowEnabled enables the OpenID Connect implicit flow.
+	// +optional
+	ImplicitFlowEnabled *bool `json:"implicitFlowEnabled,omitempty"`
+
+	// BearerOnly defines whether the client only verifies bearer tokens and can not initiate a login.
+	// +optional
+	BearerOnly *bool `json:"bearerOnly,omitempty"`
+
+	// SurrogateAuthRequired defines whether the client is allowed to request a token on behalf of another user.
+	// +optional
+	SurrogateAuthRequired *bool `json:"surrogateAuthRequired,omitempty"`
+
+	// NodeReRegistrationTimeout is the max interval in seconds for cluster nodes of the client to re-register,
+	// -1 disables the registration.
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	NodeReRegistrationTimeout *int32 `json:"nodeReRegistrationTimeout,omitempty"`
+
+	// RedirectURIsFrom is a list of Ingress or OpenShift Route objects in the client namespace.
+	// Hosts of the objects are added to the client redirect URIs and web origins in addition to webUrl.
+	// Client is updated when the host of a referenced Ingress changes, Routes are resolved on each reconciliation.
+	// +nullable
+	// +optional
+	RedirectURIsFrom []RedirectURISource `json:"redirectUrisFrom,omitempty"`
+
+	// Protocol is the protocol of the client: openid-connect (default) or saml.
 	// +nullable
 	// +optional
 	Protocol *string `json:"protocol,omitempty"`
 
+	// SAML is a typed configuration of the SAML client, protocol must be set to saml.
+	// Typed fields take precedence over the attributes.
+	// +nullable
+	// +optional
+	SAML *SAMLClientConfig `json:"saml,omitempty"`
+
+	// OIDC is a typed configuration of the advanced openid-connect client options.
+	// Typed fields take precedence over the attributes.
+	// +nullable
+	// +optional
+	OIDC *OIDCClientConfig `json:"oidc,omitempty"`
+
+	// Sessions overrides the realm token and session lifespans for the client and configures the refresh tokens.
+	// Typed fields take precedence over the attributes.
+	// +nullable
+	// +optional
+	Sessions *ClientSessionsConfig `json:"sessions,omitempty"`
+
+	// ClientAuthenticatorType is the authentication type of the confidential client.
+	// client-jwt is the signed JWT (private_key_jwt) authentication, its keys are configured by signedJwt.
+	// +kubebuilder:validation:Enum=client-secret;client-jwt;client-secret-jwt;client-x509
+	// +optional
+	ClientAuthenticatorType string `json:"clientAuthenticatorType,omitempty"`
+
+	// SignedJWT is a configuration of the keys which are used to verify JWT signed by the client,
+	// clientAuthenticatorType must be set to client-jwt.
+	// +nullable
+	// +optional
+	SignedJWT *SignedJWTConfig `json:"signedJwt,omitempty"`
+
 	// +nullable
 	// +optional
 	Attributes map[string]string `json:"attributes,omitempty"`
 
+	// LoginTheme overrides the realm login theme for the client, it must be available in keycloak.
+	// It takes precedence over the login_theme attribute. The theme is not managed if the field is not set,
+	// the empty value removes the override.
 	// +optional
-	DirectAccess bool `json:"directAccess,omitempty"`
+	LoginTheme *string `json:"loginTheme,omitempty"`
 
+	// DirectAccess enables the direct access grants, the setting is left unchanged if it is not set.
+	// +optional
+	DirectAccess *bool `json:"directAccess,omitempty"`
+
+	// AdvancedProtocolMappers adds the username and realm roles mappers to the client,
+	// the client mappers are left unchanged if it is not set.
 	// +optional
 	AdvancedProtocolMappers bool `json:"advancedProtocolMappers,omitempty"`
 
@@ -48,6 +190,9 @@ type KeycloakClientSpec struct {
 	// +optional
 	ClientRoles []string `json:"clientRoles,omitempty"`
 
+	// ProtocolMappers is a list of protocol mappers of the client, mappers without protocol get the client protocol.
+	// Mappers are matched by name: existing mappers are updated, missing are created and mappers
+	// that are not declared are removed unless the addOnly reconciliation strategy is used.
 	// +nullable
 	// +optional
 	ProtocolMappers *[]ProtocolMapper `json:"protocolMappers,omitempty"`
@@ -56,36 +201,438 @@ type KeycloakClientSpec struct {
 	// +optional
 	ServiceAccount *ServiceAccount `json:"serviceAccount,omitempty"`
 
+	// FrontChannelLogout enables the front channel logout, the setting is left unchanged if it is not set.
 	// +optional
-	FrontChannelLogout bool `json:"frontChannelLogout,omitempty"`
+	FrontChannelLogout *bool `json:"frontChannelLogout,omitempty"`
 
 	// +kubebuilder:validation:Enum=full;addOnly
 	// +optional
 	ReconciliationStrategy string `json:"reconciliationStrategy,omitempty"`
 
 	// A list of default client scopes for a keycloak client.
+	// The default scopes attached by the operator and removed from the list are detached from the client
+	// unless the addOnly reconciliation strategy is used, the other scopes of the client are not touched.
 	// +nullable
 	// +optional
 	DefaultClientScopes []string `json:"defaultClientScopes,omitempty"`
+
+	// FullScopeAllowed defines whether all roles of the user are included into the client tokens.
+	// Use it with scopeMappings to limit the roles in the token scope.
+	// +optional
+	FullScopeAllowed *bool `json:"fullScopeAllowed,omitempty"`
+
+	// ScopeMappings is a list of realm and client roles which are included into the client tokens scope.
+	// Mappings that are not declared are removed from the client unless the addOnly reconciliation strategy is used.
+	// +nullable
+	// +optional
+	ScopeMappings *ScopeMappings `json:"scopeMappings,omitempty"`
+
+	// A list of optional client scopes for a keycloak client.
+	// The optional scopes attached by the operator and removed from the list are detached from the client
+	// unless the addOnly reconciliation strategy is used, the other scopes of the client are not touched.
+	// +nullable
+	// +optional
+	OptionalClientScopes []string `json:"optionalClientScopes,omitempty"`
+
+	// Authorization is a configuration of the authorization services of the confidential client.
+	// Scopes, resources, policies and permissions that are not declared in the spec are removed from the client
+	// unless the addOnly reconciliation strategy is used.
+	// +nullable
+	// +optional
+	Authorization *ClientAuthorization `json:"authorization,omitempty"`
+
+	// AuthorizationSettingsRef is a reference to the ConfigMap key with the authorization settings JSON
+	// exported from keycloak. The settings are imported when the config map data changes.
+	// It can not be used together with authorization.
+	// +nullable
+	// +optional
+	AuthorizationSettingsRef *ConfigMapKeyRef `json:"authorizationSettingsRef,omitempty"`
+}
+
+type ConfigMapKeyRef struct {
+	// Name is the name of the config map.
+	Name string `json:"name"`
+
+	// Key is the key of the config map.
+	Key string `json:"key"`
+}
+
+type ClientAuthorization struct {
+	// PolicyEnforcementMode defines how policies are enforced when processing authorization requests.
+	// +kubebuilder:validation:Enum=ENFORCING;PERMISSIVE;DISABLED
+	// +kubebuilder:default=ENFORCING
+	// +optional
+	PolicyEnforcementMode string `json:"policyEnforcementMode,omitempty"`
+
+	// DecisionStrategy defines how permissions are evaluated to obtain the final decision.
+	// +kubebuilder:validation:Enum=UNANIMOUS;AFFIRMATIVE;CONSENSUS
+	// +kubebuilder:default=UNANIMOUS
+	// +optional
+	DecisionStrategy string `json:"decisionStrategy,omitempty"`
+
+	// AllowRemoteResourceManagement defines whether resources can be managed remotely by the resource server.
+	// +optional
+	AllowRemoteResourceManagement bool `json:"allowRemoteResourceManagement,omitempty"`
+
+	// Scopes is a list of authorization scope names.
+	// +nullable
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// +nullable
+	// +optional
+	Resources []AuthorizationResource `json:"resources,omitempty"`
+
+	// +nullable
+	// +optional
+	Policies []AuthorizationPolicy `json:"policies,omitempty"`
+
+	// +nullable
+	// +optional
+	Permissions []AuthorizationPermission `json:"permissions,omitempty"`
+}
+
+type AuthorizationResource struct {
+	// Name is a unique name of the resource.
+	Name string `json:"name"`
+
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Type is a type of the resource, it can be used to group resources in resource permissions.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// URIs is a list of URIs protected by the resource.
+	// +nullable
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+
+	// Scopes is a list of the authorization scope names associated with the resource.
+	// +nullable
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// +optional
+	OwnerManagedAccess bool `json:"ownerManagedAccess,omitempty"`
+
+	// +nullable
+	// +optional
+	Attributes map[string][]string `json:"attributes,omitempty"`
+}
+
+type AuthorizationPolicy struct {
+	// Name is a unique name of the policy.
+	Name string `json:"name"`
+
+	// Type is a type of the policy.
+	// +kubebuilder:validation:Enum=role;group;user;client;aggregate
+	Type string `json:"type"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Logic defines whether the policy decision is inverted.
+	// +kubebuilder:validation:Enum=POSITIVE;NEGATIVE
+	// +kubebuilder:default=POSITIVE
+	// +optional
+	Logic string `json:"logic,omitempty"`
+
+	// DecisionStrategy defines how aggregated policies are evaluated.
+	// +kubebuilder:validation:Enum=UNANIMOUS;AFFIRMATIVE;CONSENSUS
+	// +optional
+	DecisionStrategy string `json:"decisionStrategy,omitempty"`
+
+	// Roles is a list of roles of the role policy, client roles are set in the clientId/roleName format.
+	// +nullable
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// Groups is a list of group paths of the group policy.
+	// +nullable
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Users is a list of usernames of the user policy.
+	// +nullable
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Clients is a list of client ids of the client policy.
+	// +nullable
+	// +optional
+	Clients []string `json:"clients,omitempty"`
+
+	// Policies is a list of policy names of the aggregate policy.
+	// +nullable
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+}
+
+type AuthorizationPermission struct {
+	// Name is a unique name of the permission.
+	Name string `json:"name"`
+
+	// Type is a type of the permission.
+	// +kubebuilder:validation:Enum=resource;scope
+	Type string `json:"type"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// DecisionStrategy defines how policies of the permission are evaluated.
+	// +kubebuilder:validation:Enum=UNANIMOUS;AFFIRMATIVE;CONSENSUS
+	// +kubebuilder:default=UNANIMOUS
+	// +optional
+	DecisionStrategy string `json:"decisionStrategy,omitempty"`
+
+	// Resources is a list of resource names protected by the permission.
+	// +nullable
+	// +optional
+	Resources []string `json:"resources,omitempty"`
+
+	// ResourceType is a type of resources protected by the resource permission.
+	// +optional
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// Scopes is a list of authorization scope names protected by the scope permission.
+	// +nullable
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Policies is a list of policy names applied to the permission.
+	// +nullable
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+}
+
+type SecretRotationPolicy struct {
+	// Interval is the period of the client secret rotation, e.g. 720h.
+	Interval metav1.Duration `json:"interval"`
+
+	// OverlapWindow is the period after the rotation during which the previous secret is still available.
+	// The previous secret is kept in the previousClientSecret key of the client secret and it is passed to keycloak
+	// as the rotated secret, so keycloak versions with client secret rotation support keep accepting it.
+	// +optional
+	OverlapWindow metav1.Duration `json:"overlapWindow,omitempty"`
+}
+
+type ClientOutputSecret struct {
+	// Name is the name of the secret, it must differ from the client secret name.
+	Name string `json:"name"`
+
+	// Data is a map of the secret keys to Go templates of the values.
+	// Templates can use {{ .ClientID }}, {{ .ClientSecret }}, {{ .PreviousClientSecret }}, {{ .Realm }}
+	// and {{ .IssuerURL }} fields.
+	// Keys clientId and clientSecret are written if the data is not set.
+	// +nullable
+	// +optional
+	Data map[string]string `json:"data,omitempty"`
+}
+
+const (
+	RedirectURISourceIngress = "Ingress"
+	RedirectURISourceRoute   = "Route"
+)
+
+// RedirectURISource selects Ingress or Route objects by name or by labels.
+type RedirectURISource struct {
+	// Kind is the kind of the objects.
+	// +kubebuilder:validation:Enum=Ingress;Route
+	// +kubebuilder:default=Ingress
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the object, it can not be used together with selector.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Selector is a label selector of the objects, it can not be used together with name.
+	// +nullable
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Path is appended to the object hosts to make the redirect URIs.
+	// +kubebuilder:default="/*"
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+type OIDCClientConfig struct {
+	// PKCECodeChallengeMethod is the PKCE code challenge method the client must use, PKCE is not enforced if it is not set.
+	// +kubebuilder:validation:Enum=plain;S256
+	// +optional
+	PKCECodeChallengeMethod string `json:"pkceCodeChallengeMethod,omitempty"`
+
+	// DeviceAuthorizationGrantEnabled enables OAuth 2.0 Device Authorization Grant, requires keycloak 13 or newer.
+	// +optional
+	DeviceAuthorizationGrantEnabled *bool `json:"deviceAuthorizationGrantEnabled,omitempty"`
+
+	// CIBAGrantEnabled enables OpenID Connect Client Initiated Backchannel Authentication Grant,
+	// requires keycloak 13 or newer.
+	// +optional
+	CIBAGrantEnabled *bool `json:"cibaGrantEnabled,omitempty"`
+
+	// DPoPBoundAccessTokens enables DPoP bound access tokens, requires keycloak 23 or newer.
+	// +optional
+	DPoPBoundAccessTokens *bool `json:"dpopBoundAccessTokens,omitempty"`
+}
+
+// ClientSessionsConfig defines the client level overrides of the realm lifespans.
+// A zero duration clears the override, so the realm setting is used.
+type ClientSessionsConfig struct {
+	// AccessTokenLifespan is the max time before an access token issued for the client is expired.
+	// +optional
+	AccessTokenLifespan *metav1.Duration `json:"accessTokenLifespan,omitempty"`
+
+	// ClientSessionIdleTimeout is the time a client session is allowed to be idle before it expires.
+	// +optional
+	ClientSessionIdleTimeout *metav1.Duration `json:"clientSessionIdleTimeout,omitempty"`
+
+	// ClientSessionMaxLifespan is the max time before a client session is expired.
+	// +optional
+	ClientSessionMaxLifespan *metav1.Duration `json:"clientSessionMaxLifespan,omitempty"`
+
+	// ClientOfflineSessionIdleTimeout is the time a client offline session is allowed to be idle before it expires.
+	// +optional
+	ClientOfflineSessionIdleTimeout *metav1.Duration `json:"clientOfflineSessionIdleTimeout,omitempty"`
+
+	// ClientOfflineSessionMaxLifespan is the max time before a client offline session is expired,
+	// it is used only if the offline session max lifespan is enabled in the realm.
+	// +optional
+	ClientOfflineSessionMaxLifespan *metav1.Duration `json:"clientOfflineSessionMaxLifespan,omitempty"`
+
+	// UseRefreshTokens defines whether refresh tokens are issued. Offline tokens also require the offline_access
+	// client scope, it can be added to optionalClientScopes.
+	// +optional
+	UseRefreshTokens *bool `json:"useRefreshTokens,omitempty"`
+
+	// UseRefreshTokenForClientCredentials defines whether a refresh token is issued for the client credentials grant.
+	// +optional
+	UseRefreshTokenForClientCredentials *bool `json:"useRefreshTokenForClientCredentials,omitempty"`
+}
+
+// SignedJWTConfig defines the keys of the client, only one of jwksUrl, jwksRef and certificateRef can be set.
+type SignedJWTConfig struct {
+	// JWKSURL is the URL of the client JWKS endpoint.
+	// +optional
+	JWKSURL string `json:"jwksUrl,omitempty"`
+
+	// JWKSRef is a reference to the secret key with the client JWKS JSON.
+	// +nullable
+	// +optional
+	JWKSRef *SecretKeyRef `json:"jwksRef,omitempty"`
+
+	// CertificateRef is a reference to the secret key with the PEM encoded client certificate.
+	// +nullable
+	// +optional
+	CertificateRef *SecretKeyRef `json:"certificateRef,omitempty"`
+
+	// SigningAlgorithm is the algorithm the client must use to sign the JWT, any algorithm is allowed if it is not set.
+	// +kubebuilder:validation:Enum=RS256;RS384;RS512;PS256;PS384;PS512;ES256;ES384;ES512
+	// +optional
+	SigningAlgorithm string `json:"signingAlgorithm,omitempty"`
+}
+
+type SAMLClientConfig struct {
+	// AssertionConsumerServiceURLPost is the SAML POST binding URL of the assertion consumer service.
+	// +optional
+	AssertionConsumerServiceURLPost string `json:"assertionConsumerServiceUrlPost,omitempty"`
+
+	// AssertionConsumerServiceURLRedirect is the SAML redirect binding URL of the assertion consumer service.
+	// +optional
+	AssertionConsumerServiceURLRedirect string `json:"assertionConsumerServiceUrlRedirect,omitempty"`
+
+	// SingleLogoutServiceURLPost is the SAML POST binding URL of the single logout service.
+	// +optional
+	SingleLogoutServiceURLPost string `json:"singleLogoutServiceUrlPost,omitempty"`
+
+	// SingleLogoutServiceURLRedirect is the SAML redirect binding URL of the single logout service.
+	// +optional
+	SingleLogoutServiceURLRedirect string `json:"singleLogoutServiceUrlRedirect,omitempty"`
+
+	// NameIDFormat is the name ID format of the subject.
+	// +kubebuilder:validation:Enum=username;email;transient;persistent
+	// +optional
+	NameIDFormat string `json:"nameIdFormat,omitempty"`
+
+	// ForceNameIDFormat defines whether the requested name ID format is ignored and nameIdFormat is used.
+	// +optional
+	ForceNameIDFormat *bool `json:"forceNameIdFormat,omitempty"`
+
+	// ForcePostBinding defines whether POST binding is always used for responses.
+	// +optional
+	ForcePostBinding *bool `json:"forcePostBinding,omitempty"`
+
+	// SignDocuments defines whether SAML documents are signed by the realm.
+	// +optional
+	SignDocuments *bool `json:"signDocuments,omitempty"`
+
+	// SignAssertions defines whether SAML assertions are signed by the realm.
+	// +optional
+	SignAssertions *bool `json:"signAssertions,omitempty"`
+
+	// SignatureAlgorithm is the algorithm of the realm signatures.
+	// +kubebuilder:validation:Enum=RSA_SHA1;RSA_SHA256;RSA_SHA256_MGF1;RSA_SHA512;RSA_SHA512_MGF1;DSA_SHA1
+	// +optional
+	SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+
+	// ClientSignatureRequired defines whether requests of the client must be signed.
+	// +optional
+	ClientSignatureRequired *bool `json:"clientSignatureRequired,omitempty"`
+
+	// EncryptAssertions defines whether SAML assertions are encrypted with the client encryption certificate.
+	// +optional
+	EncryptAssertions *bool `json:"encryptAssertions,omitempty"`
+
+	// SigningCertificateRef is a reference to the secret key with the PEM encoded client signing certificate.
+	// +nullable
+	// +optional
+	SigningCertificateRef *SecretKeyRef `json:"signingCertificateRef,omitempty"`
+
+	// EncryptionCertificateRef is a reference to the secret key with the PEM encoded client encryption certificate.
+	// +nullable
+	// +optional
+	EncryptionCertificateRef *SecretKeyRef `json:"encryptionCertificateRef,omitempty"`
 }
 
 type ServiceAccount struct {
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
 
+	// RealmRoles is a list of realm roles assigned to the service account.
+	// Roles which are not declared are removed unless the addOnly reconciliation strategy is used,
+	// the realm default role default-roles-<realm> is kept.
 	// +nullable
 	// +optional
 	RealmRoles []string `json:"realmRoles"`
 
+	// ClientRoles is a list of roles of other clients assigned to the service account,
+	// e.g. roles of the realm-management client to manage the realm with the service account.
+	// Roles which are not declared are removed unless the addOnly reconciliation strategy is used.
+	// The client roles are not managed if the list is not set, an empty list removes all of them.
 	// +nullable
 	// +optional
-	ClientRoles []ClientRole `json:"clientRoles,omitempty"`
+	ClientRoles []ClientRole `json:"clientRoles"`
 
 	// +nullable
 	// +optional
 	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
+type ScopeMappings struct {
+	// RealmRoles is a list of realm role names.
+	// +nullable
+	// +optional
+	RealmRoles []string `json:"realmRoles,omitempty"`
+
+	// ClientRoles is a list of roles of other clients.
+	// The client roles are not managed if the list is not set, an empty list removes all of them.
+	// +nullable
+	// +optional
+	ClientRoles []ClientRole `json:"clientRoles"`
+}
+
 type ClientRole struct {
 	ClientID string `json:"clientId"`
 
@@ -129,6 +676,38 @@ type KeycloakClientStatus struct {
 
 	// +optional
 	ClientSecretName string `json:"clientSecretName,omitempty"`
+
+	// SecretRotationTime is the time of the last client secret rotation.
+	// +nullable
+	// +optional
+	SecretRotationTime *metav1.Time `json:"secretRotationTime,omitempty"`
+
+	// AppliedDefaultClientScopes are the default client scopes attached by the operator.
+	// Only they are detached from the client when they are removed from the spec.
+	// +nullable
+	// +optional
+	AppliedDefaultClientScopes []string `json:"appliedDefaultClientScopes,omitempty"`
+
+	// AppliedOptionalClientScopes are the optional client scopes attached by the operator.
+	// Only they are detached from the client when they are removed from the spec.
+	// +nullable
+	// +optional
+	AppliedOptionalClientScopes []string `json:"appliedOptionalClientScopes,omitempty"`
+
+	// AppliedGeneration is the generation of the spec applied by the last successful reconciliation.
+	// The client is checked for the drift only while its spec is applied.
+	// +optional
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+
+	// AuthorizationSettingsHash is a hash of the last imported authorization settings.
+	// The settings are not imported again until the config map data changes.
+	// +optional
+	AuthorizationSettingsHash string `json:"authorizationSettingsHash,omitempty"`
+
+	// Conditions report why the last reconciliation failed and whether the client in Keycloak still matches its spec.
+	// +nullable
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -152,6 +731,34 @@ func (in *KeycloakClient) SetFailureCount(count int64) {
 	in.Status.FailureCount = count
 }
 
+func (in *KeycloakClient) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakClient) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakClient) GetAppliedGeneration() int64 {
+	return in.Status.AppliedGeneration
+}
+
+func (in *KeycloakClient) SetAppliedGeneration(generation int64) {
+	in.Status.AppliedGeneration = generation
+}
+
+func (in *KeycloakClient) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakClient) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakClient) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
 func (in *KeycloakClient) GetStatus() string {
 	return in.Status.Value
 }
@@ -160,6 +767,21 @@ func (in *KeycloakClient) SetStatus(value string) {
 	in.Status.Value = value
 }
 
+// IsPublic returns true if the client is declared as public.
+func (in *KeycloakClient) IsPublic() bool {
+	return in.Spec.Public != nil && *in.Spec.Public
+}
+
+// IsSignedJWT returns true if the client is authenticated with JWT signed by the client private key.
+func (in *KeycloakClient) IsSignedJWT() bool {
+	return in.Spec.ClientAuthenticatorType == ClientAuthenticatorSignedJWT
+}
+
+// IsSAML checks if the client uses the SAML protocol.
+func (in *KeycloakClient) IsSAML() bool {
+	return in.Spec.Protocol != nil && *in.Spec.Protocol == "saml"
+}
+
 func (in *KeycloakClient) GetReconciliationStrategy() string {
 	if in.Spec.ReconciliationStrategy == "" {
 		return ReconciliationStrategyFull
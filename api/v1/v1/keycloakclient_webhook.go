@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupWebhookWithManager registers the validating webhook of KeycloakClient.
+func (in *KeycloakClient) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(in).
+		WithValidator(&keycloakClientValidator{reader: mgr.GetAPIReader()}).Complete(); err != nil {
+		return fmt.Errorf("failed to setup KeycloakClient webhook: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-v1-edp-epam-com-v1-keycloakclient,mutating=false,failurePolicy=fail,sideEffects=None,groups=v1.edp.epam.com,resources=keycloakclients,verbs=create;update,versions=v1,name=vkeycloakclient.kb.io,admissionReviewVersions=v1
+
+// keycloakClientValidator rejects KeycloakClient whose spec.secret does not name an existing Secret,
+// such a value is most likely the plaintext client secret.
+// +kubebuilder:object:generate=false
+type keycloakClientValidator struct {
+	reader client.Reader
+}
+
+var _ admission.CustomValidator = &keycloakClientValidator{}
+
+// ValidateCreate rejects KeycloakClient with the client secret value instead of the secret name.
+func (v *keycloakClientValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	kc, ok := obj.(*KeycloakClient)
+	if !ok {
+		return fmt.Errorf("expected KeycloakClient, got %T", obj)
+	}
+
+	return v.validateSecretName(ctx, kc, "")
+}
+
+// ValidateUpdate rejects KeycloakClient with the client secret value instead of the secret name.
+// The unchanged secret is accepted, so the operator can still update the resource, e.g. to remove the finalizer.
+func (v *keycloakClientValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	kc, ok := newObj.(*KeycloakClient)
+	if !ok {
+		return fmt.Errorf("expected KeycloakClient, got %T", newObj)
+	}
+
+	oldSecret := ""
+	if o, ok := oldObj.(*KeycloakClient); ok {
+		oldSecret = o.Spec.Secret
+	}
+
+	return v.validateSecretName(ctx, kc, oldSecret)
+}
+
+// ValidateDelete does nothing, deletion is always allowed.
+func (v *keycloakClientValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// validateSecretName checks that spec.secret is a name of the existing Secret in the namespace of KeycloakClient.
+// The value is not included in the error to avoid leaking the client secret, and the value which is not a valid
+// object name is not looked up, so it does not get to the audit log of the API server.
+func (v *keycloakClientValidator) validateSecretName(ctx context.Context, kc *KeycloakClient, oldSecret string) error {
+	secret := kc.Spec.Secret
+	if secret == "" || secret == oldSecret {
+		return nil
+	}
+
+	if len(validation.IsDNS1123Subdomain(secret)) == 0 {
+		err := v.reader.Get(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: secret}, &corev1.Secret{})
+		if err == nil {
+			return nil
+		}
+
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to check the client secret of KeycloakClient: %w", err)
+		}
+	}
+
+	return apierrors.NewInvalid(schema.GroupKind{Group: SchemeGroupVersion.Group, Kind: "KeycloakClient"}, kc.Name,
+		field.ErrorList{field.Forbidden(field.NewPath("spec", "secret"),
+			"must be a name of the existing Secret with the clientSecret key, plaintext client secrets are not allowed")})
+}
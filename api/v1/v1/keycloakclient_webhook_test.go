@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKeycloakClientValidator_ValidateCreate(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
+
+	reader := fake.NewClientBuilder().WithScheme(s).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "keycloak-client-secret"},
+		Data:       map[string][]byte{"clientSecret": []byte("secret")},
+	}).Build()
+
+	tests := []struct {
+		name      string
+		namespace string
+		secret    string
+		wantErr   bool
+	}{
+		{name: "no secret", namespace: "ns", secret: ""},
+		{name: "existing secret", namespace: "ns", secret: "keycloak-client-secret"},
+		{name: "plaintext secret which is a valid name", namespace: "ns", secret: "mysecret123", wantErr: true},
+		{name: "plaintext secret", namespace: "ns", secret: "Sup3r$ecret", wantErr: true},
+		{name: "secret from another namespace", namespace: "other", secret: "keycloak-client-secret", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			v := &keycloakClientValidator{reader: reader}
+			kc := &KeycloakClient{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace, Name: "client"},
+				Spec:       KeycloakClientSpec{Secret: tt.secret},
+			}
+
+			err := v.ValidateCreate(context.Background(), kc)
+			if !tt.wantErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err))
+			assert.Contains(t, err.Error(), "spec.secret")
+			assert.NotContains(t, err.Error(), tt.secret)
+		})
+	}
+}
+
+func TestKeycloakClientValidator_ValidateUpdate(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
+
+	v := &keycloakClientValidator{reader: fake.NewClientBuilder().WithScheme(s).Build()}
+
+	old := &KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "client"},
+		Spec:       KeycloakClientSpec{Secret: "mysecret123"},
+	}
+	kc := old.DeepCopy()
+	kc.Finalizers = []string{"finalizer"}
+
+	assert.NoError(t, v.ValidateUpdate(context.Background(), old, kc), "unchanged secret should be accepted")
+
+	kc.Spec.Secret = "mysecret456"
+	err := v.ValidateUpdate(context.Background(), old, kc)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+
+	assert.NoError(t, v.ValidateDelete(context.Background(), kc))
+}
+
+type failingReader struct {
+	client.Reader
+}
+
+func (failingReader) Get(context.Context, client.ObjectKey, client.Object) error {
+	return errors.New("connection refused")
+}
+
+func (failingReader) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return errors.New("connection refused")
+}
+
+func TestKeycloakClientValidator_ReaderFailure(t *testing.T) {
+	v := &keycloakClientValidator{reader: failingReader{}}
+	kc := &KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "client"},
+		Spec:       KeycloakClientSpec{Secret: "keycloak-client-secret"},
+	}
+
+	err := v.ValidateCreate(context.Background(), kc)
+	require.Error(t, err)
+	assert.False(t, apierrors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "connection refused")
+}
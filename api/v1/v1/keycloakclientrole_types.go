@@ -0,0 +1,160 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// KeycloakClientRoleSpec defines the desired state of KeycloakClientRole.
+type KeycloakClientRoleSpec struct {
+	// Name of the client role.
+	Name string `json:"name"`
+
+	// Realm is name of KeycloakRealm custom resource.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// ClientID is a clientId of the keycloak client which owns the role.
+	ClientID string `json:"clientId"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Attributes is a map of the role attributes.
+	// Attributes which are dropped from the spec are removed from the role unless the addOnly strategy is used.
+	// +nullable
+	// +optional
+	Attributes map[string][]string `json:"attributes,omitempty"`
+
+	// +optional
+	Composite bool `json:"composite,omitempty"`
+
+	// Composites is a list of realm roles which are members of the composite role.
+	// +nullable
+	// +optional
+	Composites []Composite `json:"composites,omitempty"`
+
+	// CompositesClientRoles is a map of client roles which are members of the composite role, keyed by clientId.
+	// +nullable
+	// +optional
+	CompositesClientRoles map[string][]Composite `json:"compositesClientRoles,omitempty"`
+
+	// ReconciliationStrategy is a strategy of composites and attributes reconciliation.
+	// With the full strategy the member roles and the attributes which are not declared in the spec are removed
+	// from the role, with the addOnly strategy they are kept.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +optional
+	ReconciliationStrategy string `json:"reconciliationStrategy,omitempty"`
+
+	// DeletionProtection blocks deletion of the role while it is assigned to users, groups or realm composite roles.
+	// The role can be deleted anyway if the edp.epam.com/force-delete annotation is set to true.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+}
+
+// KeycloakClientRoleStatus defines the observed state of KeycloakClientRole.
+type KeycloakClientRoleStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// UnresolvedComposites is a list of the declared member roles which do not exist in Keycloak.
+	// Client roles are listed in the clientId/role format.
+	// +optional
+	UnresolvedComposites []string `json:"unresolvedComposites,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// KeycloakClientRole is the Schema for the keycloakclientroles API.
+type KeycloakClientRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakClientRoleSpec   `json:"spec,omitempty"`
+	Status KeycloakClientRoleStatus `json:"status,omitempty"`
+}
+
+// IsDeletionProtected checks if the usage of the role must be checked before deletion.
+func (in *KeycloakClientRole) IsDeletionProtected() bool {
+	return in.Spec.DeletionProtection && in.GetAnnotations()[ForceDeleteAnnotation] != "true"
+}
+
+func (in *KeycloakClientRole) GetReconciliationStrategy() string {
+	if in.Spec.ReconciliationStrategy == "" {
+		return ReconciliationStrategyFull
+	}
+
+	return in.Spec.ReconciliationStrategy
+}
+
+func (in *KeycloakClientRole) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakClientRole) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakClientRole) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakClientRole) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
+func (in *KeycloakClientRole) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakClientRole) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakClientRole) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakClientRole) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakClientRoleList contains a list of KeycloakClientRole.
+type KeycloakClientRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakClientRole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakClientRole{}, &KeycloakClientRoleList{})
+}
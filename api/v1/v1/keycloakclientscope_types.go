@@ -8,7 +8,25 @@ type KeycloakClientScopeSpec struct {
 	Name string `json:"name"`
 
 	// Realm is name of keycloak realm
-	Realm string `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
 
 	// Protocol is SSO protocol configuration which is being supplied by this client scope
 	Protocol string `json:"protocol"`
@@ -54,9 +72,25 @@ type KeycloakClientScope struct {
 }
 
 func (in *KeycloakClientScope) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakClientScope) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakClientScope) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakClientScope) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
 func (in *KeycloakClientScope) GetFailureCount() int64 {
 	return in.Status.FailureCount
 }
@@ -4,8 +4,27 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 // KeycloakComponentSpec defines the desired state of KeycloakRealmComponent.
 type KeycloakComponentSpec struct {
-	Name         string `json:"name"`
-	Realm        string `json:"realm"`
+	Name string `json:"name"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
 	ProviderID   string `json:"providerId"`
 	ProviderType string `json:"providerType"`
 
@@ -21,6 +40,45 @@ type KeycloakComponentStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// LastSync is the result of the last user storage synchronization.
+	// +nullable
+	// +optional
+	LastSync *UserStorageSyncStatus `json:"lastSync,omitempty"`
+}
+
+// UserStorageSyncAnnotation requests synchronization of users from the user storage provider.
+// Allowed values are "full" and "changed", the annotation is removed by the operator after the sync is triggered.
+const UserStorageSyncAnnotation = "edp.epam.com/user-storage-sync"
+
+const (
+	UserStorageSyncFull    = "full"
+	UserStorageSyncChanged = "changed"
+)
+
+// UserStorageSyncStatus is the result of the user storage synchronization.
+type UserStorageSyncStatus struct {
+	// Mode is the synchronization mode: full or changed.
+	Mode string `json:"mode"`
+
+	// Time is the time when the synchronization was triggered.
+	Time metav1.Time `json:"time"`
+
+	// +optional
+	Added int `json:"added,omitempty"`
+
+	// +optional
+	Updated int `json:"updated,omitempty"`
+
+	// +optional
+	Removed int `json:"removed,omitempty"`
+
+	// +optional
+	Failed int `json:"failed,omitempty"`
+
+	// Status is the synchronization summary returned by keycloak.
+	// +optional
+	Status string `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -53,9 +111,25 @@ func (in *KeycloakRealmComponent) SetStatus(value string) {
 }
 
 func (in *KeycloakRealmComponent) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmComponent) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakRealmComponent) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakRealmComponent) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakRealmComponentList contains a list of KeycloakRealmComponent.
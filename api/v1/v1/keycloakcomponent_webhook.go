@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the validating webhook of KeycloakRealmComponent.
+func (in *KeycloakRealmComponent) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(in).Complete(); err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmComponent webhook: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-v1-edp-epam-com-v1-keycloakrealmcomponent,mutating=false,failurePolicy=fail,sideEffects=None,groups=v1.edp.epam.com,resources=keycloakrealmcomponents,verbs=create;update,versions=v1,name=vkeycloakrealmcomponent.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeycloakRealmComponent{}
+
+// ValidateCreate rejects KeycloakRealmComponent with plaintext credentials.
+func (in *KeycloakRealmComponent) ValidateCreate() error {
+	return validateCredentials("KeycloakRealmComponent", in.Name, in.credentialFields(), nil)
+}
+
+// ValidateUpdate rejects KeycloakRealmComponent with new plaintext credentials.
+func (in *KeycloakRealmComponent) ValidateUpdate(old runtime.Object) error {
+	var oldFields []credentialField
+	if o, ok := old.(*KeycloakRealmComponent); ok {
+		oldFields = o.credentialFields()
+	}
+
+	return validateCredentials("KeycloakRealmComponent", in.Name, in.credentialFields(), oldFields)
+}
+
+// ValidateDelete does nothing, deletion is always allowed.
+func (in *KeycloakRealmComponent) ValidateDelete() error {
+	return nil
+}
+
+func (in *KeycloakRealmComponent) credentialFields() []credentialField {
+	return configCredentialFields(field.NewPath("spec", "config"), in.Spec.Config,
+		"use a keycloak vault reference ${vault.<key>} instead", ldapBindCredentialKey, identityProviderClientSecretKey)
+}
@@ -0,0 +1,242 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	ConfigCliManagedFull     = "full"
+	ConfigCliManagedNoDelete = "no-delete"
+)
+
+// KeycloakConfigCliImportSpec defines the desired state of KeycloakConfigCliImport.
+// The files in the keycloak-config-cli format are applied with the keycloak partial import,
+// so only the users, clients, groups, roles and identity providers of the files are imported.
+// The realm settings of the files are not applied, they should be moved to the KeycloakRealm.
+type KeycloakConfigCliImportSpec struct {
+	// Realm is name of KeycloakRealm custom resource.
+	// The realm field of the files must be empty or match the realm name.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// Files is a list of the keycloak-config-cli JSON or YAML files which are imported in the declared order.
+	// +kubebuilder:validation:MinItems=1
+	Files []ConfigCliFile `json:"files"`
+
+	// VarSubstitution defines the substitution of the $(NAME), $(env:NAME) and $(NAME:-default) variables
+	// in the files. It is the same as the import.var-substitution.enabled keycloak-config-cli property.
+	// +nullable
+	// +optional
+	VarSubstitution *ConfigCliVarSubstitution `json:"varSubstitution,omitempty"`
+
+	// Managed defines whether the resources which were imported before and are removed from the files
+	// are deleted from keycloak. It is the same as the import.managed keycloak-config-cli properties.
+	// +optional
+	Managed ConfigCliManaged `json:"managed,omitempty"`
+
+	// IfResourceExists defines how the resources which already exist in the realm are handled.
+	// FAIL aborts the import of the file, SKIP keeps the existing resources and OVERWRITE replaces them.
+	// +kubebuilder:validation:Enum=FAIL;SKIP;OVERWRITE
+	// +kubebuilder:default=OVERWRITE
+	// +optional
+	IfResourceExists string `json:"ifResourceExists,omitempty"`
+}
+
+// ConfigCliFile is a reference to the keycloak-config-cli files. Exactly one of the references must be set.
+type ConfigCliFile struct {
+	// +nullable
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeyRef `json:"configMapKeyRef,omitempty"`
+
+	// +nullable
+	// +optional
+	SecretKeyRef *SecretKeyRef `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapName is a name of the config map all keys of which are imported in the alphabetical order.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+type ConfigCliVarSubstitution struct {
+	// Enabled defines whether the variables are substituted.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Variables is a map of the variable values.
+	// +nullable
+	// +optional
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// VariablesFrom is a list of the config maps and secrets the keys of which are used as variables.
+	// The later sources and the Variables take precedence.
+	// +nullable
+	// +optional
+	VariablesFrom []ConfigCliVariablesSource `json:"variablesFrom,omitempty"`
+}
+
+// ConfigCliVariablesSource is a reference to the variables. Exactly one of the names must be set.
+type ConfigCliVariablesSource struct {
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// ConfigCliManaged defines the removal of the imported resources per resource type.
+// With the full mode the resources imported by this resource and removed from the files are deleted,
+// with the no-delete mode they are kept. The resources which were not imported by this resource
+// and the users are never deleted.
+type ConfigCliManaged struct {
+	// +kubebuilder:validation:Enum=full;no-delete
+	// +kubebuilder:default=full
+	// +optional
+	Client string `json:"client,omitempty"`
+
+	// +kubebuilder:validation:Enum=full;no-delete
+	// +kubebuilder:default=full
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Role is a mode of the realm and client roles.
+	// +kubebuilder:validation:Enum=full;no-delete
+	// +kubebuilder:default=full
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// +kubebuilder:validation:Enum=full;no-delete
+	// +kubebuilder:default=full
+	// +optional
+	IdentityProvider string `json:"identityProvider,omitempty"`
+}
+
+// KeycloakConfigCliImportStatus defines the observed state of KeycloakConfigCliImport.
+type KeycloakConfigCliImportStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// SourceHash is a hash of the last imported files and import settings.
+	// The files are not imported again until the data or the settings change.
+	// +optional
+	SourceHash string `json:"sourceHash,omitempty"`
+
+	// Added is a number of resources added by the last import.
+	// +optional
+	Added int `json:"added,omitempty"`
+
+	// Overwritten is a number of existing resources overwritten by the last import.
+	// +optional
+	Overwritten int `json:"overwritten,omitempty"`
+
+	// Skipped is a number of existing resources skipped by the last import.
+	// +optional
+	Skipped int `json:"skipped,omitempty"`
+
+	// Deleted is a number of managed resources deleted by the last import.
+	// +optional
+	Deleted int `json:"deleted,omitempty"`
+
+	// UnsupportedFields is a list of the top level fields of the files which are not imported.
+	// +nullable
+	// +optional
+	UnsupportedFields []string `json:"unsupportedFields,omitempty"`
+
+	// ManagedResources is a list of the resources added to the realm by this resource, except the users.
+	// The resources which existed before the import are not managed and are never deleted.
+	// +nullable
+	// +optional
+	ManagedResources []ConfigCliManagedResource `json:"managedResources,omitempty"`
+}
+
+type ConfigCliManagedResource struct {
+	// Type is a type of the resource in the partial import results, e.g. CLIENT or REALM_ROLE.
+	Type string `json:"type"`
+
+	// Name is a name of the resource.
+	Name string `json:"name"`
+
+	// ID is an id of the resource in keycloak.
+	// +optional
+	ID string `json:"id,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// KeycloakConfigCliImport is the Schema for the keycloakconfigcliimports API.
+type KeycloakConfigCliImport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakConfigCliImportSpec   `json:"spec,omitempty"`
+	Status KeycloakConfigCliImportStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakConfigCliImport) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakConfigCliImport) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakConfigCliImport) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakConfigCliImport) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakConfigCliImport) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakConfigCliImport) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakConfigCliImport) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakConfigCliImport) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakConfigCliImportList contains a list of KeycloakConfigCliImport.
+type KeycloakConfigCliImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakConfigCliImport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakConfigCliImport{}, &KeycloakConfigCliImportList{})
+}
@@ -0,0 +1,113 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// KeycloakIdentityProviderMapperSpec defines the desired state of KeycloakIdentityProviderMapper.
+type KeycloakIdentityProviderMapperSpec struct {
+	// Realm is the name of the KeycloakRealm CR the identity provider belongs to.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// IdentityProviderAlias is the alias of the identity provider.
+	IdentityProviderAlias string `json:"identityProviderAlias"`
+
+	// Name is the name of the mapper, it should be unique within the identity provider.
+	Name string `json:"name"`
+
+	// IdentityProviderMapper is the mapper type, e.g. hardcoded-role-idp-mapper, oidc-user-attribute-idp-mapper,
+	// saml-user-attribute-idp-mapper, oidc-username-idp-mapper.
+	IdentityProviderMapper string `json:"identityProviderMapper"`
+
+	// +nullable
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// KeycloakIdentityProviderMapperStatus defines the observed state of KeycloakIdentityProviderMapper.
+type KeycloakIdentityProviderMapperStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// KeycloakIdentityProviderMapper is the Schema for the keycloak identity provider mapper API.
+type KeycloakIdentityProviderMapper struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakIdentityProviderMapperSpec   `json:"spec,omitempty"`
+	Status KeycloakIdentityProviderMapperStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakIdentityProviderMapper) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakIdentityProviderMapper) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakIdentityProviderMapper) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakIdentityProviderMapper) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakIdentityProviderMapper) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakIdentityProviderMapper) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakIdentityProviderMapper) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakIdentityProviderMapper) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakIdentityProviderMapperList contains a list of KeycloakIdentityProviderMapper.
+type KeycloakIdentityProviderMapperList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakIdentityProviderMapper `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakIdentityProviderMapper{}, &KeycloakIdentityProviderMapperList{})
+}
@@ -0,0 +1,237 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// KeycloakLDAPFederationSpec defines the desired state of KeycloakLDAPFederation.
+type KeycloakLDAPFederationSpec struct {
+	// Name is a display name of the LDAP user storage provider in keycloak.
+	Name string `json:"name"`
+
+	// Realm is the name of the KeycloakRealm CR the provider belongs to.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// ConnectionURL is the LDAP server connection URL, e.g. ldaps://ldap.example.com:636.
+	ConnectionURL string `json:"connectionUrl"`
+
+	// UsersDN is the full DN of the LDAP tree where users are located.
+	UsersDN string `json:"usersDn"`
+
+	// BindDN is the DN of the LDAP admin which will be used by keycloak to access LDAP server.
+	// +optional
+	BindDN string `json:"bindDn,omitempty"`
+
+	// BindCredential is a reference to the secret key with the password of the LDAP admin.
+	// +nullable
+	// +optional
+	BindCredential *SecretKeyRef `json:"bindCredential,omitempty"`
+
+	// Vendor is the LDAP vendor (provider).
+	// +kubebuilder:validation:Enum=other;ad;rhds;tivoli;edirectory
+	// +kubebuilder:default=other
+	// +optional
+	Vendor string `json:"vendor,omitempty"`
+
+	// EditMode defines how keycloak handles changes of users imported from the LDAP.
+	// +kubebuilder:validation:Enum=READ_ONLY;WRITABLE;UNSYNCED
+	// +kubebuilder:default=READ_ONLY
+	// +optional
+	EditMode string `json:"editMode,omitempty"`
+
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// UsernameLDAPAttribute is the name of the LDAP attribute which is mapped as keycloak username.
+	// +kubebuilder:default=uid
+	// +optional
+	UsernameLDAPAttribute string `json:"usernameLdapAttribute,omitempty"`
+
+	// RdnLDAPAttribute is the name of the LDAP attribute which is used as RDN (top attribute) of typical user DN.
+	// +kubebuilder:default=uid
+	// +optional
+	RdnLDAPAttribute string `json:"rdnLdapAttribute,omitempty"`
+
+	// UUIDLDAPAttribute is the name of the LDAP attribute which is used as unique object identifier (UUID) for objects in LDAP.
+	// +kubebuilder:default=entryUUID
+	// +optional
+	UUIDLDAPAttribute string `json:"uuidLdapAttribute,omitempty"`
+
+	// UserObjectClasses are all values of LDAP objectClass attribute for users in LDAP.
+	// +nullable
+	// +optional
+	UserObjectClasses []string `json:"userObjectClasses,omitempty"`
+
+	// CustomUserSearchFilter is an additional LDAP filter for filtering searched users.
+	// +optional
+	CustomUserSearchFilter string `json:"customUserSearchFilter,omitempty"`
+
+	// SearchScope is the scope of the users search: one level or subtree.
+	// +kubebuilder:validation:Enum=OneLevel;Subtree
+	// +optional
+	SearchScope string `json:"searchScope,omitempty"`
+
+	// +optional
+	StartTLS bool `json:"startTls,omitempty"`
+
+	// +optional
+	Pagination bool `json:"pagination,omitempty"`
+
+	// ConnectionTimeout is the LDAP connection timeout in milliseconds.
+	// +optional
+	ConnectionTimeout string `json:"connectionTimeout,omitempty"`
+
+	// Sync is the synchronization settings of the LDAP users.
+	// +nullable
+	// +optional
+	Sync *LDAPSyncSettings `json:"sync,omitempty"`
+
+	// SyncPeriod is the interval of the periodic users synchronization triggered by the operator, e.g. 1h or 30m.
+	// Synchronization is not scheduled if the period is not set.
+	// +nullable
+	// +optional
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+
+	// SyncPeriodMode is the mode of the periodic users synchronization: full or changed users only.
+	// +kubebuilder:validation:Enum=full;changed
+	// +kubebuilder:default=changed
+	// +optional
+	SyncPeriodMode string `json:"syncPeriodMode,omitempty"`
+
+	// Config is an additional raw config of the component, it is merged with typed fields
+	// and can be used for keys which are not covered by the spec.
+	// +nullable
+	// +optional
+	Config map[string][]string `json:"config,omitempty"`
+}
+
+type SecretKeyRef struct {
+	// Name is the name of the secret.
+	Name string `json:"name"`
+
+	// Key is the key of the secret.
+	Key string `json:"key"`
+}
+
+type LDAPSyncSettings struct {
+	// ImportEnabled defines whether LDAP users are imported into the keycloak DB.
+	// +optional
+	ImportEnabled *bool `json:"importEnabled,omitempty"`
+
+	// SyncRegistrations defines whether newly created users are created within LDAP store.
+	// +optional
+	SyncRegistrations bool `json:"syncRegistrations,omitempty"`
+
+	// BatchSize is the count of LDAP users to be imported from LDAP to keycloak within a single transaction.
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// FullSyncPeriod is the period for full synchronization in seconds, -1 disables it.
+	// +optional
+	FullSyncPeriod int `json:"fullSyncPeriod,omitempty"`
+
+	// ChangedSyncPeriod is the period for synchronization of changed or newly created LDAP users in seconds, -1 disables it.
+	// +optional
+	ChangedSyncPeriod int `json:"changedSyncPeriod,omitempty"`
+}
+
+// KeycloakLDAPFederationStatus defines the observed state of KeycloakLDAPFederation.
+type KeycloakLDAPFederationStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// LastSync is the result of the last LDAP users synchronization.
+	// +nullable
+	// +optional
+	LastSync *UserStorageSyncStatus `json:"lastSync,omitempty"`
+
+	// LastSyncTime is the time of the last LDAP users synchronization.
+	// +nullable
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// KeycloakLDAPFederation is the Schema for the keycloak LDAP user federation API.
+type KeycloakLDAPFederation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakLDAPFederationSpec   `json:"spec,omitempty"`
+	Status KeycloakLDAPFederationStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakLDAPFederation) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakLDAPFederation) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakLDAPFederation) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakLDAPFederation) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakLDAPFederation) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakLDAPFederation) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakLDAPFederation) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakLDAPFederation) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakLDAPFederationList contains a list of KeycloakLDAPFederation.
+type KeycloakLDAPFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakLDAPFederation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakLDAPFederation{}, &KeycloakLDAPFederationList{})
+}
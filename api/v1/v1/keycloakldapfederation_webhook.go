@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the validating webhook of KeycloakLDAPFederation.
+func (in *KeycloakLDAPFederation) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(in).Complete(); err != nil {
+		return fmt.Errorf("failed to setup KeycloakLDAPFederation webhook: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-v1-edp-epam-com-v1-keycloakldapfederation,mutating=false,failurePolicy=fail,sideEffects=None,groups=v1.edp.epam.com,resources=keycloakldapfederations,verbs=create;update,versions=v1,name=vkeycloakldapfederation.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeycloakLDAPFederation{}
+
+// ValidateCreate rejects KeycloakLDAPFederation with plaintext credentials.
+func (in *KeycloakLDAPFederation) ValidateCreate() error {
+	return validateCredentials("KeycloakLDAPFederation", in.Name, in.credentialFields(), nil)
+}
+
+// ValidateUpdate rejects KeycloakLDAPFederation with new plaintext credentials.
+func (in *KeycloakLDAPFederation) ValidateUpdate(old runtime.Object) error {
+	var oldFields []credentialField
+	if o, ok := old.(*KeycloakLDAPFederation); ok {
+		oldFields = o.credentialFields()
+	}
+
+	return validateCredentials("KeycloakLDAPFederation", in.Name, in.credentialFields(), oldFields)
+}
+
+// ValidateDelete does nothing, deletion is always allowed.
+func (in *KeycloakLDAPFederation) ValidateDelete() error {
+	return nil
+}
+
+func (in *KeycloakLDAPFederation) credentialFields() []credentialField {
+	return configCredentialFields(field.NewPath("spec", "config"), in.Spec.Config,
+		"use spec.bindCredential instead", ldapBindCredentialKey)
+}
@@ -0,0 +1,164 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// KeycloakOrganizationSpec defines the desired state of KeycloakOrganization.
+// Organizations require keycloak 26 or newer with the organizations enabled in the realm.
+type KeycloakOrganizationSpec struct {
+	// Realm is a name of the KeycloakRealm custom resource the organization belongs to.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// Name is a unique name of the organization in the realm.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Alias is a unique alias of the organization, the name is used if it is not set.
+	// It can not be changed after the organization is created.
+	// +optional
+	Alias string `json:"alias,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Enabled defines whether the organization is enabled.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RedirectURL is a URL the members are redirected to after they register or accept an invitation.
+	// +optional
+	RedirectURL string `json:"redirectUrl,omitempty"`
+
+	// Domains is a list of the internet domains of the organization.
+	// +kubebuilder:validation:MinItems=1
+	Domains []OrganizationDomain `json:"domains"`
+
+	// Attributes is a map of the organization attributes.
+	// +nullable
+	// +optional
+	Attributes map[string][]string `json:"attributes,omitempty"`
+
+	// IdentityProviders is a list of the realm identity providers linked to the organization.
+	// Identity providers which are not declared are unlinked.
+	// The identity providers are not managed if the list is not set, an empty list unlinks all of them.
+	// +nullable
+	// +optional
+	IdentityProviders []OrganizationIdentityProvider `json:"identityProviders"`
+
+	// Members is a list of the usernames of the organization members, the users must exist in the realm.
+	// Members which are not declared are removed from the organization.
+	// The members are not managed if the list is not set, an empty list removes all of them.
+	// +nullable
+	// +optional
+	Members []string `json:"members"`
+}
+
+type OrganizationDomain struct {
+	// Name is a name of the internet domain, e.g. example.com.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Verified defines whether the domain ownership is verified.
+	// +optional
+	Verified bool `json:"verified,omitempty"`
+}
+
+type OrganizationIdentityProvider struct {
+	// Alias is an alias of the realm identity provider.
+	// +kubebuilder:validation:MinLength=1
+	Alias string `json:"alias"`
+}
+
+// KeycloakOrganizationStatus defines the observed state of KeycloakOrganization.
+type KeycloakOrganizationStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// ID is an id of the organization in keycloak.
+	// +optional
+	ID string `json:"id,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// KeycloakOrganization is the Schema for the keycloakorganizations API.
+type KeycloakOrganization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakOrganizationSpec   `json:"spec,omitempty"`
+	Status KeycloakOrganizationStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakOrganization) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakOrganization) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakOrganization) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakOrganization) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakOrganization) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakOrganization) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakOrganization) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakOrganization) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakOrganizationList contains a list of KeycloakOrganization.
+type KeycloakOrganizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakOrganization `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakOrganization{}, &KeycloakOrganizationList{})
+}
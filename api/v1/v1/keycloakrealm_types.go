@@ -1,9 +1,34 @@
 package v1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// RealmAdminCredentials is a reference to the credentials of the admin of the realm.
+type RealmAdminCredentials struct {
+	// Secret is a name of the secret with the username and password keys in the namespace of the realm.
+	// The username is the client id and the password is the client secret for the serviceAccount admin type.
+	// +kubebuilder:validation:MinLength=1
+	Secret string `json:"secret"`
+
+	// AdminType can be user or serviceAccount. The user must have the realm-management client roles in the realm,
+	// the serviceAccount is a confidential client of the realm whose service account has these roles.
+	// +kubebuilder:validation:Enum=serviceAccount;user
+	// +kubebuilder:default=user
+	// +optional
+	AdminType string `json:"adminType,omitempty"`
+}
+
+// GetAdminType returns the admin type of the credentials, the user is used by default.
+func (in *RealmAdminCredentials) GetAdminType() string {
+	if in.AdminType == "" {
+		return KeycloakAdminTypeUser
+	}
+
+	return in.AdminType
+}
+
 // KeycloakRealmSpec defines the desired state of KeycloakRealm.
 type KeycloakRealmSpec struct {
 	RealmName string `json:"realmName"`
@@ -11,6 +36,18 @@ type KeycloakRealmSpec struct {
 	// +optional
 	KeycloakOwner string `json:"keycloakOwner,omitempty"`
 
+	// KeycloakRef is an explicit reference to the Keycloak the realm is created in.
+	// It takes precedence over the owner reference and the keycloakOwner.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// AdminCredentials are the credentials of the realm admin which are used to manage the realm and its children
+	// instead of the master realm credentials of the Keycloak. The realm must already exist in keycloak.
+	// +nullable
+	// +optional
+	AdminCredentials *RealmAdminCredentials `json:"adminCredentials,omitempty"`
+
 	// +optional
 	SsoRealmName string `json:"ssoRealmName,omitempty"`
 
@@ -38,10 +75,18 @@ type KeycloakRealmSpec struct {
 	// +optional
 	Themes *RealmThemes `json:"themes,omitempty"`
 
+	// BrowserSecurityHeaders is a map of the realm browser security headers keyed by the keycloak header name.
+	// The headers which are not declared are kept.
 	// +nullable
 	// +optional
 	BrowserSecurityHeaders *map[string]string `json:"browserSecurityHeaders,omitempty"`
 
+	// SecurityDefenses is a typed configuration of the realm security defenses.
+	// The headers are merged with browserSecurityHeaders, the same header can not be set in both with different values.
+	// +nullable
+	// +optional
+	SecurityDefenses *RealmSecurityDefenses `json:"securityDefenses,omitempty"`
+
 	// +nullable
 	// +optional
 	ID *string `json:"id,omitempty"`
@@ -53,9 +98,809 @@ type KeycloakRealmSpec struct {
 	// +optional
 	DisableCentralIDPMappers bool `json:"disableCentralIDPMappers,omitempty"`
 
+	// PasswordPolicies is a list of the realm password policies, the type is a keycloak policy id, e.g. length.
+	// The declared policies replace the realm password policy. It can not be used together with passwordPolicySettings.
 	// +nullable
 	// +optional
 	PasswordPolicies []PasswordPolicy `json:"passwordPolicy,omitempty"`
+
+	// PasswordPolicySettings is a typed realm password policy. The policies which are not set
+	// are removed from the realm, so an empty object removes all the policies.
+	// The password policy is not managed if neither passwordPolicy nor passwordPolicySettings is set.
+	// +nullable
+	// +optional
+	PasswordPolicySettings *RealmPasswordPolicy `json:"passwordPolicySettings,omitempty"`
+
+	// ClientRegistrationPolicies are policies applied to the client registration requests.
+	// +nullable
+	// +optional
+	ClientRegistrationPolicies *ClientRegistrationPolicies `json:"clientRegistrationPolicies,omitempty"`
+
+	// DefaultRoles is a set of roles which are included in the default-roles-<realm> composite role
+	// assigned to all users of the realm. Undeclared realm roles and undeclared roles of the declared clients
+	// are removed from the composite. The composite is not managed if it is not set.
+	// +nullable
+	// +optional
+	DefaultRoles *DefaultRoles `json:"defaultRoles,omitempty"`
+
+	// DefaultClientScopes are the realm client scopes which are assigned to the newly created clients.
+	// The scopes can be created by the KeycloakClientScope custom resources, the realm is requeued until they exist.
+	// +nullable
+	// +optional
+	DefaultClientScopes *RealmDefaultClientScopes `json:"defaultClientScopes,omitempty"`
+
+	// SMTP is the configuration of the email server used by the realm to send emails.
+	// The email server is not managed if it is not set.
+	// +nullable
+	// +optional
+	SMTP *RealmSMTP `json:"smtp,omitempty"`
+
+	// BruteForceProtection is the configuration of the realm brute force detection.
+	// The brute force detection is not managed if it is not set.
+	// +nullable
+	// +optional
+	BruteForceProtection *RealmBruteForceProtection `json:"bruteForceProtection,omitempty"`
+
+	// TokenSettings is the configuration of the realm token and session lifetimes.
+	// The lifetimes are not managed if it is not set.
+	// +nullable
+	// +optional
+	TokenSettings *RealmTokenSettings `json:"tokenSettings,omitempty"`
+
+	// RegistrationAllowed enables the user self-registration on the login page.
+	// The login settings which are not set are not managed.
+	// +nullable
+	// +optional
+	RegistrationAllowed *bool `json:"registrationAllowed,omitempty"`
+
+	// RegistrationEmailAsUsername uses the email as the username of the registered users.
+	// +nullable
+	// +optional
+	RegistrationEmailAsUsername *bool `json:"registrationEmailAsUsername,omitempty"`
+
+	// VerifyEmail requires the user to verify the email after the first login or the email change.
+	// +nullable
+	// +optional
+	VerifyEmail *bool `json:"verifyEmail,omitempty"`
+
+	// LoginWithEmail allows the users to log in with the email.
+	// +nullable
+	// +optional
+	LoginWithEmail *bool `json:"loginWithEmail,omitempty"`
+
+	// RememberMe shows the remember me checkbox on the login page.
+	// +nullable
+	// +optional
+	RememberMe *bool `json:"rememberMe,omitempty"`
+
+	// ResetPasswordAllowed shows the forgot password link on the login page.
+	// +nullable
+	// +optional
+	ResetPasswordAllowed *bool `json:"resetPasswordAllowed,omitempty"`
+
+	// EditUsernameAllowed allows the users to change the username.
+	// +nullable
+	// +optional
+	EditUsernameAllowed *bool `json:"editUsernameAllowed,omitempty"`
+
+	// FrontendURL is the URL of the realm used for the token issuer and the links sent to the users.
+	// It overrides the keycloak hostname for the realm behind a reverse proxy, the URL must be absolute.
+	// The empty value removes the override, the frontend URL is left unchanged if it is not set.
+	// +kubebuilder:validation:Pattern=`^(https?://.*)?$`
+	// +optional
+	FrontendURL *string `json:"frontendUrl,omitempty"`
+
+	// AdminURL is the URL the realm admin console is served from behind a reverse proxy, the URL must be absolute.
+	// It is set as the root URL of the security-admin-console client, so the console redirect URIs match it.
+	// The empty value restores the keycloak admin URL, the admin URL is left unchanged if it is not set.
+	// +kubebuilder:validation:Pattern=`^(https?://.*)?$`
+	// +optional
+	AdminURL *string `json:"adminUrl,omitempty"`
+
+	// CIBAPolicy is the configuration of the client initiated backchannel authentication of the realm.
+	// The policy is not managed if it is not set.
+	// +nullable
+	// +optional
+	CIBAPolicy *RealmCIBAPolicy `json:"cibaPolicy,omitempty"`
+
+	// Localization is the configuration of the realm internationalization and the message bundle overrides.
+	// The internationalization is enabled if it is set.
+	// +nullable
+	// +optional
+	Localization *RealmLocalization `json:"localization,omitempty"`
+
+	// UserProfileConfig is the declarative user profile of the realm, it requires keycloak 24 or later.
+	// The config replaces the realm user profile, so it must contain the username and email attributes.
+	// The user profile is not managed if it is not set.
+	// +nullable
+	// +optional
+	UserProfileConfig *UserProfileConfig `json:"userProfileConfig,omitempty"`
+
+	// KeyProviders is a list of the realm key providers, they are synced by name.
+	// The key providers which are not declared are not managed.
+	// +nullable
+	// +optional
+	KeyProviders []RealmKeyProvider `json:"keyProviders,omitempty"`
+
+	// ClientPolicies are the realm client profiles and client policies,
+	// e.g. to enforce the FAPI conformance of the realm clients.
+	// +nullable
+	// +optional
+	ClientPolicies *RealmClientPolicies `json:"clientPolicies,omitempty"`
+
+	// Prune is an opt-in policy which deletes the realm clients, roles, groups and client scopes
+	// which exist in keycloak but are not declared by any custom resource in the namespace of the realm.
+	// The built-in keycloak resources are never pruned. Nothing is pruned if it is not set.
+	// +nullable
+	// +optional
+	Prune *RealmPrune `json:"prune,omitempty"`
+}
+
+// RealmPrune defines which undeclared realm children are deleted from keycloak.
+type RealmPrune struct {
+	// Clients enables pruning of the clients which are not declared by the KeycloakClient resources.
+	// +optional
+	Clients bool `json:"clients,omitempty"`
+
+	// RealmRoles enables pruning of the realm roles which are not declared by the KeycloakRealmRole resources.
+	// +optional
+	RealmRoles bool `json:"realmRoles,omitempty"`
+
+	// Groups enables pruning of the groups which are not declared by the KeycloakRealmGroup resources.
+	// The parent groups of the declared groups are kept.
+	// +optional
+	Groups bool `json:"groups,omitempty"`
+
+	// ClientScopes enables pruning of the client scopes which are not declared by the KeycloakClientScope resources.
+	// +optional
+	ClientScopes bool `json:"clientScopes,omitempty"`
+
+	// Exclude are the resources which are never pruned.
+	// +nullable
+	// +optional
+	Exclude *RealmPruneExclusions `json:"exclude,omitempty"`
+
+	// DryRun only logs the resources which would be pruned.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// RealmPruneExclusions are the glob patterns, e.g. legacy-*, of the resources which are never pruned.
+type RealmPruneExclusions struct {
+	// Clients is a list of the clientId patterns.
+	// +nullable
+	// +optional
+	Clients []string `json:"clients,omitempty"`
+
+	// RealmRoles is a list of the role name patterns.
+	// +nullable
+	// +optional
+	RealmRoles []string `json:"realmRoles,omitempty"`
+
+	// Groups is a list of the group path patterns, e.g. /external/*, the subgroups of the excluded groups are kept.
+	// +nullable
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// ClientScopes is a list of the client scope name patterns.
+	// +nullable
+	// +optional
+	ClientScopes []string `json:"clientScopes,omitempty"`
+}
+
+// RealmClientPolicies are the realm client profiles and the client policies which apply them.
+// If a list is set, it replaces all the realm ones, so an empty list removes all of them.
+// The list is not managed if it is not set.
+type RealmClientPolicies struct {
+	// Profiles is a list of the realm client profiles. The global profiles, e.g. fapi-1-advanced,
+	// are provided by keycloak and can be used by the policies without being declared.
+	// +nullable
+	// +optional
+	Profiles []ClientProfile `json:"profiles,omitempty"`
+
+	// Policies is a list of the realm client policies.
+	// +nullable
+	// +optional
+	Policies []ClientPolicy `json:"policies,omitempty"`
+}
+
+// ClientProfile is a set of the executors applied to the clients by the client policies.
+type ClientProfile struct {
+	// Name is the name of the profile, it must be unique within the realm.
+	Name string `json:"name"`
+
+	// Description is the description of the profile.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Executors is a list of the executors of the profile.
+	// +nullable
+	// +optional
+	Executors []ClientPolicyExecutor `json:"executors,omitempty"`
+}
+
+type ClientPolicyExecutor struct {
+	// Executor is the executor provider id, e.g. secure-client-authenticator.
+	Executor string `json:"executor"`
+
+	// Configuration is a map of the executor config values, e.g. auto-configure: true.
+	// +nullable
+	// +optional
+	Configuration map[string]apiextensionsv1.JSON `json:"configuration,omitempty"`
+}
+
+// ClientPolicy applies the client profiles to the clients which match all the conditions.
+type ClientPolicy struct {
+	// Name is the name of the policy, it must be unique within the realm.
+	Name string `json:"name"`
+
+	// Description is the description of the policy.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Enabled enables the policy, it is true if it is not set.
+	// +nullable
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Conditions is a list of the conditions which the client must match.
+	// +nullable
+	// +optional
+	Conditions []ClientPolicyCondition `json:"conditions,omitempty"`
+
+	// Profiles is a list of names of the realm or the global client profiles applied by the policy.
+	// +nullable
+	// +optional
+	Profiles []string `json:"profiles,omitempty"`
+}
+
+type ClientPolicyCondition struct {
+	// Condition is the condition provider id, e.g. client-roles or client-access-type.
+	Condition string `json:"condition"`
+
+	// Configuration is a map of the condition config values, e.g. type: [confidential].
+	// +nullable
+	// +optional
+	Configuration map[string]apiextensionsv1.JSON `json:"configuration,omitempty"`
+}
+
+const (
+	KeyProviderRSAGenerated    = "rsa-generated"
+	KeyProviderRSAEncGenerated = "rsa-enc-generated"
+	KeyProviderHMACGenerated   = "hmac-generated"
+	KeyProviderAESGenerated    = "aes-generated"
+	KeyProviderRSA             = "rsa"
+)
+
+// RealmKeyProvider is a realm key provider.
+type RealmKeyProvider struct {
+	// Name is the name of the key provider component.
+	Name string `json:"name"`
+
+	// Type is the key provider type, the rsa type imports the RSA key from the secret.
+	// +kubebuilder:validation:Enum=rsa-generated;rsa-enc-generated;hmac-generated;aes-generated;rsa
+	Type string `json:"type"`
+
+	// Priority is the priority of the key, the active key with the highest priority is used to sign the tokens.
+	// +optional
+	Priority int64 `json:"priority,omitempty"`
+
+	// Enabled enables the key, it is true if it is not set.
+	// +nullable
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Active makes the key used to sign the tokens, a passive key is used only to verify them.
+	// It is true if it is not set.
+	// +nullable
+	// +optional
+	Active *bool `json:"active,omitempty"`
+
+	// Algorithm is the algorithm of the key, e.g. RS256 or HS256.
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// KeySize is the size of the generated RSA key.
+	// +kubebuilder:validation:Enum=1024;2048;4096
+	// +optional
+	KeySize int `json:"keySize,omitempty"`
+
+	// SecretSize is the size in bytes of the generated HMAC or AES secret.
+	// +kubebuilder:validation:Minimum=16
+	// +optional
+	SecretSize int `json:"secretSize,omitempty"`
+
+	// PrivateKey is a reference to the secret key with the PEM encoded RSA private key, it is required for the rsa type.
+	// +nullable
+	// +optional
+	PrivateKey *SecretKeyRef `json:"privateKey,omitempty"`
+
+	// Certificate is a reference to the secret key with the PEM encoded X509 certificate of the RSA key.
+	// +nullable
+	// +optional
+	Certificate *SecretKeyRef `json:"certificate,omitempty"`
+
+	// Rotation is the configuration of the key rotation.
+	// +nullable
+	// +optional
+	Rotation *KeyProviderRotation `json:"rotation,omitempty"`
+}
+
+// KeyProviderRotation is the configuration of the key rotation.
+// Increasing the generation creates a new key provider named <name>-<generation> and makes the keys
+// of the previous generations passive, so the tokens signed by them are still valid.
+// The key providers of the newer generations, e.g. left after the generation is decreased, are not changed.
+type KeyProviderRotation struct {
+	// Generation is the generation of the key, the key provider of the generation 0 is named <name>.
+	// +kubebuilder:validation:Minimum=0
+	Generation int `json:"generation"`
+
+	// PassiveGenerations is a number of the previous generations which are kept as passive keys,
+	// the older generations are deleted. One previous generation is kept if it is not set.
+	// +kubebuilder:validation:Minimum=0
+	// +nullable
+	// +optional
+	PassiveGenerations *int `json:"passiveGenerations,omitempty"`
+}
+
+func (in *KeyProviderRotation) GetPassiveGenerations() int {
+	if in.PassiveGenerations == nil {
+		return 1
+	}
+
+	return *in.PassiveGenerations
+}
+
+// UserProfileConfig is the declarative user profile of the realm.
+type UserProfileConfig struct {
+	// UnmanagedAttributePolicy is the policy of the attributes which are not declared in the profile.
+	// The attributes are dropped if it is not set.
+	// +kubebuilder:validation:Enum=ENABLED;ADMIN_EDIT;ADMIN_VIEW
+	// +optional
+	UnmanagedAttributePolicy string `json:"unmanagedAttributePolicy,omitempty"`
+
+	// Attributes is a list of the user attributes.
+	// +nullable
+	// +optional
+	Attributes []UserProfileAttribute `json:"attributes,omitempty"`
+
+	// Groups is a list of the attribute groups.
+	// +nullable
+	// +optional
+	Groups []UserProfileGroup `json:"groups,omitempty"`
+}
+
+type UserProfileAttribute struct {
+	// Name is the name of the attribute.
+	Name string `json:"name"`
+
+	// DisplayName is the name of the attribute shown in the forms, it can be a message key, e.g. ${firstName}.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Group is the name of the attribute group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Multivalued allows the attribute to have multiple values.
+	// +optional
+	Multivalued bool `json:"multivalued,omitempty"`
+
+	// Validations is a map of the validator configs keyed by the validator id, e.g. length: {min: 3, max: 255}.
+	// +nullable
+	// +optional
+	Validations map[string]map[string]apiextensionsv1.JSON `json:"validations,omitempty"`
+
+	// Annotations is a map of the attribute annotations, e.g. inputType: textarea.
+	// +nullable
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Required makes the attribute required for the given roles and scopes.
+	// +nullable
+	// +optional
+	Required *UserProfileAttributeRequired `json:"required,omitempty"`
+
+	// Permissions are the roles which can view and edit the attribute, e.g. admin and user.
+	// +nullable
+	// +optional
+	Permissions *UserProfileAttributePermissions `json:"permissions,omitempty"`
+
+	// Selector enables the attribute only for the given client scopes.
+	// +nullable
+	// +optional
+	Selector *UserProfileAttributeSelector `json:"selector,omitempty"`
+}
+
+type UserProfileAttributeRequired struct {
+	// +nullable
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// +nullable
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type UserProfileAttributePermissions struct {
+	// +nullable
+	// +optional
+	View []string `json:"view,omitempty"`
+
+	// +nullable
+	// +optional
+	Edit []string `json:"edit,omitempty"`
+}
+
+type UserProfileAttributeSelector struct {
+	// +nullable
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type UserProfileGroup struct {
+	// Name is the name of the group.
+	Name string `json:"name"`
+
+	// +optional
+	DisplayHeader string `json:"displayHeader,omitempty"`
+
+	// +optional
+	DisplayDescription string `json:"displayDescription,omitempty"`
+
+	// +nullable
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RealmLocalization is the configuration of the realm internationalization.
+type RealmLocalization struct {
+	// SupportedLocales is a list of the locales supported by the realm, e.g. en, de.
+	// +kubebuilder:validation:MinItems=1
+	SupportedLocales []string `json:"supportedLocales"`
+
+	// DefaultLocale is the locale used if the user locale is not supported, it must be one of the supported locales.
+	// +optional
+	DefaultLocale string `json:"defaultLocale,omitempty"`
+
+	// Messages is a list of the message bundle overrides of the realm locales.
+	// The overrides of the declared locales which are not in the bundle are removed,
+	// the overrides of the other locales are not managed.
+	// +nullable
+	// +optional
+	Messages []RealmMessageBundle `json:"messages,omitempty"`
+}
+
+// RealmMessageBundle is a set of the message overrides of the locale.
+type RealmMessageBundle struct {
+	// Locale is the locale of the messages.
+	Locale string `json:"locale"`
+
+	// ConfigMap is a name of the config map with the messages, the data keys are the message keys.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+
+	// Texts is a map of the message texts keyed by the message key, it overrides the config map messages.
+	// +nullable
+	// +optional
+	Texts map[string]string `json:"texts,omitempty"`
+}
+
+// RealmTokenSettings is the configuration of the realm token and session lifetimes.
+// The lifetimes are in seconds, the realm values are kept for the lifetimes which are not set.
+type RealmTokenSettings struct {
+	// AccessTokenLifespan is the max time before an access token expires.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	AccessTokenLifespan int `json:"accessTokenLifespan,omitempty"`
+
+	// SsoSessionIdleTimeout is the time a session can be idle before it expires.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SsoSessionIdleTimeout int `json:"ssoSessionIdleTimeout,omitempty"`
+
+	// SsoSessionMaxLifespan is the max time before a session expires.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SsoSessionMaxLifespan int `json:"ssoSessionMaxLifespan,omitempty"`
+
+	// OfflineSessionIdleTimeout is the time an offline session can be idle before it expires.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	OfflineSessionIdleTimeout int `json:"offlineSessionIdleTimeout,omitempty"`
+
+	// OfflineSessionMaxLifespanEnabled enables the max lifespan of the offline sessions.
+	// +optional
+	OfflineSessionMaxLifespanEnabled bool `json:"offlineSessionMaxLifespanEnabled,omitempty"`
+
+	// OfflineSessionMaxLifespan is the max time before an offline session expires.
+	// It is used only if offlineSessionMaxLifespanEnabled is true.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	OfflineSessionMaxLifespan int `json:"offlineSessionMaxLifespan,omitempty"`
+
+	// RevokeRefreshToken enables the refresh token revocation, a refresh token can be used
+	// only refreshTokenMaxReuse times more after it is used.
+	// +optional
+	RevokeRefreshToken bool `json:"revokeRefreshToken,omitempty"`
+
+	// RefreshTokenMaxReuse is the max number of times a refresh token can be reused.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RefreshTokenMaxReuse int `json:"refreshTokenMaxReuse,omitempty"`
+}
+
+// RealmSecurityDefenses is the configuration of the realm security defenses.
+// The brute force detection is configured by the bruteForceProtection field of the realm.
+type RealmSecurityDefenses struct {
+	// Headers are the security headers sent by keycloak to the browser.
+	// +nullable
+	// +optional
+	Headers *RealmSecurityHeaders `json:"headers,omitempty"`
+}
+
+// RealmSecurityHeaders are the realm browser security headers, the headers which are not set are kept.
+// An empty value disables the header.
+type RealmSecurityHeaders struct {
+	// +optional
+	ContentSecurityPolicy *string `json:"contentSecurityPolicy,omitempty"`
+
+	// +optional
+	ContentSecurityPolicyReportOnly *string `json:"contentSecurityPolicyReportOnly,omitempty"`
+
+	// +optional
+	XFrameOptions *string `json:"xFrameOptions,omitempty"`
+
+	// +optional
+	StrictTransportSecurity *string `json:"strictTransportSecurity,omitempty"`
+
+	// +optional
+	XContentTypeOptions *string `json:"xContentTypeOptions,omitempty"`
+
+	// +optional
+	XRobotsTag *string `json:"xRobotsTag,omitempty"`
+
+	// +optional
+	XXSSProtection *string `json:"xXSSProtection,omitempty"`
+
+	// +optional
+	ReferrerPolicy *string `json:"referrerPolicy,omitempty"`
+}
+
+// RealmBruteForceProtection is the configuration of the realm brute force detection.
+// The keycloak defaults are used for the settings which are not set.
+type RealmBruteForceProtection struct {
+	// Enabled enables the brute force detection.
+	Enabled bool `json:"enabled"`
+
+	// PermanentLockout disables the user permanently when the max login failures is reached.
+	// +optional
+	PermanentLockout bool `json:"permanentLockout,omitempty"`
+
+	// MaxLoginFailures is the number of login failures before the user is locked out.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxLoginFailures int `json:"maxLoginFailures,omitempty"`
+
+	// WaitIncrementSeconds is the time the user is locked out for when the max login failures is reached.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	WaitIncrementSeconds int `json:"waitIncrementSeconds,omitempty"`
+
+	// MaxFailureWaitSeconds is the max time the user is locked out for.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxFailureWaitSeconds int `json:"maxFailureWaitSeconds,omitempty"`
+
+	// FailureResetTimeSeconds is the time after which the login failures count is reset.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureResetTimeSeconds int `json:"failureResetTimeSeconds,omitempty"`
+
+	// QuickLoginCheckMilliSeconds is the min interval between the login failures to consider them too quick.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	QuickLoginCheckMilliSeconds int64 `json:"quickLoginCheckMilliSeconds,omitempty"`
+
+	// MinimumQuickLoginWaitSeconds is the time the user is locked out for after a too quick login failure.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinimumQuickLoginWaitSeconds int `json:"minimumQuickLoginWaitSeconds,omitempty"`
+}
+
+// RealmSMTP is the configuration of the realm email server.
+type RealmSMTP struct {
+	// Host is the host of the SMTP server.
+	Host string `json:"host"`
+
+	// Port is the port of the SMTP server, keycloak uses 25 if it is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int `json:"port,omitempty"`
+
+	// From is the email address used as the sender.
+	From string `json:"from"`
+
+	// +optional
+	FromDisplayName string `json:"fromDisplayName,omitempty"`
+
+	// +optional
+	ReplyTo string `json:"replyTo,omitempty"`
+
+	// +optional
+	ReplyToDisplayName string `json:"replyToDisplayName,omitempty"`
+
+	// EnvelopeFrom is the address used for bounces.
+	// +optional
+	EnvelopeFrom string `json:"envelopeFrom,omitempty"`
+
+	// SSL enables SSL for the connection to the SMTP server.
+	// +optional
+	SSL bool `json:"ssl,omitempty"`
+
+	// StartTLS enables StartTLS for the connection to the SMTP server.
+	// +optional
+	StartTLS bool `json:"starttls,omitempty"`
+
+	// Auth is the authentication to the SMTP server, the authentication is disabled if it is not set.
+	// +nullable
+	// +optional
+	Auth *SMTPAuth `json:"auth,omitempty"`
+}
+
+type SMTPAuth struct {
+	// Username is the username used to authenticate to the SMTP server.
+	Username string `json:"username"`
+
+	// PasswordSecret is a reference to the secret key with the password used to authenticate to the SMTP server.
+	PasswordSecret SecretKeyRef `json:"passwordSecret"`
+}
+
+// DefaultRoles is a set of roles of the default-roles-<realm> composite role.
+type DefaultRoles struct {
+	// RealmRoles is a list of realm role names, e.g. offline_access.
+	// +nullable
+	// +optional
+	RealmRoles []string `json:"realmRoles,omitempty"`
+
+	// ClientRoles is a map of client role names keyed by clientId, e.g. account: [view-profile, manage-account].
+	// +nullable
+	// +optional
+	ClientRoles map[string][]string `json:"clientRoles,omitempty"`
+}
+
+// RealmCIBAPolicy is the client initiated backchannel authentication (CIBA) policy of the realm.
+type RealmCIBAPolicy struct {
+	// BackchannelTokenDeliveryMode is the way the client gets the tokens, by polling or after the ping callback.
+	// +kubebuilder:validation:Enum=poll;ping
+	// +kubebuilder:default=poll
+	// +optional
+	BackchannelTokenDeliveryMode string `json:"backchannelTokenDeliveryMode,omitempty"`
+
+	// ExpiresIn is the expiration time of the authentication request in seconds.
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=600
+	// +kubebuilder:default=120
+	// +optional
+	ExpiresIn int `json:"expiresIn,omitempty"`
+
+	// Interval is the minimum time in seconds the client must wait between the polling requests.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=600
+	// +kubebuilder:default=5
+	// +optional
+	Interval int `json:"interval,omitempty"`
+
+	// AuthRequestedUserHint is the way the user whose authentication is requested is identified.
+	// +kubebuilder:validation:Enum=login_hint
+	// +kubebuilder:default=login_hint
+	// +optional
+	AuthRequestedUserHint string `json:"authRequestedUserHint,omitempty"`
+}
+
+// RealmDefaultClientScopes are the realm default and optional client scopes of the new clients.
+// If a list is set, it replaces all the realm scopes of the type, so an empty list removes all of them.
+// Scopes are not managed if a list is not set. A scope can not be both default and optional.
+// The lists replace the default flag of the KeycloakClientScope custom resources, so they should not be used together.
+type RealmDefaultClientScopes struct {
+	// Default is a list of client scope names which are added to the new clients as default scopes.
+	// +nullable
+	// +optional
+	Default []string `json:"default,omitempty"`
+
+	// Optional is a list of client scope names which are added to the new clients as optional scopes.
+	// +nullable
+	// +optional
+	Optional []string `json:"optional,omitempty"`
+}
+
+// ClientRegistrationPolicies are the policies of the anonymous and the authenticated client registration.
+// If a list is set, it replaces all the policies of the registration type, including the keycloak default ones,
+// so an empty list removes all the restrictions. Policies are not managed if a list is not set.
+type ClientRegistrationPolicies struct {
+	// Anonymous is a list of policies applied to registration requests without the token.
+	// +nullable
+	// +optional
+	Anonymous []ClientRegistrationPolicy `json:"anonymous,omitempty"`
+
+	// Authenticated is a list of policies applied to registration requests with the bearer or initial access token.
+	// +nullable
+	// +optional
+	Authenticated []ClientRegistrationPolicy `json:"authenticated,omitempty"`
+}
+
+// ClientRegistrationPolicy is a client registration policy, exactly one of the policy types must be set.
+type ClientRegistrationPolicy struct {
+	// Name is a name of the policy, it must be unique within the registration type.
+	Name string `json:"name"`
+
+	// TrustedHosts allows registration requests only from the trusted hosts.
+	// +optional
+	TrustedHosts *TrustedHostsPolicy `json:"trustedHosts,omitempty"`
+
+	// AllowedProtocolMappers restricts protocol mapper types of the registered clients.
+	// +optional
+	AllowedProtocolMappers *AllowedProtocolMappersPolicy `json:"allowedProtocolMappers,omitempty"`
+
+	// AllowedClientScopes restricts client scopes of the registered clients.
+	// +optional
+	AllowedClientScopes *AllowedClientScopesPolicy `json:"allowedClientScopes,omitempty"`
+
+	// MaxClients limits the number of clients in the realm.
+	// +optional
+	MaxClients *MaxClientsPolicy `json:"maxClients,omitempty"`
+
+	// ConsentRequired forces registered clients to require the user consent.
+	// +optional
+	ConsentRequired bool `json:"consentRequired,omitempty"`
+
+	// FullScopeDisabled disables the full scope allowed option of the registered clients.
+	// +optional
+	FullScopeDisabled bool `json:"fullScopeDisabled,omitempty"`
+
+	// ClientDisabled makes registered clients disabled until an administrator enables them.
+	// +optional
+	ClientDisabled bool `json:"clientDisabled,omitempty"`
+}
+
+type TrustedHostsPolicy struct {
+	// Hosts is a list of trusted hosts or domains, wildcards like *.example.com are allowed.
+	// +nullable
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+
+	// HostSendingRegistrationRequestMustMatch requires the registration request to be sent from a trusted host.
+	// +optional
+	HostSendingRegistrationRequestMustMatch *bool `json:"hostSendingRegistrationRequestMustMatch,omitempty"`
+
+	// ClientURIsMustMatch requires redirect URIs and other client URLs to use trusted hosts.
+	// +optional
+	ClientURIsMustMatch *bool `json:"clientUrisMustMatch,omitempty"`
+}
+
+type AllowedProtocolMappersPolicy struct {
+	// ProtocolMapperTypes is a list of allowed protocol mapper provider ids, e.g. oidc-full-name-mapper.
+	// +nullable
+	// +optional
+	ProtocolMapperTypes []string `json:"protocolMapperTypes,omitempty"`
+}
+
+type AllowedClientScopesPolicy struct {
+	// Scopes is a list of allowed client scopes.
+	// +nullable
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// AllowDefaultScopes allows realm default client scopes in addition to the listed ones.
+	// +optional
+	AllowDefaultScopes *bool `json:"allowDefaultScopes,omitempty"`
+}
+
+type MaxClientsPolicy struct {
+	// Limit is the maximum number of clients in the realm.
+	// +kubebuilder:validation:Minimum=1
+	Limit int `json:"limit"`
 }
 
 type User struct {
@@ -71,6 +916,74 @@ type PasswordPolicy struct {
 	Value string `json:"value"`
 }
 
+// RealmPasswordPolicy is a set of the keycloak password policies.
+type RealmPasswordPolicy struct {
+	// Length is the minimum length of the password.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Length *int `json:"length,omitempty"`
+
+	// MaxLength is the maximum length of the password.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// Digits is the minimum number of digits in the password.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Digits *int `json:"digits,omitempty"`
+
+	// LowerCase is the minimum number of lower case characters in the password.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LowerCase *int `json:"lowerCase,omitempty"`
+
+	// UpperCase is the minimum number of upper case characters in the password.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	UpperCase *int `json:"upperCase,omitempty"`
+
+	// SpecialChars is the minimum number of special characters in the password.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SpecialChars *int `json:"specialChars,omitempty"`
+
+	// NotUsername forbids the password to be equal to the username.
+	// +optional
+	NotUsername bool `json:"notUsername,omitempty"`
+
+	// NotEmail forbids the password to be equal to the email.
+	// +optional
+	NotEmail bool `json:"notEmail,omitempty"`
+
+	// PasswordHistory is the number of the last passwords which can not be reused.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PasswordHistory *int `json:"passwordHistory,omitempty"`
+
+	// ForceExpiredPasswordChange is the number of days after which the password must be changed.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ForceExpiredPasswordChange *int `json:"forceExpiredPasswordChange,omitempty"`
+
+	// HashIterations is the number of the hashing iterations.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HashIterations *int `json:"hashIterations,omitempty"`
+
+	// HashAlgorithm is the password hashing algorithm, e.g. pbkdf2-sha256.
+	// +optional
+	HashAlgorithm string `json:"hashAlgorithm,omitempty"`
+
+	// RegexPattern is a regular expression the password must match.
+	// +optional
+	RegexPattern string `json:"regexPattern,omitempty"`
+
+	// PasswordBlacklist is the name of the blacklist file with the forbidden passwords.
+	// +optional
+	PasswordBlacklist string `json:"passwordBlacklist,omitempty"`
+}
+
 type RealmEventConfig struct {
 	// +optional
 	AdminEventsDetailsEnabled bool `json:"adminEventsDetailsEnabled,omitempty"`
@@ -91,21 +1004,33 @@ type RealmEventConfig struct {
 	// +optional
 	// +nullable
 	EventsListeners []string `json:"eventsListeners,omitempty"`
+
+	// AdminEventsExpiration is a time in seconds after which the admin events are deleted.
+	// The expiration is not managed if the field is not set, 0 removes the expiration.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AdminEventsExpiration *int `json:"adminEventsExpiration,omitempty"`
 }
 
+// RealmThemes defines the realm themes, they must be available in keycloak.
+// An empty theme name sets the keycloak default theme, the themes which are not set are not managed.
 type RealmThemes struct {
+	// LoginTheme is a theme of the realm login pages.
 	// +nullable
 	// +optional
 	LoginTheme *string `json:"loginTheme"`
 
+	// AccountTheme is a theme of the realm account console.
 	// +nullable
 	// +optional
 	AccountTheme *string `json:"accountTheme"`
 
+	// AdminConsoleTheme is a theme of the realm admin console.
 	// +nullable
 	// +optional
 	AdminConsoleTheme *string `json:"adminConsoleTheme"`
 
+	// EmailTheme is a theme of the realm emails.
 	// +nullable
 	// +optional
 	EmailTheme *string `json:"emailTheme"`
@@ -145,6 +1070,16 @@ type KeycloakRealmStatus struct {
 
 	// +optional
 	Value string `json:"value,omitempty"`
+
+	// AppliedGeneration is the generation of the spec applied by the last successful reconciliation.
+	// The realm is checked for the drift only while its spec is applied.
+	// +optional
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+
+	// Conditions show whether the realm is applied and whether its settings were changed in Keycloak.
+	// +nullable
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 func (in *KeycloakRealm) GetFailureCount() int64 {
@@ -155,6 +1090,30 @@ func (in *KeycloakRealm) SetFailureCount(count int64) {
 	in.Status.FailureCount = count
 }
 
+func (in *KeycloakRealm) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealm) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakRealm) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealm) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealm) GetAppliedGeneration() int64 {
+	return in.Status.AppliedGeneration
+}
+
+func (in *KeycloakRealm) SetAppliedGeneration(generation int64) {
+	in.Status.AppliedGeneration = generation
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
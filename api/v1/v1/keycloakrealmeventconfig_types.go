@@ -0,0 +1,77 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// KeycloakRealmEventConfigSpec defines the desired state of KeycloakRealmEventConfig.
+type KeycloakRealmEventConfigSpec struct {
+	// RealmSelector is a label selector of the KeycloakRealm custom resources the event config is applied to.
+	// All realms of the namespace are selected if it is not set.
+	// Realms with their own realmEventConfig are skipped.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	RealmEventConfig `json:",inline"`
+}
+
+// KeycloakRealmEventConfigStatus defines the observed state of KeycloakRealmEventConfig.
+type KeycloakRealmEventConfigStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// Realms contains names of the KeycloakRealm custom resources the event config is applied to.
+	// +nullable
+	// +optional
+	Realms []string `json:"realms,omitempty"`
+
+	// SkippedRealms contains names of the selected KeycloakRealm custom resources with their own realmEventConfig.
+	// +nullable
+	// +optional
+	SkippedRealms []string `json:"skippedRealms,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// KeycloakRealmEventConfig is the Schema for the keycloakrealmeventconfigs API.
+type KeycloakRealmEventConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakRealmEventConfigSpec   `json:"spec,omitempty"`
+	Status KeycloakRealmEventConfigStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakRealmEventConfig) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakRealmEventConfig) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakRealmEventConfig) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealmEventConfig) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakRealmEventConfigList contains a list of KeycloakRealmEventConfig.
+type KeycloakRealmEventConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakRealmEventConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakRealmEventConfig{}, &KeycloakRealmEventConfigList{})
+}
@@ -4,12 +4,48 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 // KeycloakRealmGroupSpec defines the desired state of KeycloakRealmGroup.
 type KeycloakRealmGroupSpec struct {
-	Name  string `json:"name"`
-	Realm string `json:"realm"`
+	Name string `json:"name"`
 
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// ClusterRealm is a name of the ClusterKeycloakRealm custom resource the group belongs to.
+	// It is used instead of the realm if it is set.
+	// +optional
+	ClusterRealm string `json:"clusterRealm,omitempty"`
+
+	// Path is a full path of the group, e.g. /platform/admins.
+	// Missing intermediate groups are created. The last segment of the path must be equal to the name.
+	// A path with a single segment is a top-level group.
 	// +optional
 	Path string `json:"path,omitempty"`
 
+	// ParentGroup is a reference to the KeycloakRealmGroup custom resource of the parent group.
+	// It can not be used together with a nested path.
+	// The group is moved if its parent changes.
+	// +nullable
+	// +optional
+	ParentGroup *ParentGroup `json:"parentGroup,omitempty"`
+
+	// Attributes is a map of the group attributes, e.g. LDAP mapping hints or sources of custom claims.
+	// Attributes which are not declared are removed from the group.
 	// +nullable
 	// +optional
 	Attributes map[string][]string `json:"attributes,omitempty"`
@@ -18,17 +54,30 @@ type KeycloakRealmGroupSpec struct {
 	// +optional
 	Access map[string]bool `json:"access,omitempty"`
 
+	// RealmRoles is a list of realm roles mapped to the group.
+	// Realm roles which are mapped to the group but not declared are removed from the group.
 	// +nullable
 	// +optional
 	RealmRoles []string `json:"realmRoles,omitempty"`
 
+	// SubGroups is a list of top-level groups which are moved into this group.
+	// Subgroups which are not in the list are detached from the group.
+	// Existing subgroups are left untouched if the list is empty, so groups nested with path or parentGroup are preserved.
 	// +nullable
 	// +optional
 	SubGroups []string `json:"subGroups,omitempty"`
 
+	// ClientRoles is a list of client roles mapped to the group.
+	// Client roles which are mapped to the group but not declared are removed from the group.
+	// The client roles are not managed if the list is not set, an empty list removes all of them.
 	// +nullable
 	// +optional
-	ClientRoles []ClientRole `json:"clientRoles,omitempty"`
+	ClientRoles []ClientRole `json:"clientRoles"`
+}
+
+type ParentGroup struct {
+	// Name is a name of the KeycloakRealmGroup custom resource in the same namespace.
+	Name string `json:"name"`
 }
 
 // KeycloakRealmGroupStatus defines the observed state of KeycloakRealmGroup.
@@ -39,8 +88,22 @@ type KeycloakRealmGroupStatus struct {
 	// +optional
 	ID string `json:"id,omitempty"`
 
+	// Path is a full path of the group in Keycloak.
+	// +optional
+	Path string `json:"path,omitempty"`
+
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// AppliedGeneration is the generation of the spec applied by the last successful reconciliation.
+	// The group is checked for the drift only while its spec is applied.
+	// +optional
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+
+	// Conditions show whether the group is applied and whether it was changed in Keycloak outside of the operator.
+	// +nullable
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 func (in *KeycloakRealmGroup) GetFailureCount() int64 {
@@ -51,6 +114,22 @@ func (in *KeycloakRealmGroup) SetFailureCount(count int64) {
 	in.Status.FailureCount = count
 }
 
+func (in *KeycloakRealmGroup) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealmGroup) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealmGroup) GetAppliedGeneration() int64 {
+	return in.Status.AppliedGeneration
+}
+
+func (in *KeycloakRealmGroup) SetAppliedGeneration(generation int64) {
+	in.Status.AppliedGeneration = generation
+}
+
 func (in *KeycloakRealmGroup) GetStatus() string {
 	return in.Status.Value
 }
@@ -60,9 +139,29 @@ func (in *KeycloakRealmGroup) SetStatus(value string) {
 }
 
 func (in *KeycloakRealmGroup) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmGroup) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakRealmGroup) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakRealmGroup) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
+func (in *KeycloakRealmGroup) ClusterRealmName() string {
+	return in.Spec.ClusterRealm
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
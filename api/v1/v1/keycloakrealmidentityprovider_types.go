@@ -4,11 +4,66 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 // KeycloakRealmIdentityProviderSpec defines the desired state of KeycloakRealmIdentityProvider.
 type KeycloakRealmIdentityProviderSpec struct {
-	Realm      string            `json:"realm"`
-	ProviderID string            `json:"providerId"`
-	Alias      string            `json:"alias"`
-	Config     map[string]string `json:"config"`
-	Enabled    bool              `json:"enabled"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	ProviderID string `json:"providerId"`
+	Alias      string `json:"alias"`
+	Enabled    bool   `json:"enabled"`
+
+	// Config is a raw config of the identity provider.
+	// For SAML identity providers, typed fields from the saml section take precedence over it.
+	// +nullable
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+
+	// ClientSecretRef is a reference to the secret key with the identity provider client secret.
+	// It takes precedence over the clientSecret key of the config.
+	// +nullable
+	// +optional
+	ClientSecretRef *SecretKeyRef `json:"clientSecretRef,omitempty"`
+
+	// SAML is a typed configuration of the SAML v2.0 identity provider, providerId must be set to saml.
+	// +nullable
+	// +optional
+	SAML *SAMLIdentityProviderConfig `json:"saml,omitempty"`
+
+	// GitHub is a typed configuration of the GitHub identity provider, providerId must be set to github.
+	// +nullable
+	// +optional
+	GitHub *GitHubIdentityProviderConfig `json:"github,omitempty"`
+
+	// Google is a typed configuration of the Google identity provider, providerId must be set to google.
+	// +nullable
+	// +optional
+	Google *GoogleIdentityProviderConfig `json:"google,omitempty"`
+
+	// Microsoft is a typed configuration of the Microsoft identity provider, providerId must be set to microsoft.
+	// +nullable
+	// +optional
+	Microsoft *MicrosoftIdentityProviderConfig `json:"microsoft,omitempty"`
+
+	// GitLab is a typed configuration of the GitLab identity provider, providerId must be set to gitlab.
+	// +nullable
+	// +optional
+	GitLab *GitLabIdentityProviderConfig `json:"gitlab,omitempty"`
 
 	// +optional
 	AddReadTokenRoleOnCreate bool `json:"addReadTokenRoleOnCreate,omitempty"`
@@ -19,21 +74,196 @@ type KeycloakRealmIdentityProviderSpec struct {
 	// +optional
 	DisplayName string `json:"displayName,omitempty"`
 
+	// FirstBrokerLoginFlowAlias is the alias of the auth flow triggered after the first login with this identity provider.
 	// +optional
 	FirstBrokerLoginFlowAlias string `json:"firstBrokerLoginFlowAlias,omitempty"`
 
+	// FirstBrokerLoginFlowRef is the name of the KeycloakAuthFlow resource in the same namespace
+	// used as the first broker login flow. It takes precedence over firstBrokerLoginFlowAlias.
+	// +optional
+	FirstBrokerLoginFlowRef string `json:"firstBrokerLoginFlowRef,omitempty"`
+
+	// PostBrokerLoginFlowAlias is the alias of the auth flow triggered after each login with this identity provider.
+	// The flow is not managed if the field is not set, the empty value removes the flow from the identity provider.
+	// +optional
+	PostBrokerLoginFlowAlias *string `json:"postBrokerLoginFlowAlias,omitempty"`
+
+	// PostBrokerLoginFlowRef is the name of the KeycloakAuthFlow resource in the same namespace
+	// used as the post broker login flow. It takes precedence over postBrokerLoginFlowAlias.
+	// +optional
+	PostBrokerLoginFlowRef string `json:"postBrokerLoginFlowRef,omitempty"`
+
+	// LinkOnly defines whether users can only link their accounts with the provider and can not log in through it.
 	// +optional
 	LinkOnly bool `json:"linkOnly,omitempty"`
 
+	// SyncMode defines how user data is updated from the identity provider on login.
+	// IMPORT updates the user only on the first login, FORCE updates the user on every login,
+	// LEGACY keeps the behavior of the mappers.
+	// +kubebuilder:validation:Enum=IMPORT;LEGACY;FORCE
+	// +optional
+	SyncMode string `json:"syncMode,omitempty"`
+
+	// HideOnLoginPage defines whether the provider is hidden on the login page,
+	// it can still be requested with the kc_idp_hint parameter.
+	// +nullable
+	// +optional
+	HideOnLoginPage *bool `json:"hideOnLoginPage,omitempty"`
+
+	// GUIOrder is the order of the provider on the login page.
+	// +nullable
+	// +optional
+	GUIOrder *int `json:"guiOrder,omitempty"`
+
 	// +optional
 	StoreToken bool `json:"storeToken,omitempty"`
 
 	// +optional
 	TrustEmail bool `json:"trustEmail,omitempty"`
 
+	// Mappers is a list of identity provider mappers managed inline.
+	// Mappers of the identity provider that are not declared in the list are removed,
+	// so it should not be combined with KeycloakIdentityProviderMapper resources for the same provider.
+	// The mappers are not managed if the list is not set, an empty list removes all of them.
 	// +nullable
 	// +optional
-	Mappers []IdentityProviderMapper `json:"mappers,omitempty"`
+	Mappers []IdentityProviderMapper `json:"mappers"`
+}
+
+type GitHubIdentityProviderConfig struct {
+	// ClientID is the client id of the GitHub OAuth application.
+	ClientID string `json:"clientId"`
+
+	// DefaultScope is the list of scopes requested from GitHub, separated by spaces.
+	// +optional
+	DefaultScope string `json:"defaultScope,omitempty"`
+
+	// BaseURL is the URL of the GitHub web application, it should be changed for GitHub Enterprise.
+	// +kubebuilder:default="https://github.com"
+	// +optional
+	BaseURL string `json:"baseUrl,omitempty"`
+
+	// APIURL is the URL of the GitHub API, it should be changed for GitHub Enterprise.
+	// +kubebuilder:default="https://api.github.com"
+	// +optional
+	APIURL string `json:"apiUrl,omitempty"`
+}
+
+type GoogleIdentityProviderConfig struct {
+	// ClientID is the client id of the Google OAuth client.
+	ClientID string `json:"clientId"`
+
+	// DefaultScope is the list of scopes requested from Google, separated by spaces.
+	// +optional
+	DefaultScope string `json:"defaultScope,omitempty"`
+
+	// HostedDomain restricts login to the given Google Workspace domains, separated by commas.
+	// +optional
+	HostedDomain string `json:"hostedDomain,omitempty"`
+
+	// UseUserIPParam defines whether the userIp query parameter is used when calling Google user info service.
+	// +optional
+	UseUserIPParam *bool `json:"useUserIpParam,omitempty"`
+
+	// OfflineAccess defines whether the refresh token is requested from Google.
+	// +optional
+	OfflineAccess *bool `json:"offlineAccess,omitempty"`
+}
+
+type MicrosoftIdentityProviderConfig struct {
+	// ClientID is the application (client) id of the Microsoft Entra ID application.
+	ClientID string `json:"clientId"`
+
+	// DefaultScope is the list of scopes requested from Microsoft, separated by spaces.
+	// +optional
+	DefaultScope string `json:"defaultScope,omitempty"`
+
+	// TenantID is the directory (tenant) id the users are authenticated in.
+	// Users of any tenant and personal accounts are allowed if it is not set.
+	// +optional
+	TenantID string `json:"tenantId,omitempty"`
+}
+
+type GitLabIdentityProviderConfig struct {
+	// ClientID is the application id of the GitLab OAuth application.
+	ClientID string `json:"clientId"`
+
+	// DefaultScope is the list of scopes requested from GitLab, separated by spaces.
+	// +kubebuilder:default="openid read_user"
+	// +optional
+	DefaultScope string `json:"defaultScope,omitempty"`
+}
+
+type SAMLIdentityProviderConfig struct {
+	// MetadataURL is the URL of the SAML IdP entity descriptor.
+	// If it is set, the configuration is imported from the descriptor and typed fields are applied on top of it.
+	// +optional
+	MetadataURL string `json:"metadataUrl,omitempty"`
+
+	// SingleSignOnServiceURL is the URL that must be used to send authentication requests (SAML AuthnRequest).
+	// +optional
+	SingleSignOnServiceURL string `json:"singleSignOnServiceUrl,omitempty"`
+
+	// SingleLogoutServiceURL is the URL that must be used to send logout requests.
+	// +optional
+	SingleLogoutServiceURL string `json:"singleLogoutServiceUrl,omitempty"`
+
+	// IDPEntityID is the entity ID used to validate the Issuer for received SAML assertions.
+	// +optional
+	IDPEntityID string `json:"idpEntityId,omitempty"`
+
+	// EntityID is the entity ID of the keycloak realm used as SAML service provider.
+	// +optional
+	EntityID string `json:"entityId,omitempty"`
+
+	// SigningCertificates is a list of PEM encoded certificates used to validate signatures of the IdP.
+	// +nullable
+	// +optional
+	SigningCertificates []string `json:"signingCertificates,omitempty"`
+
+	// NameIDPolicyFormat specifies the URI reference corresponding to a name identifier format.
+	// +kubebuilder:validation:Enum=urn:oasis:names:tc:SAML:2.0:nameid-format:persistent;urn:oasis:names:tc:SAML:2.0:nameid-format:transient;urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress;urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified;urn:oasis:names:tc:SAML:2.0:nameid-format:kerberos;urn:oasis:names:tc:SAML:1.1:nameid-format:X509SubjectName;urn:oasis:names:tc:SAML:1.1:nameid-format:WindowsDomainQualifiedName
+	// +optional
+	NameIDPolicyFormat string `json:"nameIdPolicyFormat,omitempty"`
+
+	// PrincipalType defines the way to identify and track external users from the assertion.
+	// +kubebuilder:validation:Enum=SUBJECT;ATTRIBUTE;FRIENDLY_ATTRIBUTE
+	// +optional
+	PrincipalType string `json:"principalType,omitempty"`
+
+	// PrincipalAttribute is the name or friendly name of the attribute used to identify external users.
+	// +optional
+	PrincipalAttribute string `json:"principalAttribute,omitempty"`
+
+	// +optional
+	WantAssertionsSigned *bool `json:"wantAssertionsSigned,omitempty"`
+
+	// +optional
+	WantAssertionsEncrypted *bool `json:"wantAssertionsEncrypted,omitempty"`
+
+	// +optional
+	WantAuthnRequestsSigned *bool `json:"wantAuthnRequestsSigned,omitempty"`
+
+	// ValidateSignature enables signature validation of SAML responses.
+	// +optional
+	ValidateSignature *bool `json:"validateSignature,omitempty"`
+
+	// +optional
+	PostBindingResponse *bool `json:"postBindingResponse,omitempty"`
+
+	// +optional
+	PostBindingAuthnRequest *bool `json:"postBindingAuthnRequest,omitempty"`
+
+	// +optional
+	PostBindingLogout *bool `json:"postBindingLogout,omitempty"`
+
+	// SignatureAlgorithm is the signature algorithm to use to sign documents.
+	// +kubebuilder:validation:Enum=RSA_SHA1;RSA_SHA256;RSA_SHA256_MGF1;RSA_SHA512;RSA_SHA512_MGF1;DSA_SHA1
+	// +optional
+	SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+
+	// +optional
+	ForceAuthn *bool `json:"forceAuthn,omitempty"`
 }
 
 type IdentityProviderMapper struct {
@@ -90,9 +320,25 @@ func (in *KeycloakRealmIdentityProvider) SetStatus(value string) {
 }
 
 func (in *KeycloakRealmIdentityProvider) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmIdentityProvider) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakRealmIdentityProvider) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakRealmIdentityProvider) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakRealmIdentityProviderList contains a list of KeycloakRealmIdentityProvider.
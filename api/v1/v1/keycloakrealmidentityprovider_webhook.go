@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the validating webhook of KeycloakRealmIdentityProvider.
+func (in *KeycloakRealmIdentityProvider) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(in).Complete(); err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmIdentityProvider webhook: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-v1-edp-epam-com-v1-keycloakrealmidentityprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=v1.edp.epam.com,resources=keycloakrealmidentityproviders,verbs=create;update,versions=v1,name=vkeycloakrealmidentityprovider.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeycloakRealmIdentityProvider{}
+
+// ValidateCreate rejects KeycloakRealmIdentityProvider with plaintext credentials.
+func (in *KeycloakRealmIdentityProvider) ValidateCreate() error {
+	return validateCredentials("KeycloakRealmIdentityProvider", in.Name, in.credentialFields(), nil)
+}
+
+// ValidateUpdate rejects KeycloakRealmIdentityProvider with new plaintext credentials.
+func (in *KeycloakRealmIdentityProvider) ValidateUpdate(old runtime.Object) error {
+	var oldFields []credentialField
+	if o, ok := old.(*KeycloakRealmIdentityProvider); ok {
+		oldFields = o.credentialFields()
+	}
+
+	return validateCredentials("KeycloakRealmIdentityProvider", in.Name, in.credentialFields(), oldFields)
+}
+
+// ValidateDelete does nothing, deletion is always allowed.
+func (in *KeycloakRealmIdentityProvider) ValidateDelete() error {
+	return nil
+}
+
+func (in *KeycloakRealmIdentityProvider) credentialFields() []credentialField {
+	return []credentialField{{
+		path:  field.NewPath("spec", "config").Key(identityProviderClientSecretKey),
+		value: in.Spec.Config[identityProviderClientSecretKey],
+		hint:  "use spec.clientSecretRef instead",
+	}}
+}
@@ -0,0 +1,198 @@
+package v1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KeycloakRealmImportSpec defines the desired state of KeycloakRealmImport.
+// The realm representation is applied with the keycloak partial import,
+// only the users, clients, groups, roles and identity providers of the representation are imported.
+type KeycloakRealmImportSpec struct {
+	// Realm is name of KeycloakRealm custom resource.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// Representation is an inline full or partial realm representation in the keycloak export format.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +nullable
+	// +optional
+	Representation *apiextensionsv1.JSON `json:"representation,omitempty"`
+
+	// Source is a reference to the realm representation json in the ConfigMap, Secret, HTTPS URL or OCI artifact.
+	// It can not be used together with the inline representation.
+	// +nullable
+	// +optional
+	Source *RealmImportSource `json:"source,omitempty"`
+
+	// IfResourceExists defines how the resources which already exist in the realm are handled.
+	// FAIL aborts the whole import, SKIP keeps the existing resources and OVERWRITE replaces them.
+	// +kubebuilder:validation:Enum=FAIL;SKIP;OVERWRITE
+	// +kubebuilder:default=FAIL
+	// +optional
+	IfResourceExists string `json:"ifResourceExists,omitempty"`
+}
+
+// RealmImportSource is a reference to the realm representation. Exactly one of the references must be set.
+type RealmImportSource struct {
+	// +nullable
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeyRef `json:"configMapKeyRef,omitempty"`
+
+	// +nullable
+	// +optional
+	SecretKeyRef *SecretKeyRef `json:"secretKeyRef,omitempty"`
+
+	// URL is a reference to the realm representation downloaded from the HTTPS URL.
+	// +nullable
+	// +optional
+	URL *RealmImportURLSource `json:"url,omitempty"`
+
+	// OCI is a reference to the realm representation stored in the OCI registry artifact.
+	// +nullable
+	// +optional
+	OCI *RealmImportOCISource `json:"oci,omitempty"`
+}
+
+// RealmImportURLSource is an HTTPS URL of the realm representation.
+// The representation is downloaded on every reconciliation and imported again only if it changes.
+type RealmImportURLSource struct {
+	// URL is an HTTPS URL of the realm representation json.
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+
+	// SHA256 is a hex encoded sha256 digest the downloaded representation must match.
+	// +kubebuilder:validation:Pattern=`^[a-fA-F0-9]{64}$`
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+
+	// AuthSecret is a name of the secret with the token key for the bearer authentication
+	// or the username and password keys for the basic authentication.
+	// +optional
+	AuthSecret string `json:"authSecret,omitempty"`
+}
+
+// RealmImportOCISource is a reference to the OCI artifact with the realm representation.
+type RealmImportOCISource struct {
+	// Reference is an artifact reference in the registry/repository:tag or registry/repository@sha256:digest format.
+	// The artifact manifest must match the digest if it is set.
+	// +kubebuilder:validation:MinLength=1
+	Reference string `json:"reference"`
+
+	// File is a org.opencontainers.image.title annotation of the artifact layer with the representation.
+	// It can be omitted if the artifact has a single layer.
+	// +optional
+	File string `json:"file,omitempty"`
+
+	// AuthSecret is a name of the registry credentials secret
+	// with the .dockerconfigjson key or the username and password keys.
+	// +optional
+	AuthSecret string `json:"authSecret,omitempty"`
+}
+
+// KeycloakRealmImportStatus defines the observed state of KeycloakRealmImport.
+type KeycloakRealmImportStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// SourceHash is a hash of the last imported representation and import settings.
+	// The representation is not imported again until the data or the settings change.
+	// +optional
+	SourceHash string `json:"sourceHash,omitempty"`
+
+	// Added is a number of resources added by the last import.
+	// +optional
+	Added int `json:"added,omitempty"`
+
+	// Overwritten is a number of existing resources overwritten by the last import.
+	// +optional
+	Overwritten int `json:"overwritten,omitempty"`
+
+	// Skipped is a number of existing resources skipped by the last import.
+	// +optional
+	Skipped int `json:"skipped,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// KeycloakRealmImport is the Schema for the keycloakrealmimports API.
+type KeycloakRealmImport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakRealmImportSpec   `json:"spec,omitempty"`
+	Status KeycloakRealmImportStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakRealmImport) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakRealmImport) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakRealmImport) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealmImport) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakRealmImport) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakRealmImport) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakRealmImport) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakRealmImport) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakRealmImportList contains a list of KeycloakRealmImport.
+type KeycloakRealmImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakRealmImport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakRealmImport{}, &KeycloakRealmImportList{})
+}
@@ -4,14 +4,38 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 const StatusDuplicated = "duplicated"
 
+// ForceDeleteAnnotation allows deletion of the role with the deletion protection
+// which is still assigned to users, groups or composite roles.
+const ForceDeleteAnnotation = "edp.epam.com/force-delete"
+
 // KeycloakRealmRoleSpec defines the desired state of KeycloakRealmRole.
 type KeycloakRealmRoleSpec struct {
-	Name  string `json:"name"`
-	Realm string `json:"realm"`
+	Name string `json:"name"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
 
 	// +optional
 	Description string `json:"description,omitempty"`
 
+	// Attributes is a map of the role attributes.
+	// Attributes which are dropped from the spec are removed from the role unless the addOnly strategy is used.
 	// +nullable
 	// +optional
 	Attributes map[string][]string `json:"attributes,omitempty"`
@@ -19,12 +43,30 @@ type KeycloakRealmRoleSpec struct {
 	// +optional
 	Composite bool `json:"composite,omitempty"`
 
+	// Composites is a list of realm roles which are members of the composite role.
 	// +nullable
 	// +optional
 	Composites []Composite `json:"composites,omitempty"`
 
+	// CompositesClientRoles is a map of client roles which are members of the composite role, keyed by clientId.
+	// +nullable
+	// +optional
+	CompositesClientRoles map[string][]Composite `json:"compositesClientRoles,omitempty"`
+
+	// ReconciliationStrategy is a strategy of composites and attributes reconciliation.
+	// With the full strategy the member roles and the attributes which are not declared in the spec are removed
+	// from the role, with the addOnly strategy they are kept.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +optional
+	ReconciliationStrategy string `json:"reconciliationStrategy,omitempty"`
+
 	// +optional
 	IsDefault bool `json:"isDefault,omitempty"`
+
+	// DeletionProtection blocks deletion of the role while it is assigned to users, groups or realm composite roles.
+	// The role can be deleted anyway if the edp.epam.com/force-delete annotation is set to true.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
 }
 
 type Composite struct {
@@ -41,6 +83,21 @@ type KeycloakRealmRoleStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// UnresolvedComposites is a list of the declared member roles which do not exist in Keycloak.
+	// Client roles are listed in the clientId/role format.
+	// +optional
+	UnresolvedComposites []string `json:"unresolvedComposites,omitempty"`
+
+	// AppliedGeneration is the generation of the spec applied by the last successful reconciliation.
+	// The role is checked for the drift only while its spec is applied.
+	// +optional
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+
+	// Conditions report the result of the last sync of the role and its differences from the spec in Keycloak.
+	// +nullable
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -56,6 +113,19 @@ type KeycloakRealmRole struct {
 	Status KeycloakRealmRoleStatus `json:"status,omitempty"`
 }
 
+func (in *KeycloakRealmRole) GetReconciliationStrategy() string {
+	if in.Spec.ReconciliationStrategy == "" {
+		return ReconciliationStrategyFull
+	}
+
+	return in.Spec.ReconciliationStrategy
+}
+
+// IsDeletionProtected checks if the usage of the role must be checked before deletion.
+func (in *KeycloakRealmRole) IsDeletionProtected() bool {
+	return in.Spec.DeletionProtection && in.GetAnnotations()[ForceDeleteAnnotation] != "true"
+}
+
 func (in *KeycloakRealmRole) GetFailureCount() int64 {
 	return in.Status.FailureCount
 }
@@ -64,6 +134,22 @@ func (in *KeycloakRealmRole) SetFailureCount(count int64) {
 	in.Status.FailureCount = count
 }
 
+func (in *KeycloakRealmRole) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+func (in *KeycloakRealmRole) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+func (in *KeycloakRealmRole) GetAppliedGeneration() int64 {
+	return in.Status.AppliedGeneration
+}
+
+func (in *KeycloakRealmRole) SetAppliedGeneration(generation int64) {
+	in.Status.AppliedGeneration = generation
+}
+
 func (in *KeycloakRealmRole) GetStatus() string {
 	return in.Status.Value
 }
@@ -73,9 +159,25 @@ func (in *KeycloakRealmRole) SetStatus(value string) {
 }
 
 func (in *KeycloakRealmRole) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmRole) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakRealmRole) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakRealmRole) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
 // +kubebuilder:object:root=true
 
 // KeycloakRealmRoleList contains a list of KeycloakRealmRole.
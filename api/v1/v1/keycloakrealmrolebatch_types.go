@@ -8,7 +8,26 @@ import (
 
 // KeycloakRealmRoleBatchSpec defines the desired state of KeycloakRealmRoleBatch.
 type KeycloakRealmRoleBatchSpec struct {
-	Realm string      `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
 	Roles []BatchRole `json:"roles"`
 }
 
@@ -40,6 +59,25 @@ type KeycloakRealmRoleBatchStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// Roles contains the status of each role of the batch.
+	// +nullable
+	// +optional
+	Roles []BatchRoleStatus `json:"roles,omitempty"`
+}
+
+type BatchRoleStatus struct {
+	// Name is a name of the role.
+	Name string `json:"name"`
+
+	// Value is OK if the KeycloakRealmRole custom resource of the role is put
+	// or the error which occurred while the custom resource was put.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Failed is true if the KeycloakRealmRole custom resource of the role can not be put.
+	// +optional
+	Failed bool `json:"failed,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -72,9 +110,25 @@ func (in *KeycloakRealmRoleBatch) SetFailureCount(count int64) {
 }
 
 func (in *KeycloakRealmRoleBatch) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmRoleBatch) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakRealmRoleBatch) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakRealmRoleBatch) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
 func (in *KeycloakRealmRoleBatch) FormattedRoleName(baseRoleName string) string {
 	return fmt.Sprintf("%s-%s", in.Name, baseRoleName)
 }
@@ -2,9 +2,65 @@ package v1
 
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+const (
+	// SendResetPasswordEmailAnnotation requests the email with the link to update the password to be sent to the user.
+	// The annotation is removed by the operator after the email is sent.
+	SendResetPasswordEmailAnnotation = "edp.epam.com/send-reset-password-email"
+
+	// SendVerifyEmailAnnotation requests the email with the link to verify the email address to be sent to the user.
+	// The annotation is removed by the operator after the email is sent.
+	SendVerifyEmailAnnotation = "edp.epam.com/send-verify-email"
+
+	// RemoveCredentialsAnnotation requests the credentials of the user to be removed.
+	// The value is a comma-separated list of the credential types, e.g. otp,webauthn,
+	// true removes the second factor credentials otp, webauthn and webauthn-passwordless.
+	// The annotation is removed by the operator after the credentials are removed.
+	RemoveCredentialsAnnotation = "edp.epam.com/remove-credentials"
+
+	// ResetCredentialsAnnotation requests the credentials of the user to be removed and set up again
+	// by the user on the next login, e.g. otp requires the user to configure OTP.
+	// The value is the same as the value of the RemoveCredentialsAnnotation.
+	// The annotation is removed by the operator after the credentials are reset.
+	ResetCredentialsAnnotation = "edp.epam.com/reset-credentials"
+
+	// ListCredentialsAnnotation requests the credentials of the user to be listed in the status.
+	// The annotation is removed by the operator after the credentials are listed.
+	ListCredentialsAnnotation = "edp.epam.com/list-credentials"
+
+	// DeletionPolicyDelete deletes the keycloak user when the custom resource is deleted.
+	DeletionPolicyDelete = "Delete"
+
+	// DeletionPolicyRetain keeps the keycloak user when the custom resource is deleted.
+	DeletionPolicyRetain = "Retain"
+)
+
 // KeycloakRealmUserSpec defines the desired state of KeycloakRealmUser.
 type KeycloakRealmUserSpec struct {
-	Realm    string `json:"realm"`
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// ClusterRealm is a name of the ClusterKeycloakRealm custom resource the user belongs to.
+	// It is used instead of the realm if it is set.
+	// +optional
+	ClusterRealm string `json:"clusterRealm,omitempty"`
+
 	Username string `json:"username"`
 
 	// +optional
@@ -22,19 +78,42 @@ type KeycloakRealmUserSpec struct {
 	// +optional
 	EmailVerified bool `json:"emailVerified,omitempty"`
 
-	// RequiredUserActions is required action when user log in, example: CONFIGURE_TOTP, UPDATE_PASSWORD, UPDATE_PROFILE, VERIFY_EMAIL
+	// RequiredUserActions is required action when user log in, example: CONFIGURE_TOTP, UPDATE_PASSWORD, UPDATE_PROFILE, VERIFY_EMAIL.
+	// The actions are required when the user is created. The actions which are added to the spec later
+	// are added to the existing user once, keycloak removes them when the user completes them.
 	// +nullable
 	// +optional
 	RequiredUserActions []string `json:"requiredUserActions,omitempty"`
 
+	// Roles is a list of realm roles assigned to the user.
+	// Roles which are not declared are removed unless the addOnly reconciliation strategy is used,
+	// the realm default role default-roles-<realm> is kept.
 	// +nullable
 	// +optional
 	Roles []string `json:"roles,omitempty"`
 
+	// ClientRoles is a list of client roles assigned to the user.
+	// Roles which are not declared are removed unless the addOnly reconciliation strategy is used.
+	// The client roles are not managed if the list is not set, an empty list removes all of them.
+	// +nullable
+	// +optional
+	ClientRoles []ClientRole `json:"clientRoles"`
+
+	// Groups is a list of groups the user is a member of.
+	// Top-level groups are referred by the name, nested groups by the full path, e.g. /parent/child.
 	// +nullable
 	// +optional
 	Groups []string `json:"groups,omitempty"`
 
+	// PruneGroups removes the user from the groups which are not declared in the spec.
+	// +optional
+	PruneGroups bool `json:"pruneGroups,omitempty"`
+
+	// Attributes is a map of the user attributes.
+	// Attributes which are not declared are removed unless the addOnly reconciliation strategy is used.
+	// On keycloak 24+ the attributes must be declared in the realm user profile
+	// unless unmanaged attributes are enabled or editable by the admins,
+	// the undeclared attributes are reported in the status.
 	// +nullable
 	// +optional
 	Attributes map[string]string `json:"attributes,omitempty"`
@@ -42,11 +121,38 @@ type KeycloakRealmUserSpec struct {
 	// +optional
 	ReconciliationStrategy string `json:"reconciliationStrategy,omitempty"`
 
+	// Password is a plaintext password of the user.
+	// Deprecated: use PasswordSecret instead, plaintext passwords are rejected by the admission webhook.
 	// +optional
 	Password string `json:"password,omitempty"`
 
+	// PasswordSecret is a reference to the secret key with the password of the user.
+	// The password is set when the user is created.
+	// +optional
+	PasswordSecret *SecretKeyRef `json:"passwordSecret,omitempty"`
+
+	// TemporaryPassword defines whether the user must change the initial password on the first login.
+	// Defaults to true.
+	// +optional
+	TemporaryPassword *bool `json:"temporaryPassword,omitempty"`
+
+	// GeneratePassword generates a password for the user if the secret referenced by PasswordSecret
+	// doesn't exist or doesn't contain the key. The generated password is set for the new and the existing user
+	// and then written to the secret.
+	// +optional
+	GeneratePassword bool `json:"generatePassword,omitempty"`
+
 	// +optional
 	KeepResource bool `json:"keepResource,omitempty"`
+
+	// DeletionPolicy defines whether the keycloak user is deleted when the custom resource is deleted.
+	// With the Retain policy the operator only stops managing the user.
+	// The policy is applied only if keepResource is true, otherwise the custom resource is removed
+	// right after the user is synced and the user is always kept.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 // KeycloakRealmUserStatus defines the observed state of KeycloakRealmUser.
@@ -56,6 +162,22 @@ type KeycloakRealmUserStatus struct {
 
 	// +optional
 	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// RequiredUserActions is a list of the required actions which are applied to the user.
+	// +nullable
+	// +optional
+	RequiredUserActions []string `json:"requiredUserActions,omitempty"`
+
+	// UndeclaredAttributes is a list of the attributes which are not declared in the realm user profile
+	// and can not be set by the operator.
+	// +nullable
+	// +optional
+	UndeclaredAttributes []string `json:"undeclaredAttributes,omitempty"`
+
+	// Credentials is a list of the credentials of the user listed on the last list credentials request.
+	// +nullable
+	// +optional
+	Credentials []string `json:"credentials,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -79,10 +201,35 @@ func (in *KeycloakRealmUser) GetReconciliationStrategy() string {
 	return in.Spec.ReconciliationStrategy
 }
 
+// IsUserRetained checks if the keycloak user must be kept when the custom resource is deleted.
+func (in *KeycloakRealmUser) IsUserRetained() bool {
+	return in.Spec.DeletionPolicy == DeletionPolicyRetain
+}
+
 func (in *KeycloakRealmUser) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
 	return in.Spec.Realm, nil
 }
 
+func (in *KeycloakRealmUser) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakRealmUser) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakRealmUser) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
+func (in *KeycloakRealmUser) ClusterRealmName() string {
+	return in.Spec.ClusterRealm
+}
+
 func (in *KeycloakRealmUser) GetFailureCount() int64 {
 	return in.Status.FailureCount
 }
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the validating webhook of KeycloakRealmUser.
+func (in *KeycloakRealmUser) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).For(in).Complete(); err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmUser webhook: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-v1-edp-epam-com-v1-keycloakrealmuser,mutating=false,failurePolicy=fail,sideEffects=None,groups=v1.edp.epam.com,resources=keycloakrealmusers,verbs=create;update,versions=v1,name=vkeycloakrealmuser.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &KeycloakRealmUser{}
+
+// ValidateCreate rejects KeycloakRealmUser with plaintext credentials.
+func (in *KeycloakRealmUser) ValidateCreate() error {
+	return validateCredentials("KeycloakRealmUser", in.Name, in.credentialFields(), nil)
+}
+
+// ValidateUpdate rejects KeycloakRealmUser with new plaintext credentials.
+func (in *KeycloakRealmUser) ValidateUpdate(old runtime.Object) error {
+	var oldFields []credentialField
+	if o, ok := old.(*KeycloakRealmUser); ok {
+		oldFields = o.credentialFields()
+	}
+
+	return validateCredentials("KeycloakRealmUser", in.Name, in.credentialFields(), oldFields)
+}
+
+// ValidateDelete does nothing, deletion is always allowed.
+func (in *KeycloakRealmUser) ValidateDelete() error {
+	return nil
+}
+
+func (in *KeycloakRealmUser) credentialFields() []credentialField {
+	return []credentialField{{
+		path:  field.NewPath("spec", "password"),
+		value: in.Spec.Password,
+		hint:  "use spec.passwordSecret instead",
+	}}
+}
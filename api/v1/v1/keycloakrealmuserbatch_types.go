@@ -0,0 +1,187 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	UserBatchFormatJSON = "json"
+	UserBatchFormatCSV  = "csv"
+)
+
+// KeycloakRealmUserBatchSpec defines the desired state of KeycloakRealmUserBatch.
+type KeycloakRealmUserBatchSpec struct {
+	// Realm is name of KeycloakRealm custom resource.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// Source is a reference to the ConfigMap or Secret key with the users.
+	// Passwords are accepted only from a Secret.
+	Source UserBatchSource `json:"source"`
+
+	// Format is a format of the users data.
+	// The json format is a list of user objects, the csv format is a table with a header row.
+	// Columns of the csv table are username, email, firstName, lastName, enabled, emailVerified,
+	// groups, roles, requiredUserActions, password and temporaryPassword, list values are separated by semicolon.
+	// Columns with the attributes. prefix are set as user attributes.
+	// +kubebuilder:validation:Enum=json;csv
+	// +kubebuilder:default=json
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// Concurrency is a max number of users which are synced concurrently.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +kubebuilder:default=5
+	// +optional
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// ReconciliationStrategy is a strategy of the user roles, groups and attributes reconciliation.
+	// +kubebuilder:validation:Enum=full;addOnly
+	// +optional
+	ReconciliationStrategy string `json:"reconciliationStrategy,omitempty"`
+}
+
+// UserBatchSource is a reference to the users data. Exactly one of the references must be set.
+type UserBatchSource struct {
+	// +nullable
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeyRef `json:"configMapKeyRef,omitempty"`
+
+	// +nullable
+	// +optional
+	SecretKeyRef *SecretKeyRef `json:"secretKeyRef,omitempty"`
+}
+
+// KeycloakRealmUserBatchStatus defines the observed state of KeycloakRealmUserBatch.
+type KeycloakRealmUserBatchStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+
+	// SourceHash is a hash of the last imported users data and import settings.
+	// The users are not synced again until the data or the settings change.
+	// +optional
+	SourceHash string `json:"sourceHash,omitempty"`
+
+	// Created is a number of users created by the last import.
+	// +optional
+	Created int `json:"created,omitempty"`
+
+	// Updated is a number of existing users updated by the last import.
+	// +optional
+	Updated int `json:"updated,omitempty"`
+
+	// Failed is a number of users which failed to sync during the last import.
+	// +optional
+	Failed int `json:"failed,omitempty"`
+
+	// FailedUsers contains the errors of the users which failed to sync.
+	// +nullable
+	// +optional
+	FailedUsers []BatchUserFailure `json:"failedUsers,omitempty"`
+}
+
+type BatchUserFailure struct {
+	// Username is a name of the user.
+	Username string `json:"username"`
+
+	// Error is the error which occurred while the user was synced.
+	Error string `json:"error"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// KeycloakRealmUserBatch is the Schema for the keycloakrealmuserbatches API.
+type KeycloakRealmUserBatch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakRealmUserBatchSpec   `json:"spec,omitempty"`
+	Status KeycloakRealmUserBatchStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakRealmUserBatch) GetReconciliationStrategy() string {
+	if in.Spec.ReconciliationStrategy == "" {
+		return ReconciliationStrategyFull
+	}
+
+	return in.Spec.ReconciliationStrategy
+}
+
+func (in *KeycloakRealmUserBatch) GetFormat() string {
+	if in.Spec.Format == "" {
+		return UserBatchFormatJSON
+	}
+
+	return in.Spec.Format
+}
+
+func (in *KeycloakRealmUserBatch) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakRealmUserBatch) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakRealmUserBatch) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakRealmUserBatch) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
+func (in *KeycloakRealmUserBatch) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakRealmUserBatch) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakRealmUserBatch) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRealmUserBatch) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakRealmUserBatchList contains a list of KeycloakRealmUserBatch.
+type KeycloakRealmUserBatchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakRealmUserBatch `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakRealmUserBatch{}, &KeycloakRealmUserBatchList{})
+}
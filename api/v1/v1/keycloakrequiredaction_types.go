@@ -0,0 +1,128 @@
+package v1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// KeycloakRequiredActionSpec defines the desired state of KeycloakRequiredAction.
+type KeycloakRequiredActionSpec struct {
+	// Realm is a name of the KeycloakRealm custom resource the required action belongs to.
+	// +optional
+	Realm string `json:"realm,omitempty"`
+
+	// KeycloakRef is an explicit reference to the Keycloak the resource is created in.
+	// The Keycloak of the realm is used if it is not set.
+	// +nullable
+	// +optional
+	KeycloakRef *KeycloakRef `json:"keycloakRef,omitempty"`
+
+	// RealmNamespace is a namespace of the realm custom resource if it differs from the namespace of the resource.
+	// The realm must allow the namespace of the resource with the edp.epam.com/allowed-namespaces annotation.
+	// +optional
+	RealmNamespace string `json:"realmNamespace,omitempty"`
+
+	// RealmSelector selects the KeycloakRealm by the labels instead of the realm name, exactly one realm must match.
+	// The realm is selected in the realmNamespace or in the namespace of the resource.
+	// +nullable
+	// +optional
+	RealmSelector *metav1.LabelSelector `json:"realmSelector,omitempty"`
+
+	// Alias is an alias of the required action, e.g. CONFIGURE_TOTP.
+	// For the custom required actions it is the provider id, the action is registered if it is not registered yet.
+	// +kubebuilder:validation:MinLength=1
+	Alias string `json:"alias"`
+
+	// Name is a display name of the required action, the keycloak one is kept if it is not set.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Enabled defines whether the required action is enabled.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// DefaultAction defines whether the required action is assigned to the new users.
+	// +optional
+	DefaultAction bool `json:"defaultAction,omitempty"`
+
+	// Priority defines the order of the required action, actions with lower priority are executed first.
+	// The keycloak priority is kept if it is not set.
+	// +optional
+	Priority *int `json:"priority,omitempty"`
+
+	// Config is a config of the required action.
+	// +nullable
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// KeycloakRequiredActionStatus defines the observed state of KeycloakRequiredAction.
+type KeycloakRequiredActionStatus struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// +optional
+	FailureCount int64 `json:"failureCount,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// KeycloakRequiredAction is the Schema for the keycloakrequiredactions API.
+// The required action is disabled when the resource is deleted.
+type KeycloakRequiredAction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeycloakRequiredActionSpec   `json:"spec,omitempty"`
+	Status KeycloakRequiredActionStatus `json:"status,omitempty"`
+}
+
+func (in *KeycloakRequiredAction) GetFailureCount() int64 {
+	return in.Status.FailureCount
+}
+
+func (in *KeycloakRequiredAction) SetFailureCount(count int64) {
+	in.Status.FailureCount = count
+}
+
+func (in *KeycloakRequiredAction) GetStatus() string {
+	return in.Status.Value
+}
+
+func (in *KeycloakRequiredAction) SetStatus(value string) {
+	in.Status.Value = value
+}
+
+func (in *KeycloakRequiredAction) K8SParentRealmName() (string, error) {
+	if in.Spec.Realm == "" {
+		return "", ErrRealmNotSet
+	}
+
+	return in.Spec.Realm, nil
+}
+
+func (in *KeycloakRequiredAction) GetKeycloakRef() *KeycloakRef {
+	return in.Spec.KeycloakRef
+}
+
+func (in *KeycloakRequiredAction) GetRealmNamespace() string {
+	return in.Spec.RealmNamespace
+}
+
+func (in *KeycloakRequiredAction) GetRealmSelector() *metav1.LabelSelector {
+	return in.Spec.RealmSelector
+}
+
+// +kubebuilder:object:root=true
+
+// KeycloakRequiredActionList contains a list of KeycloakRequiredAction.
+type KeycloakRequiredActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KeycloakRequiredAction `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KeycloakRequiredAction{}, &KeycloakRequiredActionList{})
+}
@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrRealmNotSet is returned by the realm children which do not reference the realm by the name.
+var ErrRealmNotSet = errors.New("realm is not set")
+
+// AllowedNamespacesAnnotation is a comma separated list of the namespaces whose resources can reference the realm
+// from another namespace, * allows all namespaces. The cross namespace references are rejected if it is not set.
+const AllowedNamespacesAnnotation = "edp.epam.com/allowed-namespaces"
+
+// IsNamespaceAllowed checks whether the resources from the namespace can reference the realm.
+// The resources from the namespace of the realm are always allowed.
+func (in *KeycloakRealm) IsNamespaceAllowed(namespace string) bool {
+	if namespace == in.Namespace {
+		return true
+	}
+
+	for _, ns := range strings.Split(in.GetAnnotations()[AllowedNamespacesAnnotation], ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "*" || ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,17 @@
+package v1
+
+// ConditionReconciled is a type of the condition which shows whether the last reconciliation
+// applied the custom resource to keycloak.
+const ConditionReconciled = "Reconciled"
+
+// Reasons of the Reconciled condition, the reasons of the failure are the kinds of the keycloak errors.
+const (
+	ReasonSucceeded    = "Succeeded"
+	ReasonNotFound     = "NotFound"
+	ReasonConflict     = "Conflict"
+	ReasonUnauthorized = "Unauthorized"
+	ReasonRateLimited  = "RateLimited"
+	// ReasonExecutionOrderDrift is the reason if the declared order of the auth flow executions can not be applied.
+	ReasonExecutionOrderDrift = "ExecutionOrderDrift"
+	ReasonFailed              = "Failed"
+)
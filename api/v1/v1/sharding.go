@@ -0,0 +1,6 @@
+package v1
+
+// FinalizerOwnerAnnotation is the watch label selector of the operator instance which owns the finalizer
+// of the custom resource, so the operator instances with the overlapping selectors do not handle
+// the deletion of the same resource. It is not set for the resources of the not sharded operator.
+const FinalizerOwnerAnnotation = "edp.epam.com/finalizer-owner"
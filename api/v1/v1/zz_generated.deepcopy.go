@@ -6,9 +6,56 @@
 package v1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedClientScopesPolicy) DeepCopyInto(out *AllowedClientScopesPolicy) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowDefaultScopes != nil {
+		in, out := &in.AllowDefaultScopes, &out.AllowDefaultScopes
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedClientScopesPolicy.
+func (in *AllowedClientScopesPolicy) DeepCopy() *AllowedClientScopesPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedClientScopesPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedProtocolMappersPolicy) DeepCopyInto(out *AllowedProtocolMappersPolicy) {
+	*out = *in
+	if in.ProtocolMapperTypes != nil {
+		in, out := &in.ProtocolMapperTypes, &out.ProtocolMapperTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedProtocolMappersPolicy.
+func (in *AllowedProtocolMappersPolicy) DeepCopy() *AllowedProtocolMappersPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedProtocolMappersPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticationExecution) DeepCopyInto(out *AuthenticationExecution) {
 	*out = *in
@@ -52,281 +99,477 @@ func (in *AuthenticatorConfig) DeepCopy() *AuthenticatorConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BatchRole) DeepCopyInto(out *BatchRole) {
+func (in *AuthorizationPermission) DeepCopyInto(out *AuthorizationPermission) {
 	*out = *in
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Composites != nil {
-		in, out := &in.Composites, &out.Composites
-		*out = make([]Composite, len(*in))
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchRole.
-func (in *BatchRole) DeepCopy() *BatchRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationPermission.
+func (in *AuthorizationPermission) DeepCopy() *AuthorizationPermission {
 	if in == nil {
 		return nil
 	}
-	out := new(BatchRole)
+	out := new(AuthorizationPermission)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClientRole) DeepCopyInto(out *ClientRole) {
+func (in *AuthorizationPolicy) DeepCopyInto(out *AuthorizationPolicy) {
 	*out = *in
 	if in.Roles != nil {
 		in, out := &in.Roles, &out.Roles
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientRole.
-func (in *ClientRole) DeepCopy() *ClientRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationPolicy.
+func (in *AuthorizationPolicy) DeepCopy() *AuthorizationPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ClientRole)
+	out := new(AuthorizationPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Composite) DeepCopyInto(out *Composite) {
+func (in *AuthorizationResource) DeepCopyInto(out *AuthorizationResource) {
 	*out = *in
+	if in.URIs != nil {
+		in, out := &in.URIs, &out.URIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Composite.
-func (in *Composite) DeepCopy() *Composite {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationResource.
+func (in *AuthorizationResource) DeepCopy() *AuthorizationResource {
 	if in == nil {
 		return nil
 	}
-	out := new(Composite)
+	out := new(AuthorizationResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IdentityProviderMapper) DeepCopyInto(out *IdentityProviderMapper) {
+func (in *BatchRole) DeepCopyInto(out *BatchRole) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string]string, len(*in))
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string][]string, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
 		}
 	}
+	if in.Composites != nil {
+		in, out := &in.Composites, &out.Composites
+		*out = make([]Composite, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderMapper.
-func (in *IdentityProviderMapper) DeepCopy() *IdentityProviderMapper {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchRole.
+func (in *BatchRole) DeepCopy() *BatchRole {
 	if in == nil {
 		return nil
 	}
-	out := new(IdentityProviderMapper)
+	out := new(BatchRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Keycloak) DeepCopyInto(out *Keycloak) {
+func (in *BatchRoleStatus) DeepCopyInto(out *BatchRoleStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keycloak.
-func (in *Keycloak) DeepCopy() *Keycloak {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchRoleStatus.
+func (in *BatchRoleStatus) DeepCopy() *BatchRoleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Keycloak)
+	out := new(BatchRoleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Keycloak) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakAuthFlow) DeepCopyInto(out *KeycloakAuthFlow) {
+func (in *BatchUserFailure) DeepCopyInto(out *BatchUserFailure) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlow.
-func (in *KeycloakAuthFlow) DeepCopy() *KeycloakAuthFlow {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchUserFailure.
+func (in *BatchUserFailure) DeepCopy() *BatchUserFailure {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakAuthFlow)
+	out := new(BatchUserFailure)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakAuthFlow) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakAuthFlowList) DeepCopyInto(out *KeycloakAuthFlowList) {
+func (in *ClientAuthorization) DeepCopyInto(out *ClientAuthorization) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakAuthFlow, len(*in))
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]AuthorizationResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]AuthorizationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]AuthorizationPermission, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowList.
-func (in *KeycloakAuthFlowList) DeepCopy() *KeycloakAuthFlowList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientAuthorization.
+func (in *ClientAuthorization) DeepCopy() *ClientAuthorization {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakAuthFlowList)
+	out := new(ClientAuthorization)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakAuthFlowList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientOutputSecret) DeepCopyInto(out *ClientOutputSecret) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientOutputSecret.
+func (in *ClientOutputSecret) DeepCopy() *ClientOutputSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientOutputSecret)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakAuthFlowSpec) DeepCopyInto(out *KeycloakAuthFlowSpec) {
+func (in *ClientPolicy) DeepCopyInto(out *ClientPolicy) {
 	*out = *in
-	if in.AuthenticationExecutions != nil {
-		in, out := &in.AuthenticationExecutions, &out.AuthenticationExecutions
-		*out = make([]AuthenticationExecution, len(*in))
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClientPolicyCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowSpec.
-func (in *KeycloakAuthFlowSpec) DeepCopy() *KeycloakAuthFlowSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientPolicy.
+func (in *ClientPolicy) DeepCopy() *ClientPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakAuthFlowSpec)
+	out := new(ClientPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakAuthFlowStatus) DeepCopyInto(out *KeycloakAuthFlowStatus) {
+func (in *ClientPolicyCondition) DeepCopyInto(out *ClientPolicyCondition) {
 	*out = *in
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowStatus.
-func (in *KeycloakAuthFlowStatus) DeepCopy() *KeycloakAuthFlowStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientPolicyCondition.
+func (in *ClientPolicyCondition) DeepCopy() *ClientPolicyCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakAuthFlowStatus)
+	out := new(ClientPolicyCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClient) DeepCopyInto(out *KeycloakClient) {
+func (in *ClientPolicyExecutor) DeepCopyInto(out *ClientPolicyExecutor) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClient.
-func (in *KeycloakClient) DeepCopy() *KeycloakClient {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientPolicyExecutor.
+func (in *ClientPolicyExecutor) DeepCopy() *ClientPolicyExecutor {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClient)
+	out := new(ClientPolicyExecutor)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakClient) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientList) DeepCopyInto(out *KeycloakClientList) {
+func (in *ClientProfile) DeepCopyInto(out *ClientProfile) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakClient, len(*in))
+	if in.Executors != nil {
+		in, out := &in.Executors, &out.Executors
+		*out = make([]ClientPolicyExecutor, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientList.
-func (in *KeycloakClientList) DeepCopy() *KeycloakClientList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientProfile.
+func (in *ClientProfile) DeepCopy() *ClientProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientList)
+	out := new(ClientProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakClientList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientRegistrationPolicies) DeepCopyInto(out *ClientRegistrationPolicies) {
+	*out = *in
+	if in.Anonymous != nil {
+		in, out := &in.Anonymous, &out.Anonymous
+		*out = make([]ClientRegistrationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Authenticated != nil {
+		in, out := &in.Authenticated, &out.Authenticated
+		*out = make([]ClientRegistrationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientRegistrationPolicies.
+func (in *ClientRegistrationPolicies) DeepCopy() *ClientRegistrationPolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientRegistrationPolicies)
+	in.DeepCopyInto(out)
+	return out
+}
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientScope) DeepCopyInto(out *KeycloakClientScope) {
+func (in *ClientRegistrationPolicy) DeepCopyInto(out *ClientRegistrationPolicy) {
+	*out = *in
+	if in.TrustedHosts != nil {
+		in, out := &in.TrustedHosts, &out.TrustedHosts
+		*out = new(TrustedHostsPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedProtocolMappers != nil {
+		in, out := &in.AllowedProtocolMappers, &out.AllowedProtocolMappers
+		*out = new(AllowedProtocolMappersPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedClientScopes != nil {
+		in, out := &in.AllowedClientScopes, &out.AllowedClientScopes
+		*out = new(AllowedClientScopesPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxClients != nil {
+		in, out := &in.MaxClients, &out.MaxClients
+		*out = new(MaxClientsPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientRegistrationPolicy.
+func (in *ClientRegistrationPolicy) DeepCopy() *ClientRegistrationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientRegistrationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientRole) DeepCopyInto(out *ClientRole) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientRole.
+func (in *ClientRole) DeepCopy() *ClientRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientSessionsConfig) DeepCopyInto(out *ClientSessionsConfig) {
+	*out = *in
+	if in.AccessTokenLifespan != nil {
+		in, out := &in.AccessTokenLifespan, &out.AccessTokenLifespan
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClientSessionIdleTimeout != nil {
+		in, out := &in.ClientSessionIdleTimeout, &out.ClientSessionIdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClientSessionMaxLifespan != nil {
+		in, out := &in.ClientSessionMaxLifespan, &out.ClientSessionMaxLifespan
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClientOfflineSessionIdleTimeout != nil {
+		in, out := &in.ClientOfflineSessionIdleTimeout, &out.ClientOfflineSessionIdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClientOfflineSessionMaxLifespan != nil {
+		in, out := &in.ClientOfflineSessionMaxLifespan, &out.ClientOfflineSessionMaxLifespan
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.UseRefreshTokens != nil {
+		in, out := &in.UseRefreshTokens, &out.UseRefreshTokens
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseRefreshTokenForClientCredentials != nil {
+		in, out := &in.UseRefreshTokenForClientCredentials, &out.UseRefreshTokenForClientCredentials
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientSessionsConfig.
+func (in *ClientSessionsConfig) DeepCopy() *ClientSessionsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientSessionsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKeycloakRealm) DeepCopyInto(out *ClusterKeycloakRealm) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -334,18 +577,18 @@ func (in *KeycloakClientScope) DeepCopyInto(out *KeycloakClientScope) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScope.
-func (in *KeycloakClientScope) DeepCopy() *KeycloakClientScope {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakRealm.
+func (in *ClusterKeycloakRealm) DeepCopy() *ClusterKeycloakRealm {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientScope)
+	out := new(ClusterKeycloakRealm)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakClientScope) DeepCopyObject() runtime.Object {
+func (in *ClusterKeycloakRealm) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -353,31 +596,31 @@ func (in *KeycloakClientScope) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientScopeList) DeepCopyInto(out *KeycloakClientScopeList) {
+func (in *ClusterKeycloakRealmList) DeepCopyInto(out *ClusterKeycloakRealmList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakClientScope, len(*in))
+		*out = make([]ClusterKeycloakRealm, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeList.
-func (in *KeycloakClientScopeList) DeepCopy() *KeycloakClientScopeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakRealmList.
+func (in *ClusterKeycloakRealmList) DeepCopy() *ClusterKeycloakRealmList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientScopeList)
+	out := new(ClusterKeycloakRealmList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakClientScopeList) DeepCopyObject() runtime.Object {
+func (in *ClusterKeycloakRealmList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -385,283 +628,356 @@ func (in *KeycloakClientScopeList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientScopeSpec) DeepCopyInto(out *KeycloakClientScopeSpec) {
+func (in *ClusterKeycloakRealmSpec) DeepCopyInto(out *ClusterKeycloakRealmSpec) {
 	*out = *in
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	out.KeycloakRef = in.KeycloakRef
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.ProtocolMappers != nil {
-		in, out := &in.ProtocolMappers, &out.ProtocolMappers
-		*out = make([]ProtocolMapper, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Themes != nil {
+		in, out := &in.Themes, &out.Themes
+		*out = new(RealmThemes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmEventConfig != nil {
+		in, out := &in.RealmEventConfig, &out.RealmEventConfig
+		*out = new(RealmEventConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordPolicies != nil {
+		in, out := &in.PasswordPolicies, &out.PasswordPolicies
+		*out = make([]PasswordPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.BruteForceProtection != nil {
+		in, out := &in.BruteForceProtection, &out.BruteForceProtection
+		*out = new(RealmBruteForceProtection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenSettings != nil {
+		in, out := &in.TokenSettings, &out.TokenSettings
+		*out = new(RealmTokenSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FrontendURL != nil {
+		in, out := &in.FrontendURL, &out.FrontendURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdminURL != nil {
+		in, out := &in.AdminURL, &out.AdminURL
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeSpec.
-func (in *KeycloakClientScopeSpec) DeepCopy() *KeycloakClientScopeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakRealmSpec.
+func (in *ClusterKeycloakRealmSpec) DeepCopy() *ClusterKeycloakRealmSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientScopeSpec)
+	out := new(ClusterKeycloakRealmSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientScopeStatus) DeepCopyInto(out *KeycloakClientScopeStatus) {
+func (in *ClusterKeycloakRealmStatus) DeepCopyInto(out *ClusterKeycloakRealmStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeStatus.
-func (in *KeycloakClientScopeStatus) DeepCopy() *KeycloakClientScopeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKeycloakRealmStatus.
+func (in *ClusterKeycloakRealmStatus) DeepCopy() *ClusterKeycloakRealmStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientScopeStatus)
+	out := new(ClusterKeycloakRealmStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientSpec) DeepCopyInto(out *KeycloakClientSpec) {
+func (in *Composite) DeepCopyInto(out *Composite) {
 	*out = *in
-	if in.RealmRoles != nil {
-		in, out := &in.RealmRoles, &out.RealmRoles
-		*out = new([]RealmRole)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]RealmRole, len(*in))
-			copy(*out, *in)
-		}
-	}
-	if in.Protocol != nil {
-		in, out := &in.Protocol, &out.Protocol
-		*out = new(string)
-		**out = **in
-	}
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.ClientRoles != nil {
-		in, out := &in.ClientRoles, &out.ClientRoles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Composite.
+func (in *Composite) DeepCopy() *Composite {
+	if in == nil {
+		return nil
 	}
-	if in.ProtocolMappers != nil {
-		in, out := &in.ProtocolMappers, &out.ProtocolMappers
-		*out = new([]ProtocolMapper)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]ProtocolMapper, len(*in))
-			for i := range *in {
-				(*in)[i].DeepCopyInto(&(*out)[i])
-			}
-		}
+	out := new(Composite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigCliFile) DeepCopyInto(out *ConfigCliFile) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeyRef)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ServiceAccount != nil {
-		in, out := &in.ServiceAccount, &out.ServiceAccount
-		*out = new(ServiceAccount)
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(SecretKeyRef)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.DefaultClientScopes != nil {
-		in, out := &in.DefaultClientScopes, &out.DefaultClientScopes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigCliFile.
+func (in *ConfigCliFile) DeepCopy() *ConfigCliFile {
+	if in == nil {
+		return nil
 	}
+	out := new(ConfigCliFile)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientSpec.
-func (in *KeycloakClientSpec) DeepCopy() *KeycloakClientSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigCliManaged) DeepCopyInto(out *ConfigCliManaged) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigCliManaged.
+func (in *ConfigCliManaged) DeepCopy() *ConfigCliManaged {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientSpec)
+	out := new(ConfigCliManaged)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakClientStatus) DeepCopyInto(out *KeycloakClientStatus) {
+func (in *ConfigCliManagedResource) DeepCopyInto(out *ConfigCliManagedResource) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientStatus.
-func (in *KeycloakClientStatus) DeepCopy() *KeycloakClientStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigCliManagedResource.
+func (in *ConfigCliManagedResource) DeepCopy() *ConfigCliManagedResource {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakClientStatus)
+	out := new(ConfigCliManagedResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakComponentSpec) DeepCopyInto(out *KeycloakComponentSpec) {
+func (in *ConfigCliVarSubstitution) DeepCopyInto(out *ConfigCliVarSubstitution) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string][]string, len(*in))
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
+			(*out)[key] = val
 		}
 	}
+	if in.VariablesFrom != nil {
+		in, out := &in.VariablesFrom, &out.VariablesFrom
+		*out = make([]ConfigCliVariablesSource, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponentSpec.
-func (in *KeycloakComponentSpec) DeepCopy() *KeycloakComponentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigCliVarSubstitution.
+func (in *ConfigCliVarSubstitution) DeepCopy() *ConfigCliVarSubstitution {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakComponentSpec)
+	out := new(ConfigCliVarSubstitution)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakComponentStatus) DeepCopyInto(out *KeycloakComponentStatus) {
+func (in *ConfigCliVariablesSource) DeepCopyInto(out *ConfigCliVariablesSource) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponentStatus.
-func (in *KeycloakComponentStatus) DeepCopy() *KeycloakComponentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigCliVariablesSource.
+func (in *ConfigCliVariablesSource) DeepCopy() *ConfigCliVariablesSource {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakComponentStatus)
+	out := new(ConfigCliVariablesSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakList) DeepCopyInto(out *KeycloakList) {
+func (in *ConfigMapKeyRef) DeepCopyInto(out *ConfigMapKeyRef) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Keycloak, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakList.
-func (in *KeycloakList) DeepCopy() *KeycloakList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyRef.
+func (in *ConfigMapKeyRef) DeepCopy() *ConfigMapKeyRef {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakList)
+	out := new(ConfigMapKeyRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultRoles) DeepCopyInto(out *DefaultRoles) {
+	*out = *in
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	return nil
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultRoles.
+func (in *DefaultRoles) DeepCopy() *DefaultRoles {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultRoles)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealm) DeepCopyInto(out *KeycloakRealm) {
+func (in *GitHubIdentityProviderConfig) DeepCopyInto(out *GitHubIdentityProviderConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealm.
-func (in *KeycloakRealm) DeepCopy() *KeycloakRealm {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubIdentityProviderConfig.
+func (in *GitHubIdentityProviderConfig) DeepCopy() *GitHubIdentityProviderConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealm)
+	out := new(GitHubIdentityProviderConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealm) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabIdentityProviderConfig) DeepCopyInto(out *GitLabIdentityProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabIdentityProviderConfig.
+func (in *GitLabIdentityProviderConfig) DeepCopy() *GitLabIdentityProviderConfig {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(GitLabIdentityProviderConfig)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmComponent) DeepCopyInto(out *KeycloakRealmComponent) {
+func (in *GoogleIdentityProviderConfig) DeepCopyInto(out *GoogleIdentityProviderConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.UseUserIPParam != nil {
+		in, out := &in.UseUserIPParam, &out.UseUserIPParam
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OfflineAccess != nil {
+		in, out := &in.OfflineAccess, &out.OfflineAccess
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmComponent.
-func (in *KeycloakRealmComponent) DeepCopy() *KeycloakRealmComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleIdentityProviderConfig.
+func (in *GoogleIdentityProviderConfig) DeepCopy() *GoogleIdentityProviderConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmComponent)
+	out := new(GoogleIdentityProviderConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmComponent) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityProviderMapper) DeepCopyInto(out *IdentityProviderMapper) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderMapper.
+func (in *IdentityProviderMapper) DeepCopy() *IdentityProviderMapper {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityProviderMapper)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmComponentList) DeepCopyInto(out *KeycloakRealmComponentList) {
+func (in *KeyProviderRotation) DeepCopyInto(out *KeyProviderRotation) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmComponent, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.PassiveGenerations != nil {
+		in, out := &in.PassiveGenerations, &out.PassiveGenerations
+		*out = new(int)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmComponentList.
-func (in *KeycloakRealmComponentList) DeepCopy() *KeycloakRealmComponentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyProviderRotation.
+func (in *KeyProviderRotation) DeepCopy() *KeyProviderRotation {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmComponentList)
+	out := new(KeyProviderRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Keycloak) DeepCopyInto(out *Keycloak) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keycloak.
+func (in *Keycloak) DeepCopy() *Keycloak {
+	if in == nil {
+		return nil
+	}
+	out := new(Keycloak)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmComponentList) DeepCopyObject() runtime.Object {
+func (in *Keycloak) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -669,26 +985,26 @@ func (in *KeycloakRealmComponentList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmGroup) DeepCopyInto(out *KeycloakRealmGroup) {
+func (in *KeycloakAuthFlow) DeepCopyInto(out *KeycloakAuthFlow) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroup.
-func (in *KeycloakRealmGroup) DeepCopy() *KeycloakRealmGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlow.
+func (in *KeycloakAuthFlow) DeepCopy() *KeycloakAuthFlow {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmGroup)
+	out := new(KeycloakAuthFlow)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmGroup) DeepCopyObject() runtime.Object {
+func (in *KeycloakAuthFlow) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -696,31 +1012,31 @@ func (in *KeycloakRealmGroup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmGroupList) DeepCopyInto(out *KeycloakRealmGroupList) {
+func (in *KeycloakAuthFlowList) DeepCopyInto(out *KeycloakAuthFlowList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmGroup, len(*in))
+		*out = make([]KeycloakAuthFlow, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupList.
-func (in *KeycloakRealmGroupList) DeepCopy() *KeycloakRealmGroupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowList.
+func (in *KeycloakAuthFlowList) DeepCopy() *KeycloakAuthFlowList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmGroupList)
+	out := new(KeycloakAuthFlowList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmGroupList) DeepCopyObject() runtime.Object {
+func (in *KeycloakAuthFlowList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -728,95 +1044,80 @@ func (in *KeycloakRealmGroupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmGroupSpec) DeepCopyInto(out *KeycloakRealmGroupSpec) {
+func (in *KeycloakAuthFlowSpec) DeepCopyInto(out *KeycloakAuthFlowSpec) {
 	*out = *in
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
-	}
-	if in.Access != nil {
-		in, out := &in.Access, &out.Access
-		*out = make(map[string]bool, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.RealmRoles != nil {
-		in, out := &in.RealmRoles, &out.RealmRoles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.SubGroups != nil {
-		in, out := &in.SubGroups, &out.SubGroups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ClientRoles != nil {
-		in, out := &in.ClientRoles, &out.ClientRoles
-		*out = make([]ClientRole, len(*in))
+	if in.AuthenticationExecutions != nil {
+		in, out := &in.AuthenticationExecutions, &out.AuthenticationExecutions
+		*out = make([]AuthenticationExecution, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupSpec.
-func (in *KeycloakRealmGroupSpec) DeepCopy() *KeycloakRealmGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowSpec.
+func (in *KeycloakAuthFlowSpec) DeepCopy() *KeycloakAuthFlowSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmGroupSpec)
+	out := new(KeycloakAuthFlowSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmGroupStatus) DeepCopyInto(out *KeycloakRealmGroupStatus) {
+func (in *KeycloakAuthFlowStatus) DeepCopyInto(out *KeycloakAuthFlowStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupStatus.
-func (in *KeycloakRealmGroupStatus) DeepCopy() *KeycloakRealmGroupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakAuthFlowStatus.
+func (in *KeycloakAuthFlowStatus) DeepCopy() *KeycloakAuthFlowStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmGroupStatus)
+	out := new(KeycloakAuthFlowStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmIdentityProvider) DeepCopyInto(out *KeycloakRealmIdentityProvider) {
+func (in *KeycloakClient) DeepCopyInto(out *KeycloakClient) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProvider.
-func (in *KeycloakRealmIdentityProvider) DeepCopy() *KeycloakRealmIdentityProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClient.
+func (in *KeycloakClient) DeepCopy() *KeycloakClient {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmIdentityProvider)
+	out := new(KeycloakClient)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmIdentityProvider) DeepCopyObject() runtime.Object {
+func (in *KeycloakClient) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -824,31 +1125,31 @@ func (in *KeycloakRealmIdentityProvider) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmIdentityProviderList) DeepCopyInto(out *KeycloakRealmIdentityProviderList) {
+func (in *KeycloakClientList) DeepCopyInto(out *KeycloakClientList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmIdentityProvider, len(*in))
+		*out = make([]KeycloakClient, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderList.
-func (in *KeycloakRealmIdentityProviderList) DeepCopy() *KeycloakRealmIdentityProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientList.
+func (in *KeycloakClientList) DeepCopy() *KeycloakClientList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmIdentityProviderList)
+	out := new(KeycloakClientList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmIdentityProviderList) DeepCopyObject() runtime.Object {
+func (in *KeycloakClientList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -856,75 +1157,58 @@ func (in *KeycloakRealmIdentityProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmIdentityProviderSpec) DeepCopyInto(out *KeycloakRealmIdentityProviderSpec) {
+func (in *KeycloakClientRole) DeepCopyInto(out *KeycloakClientRole) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Mappers != nil {
-		in, out := &in.Mappers, &out.Mappers
-		*out = make([]IdentityProviderMapper, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderSpec.
-func (in *KeycloakRealmIdentityProviderSpec) DeepCopy() *KeycloakRealmIdentityProviderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientRole.
+func (in *KeycloakClientRole) DeepCopy() *KeycloakClientRole {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmIdentityProviderSpec)
+	out := new(KeycloakClientRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmIdentityProviderStatus) DeepCopyInto(out *KeycloakRealmIdentityProviderStatus) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderStatus.
-func (in *KeycloakRealmIdentityProviderStatus) DeepCopy() *KeycloakRealmIdentityProviderStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(KeycloakRealmIdentityProviderStatus)
-	in.DeepCopyInto(out)
-	return out
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakClientRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmList) DeepCopyInto(out *KeycloakRealmList) {
+func (in *KeycloakClientRoleList) DeepCopyInto(out *KeycloakClientRoleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealm, len(*in))
+		*out = make([]KeycloakClientRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmList.
-func (in *KeycloakRealmList) DeepCopy() *KeycloakRealmList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientRoleList.
+func (in *KeycloakClientRoleList) DeepCopy() *KeycloakClientRoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmList)
+	out := new(KeycloakClientRoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmList) DeepCopyObject() runtime.Object {
+func (in *KeycloakClientRoleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -932,85 +1216,106 @@ func (in *KeycloakRealmList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRole) DeepCopyInto(out *KeycloakRealmRole) {
+func (in *KeycloakClientRoleSpec) DeepCopyInto(out *KeycloakClientRoleSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Composites != nil {
+		in, out := &in.Composites, &out.Composites
+		*out = make([]Composite, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompositesClientRoles != nil {
+		in, out := &in.CompositesClientRoles, &out.CompositesClientRoles
+		*out = make(map[string][]Composite, len(*in))
+		for key, val := range *in {
+			var outVal []Composite
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]Composite, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRole.
-func (in *KeycloakRealmRole) DeepCopy() *KeycloakRealmRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientRoleSpec.
+func (in *KeycloakClientRoleSpec) DeepCopy() *KeycloakClientRoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRole)
+	out := new(KeycloakClientRoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmRole) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleBatch) DeepCopyInto(out *KeycloakRealmRoleBatch) {
+func (in *KeycloakClientRoleStatus) DeepCopyInto(out *KeycloakClientRoleStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.UnresolvedComposites != nil {
+		in, out := &in.UnresolvedComposites, &out.UnresolvedComposites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatch.
-func (in *KeycloakRealmRoleBatch) DeepCopy() *KeycloakRealmRoleBatch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientRoleStatus.
+func (in *KeycloakClientRoleStatus) DeepCopy() *KeycloakClientRoleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleBatch)
+	out := new(KeycloakClientRoleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmRoleBatch) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleBatchList) DeepCopyInto(out *KeycloakRealmRoleBatchList) {
+func (in *KeycloakClientScope) DeepCopyInto(out *KeycloakClientScope) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmRoleBatch, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchList.
-func (in *KeycloakRealmRoleBatchList) DeepCopy() *KeycloakRealmRoleBatchList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScope.
+func (in *KeycloakClientScope) DeepCopy() *KeycloakClientScope {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleBatchList)
+	out := new(KeycloakClientScope)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmRoleBatchList) DeepCopyObject() runtime.Object {
+func (in *KeycloakClientScope) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1018,68 +1323,31 @@ func (in *KeycloakRealmRoleBatchList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleBatchSpec) DeepCopyInto(out *KeycloakRealmRoleBatchSpec) {
-	*out = *in
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]BatchRole, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchSpec.
-func (in *KeycloakRealmRoleBatchSpec) DeepCopy() *KeycloakRealmRoleBatchSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(KeycloakRealmRoleBatchSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleBatchStatus) DeepCopyInto(out *KeycloakRealmRoleBatchStatus) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchStatus.
-func (in *KeycloakRealmRoleBatchStatus) DeepCopy() *KeycloakRealmRoleBatchStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(KeycloakRealmRoleBatchStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleList) DeepCopyInto(out *KeycloakRealmRoleList) {
+func (in *KeycloakClientScopeList) DeepCopyInto(out *KeycloakClientScopeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmRole, len(*in))
+		*out = make([]KeycloakClientScope, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleList.
-func (in *KeycloakRealmRoleList) DeepCopy() *KeycloakRealmRoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeList.
+func (in *KeycloakClientScopeList) DeepCopy() *KeycloakClientScopeList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleList)
+	out := new(KeycloakClientScopeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmRoleList) DeepCopyObject() runtime.Object {
+func (in *KeycloakClientScopeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1087,225 +1355,3540 @@ func (in *KeycloakRealmRoleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleSpec) DeepCopyInto(out *KeycloakRealmRoleSpec) {
+func (in *KeycloakClientScopeSpec) DeepCopyInto(out *KeycloakClientScopeSpec) {
 	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Attributes != nil {
 		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[string][]string, len(*in))
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
+			(*out)[key] = val
 		}
 	}
-	if in.Composites != nil {
-		in, out := &in.Composites, &out.Composites
-		*out = make([]Composite, len(*in))
-		copy(*out, *in)
+	if in.ProtocolMappers != nil {
+		in, out := &in.ProtocolMappers, &out.ProtocolMappers
+		*out = make([]ProtocolMapper, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleSpec.
-func (in *KeycloakRealmRoleSpec) DeepCopy() *KeycloakRealmRoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeSpec.
+func (in *KeycloakClientScopeSpec) DeepCopy() *KeycloakClientScopeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleSpec)
+	out := new(KeycloakClientScopeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmRoleStatus) DeepCopyInto(out *KeycloakRealmRoleStatus) {
+func (in *KeycloakClientScopeStatus) DeepCopyInto(out *KeycloakClientScopeStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleStatus.
-func (in *KeycloakRealmRoleStatus) DeepCopy() *KeycloakRealmRoleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientScopeStatus.
+func (in *KeycloakClientScopeStatus) DeepCopy() *KeycloakClientScopeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmRoleStatus)
+	out := new(KeycloakClientScopeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmSpec) DeepCopyInto(out *KeycloakRealmSpec) {
+func (in *KeycloakClientSpec) DeepCopyInto(out *KeycloakClientSpec) {
 	*out = *in
-	if in.SsoRealmEnabled != nil {
-		in, out := &in.SsoRealmEnabled, &out.SsoRealmEnabled
-		*out = new(bool)
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
 		**out = **in
 	}
-	if in.SsoAutoRedirectEnabled != nil {
-		in, out := &in.SsoAutoRedirectEnabled, &out.SsoAutoRedirectEnabled
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Users != nil {
-		in, out := &in.Users, &out.Users
-		*out = make([]User, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.SSORealmMappers != nil {
-		in, out := &in.SSORealmMappers, &out.SSORealmMappers
-		*out = new([]SSORealmMapper)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]SSORealmMapper, len(*in))
-			for i := range *in {
-				(*in)[i].DeepCopyInto(&(*out)[i])
-			}
-		}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.BrowserFlow != nil {
-		in, out := &in.BrowserFlow, &out.BrowserFlow
-		*out = new(string)
-		**out = **in
+	if in.SecretRotation != nil {
+		in, out := &in.SecretRotation, &out.SecretRotation
+		*out = new(SecretRotationPolicy)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Themes != nil {
-		in, out := &in.Themes, &out.Themes
-		*out = new(RealmThemes)
+	if in.OutputSecret != nil {
+		in, out := &in.OutputSecret, &out.OutputSecret
+		*out = new(ClientOutputSecret)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.BrowserSecurityHeaders != nil {
-		in, out := &in.BrowserSecurityHeaders, &out.BrowserSecurityHeaders
-		*out = new(map[string]string)
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = new([]RealmRole)
 		if **in != nil {
 			in, out := *in, *out
-			*out = make(map[string]string, len(*in))
-			for key, val := range *in {
-				(*out)[key] = val
-			}
+			*out = make([]RealmRole, len(*in))
+			copy(*out, *in)
 		}
 	}
-	if in.ID != nil {
-		in, out := &in.ID, &out.ID
+	if in.Public != nil {
+		in, out := &in.Public, &out.Public
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BaseURL != nil {
+		in, out := &in.BaseURL, &out.BaseURL
 		*out = new(string)
 		**out = **in
 	}
-	if in.RealmEventConfig != nil {
-		in, out := &in.RealmEventConfig, &out.RealmEventConfig
-		*out = new(RealmEventConfig)
-		(*in).DeepCopyInto(*out)
+	if in.AdminURL != nil {
+		in, out := &in.AdminURL, &out.AdminURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConsentRequired != nil {
+		in, out := &in.ConsentRequired, &out.ConsentRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AlwaysDisplayInConsole != nil {
+		in, out := &in.AlwaysDisplayInConsole, &out.AlwaysDisplayInConsole
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StandardFlowEnabled != nil {
+		in, out := &in.StandardFlowEnabled, &out.StandardFlowEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImplicitFlowEnabled != nil {
+		in, out := &in.ImplicitFlowEnabled, &out.ImplicitFlowEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BearerOnly != nil {
+		in, out := &in.BearerOnly, &out.BearerOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SurrogateAuthRequired != nil {
+		in, out := &in.SurrogateAuthRequired, &out.SurrogateAuthRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeReRegistrationTimeout != nil {
+		in, out := &in.NodeReRegistrationTimeout, &out.NodeReRegistrationTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RedirectURIsFrom != nil {
+		in, out := &in.RedirectURIsFrom, &out.RedirectURIsFrom
+		*out = make([]RedirectURISource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.SAML != nil {
+		in, out := &in.SAML, &out.SAML
+		*out = new(SAMLClientConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCClientConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sessions != nil {
+		in, out := &in.Sessions, &out.Sessions
+		*out = new(ClientSessionsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SignedJWT != nil {
+		in, out := &in.SignedJWT, &out.SignedJWT
+		*out = new(SignedJWTConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LoginTheme != nil {
+		in, out := &in.LoginTheme, &out.LoginTheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.DirectAccess != nil {
+		in, out := &in.DirectAccess, &out.DirectAccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtocolMappers != nil {
+		in, out := &in.ProtocolMappers, &out.ProtocolMappers
+		*out = new([]ProtocolMapper)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]ProtocolMapper, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FrontChannelLogout != nil {
+		in, out := &in.FrontChannelLogout, &out.FrontChannelLogout
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultClientScopes != nil {
+		in, out := &in.DefaultClientScopes, &out.DefaultClientScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FullScopeAllowed != nil {
+		in, out := &in.FullScopeAllowed, &out.FullScopeAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ScopeMappings != nil {
+		in, out := &in.ScopeMappings, &out.ScopeMappings
+		*out = new(ScopeMappings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OptionalClientScopes != nil {
+		in, out := &in.OptionalClientScopes, &out.OptionalClientScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(ClientAuthorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthorizationSettingsRef != nil {
+		in, out := &in.AuthorizationSettingsRef, &out.AuthorizationSettingsRef
+		*out = new(ConfigMapKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientSpec.
+func (in *KeycloakClientSpec) DeepCopy() *KeycloakClientSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientStatus) DeepCopyInto(out *KeycloakClientStatus) {
+	*out = *in
+	if in.SecretRotationTime != nil {
+		in, out := &in.SecretRotationTime, &out.SecretRotationTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AppliedDefaultClientScopes != nil {
+		in, out := &in.AppliedDefaultClientScopes, &out.AppliedDefaultClientScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedOptionalClientScopes != nil {
+		in, out := &in.AppliedOptionalClientScopes, &out.AppliedOptionalClientScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientStatus.
+func (in *KeycloakClientStatus) DeepCopy() *KeycloakClientStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakComponentSpec) DeepCopyInto(out *KeycloakComponentSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponentSpec.
+func (in *KeycloakComponentSpec) DeepCopy() *KeycloakComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakComponentStatus) DeepCopyInto(out *KeycloakComponentStatus) {
+	*out = *in
+	if in.LastSync != nil {
+		in, out := &in.LastSync, &out.LastSync
+		*out = new(UserStorageSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakComponentStatus.
+func (in *KeycloakComponentStatus) DeepCopy() *KeycloakComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakConfigCliImport) DeepCopyInto(out *KeycloakConfigCliImport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakConfigCliImport.
+func (in *KeycloakConfigCliImport) DeepCopy() *KeycloakConfigCliImport {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakConfigCliImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakConfigCliImport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakConfigCliImportList) DeepCopyInto(out *KeycloakConfigCliImportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakConfigCliImport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakConfigCliImportList.
+func (in *KeycloakConfigCliImportList) DeepCopy() *KeycloakConfigCliImportList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakConfigCliImportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakConfigCliImportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakConfigCliImportSpec) DeepCopyInto(out *KeycloakConfigCliImportSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]ConfigCliFile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VarSubstitution != nil {
+		in, out := &in.VarSubstitution, &out.VarSubstitution
+		*out = new(ConfigCliVarSubstitution)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Managed = in.Managed
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakConfigCliImportSpec.
+func (in *KeycloakConfigCliImportSpec) DeepCopy() *KeycloakConfigCliImportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakConfigCliImportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakConfigCliImportStatus) DeepCopyInto(out *KeycloakConfigCliImportStatus) {
+	*out = *in
+	if in.UnsupportedFields != nil {
+		in, out := &in.UnsupportedFields, &out.UnsupportedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedResources != nil {
+		in, out := &in.ManagedResources, &out.ManagedResources
+		*out = make([]ConfigCliManagedResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakConfigCliImportStatus.
+func (in *KeycloakConfigCliImportStatus) DeepCopy() *KeycloakConfigCliImportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakConfigCliImportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakHeadersSource) DeepCopyInto(out *KeycloakHeadersSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakHeadersSource.
+func (in *KeycloakHeadersSource) DeepCopy() *KeycloakHeadersSource {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakHeadersSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakIdentityProviderMapper) DeepCopyInto(out *KeycloakIdentityProviderMapper) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakIdentityProviderMapper.
+func (in *KeycloakIdentityProviderMapper) DeepCopy() *KeycloakIdentityProviderMapper {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakIdentityProviderMapper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakIdentityProviderMapper) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakIdentityProviderMapperList) DeepCopyInto(out *KeycloakIdentityProviderMapperList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakIdentityProviderMapper, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakIdentityProviderMapperList.
+func (in *KeycloakIdentityProviderMapperList) DeepCopy() *KeycloakIdentityProviderMapperList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakIdentityProviderMapperList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakIdentityProviderMapperList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakIdentityProviderMapperSpec) DeepCopyInto(out *KeycloakIdentityProviderMapperSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakIdentityProviderMapperSpec.
+func (in *KeycloakIdentityProviderMapperSpec) DeepCopy() *KeycloakIdentityProviderMapperSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakIdentityProviderMapperSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakIdentityProviderMapperStatus) DeepCopyInto(out *KeycloakIdentityProviderMapperStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakIdentityProviderMapperStatus.
+func (in *KeycloakIdentityProviderMapperStatus) DeepCopy() *KeycloakIdentityProviderMapperStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakIdentityProviderMapperStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakLDAPFederation) DeepCopyInto(out *KeycloakLDAPFederation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakLDAPFederation.
+func (in *KeycloakLDAPFederation) DeepCopy() *KeycloakLDAPFederation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakLDAPFederation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakLDAPFederation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakLDAPFederationList) DeepCopyInto(out *KeycloakLDAPFederationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakLDAPFederation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakLDAPFederationList.
+func (in *KeycloakLDAPFederationList) DeepCopy() *KeycloakLDAPFederationList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakLDAPFederationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakLDAPFederationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakLDAPFederationSpec) DeepCopyInto(out *KeycloakLDAPFederationSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BindCredential != nil {
+		in, out := &in.BindCredential, &out.BindCredential
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UserObjectClasses != nil {
+		in, out := &in.UserObjectClasses, &out.UserObjectClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(LDAPSyncSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakLDAPFederationSpec.
+func (in *KeycloakLDAPFederationSpec) DeepCopy() *KeycloakLDAPFederationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakLDAPFederationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakLDAPFederationStatus) DeepCopyInto(out *KeycloakLDAPFederationStatus) {
+	*out = *in
+	if in.LastSync != nil {
+		in, out := &in.LastSync, &out.LastSync
+		*out = new(UserStorageSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = new(metav1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakLDAPFederationStatus.
+func (in *KeycloakLDAPFederationStatus) DeepCopy() *KeycloakLDAPFederationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakLDAPFederationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakList) DeepCopyInto(out *KeycloakList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Keycloak, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakList.
+func (in *KeycloakList) DeepCopy() *KeycloakList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakOrganization) DeepCopyInto(out *KeycloakOrganization) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakOrganization.
+func (in *KeycloakOrganization) DeepCopy() *KeycloakOrganization {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakOrganization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakOrganization) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakOrganizationList) DeepCopyInto(out *KeycloakOrganizationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakOrganization, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakOrganizationList.
+func (in *KeycloakOrganizationList) DeepCopy() *KeycloakOrganizationList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakOrganizationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakOrganizationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakOrganizationSpec) DeepCopyInto(out *KeycloakOrganizationSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]OrganizationDomain, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.IdentityProviders != nil {
+		in, out := &in.IdentityProviders, &out.IdentityProviders
+		*out = make([]OrganizationIdentityProvider, len(*in))
+		copy(*out, *in)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakOrganizationSpec.
+func (in *KeycloakOrganizationSpec) DeepCopy() *KeycloakOrganizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakOrganizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakOrganizationStatus) DeepCopyInto(out *KeycloakOrganizationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakOrganizationStatus.
+func (in *KeycloakOrganizationStatus) DeepCopy() *KeycloakOrganizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakOrganizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakProxyAuth) DeepCopyInto(out *KeycloakProxyAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakProxyAuth.
+func (in *KeycloakProxyAuth) DeepCopy() *KeycloakProxyAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakProxyAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRateLimit) DeepCopyInto(out *KeycloakRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRateLimit.
+func (in *KeycloakRateLimit) DeepCopy() *KeycloakRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealm) DeepCopyInto(out *KeycloakRealm) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealm.
+func (in *KeycloakRealm) DeepCopy() *KeycloakRealm {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealm) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmComponent) DeepCopyInto(out *KeycloakRealmComponent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmComponent.
+func (in *KeycloakRealmComponent) DeepCopy() *KeycloakRealmComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmComponent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmComponentList) DeepCopyInto(out *KeycloakRealmComponentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmComponent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmComponentList.
+func (in *KeycloakRealmComponentList) DeepCopy() *KeycloakRealmComponentList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmComponentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmComponentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmEventConfig) DeepCopyInto(out *KeycloakRealmEventConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmEventConfig.
+func (in *KeycloakRealmEventConfig) DeepCopy() *KeycloakRealmEventConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmEventConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmEventConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmEventConfigList) DeepCopyInto(out *KeycloakRealmEventConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmEventConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmEventConfigList.
+func (in *KeycloakRealmEventConfigList) DeepCopy() *KeycloakRealmEventConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmEventConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmEventConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmEventConfigSpec) DeepCopyInto(out *KeycloakRealmEventConfigSpec) {
+	*out = *in
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.RealmEventConfig.DeepCopyInto(&out.RealmEventConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmEventConfigSpec.
+func (in *KeycloakRealmEventConfigSpec) DeepCopy() *KeycloakRealmEventConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmEventConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmEventConfigStatus) DeepCopyInto(out *KeycloakRealmEventConfigStatus) {
+	*out = *in
+	if in.Realms != nil {
+		in, out := &in.Realms, &out.Realms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkippedRealms != nil {
+		in, out := &in.SkippedRealms, &out.SkippedRealms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmEventConfigStatus.
+func (in *KeycloakRealmEventConfigStatus) DeepCopy() *KeycloakRealmEventConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmEventConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmGroup) DeepCopyInto(out *KeycloakRealmGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroup.
+func (in *KeycloakRealmGroup) DeepCopy() *KeycloakRealmGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmGroupList) DeepCopyInto(out *KeycloakRealmGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupList.
+func (in *KeycloakRealmGroupList) DeepCopy() *KeycloakRealmGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmGroupSpec) DeepCopyInto(out *KeycloakRealmGroupSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ParentGroup != nil {
+		in, out := &in.ParentGroup, &out.ParentGroup
+		*out = new(ParentGroup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubGroups != nil {
+		in, out := &in.SubGroups, &out.SubGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]ClientRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupSpec.
+func (in *KeycloakRealmGroupSpec) DeepCopy() *KeycloakRealmGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmGroupStatus) DeepCopyInto(out *KeycloakRealmGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmGroupStatus.
+func (in *KeycloakRealmGroupStatus) DeepCopy() *KeycloakRealmGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmIdentityProvider) DeepCopyInto(out *KeycloakRealmIdentityProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProvider.
+func (in *KeycloakRealmIdentityProvider) DeepCopy() *KeycloakRealmIdentityProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmIdentityProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmIdentityProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmIdentityProviderList) DeepCopyInto(out *KeycloakRealmIdentityProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmIdentityProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderList.
+func (in *KeycloakRealmIdentityProviderList) DeepCopy() *KeycloakRealmIdentityProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmIdentityProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmIdentityProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmIdentityProviderSpec) DeepCopyInto(out *KeycloakRealmIdentityProviderSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ClientSecretRef != nil {
+		in, out := &in.ClientSecretRef, &out.ClientSecretRef
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SAML != nil {
+		in, out := &in.SAML, &out.SAML
+		*out = new(SAMLIdentityProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(GitHubIdentityProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Google != nil {
+		in, out := &in.Google, &out.Google
+		*out = new(GoogleIdentityProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Microsoft != nil {
+		in, out := &in.Microsoft, &out.Microsoft
+		*out = new(MicrosoftIdentityProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(GitLabIdentityProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostBrokerLoginFlowAlias != nil {
+		in, out := &in.PostBrokerLoginFlowAlias, &out.PostBrokerLoginFlowAlias
+		*out = new(string)
+		**out = **in
+	}
+	if in.HideOnLoginPage != nil {
+		in, out := &in.HideOnLoginPage, &out.HideOnLoginPage
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GUIOrder != nil {
+		in, out := &in.GUIOrder, &out.GUIOrder
+		*out = new(int)
+		**out = **in
+	}
+	if in.Mappers != nil {
+		in, out := &in.Mappers, &out.Mappers
+		*out = make([]IdentityProviderMapper, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderSpec.
+func (in *KeycloakRealmIdentityProviderSpec) DeepCopy() *KeycloakRealmIdentityProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmIdentityProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmIdentityProviderStatus) DeepCopyInto(out *KeycloakRealmIdentityProviderStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmIdentityProviderStatus.
+func (in *KeycloakRealmIdentityProviderStatus) DeepCopy() *KeycloakRealmIdentityProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmIdentityProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmImport) DeepCopyInto(out *KeycloakRealmImport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmImport.
+func (in *KeycloakRealmImport) DeepCopy() *KeycloakRealmImport {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmImport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmImportList) DeepCopyInto(out *KeycloakRealmImportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmImport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmImportList.
+func (in *KeycloakRealmImportList) DeepCopy() *KeycloakRealmImportList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmImportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmImportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmImportSpec) DeepCopyInto(out *KeycloakRealmImportSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Representation != nil {
+		in, out := &in.Representation, &out.Representation
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(RealmImportSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmImportSpec.
+func (in *KeycloakRealmImportSpec) DeepCopy() *KeycloakRealmImportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmImportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmImportStatus) DeepCopyInto(out *KeycloakRealmImportStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmImportStatus.
+func (in *KeycloakRealmImportStatus) DeepCopy() *KeycloakRealmImportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmImportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmList) DeepCopyInto(out *KeycloakRealmList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmList.
+func (in *KeycloakRealmList) DeepCopy() *KeycloakRealmList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRole) DeepCopyInto(out *KeycloakRealmRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRole.
+func (in *KeycloakRealmRole) DeepCopy() *KeycloakRealmRole {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleBatch) DeepCopyInto(out *KeycloakRealmRoleBatch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatch.
+func (in *KeycloakRealmRoleBatch) DeepCopy() *KeycloakRealmRoleBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmRoleBatch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleBatchList) DeepCopyInto(out *KeycloakRealmRoleBatchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmRoleBatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchList.
+func (in *KeycloakRealmRoleBatchList) DeepCopy() *KeycloakRealmRoleBatchList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleBatchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmRoleBatchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleBatchSpec) DeepCopyInto(out *KeycloakRealmRoleBatchSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]BatchRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchSpec.
+func (in *KeycloakRealmRoleBatchSpec) DeepCopy() *KeycloakRealmRoleBatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleBatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleBatchStatus) DeepCopyInto(out *KeycloakRealmRoleBatchStatus) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]BatchRoleStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleBatchStatus.
+func (in *KeycloakRealmRoleBatchStatus) DeepCopy() *KeycloakRealmRoleBatchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleBatchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleList) DeepCopyInto(out *KeycloakRealmRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleList.
+func (in *KeycloakRealmRoleList) DeepCopy() *KeycloakRealmRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleSpec) DeepCopyInto(out *KeycloakRealmRoleSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Composites != nil {
+		in, out := &in.Composites, &out.Composites
+		*out = make([]Composite, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompositesClientRoles != nil {
+		in, out := &in.CompositesClientRoles, &out.CompositesClientRoles
+		*out = make(map[string][]Composite, len(*in))
+		for key, val := range *in {
+			var outVal []Composite
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]Composite, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleSpec.
+func (in *KeycloakRealmRoleSpec) DeepCopy() *KeycloakRealmRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmRoleStatus) DeepCopyInto(out *KeycloakRealmRoleStatus) {
+	*out = *in
+	if in.UnresolvedComposites != nil {
+		in, out := &in.UnresolvedComposites, &out.UnresolvedComposites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmRoleStatus.
+func (in *KeycloakRealmRoleStatus) DeepCopy() *KeycloakRealmRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmSpec) DeepCopyInto(out *KeycloakRealmSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminCredentials != nil {
+		in, out := &in.AdminCredentials, &out.AdminCredentials
+		*out = new(RealmAdminCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SsoRealmEnabled != nil {
+		in, out := &in.SsoRealmEnabled, &out.SsoRealmEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SsoAutoRedirectEnabled != nil {
+		in, out := &in.SsoAutoRedirectEnabled, &out.SsoAutoRedirectEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]User, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SSORealmMappers != nil {
+		in, out := &in.SSORealmMappers, &out.SSORealmMappers
+		*out = new([]SSORealmMapper)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]SSORealmMapper, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+	if in.BrowserFlow != nil {
+		in, out := &in.BrowserFlow, &out.BrowserFlow
+		*out = new(string)
+		**out = **in
+	}
+	if in.Themes != nil {
+		in, out := &in.Themes, &out.Themes
+		*out = new(RealmThemes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BrowserSecurityHeaders != nil {
+		in, out := &in.BrowserSecurityHeaders, &out.BrowserSecurityHeaders
+		*out = new(map[string]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]string, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
+	}
+	if in.SecurityDefenses != nil {
+		in, out := &in.SecurityDefenses, &out.SecurityDefenses
+		*out = new(RealmSecurityDefenses)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.RealmEventConfig != nil {
+		in, out := &in.RealmEventConfig, &out.RealmEventConfig
+		*out = new(RealmEventConfig)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PasswordPolicies != nil {
 		in, out := &in.PasswordPolicies, &out.PasswordPolicies
 		*out = make([]PasswordPolicy, len(*in))
 		copy(*out, *in)
 	}
+	if in.PasswordPolicySettings != nil {
+		in, out := &in.PasswordPolicySettings, &out.PasswordPolicySettings
+		*out = new(RealmPasswordPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientRegistrationPolicies != nil {
+		in, out := &in.ClientRegistrationPolicies, &out.ClientRegistrationPolicies
+		*out = new(ClientRegistrationPolicies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultRoles != nil {
+		in, out := &in.DefaultRoles, &out.DefaultRoles
+		*out = new(DefaultRoles)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultClientScopes != nil {
+		in, out := &in.DefaultClientScopes, &out.DefaultClientScopes
+		*out = new(RealmDefaultClientScopes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SMTP != nil {
+		in, out := &in.SMTP, &out.SMTP
+		*out = new(RealmSMTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BruteForceProtection != nil {
+		in, out := &in.BruteForceProtection, &out.BruteForceProtection
+		*out = new(RealmBruteForceProtection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenSettings != nil {
+		in, out := &in.TokenSettings, &out.TokenSettings
+		*out = new(RealmTokenSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistrationAllowed != nil {
+		in, out := &in.RegistrationAllowed, &out.RegistrationAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RegistrationEmailAsUsername != nil {
+		in, out := &in.RegistrationEmailAsUsername, &out.RegistrationEmailAsUsername
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VerifyEmail != nil {
+		in, out := &in.VerifyEmail, &out.VerifyEmail
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LoginWithEmail != nil {
+		in, out := &in.LoginWithEmail, &out.LoginWithEmail
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RememberMe != nil {
+		in, out := &in.RememberMe, &out.RememberMe
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResetPasswordAllowed != nil {
+		in, out := &in.ResetPasswordAllowed, &out.ResetPasswordAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EditUsernameAllowed != nil {
+		in, out := &in.EditUsernameAllowed, &out.EditUsernameAllowed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FrontendURL != nil {
+		in, out := &in.FrontendURL, &out.FrontendURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdminURL != nil {
+		in, out := &in.AdminURL, &out.AdminURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.CIBAPolicy != nil {
+		in, out := &in.CIBAPolicy, &out.CIBAPolicy
+		*out = new(RealmCIBAPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Localization != nil {
+		in, out := &in.Localization, &out.Localization
+		*out = new(RealmLocalization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserProfileConfig != nil {
+		in, out := &in.UserProfileConfig, &out.UserProfileConfig
+		*out = new(UserProfileConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyProviders != nil {
+		in, out := &in.KeyProviders, &out.KeyProviders
+		*out = make([]RealmKeyProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClientPolicies != nil {
+		in, out := &in.ClientPolicies, &out.ClientPolicies
+		*out = new(RealmClientPolicies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(RealmPrune)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmSpec.
+func (in *KeycloakRealmSpec) DeepCopy() *KeycloakRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmStatus) DeepCopyInto(out *KeycloakRealmStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmStatus.
+func (in *KeycloakRealmStatus) DeepCopy() *KeycloakRealmStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUser) DeepCopyInto(out *KeycloakRealmUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUser.
+func (in *KeycloakRealmUser) DeepCopy() *KeycloakRealmUser {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserBatch) DeepCopyInto(out *KeycloakRealmUserBatch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserBatch.
+func (in *KeycloakRealmUserBatch) DeepCopy() *KeycloakRealmUserBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmUserBatch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserBatchList) DeepCopyInto(out *KeycloakRealmUserBatchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmUserBatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserBatchList.
+func (in *KeycloakRealmUserBatchList) DeepCopy() *KeycloakRealmUserBatchList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserBatchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmUserBatchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserBatchSpec) DeepCopyInto(out *KeycloakRealmUserBatchSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserBatchSpec.
+func (in *KeycloakRealmUserBatchSpec) DeepCopy() *KeycloakRealmUserBatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserBatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserBatchStatus) DeepCopyInto(out *KeycloakRealmUserBatchStatus) {
+	*out = *in
+	if in.FailedUsers != nil {
+		in, out := &in.FailedUsers, &out.FailedUsers
+		*out = make([]BatchUserFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserBatchStatus.
+func (in *KeycloakRealmUserBatchStatus) DeepCopy() *KeycloakRealmUserBatchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserBatchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserList) DeepCopyInto(out *KeycloakRealmUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRealmUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserList.
+func (in *KeycloakRealmUserList) DeepCopy() *KeycloakRealmUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRealmUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserSpec) DeepCopyInto(out *KeycloakRealmUserSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequiredUserActions != nil {
+		in, out := &in.RequiredUserActions, &out.RequiredUserActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]ClientRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemporaryPassword != nil {
+		in, out := &in.TemporaryPassword, &out.TemporaryPassword
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserSpec.
+func (in *KeycloakRealmUserSpec) DeepCopy() *KeycloakRealmUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealmUserStatus) DeepCopyInto(out *KeycloakRealmUserStatus) {
+	*out = *in
+	if in.RequiredUserActions != nil {
+		in, out := &in.RequiredUserActions, &out.RequiredUserActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UndeclaredAttributes != nil {
+		in, out := &in.UndeclaredAttributes, &out.UndeclaredAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserStatus.
+func (in *KeycloakRealmUserStatus) DeepCopy() *KeycloakRealmUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealmUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRef) DeepCopyInto(out *KeycloakRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRef.
+func (in *KeycloakRef) DeepCopy() *KeycloakRef {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRequiredAction) DeepCopyInto(out *KeycloakRequiredAction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRequiredAction.
+func (in *KeycloakRequiredAction) DeepCopy() *KeycloakRequiredAction {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRequiredAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRequiredAction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRequiredActionList) DeepCopyInto(out *KeycloakRequiredActionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeycloakRequiredAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRequiredActionList.
+func (in *KeycloakRequiredActionList) DeepCopy() *KeycloakRequiredActionList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRequiredActionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeycloakRequiredActionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRequiredActionSpec) DeepCopyInto(out *KeycloakRequiredActionSpec) {
+	*out = *in
+	if in.KeycloakRef != nil {
+		in, out := &in.KeycloakRef, &out.KeycloakRef
+		*out = new(KeycloakRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RealmSelector != nil {
+		in, out := &in.RealmSelector, &out.RealmSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRequiredActionSpec.
+func (in *KeycloakRequiredActionSpec) DeepCopy() *KeycloakRequiredActionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRequiredActionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRequiredActionStatus) DeepCopyInto(out *KeycloakRequiredActionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRequiredActionStatus.
+func (in *KeycloakRequiredActionStatus) DeepCopy() *KeycloakRequiredActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRequiredActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakServiceAccountToken) DeepCopyInto(out *KeycloakServiceAccountToken) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakServiceAccountToken.
+func (in *KeycloakServiceAccountToken) DeepCopy() *KeycloakServiceAccountToken {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakServiceAccountToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
+	*out = *in
+	if in.FailoverURLs != nil {
+		in, out := &in.FailoverURLs, &out.FailoverURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(KeycloakVaultCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(KeycloakServiceAccountToken)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyAuth != nil {
+		in, out := &in.ProxyAuth, &out.ProxyAuth
+		*out = new(KeycloakProxyAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = make([]KeycloakHeadersSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(KeycloakRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakSpec.
+func (in *KeycloakSpec) DeepCopy() *KeycloakSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakStatus) DeepCopyInto(out *KeycloakStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakStatus.
+func (in *KeycloakStatus) DeepCopy() *KeycloakStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakVaultCredentials) DeepCopyInto(out *KeycloakVaultCredentials) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakVaultCredentials.
+func (in *KeycloakVaultCredentials) DeepCopy() *KeycloakVaultCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakVaultCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPSyncSettings) DeepCopyInto(out *LDAPSyncSettings) {
+	*out = *in
+	if in.ImportEnabled != nil {
+		in, out := &in.ImportEnabled, &out.ImportEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPSyncSettings.
+func (in *LDAPSyncSettings) DeepCopy() *LDAPSyncSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(LDAPSyncSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxClientsPolicy) DeepCopyInto(out *MaxClientsPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxClientsPolicy.
+func (in *MaxClientsPolicy) DeepCopy() *MaxClientsPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxClientsPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MicrosoftIdentityProviderConfig) DeepCopyInto(out *MicrosoftIdentityProviderConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MicrosoftIdentityProviderConfig.
+func (in *MicrosoftIdentityProviderConfig) DeepCopy() *MicrosoftIdentityProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MicrosoftIdentityProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCClientConfig) DeepCopyInto(out *OIDCClientConfig) {
+	*out = *in
+	if in.DeviceAuthorizationGrantEnabled != nil {
+		in, out := &in.DeviceAuthorizationGrantEnabled, &out.DeviceAuthorizationGrantEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CIBAGrantEnabled != nil {
+		in, out := &in.CIBAGrantEnabled, &out.CIBAGrantEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DPoPBoundAccessTokens != nil {
+		in, out := &in.DPoPBoundAccessTokens, &out.DPoPBoundAccessTokens
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCClientConfig.
+func (in *OIDCClientConfig) DeepCopy() *OIDCClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationDomain) DeepCopyInto(out *OrganizationDomain) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationDomain.
+func (in *OrganizationDomain) DeepCopy() *OrganizationDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationIdentityProvider) DeepCopyInto(out *OrganizationIdentityProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationIdentityProvider.
+func (in *OrganizationIdentityProvider) DeepCopy() *OrganizationIdentityProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationIdentityProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParentGroup) DeepCopyInto(out *ParentGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParentGroup.
+func (in *ParentGroup) DeepCopy() *ParentGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ParentGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicy.
+func (in *PasswordPolicy) DeepCopy() *PasswordPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtocolMapper) DeepCopyInto(out *ProtocolMapper) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtocolMapper.
+func (in *ProtocolMapper) DeepCopy() *ProtocolMapper {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtocolMapper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmAdminCredentials) DeepCopyInto(out *RealmAdminCredentials) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmAdminCredentials.
+func (in *RealmAdminCredentials) DeepCopy() *RealmAdminCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmAdminCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmBruteForceProtection) DeepCopyInto(out *RealmBruteForceProtection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmBruteForceProtection.
+func (in *RealmBruteForceProtection) DeepCopy() *RealmBruteForceProtection {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmBruteForceProtection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmCIBAPolicy) DeepCopyInto(out *RealmCIBAPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmCIBAPolicy.
+func (in *RealmCIBAPolicy) DeepCopy() *RealmCIBAPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmCIBAPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmClientPolicies) DeepCopyInto(out *RealmClientPolicies) {
+	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]ClientProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]ClientPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmClientPolicies.
+func (in *RealmClientPolicies) DeepCopy() *RealmClientPolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmClientPolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmDefaultClientScopes) DeepCopyInto(out *RealmDefaultClientScopes) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmDefaultClientScopes.
+func (in *RealmDefaultClientScopes) DeepCopy() *RealmDefaultClientScopes {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmDefaultClientScopes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmEventConfig) DeepCopyInto(out *RealmEventConfig) {
+	*out = *in
+	if in.EnabledEventTypes != nil {
+		in, out := &in.EnabledEventTypes, &out.EnabledEventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EventsListeners != nil {
+		in, out := &in.EventsListeners, &out.EventsListeners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdminEventsExpiration != nil {
+		in, out := &in.AdminEventsExpiration, &out.AdminEventsExpiration
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmEventConfig.
+func (in *RealmEventConfig) DeepCopy() *RealmEventConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmEventConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmImportOCISource) DeepCopyInto(out *RealmImportOCISource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmImportOCISource.
+func (in *RealmImportOCISource) DeepCopy() *RealmImportOCISource {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmImportOCISource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmImportSource) DeepCopyInto(out *RealmImportSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(RealmImportURLSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(RealmImportOCISource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmImportSource.
+func (in *RealmImportSource) DeepCopy() *RealmImportSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmImportSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmImportURLSource) DeepCopyInto(out *RealmImportURLSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmImportURLSource.
+func (in *RealmImportURLSource) DeepCopy() *RealmImportURLSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmImportURLSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmKeyProvider) DeepCopyInto(out *RealmKeyProvider) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PrivateKey != nil {
+		in, out := &in.PrivateKey, &out.PrivateKey
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(KeyProviderRotation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmKeyProvider.
+func (in *RealmKeyProvider) DeepCopy() *RealmKeyProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmKeyProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmLocalization) DeepCopyInto(out *RealmLocalization) {
+	*out = *in
+	if in.SupportedLocales != nil {
+		in, out := &in.SupportedLocales, &out.SupportedLocales
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Messages != nil {
+		in, out := &in.Messages, &out.Messages
+		*out = make([]RealmMessageBundle, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmLocalization.
+func (in *RealmLocalization) DeepCopy() *RealmLocalization {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmLocalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmMessageBundle) DeepCopyInto(out *RealmMessageBundle) {
+	*out = *in
+	if in.Texts != nil {
+		in, out := &in.Texts, &out.Texts
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmMessageBundle.
+func (in *RealmMessageBundle) DeepCopy() *RealmMessageBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmMessageBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmPasswordPolicy) DeepCopyInto(out *RealmPasswordPolicy) {
+	*out = *in
+	if in.Length != nil {
+		in, out := &in.Length, &out.Length
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxLength != nil {
+		in, out := &in.MaxLength, &out.MaxLength
+		*out = new(int)
+		**out = **in
+	}
+	if in.Digits != nil {
+		in, out := &in.Digits, &out.Digits
+		*out = new(int)
+		**out = **in
+	}
+	if in.LowerCase != nil {
+		in, out := &in.LowerCase, &out.LowerCase
+		*out = new(int)
+		**out = **in
+	}
+	if in.UpperCase != nil {
+		in, out := &in.UpperCase, &out.UpperCase
+		*out = new(int)
+		**out = **in
+	}
+	if in.SpecialChars != nil {
+		in, out := &in.SpecialChars, &out.SpecialChars
+		*out = new(int)
+		**out = **in
+	}
+	if in.PasswordHistory != nil {
+		in, out := &in.PasswordHistory, &out.PasswordHistory
+		*out = new(int)
+		**out = **in
+	}
+	if in.ForceExpiredPasswordChange != nil {
+		in, out := &in.ForceExpiredPasswordChange, &out.ForceExpiredPasswordChange
+		*out = new(int)
+		**out = **in
+	}
+	if in.HashIterations != nil {
+		in, out := &in.HashIterations, &out.HashIterations
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmPasswordPolicy.
+func (in *RealmPasswordPolicy) DeepCopy() *RealmPasswordPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmPasswordPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmPrune) DeepCopyInto(out *RealmPrune) {
+	*out = *in
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = new(RealmPruneExclusions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmPrune.
+func (in *RealmPrune) DeepCopy() *RealmPrune {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmPrune)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmPruneExclusions) DeepCopyInto(out *RealmPruneExclusions) {
+	*out = *in
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientScopes != nil {
+		in, out := &in.ClientScopes, &out.ClientScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmSpec.
-func (in *KeycloakRealmSpec) DeepCopy() *KeycloakRealmSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmPruneExclusions.
+func (in *RealmPruneExclusions) DeepCopy() *RealmPruneExclusions {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmSpec)
+	out := new(RealmPruneExclusions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmRole) DeepCopyInto(out *RealmRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmRole.
+func (in *RealmRole) DeepCopy() *RealmRole {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmSMTP) DeepCopyInto(out *RealmSMTP) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(SMTPAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmSMTP.
+func (in *RealmSMTP) DeepCopy() *RealmSMTP {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmSMTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmSecurityDefenses) DeepCopyInto(out *RealmSecurityDefenses) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = new(RealmSecurityHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmSecurityDefenses.
+func (in *RealmSecurityDefenses) DeepCopy() *RealmSecurityDefenses {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmSecurityDefenses)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmSecurityHeaders) DeepCopyInto(out *RealmSecurityHeaders) {
+	*out = *in
+	if in.ContentSecurityPolicy != nil {
+		in, out := &in.ContentSecurityPolicy, &out.ContentSecurityPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.ContentSecurityPolicyReportOnly != nil {
+		in, out := &in.ContentSecurityPolicyReportOnly, &out.ContentSecurityPolicyReportOnly
+		*out = new(string)
+		**out = **in
+	}
+	if in.XFrameOptions != nil {
+		in, out := &in.XFrameOptions, &out.XFrameOptions
+		*out = new(string)
+		**out = **in
+	}
+	if in.StrictTransportSecurity != nil {
+		in, out := &in.StrictTransportSecurity, &out.StrictTransportSecurity
+		*out = new(string)
+		**out = **in
+	}
+	if in.XContentTypeOptions != nil {
+		in, out := &in.XContentTypeOptions, &out.XContentTypeOptions
+		*out = new(string)
+		**out = **in
+	}
+	if in.XRobotsTag != nil {
+		in, out := &in.XRobotsTag, &out.XRobotsTag
+		*out = new(string)
+		**out = **in
+	}
+	if in.XXSSProtection != nil {
+		in, out := &in.XXSSProtection, &out.XXSSProtection
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReferrerPolicy != nil {
+		in, out := &in.ReferrerPolicy, &out.ReferrerPolicy
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmSecurityHeaders.
+func (in *RealmSecurityHeaders) DeepCopy() *RealmSecurityHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmSecurityHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmThemes) DeepCopyInto(out *RealmThemes) {
+	*out = *in
+	if in.LoginTheme != nil {
+		in, out := &in.LoginTheme, &out.LoginTheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccountTheme != nil {
+		in, out := &in.AccountTheme, &out.AccountTheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdminConsoleTheme != nil {
+		in, out := &in.AdminConsoleTheme, &out.AdminConsoleTheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.EmailTheme != nil {
+		in, out := &in.EmailTheme, &out.EmailTheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.InternationalizationEnabled != nil {
+		in, out := &in.InternationalizationEnabled, &out.InternationalizationEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmThemes.
+func (in *RealmThemes) DeepCopy() *RealmThemes {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmThemes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RealmTokenSettings) DeepCopyInto(out *RealmTokenSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmTokenSettings.
+func (in *RealmTokenSettings) DeepCopy() *RealmTokenSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RealmTokenSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectURISource) DeepCopyInto(out *RedirectURISource) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectURISource.
+func (in *RedirectURISource) DeepCopy() *RedirectURISource {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectURISource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SAMLClientConfig) DeepCopyInto(out *SAMLClientConfig) {
+	*out = *in
+	if in.ForceNameIDFormat != nil {
+		in, out := &in.ForceNameIDFormat, &out.ForceNameIDFormat
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ForcePostBinding != nil {
+		in, out := &in.ForcePostBinding, &out.ForcePostBinding
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SignDocuments != nil {
+		in, out := &in.SignDocuments, &out.SignDocuments
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SignAssertions != nil {
+		in, out := &in.SignAssertions, &out.SignAssertions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ClientSignatureRequired != nil {
+		in, out := &in.ClientSignatureRequired, &out.ClientSignatureRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EncryptAssertions != nil {
+		in, out := &in.EncryptAssertions, &out.EncryptAssertions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SigningCertificateRef != nil {
+		in, out := &in.SigningCertificateRef, &out.SigningCertificateRef
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EncryptionCertificateRef != nil {
+		in, out := &in.EncryptionCertificateRef, &out.EncryptionCertificateRef
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SAMLClientConfig.
+func (in *SAMLClientConfig) DeepCopy() *SAMLClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SAMLClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SAMLIdentityProviderConfig) DeepCopyInto(out *SAMLIdentityProviderConfig) {
+	*out = *in
+	if in.SigningCertificates != nil {
+		in, out := &in.SigningCertificates, &out.SigningCertificates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WantAssertionsSigned != nil {
+		in, out := &in.WantAssertionsSigned, &out.WantAssertionsSigned
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WantAssertionsEncrypted != nil {
+		in, out := &in.WantAssertionsEncrypted, &out.WantAssertionsEncrypted
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WantAuthnRequestsSigned != nil {
+		in, out := &in.WantAuthnRequestsSigned, &out.WantAuthnRequestsSigned
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValidateSignature != nil {
+		in, out := &in.ValidateSignature, &out.ValidateSignature
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PostBindingResponse != nil {
+		in, out := &in.PostBindingResponse, &out.PostBindingResponse
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PostBindingAuthnRequest != nil {
+		in, out := &in.PostBindingAuthnRequest, &out.PostBindingAuthnRequest
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PostBindingLogout != nil {
+		in, out := &in.PostBindingLogout, &out.PostBindingLogout
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ForceAuthn != nil {
+		in, out := &in.ForceAuthn, &out.ForceAuthn
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SAMLIdentityProviderConfig.
+func (in *SAMLIdentityProviderConfig) DeepCopy() *SAMLIdentityProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SAMLIdentityProviderConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmStatus) DeepCopyInto(out *KeycloakRealmStatus) {
+func (in *SMTPAuth) DeepCopyInto(out *SMTPAuth) {
 	*out = *in
+	out.PasswordSecret = in.PasswordSecret
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmStatus.
-func (in *KeycloakRealmStatus) DeepCopy() *KeycloakRealmStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMTPAuth.
+func (in *SMTPAuth) DeepCopy() *SMTPAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmStatus)
+	out := new(SMTPAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmUser) DeepCopyInto(out *KeycloakRealmUser) {
+func (in *SSORealmMapper) DeepCopyInto(out *SSORealmMapper) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUser.
-func (in *KeycloakRealmUser) DeepCopy() *KeycloakRealmUser {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSORealmMapper.
+func (in *SSORealmMapper) DeepCopy() *SSORealmMapper {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmUser)
+	out := new(SSORealmMapper)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmUser) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmUserList) DeepCopyInto(out *KeycloakRealmUserList) {
+func (in *ScopeMappings) DeepCopyInto(out *ScopeMappings) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeycloakRealmUser, len(*in))
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]ClientRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserList.
-func (in *KeycloakRealmUserList) DeepCopy() *KeycloakRealmUserList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScopeMappings.
+func (in *ScopeMappings) DeepCopy() *ScopeMappings {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmUserList)
+	out := new(ScopeMappings)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeycloakRealmUserList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(SecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmUserSpec) DeepCopyInto(out *KeycloakRealmUserSpec) {
+func (in *SecretRotationPolicy) DeepCopyInto(out *SecretRotationPolicy) {
 	*out = *in
-	if in.RequiredUserActions != nil {
-		in, out := &in.RequiredUserActions, &out.RequiredUserActions
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.Interval = in.Interval
+	out.OverlapWindow = in.OverlapWindow
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRotationPolicy.
+func (in *SecretRotationPolicy) DeepCopy() *SecretRotationPolicy {
+	if in == nil {
+		return nil
 	}
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
+	out := new(SecretRotationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
+	*out = *in
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]ClientRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Attributes != nil {
 		in, out := &in.Attributes, &out.Attributes
@@ -1316,217 +4899,274 @@ func (in *KeycloakRealmUserSpec) DeepCopyInto(out *KeycloakRealmUserSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserSpec.
-func (in *KeycloakRealmUserSpec) DeepCopy() *KeycloakRealmUserSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccount.
+func (in *ServiceAccount) DeepCopy() *ServiceAccount {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmUserSpec)
+	out := new(ServiceAccount)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakRealmUserStatus) DeepCopyInto(out *KeycloakRealmUserStatus) {
+func (in *SignedJWTConfig) DeepCopyInto(out *SignedJWTConfig) {
 	*out = *in
+	if in.JWKSRef != nil {
+		in, out := &in.JWKSRef, &out.JWKSRef
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateRef != nil {
+		in, out := &in.CertificateRef, &out.CertificateRef
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealmUserStatus.
-func (in *KeycloakRealmUserStatus) DeepCopy() *KeycloakRealmUserStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignedJWTConfig.
+func (in *SignedJWTConfig) DeepCopy() *SignedJWTConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakRealmUserStatus)
+	out := new(SignedJWTConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
+func (in *TrustedHostsPolicy) DeepCopyInto(out *TrustedHostsPolicy) {
 	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostSendingRegistrationRequestMustMatch != nil {
+		in, out := &in.HostSendingRegistrationRequestMustMatch, &out.HostSendingRegistrationRequestMustMatch
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ClientURIsMustMatch != nil {
+		in, out := &in.ClientURIsMustMatch, &out.ClientURIsMustMatch
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakSpec.
-func (in *KeycloakSpec) DeepCopy() *KeycloakSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedHostsPolicy.
+func (in *TrustedHostsPolicy) DeepCopy() *TrustedHostsPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakSpec)
+	out := new(TrustedHostsPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakStatus) DeepCopyInto(out *KeycloakStatus) {
+func (in *User) DeepCopyInto(out *User) {
 	*out = *in
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakStatus.
-func (in *KeycloakStatus) DeepCopy() *KeycloakStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new User.
+func (in *User) DeepCopy() *User {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakStatus)
+	out := new(User)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
+func (in *UserBatchSource) DeepCopyInto(out *UserBatchSource) {
 	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicy.
-func (in *PasswordPolicy) DeepCopy() *PasswordPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserBatchSource.
+func (in *UserBatchSource) DeepCopy() *UserBatchSource {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordPolicy)
+	out := new(UserBatchSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProtocolMapper) DeepCopyInto(out *ProtocolMapper) {
+func (in *UserProfileAttribute) DeepCopyInto(out *UserProfileAttribute) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = make(map[string]map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			var outVal map[string]apiextensionsv1.JSON
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]apiextensionsv1.JSON, len(*in))
+				for key, val := range *in {
+					(*out)[key] = *val.DeepCopy()
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(UserProfileAttributeRequired)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = new(UserProfileAttributePermissions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(UserProfileAttributeSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtocolMapper.
-func (in *ProtocolMapper) DeepCopy() *ProtocolMapper {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttribute.
+func (in *UserProfileAttribute) DeepCopy() *UserProfileAttribute {
 	if in == nil {
 		return nil
 	}
-	out := new(ProtocolMapper)
+	out := new(UserProfileAttribute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RealmEventConfig) DeepCopyInto(out *RealmEventConfig) {
+func (in *UserProfileAttributePermissions) DeepCopyInto(out *UserProfileAttributePermissions) {
 	*out = *in
-	if in.EnabledEventTypes != nil {
-		in, out := &in.EnabledEventTypes, &out.EnabledEventTypes
+	if in.View != nil {
+		in, out := &in.View, &out.View
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.EventsListeners != nil {
-		in, out := &in.EventsListeners, &out.EventsListeners
+	if in.Edit != nil {
+		in, out := &in.Edit, &out.Edit
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmEventConfig.
-func (in *RealmEventConfig) DeepCopy() *RealmEventConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttributePermissions.
+func (in *UserProfileAttributePermissions) DeepCopy() *UserProfileAttributePermissions {
 	if in == nil {
 		return nil
 	}
-	out := new(RealmEventConfig)
+	out := new(UserProfileAttributePermissions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RealmRole) DeepCopyInto(out *RealmRole) {
+func (in *UserProfileAttributeRequired) DeepCopyInto(out *UserProfileAttributeRequired) {
 	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmRole.
-func (in *RealmRole) DeepCopy() *RealmRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttributeRequired.
+func (in *UserProfileAttributeRequired) DeepCopy() *UserProfileAttributeRequired {
 	if in == nil {
 		return nil
 	}
-	out := new(RealmRole)
+	out := new(UserProfileAttributeRequired)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RealmThemes) DeepCopyInto(out *RealmThemes) {
+func (in *UserProfileAttributeSelector) DeepCopyInto(out *UserProfileAttributeSelector) {
 	*out = *in
-	if in.LoginTheme != nil {
-		in, out := &in.LoginTheme, &out.LoginTheme
-		*out = new(string)
-		**out = **in
-	}
-	if in.AccountTheme != nil {
-		in, out := &in.AccountTheme, &out.AccountTheme
-		*out = new(string)
-		**out = **in
-	}
-	if in.AdminConsoleTheme != nil {
-		in, out := &in.AdminConsoleTheme, &out.AdminConsoleTheme
-		*out = new(string)
-		**out = **in
-	}
-	if in.EmailTheme != nil {
-		in, out := &in.EmailTheme, &out.EmailTheme
-		*out = new(string)
-		**out = **in
-	}
-	if in.InternationalizationEnabled != nil {
-		in, out := &in.InternationalizationEnabled, &out.InternationalizationEnabled
-		*out = new(bool)
-		**out = **in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RealmThemes.
-func (in *RealmThemes) DeepCopy() *RealmThemes {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileAttributeSelector.
+func (in *UserProfileAttributeSelector) DeepCopy() *UserProfileAttributeSelector {
 	if in == nil {
 		return nil
 	}
-	out := new(RealmThemes)
+	out := new(UserProfileAttributeSelector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SSORealmMapper) DeepCopyInto(out *SSORealmMapper) {
+func (in *UserProfileConfig) DeepCopyInto(out *UserProfileConfig) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make([]UserProfileAttribute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]UserProfileGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSORealmMapper.
-func (in *SSORealmMapper) DeepCopy() *SSORealmMapper {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileConfig.
+func (in *UserProfileConfig) DeepCopy() *UserProfileConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(SSORealmMapper)
+	out := new(UserProfileConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
+func (in *UserProfileGroup) DeepCopyInto(out *UserProfileGroup) {
 	*out = *in
-	if in.RealmRoles != nil {
-		in, out := &in.RealmRoles, &out.RealmRoles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ClientRoles != nil {
-		in, out := &in.ClientRoles, &out.ClientRoles
-		*out = make([]ClientRole, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
@@ -1534,32 +5174,28 @@ func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccount.
-func (in *ServiceAccount) DeepCopy() *ServiceAccount {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProfileGroup.
+func (in *UserProfileGroup) DeepCopy() *UserProfileGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceAccount)
+	out := new(UserProfileGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *User) DeepCopyInto(out *User) {
+func (in *UserStorageSyncStatus) DeepCopyInto(out *UserStorageSyncStatus) {
 	*out = *in
-	if in.RealmRoles != nil {
-		in, out := &in.RealmRoles, &out.RealmRoles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.Time.DeepCopyInto(&out.Time)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new User.
-func (in *User) DeepCopy() *User {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserStorageSyncStatus.
+func (in *UserStorageSyncStatus) DeepCopy() *UserStorageSyncStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(User)
+	out := new(UserStorageSyncStatus)
 	in.DeepCopyInto(out)
 	return out
 }
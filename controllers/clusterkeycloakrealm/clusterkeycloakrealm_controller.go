@@ -0,0 +1,140 @@
+package clusterkeycloakrealm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+const finalizerName = "keycloak.clusterrealm.operator.finalizer.name"
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+	InvalidateKeycloakClientTokenSecret(ctx context.Context, namespace, rootKeycloakName string) error
+}
+
+// Reconcile reconciles a ClusterKeycloakRealm object.
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	chain                   handler.RealmHandler
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("cluster-keycloak-realm"),
+		chain:  chain.CreateClusterRealmChain(client, helper),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: helper.IsFailuresUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.ClusterKeycloakRealm{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup ClusterKeycloakRealm controller: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,resources=clusterkeycloakrealms,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,resources=clusterkeycloakrealms/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,resources=clusterkeycloakrealms/finalizers,verbs=update
+
+// Reconcile is a loop for reconciling ClusterKeycloakRealm object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := r.log.WithValues("Request.Name", request.Name)
+	log.Info("Reconciling ClusterKeycloakRealm")
+
+	var instance keycloakApi.ClusterKeycloakRealm
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("instance not found")
+
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get cluster keycloak realm from k8s")
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		instance.Status.Available = false
+		helper.SetErrorStatus(&instance, err)
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling cluster keycloak realm", "name", request.Name)
+	} else {
+		instance.Status.Available = true
+		instance.Status.Value = helper.StatusOK
+		instance.Status.FailureCount = 0
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = errors.Wrap(err, "unable to update status")
+	}
+
+	log.Info("Reconciling ClusterKeycloakRealm done")
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, clusterRealm *keycloakApi.ClusterKeycloakRealm) error {
+	if clusterRealm.Spec.KeycloakRef.Namespace == "" {
+		return errors.New("namespace of the keycloakRef is required for the cluster realm")
+	}
+
+	realm := clusterRealm.ToKeycloakRealm()
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client for realm")
+	}
+
+	deleted, err := r.helper.TryToDelete(ctx, clusterRealm,
+		makeTerminator(clusterRealm.Spec.RealmName, kClient, r.log.WithName("cluster-realm-term")), finalizerName)
+	if err != nil {
+		return errors.Wrap(err, "error during cluster realm deletion")
+	}
+
+	if deleted {
+		return nil
+	}
+
+	if err := r.chain.ServeRequest(ctx, realm, kClient); err != nil {
+		return errors.Wrap(err, "error during cluster realm chain")
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package clusterkeycloakrealm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func reconcileClusterRealm(t *testing.T, h *helper.Mock) *keycloakApi.ClusterKeycloakRealm {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	clusterRealm := keycloakApi.ClusterKeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+		Spec: keycloakApi.ClusterKeycloakRealmSpec{
+			RealmName:   "shared-realm",
+			KeycloakRef: keycloakApi.KeycloakRef{Name: "keycloak", Namespace: "security"},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&clusterRealm).Build()
+
+	rec := Reconcile{
+		client:                  k8sClient,
+		log:                     mock.NewLogr(),
+		helper:                  h,
+		chain:                   chain.CreateClusterRealmChain(k8sClient, h),
+		successReconcileTimeout: time.Hour,
+	}
+
+	_, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: clusterRealm.Name},
+	})
+	require.NoError(t, err)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.ClusterKeycloakRealm)
+	require.True(t, ok)
+
+	return updated
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("ExistRealm", "shared-realm").Return(false, nil)
+	kClient.On("CreateRealmWithDefaultConfig", &dto.Realm{Name: "shared-realm", SsoAutoRedirectEnabled: true}).
+		Return(nil)
+
+	realm := keycloakApi.ClusterKeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+		Spec: keycloakApi.ClusterKeycloakRealmSpec{
+			RealmName:   "shared-realm",
+			KeycloakRef: keycloakApi.KeycloakRef{Name: "keycloak", Namespace: "security"},
+		},
+	}
+
+	h := helper.Mock{}
+	h.On("CreateKeycloakClientForRealm", realm.ToKeycloakRealm()).Return(kClient, nil)
+	h.On("TryToDelete", testifyMock.Anything, testifyMock.Anything, finalizerName).Return(false, nil)
+	h.On("InvalidateKeycloakClientTokenSecret", "security", "keycloak").Return(nil)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	updated := reconcileClusterRealm(t, &h)
+
+	assert.True(t, updated.Status.Available)
+	assert.Equal(t, helper.StatusOK, updated.Status.Value)
+	kClient.AssertExpectations(t)
+}
+
+func TestReconcile_Reconcile_Failure(t *testing.T) {
+	h := helper.Mock{}
+	h.On("CreateKeycloakClientForRealm", testifyMock.Anything).Return(nil, errors.New("keycloak is not connected"))
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	updated := reconcileClusterRealm(t, &h)
+
+	assert.False(t, updated.Status.Available)
+	assert.Contains(t, updated.Status.Value, "keycloak is not connected")
+}
@@ -0,0 +1,341 @@
+// Package driftdetector periodically compares the applied custom resources with keycloak.
+package driftdetector
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/verify"
+)
+
+var driftedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "keycloak_operator_resource_drifted",
+	Help: "Whether keycloak differs from the applied custom resource, 1 if it is drifted.",
+}, []string{"namespace", "kind", "name"})
+
+func init() {
+	metrics.Registry.MustRegister(driftedResources)
+}
+
+// Kinds of the custom resources checked by the detector.
+var kinds = map[string]func() object{
+	"KeycloakRealm":      func() object { return &keycloakApi.KeycloakRealm{} },
+	"KeycloakRealmRole":  func() object { return &keycloakApi.KeycloakRealmRole{} },
+	"KeycloakClient":     func() object { return &keycloakApi.KeycloakClient{} },
+	"KeycloakRealmGroup": func() object { return &keycloakApi.KeycloakRealmGroup{} },
+	"KeycloakAuthFlow":   func() object { return &keycloakApi.KeycloakAuthFlow{} },
+}
+
+type object interface {
+	client.Object
+	helper.StatusValue
+	helper.Conditioned
+	helper.AppliedGenerationRecorder
+}
+
+// gaugeLabels are the labels of the drift metric of the resource.
+type gaugeLabels struct {
+	namespace, kind, name string
+}
+
+type Helper interface {
+	RealmOwnerHelper
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+}
+
+// Detector compares the custom resources which are successfully applied with keycloak, sets their Drifted
+// condition and the drift metric and triggers the reconciliation of the drifted ones with the heal policy.
+// The report of the realm is stored in the config map set by the drift report annotation of the realm.
+type Detector struct {
+	client        client.Client
+	helper        Helper
+	log           logr.Logger
+	interval      time.Duration
+	defaultPolicy string
+	triggers      map[string]chan event.GenericEvent
+	// triggerNamespaces are the namespaces of the resources whose reconciliation is triggered,
+	// the resources of all namespaces are triggered if the kind has no namespaces.
+	triggerNamespaces map[string]map[string]bool
+	// gauges are the drift metrics set by the last detection of the realms,
+	// the metrics of the resources which are not checked anymore are deleted.
+	gauges map[types.NamespacedName]map[gaugeLabels]struct{}
+}
+
+func NewDetector(client client.Client, log logr.Logger, helper Helper, interval time.Duration,
+	defaultPolicy string) *Detector {
+	return &Detector{
+		client:            client,
+		helper:            helper,
+		log:               log.WithName("drift-detector"),
+		interval:          interval,
+		defaultPolicy:     defaultPolicy,
+		triggers:          make(map[string]chan event.GenericEvent, len(kinds)),
+		triggerNamespaces: make(map[string]map[string]bool),
+		gauges:            make(map[types.NamespacedName]map[gaugeLabels]struct{}),
+	}
+}
+
+// Trigger returns the source of the reconciliation requests for the drifted custom resources of the kind.
+// It must be called before the detector is started, the drifted resources of the kinds without the trigger
+// are not reconciled, e.g. if their controller is disabled. If the namespaces are set, only the resources
+// from them are reconciled, like the resources the controller of the kind watches.
+func (d *Detector) Trigger(kind string, namespaces ...string) source.Source {
+	if _, ok := d.triggers[kind]; !ok {
+		d.triggers[kind] = make(chan event.GenericEvent)
+	}
+
+	if len(namespaces) > 0 {
+		d.triggerNamespaces[kind] = make(map[string]bool, len(namespaces))
+		for _, n := range namespaces {
+			d.triggerNamespaces[kind][n] = true
+		}
+	}
+
+	return &source.Channel{Source: d.triggers[kind]}
+}
+
+// NeedLeaderElection makes the detector run only on the leader.
+func (d *Detector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the detection with the interval until the context is done.
+func (d *Detector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := d.Detect(ctx); err != nil {
+				d.log.Error(err, "drift detection failed")
+			}
+		}
+	}
+}
+
+// Detect checks the custom resources of all available realms once.
+func (d *Detector) Detect(ctx context.Context) error {
+	var realms keycloakApi.KeycloakRealmList
+	if err := d.client.List(ctx, &realms); err != nil {
+		return errors.Wrap(err, "unable to list keycloak realms")
+	}
+
+	detected := make(map[types.NamespacedName]bool, len(realms.Items))
+
+	for i := range realms.Items {
+		realm := &realms.Items[i]
+		if !realm.Status.Available || !realm.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		realmName := types.NamespacedName{Namespace: realm.Namespace, Name: realm.Name}
+		detected[realmName] = true
+
+		gauges, err := d.detectRealm(ctx, realm)
+		if err != nil {
+			d.log.Error(err, "unable to detect drift", "realm", realm.Spec.RealmName, "namespace", realm.Namespace)
+
+			continue
+		}
+
+		d.replaceGauges(realmName, gauges)
+	}
+
+	for realmName := range d.gauges {
+		if !detected[realmName] {
+			d.replaceGauges(realmName, nil)
+		}
+	}
+
+	return nil
+}
+
+// replaceGauges deletes the drift metrics of the realm which are not set by its last detection.
+func (d *Detector) replaceGauges(realm types.NamespacedName, gauges map[gaugeLabels]struct{}) {
+	for l := range d.gauges[realm] {
+		if _, ok := gauges[l]; !ok {
+			driftedResources.DeleteLabelValues(l.namespace, l.kind, l.name)
+		}
+	}
+
+	if len(gauges) == 0 {
+		delete(d.gauges, realm)
+
+		return
+	}
+
+	d.gauges[realm] = gauges
+}
+
+// detectRealm checks the custom resources of the realm and returns the drift metrics it has set.
+func (d *Detector) detectRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (map[gaugeLabels]struct{}, error) {
+	kClient, err := d.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	// the children of the realm can be in the other namespaces
+	report, err := verify.Verify(ctx, d.client, NewRealmResolver(d.client, d.helper), kClient, realm, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to verify realm")
+	}
+
+	if err := d.storeReport(ctx, realm, report); err != nil {
+		return nil, err
+	}
+
+	gauges := make(map[gaugeLabels]struct{}, len(report.Resources))
+
+	for i := range report.Resources {
+		res := &report.Resources[i]
+
+		measured, err := d.handle(ctx, res)
+		if err != nil {
+			return nil, err
+		}
+
+		if measured {
+			gauges[gaugeLabels{namespace: res.Namespace, kind: res.Kind, name: res.Name}] = struct{}{}
+		}
+	}
+
+	return gauges, nil
+}
+
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get;create;update
+
+// storeReport stores the report in the config map of the realm if the realm has the drift report annotation.
+func (d *Detector) storeReport(ctx context.Context, realm *keycloakApi.KeycloakRealm, report *verify.Report) error {
+	name := realm.GetAnnotations()[keycloakApi.DriftReportConfigMapAnnotation]
+	if name == "" {
+		return nil
+	}
+
+	data, err := verify.EncodeReport(report, verify.OutputYAML)
+	if err != nil {
+		return err
+	}
+
+	return verify.StoreReport(ctx, d.client, realm.Namespace, name, verify.ReportKey(verify.OutputYAML), data)
+}
+
+// handle sets the drift of the custom resource and returns whether its drift metric is set.
+// The kinds without the Drifted condition are only reported.
+func (d *Detector) handle(ctx context.Context, res *verify.ResourceReport) (bool, error) {
+	newObject, ok := kinds[res.Kind]
+	if !ok {
+		return false, nil
+	}
+
+	namespace := res.Namespace
+
+	obj := newObject()
+	if err := d.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: res.Name}, obj); err != nil {
+		// the resource is deleted after the verification
+		if k8sErrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, errors.Wrapf(err, "unable to get %s %s", res.Kind, res.Name)
+	}
+
+	// The drift is measured only against the spec applied by the last successful reconciliation,
+	// the changed specs are reconciled anyway.
+	if obj.GetStatus() != helper.StatusOK || obj.GetAppliedGeneration() != obj.GetGeneration() ||
+		helper.IsDryRunEnabled(obj) || !obj.GetDeletionTimestamp().IsZero() {
+		return false, nil
+	}
+
+	drifted := res.Status != verify.StatusInSync
+
+	gauge := 0.0
+	if drifted {
+		gauge = 1
+	}
+
+	driftedResources.WithLabelValues(namespace, res.Kind, res.Name).Set(gauge)
+
+	if setDriftedCondition(obj, res) {
+		if err := d.client.Status().Update(ctx, obj); err != nil {
+			return true, errors.Wrapf(err, "unable to update %s %s status", res.Kind, res.Name)
+		}
+	}
+
+	if !drifted {
+		return true, nil
+	}
+
+	policy := d.defaultPolicy
+	if p := obj.GetAnnotations()[keycloakApi.DriftPolicyAnnotation]; p != "" {
+		policy = p
+	}
+
+	d.log.Info("Drift detected", "kind", res.Kind, "name", res.Name, "namespace", namespace,
+		"policy", policy, "changes", res.Summary())
+
+	trigger, ok := d.triggers[res.Kind]
+	if policy != keycloakApi.DriftPolicyHeal || !ok {
+		return true, nil
+	}
+
+	if allowed := d.triggerNamespaces[res.Kind]; len(allowed) > 0 && !allowed[namespace] {
+		return true, nil
+	}
+
+	select {
+	case trigger <- event.GenericEvent{Object: obj}:
+	case <-ctx.Done():
+	}
+
+	return true, nil
+}
+
+// setDriftedCondition sets the Drifted condition from the report and returns true if it is changed.
+func setDriftedCondition(obj helper.Conditioned, res *verify.ResourceReport) bool {
+	condition := metav1.Condition{
+		Type:    keycloakApi.ConditionDrifted,
+		Status:  metav1.ConditionFalse,
+		Reason:  keycloakApi.ReasonInSync,
+		Message: "Keycloak matches the custom resource",
+	}
+
+	switch res.Status {
+	case verify.StatusDrifted:
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionTrue, keycloakApi.ReasonDriftDetected,
+			res.Summary()
+	case verify.StatusMissing:
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionTrue, keycloakApi.ReasonMissing,
+			res.Kind+" "+res.Name+" does not exist in keycloak"
+	}
+
+	conditions := obj.GetConditions()
+
+	current := meta.FindStatusCondition(conditions, keycloakApi.ConditionDrifted)
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason &&
+		current.Message == condition.Message {
+		return false
+	}
+
+	meta.SetStatusCondition(&conditions, condition)
+	obj.SetConditions(conditions)
+
+	return true
+}
@@ -0,0 +1,203 @@
+package driftdetector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+	"github.com/epam/edp-keycloak-operator/pkg/verify"
+)
+
+// testHelper resolves the realms with the helper and returns the keycloak client mock.
+type testHelper struct {
+	*helper.Helper
+	kClient keycloak.Client
+}
+
+func (h *testHelper) CreateKeycloakClientForRealm(context.Context, *keycloakApi.KeycloakRealm) (keycloak.Client, error) {
+	return h.kClient, nil
+}
+
+func TestDetector_Detect(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	ns := "ns"
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "main", Annotations: map[string]string{
+			keycloakApi.AllowedNamespacesAnnotation:    "team",
+			keycloakApi.DriftReportConfigMapAnnotation: "drift-report",
+		}},
+		Spec:   keycloakApi.KeycloakRealmSpec{RealmName: "realm"},
+		Status: keycloakApi.KeycloakRealmStatus{Available: true, Value: helper.StatusOK},
+	}
+	inSync := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "developer"},
+		Spec:       keycloakApi.KeycloakRealmRoleSpec{Realm: "main", Name: "developer", Description: "Developers"},
+		Status:     keycloakApi.KeycloakRealmRoleStatus{Value: helper.StatusOK},
+	}
+	drifted := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "viewer",
+			Annotations: map[string]string{keycloakApi.DriftPolicyAnnotation: keycloakApi.DriftPolicyHeal}},
+		Spec:   keycloakApi.KeycloakRealmRoleSpec{Realm: "main", Name: "viewer", Description: "Viewers"},
+		Status: keycloakApi.KeycloakRealmRoleStatus{Value: helper.StatusOK},
+	}
+	missing := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "admin"},
+		Spec:       keycloakApi.KeycloakRealmRoleSpec{Realm: "main", Name: "admin"},
+		Status:     keycloakApi.KeycloakRealmRoleStatus{Value: helper.StatusOK},
+	}
+	notApplied := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "auditor"},
+		Spec:       keycloakApi.KeycloakRealmRoleSpec{Realm: "main", Name: "auditor"},
+		Status:     keycloakApi.KeycloakRealmRoleStatus{Value: "unable to sync realm role"},
+	}
+	// the changed spec is not applied yet, so keycloak differs from it
+	changed := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "tester", Generation: 2},
+		Spec:       keycloakApi.KeycloakRealmRoleSpec{Realm: "main", Name: "tester", Description: "New"},
+		Status:     keycloakApi.KeycloakRealmRoleStatus{Value: helper.StatusOK, AppliedGeneration: 1},
+	}
+
+	// the role of another namespace references the realm by the realm namespace
+	otherNamespace := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "team-viewer"},
+		Spec: keycloakApi.KeycloakRealmRoleSpec{Realm: "main", RealmNamespace: ns, Name: "viewer",
+			Description: "Viewers"},
+		Status: keycloakApi.KeycloakRealmRoleStatus{Value: helper.StatusOK},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).
+		WithObjects(&realm, &inSync, &drifted, &missing, &notApplied, &changed, &otherNamespace).Build()
+
+	kClient := adapter.Mock{}
+	kClient.On("ExportRealm", "realm").Return(&adapter.RealmExport{
+		Realm: "realm",
+		Roles: adapter.ExportRoles{Realm: []gocloak.Role{
+			{Name: gocloak.StringP("developer"), Description: gocloak.StringP("Developers")},
+			{Name: gocloak.StringP("viewer"), Description: gocloak.StringP("Changed in keycloak")},
+			{Name: gocloak.StringP("tester"), Description: gocloak.StringP("Old")},
+		}},
+	}, nil)
+
+	h := testHelper{Helper: helper.MakeHelper(k8sClient, sch, mock.NewLogr()), kClient: &kClient}
+
+	d := NewDetector(k8sClient, mock.NewLogr(), &h, time.Minute, keycloakApi.DriftPolicyAlert)
+
+	d.Trigger("KeycloakRealmRole")
+
+	healed := make(chan event.GenericEvent, 1)
+
+	go func() {
+		for e := range d.triggers["KeycloakRealmRole"] {
+			select {
+			case healed <- e:
+			default:
+			}
+		}
+	}()
+
+	require.NoError(t, d.Detect(context.Background()))
+
+	select {
+	case e := <-healed:
+		require.Equal(t, "viewer", e.Object.GetName())
+	case <-time.After(time.Second):
+		t.Fatal("drifted role with the heal policy is not reconciled")
+	}
+
+	conditionsIn := func(namespace, name string) []metav1.Condition {
+		var role keycloakApi.KeycloakRealmRole
+		require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name},
+			&role))
+
+		return role.Status.Conditions
+	}
+
+	conditions := func(name string) []metav1.Condition {
+		return conditionsIn(ns, name)
+	}
+
+	cond := meta.FindStatusCondition(conditions("viewer"), keycloakApi.ConditionDrifted)
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionTrue, cond.Status)
+	require.Equal(t, keycloakApi.ReasonDriftDetected, cond.Reason)
+	require.Contains(t, cond.Message, `description: "Changed in keycloak" -> "Viewers"`)
+
+	cond = meta.FindStatusCondition(conditions("admin"), keycloakApi.ConditionDrifted)
+	require.NotNil(t, cond)
+	require.Equal(t, keycloakApi.ReasonMissing, cond.Reason)
+
+	require.True(t, meta.IsStatusConditionFalse(conditions("developer"), keycloakApi.ConditionDrifted))
+	require.Empty(t, conditions("auditor"))
+	require.Empty(t, conditions("tester"))
+
+	require.Equal(t, 1.0, testutil.ToFloat64(driftedResources.WithLabelValues(ns, "KeycloakRealmRole", "viewer")))
+	require.Equal(t, 1.0, testutil.ToFloat64(driftedResources.WithLabelValues(ns, "KeycloakRealmRole", "admin")))
+	require.Equal(t, 0.0, testutil.ToFloat64(driftedResources.WithLabelValues(ns, "KeycloakRealmRole", "developer")))
+	require.True(t, meta.IsStatusConditionTrue(conditionsIn("team", "team-viewer"), keycloakApi.ConditionDrifted))
+	require.Equal(t, 1.0, testutil.ToFloat64(driftedResources.WithLabelValues("team", "KeycloakRealmRole", "team-viewer")))
+	require.Equal(t, 5, testutil.CollectAndCount(driftedResources), "the realm and its applied roles are measured")
+
+	var report corev1.ConfigMap
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: "drift-report"},
+		&report))
+	require.Contains(t, report.Data[verify.ReportKey(verify.OutputYAML)], "name: team-viewer")
+
+	// the metrics of the deleted resources are removed by the next detection
+	require.NoError(t, k8sClient.Delete(context.Background(), &missing))
+	require.NoError(t, d.Detect(context.Background()))
+	require.Equal(t, 4, testutil.CollectAndCount(driftedResources))
+
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: realm.Name}, &realm))
+	realm.Status.Available = false
+	require.NoError(t, k8sClient.Status().Update(context.Background(), &realm))
+	require.NoError(t, d.Detect(context.Background()))
+	require.Equal(t, 0, testutil.CollectAndCount(driftedResources))
+}
+
+func TestDetector_handle_NotFound(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	d := NewDetector(fake.NewClientBuilder().WithScheme(sch).Build(), mock.NewLogr(), &helper.Mock{}, time.Minute,
+		keycloakApi.DriftPolicyAlert)
+
+	measured, err := d.handle(context.Background(), &verify.ResourceReport{Kind: "KeycloakRealmRole", Namespace: "ns",
+		Name: "deleted", Status: verify.StatusDrifted})
+	require.NoError(t, err)
+	require.False(t, measured)
+
+	measured, err = d.handle(context.Background(), &verify.ResourceReport{Kind: "KeycloakClientScope", Namespace: "ns",
+		Name: "scope", Status: verify.StatusDrifted})
+	require.NoError(t, err)
+	require.False(t, measured, "the kinds without the Drifted condition are only reported")
+}
+
+func TestSetDriftedCondition(t *testing.T) {
+	role := keycloakApi.KeycloakRealmRole{}
+
+	require.True(t, setDriftedCondition(&role, &verify.ResourceReport{Status: verify.StatusInSync}))
+	require.False(t, setDriftedCondition(&role, &verify.ResourceReport{Status: verify.StatusInSync}))
+	require.True(t, setDriftedCondition(&role, &verify.ResourceReport{Kind: "KeycloakRealmRole", Name: "role",
+		Status: verify.StatusDrifted, Diffs: []verify.FieldDiff{{Field: "description", Desired: "a", Live: "b"}}}))
+	require.True(t, meta.IsStatusConditionTrue(role.Status.Conditions, keycloakApi.ConditionDrifted))
+}
@@ -0,0 +1,58 @@
+package driftdetector
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakclient"
+)
+
+type RealmOwnerHelper interface {
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *metav1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+}
+
+// RealmResolver resolves the realms of the custom resources with the helper the controllers use,
+// so the realm selectors, the realm namespaces and the owner references are taken into account.
+type RealmResolver struct {
+	client client.Client
+	helper RealmOwnerHelper
+}
+
+func NewRealmResolver(client client.Client, helper RealmOwnerHelper) *RealmResolver {
+	return &RealmResolver{client: client, helper: helper}
+}
+
+// ResolveRealm returns the realm of the custom resource, the resource is not changed.
+func (r *RealmResolver) ResolveRealm(obj client.Object) (*keycloakApi.KeycloakRealm, error) {
+	copied, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, errors.Errorf("unable to copy %T", obj)
+	}
+
+	var child helper.RealmChild
+
+	switch o := copied.(type) {
+	case *keycloakApi.KeycloakClient:
+		child = keycloakclient.NewRealmChild(r.client, o)
+	case helper.RealmChild:
+		child = o
+	default:
+		return nil, errors.Errorf("%T is not a realm child", obj)
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		OwnerReferences: obj.GetOwnerReferences(),
+	}
+
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(child, &objectMeta)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get realm of %s", obj.GetName())
+	}
+
+	return realm, nil
+}
@@ -0,0 +1,192 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const (
+	// circuitFailureThreshold is a number of the consecutive failures of keycloak which open the circuit.
+	circuitFailureThreshold = 5
+	circuitOpenTimeout      = 30 * time.Second
+	circuitMaxOpenTimeout   = 5 * time.Minute
+)
+
+// KeycloakUnavailableError is returned without sending the request when the circuit of the keycloak is open.
+type KeycloakUnavailableError string
+
+func (e KeycloakUnavailableError) Error() string {
+	return string(e)
+}
+
+func keycloakUnavailable(nn types.NamespacedName, retryAt time.Time) error {
+	return KeycloakUnavailableError(fmt.Sprintf("keycloak %s is unavailable, the requests are suspended until %s",
+		nn.String(), retryAt.Format(time.RFC3339)))
+}
+
+// circuitBreaker stops the requests to the keycloak after its consecutive failures, so the custom resources
+// fail fast instead of waiting for the timeouts while keycloak is down. When the open timeout elapses, a single
+// probe request is let through: the circuit is closed if it succeeds and it is opened again with the doubled
+// timeout if it fails.
+type circuitBreaker struct {
+	mu          sync.Mutex
+	failures    int
+	openUntil   time.Time
+	openTimeout time.Duration
+	probing     bool
+	// onOpen is called when the circuit is opened, so the keycloak shows its degraded state
+	// without waiting for its next reconciliation.
+	onOpen func()
+}
+
+// allow reports whether the request can be sent at the time.
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return true
+	}
+
+	return b.startProbe(now)
+}
+
+// tryProbe starts the probe if the circuit is open and the open timeout has elapsed.
+func (b *circuitBreaker) tryProbe(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return !b.openUntil.IsZero() && b.startProbe(now)
+}
+
+func (b *circuitBreaker) startProbe(now time.Time) bool {
+	if now.Before(b.openUntil) || b.probing {
+		return false
+	}
+
+	b.probing = true
+
+	return true
+}
+
+// cancelProbe lets the next request probe the keycloak if the probe request is canceled before the response.
+func (b *circuitBreaker) cancelProbe() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *circuitBreaker) succeed() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.openUntil = time.Time{}
+	b.openTimeout = 0
+	b.probing = false
+}
+
+func (b *circuitBreaker) fail(now time.Time) {
+	if b.open(now) && b.onOpen != nil {
+		b.onOpen()
+	}
+}
+
+// open counts the failure and reports whether the circuit is opened by it.
+func (b *circuitBreaker) open(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.openUntil.IsZero() {
+		// the failures of the requests sent before the circuit is opened do not extend the open timeout
+		if !b.probing {
+			return false
+		}
+
+		b.openTimeout *= 2
+		if b.openTimeout > circuitMaxOpenTimeout {
+			b.openTimeout = circuitMaxOpenTimeout
+		}
+
+		b.openUntil = now.Add(b.openTimeout)
+		b.probing = false
+
+		return true
+	}
+
+	b.failures++
+	if b.failures < circuitFailureThreshold {
+		return false
+	}
+
+	b.openTimeout = circuitOpenTimeout
+	b.openUntil = now.Add(b.openTimeout)
+
+	return true
+}
+
+// state returns whether the circuit is open and the time of the next probe.
+func (b *circuitBreaker) state() (retryAt time.Time, open bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.openUntil, !b.openUntil.IsZero()
+}
+
+// install makes the resty client of the keycloak send the requests through the circuit breaker.
+// The 502, 503 and 504 responses and the connection errors are the failures of keycloak,
+// the health checks bypass the circuit breaker.
+func (b *circuitBreaker) install(c *resty.Client, nn types.NamespacedName) {
+	c.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if adapter.IsHealthCheck(req.Context()) || b.allow(time.Now()) {
+			return nil
+		}
+
+		retryAt, _ := b.state()
+
+		return keycloakUnavailable(nn, retryAt)
+	})
+
+	c.OnAfterResponse(func(_ *resty.Client, rsp *resty.Response) error {
+		if adapter.IsHealthCheck(rsp.Request.Context()) {
+			return nil
+		}
+
+		switch rsp.StatusCode() {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			b.fail(time.Now())
+		default:
+			b.succeed()
+		}
+
+		return nil
+	})
+
+	c.OnError(func(req *resty.Request, err error) {
+		var unavailable KeycloakUnavailableError
+		if adapter.IsHealthCheck(req.Context()) || errors.As(err, &unavailable) {
+			return
+		}
+
+		// The request which waits too long for the rate limit or is stopped by the caller tells nothing
+		// about keycloak. The timeouts of the HTTP client itself are the failures of keycloak.
+		var limited rateLimitError
+		if errors.As(err, &limited) || errors.Is(err, context.Canceled) || req.Context().Err() != nil {
+			b.cancelProbe()
+
+			return
+		}
+
+		b.fail(time.Now())
+	})
+}
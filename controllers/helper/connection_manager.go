@@ -0,0 +1,132 @@
+package helper
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	keycloakRequestTimeout      = 30 * time.Second
+	keycloakIdleConnTimeout     = 90 * time.Second
+	keycloakMaxIdleConnsPerHost = 10
+	keycloakDialTimeout         = 10 * time.Second
+	keycloakKeepAlive           = 30 * time.Second
+	keycloakTLSHandshakeTimeout = 10 * time.Second
+)
+
+// instanceRestyClient is the HTTP client of the keycloak with the settings it is created with.
+type instanceRestyClient struct {
+	settings string
+	client   *resty.Client
+}
+
+// connectionManager hands out the HTTP clients of the keycloak instances shared by all controllers,
+// so the connections to keycloak are kept alive between the reconciliations instead of being opened
+// by every reconciliation. The client of the instance is created again when its HTTP settings change.
+type connectionManager struct {
+	mu       sync.Mutex
+	clients  map[types.NamespacedName]instanceRestyClient
+	breakers map[types.NamespacedName]*circuitBreaker
+	limiters map[types.NamespacedName]*rate.Limiter
+	// global limits the requests to all keycloak instances together.
+	global    *rate.Limiter
+	newClient func() *resty.Client
+	// onCircuitOpen is called when the circuit of the keycloak is opened.
+	onCircuitOpen func(nn types.NamespacedName)
+}
+
+func newConnectionManager(newClient func() *resty.Client) *connectionManager {
+	return &connectionManager{
+		clients:   make(map[types.NamespacedName]instanceRestyClient),
+		breakers:  make(map[types.NamespacedName]*circuitBreaker),
+		limiters:  make(map[types.NamespacedName]*rate.Limiter),
+		global:    rate.NewLimiter(rate.Inf, 0),
+		newClient: newClient,
+	}
+}
+
+// get returns the client of the keycloak with the settings, the new client is configured with the configure function.
+func (m *connectionManager) get(nn types.NamespacedName, settings string,
+	configure func(c *resty.Client)) *resty.Client {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.clients[nn]; ok {
+		if c.settings == settings {
+			return c.client
+		}
+
+		closeIdleConnections(c.client)
+	}
+
+	c := m.newClient()
+	configure(c)
+	m.getBreaker(nn).install(c, nn)
+	m.installRateLimiter(c, nn)
+
+	m.clients[nn] = instanceRestyClient{settings: settings, client: c}
+
+	return c
+}
+
+// release removes the client of the deleted keycloak and closes its idle connections.
+func (m *connectionManager) release(nn types.NamespacedName) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.clients[nn]; ok {
+		closeIdleConnections(c.client)
+		delete(m.clients, nn)
+	}
+
+	delete(m.breakers, nn)
+	delete(m.limiters, nn)
+}
+
+// breaker returns the circuit breaker of the keycloak, it is shared by the clients of the keycloak.
+func (m *connectionManager) breaker(nn types.NamespacedName) *circuitBreaker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.getBreaker(nn)
+}
+
+func (m *connectionManager) getBreaker(nn types.NamespacedName) *circuitBreaker {
+	b, ok := m.breakers[nn]
+	if !ok {
+		b = &circuitBreaker{}
+		if m.onCircuitOpen != nil {
+			b.onOpen = func() { m.onCircuitOpen(nn) }
+		}
+
+		m.breakers[nn] = b
+	}
+
+	return b
+}
+
+// newKeycloakTransport creates the transport which keeps the connections to keycloak alive
+// between the reconciliations.
+func newKeycloakTransport() *http.Transport {
+	dialer := &net.Dialer{Timeout: keycloakDialTimeout, KeepAlive: keycloakKeepAlive}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConnsPerHost:   keycloakMaxIdleConnsPerHost,
+		IdleConnTimeout:       keycloakIdleConnTimeout,
+		TLSHandshakeTimeout:   keycloakTLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+func closeIdleConnections(c *resty.Client) {
+	c.GetClient().CloseIdleConnections()
+}
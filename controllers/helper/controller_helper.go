@@ -3,6 +3,7 @@ package helper
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
@@ -12,38 +13,105 @@ import (
 	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/vault"
 )
 
 const (
 	DefaultRequeueTime       = 120 * time.Second
 	StatusOK                 = "OK"
 	localConfigsRelativePath = "build/configs"
+	vaultRequestTimeout      = 30 * time.Second
+	// circuitEventsBuffer is a number of the keycloaks with the opened circuit waiting for the reconciliation.
+	circuitEventsBuffer = 100
 )
 
-type adapterBuilder func(ctx context.Context, url, user, password, adminType string, log logr.Logger,
+type adapterBuilder func(ctx context.Context, url, user, password, adminType, realm string, log logr.Logger,
 	restyClient *resty.Client) (keycloak.Client, error)
 
 type Helper struct {
-	client          client.Client
-	scheme          *runtime.Scheme
+	client client.Client
+	scheme *runtime.Scheme
+	// restyClient is used instead of the shared client of the keycloak without the custom HTTP settings if it is set.
 	restyClient     *resty.Client
 	logger          logr.Logger
 	adapterBuilder  adapterBuilder
 	tokenSecretLock *sync.Mutex
+	watchSelector   labels.Selector
+	// credentialsKey is the key of the HMAC of the admin credentials stored with the tokens.
+	credentialsKey     []byte
+	credentialsKeyErr  error
+	credentialsKeyOnce sync.Once
+
+	// pageSize is the number of the items requested at once by the list operations of the keycloak clients.
+	pageSize int
+
+	// connections are the HTTP clients of the keycloak instances shared by the controllers.
+	connections     *connectionManager
+	connectionsOnce sync.Once
+	circuitEvents   chan event.GenericEvent
+
+	vault  *vault.CredentialProvider
+	tokens tokenStore
+
+	serviceAccountToken ServiceAccountTokenConfig
+
+	// issuedTokens are the access tokens used by the operator keyed by the namespaced name of the stored token,
+	// the stored token is removed when keycloak rejects its access token.
+	issuedTokens     map[types.NamespacedName]string
+	issuedTokensLock sync.Mutex
+
+	// baseURLs are the detected base URLs of the keycloak API keyed by the keycloak URLs.
+	baseURLs     map[string]string
+	baseURLsLock sync.Mutex
 }
 
 func (h *Helper) TokenSecretLock() *sync.Mutex {
 	return h.tokenSecretLock
 }
 
+// SetWatchSelector sets the label selector of the custom resources handled by the operator instance.
+// The selector is recorded as the owner of the finalizers added by the instance.
+func (h *Helper) SetWatchSelector(selector labels.Selector) {
+	h.watchSelector = selector
+}
+
+// SetPageSize sets the number of the users, groups and clients the keycloak clients request at once,
+// the default page size of the adapter is used if the size is not positive.
+func (h *Helper) SetPageSize(size int) {
+	h.pageSize = size
+}
+
+// UseInMemoryTokenCache keeps the keycloak tokens in the memory of the operator instead of the kc-token secrets,
+// so the tokens are not stored at rest in etcd.
+func (h *Helper) UseInMemoryTokenCache() {
+	h.tokens = newMemoryTokenStore(h.client)
+}
+
+// SetVault sets the Vault the admin credentials of the keycloaks are read from.
+// The keycloaks with the vault credentials are rejected if it is not set.
+func (h *Helper) SetVault(cfg vault.Config) {
+	h.vault = vault.NewCredentialProvider(&http.Client{Timeout: vaultRequestTimeout}, cfg)
+}
+
+// getTokenStore returns the store of the keycloak tokens, the tokens are stored in the secrets by default.
+func (h *Helper) getTokenStore() tokenStore {
+	if h.tokens == nil {
+		return &secretTokenStore{client: h.client}
+	}
+
+	return h.tokens
+}
+
 func (h *Helper) GetScheme() *runtime.Scheme {
 	return h.scheme
 }
@@ -51,6 +119,7 @@ func (h *Helper) GetScheme() *runtime.Scheme {
 func MakeHelper(client client.Client, scheme *runtime.Scheme, logger logr.Logger) *Helper {
 	return &Helper{
 		tokenSecretLock: new(sync.Mutex),
+		circuitEvents:   make(chan event.GenericEvent, circuitEventsBuffer),
 		client:          client,
 		scheme:          scheme,
 		logger:          logger,
@@ -59,20 +128,29 @@ func MakeHelper(client client.Client, scheme *runtime.Scheme, logger logr.Logger
 			url,
 			user,
 			password,
-			adminType string,
+			adminType,
+			realm string,
 			log logr.Logger,
 			restyClient *resty.Client,
 		) (keycloak.Client, error) {
-			if adminType == keycloakApi.KeycloakAdminTypeServiceAccount {
-				goKeycloakAdapter, err := adapter.MakeFromServiceAccount(ctx, url, user, password, "master", log, restyClient)
+			switch adminType {
+			case keycloakApi.KeycloakAdminTypeServiceAccount:
+				goKeycloakAdapter, err := adapter.MakeFromServiceAccount(ctx, url, user, password, realm, log, restyClient)
 				if err != nil {
 					return nil, fmt.Errorf("failed to make go keycloak adapter from seviceaccount: %w", err)
 				}
 
+				return goKeycloakAdapter, nil
+			case keycloakApi.KeycloakAdminTypeServiceAccountToken:
+				goKeycloakAdapter, err := adapter.MakeFromServiceAccountToken(ctx, url, user, password, realm, log, restyClient)
+				if err != nil {
+					return nil, fmt.Errorf("failed to make go keycloak adapter from seviceaccount token: %w", err)
+				}
+
 				return goKeycloakAdapter, nil
 			}
 
-			goKeycloakAdapter, err := adapter.Make(ctx, url, user, password, log, restyClient)
+			goKeycloakAdapter, err := adapter.MakeInRealm(ctx, url, user, password, realm, log, restyClient)
 			if err != nil {
 				return nil, fmt.Errorf("failed to make go keycloak adapter: %w", err)
 			}
@@ -90,7 +168,7 @@ func (e OwnerNotFoundError) Error() string {
 
 func (h *Helper) GetOwnerKeycloak(slave *v1.ObjectMeta) (*keycloakApi.Keycloak, error) {
 	var kc keycloakApi.Keycloak
-	if err := h.GetOwner(slave, &kc, "Keycloak"); err != nil {
+	if err := h.GetOwner(slave, &kc, keycloakApi.KeycloakKind); err != nil {
 		return nil, errors.Wrap(err, "unable to get keycloak owner")
 	}
 
@@ -220,7 +298,18 @@ func (h *Helper) getKeycloakFromSpec(realm *keycloakApi.KeycloakRealm) (*keycloa
 	return &k, nil
 }
 
+// GetOrCreateKeycloakOwnerRef returns the Keycloak of the realm.
+// The explicit keycloakRef takes precedence over the owner reference and the keycloakOwner.
 func (h *Helper) GetOrCreateKeycloakOwnerRef(realm *keycloakApi.KeycloakRealm) (*keycloakApi.Keycloak, error) {
+	if realm.Spec.KeycloakRef != nil {
+		kc, err := h.getKeycloakFromRef(realm.Spec.KeycloakRef, realm.Namespace)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get keycloak from ref")
+		}
+
+		return kc, nil
+	}
+
 	o, err := h.GetOwnerKeycloak(&realm.ObjectMeta)
 	if err != nil {
 		ownerNotFoundErr := OwnerNotFoundError("")
@@ -238,6 +327,24 @@ func (h *Helper) GetOrCreateKeycloakOwnerRef(realm *keycloakApi.KeycloakRealm) (
 	return o, nil
 }
 
+// getKeycloakFromRef returns the referenced Keycloak, the namespace is used if the ref has no namespace.
+func (h *Helper) getKeycloakFromRef(ref *keycloakApi.KeycloakRef, namespace string) (*keycloakApi.Keycloak, error) {
+	if ref.Kind != "" && ref.Kind != keycloakApi.KeycloakKind {
+		return nil, errors.Errorf("keycloak kind %s is not supported", ref.Kind)
+	}
+
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+
+	var kc keycloakApi.Keycloak
+	if err := h.client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: namespace}, &kc); err != nil {
+		return nil, errors.Wrapf(err, "unable to get keycloak %s/%s from k8s", namespace, ref.Name)
+	}
+
+	return &kc, nil
+}
+
 func (h *Helper) getKeycloakRealm(object v1.Object, name string) (*keycloakApi.KeycloakRealm, error) {
 	var realm keycloakApi.KeycloakRealm
 	if err := h.client.Get(context.TODO(), types.NamespacedName{
@@ -260,7 +367,77 @@ type RealmChild interface {
 	v1.Object
 }
 
+// ClusterRealmChild is a realm child which can reference the ClusterKeycloakRealm instead of the KeycloakRealm.
+type ClusterRealmChild interface {
+	ClusterRealmName() string
+}
+
+// CrossNamespaceRealmChild is a realm child which can reference the realm in another namespace.
+type CrossNamespaceRealmChild interface {
+	GetRealmNamespace() string
+}
+
+// RealmSelectorChild is a realm child which can select the realm by the labels.
+type RealmSelectorChild interface {
+	GetRealmSelector() *v1.LabelSelector
+}
+
+// KeycloakRefHolder is a resource which can reference the Keycloak explicitly.
+type KeycloakRefHolder interface {
+	GetKeycloakRef() *keycloakApi.KeycloakRef
+}
+
+// GetOrCreateRealmOwnerRef returns the realm of the object.
+// The explicit keycloakRef of the object is set to the realm, so the object is handled in the referenced Keycloak.
+// Only the Keycloak from the namespace of the object can be referenced, so the object can not use the Keycloak
+// of another namespace bypassing the allowed namespaces of the realms.
 func (h *Helper) GetOrCreateRealmOwnerRef(object RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error) {
+	realm, err := h.getParentRealm(object, objectMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	if holder, ok := object.(KeycloakRefHolder); ok && holder.GetKeycloakRef() != nil {
+		ref := *holder.GetKeycloakRef()
+		if ref.Namespace == "" {
+			ref.Namespace = object.GetNamespace()
+		}
+
+		if ref.Namespace != object.GetNamespace() {
+			return nil, errors.Errorf("keycloak %s/%s can not be referenced from namespace %s",
+				ref.Namespace, ref.Name, object.GetNamespace())
+		}
+
+		realm.Spec.KeycloakRef = &ref
+	}
+
+	return realm, nil
+}
+
+func (h *Helper) getParentRealm(object RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error) {
+	if c, ok := object.(ClusterRealmChild); ok && c.ClusterRealmName() != "" {
+		return h.getClusterKeycloakRealm(c.ClusterRealmName(), object.GetNamespace())
+	}
+
+	if c, ok := object.(RealmSelectorChild); ok && c.GetRealmSelector() != nil {
+		realmNamespace := object.GetNamespace()
+		if nc, ok := object.(CrossNamespaceRealmChild); ok && nc.GetRealmNamespace() != "" {
+			realmNamespace = nc.GetRealmNamespace()
+		}
+
+		return h.getRealmBySelector(c.GetRealmSelector(), realmNamespace, object.GetNamespace())
+	}
+
+	if c, ok := object.(CrossNamespaceRealmChild); ok && c.GetRealmNamespace() != "" &&
+		c.GetRealmNamespace() != object.GetNamespace() {
+		parentRealm, err := object.K8SParentRealmName()
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable get parent realm for: %+v", object)
+		}
+
+		return h.getCrossNamespaceRealm(parentRealm, c.GetRealmNamespace(), object.GetNamespace())
+	}
+
 	realm, err := h.GetOwnerKeycloakRealm(objectMeta)
 	if err != nil {
 		ownerNotFoundErr := OwnerNotFoundError("")
@@ -285,6 +462,67 @@ func (h *Helper) GetOrCreateRealmOwnerRef(object RealmChild, objectMeta *v1.Obje
 	return realm, nil
 }
 
+// getClusterKeycloakRealm returns the cluster realm converted to the KeycloakRealm.
+// The owner reference is not set, so the children are not removed with the cluster realm.
+func (h *Helper) getClusterKeycloakRealm(name, namespace string) (*keycloakApi.KeycloakRealm, error) {
+	var clusterRealm keycloakApi.ClusterKeycloakRealm
+	if err := h.client.Get(context.TODO(), types.NamespacedName{Name: name}, &clusterRealm); err != nil {
+		return nil, errors.Wrap(err, "unable to get cluster realm from k8s")
+	}
+
+	if !clusterRealm.IsNamespaceAllowed(namespace) {
+		return nil, errors.Errorf("cluster realm %s can not be referenced from namespace %s", name, namespace)
+	}
+
+	return clusterRealm.ToKeycloakRealm(), nil
+}
+
+// getCrossNamespaceRealm returns the realm from another namespace if it allows the namespace of the child.
+// The owner reference is not set, because the cross namespace owner references are not supported by k8s.
+func (h *Helper) getCrossNamespaceRealm(name, realmNamespace, namespace string) (*keycloakApi.KeycloakRealm, error) {
+	var realm keycloakApi.KeycloakRealm
+	if err := h.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: realmNamespace},
+		&realm); err != nil {
+		return nil, errors.Wrap(err, "unable to get realm from k8s")
+	}
+
+	if !realm.IsNamespaceAllowed(namespace) {
+		return nil, errors.Errorf("realm %s/%s can not be referenced from namespace %s, see the %s annotation",
+			realmNamespace, name, namespace, keycloakApi.AllowedNamespacesAnnotation)
+	}
+
+	return &realm, nil
+}
+
+// getRealmBySelector returns the only realm matching the selector.
+// The owner reference is not set, because the matching realm can change with the labels.
+func (h *Helper) getRealmBySelector(selector *v1.LabelSelector, realmNamespace, namespace string) (*keycloakApi.KeycloakRealm, error) {
+	sel, err := v1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse realm selector")
+	}
+
+	var realmList keycloakApi.KeycloakRealmList
+	if err = h.client.List(context.TODO(), &realmList, client.InNamespace(realmNamespace),
+		client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return nil, errors.Wrap(err, "unable to list realms by selector")
+	}
+
+	if len(realmList.Items) != 1 {
+		return nil, errors.Errorf("exactly one realm must match the selector %s in namespace %s, found %d",
+			sel, realmNamespace, len(realmList.Items))
+	}
+
+	realm := realmList.Items[0]
+
+	if realmNamespace != namespace && !realm.IsNamespaceAllowed(namespace) {
+		return nil, errors.Errorf("realm %s/%s can not be referenced from namespace %s, see the %s annotation",
+			realmNamespace, realm.Name, namespace, keycloakApi.AllowedNamespacesAnnotation)
+	}
+
+	return &realm, nil
+}
+
 func (h *Helper) UpdateStatus(obj client.Object) error {
 	if err := h.client.Status().Update(context.TODO(), obj); err != nil {
 		return errors.Wrap(err, "unable to update object status")
@@ -307,13 +545,21 @@ func (h *Helper) TryToDelete(ctx context.Context, obj Deletable, terminator Term
 	finalizers := obj.GetFinalizers()
 	logger := terminator.GetLogger()
 
+	ownerChanged, err := h.takeFinalizerOwnership(obj)
+	if err != nil {
+		return false, err
+	}
+
 	if obj.GetDeletionTimestamp().IsZero() {
 		logger.Info("instance timestamp is zero")
 
-		if !ContainsString(finalizers, finalizer) {
+		if !ContainsString(finalizers, finalizer) || ownerChanged {
 			logger.Info("instance has not finalizers, adding...")
 
-			finalizers = append(finalizers, finalizer)
+			if !ContainsString(finalizers, finalizer) {
+				finalizers = append(finalizers, finalizer)
+			}
+
 			obj.SetFinalizers(finalizers)
 
 			if err := h.client.Update(ctx, obj); err != nil {
@@ -346,6 +592,44 @@ func (h *Helper) TryToDelete(ctx context.Context, obj Deletable, terminator Term
 	return true, nil
 }
 
+// takeFinalizerOwnership checks that the finalizer of the object is not owned by another operator instance
+// and records the watch selector of this instance as the owner. The ownership is taken over
+// if the selector of the previous owner does not match the object anymore.
+func (h *Helper) takeFinalizerOwnership(obj Deletable) (changed bool, err error) {
+	shard := ""
+	if h.watchSelector != nil {
+		shard = h.watchSelector.String()
+	}
+
+	annotations := obj.GetAnnotations()
+
+	owner, ok := annotations[keycloakApi.FinalizerOwnerAnnotation]
+	if owner == shard {
+		return false, nil
+	}
+
+	if ok {
+		ownerSelector, err := labels.Parse(owner)
+		if err == nil && ownerSelector.Matches(labels.Set(obj.GetLabels())) {
+			return false, errors.Errorf("finalizer of the resource is owned by the operator instance watching %q", owner)
+		}
+	}
+
+	if shard == "" {
+		delete(annotations, keycloakApi.FinalizerOwnerAnnotation)
+	} else {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+
+		annotations[keycloakApi.FinalizerOwnerAnnotation] = shard
+	}
+
+	obj.SetAnnotations(annotations)
+
+	return true, nil
+}
+
 func CreatePathToTemplateDirectory(directory string) string {
 	return fmt.Sprintf("%s/%s", localConfigsRelativePath, directory)
 }
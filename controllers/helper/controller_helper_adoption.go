@@ -0,0 +1,53 @@
+package helper
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+)
+
+// AdoptionSkippedError is returned when the existing keycloak object is skipped because of the adoption policy.
+type AdoptionSkippedError string
+
+func (e AdoptionSkippedError) Error() string {
+	return string(e)
+}
+
+func IsAdoptionSkipped(err error) bool {
+	errSkipped := AdoptionSkippedError("")
+
+	return errors.As(err, &errSkipped)
+}
+
+// GetAdoptionPolicy returns the adoption policy of the object or the default policy if it is not set.
+func GetAdoptionPolicy(obj metav1.Object, defaultPolicy string) string {
+	if policy := obj.GetAnnotations()[keycloakApi.AdoptionPolicyAnnotation]; policy != "" {
+		return policy
+	}
+
+	return defaultPolicy
+}
+
+// CheckAdoption checks whether the existing keycloak object can be taken over by the custom resource.
+// It returns nil for the adopt policy, AdoptionSkippedError for the skip policy and an error otherwise.
+func CheckAdoption(obj metav1.Object, defaultPolicy, kind, name string) error {
+	switch policy := GetAdoptionPolicy(obj, defaultPolicy); policy {
+	case keycloakApi.AdoptionPolicyAdopt:
+		return nil
+	case keycloakApi.AdoptionPolicySkip:
+		return AdoptionSkippedError(kind + " " + name + " already exists in keycloak and is skipped")
+	case keycloakApi.AdoptionPolicyFail:
+		return errors.Errorf("%s %s already exists in keycloak, set the %s annotation to %s to take it over",
+			kind, name, keycloakApi.AdoptionPolicyAnnotation, keycloakApi.AdoptionPolicyAdopt)
+	default:
+		return errors.Errorf("unknown adoption policy %s, supported policies are %s, %s and %s", policy,
+			keycloakApi.AdoptionPolicyAdopt, keycloakApi.AdoptionPolicyFail, keycloakApi.AdoptionPolicySkip)
+	}
+}
+
+// SetSkippedStatus resets the failure count and sets the skipped status.
+func SetSkippedStatus(obj StatusValueFailureCountable) {
+	obj.SetFailureCount(0)
+	obj.SetStatus(keycloakApi.StatusSkipped)
+}
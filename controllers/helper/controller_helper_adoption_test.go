@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+)
+
+func TestCheckAdoption(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		defaultPolicy string
+		wantErr       require.ErrorAssertionFunc
+		wantSkipped   bool
+	}{
+		{
+			name:          "default adopt",
+			defaultPolicy: keycloakApi.AdoptionPolicyAdopt,
+			wantErr:       require.NoError,
+		},
+		{
+			name:          "annotation overrides default",
+			annotations:   map[string]string{keycloakApi.AdoptionPolicyAnnotation: keycloakApi.AdoptionPolicySkip},
+			defaultPolicy: keycloakApi.AdoptionPolicyAdopt,
+			wantErr:       require.Error,
+			wantSkipped:   true,
+		},
+		{
+			name:          "fail",
+			annotations:   map[string]string{keycloakApi.AdoptionPolicyAnnotation: keycloakApi.AdoptionPolicyFail},
+			defaultPolicy: keycloakApi.AdoptionPolicyAdopt,
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "client app already exists in keycloak")
+			},
+		},
+		{
+			name:          "unknown policy",
+			annotations:   map[string]string{keycloakApi.AdoptionPolicyAnnotation: "merge"},
+			defaultPolicy: keycloakApi.AdoptionPolicyAdopt,
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "unknown adoption policy merge")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			obj := &keycloakApi.KeycloakClient{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+
+			err := CheckAdoption(obj, tt.defaultPolicy, "client", "app")
+			tt.wantErr(t, err)
+			require.Equal(t, tt.wantSkipped, IsAdoptionSkipped(errors.Wrap(err, "wrapped")))
+		})
+	}
+}
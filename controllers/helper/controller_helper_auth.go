@@ -2,13 +2,22 @@ package helper
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
@@ -16,23 +25,100 @@ import (
 )
 
 const (
-	keycloakTokenSecretPrefix = "kc-token-"
-	keycloakTokenSecretKey    = "token"
+	keycloakTokenSecretPrefix      = "kc-token-"
+	keycloakRealmTokenSecretPrefix = "kc-realm-token-"
+	keycloakTokenSecretKey         = "token"
+	keycloakTokenSecretURLKey      = "url"
+	// keycloakTokenSecretCredentialsKey is a key of the hash of the credentials the token is issued for.
+	keycloakTokenSecretCredentialsKey = "credentials"
 )
 
+// DefaultServiceAccountTokenPath is a default path of the projected ServiceAccount token file
+// the operator authenticates to keycloak with for the serviceAccountToken admin type.
+const DefaultServiceAccountTokenPath = "/var/run/secrets/keycloak/token"
+
+// ServiceAccountTokenConfig is the projected ServiceAccount token of the operator for the serviceAccountToken admin type
+// and the keycloaks it can be sent to.
+type ServiceAccountTokenConfig struct {
+	// TokenPath is a path of the token file, the token is read on every login, so the rotated token is used.
+	TokenPath string
+	// Namespace is the namespace of the operator, the token is sent to the keycloaks in it.
+	Namespace string
+	// AllowedURLs are the keycloak URLs the token is sent to from the other namespaces.
+	AllowedURLs []string
+}
+
+// SetServiceAccountToken sets the projected ServiceAccount token of the operator for the serviceAccountToken admin type.
+func (h *Helper) SetServiceAccountToken(cfg ServiceAccountTokenConfig) {
+	h.serviceAccountToken = cfg
+}
+
+// checkServiceAccountTokenAllowed rejects the keycloaks outside of the operator namespace whose URLs are not allowed,
+// so the token of the operator is not sent as the client assertion to the URLs chosen by the tenants.
+func (h *Helper) checkServiceAccountTokenAllowed(kc *keycloakApi.Keycloak) error {
+	cfg := h.serviceAccountToken
+
+	if cfg.Namespace != "" && kc.Namespace == cfg.Namespace {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(cfg.AllowedURLs))
+	for _, u := range cfg.AllowedURLs {
+		allowed[strings.TrimSuffix(u, "/")] = true
+	}
+
+	for _, u := range kc.GetURLs() {
+		if !allowed[strings.TrimSuffix(u, "/")] {
+			return errors.Errorf(
+				"the serviceAccountToken admin type is allowed only in the operator namespace or for the allowed urls, %s is not allowed", u)
+		}
+	}
+
+	return nil
+}
+
+const (
+	tokenIssuedByLogin   = "login"
+	tokenIssuedByRefresh = "refresh"
+)
+
+var tokensIssued = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "keycloak_operator_tokens_issued_total",
+	Help: "Number of the keycloak admin tokens issued to the operator by the login or by the refresh token.",
+}, []string{"type"})
+
+func init() {
+	metrics.Registry.MustRegister(tokensIssued)
+}
+
 func (h *Helper) CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error) {
 	kc, err := h.GetOrCreateKeycloakOwnerRef(realm)
 	if err != nil {
 		return nil, err
 	}
 
-	if !kc.Status.Connected {
+	// the connected status shows that the master realm credentials are valid, they are not used by the realm admin
+	if !kc.Status.Connected && realm.Spec.AdminCredentials == nil {
 		return nil, errors.New("Owner keycloak is not in connected status")
 	}
 
+	// the custom resources fail fast while keycloak is unavailable
+	nn := types.NamespacedName{Namespace: kc.Namespace, Name: kc.Name}
+	if retryAt, open := h.getConnections().breaker(nn).state(); open && time.Now().Before(retryAt) {
+		return nil, keycloakUnavailable(nn, retryAt)
+	}
+
 	h.tokenSecretLock.Lock()
 	defer h.tokenSecretLock.Unlock()
 
+	if err = h.SelectKeycloakURL(ctx, kc); err != nil {
+		return nil, err
+	}
+
+	if realm.Spec.AdminCredentials != nil {
+		return h.createKeycloakClientForRealmAdmin(ctx, kc, realm)
+	}
+
 	clientAdapter, err := h.CreateKeycloakClientFromTokenSecret(ctx, kc)
 	if err == nil {
 		return clientAdapter, nil
@@ -51,16 +137,18 @@ func (h *Helper) CreateKeycloakClientForRealm(ctx context.Context, realm *keyclo
 }
 
 func (h *Helper) CreateKeycloakClientFromLoginPassword(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error) {
-	var secret coreV1.Secret
-	if err := h.client.Get(ctx, types.NamespacedName{
-		Name:      kc.Spec.Secret,
-		Namespace: kc.Namespace,
-	}, &secret); err != nil {
-		return nil, errors.Wrap(err, "kc login password secret not found")
+	user, password, err := h.getKeycloakCredentials(ctx, kc)
+	if err != nil {
+		return nil, err
 	}
 
-	clientAdapter, err := h.CreateKeycloakClient(ctx, kc.Spec.Url, string(secret.Data["username"]),
-		string(secret.Data["password"]), kc.GetAdminType())
+	restyClient, err := h.restyClientFor(ctx, kc)
+	if err != nil {
+		return nil, err
+	}
+
+	clientAdapter, err := h.createKeycloakClient(ctx, h.keycloakBaseURL(ctx, kc, restyClient, kc.GetActiveURL()), user,
+		password, kc.GetAdminType(), "master", restyClient)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to init kc client adapter")
 	}
@@ -70,30 +158,182 @@ func (h *Helper) CreateKeycloakClientFromLoginPassword(ctx context.Context, kc *
 		return nil, errors.Wrap(err, "unable to export kc client token")
 	}
 
-	if err := h.SaveKeycloakClientTokenSecret(ctx, kc, jwtToken); err != nil {
+	credentials, err := h.keycloakCredentialsHash(kc, user, password)
+	if err != nil {
+		return nil, err
+	}
+
+	nn := types.NamespacedName{Namespace: kc.Namespace, Name: tokenSecretName(kc.Name)}
+	if err := h.saveTokenSecret(ctx, nn, map[string][]byte{
+		keycloakTokenSecretKey:            jwtToken,
+		keycloakTokenSecretCredentialsKey: []byte(credentials),
+	}); err != nil {
 		return nil, errors.Wrap(err, "unable to save kc token to secret")
 	}
 
+	h.trackToken(nn, jwtToken)
+
 	return clientAdapter, nil
 }
 
+// getKeycloakCredentials returns the user and the password the operator logs in to the keycloak with.
+// For the serviceAccountToken admin type they are the client id and the ServiceAccount token read from the file,
+// otherwise they are read from Vault or from the secret.
+func (h *Helper) getKeycloakCredentials(ctx context.Context, kc *keycloakApi.Keycloak) (user, password string, err error) {
+	if kc.GetAdminType() == keycloakApi.KeycloakAdminTypeServiceAccountToken {
+		sat := kc.Spec.ServiceAccountToken
+		if sat == nil {
+			return "", "", errors.New("serviceAccountToken is required for the serviceAccountToken admin type")
+		}
+
+		if err := h.checkServiceAccountTokenAllowed(kc); err != nil {
+			return "", "", err
+		}
+
+		tokenPath := h.serviceAccountToken.TokenPath
+		if tokenPath == "" {
+			tokenPath = DefaultServiceAccountTokenPath
+		}
+
+		token, err := os.ReadFile(tokenPath)
+		if err != nil {
+			return "", "", errors.Wrap(err, "unable to read service account token")
+		}
+
+		return sat.ClientID, strings.TrimSpace(string(token)), nil
+	}
+
+	if v := kc.Spec.Vault; v != nil {
+		if h.vault == nil {
+			return "", "", errors.New("vault is not configured in the operator")
+		}
+
+		data, err := h.vault.ReadSecret(ctx, v.Path)
+		if err != nil {
+			return "", "", errors.Wrap(err, "unable to get kc credentials from vault")
+		}
+
+		user, ok := data[v.GetUsernameKey()]
+		if !ok {
+			return "", "", errors.Errorf("vault secret %s does not contain key %s", v.Path, v.GetUsernameKey())
+		}
+
+		password, ok := data[v.GetPasswordKey()]
+		if !ok {
+			return "", "", errors.Errorf("vault secret %s does not contain key %s", v.Path, v.GetPasswordKey())
+		}
+
+		return user, password, nil
+	}
+
+	var secret coreV1.Secret
+	if err := h.client.Get(ctx, types.NamespacedName{
+		Name:      kc.Spec.Secret,
+		Namespace: kc.Namespace,
+	}, &secret); err != nil {
+		return "", "", errors.Wrap(err, "kc login password secret not found")
+	}
+
+	return string(secret.Data["username"]), string(secret.Data["password"]), nil
+}
+
+// CheckKeycloakCircuit returns whether the requests to the keycloak are suspended by its circuit breaker
+// and the time of the next probe. If the probe is due, the health of the keycloak URLs is checked
+// and the circuit is closed if one of them is healthy.
+func (h *Helper) CheckKeycloakCircuit(ctx context.Context, kc *keycloakApi.Keycloak) (retryAt time.Time, open bool) {
+	breaker := h.getConnections().breaker(types.NamespacedName{Namespace: kc.Namespace, Name: kc.Name})
+
+	if breaker.tryProbe(time.Now()) {
+		restyClient, err := h.restyClientFor(ctx, kc)
+		if err == nil {
+			_, err = adapter.SelectHealthyURL(ctx, restyClient, kc.GetURLs())
+		}
+
+		if err != nil {
+			h.logger.Info("Keycloak is still unavailable", "keycloak", kc.Name, "reason", err.Error())
+			breaker.fail(time.Now())
+		} else {
+			h.logger.Info("Keycloak is available again", "keycloak", kc.Name)
+			breaker.succeed()
+		}
+	}
+
+	return breaker.state()
+}
+
+// SelectKeycloakURL checks the health of the active URL of the keycloak with the failover URLs
+// and fails over to the first healthy URL. The new active URL is saved in the status and the token secret
+// is removed, so the operator logs in to the new URL.
+func (h *Helper) SelectKeycloakURL(ctx context.Context, kc *keycloakApi.Keycloak) error {
+	urls := kc.GetURLs()
+	if len(urls) == 1 {
+		return nil
+	}
+
+	restyClient, err := h.restyClientFor(ctx, kc)
+	if err != nil {
+		return err
+	}
+
+	active := kc.GetActiveURL()
+
+	if err = adapter.CheckHealth(ctx, restyClient, active); err != nil {
+		h.logger.Info("Keycloak url is not healthy, failing over", "keycloak", kc.Name, "url", active, "reason", err.Error())
+
+		if active, err = adapter.SelectHealthyURL(ctx, restyClient, urls); err != nil {
+			return errors.Wrap(err, "unable to fail over keycloak")
+		}
+	}
+
+	if active == kc.Status.ActiveURL {
+		return nil
+	}
+
+	previous := kc.GetActiveURL()
+	kc.Status.ActiveURL = active
+
+	if err := h.client.Status().Update(ctx, kc); err != nil {
+		return errors.Wrap(err, "unable to update keycloak active url")
+	}
+
+	if active == previous {
+		return nil
+	}
+
+	h.logger.Info("Keycloak failed over", "keycloak", kc.Name, "url", active)
+
+	err = h.getTokenStore().delete(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: tokenSecretName(kc.Name)})
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return errors.Wrap(err, "unable to delete client token secret")
+	}
+
+	return nil
+}
+
 func (h *Helper) CreateKeycloakClient(ctx context.Context, url, user, password, adminType string) (keycloak.Client, error) {
-	clientAdapter, err := h.adapterBuilder(ctx, url, user, password, adminType, h.logger, h.restyClient)
+	return h.createKeycloakClient(ctx, url, user, password, adminType, "master", h.restyClient)
+}
+
+func (h *Helper) createKeycloakClient(ctx context.Context, url, user, password, adminType, realm string,
+	restyClient *resty.Client) (keycloak.Client, error) {
+	clientAdapter, err := h.adapterBuilder(ctx, url, user, password, adminType, realm, h.logger, restyClient)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to init kc client adapter")
 	}
 
+	if a, ok := clientAdapter.(*adapter.GoCloakAdapter); ok {
+		a.SetPageSize(h.pageSize)
+	}
+
+	tokensIssued.WithLabelValues(tokenIssuedByLogin).Inc()
+
 	return clientAdapter, nil
 }
 
 func (h *Helper) InvalidateKeycloakClientTokenSecret(ctx context.Context, namespace, rootKeycloakName string) error {
-	var secret coreV1.Secret
-	if err := h.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: tokenSecretName(rootKeycloakName)},
-		&secret); err != nil {
-		return errors.Wrap(err, "unable to get client token secret")
-	}
+	nn := types.NamespacedName{Namespace: namespace, Name: tokenSecretName(rootKeycloakName)}
 
-	if err := h.client.Delete(ctx, &secret); err != nil {
+	if err := h.getTokenStore().delete(ctx, nn); err != nil {
 		return errors.Wrap(err, "unable to delete client token secret")
 	}
 
@@ -101,54 +341,220 @@ func (h *Helper) InvalidateKeycloakClientTokenSecret(ctx context.Context, namesp
 }
 
 func (h *Helper) SaveKeycloakClientTokenSecret(ctx context.Context, kc *keycloakApi.Keycloak, token []byte) error {
-	var secret coreV1.Secret
+	return h.saveTokenSecret(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: tokenSecretName(kc.Name)},
+		map[string][]byte{
+			keycloakTokenSecretKey: token,
+		})
+}
+
+func (h *Helper) saveTokenSecret(ctx context.Context, nn types.NamespacedName, data map[string][]byte) error {
+	return h.getTokenStore().save(ctx, nn, data)
+}
+
+// CreateKeycloakClientFromTokenSecret creates the keycloak client with the stored token of the keycloak.
+// The token is refreshed before it expires, the TokenExpiredError is returned if it can not be refreshed.
+func (h *Helper) CreateKeycloakClientFromTokenSecret(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error) {
+	nn := types.NamespacedName{Name: tokenSecretName(kc.Name), Namespace: kc.Namespace}
+
+	tokenData, err := h.getTokenStore().get(ctx, nn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.checkKeycloakCredentials(ctx, kc, tokenData); err != nil {
+		return nil, err
+	}
+
+	restyClient, err := h.restyClientFor(ctx, kc)
+	if err != nil {
+		return nil, err
+	}
 
-	err := h.client.Get(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: tokenSecretName(kc.Name)}, &secret)
+	clientAdapter, err := h.makeFromStoredToken(ctx, h.keycloakBaseURL(ctx, kc, restyClient, kc.GetActiveURL()),
+		"master", nn, tokenData, restyClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to make kc client from token")
+	}
+
+	return clientAdapter, nil
+}
+
+// makeFromStoredToken creates the keycloak client with the stored token issued in the realm.
+// The expiring token is exchanged for the new one with its refresh token and the new token is stored,
+// so the operator logs in again only if keycloak rejects the refresh token.
+func (h *Helper) makeFromStoredToken(ctx context.Context, url, realm string, nn types.NamespacedName,
+	tokenData map[string][]byte, restyClient *resty.Client) (keycloak.Client, error) {
+	clientAdapter, err := adapter.MakeFromTokenWithClient(url, tokenData[keycloakTokenSecretKey], h.logger, restyClient)
 	if err == nil {
-		secret.Data = map[string][]byte{
-			keycloakTokenSecretKey: token,
+		clientAdapter.SetPageSize(h.pageSize)
+		h.trackToken(nn, tokenData[keycloakTokenSecretKey])
+
+		return clientAdapter, nil
+	}
+
+	if !adapter.IsErrTokenExpired(err) {
+		return nil, err
+	}
+
+	refreshed, err := adapter.MakeFromRefreshToken(ctx, url, tokenData[keycloakTokenSecretKey], realm, h.logger,
+		restyClient)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed.SetPageSize(h.pageSize)
+	tokensIssued.WithLabelValues(tokenIssuedByRefresh).Inc()
+
+	jwtToken, err := refreshed.ExportToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export refreshed kc client token")
+	}
+
+	data := copyTokenData(tokenData)
+	data[keycloakTokenSecretKey] = jwtToken
+
+	if err := h.saveTokenSecret(ctx, nn, data); err != nil {
+		return nil, errors.Wrap(err, "unable to save refreshed kc token")
+	}
+
+	h.trackToken(nn, jwtToken)
+
+	return refreshed, nil
+}
+
+// createKeycloakClientForRealmAdmin creates the keycloak client logged in to the realm with its admin credentials.
+// The token is saved in the secret in the namespace of the realm with the keycloak URL it is issued by
+// and the hash of the credentials, so the operator logs in again if the keycloak fails over or the credentials change.
+func (h *Helper) createKeycloakClientForRealmAdmin(ctx context.Context, kc *keycloakApi.Keycloak,
+	realm *keycloakApi.KeycloakRealm) (keycloak.Client, error) {
+	url := kc.GetActiveURL()
+	tokenSecretNN := types.NamespacedName{Namespace: realm.Namespace, Name: keycloakRealmTokenSecretPrefix + realm.Name}
+
+	restyClient, err := h.restyClientFor(ctx, kc)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := realm.Spec.AdminCredentials
+
+	var secret coreV1.Secret
+	if err = h.client.Get(ctx, types.NamespacedName{Name: creds.Secret, Namespace: realm.Namespace}, &secret); err != nil {
+		return nil, errors.Wrap(err, "realm admin credentials secret not found")
+	}
+
+	user, password := string(secret.Data["username"]), string(secret.Data["password"])
+	credentials, err := h.credentialsHash(creds.GetAdminType(), user, password)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenData, err := h.getTokenStore().get(ctx, tokenSecretNN)
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, "unable to get realm token secret")
+	}
+
+	if err == nil && string(tokenData[keycloakTokenSecretURLKey]) == url &&
+		string(tokenData[keycloakTokenSecretCredentialsKey]) == credentials {
+		clientAdapter, err := h.makeFromStoredToken(ctx, h.keycloakBaseURL(ctx, kc, restyClient, url),
+			realm.Spec.RealmName, tokenSecretNN, tokenData, restyClient)
+		if err == nil {
+			return clientAdapter, nil
 		}
 
-		if err = h.client.Update(ctx, &secret); err != nil {
-			return errors.Wrap(err, "unable to update token secret")
+		if !adapter.IsErrTokenExpired(err) {
+			return nil, errors.Wrap(err, "unable to make kc client from realm token")
 		}
+	}
+
+	clientAdapter, err := h.createKeycloakClient(ctx, h.keycloakBaseURL(ctx, kc, restyClient, url), user, password,
+		creds.GetAdminType(), realm.Spec.RealmName, restyClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to login with realm admin credentials")
+	}
+
+	jwtToken, err := clientAdapter.ExportToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export kc client token")
+	}
 
+	if err := h.saveTokenSecret(ctx, tokenSecretNN, map[string][]byte{
+		keycloakTokenSecretKey:            jwtToken,
+		keycloakTokenSecretURLKey:         []byte(url),
+		keycloakTokenSecretCredentialsKey: []byte(credentials),
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to save realm token to secret")
+	}
+
+	h.trackToken(tokenSecretNN, jwtToken)
+
+	return clientAdapter, nil
+}
+
+// checkKeycloakCredentials returns the TokenExpiredError if the token is issued for the previous admin credentials
+// of the keycloak, so the operator logs in with the rotated credentials. The tokens without the credentials hash
+// are not checked.
+func (h *Helper) checkKeycloakCredentials(ctx context.Context, kc *keycloakApi.Keycloak, tokenData map[string][]byte) error {
+	issuedFor, ok := tokenData[keycloakTokenSecretCredentialsKey]
+	if !ok {
 		return nil
 	}
 
-	if k8sErrors.IsNotFound(err) {
-		secret = coreV1.Secret{ObjectMeta: metav1.ObjectMeta{
-			Namespace: kc.Namespace,
-			Name:      tokenSecretName(kc.Name),
-		}, Data: map[string][]byte{
-			keycloakTokenSecretKey: token,
-		}}
+	var user, password string
 
-		if err = h.client.Create(ctx, &secret); err != nil {
-			return errors.Wrap(err, "unable to create token secret")
+	if kc.GetAdminType() == keycloakApi.KeycloakAdminTypeServiceAccountToken {
+		if kc.Spec.ServiceAccountToken != nil {
+			user = kc.Spec.ServiceAccountToken.ClientID
 		}
+	} else {
+		var err error
+		if user, password, err = h.getKeycloakCredentials(ctx, kc); err != nil {
+			return err
+		}
+	}
 
-		return nil
+	credentials, err := h.keycloakCredentialsHash(kc, user, password)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(credentials), issuedFor) {
+		h.logger.Info("Keycloak admin credentials are changed, logging in again", "keycloak", kc.Name)
+
+		return adapter.TokenExpiredError("admin credentials are changed")
 	}
 
-	return errors.Wrap(err, "error during token secret retrieval")
+	return nil
 }
 
-func (h *Helper) CreateKeycloakClientFromTokenSecret(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error) {
-	var tokenSecret coreV1.Secret
-	if err := h.client.Get(ctx, types.NamespacedName{
-		Name:      tokenSecretName(kc.Name),
-		Namespace: kc.Namespace,
-	}, &tokenSecret); err != nil {
-		return nil, errors.Wrap(err, "unable to get token secret")
+// keycloakCredentialsHash returns the hash of the keycloak admin credentials.
+// The ServiceAccount token is rotated by kubelet, so only the client id is hashed for the serviceAccountToken admin type.
+func (h *Helper) keycloakCredentialsHash(kc *keycloakApi.Keycloak, user, password string) (string, error) {
+	if kc.GetAdminType() == keycloakApi.KeycloakAdminTypeServiceAccountToken {
+		password = ""
 	}
 
-	clientAdapter, err := adapter.MakeFromToken(kc.Spec.Url, tokenSecret.Data[keycloakTokenSecretKey], h.logger)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to make kc client from token")
+	return h.credentialsHash(kc.GetAdminType(), user, password)
+}
+
+// credentialsHash returns the HMAC of the admin credentials stored with the token to detect the rotated credentials.
+// The key is generated by the operator on start and is never stored, so the password can not be brute-forced
+// from the token secret. The tokens issued before the restart of the operator are not reused.
+func (h *Helper) credentialsHash(adminType, user, password string) (string, error) {
+	h.credentialsKeyOnce.Do(func() {
+		h.credentialsKey = make([]byte, sha256.Size)
+		if _, err := rand.Read(h.credentialsKey); err != nil {
+			h.credentialsKey, h.credentialsKeyErr = nil, errors.Wrap(err, "unable to generate credentials hash key")
+		}
+	})
+
+	if h.credentialsKeyErr != nil {
+		return "", h.credentialsKeyErr
 	}
 
-	return clientAdapter, nil
+	mac := hmac.New(sha256.New, h.credentialsKey)
+	mac.Write([]byte(adminType + "\n" + user + "\n" + password))
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
 }
 
 func tokenSecretName(keycloakName string) string {
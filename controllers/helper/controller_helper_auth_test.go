@@ -2,8 +2,15 @@ package helper
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -14,10 +21,12 @@ import (
 	"github.com/jarcoal/httpmock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -28,6 +37,7 @@ import (
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+	"github.com/epam/edp-keycloak-operator/pkg/vault"
 )
 
 func TestHelper_CreateKeycloakClientForRealm(t *testing.T) {
@@ -98,7 +108,7 @@ func TestCreateKeycloakClientFromLoginPassword_FailureExportToken(t *testing.T)
 	adapterMock := adapter.Mock{
 		ExportTokenErr: errors.New("export token fatal"),
 	}
-	helper.adapterBuilder = func(ctx context.Context, url, user, password, adminType string, log logr.Logger,
+	helper.adapterBuilder = func(ctx context.Context, url, user, password, adminType, realm string, log logr.Logger,
 		restyClient *resty.Client) (keycloak.Client, error) {
 		return &adapterMock, nil
 	}
@@ -113,6 +123,340 @@ func TestCreateKeycloakClientFromLoginPassword_FailureExportToken(t *testing.T)
 	}
 }
 
+func TestHelper_CreateKeycloakClientFromLoginPassword_ServiceAccountToken(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("sa-token\n"), 0o600))
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec: v13.KeycloakSpec{
+			Url:       "https://keycloak.example.com",
+			AdminType: v13.KeycloakAdminTypeServiceAccountToken,
+			ServiceAccountToken: &v13.KeycloakServiceAccountToken{
+				ClientID: "keycloak-operator",
+			},
+		},
+	}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+	h.SetServiceAccountToken(ServiceAccountTokenConfig{TokenPath: tokenPath, Namespace: "ns"})
+	h.adapterBuilder = func(ctx context.Context, url, user, password, adminType, realm string, log logr.Logger,
+		restyClient *resty.Client) (keycloak.Client, error) {
+		require.Equal(t, "keycloak-operator", user)
+		require.Equal(t, "sa-token", password)
+		require.Equal(t, v13.KeycloakAdminTypeServiceAccountToken, adminType)
+
+		return &adapter.Mock{ExportTokenResult: []byte(`{}`)}, nil
+	}
+
+	_, err := h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.NoError(t, err)
+
+	// the token must not be sent to the urls chosen in the other namespaces
+	kc.Namespace = "tenant"
+	kc.Spec.FailoverURLs = []string{"https://attacker.example.com"}
+	h.SetServiceAccountToken(ServiceAccountTokenConfig{
+		TokenPath:   tokenPath,
+		Namespace:   "ns",
+		AllowedURLs: []string{"https://keycloak.example.com/"},
+	})
+
+	_, err = h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "https://attacker.example.com is not allowed")
+
+	kc.Spec.FailoverURLs = nil
+
+	_, err = h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.NoError(t, err, "allowed url must be accepted in the other namespaces")
+
+	kc.Spec.ServiceAccountToken = nil
+
+	_, err = h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "serviceAccountToken is required")
+}
+
+func TestHelper_CreateKeycloakClientFromTokenSecret_CredentialsRotated(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec:       v13.KeycloakSpec{Url: "https://keycloak.example.com", Secret: "kc-admin"},
+	}
+	adminSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc-admin"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("old")},
+	}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc, &adminSecret).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Hour).Unix())))
+	token, err := json.Marshal(&gocloak.JWT{AccessToken: "header." + payload + ".signature"})
+	require.NoError(t, err)
+
+	h.adapterBuilder = func(ctx context.Context, url, user, password, adminType, realm string, log logr.Logger,
+		restyClient *resty.Client) (keycloak.Client, error) {
+		return &adapter.Mock{ExportTokenResult: token}, nil
+	}
+
+	_, err = h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.NoError(t, err)
+
+	_, err = h.CreateKeycloakClientFromTokenSecret(context.Background(), &kc)
+	require.NoError(t, err, "token of the current credentials must be valid")
+
+	adminSecret.Data["password"] = []byte("new")
+	require.NoError(t, fakeCl.Update(context.Background(), &adminSecret))
+
+	_, err = h.CreateKeycloakClientFromTokenSecret(context.Background(), &kc)
+	require.Error(t, err)
+	require.True(t, adapter.IsErrTokenExpired(err))
+}
+
+func TestHelper_CreateKeycloakClientFromLoginPassword_CredentialsHMAC(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec:       v13.KeycloakSpec{Url: "https://keycloak.example.com", Secret: "kc-admin"},
+	}
+	adminSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc-admin"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("pass")},
+	}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc, &adminSecret).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Hour).Unix())))
+	token, err := json.Marshal(&gocloak.JWT{AccessToken: "header." + payload + ".signature"})
+	require.NoError(t, err)
+
+	h.adapterBuilder = func(ctx context.Context, url, user, password, adminType, realm string, log logr.Logger,
+		restyClient *resty.Client) (keycloak.Client, error) {
+		return &adapter.Mock{ExportTokenResult: token}, nil
+	}
+
+	_, err = h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.NoError(t, err)
+
+	var tokenSecret corev1.Secret
+	require.NoError(t, fakeCl.Get(context.Background(),
+		types.NamespacedName{Namespace: "ns", Name: tokenSecretName(kc.Name)}, &tokenSecret))
+
+	plain := sha256.Sum256([]byte(kc.GetAdminType() + "\nadmin\npass"))
+	require.NotEqual(t, hex.EncodeToString(plain[:]), string(tokenSecret.Data[keycloakTokenSecretCredentialsKey]),
+		"the plain digest of the password must not be stored")
+
+	restarted := MakeHelper(fakeCl, sch, mock.NewLogr())
+	_, err = restarted.CreateKeycloakClientFromTokenSecret(context.Background(), &kc)
+	require.Error(t, err, "the token must not be reused with the key of another operator")
+	require.True(t, adapter.IsErrTokenExpired(err))
+}
+
+func TestHelper_CreateKeycloakClientFromTokenSecret_Refresh(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	jwt := func(exp time.Time) string {
+		payload := fmt.Sprintf(`{"exp":%d,"azp":"admin-cli"}`, exp.Unix())
+
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+	}
+
+	refreshed := jwt(time.Now().Add(time.Hour))
+
+	var refreshes int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/realms/master/protocol/openid-connect/token":
+			refreshes++
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"access_token":%q,"refresh_token":%q}`, refreshed, jwt(time.Now().Add(time.Hour)))
+		case "/admin/realms/master":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec:       v13.KeycloakSpec{Url: server.URL, Secret: "kc-admin"},
+	}
+	token, err := json.Marshal(&gocloak.JWT{
+		AccessToken:  jwt(time.Now().Add(-time.Minute)),
+		RefreshToken: jwt(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+
+	tokenSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: tokenSecretName("kc")},
+		Data:       map[string][]byte{keycloakTokenSecretKey: token},
+	}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc, &tokenSecret).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+
+	h.adapterBuilder = func(ctx context.Context, url, user, password, adminType, realm string, log logr.Logger,
+		restyClient *resty.Client) (keycloak.Client, error) {
+		return nil, errors.New("login is not expected")
+	}
+
+	_, err = h.CreateKeycloakClientFromTokenSecret(context.Background(), &kc)
+	require.NoError(t, err)
+
+	_, err = h.CreateKeycloakClientFromTokenSecret(context.Background(), &kc)
+	require.NoError(t, err)
+	require.Equal(t, 1, refreshes, "refreshed token must be reused")
+
+	var stored corev1.Secret
+	require.NoError(t, fakeCl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: tokenSecretName("kc")},
+		&stored))
+	require.Contains(t, string(stored.Data[keycloakTokenSecretKey]), refreshed)
+
+	restyClient, err := h.restyClientFor(context.Background(), &kc)
+	require.NoError(t, err)
+
+	_, err = restyClient.R().SetAuthToken(refreshed).Get(server.URL + "/admin/realms/master")
+	require.NoError(t, err)
+
+	err = fakeCl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: tokenSecretName("kc")},
+		&corev1.Secret{})
+	require.True(t, k8sErrors.IsNotFound(err), "rejected token must be removed")
+}
+
+func TestHelper_CreateKeycloakClientFromLoginPassword_Vault(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"vault-token","lease_duration":3600}}`))
+		case "/v1/secret/data/keycloak":
+			_, _ = w.Write([]byte(`{"data":{"data":{"user":"admin","pass":"vault-pass"},"metadata":{}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("sa-jwt"), 0o600))
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec: v13.KeycloakSpec{
+			Url: "https://keycloak.example.com",
+			Vault: &v13.KeycloakVaultCredentials{
+				Path:        "secret/data/keycloak",
+				UsernameKey: "user",
+				PasswordKey: "pass",
+			},
+		},
+	}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+	h.adapterBuilder = func(ctx context.Context, url, user, password, adminType, realm string, log logr.Logger,
+		restyClient *resty.Client) (keycloak.Client, error) {
+		require.Equal(t, "admin", user)
+		require.Equal(t, "vault-pass", password)
+
+		return &adapter.Mock{ExportTokenResult: []byte(`{}`)}, nil
+	}
+
+	_, err := h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "vault is not configured in the operator")
+
+	h.SetVault(vault.Config{
+		Address:    server.URL,
+		AuthMount:  "kubernetes",
+		Role:       "keycloak-operator",
+		TokenPath:  tokenPath,
+		PathPrefix: "secret/data/keycloak",
+	})
+
+	_, err = h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.NoError(t, err)
+
+	kc.Spec.Vault.Path = "secret/data/keycloak/../other"
+
+	_, err = h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not under the allowed path secret/data/keycloak")
+
+	kc.Spec.Vault.Path = "secret/data/keycloak/missing"
+
+	_, err = h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to get kc credentials from vault")
+}
+
+func TestHelper_UseInMemoryTokenCache(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec:       v13.KeycloakSpec{Url: "https://keycloak.example.com", Secret: "kc-admin"},
+	}
+	adminSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc-admin"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("pass")},
+	}
+	leftToken := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: tokenSecretName("kc")}}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc, &adminSecret, &leftToken).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+	h.UseInMemoryTokenCache()
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Hour).Unix())))
+	token, err := json.Marshal(&gocloak.JWT{AccessToken: "header." + payload + ".signature"})
+	require.NoError(t, err)
+
+	h.adapterBuilder = func(ctx context.Context, url, user, password, adminType, realm string, log logr.Logger,
+		restyClient *resty.Client) (keycloak.Client, error) {
+		return &adapter.Mock{ExportTokenResult: token}, nil
+	}
+
+	_, err = h.CreateKeycloakClientFromTokenSecret(context.Background(), &kc)
+	require.True(t, k8sErrors.IsNotFound(err), "token must not be read from the secret")
+
+	_, err = h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.NoError(t, err)
+
+	err = fakeCl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: leftToken.Name}, &corev1.Secret{})
+	require.True(t, k8sErrors.IsNotFound(err), "token secret must be removed")
+
+	_, err = h.CreateKeycloakClientFromTokenSecret(context.Background(), &kc)
+	require.NoError(t, err)
+
+	require.NoError(t, h.InvalidateKeycloakClientTokenSecret(context.Background(), "ns", "kc"))
+
+	_, err = h.CreateKeycloakClientFromTokenSecret(context.Background(), &kc)
+	require.True(t, k8sErrors.IsNotFound(err))
+}
+
 func TestCreateKeycloakClientFromLoginPassword(t *testing.T) {
 	s := scheme.Scheme
 	utilruntime.Must(v13.AddToScheme(s))
@@ -361,3 +705,524 @@ func TestHelper_InvalidateKeycloakClientTokenSecret_FailureToDelete(t *testing.T
 		t.Fatalf("wrong error returned: %+v", err)
 	}
 }
+
+func TestHelper_SelectKeycloakURL(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec: v13.KeycloakSpec{
+			Url:          "https://site-a.example.com",
+			FailoverURLs: []string{"https://site-b.example.com"},
+		},
+	}
+	tokenSecret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: tokenSecretName("kc")}}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc, &tokenSecret).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+	h.restyClient = resty.New()
+	httpmock.ActivateNonDefault(h.restyClient.GetClient())
+
+	httpmock.RegisterResponder("GET", "https://site-a.example.com/realms/master",
+		httpmock.NewStringResponder(503, ""))
+	httpmock.RegisterResponder("GET", "https://site-b.example.com/realms/master",
+		httpmock.NewStringResponder(200, "{}"))
+
+	require.NoError(t, h.SelectKeycloakURL(context.Background(), &kc))
+	require.Equal(t, "https://site-b.example.com", kc.GetActiveURL())
+
+	var persisted v13.Keycloak
+	require.NoError(t, fakeCl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "kc"}, &persisted))
+	require.Equal(t, "https://site-b.example.com", persisted.Status.ActiveURL)
+
+	err := fakeCl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: tokenSecret.Name}, &corev1.Secret{})
+	require.True(t, k8sErrors.IsNotFound(err), "token secret of the previous url is not removed")
+
+	httpmock.RegisterResponder("GET", "https://site-b.example.com/realms/master",
+		httpmock.NewStringResponder(503, ""))
+
+	err = h.SelectKeycloakURL(context.Background(), &kc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no healthy keycloak url")
+}
+
+func TestHelper_SelectKeycloakURL_InsecureSkipVerify(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec: v13.KeycloakSpec{
+			Url:          "https://127.0.0.1:1",
+			FailoverURLs: []string{server.URL},
+		},
+	}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+
+	err := h.SelectKeycloakURL(context.Background(), &kc)
+	require.Error(t, err, "self-signed certificate must not be trusted by default")
+
+	kc.Spec.InsecureSkipVerify = true
+
+	require.NoError(t, h.SelectKeycloakURL(context.Background(), &kc))
+	require.Equal(t, server.URL, kc.GetActiveURL())
+}
+
+func TestHelper_SelectKeycloakURL_Proxy(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	var proxiedHost string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.Host
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec: v13.KeycloakSpec{
+			Url:          "http://site-a.keycloak.invalid",
+			FailoverURLs: []string{"http://site-b.keycloak.invalid"},
+			ProxyURL:     proxy.URL,
+		},
+	}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+
+	require.NoError(t, h.SelectKeycloakURL(context.Background(), &kc))
+	require.Equal(t, "site-a.keycloak.invalid", proxiedHost)
+
+	first, err := h.restyClientFor(context.Background(), &kc)
+	require.NoError(t, err)
+
+	second, err := h.restyClientFor(context.Background(), kc.DeepCopy())
+	require.NoError(t, err)
+	require.Same(t, first, second)
+}
+
+func TestHelper_SelectKeycloakURL_Headers(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	var received http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec: v13.KeycloakSpec{
+			Url:          server.URL,
+			FailoverURLs: []string{"http://127.0.0.1:1"},
+			HeadersFrom: []v13.KeycloakHeadersSource{
+				{ConfigMapName: "kc-headers"},
+				{SecretName: "kc-waf"},
+			},
+		},
+	}
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc-headers"},
+		Data:       map[string]string{"X-Forwarded-Host": "keycloak.example.com", "X-Tenant": "default"},
+	}
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc-waf"},
+		Data:       map[string][]byte{"X-Waf-Token": []byte("secret-token"), "X-Tenant": []byte("team")},
+	}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc, &cm, &secret).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+
+	require.NoError(t, h.SelectKeycloakURL(context.Background(), &kc))
+	require.Equal(t, "keycloak.example.com", received.Get("X-Forwarded-Host"))
+	require.Equal(t, "secret-token", received.Get("X-Waf-Token"))
+	require.Equal(t, "team", received.Get("X-Tenant"))
+
+	kc.Spec.HeadersFrom = []v13.KeycloakHeadersSource{{ConfigMapName: "kc-headers", SecretName: "kc-waf"}}
+
+	err := h.SelectKeycloakURL(context.Background(), &kc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exactly one of configMapName or secretName")
+}
+
+func TestHelper_CreateKeycloakClientForRealm_AdminCredentials(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec:       v13.KeycloakSpec{Url: "https://keycloak.example.com", Secret: "master-admin"},
+	}
+	realm := v13.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "team"},
+		Spec: v13.KeycloakRealmSpec{
+			RealmName:   "team-realm",
+			KeycloakRef: &v13.KeycloakRef{Kind: v13.KeycloakKind, Name: "kc"},
+			AdminCredentials: &v13.RealmAdminCredentials{
+				Secret:    "team-admin",
+				AdminType: v13.KeycloakAdminTypeServiceAccount,
+			},
+		},
+	}
+	credentials := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "team-admin"},
+		Data:       map[string][]byte{"username": []byte("operator"), "password": []byte("secret")},
+	}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc, &credentials).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+
+	var loginRealm, loginType, loginUser string
+
+	h.adapterBuilder = func(ctx context.Context, url, user, password, adminType, realm string, log logr.Logger,
+		restyClient *resty.Client) (keycloak.Client, error) {
+		loginRealm, loginType, loginUser = realm, adminType, user
+
+		return &adapter.Mock{ExportTokenResult: []byte(`{"access_token":"token"}`)}, nil
+	}
+
+	_, err := h.CreateKeycloakClientForRealm(context.Background(), &realm)
+	require.NoError(t, err)
+	require.Equal(t, "team-realm", loginRealm)
+	require.Equal(t, v13.KeycloakAdminTypeServiceAccount, loginType)
+	require.Equal(t, "operator", loginUser)
+
+	var tokenSecret corev1.Secret
+	require.NoError(t, fakeCl.Get(context.Background(),
+		types.NamespacedName{Namespace: "ns", Name: keycloakRealmTokenSecretPrefix + "team"}, &tokenSecret))
+	require.Equal(t, "https://keycloak.example.com", string(tokenSecret.Data[keycloakTokenSecretURLKey]))
+
+	err = fakeCl.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: tokenSecretName("kc")},
+		&corev1.Secret{})
+	require.True(t, k8sErrors.IsNotFound(err), "master token secret is created for the realm admin")
+}
+
+func TestHelper_CreateKeycloakClientFromLoginPassword_ReverseProxy(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	var received http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auth/realms/master" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec: v13.KeycloakSpec{
+			Url:       server.URL,
+			Secret:    "kc-admin",
+			ProxyAuth: &v13.KeycloakProxyAuth{Secret: "kc-proxy"},
+		},
+	}
+	admin := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc-admin"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("admin")},
+	}
+	proxyAuth := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc-proxy"},
+		Data:       map[string][]byte{"username": []byte("proxy"), "password": []byte("secret")},
+	}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc, &admin, &proxyAuth).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+
+	var loginURL string
+
+	h.adapterBuilder = func(ctx context.Context, url, user, password, adminType, realm string, log logr.Logger,
+		restyClient *resty.Client) (keycloak.Client, error) {
+		loginURL = url
+
+		return &adapter.Mock{ExportTokenResult: []byte(`{"access_token":"token"}`)}, nil
+	}
+
+	_, err := h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/auth", loginURL)
+	require.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("proxy:secret")),
+		received.Get("Proxy-Authorization"))
+
+	kc.Spec.ContextPath = "/"
+
+	_, err = h.CreateKeycloakClientFromLoginPassword(context.Background(), &kc)
+	require.NoError(t, err)
+	require.Equal(t, server.URL, loginURL)
+}
+
+func TestHelper_restyClientFor_SharedPerKeycloak(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	first := v13.Keycloak{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "first"}}
+	second := v13.Keycloak{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "second"}}
+
+	h := MakeHelper(fake.NewClientBuilder().WithScheme(sch).Build(), sch, mock.NewLogr())
+
+	c1, err := h.restyClientFor(context.Background(), &first)
+	require.NoError(t, err)
+	require.Equal(t, keycloakRequestTimeout, c1.GetClient().Timeout)
+
+	c2, err := h.restyClientFor(context.Background(), first.DeepCopy())
+	require.NoError(t, err)
+	require.Same(t, c1, c2, "client must be shared by the reconciliations")
+
+	other, err := h.restyClientFor(context.Background(), &second)
+	require.NoError(t, err)
+	require.NotSame(t, c1, other)
+
+	h.ReleaseKeycloakConnection(types.NamespacedName{Namespace: "ns", Name: "first"})
+
+	c3, err := h.restyClientFor(context.Background(), &first)
+	require.NoError(t, err)
+	require.NotSame(t, c1, c3, "client of the released keycloak must be created again")
+}
+
+func TestHelper_CheckKeycloakCircuit(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	var (
+		available bool
+		requests  int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if !available {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec:       v13.KeycloakSpec{Url: server.URL},
+		Status:     v13.KeycloakStatus{Connected: true},
+	}
+	nn := types.NamespacedName{Namespace: kc.Namespace, Name: kc.Name}
+
+	fakeCl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc).Build()
+	h := MakeHelper(fakeCl, sch, mock.NewLogr())
+
+	restyClient, err := h.restyClientFor(context.Background(), &kc)
+	require.NoError(t, err)
+
+	for i := 0; i < circuitFailureThreshold; i++ {
+		_, err = restyClient.R().Post(server.URL + "/admin/realms")
+		require.NoError(t, err)
+	}
+
+	_, err = restyClient.R().Post(server.URL + "/admin/realms")
+	require.Error(t, err)
+
+	var unavailable KeycloakUnavailableError
+	require.True(t, errors.As(err, &unavailable))
+	require.Equal(t, circuitFailureThreshold, requests)
+
+	select {
+	case e := <-h.KeycloakCircuitEvents():
+		require.Equal(t, kc.Name, e.Object.GetName())
+		require.Equal(t, kc.Namespace, e.Object.GetNamespace())
+	default:
+		require.Fail(t, "the opened circuit must enqueue the keycloak")
+	}
+
+	_, err = h.CreateKeycloakClientForRealm(context.Background(), &v13.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "realm"},
+		Spec:       v13.KeycloakRealmSpec{KeycloakOwner: kc.Name},
+	})
+	require.Error(t, err)
+	require.True(t, errors.As(err, &unavailable))
+
+	retryAt, open := h.CheckKeycloakCircuit(context.Background(), &kc)
+	require.True(t, open)
+	require.WithinDuration(t, time.Now().Add(circuitOpenTimeout), retryAt, time.Second)
+	require.Equal(t, circuitFailureThreshold, requests, "the probe must wait for the open timeout")
+
+	breaker := h.getConnections().breaker(nn)
+	breaker.openUntil = time.Now().Add(-time.Second)
+
+	_, open = h.CheckKeycloakCircuit(context.Background(), &kc)
+	require.True(t, open, "the failed probe must keep the circuit open")
+	require.WithinDuration(t, time.Now().Add(2*circuitOpenTimeout), breaker.openUntil, time.Second)
+
+	breaker.openUntil = time.Now().Add(-time.Second)
+	available = true
+
+	_, open = h.CheckKeycloakCircuit(context.Background(), &kc)
+	require.False(t, open)
+
+	_, err = restyClient.R().Post(server.URL + "/admin/realms")
+	require.NoError(t, err)
+}
+
+func TestHelper_keycloakRateLimit(t *testing.T) {
+	h := MakeHelper(nil, nil, mock.NewLogr())
+
+	limit, burst := h.keycloakRateLimit(&v13.Keycloak{})
+	require.Equal(t, rate.Inf, limit)
+	require.Equal(t, 0, burst)
+
+	h.SetRateLimit(2.5, 0)
+
+	limit, burst = h.keycloakRateLimit(&v13.Keycloak{})
+	require.Equal(t, rate.Inf, limit, "the global rate limit is not the default of the keycloak")
+	require.Equal(t, 0, burst)
+
+	limit, burst = h.keycloakRateLimit(&v13.Keycloak{Spec: v13.KeycloakSpec{
+		RateLimit: &v13.KeycloakRateLimit{RequestsPerSecond: 10, Burst: 20},
+	}})
+	require.Equal(t, rate.Limit(10), limit)
+	require.Equal(t, 20, burst)
+
+	limit, burst = h.keycloakRateLimit(&v13.Keycloak{Spec: v13.KeycloakSpec{
+		RateLimit: &v13.KeycloakRateLimit{RequestsPerSecond: 3},
+	}})
+	require.Equal(t, rate.Limit(3), limit)
+	require.Equal(t, 3, burst)
+}
+
+func TestHelper_SetRateLimit(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	kc1 := v13.Keycloak{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc1"}, Spec: v13.KeycloakSpec{Url: server.URL}}
+	kc2 := v13.Keycloak{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc2"}, Spec: v13.KeycloakSpec{Url: server.URL}}
+
+	h := MakeHelper(fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc1, &kc2).Build(), sch, mock.NewLogr())
+	h.SetRateLimit(1, 1)
+
+	client1, err := h.restyClientFor(context.Background(), &kc1)
+	require.NoError(t, err)
+
+	client2, err := h.restyClientFor(context.Background(), &kc2)
+	require.NoError(t, err)
+
+	_, err = client1.R().Get(server.URL + "/admin/realms")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = client2.R().SetContext(ctx).Get(server.URL + "/admin/realms")
+	require.Error(t, err, "the global rate limit must be shared by the keycloak instances")
+	require.Contains(t, err.Error(), "rate limit")
+}
+
+func TestHelper_restyClientFor_RateLimitDoesNotOpenCircuit(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec: v13.KeycloakSpec{
+			Url:       server.URL,
+			RateLimit: &v13.KeycloakRateLimit{RequestsPerSecond: 1},
+		},
+	}
+
+	h := MakeHelper(fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc).Build(), sch, mock.NewLogr())
+
+	restyClient, err := h.restyClientFor(context.Background(), &kc)
+	require.NoError(t, err)
+
+	_, err = restyClient.R().Get(server.URL + "/admin/realms")
+	require.NoError(t, err)
+
+	for i := 0; i < circuitFailureThreshold+1; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err = restyClient.R().SetContext(ctx).Get(server.URL + "/admin/realms")
+
+		cancel()
+		require.Error(t, err)
+	}
+
+	_, open := h.getConnections().breaker(types.NamespacedName{Namespace: "ns", Name: "kc"}).state()
+	require.False(t, open, "the throttled requests must not open the circuit")
+}
+
+func TestHelper_restyClientFor_RateLimit(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	kc := v13.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kc"},
+		Spec: v13.KeycloakSpec{
+			Url:       server.URL,
+			RateLimit: &v13.KeycloakRateLimit{RequestsPerSecond: 1},
+		},
+	}
+
+	h := MakeHelper(fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc).Build(), sch, mock.NewLogr())
+
+	restyClient, err := h.restyClientFor(context.Background(), &kc)
+	require.NoError(t, err)
+
+	_, err = restyClient.R().Get(server.URL + "/admin/realms")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = restyClient.R().SetContext(ctx).Get(server.URL + "/admin/realms")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "rate limit")
+
+	require.NoError(t, adapter.CheckHealth(context.Background(), restyClient, server.URL),
+		"the health checks must not be limited")
+
+	kc.Spec.RateLimit = nil
+
+	restyClient, err = h.restyClientFor(context.Background(), &kc)
+	require.NoError(t, err)
+
+	_, err = restyClient.R().Get(server.URL + "/admin/realms")
+	require.NoError(t, err)
+}
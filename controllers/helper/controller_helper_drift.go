@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+)
+
+type Conditioned interface {
+	GetConditions() []metav1.Condition
+	SetConditions(conditions []metav1.Condition)
+}
+
+// IsDriftConditionUpdated returns true if the update changes the Drifted condition.
+// The condition is changed only by the drift detector, so such updates must not trigger the reconciliation.
+func IsDriftConditionUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(Conditioned)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(Conditioned)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(meta.FindStatusCondition(oo.GetConditions(), keycloakApi.ConditionDrifted),
+		meta.FindStatusCondition(no.GetConditions(), keycloakApi.ConditionDrifted))
+}
@@ -0,0 +1,26 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+)
+
+func TestIsDriftConditionUpdated(t *testing.T) {
+	old := &keycloakApi.KeycloakClient{}
+	drifted := &keycloakApi.KeycloakClient{Status: keycloakApi.KeycloakClientStatus{Conditions: []metav1.Condition{{
+		Type:   keycloakApi.ConditionDrifted,
+		Status: metav1.ConditionTrue,
+		Reason: keycloakApi.ReasonDriftDetected,
+	}}}}
+	failed := &keycloakApi.KeycloakClient{Status: keycloakApi.KeycloakClientStatus{Value: "error", FailureCount: 1}}
+
+	require.True(t, IsDriftConditionUpdated(event.UpdateEvent{ObjectOld: old, ObjectNew: drifted}))
+	require.False(t, IsDriftConditionUpdated(event.UpdateEvent{ObjectOld: old, ObjectNew: failed}))
+	require.False(t, IsDriftConditionUpdated(event.UpdateEvent{ObjectOld: &keycloakApi.KeycloakRealmUser{},
+		ObjectNew: &keycloakApi.KeycloakRealmUser{}}))
+}
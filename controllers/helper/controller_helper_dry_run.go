@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/export"
+	"github.com/epam/edp-keycloak-operator/pkg/verify"
+)
+
+// DryRunPreview is returned instead of applying the custom resource when the dry run is enabled.
+// It contains a human-readable description of the changes which would be made in keycloak.
+type DryRunPreview string
+
+func (p DryRunPreview) Error() string {
+	return "dry run: " + string(p)
+}
+
+func IsDryRunPreview(err error) bool {
+	preview := DryRunPreview("")
+
+	return errors.As(err, &preview)
+}
+
+// IsDryRunEnabled returns true if the object has the dry run annotation.
+func IsDryRunEnabled(obj metav1.Object) bool {
+	return obj.GetAnnotations()[keycloakApi.DryRunAnnotation] == "true"
+}
+
+// ReleaseDeletedDryRun removes the finalizer of the deleted dry run object, so its deletion is not blocked.
+// The object is not deleted from keycloak, because the dry run does not change keycloak.
+// It returns true if the object is the deleted dry run object.
+func ReleaseDeletedDryRun(ctx context.Context, c client.Client, obj client.Object, finalizer string) (bool, error) {
+	if !IsDryRunEnabled(obj) || obj.GetDeletionTimestamp().IsZero() {
+		return false, nil
+	}
+
+	if !ContainsString(obj.GetFinalizers(), finalizer) {
+		return true, nil
+	}
+
+	obj.SetFinalizers(RemoveString(obj.GetFinalizers(), finalizer))
+
+	if err := c.Update(ctx, obj); err != nil {
+		return true, errors.Wrap(err, "unable to remove finalizer of dry run object")
+	}
+
+	return true, nil
+}
+
+// PreviewChanges compares the object with keycloak and returns DryRunPreview with the changes.
+func PreviewChanges(ctx context.Context, kClient export.RealmExporter, realm *keycloakApi.KeycloakRealm,
+	obj client.Object) error {
+	report, err := verify.VerifyObject(ctx, kClient, realm, obj)
+	if err != nil {
+		return errors.Wrap(err, "unable to preview changes")
+	}
+
+	return DryRunPreview(report.Summary())
+}
+
+// DryRunStatus returns the status value with the dry run preview from the error.
+func DryRunStatus(err error) string {
+	preview := DryRunPreview("")
+	if errors.As(err, &preview) {
+		return preview.Error()
+	}
+
+	return err.Error()
+}
+
+// SetDryRunStatus resets the failure count and sets the status to the dry run preview.
+func SetDryRunStatus(obj StatusValueFailureCountable, err error) {
+	obj.SetStatus(DryRunStatus(err))
+	obj.SetFailureCount(0)
+}
@@ -3,7 +3,12 @@ package helper
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
 type FailureCountable interface {
@@ -50,7 +55,69 @@ func IsFailuresUpdated(e event.UpdateEvent) bool {
 	return oo.GetFailureCount() == no.GetFailureCount()
 }
 
+// AppliedGenerationRecorder records the generation of the spec applied to keycloak.
+type AppliedGenerationRecorder interface {
+	GetGeneration() int64
+	GetAppliedGeneration() int64
+	SetAppliedGeneration(generation int64)
+}
+
 func SetSuccessStatus(el StatusValueFailureCountable) {
 	el.SetStatus(StatusOK)
 	el.SetFailureCount(0)
+
+	if r, ok := el.(AppliedGenerationRecorder); ok {
+		r.SetAppliedGeneration(r.GetGeneration())
+	}
+
+	SetReconciledCondition(el, nil)
+}
+
+// SetErrorStatus sets the error of the reconciliation as the status.
+// The resources with the conditions also get the Reconciled condition with the kind of the error as the reason.
+func SetErrorStatus(el StatusValue, err error) {
+	el.SetStatus(err.Error())
+	SetReconciledCondition(el, err)
+}
+
+// ErrorReason returns the reason of the Reconciled condition for the kind of the keycloak error.
+func ErrorReason(err error) string {
+	switch {
+	case adapter.IsErrNotFound(err):
+		return keycloakApi.ReasonNotFound
+	case adapter.IsErrConflict(err):
+		return keycloakApi.ReasonConflict
+	case adapter.IsErrUnauthorized(err):
+		return keycloakApi.ReasonUnauthorized
+	case adapter.IsErrRateLimited(err):
+		return keycloakApi.ReasonRateLimited
+	case adapter.IsErrExecutionOrderDrift(err):
+		return keycloakApi.ReasonExecutionOrderDrift
+	default:
+		return keycloakApi.ReasonFailed
+	}
+}
+
+// SetReconciledCondition sets the Reconciled condition of the resource with the conditions from the result
+// of the reconciliation.
+func SetReconciledCondition(el interface{}, err error) {
+	c, ok := el.(Conditioned)
+	if !ok {
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:    keycloakApi.ConditionReconciled,
+		Status:  metav1.ConditionTrue,
+		Reason:  keycloakApi.ReasonSucceeded,
+		Message: "The custom resource is applied to keycloak",
+	}
+
+	if err != nil {
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, ErrorReason(err), err.Error()
+	}
+
+	conditions := c.GetConditions()
+	meta.SetStatusCondition(&conditions, condition)
+	c.SetConditions(conditions)
 }
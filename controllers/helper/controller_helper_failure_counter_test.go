@@ -0,0 +1,82 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestErrorReason(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "not found", err: adapter.NotFoundError("role not found"), want: keycloakApi.ReasonNotFound},
+		{
+			name: "wrapped conflict",
+			err:  errors.Wrap(adapter.DuplicatedError("client already exists"), "unable to create client"),
+			want: keycloakApi.ReasonConflict,
+		},
+		{
+			name: "gocloak unauthorized",
+			err:  fmt.Errorf("unable to get realm: %w", &gocloak.APIError{Code: http.StatusUnauthorized}),
+			want: keycloakApi.ReasonUnauthorized,
+		},
+		{
+			name: "rate limited",
+			err:  &adapter.StatusError{Code: http.StatusTooManyRequests},
+			want: keycloakApi.ReasonRateLimited,
+		},
+		{
+			name: "execution order drift",
+			err:  errors.Wrap(adapter.ExecutionOrderDriftError("executions order does not match"), "unable to sync auth flow"),
+			want: keycloakApi.ReasonExecutionOrderDrift,
+		},
+		{name: "other", err: errors.New("fatal"), want: keycloakApi.ReasonFailed},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorReason(tt.err))
+		})
+	}
+}
+
+func TestSetErrorStatus(t *testing.T) {
+	role := keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+
+	SetErrorStatus(&role, errors.Wrap(adapter.NotFoundError("realm not found"), "unable to put role"))
+
+	assert.Equal(t, "unable to put role: realm not found", role.Status.Value)
+
+	cond := meta.FindStatusCondition(role.Status.Conditions, keycloakApi.ConditionReconciled)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, keycloakApi.ReasonNotFound, cond.Reason)
+	assert.Zero(t, role.Status.AppliedGeneration)
+
+	role.Status.FailureCount = 3
+	SetSuccessStatus(&role)
+
+	assert.Equal(t, StatusOK, role.Status.Value)
+	assert.Zero(t, role.Status.FailureCount)
+	assert.Equal(t, int64(2), role.Status.AppliedGeneration)
+	assert.True(t, meta.IsStatusConditionTrue(role.Status.Conditions, keycloakApi.ConditionReconciled))
+
+	// the resources without the conditions get only the status value
+	user := keycloakApi.KeycloakRealmUser{}
+	SetErrorStatus(&user, adapter.TokenExpiredError("token expired"))
+	assert.Equal(t, "token expired", user.Status.Value)
+}
@@ -0,0 +1,230 @@
+package helper
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// newRestyClient creates the HTTP client of keycloak with the request timeout, the keep-alive connections
+// and the retries of the transient failures.
+// The client drops the stored token rejected by keycloak, so the operator logs in again on the next reconciliation.
+func (h *Helper) newRestyClient() *resty.Client {
+	c := resty.New().
+		SetTransport(newKeycloakTransport()).
+		SetTimeout(keycloakRequestTimeout).
+		OnAfterResponse(h.dropRejectedToken)
+
+	adapter.SetRetries(c)
+
+	return c
+}
+
+// getConnections returns the connection manager of the keycloak instances, it is created on the first use.
+func (h *Helper) getConnections() *connectionManager {
+	h.connectionsOnce.Do(func() {
+		h.connections = newConnectionManager(h.newRestyClient)
+		h.connections.onCircuitOpen = h.notifyCircuitOpen
+	})
+
+	return h.connections
+}
+
+// KeycloakCircuitEvents returns the events of the keycloaks whose circuit is opened,
+// the keycloak controller reconciles them to show the degraded state and to probe keycloak.
+func (h *Helper) KeycloakCircuitEvents() <-chan event.GenericEvent {
+	return h.circuitEvents
+}
+
+// notifyCircuitOpen sends the event of the keycloak without blocking the request which opened the circuit,
+// the event is dropped if the channel is full since the keycloak is already going to be reconciled.
+func (h *Helper) notifyCircuitOpen(nn types.NamespacedName) {
+	select {
+	case h.circuitEvents <- event.GenericEvent{Object: &keycloakApi.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Namespace: nn.Namespace, Name: nn.Name},
+	}}:
+	default:
+	}
+}
+
+// ReleaseKeycloakConnection closes the connections of the deleted keycloak.
+func (h *Helper) ReleaseKeycloakConnection(nn types.NamespacedName) {
+	h.getConnections().release(nn)
+}
+
+func (h *Helper) dropRejectedToken(_ *resty.Client, rsp *resty.Response) error {
+	if rsp.StatusCode() != http.StatusUnauthorized || rsp.Request == nil || rsp.Request.RawRequest == nil {
+		return nil
+	}
+
+	token := strings.TrimPrefix(rsp.Request.RawRequest.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return nil
+	}
+
+	nn, ok := h.forgetToken(token)
+	if !ok {
+		return nil
+	}
+
+	h.logger.Info("Keycloak token is rejected, it is removed", "token", nn.String())
+
+	if err := h.getTokenStore().delete(rsp.Request.Context(), nn); err != nil && !k8sErrors.IsNotFound(err) {
+		h.logger.Error(err, "unable to remove rejected keycloak token", "token", nn.String())
+	}
+
+	return nil
+}
+
+// restyClientFor returns the HTTP client for the keycloak with its TLS, proxy, headers and token endpoint settings.
+// The client is shared by all controllers and it is created again when the settings change.
+func (h *Helper) restyClientFor(ctx context.Context, kc *keycloakApi.Keycloak) (*resty.Client, error) {
+	if h.restyClient != nil && !kc.Spec.InsecureSkipVerify && kc.Spec.ProxyURL == "" && len(kc.Spec.HeadersFrom) == 0 &&
+		kc.Spec.ProxyAuth == nil && kc.Spec.TokenEndpointPath == "" && kc.Spec.RateLimit == nil {
+		return h.restyClient, nil
+	}
+
+	headers, err := h.getKeycloakHeaders(ctx, kc)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := fmt.Sprintf("insecure=%t,proxy=%s,headers=%x,token=%s", kc.Spec.InsecureSkipVerify, kc.Spec.ProxyURL,
+		hashHeaders(headers), kc.Spec.TokenEndpointPath)
+
+	nn := types.NamespacedName{Namespace: kc.Namespace, Name: kc.Name}
+
+	limit, burst := h.keycloakRateLimit(kc)
+	h.getConnections().setLimit(nn, limit, burst)
+
+	c := h.getConnections().get(nn, settings,
+		func(c *resty.Client) {
+			c.SetHeaders(headers)
+
+			if kc.Spec.InsecureSkipVerify {
+				c.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) //nolint:gosec // explicitly enabled in the Keycloak
+			}
+
+			if kc.Spec.ProxyURL != "" {
+				c.SetProxy(kc.Spec.ProxyURL)
+			}
+
+			adapter.SetTokenEndpointPath(c, kc.Spec.TokenEndpointPath)
+		})
+
+	return c, nil
+}
+
+// getKeycloakHeaders reads the HTTP headers of the keycloak from the referenced config maps and secrets,
+// the headers of the later sources override the earlier ones. The basic credentials of the reverse proxy
+// are added to the headers.
+func (h *Helper) getKeycloakHeaders(ctx context.Context, kc *keycloakApi.Keycloak) (map[string]string, error) {
+	headers := make(map[string]string)
+
+	for i, src := range kc.Spec.HeadersFrom {
+		switch {
+		case src.ConfigMapName != "" && src.SecretName == "":
+			var cm coreV1.ConfigMap
+			if err := h.client.Get(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: src.ConfigMapName}, &cm); err != nil {
+				return nil, errors.Wrapf(err, "unable to get headers config map %s", src.ConfigMapName)
+			}
+
+			for k, v := range cm.Data {
+				headers[k] = v
+			}
+		case src.SecretName != "" && src.ConfigMapName == "":
+			var secret coreV1.Secret
+			if err := h.client.Get(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: src.SecretName}, &secret); err != nil {
+				return nil, errors.Wrapf(err, "unable to get headers secret %s", src.SecretName)
+			}
+
+			for k, v := range secret.Data {
+				headers[k] = string(v)
+			}
+		default:
+			return nil, errors.Errorf("exactly one of configMapName or secretName must be set in headers source #%d", i+1)
+		}
+	}
+
+	if auth := kc.Spec.ProxyAuth; auth != nil {
+		var secret coreV1.Secret
+		if err := h.client.Get(ctx, types.NamespacedName{Namespace: kc.Namespace, Name: auth.Secret}, &secret); err != nil {
+			return nil, errors.Wrapf(err, "unable to get proxy auth secret %s", auth.Secret)
+		}
+
+		credentials := string(secret.Data["username"]) + ":" + string(secret.Data["password"])
+		headers[auth.GetHeader()] = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+
+	return headers, nil
+}
+
+// keycloakBaseURL returns the base URL of the keycloak API at the URL with the context path of the keycloak.
+// If the context path is not set, it is detected once for the URL.
+func (h *Helper) keycloakBaseURL(ctx context.Context, kc *keycloakApi.Keycloak, restyClient *resty.Client,
+	url string) string {
+	url = strings.TrimSuffix(url, "/")
+
+	if kc.Spec.ContextPath != "" {
+		contextPath := strings.TrimSuffix(kc.Spec.ContextPath, "/")
+		if strings.HasSuffix(url, contextPath) {
+			return url
+		}
+
+		return url + contextPath
+	}
+
+	h.baseURLsLock.Lock()
+	defer h.baseURLsLock.Unlock()
+
+	if base, ok := h.baseURLs[url]; ok {
+		return base
+	}
+
+	base, err := adapter.ResolveBaseURL(ctx, restyClient, url)
+	if err != nil {
+		// the url is used as is, the connection error is reported by the login
+		return url
+	}
+
+	if h.baseURLs == nil {
+		h.baseURLs = make(map[string]string)
+	}
+
+	h.baseURLs[url] = base
+
+	return base
+}
+
+func hashHeaders(headers map[string]string) []byte {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	hash := fnv.New64a()
+
+	for _, name := range names {
+		_, _ = fmt.Fprintf(hash, "%s=%s\n", name, headers[name])
+	}
+
+	return hash.Sum(nil)
+}
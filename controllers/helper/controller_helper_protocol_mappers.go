@@ -0,0 +1,33 @@
+package helper
+
+import (
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// ConvertProtocolMappers converts protocol mappers from the spec to the keycloak representation.
+// Mappers without protocol get the default protocol of the client or the client scope they belong to.
+func ConvertProtocolMappers(mappers []keycloakApi.ProtocolMapper, defaultProtocol string) []adapter.ProtocolMapper {
+	aMappers := make([]adapter.ProtocolMapper, 0, len(mappers))
+
+	for _, m := range mappers {
+		protocol := m.Protocol
+		if protocol == "" {
+			protocol = defaultProtocol
+		}
+
+		config := make(map[string]string, len(m.Config))
+		for k, v := range m.Config {
+			config[k] = v
+		}
+
+		aMappers = append(aMappers, adapter.ProtocolMapper{
+			Name:           m.Name,
+			Config:         config,
+			ProtocolMapper: m.ProtocolMapper,
+			Protocol:       protocol,
+		})
+	}
+
+	return aMappers
+}
@@ -0,0 +1,28 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestConvertProtocolMappers(t *testing.T) {
+	spec := []keycloakApi.ProtocolMapper{
+		{Name: "test1", ProtocolMapper: "oidc-audience-mapper", Config: map[string]string{"a": "b"}},
+		{Name: "test2", Protocol: "saml"},
+	}
+
+	mappers := ConvertProtocolMappers(spec, "openid-connect")
+	assert.Equal(t, []adapter.ProtocolMapper{
+		{Name: "test1", Protocol: "openid-connect", ProtocolMapper: "oidc-audience-mapper", Config: map[string]string{"a": "b"}},
+		{Name: "test2", Protocol: "saml", Config: map[string]string{}},
+	}, mappers)
+
+	mappers[0].Config["a"] = "c"
+	assert.Equal(t, "b", spec[0].Config["a"], "spec config must not be modified")
+
+	assert.Empty(t, ConvertProtocolMappers(nil, "openid-connect"))
+}
@@ -16,6 +16,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -126,6 +127,213 @@ func TestHelper_GetOrCreateRealmOwnerRef_Failure(t *testing.T) {
 	assert.ErrorIs(t, err, mockErr)
 }
 
+func TestHelper_GetOrCreateRealmOwnerRef_ClusterRealm(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	clusterRealm := v13.ClusterKeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+		Spec: v13.ClusterKeycloakRealmSpec{
+			RealmName:         "shared-realm",
+			KeycloakRef:       v13.KeycloakRef{Name: "keycloak", Namespace: "security"},
+			AllowedNamespaces: []string{"team-a"},
+		},
+	}
+
+	helper := MakeHelper(fake.NewClientBuilder().WithScheme(sch).WithObjects(&clusterRealm).Build(), sch,
+		mock.NewLogr())
+
+	group := v13.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "team-a"},
+		Spec:       v13.KeycloakRealmGroupSpec{Name: "developers", ClusterRealm: "shared"},
+	}
+
+	realm, err := helper.GetOrCreateRealmOwnerRef(&group, &group.ObjectMeta)
+	require.NoError(t, err)
+	assert.Equal(t, "shared-realm", realm.Spec.RealmName)
+	assert.Equal(t, "keycloak", realm.Spec.KeycloakOwner)
+	assert.Equal(t, "security", realm.Namespace)
+	assert.Empty(t, group.OwnerReferences)
+
+	group.Namespace = "team-b"
+
+	_, err = helper.GetOrCreateRealmOwnerRef(&group, &group.ObjectMeta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can not be referenced from namespace team-b")
+
+	group.Spec.ClusterRealm = "missing"
+
+	_, err = helper.GetOrCreateRealmOwnerRef(&group, &group.ObjectMeta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get cluster realm from k8s")
+}
+
+func TestHelper_GetOrCreateRealmOwnerRef_KeycloakRef(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	realm := v13.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "team-a"},
+		Spec:       v13.KeycloakRealmSpec{RealmName: "main", KeycloakOwner: "keycloak"},
+	}
+
+	helper := MakeHelper(fake.NewClientBuilder().WithScheme(sch).WithObjects(&realm).Build(), sch, mock.NewLogr())
+
+	role := v13.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "admin", Namespace: "team-a"},
+		Spec: v13.KeycloakRealmRoleSpec{
+			Name:        "admin",
+			Realm:       "main",
+			KeycloakRef: &v13.KeycloakRef{Name: "keycloak-eu"},
+		},
+	}
+
+	got, err := helper.GetOrCreateRealmOwnerRef(&role, &role.ObjectMeta)
+	require.NoError(t, err)
+	assert.Equal(t, &v13.KeycloakRef{Name: "keycloak-eu", Namespace: "team-a"}, got.Spec.KeycloakRef)
+	assert.Equal(t, "keycloak", got.Spec.KeycloakOwner)
+
+	role.Spec.KeycloakRef = &v13.KeycloakRef{Name: "keycloak", Namespace: "security"}
+
+	_, err = helper.GetOrCreateRealmOwnerRef(&role, &role.ObjectMeta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "keycloak security/keycloak can not be referenced from namespace team-a")
+}
+
+func TestHelper_GetOrCreateKeycloakOwnerRef_KeycloakRef(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	kc := v13.Keycloak{ObjectMeta: metav1.ObjectMeta{Name: "keycloak-eu", Namespace: "security"}}
+	owner := v13.Keycloak{ObjectMeta: metav1.ObjectMeta{Name: "keycloak", Namespace: "team-a"}}
+
+	helper := MakeHelper(fake.NewClientBuilder().WithScheme(sch).WithObjects(&kc, &owner).Build(), sch,
+		mock.NewLogr())
+
+	realm := v13.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "team-a"},
+		Spec: v13.KeycloakRealmSpec{
+			RealmName:     "main",
+			KeycloakOwner: "keycloak",
+			KeycloakRef:   &v13.KeycloakRef{Name: "keycloak-eu", Namespace: "security"},
+		},
+	}
+
+	got, err := helper.GetOrCreateKeycloakOwnerRef(&realm)
+	require.NoError(t, err)
+	assert.Equal(t, "keycloak-eu", got.Name)
+	assert.Equal(t, "security", got.Namespace)
+	assert.Empty(t, realm.OwnerReferences)
+
+	realm.Spec.KeycloakRef = &v13.KeycloakRef{Name: "keycloak"}
+
+	got, err = helper.GetOrCreateKeycloakOwnerRef(&realm)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", got.Namespace)
+
+	realm.Spec.KeycloakRef = &v13.KeycloakRef{Kind: "ClusterKeycloak", Name: "keycloak"}
+
+	_, err = helper.GetOrCreateKeycloakOwnerRef(&realm)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "keycloak kind ClusterKeycloak is not supported")
+
+	realm.Spec.KeycloakRef = &v13.KeycloakRef{Name: "missing"}
+
+	_, err = helper.GetOrCreateKeycloakOwnerRef(&realm)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get keycloak team-a/missing from k8s")
+}
+
+func TestHelper_GetOrCreateRealmOwnerRef_CrossNamespace(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	realm := v13.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "main",
+			Namespace:   "security",
+			Annotations: map[string]string{v13.AllowedNamespacesAnnotation: "team-a, team-c"},
+		},
+		Spec: v13.KeycloakRealmSpec{RealmName: "main"},
+	}
+
+	helper := MakeHelper(fake.NewClientBuilder().WithScheme(sch).WithObjects(&realm).Build(), sch, mock.NewLogr())
+
+	group := v13.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "team-a"},
+		Spec:       v13.KeycloakRealmGroupSpec{Name: "developers", Realm: "main", RealmNamespace: "security"},
+	}
+
+	got, err := helper.GetOrCreateRealmOwnerRef(&group, &group.ObjectMeta)
+	require.NoError(t, err)
+	assert.Equal(t, "security", got.Namespace)
+	assert.Empty(t, group.OwnerReferences)
+
+	group.Namespace = "team-b"
+
+	_, err = helper.GetOrCreateRealmOwnerRef(&group, &group.ObjectMeta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "realm security/main can not be referenced from namespace team-b")
+
+	realm.Annotations[v13.AllowedNamespacesAnnotation] = "*"
+	assert.True(t, realm.IsNamespaceAllowed("team-b"))
+
+	delete(realm.Annotations, v13.AllowedNamespacesAnnotation)
+	assert.False(t, realm.IsNamespaceAllowed("team-a"))
+	assert.True(t, realm.IsNamespaceAllowed("security"))
+}
+
+func TestHelper_GetOrCreateRealmOwnerRef_RealmSelector(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	realm := v13.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "main",
+			Namespace: "team-a",
+			Labels:    map[string]string{"tier": "shared"},
+		},
+		Spec: v13.KeycloakRealmSpec{RealmName: "main"},
+	}
+
+	other := v13.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-a", Labels: map[string]string{"tier": "dev"}},
+		Spec:       v13.KeycloakRealmSpec{RealmName: "other"},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(&realm, &other).Build()
+	helper := MakeHelper(k8sClient, sch, mock.NewLogr())
+
+	group := v13.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "team-a"},
+		Spec: v13.KeycloakRealmGroupSpec{
+			Name:          "developers",
+			RealmSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "shared"}},
+		},
+	}
+
+	got, err := helper.GetOrCreateRealmOwnerRef(&group, &group.ObjectMeta)
+	require.NoError(t, err)
+	assert.Equal(t, "main", got.Name)
+	assert.Empty(t, group.OwnerReferences)
+
+	group.Spec.RealmSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "tier", Operator: metav1.LabelSelectorOpExists},
+	}}
+
+	_, err = helper.GetOrCreateRealmOwnerRef(&group, &group.ObjectMeta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly one realm must match the selector")
+
+	group.Namespace = "team-b"
+	group.Spec.RealmNamespace = "team-a"
+	group.Spec.RealmSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "shared"}}
+
+	_, err = helper.GetOrCreateRealmOwnerRef(&group, &group.ObjectMeta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "realm team-a/main can not be referenced from namespace team-b")
+}
+
 func TestHelper_GetOrCreateKeycloakOwnerRef(t *testing.T) {
 	mc := K8SClientMock{}
 
@@ -280,7 +488,7 @@ func TestMakeHelper(t *testing.T) {
 	logger := mock.NewLogr()
 	h := MakeHelper(nil, nil, logger)
 	_, err := h.adapterBuilder(context.Background(), "k-url", "foo", "bar",
-		v13.KeycloakAdminTypeServiceAccount, logger, rCl)
+		v13.KeycloakAdminTypeServiceAccount, "master", logger, rCl)
 	require.NoError(t, err)
 }
 
@@ -374,3 +582,28 @@ func TestHelper_TryToDelete(t *testing.T) {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
+
+func TestHelper_TryToDelete_FinalizerOwner(t *testing.T) {
+	term := testTerminator{log: mock.NewLogr()}
+	secret := v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-secret1", Labels: map[string]string{"tenant": "a"}}}
+	fakeClient := fake.NewClientBuilder().WithRuntimeObjects(&secret).Build()
+
+	shardA := Helper{client: fakeClient, watchSelector: labels.SelectorFromSet(labels.Set{"tenant": "a"})}
+	overlapping := Helper{client: fakeClient, watchSelector: labels.Everything()}
+
+	_, err := shardA.TryToDelete(context.Background(), &secret, &term, "fin")
+	require.NoError(t, err)
+	assert.Equal(t, "tenant=a", secret.Annotations[v13.FinalizerOwnerAnnotation])
+	assert.Contains(t, secret.Finalizers, "fin")
+
+	_, err = overlapping.TryToDelete(context.Background(), &secret, &term, "fin")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `finalizer of the resource is owned by the operator instance watching "tenant=a"`)
+
+	secret.Labels["tenant"] = "b"
+
+	_, err = overlapping.TryToDelete(context.Background(), &secret, &term, "fin")
+	require.NoError(t, err)
+	assert.NotContains(t, secret.Annotations, v13.FinalizerOwnerAnnotation)
+	assert.Contains(t, secret.Finalizers, "fin")
+}
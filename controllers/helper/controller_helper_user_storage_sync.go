@@ -0,0 +1,83 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// UserStorageSyncRequested checks if the sync annotation was added or changed between object versions.
+func UserStorageSyncRequested(oldObj, newObj metav1.Object) bool {
+	return newObj.GetAnnotations()[keycloakApi.UserStorageSyncAnnotation] != "" &&
+		oldObj.GetAnnotations()[keycloakApi.UserStorageSyncAnnotation] != newObj.GetAnnotations()[keycloakApi.UserStorageSyncAnnotation]
+}
+
+// SyncUserStorageByAnnotation triggers user storage synchronization if the object has the sync annotation.
+// The annotation is removed from the object before the sync, so the sync is not repeated if the status update
+// fails, a failed sync is reported with the error and must be requested again.
+// It returns nil status if sync was not requested.
+func SyncUserStorageByAnnotation(ctx context.Context, k8sClient client.Client, kClient keycloak.Client, obj client.Object,
+	realmName, componentName string) (*keycloakApi.UserStorageSyncStatus, error) {
+	mode, ok := obj.GetAnnotations()[keycloakApi.UserStorageSyncAnnotation]
+	if !ok || !obj.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, keycloakApi.UserStorageSyncAnnotation)
+	obj.SetAnnotations(annotations)
+
+	if err := k8sClient.Update(ctx, obj); err != nil {
+		return nil, fmt.Errorf("unable to remove sync annotation: %w", err)
+	}
+
+	return SyncUserStorage(ctx, kClient, realmName, componentName, mode)
+}
+
+// SyncUserStorage triggers synchronization of users from the user storage provider component in the given mode.
+func SyncUserStorage(
+	ctx context.Context, kClient keycloak.Client, realmName, componentName, mode string,
+) (*keycloakApi.UserStorageSyncStatus, error) {
+	action, err := userStorageSyncAction(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	cmp, err := kClient.GetComponent(ctx, realmName, componentName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get component %s: %w", componentName, err)
+	}
+
+	res, err := kClient.SyncUserStorage(ctx, realmName, cmp.ID, action)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sync user storage %s: %w", componentName, err)
+	}
+
+	return &keycloakApi.UserStorageSyncStatus{
+		Mode:    mode,
+		Time:    metav1.Now(),
+		Added:   res.Added,
+		Updated: res.Updated,
+		Removed: res.Removed,
+		Failed:  res.Failed,
+		Status:  res.Status,
+	}, nil
+}
+
+func userStorageSyncAction(mode string) (string, error) {
+	switch mode {
+	case keycloakApi.UserStorageSyncFull:
+		return adapter.UserStorageFullSync, nil
+	case keycloakApi.UserStorageSyncChanged:
+		return adapter.UserStorageChangedUsersSync, nil
+	default:
+		return "", fmt.Errorf("unknown user storage sync mode %q, allowed values: %s, %s", mode,
+			keycloakApi.UserStorageSyncFull, keycloakApi.UserStorageSyncChanged)
+	}
+}
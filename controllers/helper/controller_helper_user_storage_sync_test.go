@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v13 "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestSyncUserStorageByAnnotation(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	comp := v13.KeycloakRealmComponent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ldap",
+			Namespace:   "ns",
+			Annotations: map[string]string{v13.UserStorageSyncAnnotation: v13.UserStorageSyncChanged},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(&comp).Build()
+
+	var kClient adapter.Mock
+
+	kClient.On("GetComponent", "realm", "ldap").Return(&adapter.Component{ID: "cmp-id"}, nil)
+	kClient.On("SyncUserStorage", "realm", "cmp-id", adapter.UserStorageChangedUsersSync).
+		Return(&adapter.UserStorageSyncResult{Added: 3, Failed: 1, Status: "done"}, nil)
+
+	status, err := SyncUserStorageByAnnotation(context.Background(), k8sClient, &kClient, &comp, "realm", "ldap")
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, v13.UserStorageSyncChanged, status.Mode)
+	assert.Equal(t, 3, status.Added)
+	assert.Equal(t, 1, status.Failed)
+
+	var updated v13.KeycloakRealmComponent
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&comp), &updated))
+	assert.NotContains(t, updated.Annotations, v13.UserStorageSyncAnnotation)
+
+	status, err = SyncUserStorageByAnnotation(context.Background(), k8sClient, &kClient, &updated, "realm", "ldap")
+	require.NoError(t, err)
+	assert.Nil(t, status)
+
+	updated.Annotations = map[string]string{v13.UserStorageSyncAnnotation: "wrong"}
+	_, err = SyncUserStorageByAnnotation(context.Background(), k8sClient, &kClient, &updated, "realm", "ldap")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown user storage sync mode")
+
+	// the annotation is removed before the sync, so the failed sync is not repeated.
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(&comp), &updated))
+	assert.NotContains(t, updated.Annotations, v13.UserStorageSyncAnnotation)
+}
+
+func TestSyncUserStorageByAnnotation_UpdateFailure(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(v13.AddToScheme(sch))
+
+	comp := v13.KeycloakRealmComponent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ldap",
+			Namespace:   "ns",
+			Annotations: map[string]string{v13.UserStorageSyncAnnotation: v13.UserStorageSyncFull},
+		},
+	}
+
+	// the object does not exist, so the annotation can not be removed and the sync is not started.
+	var kClient adapter.Mock
+
+	_, err := SyncUserStorageByAnnotation(context.Background(), fake.NewClientBuilder().WithScheme(sch).Build(),
+		&kClient, &comp, "realm", "ldap")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to remove sync annotation")
+	kClient.AssertNotCalled(t, "SyncUserStorage")
+}
+
+func TestUserStorageSyncRequested(t *testing.T) {
+	oldObj := v13.KeycloakRealmComponent{}
+	newObj := v13.KeycloakRealmComponent{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{v13.UserStorageSyncAnnotation: v13.UserStorageSyncFull},
+	}}
+
+	assert.True(t, UserStorageSyncRequested(&oldObj, &newObj))
+	assert.False(t, UserStorageSyncRequested(&newObj, &newObj))
+	assert.False(t, UserStorageSyncRequested(&newObj, &oldObj))
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	v13 "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
@@ -71,6 +73,10 @@ func (m *Mock) IsOwner(slave client.Object, master client.Object) bool {
 	return m.Called(slave, master).Bool(0)
 }
 
+func (m *Mock) SelectKeycloakURL(_ context.Context, kc *v13.Keycloak) error {
+	return m.Called(kc).Error(0)
+}
+
 func (m *Mock) CreateKeycloakClientFromTokenSecret(ctx context.Context, kc *v13.Keycloak) (keycloak.Client, error) {
 	called := m.Called(kc)
 	if err := called.Error(1); err != nil {
@@ -96,3 +102,17 @@ func (m *Mock) InvalidateKeycloakClientTokenSecret(ctx context.Context, namespac
 func (m *Mock) TokenSecretLock() *sync.Mutex {
 	return &m.tokenSecretLock
 }
+
+func (m *Mock) ReleaseKeycloakConnection(nn types.NamespacedName) {
+	m.Called(nn)
+}
+
+func (m *Mock) CheckKeycloakCircuit(_ context.Context, kc *v13.Keycloak) (time.Time, bool) {
+	called := m.Called(kc)
+
+	return called.Get(0).(time.Time), called.Bool(1)
+}
+
+func (m *Mock) KeycloakCircuitEvents() <-chan event.GenericEvent {
+	return nil
+}
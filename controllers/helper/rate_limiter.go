@@ -0,0 +1,117 @@
+package helper
+
+import (
+	"math"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// rateLimitError is returned if the request can not wait for the rate limit, e.g. its context is done.
+// The request is not sent to keycloak.
+type rateLimitError struct {
+	err error
+}
+
+func (e rateLimitError) Error() string {
+	return "unable to wait for the rate limit of keycloak: " + e.err.Error()
+}
+
+func (e rateLimitError) Unwrap() error {
+	return e.err
+}
+
+// SetRateLimit limits the rate of the requests to all keycloak instances together, so a burst of the reconciliations,
+// e.g. after the operator restart, does not overload keycloak. The rate limit of the Keycloak is applied on top of it.
+// The requests are not limited if the requests per second are not positive, the burst defaults to the rate.
+func (h *Helper) SetRateLimit(requestsPerSecond float64, burst int) {
+	h.getConnections().setGlobalLimit(rateLimit(requestsPerSecond, burst))
+}
+
+// keycloakRateLimit returns the rate limit of the requests to the keycloak.
+func (h *Helper) keycloakRateLimit(kc *keycloakApi.Keycloak) (rate.Limit, int) {
+	if kc.Spec.RateLimit == nil {
+		return rate.Inf, 0
+	}
+
+	return rateLimit(float64(kc.Spec.RateLimit.RequestsPerSecond), kc.Spec.RateLimit.Burst)
+}
+
+func rateLimit(requestsPerSecond float64, burst int) (rate.Limit, int) {
+	if requestsPerSecond <= 0 {
+		return rate.Inf, 0
+	}
+
+	if burst <= 0 {
+		burst = int(math.Ceil(requestsPerSecond))
+	}
+
+	return rate.Limit(requestsPerSecond), burst
+}
+
+// setLimit updates the rate limit of the keycloak, the requests waiting for the limiter are not affected.
+func (m *connectionManager) setLimit(nn types.NamespacedName, limit rate.Limit, burst int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, ok := m.limiters[nn]
+	if !ok {
+		m.limiters[nn] = rate.NewLimiter(limit, burst)
+
+		return
+	}
+
+	if l.Limit() != limit || l.Burst() != burst {
+		l.SetBurst(burst)
+		l.SetLimit(limit)
+	}
+}
+
+// setGlobalLimit replaces the limiter shared by all keycloak instances.
+func (m *connectionManager) setGlobalLimit(limit rate.Limit, burst int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.global = rate.NewLimiter(limit, burst)
+}
+
+func (m *connectionManager) getLimiter(nn types.NamespacedName) *rate.Limiter {
+	l, ok := m.limiters[nn]
+	if !ok {
+		l = rate.NewLimiter(rate.Inf, 0)
+		m.limiters[nn] = l
+	}
+
+	return l
+}
+
+// rateLimiters returns the limiter of the keycloak and the limiter shared by all keycloak instances.
+func (m *connectionManager) rateLimiters(nn types.NamespacedName) []*rate.Limiter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return []*rate.Limiter{m.getLimiter(nn), m.global}
+}
+
+// installRateLimiter makes the requests of the resty client wait for the rate limit of the keycloak
+// and for the global rate limit. The health checks are not limited, so the failover is not delayed
+// by the waiting requests.
+func (m *connectionManager) installRateLimiter(c *resty.Client, nn types.NamespacedName) {
+	c.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if adapter.IsHealthCheck(req.Context()) {
+			return nil
+		}
+
+		for _, l := range m.rateLimiters(nn) {
+			if err := l.Wait(req.Context()); err != nil {
+				return rateLimitError{err: err}
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,179 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// tokenStore stores the keycloak tokens of the operator with their metadata.
+// The get method returns the k8s NotFound error if the token is not stored.
+type tokenStore interface {
+	get(ctx context.Context, nn types.NamespacedName) (map[string][]byte, error)
+	save(ctx context.Context, nn types.NamespacedName, data map[string][]byte) error
+	delete(ctx context.Context, nn types.NamespacedName) error
+}
+
+// secretTokenStore stores the tokens in the k8s secrets, so they are shared by the operator restarts.
+type secretTokenStore struct {
+	client client.Client
+}
+
+func (s *secretTokenStore) get(ctx context.Context, nn types.NamespacedName) (map[string][]byte, error) {
+	var secret coreV1.Secret
+	if err := s.client.Get(ctx, nn, &secret); err != nil {
+		return nil, errors.Wrap(err, "unable to get token secret")
+	}
+
+	return secret.Data, nil
+}
+
+func (s *secretTokenStore) save(ctx context.Context, nn types.NamespacedName, data map[string][]byte) error {
+	var secret coreV1.Secret
+
+	err := s.client.Get(ctx, nn, &secret)
+	if err == nil {
+		secret.Data = data
+
+		if err = s.client.Update(ctx, &secret); err != nil {
+			return errors.Wrap(err, "unable to update token secret")
+		}
+
+		return nil
+	}
+
+	if k8sErrors.IsNotFound(err) {
+		secret = coreV1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace: nn.Namespace,
+			Name:      nn.Name,
+		}, Data: data}
+
+		if err = s.client.Create(ctx, &secret); err != nil {
+			return errors.Wrap(err, "unable to create token secret")
+		}
+
+		return nil
+	}
+
+	return errors.Wrap(err, "error during token secret retrieval")
+}
+
+func (s *secretTokenStore) delete(ctx context.Context, nn types.NamespacedName) error {
+	var secret coreV1.Secret
+	if err := s.client.Get(ctx, nn, &secret); err != nil {
+		return errors.Wrap(err, "unable to get token secret")
+	}
+
+	if err := s.client.Delete(ctx, &secret); err != nil {
+		return errors.Wrap(err, "unable to delete token secret")
+	}
+
+	return nil
+}
+
+// memoryTokenStore stores the tokens in the memory of the operator, so they are not stored at rest in etcd.
+// The operator logs in again after the restart. The token secrets left by the secret store are removed
+// when the token is saved for the first time.
+type memoryTokenStore struct {
+	client client.Client
+	mu     sync.Mutex
+	tokens map[types.NamespacedName]map[string][]byte
+}
+
+func newMemoryTokenStore(k8sClient client.Client) *memoryTokenStore {
+	return &memoryTokenStore{client: k8sClient, tokens: make(map[types.NamespacedName]map[string][]byte)}
+}
+
+func (s *memoryTokenStore) get(_ context.Context, nn types.NamespacedName) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.tokens[nn]
+	if !ok {
+		return nil, k8sErrors.NewNotFound(coreV1.Resource("secrets"), nn.Name)
+	}
+
+	return copyTokenData(data), nil
+}
+
+func (s *memoryTokenStore) save(ctx context.Context, nn types.NamespacedName, data map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tokens[nn]; !ok {
+		secret := coreV1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: nn.Namespace, Name: nn.Name}}
+		if err := s.client.Delete(ctx, &secret); err != nil && !k8sErrors.IsNotFound(err) {
+			return errors.Wrap(err, "unable to delete token secret")
+		}
+	}
+
+	s.tokens[nn] = copyTokenData(data)
+
+	return nil
+}
+
+func (s *memoryTokenStore) delete(_ context.Context, nn types.NamespacedName) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tokens[nn]; !ok {
+		return k8sErrors.NewNotFound(coreV1.Resource("secrets"), nn.Name)
+	}
+
+	delete(s.tokens, nn)
+
+	return nil
+}
+
+func copyTokenData(data map[string][]byte) map[string][]byte {
+	res := make(map[string][]byte, len(data))
+
+	for k, v := range data {
+		res[k] = append([]byte(nil), v...)
+	}
+
+	return res
+}
+
+// trackToken records the access token of the stored token, so the stored token is removed if keycloak rejects it.
+func (h *Helper) trackToken(nn types.NamespacedName, tokenData []byte) {
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+
+	if err := json.Unmarshal(tokenData, &token); err != nil || token.AccessToken == "" {
+		return
+	}
+
+	h.issuedTokensLock.Lock()
+	defer h.issuedTokensLock.Unlock()
+
+	if h.issuedTokens == nil {
+		h.issuedTokens = make(map[types.NamespacedName]string)
+	}
+
+	h.issuedTokens[nn] = token.AccessToken
+}
+
+// forgetToken stops tracking the access token and returns the namespaced name of the stored token it belongs to.
+func (h *Helper) forgetToken(accessToken string) (types.NamespacedName, bool) {
+	h.issuedTokensLock.Lock()
+	defer h.issuedTokensLock.Unlock()
+
+	for nn, token := range h.issuedTokens {
+		if token == accessToken {
+			delete(h.issuedTokens, nn)
+
+			return nn, true
+		}
+	}
+
+	return types.NamespacedName{}, false
+}
@@ -3,19 +3,26 @@ package keycloak
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	pkgErrors "github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
@@ -31,9 +38,26 @@ const (
 )
 
 type Helper interface {
+	SelectKeycloakURL(ctx context.Context, kc *keycloakApi.Keycloak) error
 	CreateKeycloakClientFromTokenSecret(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error)
 	CreateKeycloakClientFromLoginPassword(ctx context.Context, kc *keycloakApi.Keycloak) (keycloak.Client, error)
 	TokenSecretLock() *sync.Mutex
+	ReleaseKeycloakConnection(nn types.NamespacedName)
+	CheckKeycloakCircuit(ctx context.Context, kc *keycloakApi.Keycloak) (retryAt time.Time, open bool)
+	KeycloakCircuitEvents() <-chan event.GenericEvent
+}
+
+// minDegradedRequeue is the shortest delay of the reconciliation of the degraded keycloak,
+// the probe may be already due when the reconciliation finishes.
+const minDegradedRequeue = time.Second
+
+// degradedError is returned by the reconciliation while the requests to keycloak are suspended.
+type degradedError struct {
+	retryAt time.Time
+}
+
+func (e *degradedError) Error() string {
+	return fmt.Sprintf("keycloak is unavailable, it is probed at %s", e.retryAt.Format(time.RFC3339))
 }
 
 func NewReconcileKeycloak(client client.Client, scheme *runtime.Scheme, log logr.Logger, helper Helper) *ReconcileKeycloak {
@@ -65,6 +89,9 @@ func (r *ReconcileKeycloak) SetupWithManager(mgr ctrl.Manager, successReconcileT
 
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakApi.Keycloak{}, builder.WithPredicates(pred)).
+		Watches(&source.Kind{Type: &coreV1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.mapSecretToKeycloaks),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: isSecretDataUpdated})).
+		Watches(&source.Channel{Source: r.helper.KeycloakCircuitEvents()}, &handler.EnqueueRequestForObject{}).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup Keycloak controller: %w", err)
@@ -73,6 +100,44 @@ func (r *ReconcileKeycloak) SetupWithManager(mgr ctrl.Manager, successReconcileT
 	return nil
 }
 
+// mapSecretToKeycloaks returns reconcile requests for keycloaks which use the secret as the admin credentials,
+// so the operator logs in again when the credentials are rotated.
+func (r *ReconcileKeycloak) mapSecretToKeycloaks(object client.Object) []reconcile.Request {
+	var kcList keycloakApi.KeycloakList
+	if err := r.client.List(context.Background(), &kcList, client.InNamespace(object.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list keycloaks for secret", "secret", object.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range kcList.Items {
+		if kcList.Items[i].Spec.Secret == object.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: kcList.Items[i].Namespace,
+				Name:      kcList.Items[i].Name,
+			}})
+		}
+	}
+
+	return requests
+}
+
+func isSecretDataUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*coreV1.Secret)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*coreV1.Secret)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Data, no.Data)
+}
+
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloaks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloaks/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloaks/finalizers,verbs=update
@@ -86,6 +151,8 @@ func (r *ReconcileKeycloak) Reconcile(ctx context.Context, request reconcile.Req
 	if err := r.client.Get(ctx, request.NamespacedName, instance); err != nil {
 		if errors.IsNotFound(err) {
 			log.Info("instance not found")
+			r.helper.ReleaseKeycloakConnection(request.NamespacedName)
+
 			return reconcile.Result{}, nil
 		}
 
@@ -95,7 +162,15 @@ func (r *ReconcileKeycloak) Reconcile(ctx context.Context, request reconcile.Req
 	}
 
 	if err := r.tryToReconcile(ctx, instance, request); err != nil {
+		var degraded *degradedError
+		if pkgErrors.As(err, &degraded) {
+			log.Info("Keycloak is degraded", "reason", err.Error())
+
+			return reconcile.Result{RequeueAfter: degradedRequeue(degraded.retryAt)}, nil
+		}
+
 		log.Error(err, "error during reconcilation")
+
 		return reconcile.Result{RequeueAfter: helper.DefaultRequeueTime}, nil
 	}
 
@@ -128,14 +203,21 @@ func (r *ReconcileKeycloak) updateConnectionStatusToKeycloak(ctx context.Context
 	log := r.log.WithValues(keycloakCRLogKey, instance)
 	log.Info("Start updating connection status to Keycloak")
 
-	connected, err := r.isInstanceConnected(ctx, instance, log)
-	if err != nil {
-		return pkgErrors.Wrap(err, "error during kc checking connection")
+	// the connection is not checked while the requests to keycloak are suspended
+	retryAt, degraded := r.helper.CheckKeycloakCircuit(ctx, instance)
+	if !degraded {
+		connected, err := r.isInstanceConnected(ctx, instance, log)
+		if err != nil {
+			return pkgErrors.Wrap(err, "error during kc checking connection")
+		}
+
+		instance.Status.Connected = connected
 	}
 
-	instance.Status.Connected = connected
+	setInsecureSkipVerifyCondition(instance, log)
+	setDegradedCondition(instance, degraded)
 
-	err = r.client.Status().Update(ctx, instance)
+	err := r.client.Status().Update(ctx, instance)
 	if err != nil {
 		log.Error(err, "unable to update keycloak cr status")
 
@@ -148,14 +230,77 @@ func (r *ReconcileKeycloak) updateConnectionStatusToKeycloak(ctx context.Context
 
 	log.Info("Status has been updated", "status", instance.Status)
 
+	if degraded {
+		return &degradedError{retryAt: retryAt}
+	}
+
 	return nil
 }
 
+// degradedRequeue returns the delay of the next probe of the degraded keycloak.
+func degradedRequeue(retryAt time.Time) time.Duration {
+	if d := time.Until(retryAt); d > minDegradedRequeue {
+		return d
+	}
+
+	return minDegradedRequeue
+}
+
+// setDegradedCondition shows in the status whether the requests to keycloak are suspended by the circuit breaker.
+func setDegradedCondition(instance *keycloakApi.Keycloak, degraded bool) {
+	if !degraded {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    keycloakApi.ConditionDegraded,
+			Status:  metav1.ConditionFalse,
+			Reason:  keycloakApi.ReasonKeycloakAvailable,
+			Message: "Keycloak is available",
+		})
+
+		return
+	}
+
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    keycloakApi.ConditionDegraded,
+		Status:  metav1.ConditionTrue,
+		Reason:  keycloakApi.ReasonKeycloakUnavailable,
+		Message: "Keycloak is unavailable, the requests are suspended until it passes the health check",
+	})
+}
+
+// setInsecureSkipVerifyCondition shows in the status whether the verification of the keycloak TLS certificate is disabled.
+func setInsecureSkipVerifyCondition(instance *keycloakApi.Keycloak, log logr.Logger) {
+	if !instance.Spec.InsecureSkipVerify {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    keycloakApi.ConditionInsecureSkipVerify,
+			Status:  metav1.ConditionFalse,
+			Reason:  keycloakApi.ReasonTLSVerificationEnabled,
+			Message: "TLS certificate of keycloak is verified",
+		})
+
+		return
+	}
+
+	log.Info("TLS certificate verification of keycloak is disabled, it must not be used in production")
+
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    keycloakApi.ConditionInsecureSkipVerify,
+		Status:  metav1.ConditionTrue,
+		Reason:  keycloakApi.ReasonTLSVerificationDisabled,
+		Message: "TLS certificate of keycloak is not verified, it must not be used in production",
+	})
+}
+
 func (r *ReconcileKeycloak) isInstanceConnected(ctx context.Context, instance *keycloakApi.Keycloak,
 	logger logr.Logger) (bool, error) {
 	r.helper.TokenSecretLock().Lock()
 	defer r.helper.TokenSecretLock().Unlock()
 
+	if err := r.helper.SelectKeycloakURL(ctx, instance); err != nil {
+		logger.Error(err, "error during the selection of keycloak url")
+
+		return false, nil
+	}
+
 	_, err := r.helper.CreateKeycloakClientFromTokenSecret(ctx, instance)
 	if err == nil {
 		return true, nil
@@ -3,18 +3,22 @@ package keycloak
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
@@ -72,6 +76,8 @@ func TestReconcileKeycloak_ReconcileInvalidSpec(t *testing.T) {
 
 	logger := mock.NewLogr()
 	h := helper.Mock{}
+	h.On("SelectKeycloakURL", cr).Return(nil)
+	h.On("CheckKeycloakCircuit", cr).Return(time.Time{}, false)
 	h.On("CreateKeycloakClientFromTokenSecret", cr).
 		Return(nil, adapter.TokenExpiredError("token expired"))
 	h.On("CreateKeycloakClientFromLoginPassword", cr).Return(nil, errors.New("fatal"))
@@ -113,15 +119,19 @@ func TestReconcileKeycloak_Reconcile_FailureGetInstance(t *testing.T) {
 	s.AddKnownTypes(v1.SchemeGroupVersion, cr, &keycloakApi.KeycloakRealm{})
 	cl := fake.NewClientBuilder().WithRuntimeObjects(cr).Build()
 
+	rq := reconcile.Request{NamespacedName: types.NamespacedName{Name: "foo23", Namespace: "bar23"}}
+
+	h := helper.Mock{}
+	h.On("ReleaseKeycloakConnection", rq.NamespacedName).Return()
+
 	logger := mock.NewLogr()
 	r := ReconcileKeycloak{
 		client: cl,
 		scheme: s,
 		log:    logger,
+		helper: &h,
 	}
 
-	rq := reconcile.Request{NamespacedName: types.NamespacedName{Name: "foo23", Namespace: "bar23"}}
-
 	res, err := r.Reconcile(context.Background(), rq)
 	require.NoError(t, err)
 
@@ -166,6 +176,8 @@ func TestReconcileKeycloak_Reconcile_FailureUpdateConnectionStatusToKeycloak(t *
 
 	logger := mock.NewLogr()
 	h := helper.Mock{}
+	h.On("SelectKeycloakURL", cr).Return(nil)
+	h.On("CheckKeycloakCircuit", cr).Return(time.Time{}, false)
 	h.On("CreateKeycloakClientFromTokenSecret", cr).
 		Return(nil, adapter.TokenExpiredError("token expired"))
 	h.On("CreateKeycloakClientFromLoginPassword", cr).Return(nil,
@@ -215,6 +227,8 @@ func TestReconcileKeycloak_Reconcile_FailureIsStatusConnected(t *testing.T) {
 	cl.On("Get", types.NamespacedName{Namespace: kc.Namespace, Name: kc.Spec.Secret},
 		&corev1.Secret{}).Return(nil)
 
+	hm.On("SelectKeycloakURL", &kc).Return(nil)
+	hm.On("CheckKeycloakCircuit", &kc).Return(time.Time{}, false)
 	hm.On("CreateKeycloakClientFromTokenSecret", &kc).
 		Return(nil, adapter.TokenExpiredError("token expired"))
 	hm.On("CreateKeycloakClientFromLoginPassword", &kc).Return(&kClMock, nil)
@@ -230,3 +244,75 @@ func TestReconcileKeycloak_Reconcile_FailureIsStatusConnected(t *testing.T) {
 	require.Error(t, loggerSink.LastError())
 	assert.Contains(t, loggerSink.LastError().Error(), "isStatusConnected fatal")
 }
+
+func TestReconcileKeycloak_Reconcile_Degraded(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(s))
+
+	kc := keycloakApi.Keycloak{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "main"},
+		Spec: keycloakApi.KeycloakSpec{Url: "https://some", Secret: "kc-admin"}}
+	rq := reconcile.Request{NamespacedName: types.NamespacedName{Name: kc.Name, Namespace: kc.Namespace}}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(&kc).Build()
+
+	retryAt := time.Now().Add(time.Minute)
+	h := helper.Mock{}
+	h.On("CheckKeycloakCircuit", testifyMock.Anything).Return(retryAt, true)
+
+	r := NewReconcileKeycloak(cl, s, mock.NewLogr(), &h)
+
+	res, err := r.Reconcile(context.Background(), rq)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Minute, res.RequeueAfter, float64(time.Second))
+
+	var updated keycloakApi.Keycloak
+	require.NoError(t, cl.Get(context.Background(), rq.NamespacedName, &updated))
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, keycloakApi.ConditionDegraded))
+	h.AssertNotCalled(t, "CreateKeycloakClientFromTokenSecret", testifyMock.Anything)
+}
+
+func TestDegradedRequeue(t *testing.T) {
+	assert.InDelta(t, time.Minute, degradedRequeue(time.Now().Add(time.Minute)), float64(time.Second))
+	assert.Equal(t, minDegradedRequeue, degradedRequeue(time.Now()))
+	assert.Equal(t, minDegradedRequeue, degradedRequeue(time.Now().Add(-time.Minute)),
+		"the due probe must not be requeued immediately")
+}
+
+func TestSetInsecureSkipVerifyCondition(t *testing.T) {
+	kc := keycloakApi.Keycloak{Spec: keycloakApi.KeycloakSpec{InsecureSkipVerify: true}}
+
+	setInsecureSkipVerifyCondition(&kc, mock.NewLogr())
+	assert.True(t, meta.IsStatusConditionTrue(kc.Status.Conditions, keycloakApi.ConditionInsecureSkipVerify))
+
+	kc.Spec.InsecureSkipVerify = false
+
+	setInsecureSkipVerifyCondition(&kc, mock.NewLogr())
+	assert.True(t, meta.IsStatusConditionFalse(kc.Status.Conditions, keycloakApi.ConditionInsecureSkipVerify))
+	assert.Len(t, kc.Status.Conditions, 1)
+}
+
+func TestReconcileKeycloak_mapSecretToKeycloaks(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(s))
+	require.NoError(t, corev1.AddToScheme(s))
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&keycloakApi.Keycloak{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "ns"},
+			Spec:       keycloakApi.KeycloakSpec{Secret: "kc-admin"},
+		},
+		&keycloakApi.Keycloak{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"},
+			Spec:       keycloakApi.KeycloakSpec{Secret: "other-admin"},
+		},
+	).Build()
+
+	r := NewReconcileKeycloak(cl, s, mock.NewLogr(), &helper.Mock{})
+
+	requests := r.mapSecretToKeycloaks(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "kc-admin", Namespace: "ns"}})
+	require.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "main"}}}, requests)
+
+	old := &corev1.Secret{Data: map[string][]byte{"password": []byte("old")}}
+	assert.False(t, isSecretDataUpdated(event.UpdateEvent{ObjectOld: old, ObjectNew: old.DeepCopy()}))
+	assert.True(t, isSecretDataUpdated(event.UpdateEvent{ObjectOld: old,
+		ObjectNew: &corev1.Secret{Data: map[string][]byte{"password": []byte("new")}}}))
+}
@@ -14,8 +14,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
@@ -49,16 +51,23 @@ func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconci
 	}
 }
 
-func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+// SetupWithManager registers the controller, the flows changed in Keycloak are enqueued by the triggers.
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration,
+	triggers ...source.Source) error {
 	r.successReconcileTimeout = successReconcileTimeout
 
 	pred := predicate.Funcs{
 		UpdateFunc: isSpecUpdated,
 	}
 
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&keycloakApi.KeycloakAuthFlow{}, builder.WithPredicates(pred)).
-		Complete(r)
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakAuthFlow{}, builder.WithPredicates(pred))
+
+	for _, t := range triggers {
+		b = b.Watches(t, &handler.EnqueueRequestForObject{})
+	}
+
+	err := b.Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup keycloakAuthFlow controller: %w", err)
 	}
@@ -102,12 +111,20 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return
 	}
 
-	if err := r.tryReconcile(ctx, &instance); err != nil {
-		instance.Status.Value = err.Error()
+	err := r.tryReconcile(ctx, &instance)
+
+	switch {
+	case helper.IsDryRunPreview(err):
+		log.Info("Dry run is enabled, changes are not applied", "preview", err.Error())
+
+		helper.SetDryRunStatus(&instance, err)
+		result.RequeueAfter = r.successReconcileTimeout
+	case err != nil:
+		helper.SetErrorStatus(&instance, err)
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
 
 		log.Error(err, "an error has occurred while handling keycloak auth flow", "name", request.Name)
-	} else {
+	default:
 		result.RequeueAfter = r.successReconcileTimeout
 		helper.SetSuccessStatus(&instance)
 	}
@@ -122,6 +139,10 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 }
 
 func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.KeycloakAuthFlow) error {
+	if released, err := helper.ReleaseDeletedDryRun(ctx, r.client, instance, finalizerName); err != nil || released {
+		return err
+	}
+
 	realm, err := r.helper.GetOrCreateRealmOwnerRef(instance, &instance.ObjectMeta)
 	if err != nil {
 		return errors.Wrap(err, "unable to get realm owner ref")
@@ -132,6 +153,10 @@ func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.Keyc
 		return errors.Wrap(err, "unable to create keycloak client")
 	}
 
+	if helper.IsDryRunEnabled(instance) {
+		return helper.PreviewChanges(ctx, kClient, realm, instance)
+	}
+
 	keycloakAuthFlow := authFlowSpecToAdapterAuthFlow(&instance.Spec)
 
 	deleted, err := r.helper.TryToDelete(ctx, instance,
@@ -145,6 +170,10 @@ func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.Keyc
 		return nil
 	}
 
+	if errs := instance.Spec.ValidateAuthenticationExecutions(); len(errs) > 0 {
+		return errors.Wrap(errs.ToAggregate(), "invalid authentication executions")
+	}
+
 	if err := kClient.SyncAuthFlow(realm.Spec.RealmName, keycloakAuthFlow); err != nil {
 		return errors.Wrap(err, "unable to sync auth flow")
 	}
@@ -162,6 +191,7 @@ func authFlowSpecToAdapterAuthFlow(spec *keycloakApi.KeycloakAuthFlowSpec) *adap
 		AuthenticationExecutions: make([]adapter.AuthenticationExecution, 0, len(spec.AuthenticationExecutions)),
 		ParentName:               spec.ParentName,
 		ChildType:                spec.ChildType,
+		FallbackFlow:             spec.FallbackFlow,
 	}
 
 	for _, ae := range spec.AuthenticationExecutions {
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -71,7 +73,7 @@ func TestNewReconcile(t *testing.T) {
 
 	h.On("TryToDelete", &flow,
 		makeTerminator(&realm, keycloakAuthFlow, client, &kClient, log), finalizerName).Return(false, nil)
-	h.On("UpdateStatus", &flow).Return(nil)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
 
 	r := Reconcile{
 		helper:                  &h,
@@ -125,8 +127,8 @@ func TestReconcile_Reconcile_Failure(t *testing.T) {
 
 	h.On("GetOrCreateRealmOwnerRef", &flow, &flow.ObjectMeta).Return(&realm, nil)
 	h.On("CreateKeycloakClientForRealm", &realm).Return(&kClient, nil)
-	h.On("SetFailureCount", &flow).Return(time.Second)
-	h.On("UpdateStatus", &flow).Return(nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Second)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
 	h.On("TryToDelete", &flow,
 		makeTerminator(&realm, authFlowSpecToAdapterAuthFlow(&flow.Spec), client, &kClient, log),
 		finalizerName).Return(false, nil)
@@ -152,6 +154,14 @@ func TestReconcile_Reconcile_Failure(t *testing.T) {
 	if result.RequeueAfter != time.Second {
 		t.Fatal("RequeueAfter is not set")
 	}
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakAuthFlow)
+	require.True(t, ok)
+
+	cond := meta.FindStatusCondition(updated.Status.Conditions, keycloakApi.ConditionReconciled)
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+	require.Equal(t, keycloakApi.ReasonFailed, cond.Reason)
 }
 
 func TestReconcile_Reconcile_FailureToGetParentRealm(t *testing.T) {
@@ -188,8 +198,8 @@ func TestReconcile_Reconcile_FailureToGetParentRealm(t *testing.T) {
 
 	h.On("GetOrCreateRealmOwnerRef", &flow, &flow.ObjectMeta).Return(&realm, nil)
 	h.On("CreateKeycloakClientForRealm", &realm).Return(&kClient, nil)
-	h.On("SetFailureCount", &flow).Return(time.Second)
-	h.On("UpdateStatus", &flow).Return(nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Second)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
 	h.On("TryToDelete", &flow,
 		makeTerminator(&realm, authFlowSpecToAdapterAuthFlow(&flow.Spec), client, &kClient, log),
 		finalizerName).Return(false, nil)
@@ -216,3 +226,47 @@ func TestReconcile_Reconcile_FailureToGetParentRealm(t *testing.T) {
 		t.Fatal("RequeueAfter is not set")
 	}
 }
+
+func TestReconcile_Reconcile_InvalidExecutions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	flow := keycloakApi.KeycloakAuthFlow{
+		ObjectMeta: metav1.ObjectMeta{Name: "flow123", Namespace: "namespace1"},
+		Spec: keycloakApi.KeycloakAuthFlowSpec{
+			Alias: "flow123",
+			AuthenticationExecutions: []keycloakApi.AuthenticationExecution{
+				{Authenticator: "conditional-user-role", Requirement: "REQUIRED"},
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&flow).Build()
+	h := helper.Mock{}
+	realm := keycloakApi.KeycloakRealm{Spec: keycloakApi.KeycloakRealmSpec{RealmName: "realm11"}}
+	kClient := adapter.Mock{}
+
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+	h.On("CreateKeycloakClientForRealm", &realm).Return(&kClient, nil)
+	h.On("TryToDelete", testifyMock.Anything, testifyMock.Anything, finalizerName).Return(false, nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Second)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	r := Reconcile{
+		helper: &h,
+		log:    mock.NewLogr(),
+		client: client,
+	}
+
+	result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: flow.Namespace,
+		Name:      flow.Name,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, time.Second, result.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakAuthFlow)
+	require.True(t, ok)
+	require.Contains(t, updated.Status.Value, "invalid authentication executions")
+	kClient.AssertNotCalled(t, "SyncAuthFlow", testifyMock.Anything, testifyMock.Anything)
+}
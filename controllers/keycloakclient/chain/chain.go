@@ -3,14 +3,16 @@ package chain
 import (
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func Make(scheme *runtime.Scheme, client client.Client, logger logr.Logger) Element {
+func Make(scheme *runtime.Scheme, client client.Client, logger logr.Logger, recorder record.EventRecorder) Element {
 	baseElement := BaseElement{
-		scheme: scheme,
-		Client: client,
-		Logger: logger,
+		scheme:   scheme,
+		Client:   client,
+		Logger:   logger,
+		recorder: recorder,
 	}
 
 	return &PutClient{
@@ -21,10 +23,19 @@ func Make(scheme *runtime.Scheme, client client.Client, logger logr.Logger) Elem
 				BaseElement: baseElement,
 				next: &PutClientScope{
 					BaseElement: baseElement,
-					next: &PutProtocolMappers{
+					next: &PutClientScopeMappings{
 						BaseElement: baseElement,
-						next: &ServiceAccount{
+						next: &PutProtocolMappers{
 							BaseElement: baseElement,
+							next: &ServiceAccount{
+								BaseElement: baseElement,
+								next: &PutClientAuthorization{
+									BaseElement: baseElement,
+									next: &PutOutputSecret{
+										BaseElement: baseElement,
+									},
+								},
+							},
 						},
 					},
 				},
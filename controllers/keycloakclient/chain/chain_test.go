@@ -14,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
@@ -28,7 +29,7 @@ func TestPrivateClientSecret(t *testing.T) {
 		Spec: keycloakApi.KeycloakClientSpec{TargetRealm: "namespace.main", Secret: "keycloak-secret",
 			RealmRoles: &[]keycloakApi.RealmRole{{Name: "fake-client-administrators", Composite: "administrator"},
 				{Name: "fake-client-users", Composite: "developer"},
-			}, Public: false, ClientId: "fake-client", WebUrl: "fake-url", DirectAccess: false,
+			}, Public: gocloak.BoolP(false), ClientId: "fake-client", WebUrl: "fake-url", DirectAccess: gocloak.BoolP(false),
 			AdvancedProtocolMappers: true, ClientRoles: nil, ProtocolMappers: &[]keycloakApi.ProtocolMapper{
 				{Name: "bar", Config: map[string]string{"bar": "1"}},
 				{Name: "foo", Config: map[string]string{"foo": "2"}},
@@ -110,7 +111,7 @@ func TestMake(t *testing.T) {
 		Spec: keycloakApi.KeycloakClientSpec{TargetRealm: "namespace.main", Secret: "keycloak-secret",
 			RealmRoles: &[]keycloakApi.RealmRole{{Name: "fake-client-administrators", Composite: "administrator"},
 				{Name: "fake-client-users", Composite: "developer"},
-			}, Public: true, ClientId: "fake-client", WebUrl: "fake-url", DirectAccess: false,
+			}, Public: gocloak.BoolP(true), ClientId: "fake-client", WebUrl: "fake-url", DirectAccess: gocloak.BoolP(false),
 			AdvancedProtocolMappers: true, ClientRoles: nil, ProtocolMappers: &[]keycloakApi.ProtocolMapper{
 				{Name: "bar", Config: map[string]string{"bar": "1"}},
 				{Name: "foo", Config: map[string]string{"foo": "2"}},
@@ -124,7 +125,7 @@ func TestMake(t *testing.T) {
 	h := helper.MakeHelper(client, s, mock.NewLogr())
 
 	kClient := new(adapter.Mock)
-	chain := Make(h.GetScheme(), client, mock.NewLogr())
+	chain := Make(h.GetScheme(), client, mock.NewLogr(), record.NewFakeRecorder(10))
 
 	clientDTO := dto.ConvertSpecToClient(&kc.Spec, "")
 	kClient.On("ExistClient", clientDTO.ClientId, clientDTO.RealmName).
@@ -137,9 +138,9 @@ func TestMake(t *testing.T) {
 	kClient.On("ExistRealmRole", kr.Spec.RealmName, "fake-client-administrators").
 		Return(false, nil)
 	kClient.On("SyncClientProtocolMapper", clientDTO, []gocloak.ProtocolMapperRepresentation{
-		{Name: gocloak.StringP("bar"), Protocol: gocloak.StringP(""), Config: &map[string]string{"bar": "1"},
+		{Name: gocloak.StringP("bar"), Protocol: gocloak.StringP("openid-connect"), Config: &map[string]string{"bar": "1"},
 			ProtocolMapper: gocloak.StringP("")},
-		{Name: gocloak.StringP("foo"), Protocol: gocloak.StringP(""), Config: &map[string]string{"foo": "2"},
+		{Name: gocloak.StringP("foo"), Protocol: gocloak.StringP("openid-connect"), Config: &map[string]string{"foo": "2"},
 			ProtocolMapper: gocloak.StringP("")},
 	}, false).Return(nil)
 
@@ -169,3 +170,86 @@ func TestPutClientScope_Serve(t *testing.T) {
 	err := pcs.putClientScope(ctx, &kc, kClient)
 	assert.NoError(t, err)
 }
+
+func TestPutClientScope_Serve_SyncScopes(t *testing.T) {
+	pcs := PutClientScope{}
+	kc := keycloakApi.KeycloakClient{
+		Spec: keycloakApi.KeycloakClientSpec{
+			ClientId:             "clid1",
+			TargetRealm:          "realm1",
+			DefaultClientScopes:  []string{"profile"},
+			OptionalClientScopes: []string{"email"},
+		},
+		Status: keycloakApi.KeycloakClientStatus{
+			AppliedDefaultClientScopes: []string{"profile", "email"},
+		},
+	}
+	kClient := new(adapter.Mock)
+	profile := adapter.ClientScope{ID: "profile-id", Name: "profile"}
+	email := adapter.ClientScope{ID: "email-id", Name: "email"}
+	roles := adapter.ClientScope{ID: "roles-id", Name: "roles"}
+
+	ctx := context.Background()
+
+	// roles is not applied by the operator, so it is kept
+	kClient.On("GetClientDefaultScopes", ctx, "realm1", "clid1").
+		Return([]adapter.ClientScope{profile, email, roles}, nil)
+	kClient.On("RemoveDefaultScopeFromClient", ctx, "realm1", "clid1", []adapter.ClientScope{email}).
+		Return(nil)
+	kClient.On("GetClientScopesByNames", ctx, "realm1", []string{"profile"}).Return([]adapter.ClientScope{profile}, nil)
+	kClient.On("AddDefaultScopeToClient", ctx, "realm1", "clid1", []adapter.ClientScope{profile}).Return(nil)
+	kClient.On("GetClientScopesByNames", ctx, "realm1", []string{"email"}).Return([]adapter.ClientScope{email}, nil)
+	kClient.On("AddOptionalScopeToClient", ctx, "realm1", "clid1", []adapter.ClientScope{email}).Return(nil)
+
+	require.NoError(t, pcs.putClientScope(ctx, &kc, kClient))
+	kClient.AssertExpectations(t)
+	assert.Equal(t, []string{"profile"}, kc.Status.AppliedDefaultClientScopes)
+	assert.Equal(t, []string{"email"}, kc.Status.AppliedOptionalClientScopes)
+}
+
+func TestPutClientScope_Serve_RemovedList(t *testing.T) {
+	pcs := PutClientScope{}
+	kc := keycloakApi.KeycloakClient{
+		Spec: keycloakApi.KeycloakClientSpec{ClientId: "clid1", TargetRealm: "realm1"},
+		Status: keycloakApi.KeycloakClientStatus{
+			AppliedOptionalClientScopes: []string{"email"},
+		},
+	}
+	kClient := new(adapter.Mock)
+	email := adapter.ClientScope{ID: "email-id", Name: "email"}
+	phone := adapter.ClientScope{ID: "phone-id", Name: "phone"}
+
+	ctx := context.Background()
+
+	kClient.On("GetClientOptionalScopes", ctx, "realm1", "clid1").Return([]adapter.ClientScope{email, phone}, nil)
+	kClient.On("RemoveOptionalScopeFromClient", ctx, "realm1", "clid1", []adapter.ClientScope{email}).Return(nil)
+
+	require.NoError(t, pcs.putClientScope(ctx, &kc, kClient))
+	kClient.AssertExpectations(t)
+	assert.Nil(t, kc.Status.AppliedOptionalClientScopes)
+}
+
+func TestPutClientScope_Serve_AddOnly(t *testing.T) {
+	pcs := PutClientScope{}
+	kc := keycloakApi.KeycloakClient{
+		Spec: keycloakApi.KeycloakClientSpec{
+			ClientId:               "clid1",
+			TargetRealm:            "realm1",
+			OptionalClientScopes:   []string{"email"},
+			ReconciliationStrategy: keycloakApi.ReconciliationStrategyAddOnly,
+		},
+		Status: keycloakApi.KeycloakClientStatus{AppliedOptionalClientScopes: []string{"phone"}},
+	}
+	kClient := new(adapter.Mock)
+	email := adapter.ClientScope{ID: "email-id", Name: "email"}
+
+	ctx := context.Background()
+
+	kClient.On("GetClientScopesByNames", ctx, "realm1", []string{"email"}).Return([]adapter.ClientScope{email}, nil)
+	kClient.On("AddOptionalScopeToClient", ctx, "realm1", "clid1", []adapter.ClientScope{email}).Return(nil)
+
+	require.NoError(t, pcs.putClientScope(ctx, &kc, kClient))
+	kClient.AssertExpectations(t)
+	assert.Equal(t, []string{"phone", "email"}, kc.Status.AppliedOptionalClientScopes,
+		"the scopes applied before are kept, because they are not detached")
+}
@@ -0,0 +1,49 @@
+package chain
+
+import (
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+// setSessionAttributes merges typed token and session settings into the client attributes.
+func setSessionAttributes(keycloakClient *keycloakApi.KeycloakClient, clientDto *dto.Client) {
+	cfg := keycloakClient.Spec.Sessions
+	if cfg == nil {
+		return
+	}
+
+	attributes := make(map[string]string, len(clientDto.Attributes))
+	for k, v := range clientDto.Attributes {
+		attributes[k] = v
+	}
+
+	setDurationAttribute(attributes, "access.token.lifespan", cfg.AccessTokenLifespan)
+	setDurationAttribute(attributes, "client.session.idle.timeout", cfg.ClientSessionIdleTimeout)
+	setDurationAttribute(attributes, "client.session.max.lifespan", cfg.ClientSessionMaxLifespan)
+	setDurationAttribute(attributes, "client.offline.session.idle.timeout", cfg.ClientOfflineSessionIdleTimeout)
+	setDurationAttribute(attributes, "client.offline.session.max.lifespan", cfg.ClientOfflineSessionMaxLifespan)
+	setBoolAttribute(attributes, "use.refresh.tokens", cfg.UseRefreshTokens)
+	setBoolAttribute(attributes, "client_credentials.use_refresh_token", cfg.UseRefreshTokenForClientCredentials)
+
+	clientDto.Attributes = attributes
+}
+
+// setDurationAttribute sets the duration in seconds, zero duration sets the empty attribute to use the realm setting.
+// Keycloak merges the attributes on update, so the removed attribute would keep its current value.
+func setDurationAttribute(attributes map[string]string, key string, value *metav1.Duration) {
+	if value == nil {
+		return
+	}
+
+	seconds := int64(value.Seconds())
+	if seconds == 0 {
+		attributes[key] = ""
+		return
+	}
+
+	attributes[key] = strconv.FormatInt(seconds, 10)
+}
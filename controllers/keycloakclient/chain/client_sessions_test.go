@@ -0,0 +1,49 @@
+package chain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+func TestSetSessionAttributes(t *testing.T) {
+	useRefreshTokens := false
+	kc := keycloakApi.KeycloakClient{Spec: keycloakApi.KeycloakClientSpec{
+		Sessions: &keycloakApi.ClientSessionsConfig{
+			AccessTokenLifespan:             &metav1.Duration{Duration: 5 * time.Minute},
+			ClientSessionIdleTimeout:        &metav1.Duration{Duration: 30 * time.Minute},
+			ClientSessionMaxLifespan:        &metav1.Duration{Duration: 10 * time.Hour},
+			ClientOfflineSessionIdleTimeout: &metav1.Duration{},
+			UseRefreshTokens:                &useRefreshTokens,
+		},
+	}}
+	clientDto := dto.Client{Attributes: map[string]string{
+		"post.logout.redirect.uris":           "+",
+		"access.token.lifespan":               "60",
+		"client.offline.session.idle.timeout": "3600",
+	}}
+
+	setSessionAttributes(&kc, &clientDto)
+
+	assert.Equal(t, map[string]string{
+		"post.logout.redirect.uris":           "+",
+		"access.token.lifespan":               "300",
+		"client.session.idle.timeout":         "1800",
+		"client.session.max.lifespan":         "36000",
+		"client.offline.session.idle.timeout": "",
+		"use.refresh.tokens":                  "false",
+	}, clientDto.Attributes)
+}
+
+func TestSetSessionAttributes_NotSet(t *testing.T) {
+	clientDto := dto.Client{Attributes: map[string]string{"access.token.lifespan": "60"}}
+
+	setSessionAttributes(&keycloakApi.KeycloakClient{}, &clientDto)
+
+	assert.Equal(t, map[string]string{"access.token.lifespan": "60"}, clientDto.Attributes)
+}
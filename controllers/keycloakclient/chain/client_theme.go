@@ -0,0 +1,47 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+const loginThemeAttribute = "login_theme"
+
+// setLoginThemeAttribute sets the client login theme override after it is checked against the keycloak themes,
+// the empty theme removes the override.
+func setLoginThemeAttribute(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, clientDto *dto.Client,
+	adapterClient keycloak.Client) error {
+	if keycloakClient.Spec.LoginTheme == nil {
+		return nil
+	}
+
+	theme := *keycloakClient.Spec.LoginTheme
+
+	if theme != "" {
+		themes, err := adapterClient.GetServerThemes(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to get keycloak themes: %w", err)
+		}
+
+		if !themes.Has(adapter.ThemeTypeLogin, theme) {
+			return fmt.Errorf("login theme %s is not available in keycloak, available themes: %s", theme,
+				strings.Join(themes.Names(adapter.ThemeTypeLogin), ", "))
+		}
+	}
+
+	attributes := make(map[string]string, len(clientDto.Attributes)+1)
+	for k, v := range clientDto.Attributes {
+		attributes[k] = v
+	}
+
+	attributes[loginThemeAttribute] = theme
+	clientDto.Attributes = attributes
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+func TestSetLoginThemeAttribute(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("GetServerThemes").Return(adapter.ServerThemes{
+		adapter.ThemeTypeLogin: {"keycloak": {}, "custom": {}},
+	}, nil)
+
+	kc := keycloakApi.KeycloakClient{Spec: keycloakApi.KeycloakClientSpec{LoginTheme: gocloak.StringP("custom")}}
+	clientDto := dto.Client{Attributes: map[string]string{"login_theme": "keycloak", "custom": "value"}}
+
+	require.NoError(t, setLoginThemeAttribute(context.Background(), &kc, &clientDto, kClient))
+	assert.Equal(t, map[string]string{"login_theme": "custom", "custom": "value"}, clientDto.Attributes)
+
+	// the empty theme removes the override without the themes check.
+	kc.Spec.LoginTheme = gocloak.StringP("")
+
+	require.NoError(t, setLoginThemeAttribute(context.Background(), &kc, &clientDto, new(adapter.Mock)))
+	assert.Equal(t, map[string]string{"login_theme": "", "custom": "value"}, clientDto.Attributes)
+
+	kc.Spec.LoginTheme = gocloak.StringP("unknown")
+
+	err := setLoginThemeAttribute(context.Background(), &kc, &clientDto, kClient)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "login theme unknown is not available in keycloak, available themes: custom, keycloak")
+}
+
+func TestSetLoginThemeAttribute_NotSet(t *testing.T) {
+	clientDto := dto.Client{Attributes: map[string]string{"login_theme": "keycloak"}}
+
+	require.NoError(t, setLoginThemeAttribute(context.Background(), &keycloakApi.KeycloakClient{}, &clientDto,
+		new(adapter.Mock)))
+	assert.Equal(t, map[string]string{"login_theme": "keycloak"}, clientDto.Attributes)
+}
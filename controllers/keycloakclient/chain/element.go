@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
@@ -21,6 +22,8 @@ type BaseElement struct {
 	Client client.Client
 	Logger logr.Logger
 	scheme *runtime.Scheme
+
+	recorder record.EventRecorder
 }
 
 func (b *BaseElement) NextServeOrNil(
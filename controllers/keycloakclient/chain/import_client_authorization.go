@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+// importAuthorization imports the authorization settings from the config map into the client
+// if they changed since the last import.
+func (el *PutClientAuthorization) importAuthorization(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient,
+	adapterClient keycloak.Client) error {
+	ref := keycloakClient.Spec.AuthorizationSettingsRef
+
+	var cm coreV1.ConfigMap
+	if err := el.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: keycloakClient.Namespace}, &cm); err != nil {
+		return fmt.Errorf("unable to get authorization settings config map %s: %w", ref.Name, err)
+	}
+
+	settings, ok := cm.Data[ref.Key]
+	if !ok {
+		return fmt.Errorf("config map %s does not contain key %s", ref.Name, ref.Key)
+	}
+
+	if !json.Valid([]byte(settings)) {
+		return fmt.Errorf("config map %s key %s does not contain valid authorization settings JSON", ref.Name, ref.Key)
+	}
+
+	realm, clientID := keycloakClient.Spec.TargetRealm, keycloakClient.Status.ClientID
+
+	hash := authzSettingsHash(clientID, []byte(settings))
+	if keycloakClient.Status.AuthorizationSettingsHash == hash {
+		el.Logger.Info("Authorization settings are up to date")
+
+		return nil
+	}
+
+	el.Logger.Info("Importing authorization settings", "configMap", ref.Name)
+
+	if err := adapterClient.ImportAuthzSettings(ctx, realm, clientID, []byte(settings)); err != nil {
+		return err
+	}
+
+	keycloakClient.Status.AuthorizationSettingsHash = hash
+
+	return nil
+}
+
+// authzSettingsHash is a hash of the authorization settings and the id of the client they are imported into,
+// so the settings are imported again into the recreated client.
+func authzSettingsHash(clientID string, settings []byte) string {
+	h := sha256.New()
+	h.Write([]byte(clientID + "\n"))
+	h.Write(settings)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,85 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+const testAuthzSettings = `{
+  "allowRemoteResourceManagement": true,
+  "policyEnforcementMode": "ENFORCING",
+  "resources": [{"name": "docs", "uris": ["/docs/*"]}],
+  "policies": [{"name": "admins", "type": "role", "config": {"roles": "[{\"id\":\"admin\"}]"}}],
+  "decisionStrategy": "UNANIMOUS"
+}`
+
+func TestPutClientAuthorization_importAuthorization(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(coreV1.AddToScheme(sch))
+
+	cm := coreV1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "authz", Namespace: "ns"},
+		Data:       map[string]string{"settings.json": testAuthzSettings},
+	}
+
+	el := PutClientAuthorization{BaseElement: BaseElement{
+		Logger: mock.NewLogr(),
+		Client: fake.NewClientBuilder().WithScheme(sch).WithObjects(&cm).Build(),
+	}}
+
+	kc := keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			ClientId:                 "client",
+			TargetRealm:              "realm",
+			AuthorizationSettingsRef: &keycloakApi.ConfigMapKeyRef{Name: "authz", Key: "settings.json"},
+		},
+		Status: keycloakApi.KeycloakClientStatus{ClientID: "client-uuid"},
+	}
+
+	kClient := new(adapter.Mock)
+	kClient.On("ImportAuthzSettings", "realm", "client-uuid", []byte(testAuthzSettings)).Return(nil).Once()
+
+	require.NoError(t, el.Serve(context.Background(), &kc, kClient))
+	assert.Equal(t, authzSettingsHash("client-uuid", []byte(testAuthzSettings)), kc.Status.AuthorizationSettingsHash)
+
+	// the unchanged settings are not imported again
+	require.NoError(t, el.Serve(context.Background(), &kc, kClient))
+	kClient.AssertExpectations(t)
+
+	// the settings are imported again into the recreated client
+	kc.Status.ClientID = "new-client-uuid"
+	kClient.On("ImportAuthzSettings", "realm", "new-client-uuid", []byte(testAuthzSettings)).Return(nil).Once()
+
+	require.NoError(t, el.Serve(context.Background(), &kc, kClient))
+	kClient.AssertExpectations(t)
+
+	cm.Data["settings.json"] = "not json"
+	require.NoError(t, el.Client.Update(context.Background(), &cm))
+
+	err := el.Serve(context.Background(), &kc, kClient)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not contain valid authorization settings JSON")
+
+	kc.Spec.AuthorizationSettingsRef.Key = "missing"
+	err = el.Serve(context.Background(), &kc, kClient)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config map authz does not contain key missing")
+
+	kc.Spec.Authorization = &keycloakApi.ClientAuthorization{}
+	err = el.Serve(context.Background(), &kc, kClient)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can not be used together")
+}
@@ -0,0 +1,115 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+// oidcFeature is an advanced openid-connect client option which is available since the given keycloak version.
+type oidcFeature struct {
+	name         string
+	attribute    string
+	value        func(cfg *keycloakApi.OIDCClientConfig) *bool
+	minKCVersion int
+}
+
+var oidcFeatures = []oidcFeature{
+	{
+		name:      "deviceAuthorizationGrantEnabled",
+		attribute: "oauth2.device.authorization.grant.enabled",
+		value: func(cfg *keycloakApi.OIDCClientConfig) *bool {
+			return cfg.DeviceAuthorizationGrantEnabled
+		},
+		minKCVersion: 13,
+	},
+	{
+		name:      "cibaGrantEnabled",
+		attribute: "oidc.ciba.grant.enabled",
+		value: func(cfg *keycloakApi.OIDCClientConfig) *bool {
+			return cfg.CIBAGrantEnabled
+		},
+		minKCVersion: 13,
+	},
+	{
+		name:      "dpopBoundAccessTokens",
+		attribute: "dpop.bound.access.tokens",
+		value: func(cfg *keycloakApi.OIDCClientConfig) *bool {
+			return cfg.DPoPBoundAccessTokens
+		},
+		minKCVersion: 23,
+	},
+}
+
+// setOIDCAttributes merges typed openid-connect options into the client attributes.
+// Options which are enabled are checked against the version of the connected keycloak.
+func setOIDCAttributes(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, clientDto *dto.Client,
+	adapterClient keycloak.Client) error {
+	cfg := keycloakClient.Spec.OIDC
+	if cfg == nil {
+		return nil
+	}
+
+	if keycloakClient.IsSAML() {
+		return fmt.Errorf("oidc config is not allowed for the %s protocol", dto.SAMLClientProtocol)
+	}
+
+	if err := checkOIDCFeatures(ctx, cfg, adapterClient); err != nil {
+		return err
+	}
+
+	attributes := make(map[string]string, len(clientDto.Attributes))
+	for k, v := range clientDto.Attributes {
+		attributes[k] = v
+	}
+
+	setStringAttribute(attributes, "pkce.code.challenge.method", cfg.PKCECodeChallengeMethod)
+
+	for _, f := range oidcFeatures {
+		setBoolAttribute(attributes, f.attribute, f.value(cfg))
+	}
+
+	clientDto.Attributes = attributes
+
+	return nil
+}
+
+func checkOIDCFeatures(ctx context.Context, cfg *keycloakApi.OIDCClientConfig, adapterClient keycloak.Client) error {
+	var (
+		major int
+		err   error
+	)
+
+	for _, f := range oidcFeatures {
+		v := f.value(cfg)
+		if v == nil || !*v {
+			continue
+		}
+
+		if major == 0 {
+			if major, err = serverMajorVersion(ctx, adapterClient); err != nil {
+				return err
+			}
+		}
+
+		if major < f.minKCVersion {
+			return fmt.Errorf("%s requires keycloak %d or newer, connected keycloak version is %d",
+				f.name, f.minKCVersion, major)
+		}
+	}
+
+	return nil
+}
+
+func serverMajorVersion(ctx context.Context, adapterClient keycloak.Client) (int, error) {
+	version, err := adapterClient.GetServerVersion(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get keycloak version: %w", err)
+	}
+
+	return adapter.ServerMajorVersion(version)
+}
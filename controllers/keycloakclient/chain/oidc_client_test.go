@@ -0,0 +1,81 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+func TestSetOIDCAttributes(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	tests := []struct {
+		name       string
+		cfg        *keycloakApi.OIDCClientConfig
+		version    string
+		wantAttrs  map[string]string
+		wantErrMsg string
+	}{
+		{
+			name: "pkce and device flow",
+			cfg: &keycloakApi.OIDCClientConfig{
+				PKCECodeChallengeMethod:         "S256",
+				DeviceAuthorizationGrantEnabled: &enabled,
+				DPoPBoundAccessTokens:           &disabled,
+			},
+			version: "21.1.2",
+			wantAttrs: map[string]string{
+				"custom":                     "value",
+				"pkce.code.challenge.method": "S256",
+				"oauth2.device.authorization.grant.enabled": "true",
+				"dpop.bound.access.tokens":                  "false",
+			},
+		},
+		{
+			name: "pkce does not require version check",
+			cfg:  &keycloakApi.OIDCClientConfig{PKCECodeChallengeMethod: "plain"},
+			wantAttrs: map[string]string{
+				"custom":                     "value",
+				"pkce.code.challenge.method": "plain",
+			},
+		},
+		{
+			name:       "dpop is not supported",
+			cfg:        &keycloakApi.OIDCClientConfig{DPoPBoundAccessTokens: &enabled},
+			version:    "21.1.2",
+			wantErrMsg: "dpopBoundAccessTokens requires keycloak 23 or newer, connected keycloak version is 21",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			kClient := new(adapter.Mock)
+			if tt.version != "" {
+				kClient.On("GetServerVersion").Return(tt.version, nil)
+			}
+
+			kc := keycloakApi.KeycloakClient{Spec: keycloakApi.KeycloakClientSpec{OIDC: tt.cfg}}
+			clientDto := dto.Client{Attributes: map[string]string{"custom": "value"}}
+
+			err := setOIDCAttributes(context.Background(), &kc, &clientDto, kClient)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrMsg)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAttrs, clientDto.Attributes)
+			kClient.AssertExpectations(t)
+		})
+	}
+}
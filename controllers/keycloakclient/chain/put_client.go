@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/sethvargo/go-password/password"
 	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -12,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
@@ -50,11 +52,34 @@ func (el *PutClient) putKeycloakClient(ctx context.Context, keycloakClient *keyc
 		return "", fmt.Errorf("error during convertCrToDto: %w", err)
 	}
 
+	if err = setOIDCAttributes(ctx, keycloakClient, clientDto, adapterClient); err != nil {
+		return "", fmt.Errorf("unable to set oidc options: %w", err)
+	}
+
+	setSessionAttributes(keycloakClient, clientDto)
+
+	if err = setLoginThemeAttribute(ctx, keycloakClient, clientDto, adapterClient); err != nil {
+		return "", fmt.Errorf("unable to set login theme: %w", err)
+	}
+
+	if err = el.setRedirectURIs(ctx, keycloakClient, clientDto); err != nil {
+		return "", fmt.Errorf("unable to set redirect uris: %w", err)
+	}
+
 	clientID, err := adapterClient.GetClientID(clientDto.ClientId, clientDto.RealmName)
 	if err != nil && !adapter.IsErrNotFound(err) {
 		return "", fmt.Errorf("unable to check client id: %w", err)
 	}
 
+	if clientID != "" && keycloakClient.Status.ClientID == "" {
+		if err = helper.CheckAdoption(keycloakClient, keycloakApi.AdoptionPolicyAdopt, "client",
+			clientDto.ClientId); err != nil {
+			return "", err
+		}
+
+		reqLog.Info("Adopting existing client")
+	}
+
 	if clientID != "" {
 		reqLog.Info("Client already exists")
 
@@ -82,18 +107,39 @@ func (el *PutClient) putKeycloakClient(ctx context.Context, keycloakClient *keyc
 }
 
 func (el *PutClient) convertCrToDto(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient) (*dto.Client, error) {
-	if keycloakClient.Spec.Public {
+	if keycloakClient.Spec.SAML != nil && !keycloakClient.IsSAML() {
+		return nil, fmt.Errorf("saml config is allowed only for the %s protocol", dto.SAMLClientProtocol)
+	}
+
+	if keycloakClient.IsSAML() {
+		return el.convertSAMLCrToDto(ctx, keycloakClient)
+	}
+
+	if keycloakClient.Spec.SignedJWT != nil && !keycloakClient.IsSignedJWT() {
+		return nil, fmt.Errorf("signedJwt config is allowed only for the %s client authenticator",
+			dto.SignedJWTClientAuthenticator)
+	}
+
+	if keycloakClient.IsPublic() {
+		if keycloakClient.IsSignedJWT() {
+			return nil, errors.New("signed JWT authentication can not be used with public client")
+		}
+
 		res := dto.ConvertSpecToClient(&keycloakClient.Spec, "")
 		return res, nil
 	}
 
+	if keycloakClient.IsSignedJWT() {
+		return el.convertSignedJWTCrToDto(ctx, keycloakClient)
+	}
+
 	if keycloakClient.Spec.Secret != "" {
 		secret, err := el.getSecret(ctx, keycloakClient)
 		if err != nil {
 			return nil, fmt.Errorf("unable to get secret, err: %w", err)
 		}
 
-		return dto.ConvertSpecToClient(&keycloakClient.Spec, secret), nil
+		return el.applySecretRotation(ctx, keycloakClient, dto.ConvertSpecToClient(&keycloakClient.Spec, secret))
 	}
 
 	secret, err := el.generateSecret(ctx, keycloakClient)
@@ -101,7 +147,7 @@ func (el *PutClient) convertCrToDto(ctx context.Context, keycloakClient *keycloa
 		return nil, fmt.Errorf("unable to generate secret: %w", err)
 	}
 
-	return dto.ConvertSpecToClient(&keycloakClient.Spec, secret), nil
+	return el.applySecretRotation(ctx, keycloakClient, dto.ConvertSpecToClient(&keycloakClient.Spec, secret))
 }
 
 func (el *PutClient) getSecret(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient) (string, error) {
@@ -0,0 +1,300 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type PutClientAuthorization struct {
+	BaseElement
+	next Element
+}
+
+func (el *PutClientAuthorization) Serve(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, adapterClient keycloak.Client) error {
+	if err := el.putAuthorization(ctx, keycloakClient, adapterClient); err != nil {
+		return errors.Wrap(err, "unable to put client authorization")
+	}
+
+	return el.NextServeOrNil(ctx, el.next, keycloakClient, adapterClient)
+}
+
+func (el *PutClientAuthorization) putAuthorization(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient,
+	adapterClient keycloak.Client) error {
+	authz := keycloakClient.Spec.Authorization
+	settingsRef := keycloakClient.Spec.AuthorizationSettingsRef
+
+	if settingsRef == nil {
+		keycloakClient.Status.AuthorizationSettingsHash = ""
+	}
+
+	if authz == nil && settingsRef == nil {
+		return nil
+	}
+
+	if keycloakClient.IsPublic() {
+		return errors.New("authorization services can be enabled only for confidential clients")
+	}
+
+	if settingsRef != nil {
+		if authz != nil {
+			return errors.New("authorization and authorizationSettingsRef can not be used together")
+		}
+
+		return el.importAuthorization(ctx, keycloakClient, adapterClient)
+	}
+
+	el.Logger.Info("Start put client authorization")
+
+	s := authzSync{
+		ctx:      ctx,
+		kClient:  adapterClient,
+		realm:    keycloakClient.Spec.TargetRealm,
+		clientID: keycloakClient.Status.ClientID,
+		addOnly:  keycloakClient.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly,
+	}
+
+	if err := adapterClient.UpdateResourceServerSettings(ctx, s.realm, s.clientID, &adapter.ResourceServerSettings{
+		PolicyEnforcementMode:         authz.PolicyEnforcementMode,
+		DecisionStrategy:              authz.DecisionStrategy,
+		AllowRemoteResourceManagement: authz.AllowRemoteResourceManagement,
+	}); err != nil {
+		return err
+	}
+
+	// Scopes and resources must be synced before the policies and the permissions which refer to them.
+	if err := s.syncScopes(authz.Scopes); err != nil {
+		return err
+	}
+
+	if err := s.syncResources(authz.Resources); err != nil {
+		return err
+	}
+
+	if err := s.syncPolicies(authz.Policies); err != nil {
+		return err
+	}
+
+	if err := s.syncPermissions(authz.Permissions); err != nil {
+		return err
+	}
+
+	el.Logger.Info("End put client authorization")
+
+	return nil
+}
+
+// authzSync syncs authorization entities of the client: entities are updated by name,
+// missing entities are created and entities that are not declared are deleted unless addOnly is set.
+type authzSync struct {
+	ctx      context.Context
+	kClient  keycloak.Client
+	realm    string
+	clientID string
+	addOnly  bool
+}
+
+func (s *authzSync) syncScopes(names []string) error {
+	current, err := s.kClient.GetAuthzScopes(s.ctx, s.realm, s.clientID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]string, len(current))
+	for i := range current {
+		existing[current[i].Name] = current[i].ID
+	}
+
+	for _, name := range names {
+		scope := adapter.AuthzScope{Name: name}
+
+		if id, ok := existing[name]; ok {
+			delete(existing, name)
+
+			scope.ID = id
+			if err := s.kClient.UpdateAuthzScope(s.ctx, s.realm, s.clientID, &scope); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := s.kClient.CreateAuthzScope(s.ctx, s.realm, s.clientID, &scope); err != nil {
+			return err
+		}
+	}
+
+	return s.prune(existing, s.kClient.DeleteAuthzScope)
+}
+
+func (s *authzSync) syncResources(resources []keycloakApi.AuthorizationResource) error {
+	current, err := s.kClient.GetAuthzResources(s.ctx, s.realm, s.clientID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]string, len(current))
+	for i := range current {
+		existing[current[i].Name] = current[i].ID
+	}
+
+	for i := range resources {
+		resource := convertAuthzResource(&resources[i])
+
+		if id, ok := existing[resource.Name]; ok {
+			delete(existing, resource.Name)
+
+			resource.ID = id
+			if err := s.kClient.UpdateAuthzResource(s.ctx, s.realm, s.clientID, resource); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := s.kClient.CreateAuthzResource(s.ctx, s.realm, s.clientID, resource); err != nil {
+			return err
+		}
+	}
+
+	return s.prune(existing, s.kClient.DeleteAuthzResource)
+}
+
+func (s *authzSync) syncPolicies(policies []keycloakApi.AuthorizationPolicy) error {
+	current, err := s.kClient.GetAuthzPolicies(s.ctx, s.realm, s.clientID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]string, len(current))
+	for i := range current {
+		existing[current[i].Name] = current[i].ID
+	}
+
+	for i := range policies {
+		policy := convertAuthzPolicy(&policies[i])
+
+		if id, ok := existing[policy.Name]; ok {
+			delete(existing, policy.Name)
+
+			policy.ID = id
+			if err := s.kClient.UpdateAuthzPolicy(s.ctx, s.realm, s.clientID, policy); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := s.kClient.CreateAuthzPolicy(s.ctx, s.realm, s.clientID, policy); err != nil {
+			return err
+		}
+	}
+
+	return s.prune(existing, s.kClient.DeleteAuthzPolicy)
+}
+
+func (s *authzSync) syncPermissions(permissions []keycloakApi.AuthorizationPermission) error {
+	current, err := s.kClient.GetAuthzPermissions(s.ctx, s.realm, s.clientID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]string, len(current))
+	for i := range current {
+		existing[current[i].Name] = current[i].ID
+	}
+
+	for i := range permissions {
+		permission := convertAuthzPermission(&permissions[i])
+
+		if id, ok := existing[permission.Name]; ok {
+			delete(existing, permission.Name)
+
+			permission.ID = id
+			if err := s.kClient.UpdateAuthzPermission(s.ctx, s.realm, s.clientID, permission); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := s.kClient.CreateAuthzPermission(s.ctx, s.realm, s.clientID, permission); err != nil {
+			return err
+		}
+	}
+
+	return s.prune(existing, s.kClient.DeleteAuthzPermission)
+}
+
+func (s *authzSync) prune(undeclared map[string]string,
+	deleteFunc func(ctx context.Context, realmName, clientID, entityID string) error) error {
+	if s.addOnly {
+		return nil
+	}
+
+	for name, id := range undeclared {
+		if err := deleteFunc(s.ctx, s.realm, s.clientID, id); err != nil {
+			return fmt.Errorf("unable to delete undeclared entity %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func convertAuthzResource(spec *keycloakApi.AuthorizationResource) *adapter.AuthzResource {
+	scopes := make([]adapter.AuthzScope, 0, len(spec.Scopes))
+	for _, name := range spec.Scopes {
+		scopes = append(scopes, adapter.AuthzScope{Name: name})
+	}
+
+	return &adapter.AuthzResource{
+		Name:               spec.Name,
+		DisplayName:        spec.DisplayName,
+		Type:               spec.Type,
+		URIs:               spec.URIs,
+		Scopes:             scopes,
+		OwnerManagedAccess: spec.OwnerManagedAccess,
+		Attributes:         spec.Attributes,
+	}
+}
+
+func convertAuthzPolicy(spec *keycloakApi.AuthorizationPolicy) *adapter.AuthzPolicy {
+	policy := &adapter.AuthzPolicy{
+		Name:             spec.Name,
+		Type:             spec.Type,
+		Description:      spec.Description,
+		Logic:            spec.Logic,
+		DecisionStrategy: spec.DecisionStrategy,
+		Users:            spec.Users,
+		Clients:          spec.Clients,
+		Policies:         spec.Policies,
+	}
+
+	for _, r := range spec.Roles {
+		policy.Roles = append(policy.Roles, adapter.AuthzPolicyRole{ID: r})
+	}
+
+	for _, g := range spec.Groups {
+		policy.Groups = append(policy.Groups, adapter.AuthzPolicyGroup{Path: g})
+	}
+
+	return policy
+}
+
+func convertAuthzPermission(spec *keycloakApi.AuthorizationPermission) *adapter.AuthzPolicy {
+	return &adapter.AuthzPolicy{
+		Name:             spec.Name,
+		Type:             spec.Type,
+		Description:      spec.Description,
+		DecisionStrategy: spec.DecisionStrategy,
+		Resources:        spec.Resources,
+		ResourceType:     spec.ResourceType,
+		Scopes:           spec.Scopes,
+		Policies:         spec.Policies,
+	}
+}
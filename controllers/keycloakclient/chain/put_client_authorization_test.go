@@ -0,0 +1,139 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func testAuthzClient() *keycloakApi.KeycloakClient {
+	return &keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "client", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			ClientId:    "client",
+			TargetRealm: "realm",
+			Authorization: &keycloakApi.ClientAuthorization{
+				PolicyEnforcementMode: "ENFORCING",
+				DecisionStrategy:      "UNANIMOUS",
+				Scopes:                []string{"view", "edit"},
+				Resources: []keycloakApi.AuthorizationResource{
+					{Name: "docs", URIs: []string{"/docs/*"}, Scopes: []string{"view"}},
+				},
+				Policies: []keycloakApi.AuthorizationPolicy{
+					{Name: "admins", Type: "role", Roles: []string{"admin", "client/manager"}},
+				},
+				Permissions: []keycloakApi.AuthorizationPermission{
+					{Name: "docs-permission", Type: "resource", Resources: []string{"docs"}, Policies: []string{"admins"}},
+				},
+			},
+		},
+		Status: keycloakApi.KeycloakClientStatus{ClientID: "client-uuid"},
+	}
+}
+
+func TestPutClientAuthorization_Serve(t *testing.T) {
+	kc := testAuthzClient()
+	kClient := new(adapter.Mock)
+
+	kClient.On("UpdateResourceServerSettings", "realm", "client-uuid", &adapter.ResourceServerSettings{
+		PolicyEnforcementMode: "ENFORCING",
+		DecisionStrategy:      "UNANIMOUS",
+	}).Return(nil)
+
+	kClient.On("GetAuthzScopes", "realm", "client-uuid").
+		Return([]adapter.AuthzScope{{ID: "view-id", Name: "view"}, {ID: "old-id", Name: "old"}}, nil)
+	kClient.On("UpdateAuthzScope", "realm", "client-uuid", &adapter.AuthzScope{ID: "view-id", Name: "view"}).Return(nil)
+	kClient.On("CreateAuthzScope", "realm", "client-uuid", &adapter.AuthzScope{Name: "edit"}).Return(nil)
+	kClient.On("DeleteAuthzScope", "realm", "client-uuid", "old-id").Return(nil)
+
+	kClient.On("GetAuthzResources", "realm", "client-uuid").
+		Return([]adapter.AuthzResource{{ID: "default-id", Name: "Default Resource"}}, nil)
+	kClient.On("CreateAuthzResource", "realm", "client-uuid", &adapter.AuthzResource{
+		Name:   "docs",
+		URIs:   []string{"/docs/*"},
+		Scopes: []adapter.AuthzScope{{Name: "view"}},
+	}).Return(nil)
+	kClient.On("DeleteAuthzResource", "realm", "client-uuid", "default-id").Return(nil)
+
+	kClient.On("GetAuthzPolicies", "realm", "client-uuid").
+		Return([]adapter.AuthzPolicy{{ID: "admins-id", Name: "admins", Type: "role"}}, nil)
+	kClient.On("UpdateAuthzPolicy", "realm", "client-uuid", &adapter.AuthzPolicy{
+		ID:    "admins-id",
+		Name:  "admins",
+		Type:  "role",
+		Roles: []adapter.AuthzPolicyRole{{ID: "admin"}, {ID: "client/manager"}},
+	}).Return(nil)
+
+	kClient.On("GetAuthzPermissions", "realm", "client-uuid").Return([]adapter.AuthzPolicy{}, nil)
+	kClient.On("CreateAuthzPermission", "realm", "client-uuid", &adapter.AuthzPolicy{
+		Name:      "docs-permission",
+		Type:      "resource",
+		Resources: []string{"docs"},
+		Policies:  []string{"admins"},
+	}).Return(nil)
+
+	el := PutClientAuthorization{BaseElement: BaseElement{Logger: mock.NewLogr()}}
+
+	require.NoError(t, el.Serve(context.Background(), kc, kClient))
+	kClient.AssertExpectations(t)
+}
+
+func TestPutClientAuthorization_Serve_AddOnly(t *testing.T) {
+	kc := testAuthzClient()
+	kc.Spec.ReconciliationStrategy = keycloakApi.ReconciliationStrategyAddOnly
+	kc.Spec.Authorization.Resources = nil
+	kc.Spec.Authorization.Policies = nil
+	kc.Spec.Authorization.Permissions = nil
+	kc.Spec.Authorization.Scopes = nil
+
+	kClient := new(adapter.Mock)
+	kClient.On("UpdateResourceServerSettings", "realm", "client-uuid", &adapter.ResourceServerSettings{
+		PolicyEnforcementMode: "ENFORCING",
+		DecisionStrategy:      "UNANIMOUS",
+	}).Return(nil)
+	kClient.On("GetAuthzScopes", "realm", "client-uuid").
+		Return([]adapter.AuthzScope{{ID: "old-id", Name: "old"}}, nil)
+	kClient.On("GetAuthzResources", "realm", "client-uuid").Return([]adapter.AuthzResource{}, nil)
+	kClient.On("GetAuthzPolicies", "realm", "client-uuid").Return([]adapter.AuthzPolicy{}, nil)
+	kClient.On("GetAuthzPermissions", "realm", "client-uuid").Return([]adapter.AuthzPolicy{}, nil)
+
+	el := PutClientAuthorization{BaseElement: BaseElement{Logger: mock.NewLogr()}}
+
+	require.NoError(t, el.Serve(context.Background(), kc, kClient))
+	kClient.AssertNotCalled(t, "DeleteAuthzScope", "realm", "client-uuid", "old-id")
+}
+
+func TestPutClientAuthorization_Serve_Errors(t *testing.T) {
+	el := PutClientAuthorization{BaseElement: BaseElement{Logger: mock.NewLogr()}}
+
+	kc := testAuthzClient()
+	kc.Spec.Public = gocloak.BoolP(true)
+
+	err := el.Serve(context.Background(), kc, new(adapter.Mock))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only for confidential clients")
+
+	kc.Spec.Public = gocloak.BoolP(false)
+	kClient := new(adapter.Mock)
+	kClient.On("UpdateResourceServerSettings", "realm", "client-uuid", &adapter.ResourceServerSettings{
+		PolicyEnforcementMode: "ENFORCING",
+		DecisionStrategy:      "UNANIMOUS",
+	}).Return(nil)
+	kClient.On("GetAuthzScopes", "realm", "client-uuid").Return(nil, errors.New("scopes fatal"))
+
+	err = el.Serve(context.Background(), kc, kClient)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "scopes fatal")
+
+	kc.Spec.Authorization = nil
+	require.NoError(t, el.Serve(context.Background(), kc, new(adapter.Mock)))
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/pkg/errors"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
 type PutClientScope struct {
@@ -25,19 +27,127 @@ func (el *PutClientScope) Serve(ctx context.Context, keycloakClient *keycloakApi
 
 func (el *PutClientScope) putClientScope(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, adapterClient keycloak.Client) error {
 	kCloakSpec := keycloakClient.Spec
-	if len(kCloakSpec.DefaultClientScopes) == 0 {
-		return nil
+	addOnly := keycloakClient.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly
+
+	// The scopes applied by the operator and removed from the spec are detached before attaching the declared ones,
+	// so a scope can be moved between the default and the optional lists.
+	if !addOnly {
+		if err := el.detachRemovedScopes(ctx, keycloakClient, adapterClient); err != nil {
+			return err
+		}
 	}
 
-	scopes, err := adapterClient.GetClientScopesByNames(ctx, kCloakSpec.TargetRealm, kCloakSpec.DefaultClientScopes)
-	if err != nil {
-		return errors.Wrap(err, "error during GetClientScope")
+	if len(kCloakSpec.DefaultClientScopes) > 0 {
+		scopes, err := adapterClient.GetClientScopesByNames(ctx, kCloakSpec.TargetRealm, kCloakSpec.DefaultClientScopes)
+		if err != nil {
+			return errors.Wrap(err, "error during GetClientScope")
+		}
+
+		err = adapterClient.AddDefaultScopeToClient(ctx, kCloakSpec.TargetRealm, kCloakSpec.ClientId, scopes)
+		if err != nil {
+			return fmt.Errorf("failed to add default scope to client %s: %w", keycloakClient.Name, err)
+		}
 	}
 
-	err = adapterClient.AddDefaultScopeToClient(ctx, kCloakSpec.TargetRealm, kCloakSpec.ClientId, scopes)
-	if err != nil {
-		return fmt.Errorf("failed to add default scope to client %s: %w", keycloakClient.Name, err)
+	if len(kCloakSpec.OptionalClientScopes) > 0 {
+		scopes, err := adapterClient.GetClientScopesByNames(ctx, kCloakSpec.TargetRealm, kCloakSpec.OptionalClientScopes)
+		if err != nil {
+			return errors.Wrap(err, "error during GetClientScope")
+		}
+
+		err = adapterClient.AddOptionalScopeToClient(ctx, kCloakSpec.TargetRealm, kCloakSpec.ClientId, scopes)
+		if err != nil {
+			return fmt.Errorf("failed to add optional scope to client %s: %w", keycloakClient.Name, err)
+		}
 	}
 
+	keycloakClient.Status.AppliedDefaultClientScopes = appliedScopes(
+		keycloakClient.Status.AppliedDefaultClientScopes, kCloakSpec.DefaultClientScopes, addOnly)
+	keycloakClient.Status.AppliedOptionalClientScopes = appliedScopes(
+		keycloakClient.Status.AppliedOptionalClientScopes, kCloakSpec.OptionalClientScopes, addOnly)
+
 	return nil
 }
+
+// detachRemovedScopes detaches the scopes applied by the operator which are no longer declared in the spec.
+// The scopes attached in keycloak by other means, e.g. the built-in default scopes, are not touched.
+func (el *PutClientScope) detachRemovedScopes(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient,
+	adapterClient keycloak.Client) error {
+	kCloakSpec := keycloakClient.Spec
+	status := keycloakClient.Status
+
+	if removed := removedScopes(status.AppliedDefaultClientScopes, kCloakSpec.DefaultClientScopes); len(removed) > 0 {
+		current, err := adapterClient.GetClientDefaultScopes(ctx, kCloakSpec.TargetRealm, kCloakSpec.ClientId)
+		if err != nil {
+			return fmt.Errorf("unable to get default scopes of client %s: %w", keycloakClient.Name, err)
+		}
+
+		if err := adapterClient.RemoveDefaultScopeFromClient(ctx, kCloakSpec.TargetRealm, kCloakSpec.ClientId,
+			filterScopes(current, removed)); err != nil {
+			return fmt.Errorf("unable to remove default scopes from client %s: %w", keycloakClient.Name, err)
+		}
+	}
+
+	if removed := removedScopes(status.AppliedOptionalClientScopes, kCloakSpec.OptionalClientScopes); len(removed) > 0 {
+		current, err := adapterClient.GetClientOptionalScopes(ctx, kCloakSpec.TargetRealm, kCloakSpec.ClientId)
+		if err != nil {
+			return fmt.Errorf("unable to get optional scopes of client %s: %w", keycloakClient.Name, err)
+		}
+
+		if err := adapterClient.RemoveOptionalScopeFromClient(ctx, kCloakSpec.TargetRealm, kCloakSpec.ClientId,
+			filterScopes(current, removed)); err != nil {
+			return fmt.Errorf("unable to remove optional scopes from client %s: %w", keycloakClient.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// removedScopes returns the names of the applied scopes which are not declared.
+func removedScopes(applied, declared []string) map[string]struct{} {
+	removed := make(map[string]struct{}, len(applied))
+	for _, name := range applied {
+		removed[name] = struct{}{}
+	}
+
+	for _, name := range declared {
+		delete(removed, name)
+	}
+
+	return removed
+}
+
+// filterScopes returns the current scopes with the names.
+func filterScopes(current []adapter.ClientScope, names map[string]struct{}) []adapter.ClientScope {
+	filtered := make([]adapter.ClientScope, 0, len(names))
+
+	for _, s := range current {
+		if _, ok := names[s.Name]; ok {
+			filtered = append(filtered, s)
+		}
+	}
+
+	return filtered
+}
+
+// appliedScopes returns the scopes applied by the operator after the reconciliation,
+// the previously applied scopes are kept for the addOnly strategy, because they are not detached.
+func appliedScopes(applied, declared []string, addOnly bool) []string {
+	result := make([]string, 0, len(applied)+len(declared))
+
+	if addOnly {
+		result = append(result, applied...)
+	}
+
+	for _, name := range declared {
+		if !helper.ContainsString(result, name) {
+			result = append(result, name)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
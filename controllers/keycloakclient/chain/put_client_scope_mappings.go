@@ -0,0 +1,49 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+type PutClientScopeMappings struct {
+	BaseElement
+	next Element
+}
+
+func (el *PutClientScopeMappings) Serve(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, adapterClient keycloak.Client) error {
+	if err := el.putScopeMappings(ctx, keycloakClient, adapterClient); err != nil {
+		return errors.Wrap(err, "unable to put client scope mappings")
+	}
+
+	return el.NextServeOrNil(ctx, el.next, keycloakClient, adapterClient)
+}
+
+func (el *PutClientScopeMappings) putScopeMappings(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient,
+	adapterClient keycloak.Client) error {
+	mappings := keycloakClient.Spec.ScopeMappings
+	if mappings == nil {
+		return nil
+	}
+
+	// the client roles are not managed if the list is not set
+	var clientRoles map[string][]string
+	if mappings.ClientRoles != nil {
+		clientRoles = make(map[string][]string)
+	}
+
+	for _, v := range mappings.ClientRoles {
+		clientRoles[v.ClientID] = v.Roles
+	}
+
+	if err := adapterClient.SyncClientScopeMappings(ctx, keycloakClient.Spec.TargetRealm, keycloakClient.Status.ClientID,
+		mappings.RealmRoles, clientRoles,
+		keycloakClient.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly); err != nil {
+		return errors.Wrap(err, "unable to sync client scope mappings")
+	}
+
+	return nil
+}
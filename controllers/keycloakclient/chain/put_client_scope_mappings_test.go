@@ -0,0 +1,51 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutClientScopeMappings_Serve(t *testing.T) {
+	el := PutClientScopeMappings{}
+
+	kc := keycloakApi.KeycloakClient{
+		Spec: keycloakApi.KeycloakClientSpec{
+			TargetRealm:            "realm1",
+			ReconciliationStrategy: keycloakApi.ReconciliationStrategyAddOnly,
+			ScopeMappings: &keycloakApi.ScopeMappings{
+				RealmRoles: []string{"developer"},
+				ClientRoles: []keycloakApi.ClientRole{
+					{
+						ClientID: "clid2",
+						Roles:    []string{"foo", "bar"},
+					},
+				},
+			},
+		},
+		Status: keycloakApi.KeycloakClientStatus{
+			ClientID: "clid1",
+		},
+	}
+	kClient := new(adapter.Mock)
+
+	kClient.On("SyncClientScopeMappings", "realm1", "clid1", []string{"developer"},
+		map[string][]string{"clid2": {"foo", "bar"}}, true).Return(nil)
+
+	err := el.Serve(context.Background(), &kc, kClient)
+	require.NoError(t, err)
+	kClient.AssertExpectations(t)
+}
+
+func TestPutClientScopeMappings_Serve_NotSet(t *testing.T) {
+	el := PutClientScopeMappings{}
+	kClient := new(adapter.Mock)
+
+	err := el.Serve(context.Background(), &keycloakApi.KeycloakClient{}, kClient)
+	require.NoError(t, err)
+	kClient.AssertNotCalled(t, "SyncClientScopeMappings")
+}
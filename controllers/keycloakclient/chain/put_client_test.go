@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/Nerzal/gocloak/v12"
 	"github.com/stretchr/testify/assert"
 	testifyMock "github.com/stretchr/testify/mock"
 	v1 "k8s.io/api/apps/v1"
@@ -14,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
 )
@@ -25,7 +27,7 @@ func TestPutClient_Serve(t *testing.T) {
 		Spec: keycloakApi.KeycloakClientSpec{TargetRealm: "namespace.main",
 			RealmRoles: &[]keycloakApi.RealmRole{{Name: "fake-client-administrators", Composite: "administrator"},
 				{Name: "fake-client-users", Composite: "developer"},
-			}, Public: false, ClientId: "fake-client", WebUrl: "fake-url", DirectAccess: false,
+			}, Public: gocloak.BoolP(false), ClientId: "fake-client", WebUrl: "fake-url", DirectAccess: gocloak.BoolP(false),
 			AdvancedProtocolMappers: true, ClientRoles: nil, ProtocolMappers: &[]keycloakApi.ProtocolMapper{
 				{Name: "bar", Config: map[string]string{"bar": "1"}},
 				{Name: "foo", Config: map[string]string{"foo": "2"}},
@@ -74,7 +76,7 @@ func TestPutClient_Serve_FailureToUpdateClient(t *testing.T) {
 		Spec: keycloakApi.KeycloakClientSpec{TargetRealm: "namespace.main",
 			RealmRoles: &[]keycloakApi.RealmRole{{Name: "fake-client-administrators", Composite: "administrator"},
 				{Name: "fake-client-users", Composite: "developer"},
-			}, Public: false, ClientId: "fake-client", WebUrl: "fake-url", DirectAccess: false,
+			}, Public: gocloak.BoolP(false), ClientId: "fake-client", WebUrl: "fake-url", DirectAccess: gocloak.BoolP(false),
 			AdvancedProtocolMappers: true, ClientRoles: nil, ProtocolMappers: &[]keycloakApi.ProtocolMapper{
 				{Name: "bar", Config: map[string]string{"bar": "1"}},
 				{Name: "foo", Config: map[string]string{"foo": "2"}},
@@ -110,10 +112,48 @@ func TestPutClient_Serve_FailureToUpdateClient(t *testing.T) {
 	kClient.AssertExpectations(t)
 
 	kc.Spec.Secret = "sec"
-	kc.Spec.Public = false
+	kc.Spec.Public = gocloak.BoolP(false)
 
 	pc.BaseElement.Client = fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(&kc).Build()
 	err = pc.Serve(context.Background(), &kc, kClient)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "secrets \"sec\" not found")
 }
+
+func TestPutClient_Serve_AdoptionPolicy(t *testing.T) {
+	kc := keycloakApi.KeycloakClient{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "namespace",
+		Annotations: map[string]string{keycloakApi.AdoptionPolicyAnnotation: keycloakApi.AdoptionPolicySkip}},
+		Spec: keycloakApi.KeycloakClientSpec{TargetRealm: "namespace.main", ClientId: "fake-client", Public: gocloak.BoolP(true)},
+	}
+
+	pc := PutClient{
+		BaseElement: BaseElement{
+			Logger: mock.NewLogr(),
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+			scheme: scheme.Scheme,
+		},
+	}
+	kClient := new(adapter.Mock)
+
+	kClient.On("GetClientID", kc.Spec.ClientId, kc.Spec.TargetRealm).Return("id1", nil)
+
+	err := pc.Serve(context.Background(), &kc, kClient)
+	assert.True(t, helper.IsAdoptionSkipped(err))
+	assert.Empty(t, kc.Status.ClientID)
+	kClient.AssertNotCalled(t, "UpdateClient", testifyMock.Anything)
+
+	kc.Annotations[keycloakApi.AdoptionPolicyAnnotation] = keycloakApi.AdoptionPolicyFail
+
+	err = pc.Serve(context.Background(), &kc, kClient)
+	assert.ErrorContains(t, err, "client fake-client already exists in keycloak")
+	kClient.AssertNotCalled(t, "UpdateClient", testifyMock.Anything)
+
+	// the policy is not applied to the client which is already owned by the custom resource
+	kc.Status.ClientID = "id1"
+
+	kClient.On("UpdateClient", testifyMock.Anything).Return(nil).Once()
+
+	err = pc.Serve(context.Background(), &kc, kClient)
+	assert.NoError(t, err)
+	kClient.AssertExpectations(t)
+}
@@ -0,0 +1,169 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+// outputSecretValues are the fields available in the output secret templates.
+type outputSecretValues struct {
+	ClientID     string
+	ClientSecret string
+	Realm        string
+	IssuerURL    string
+
+	// PreviousClientSecret is the client secret before the last rotation, it is empty after the overlap window.
+	PreviousClientSecret string
+}
+
+var defaultOutputSecretData = map[string]string{
+	"clientId":     "{{ .ClientID }}",
+	"clientSecret": "{{ .ClientSecret }}",
+}
+
+type PutOutputSecret struct {
+	BaseElement
+	next Element
+}
+
+func (el *PutOutputSecret) Serve(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, adapterClient keycloak.Client) error {
+	if err := el.putOutputSecret(ctx, keycloakClient, adapterClient); err != nil {
+		return errors.Wrap(err, "unable to put output secret")
+	}
+
+	return el.NextServeOrNil(ctx, el.next, keycloakClient, adapterClient)
+}
+
+func (el *PutOutputSecret) putOutputSecret(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient,
+	adapterClient keycloak.Client) error {
+	out := keycloakClient.Spec.OutputSecret
+	if out == nil {
+		return nil
+	}
+
+	if out.Name == keycloakClient.Spec.Secret {
+		return fmt.Errorf("output secret name must differ from the client secret name %s", out.Name)
+	}
+
+	values, err := el.makeOutputSecretValues(ctx, keycloakClient, adapterClient)
+	if err != nil {
+		return err
+	}
+
+	data, err := renderOutputSecretData(out.Data, values)
+	if err != nil {
+		return err
+	}
+
+	secret := coreV1.Secret{ObjectMeta: v1.ObjectMeta{Name: out.Name, Namespace: keycloakClient.Namespace}}
+
+	res, err := controllerutil.CreateOrUpdate(ctx, el.Client, &secret, func() error {
+		secret.Data = data
+
+		return controllerutil.SetControllerReference(keycloakClient, &secret, el.scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to save output secret %s: %w", out.Name, err)
+	}
+
+	if res != controllerutil.OperationResultNone {
+		el.Logger.Info("Output secret is saved", "secret", out.Name, "result", res)
+	}
+
+	return nil
+}
+
+func (el *PutOutputSecret) makeOutputSecretValues(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient,
+	adapterClient keycloak.Client) (*outputSecretValues, error) {
+	values := outputSecretValues{
+		ClientID: keycloakClient.Spec.ClientId,
+		Realm:    keycloakClient.Spec.TargetRealm,
+	}
+
+	if !keycloakClient.IsPublic() && keycloakClient.Spec.Secret != "" {
+		var clientSecret coreV1.Secret
+		if err := el.Client.Get(ctx, types.NamespacedName{Name: keycloakClient.Spec.Secret,
+			Namespace: keycloakClient.Namespace}, &clientSecret); err != nil {
+			return nil, fmt.Errorf("unable to get client secret %s: %w", keycloakClient.Spec.Secret, err)
+		}
+
+		values.ClientSecret = string(clientSecret.Data[clientSecretKey])
+		values.PreviousClientSecret = string(clientSecret.Data[previousClientSecretKey])
+	}
+
+	if usesIssuerURL(keycloakClient.Spec.OutputSecret.Data) {
+		issuer, err := getIssuerURL(adapterClient, keycloakClient.Spec.TargetRealm)
+		if err != nil {
+			return nil, err
+		}
+
+		values.IssuerURL = issuer
+	}
+
+	return &values, nil
+}
+
+func usesIssuerURL(data map[string]string) bool {
+	for _, v := range data {
+		if strings.Contains(v, "IssuerURL") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getIssuerURL(adapterClient keycloak.Client, realmName string) (string, error) {
+	cfg, err := adapterClient.GetOpenIdConfig(&dto.Realm{Name: realmName})
+	if err != nil {
+		return "", fmt.Errorf("unable to get openid configuration: %w", err)
+	}
+
+	var oidcConfig struct {
+		Issuer string `json:"issuer"`
+	}
+
+	if err := json.Unmarshal([]byte(cfg), &oidcConfig); err != nil {
+		return "", fmt.Errorf("unable to decode openid configuration: %w", err)
+	}
+
+	return oidcConfig.Issuer, nil
+}
+
+func renderOutputSecretData(templates map[string]string, values *outputSecretValues) (map[string][]byte, error) {
+	if len(templates) == 0 {
+		templates = defaultOutputSecretData
+	}
+
+	data := make(map[string][]byte, len(templates))
+
+	for key, text := range templates {
+		tpl, err := template.New(key).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse template of key %s: %w", key, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, values); err != nil {
+			return nil, fmt.Errorf("unable to render template of key %s: %w", key, err)
+		}
+
+		data[key] = buf.Bytes()
+	}
+
+	return data, nil
+}
@@ -0,0 +1,104 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestPutOutputSecret_Serve(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(coreV1.AddToScheme(sch))
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	clientSecret := coreV1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-secret", Namespace: "ns"},
+		Data:       map[string][]byte{clientSecretKey: []byte("s3cr3t")},
+	}
+	outputSecret := coreV1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-oidc", Namespace: "ns"},
+		Data:       map[string][]byte{"stale": []byte("value")},
+	}
+
+	kc := keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns", UID: "uid"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			ClientId:    "app",
+			TargetRealm: "realm",
+			Secret:      "app-secret",
+			OutputSecret: &keycloakApi.ClientOutputSecret{
+				Name: "app-oidc",
+				Data: map[string]string{
+					"OIDC_CLIENT_ID":     "{{ .ClientID }}",
+					"OIDC_CLIENT_SECRET": "{{ .ClientSecret }}",
+					"OIDC_ISSUER_URL":    "{{ .IssuerURL }}",
+				},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(&clientSecret, &outputSecret, &kc).Build()
+	el := PutOutputSecret{BaseElement: BaseElement{Logger: mock.NewLogr(), Client: k8sClient, scheme: sch}}
+
+	kClient := new(adapter.Mock)
+	kClient.On("GetOpenIdConfig", &dto.Realm{Name: "realm"}).
+		Return(`{"issuer":"https://sso.example.com/realms/realm"}`, nil)
+
+	require.NoError(t, el.Serve(context.Background(), &kc, kClient))
+
+	var res coreV1.Secret
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: "app-oidc", Namespace: "ns"}, &res))
+	assert.Equal(t, map[string][]byte{
+		"OIDC_CLIENT_ID":     []byte("app"),
+		"OIDC_CLIENT_SECRET": []byte("s3cr3t"),
+		"OIDC_ISSUER_URL":    []byte("https://sso.example.com/realms/realm"),
+	}, res.Data)
+	require.Len(t, res.OwnerReferences, 1)
+	assert.Equal(t, "app", res.OwnerReferences[0].Name)
+}
+
+func TestPutOutputSecret_Serve_DefaultData(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(coreV1.AddToScheme(sch))
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	kc := keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			ClientId:     "app",
+			Public:       gocloak.BoolP(true),
+			OutputSecret: &keycloakApi.ClientOutputSecret{Name: "app-oidc"},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(sch).Build()
+	el := PutOutputSecret{BaseElement: BaseElement{Logger: mock.NewLogr(), Client: k8sClient, scheme: sch}}
+
+	require.NoError(t, el.Serve(context.Background(), &kc, new(adapter.Mock)))
+
+	var res coreV1.Secret
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: "app-oidc", Namespace: "ns"}, &res))
+	assert.Equal(t, "app", string(res.Data["clientId"]))
+	assert.Contains(t, res.Data, "clientSecret")
+	assert.Empty(t, res.Data["clientSecret"])
+}
+
+func TestRenderOutputSecretData_InvalidTemplate(t *testing.T) {
+	_, err := renderOutputSecretData(map[string]string{"key": "{{ .Unknown }}"}, &outputSecretValues{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to render template of key key")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
 )
@@ -24,36 +25,27 @@ func (el *PutProtocolMappers) Serve(ctx context.Context, keycloakClient *keycloa
 	return el.NextServeOrNil(ctx, el.next, keycloakClient, adapterClient)
 }
 
-func copyMap(in map[string]string) map[string]string {
-	out := make(map[string]string)
-	for k, v := range in {
-		out[k] = v
-	}
-
-	return out
-}
-
 func (el *PutProtocolMappers) putProtocolMappers(keycloakClient *keycloakApi.KeycloakClient, adapterClient keycloak.Client) error {
+	clientDto := dto.ConvertSpecToClient(&keycloakClient.Spec, "")
+
 	var protocolMappers []gocloak.ProtocolMapperRepresentation
 
 	if keycloakClient.Spec.ProtocolMappers != nil {
-		protocolMappers = make([]gocloak.ProtocolMapperRepresentation, 0,
-			len(*keycloakClient.Spec.ProtocolMappers))
-
-		for _, mapper := range *keycloakClient.Spec.ProtocolMappers {
-			configCopy := copyMap(mapper.Config)
+		mappers := helper.ConvertProtocolMappers(*keycloakClient.Spec.ProtocolMappers, clientDto.Protocol)
+		protocolMappers = make([]gocloak.ProtocolMapperRepresentation, 0, len(mappers))
 
+		for i := range mappers {
 			protocolMappers = append(protocolMappers, gocloak.ProtocolMapperRepresentation{
-				Name:           gocloak.StringP(mapper.Name),
-				Protocol:       gocloak.StringP(mapper.Protocol),
-				ProtocolMapper: gocloak.StringP(mapper.ProtocolMapper),
-				Config:         &configCopy,
+				Name:           gocloak.StringP(mappers[i].Name),
+				Protocol:       gocloak.StringP(mappers[i].Protocol),
+				ProtocolMapper: gocloak.StringP(mappers[i].ProtocolMapper),
+				Config:         &mappers[i].Config,
 			})
 		}
 	}
 
 	if err := adapterClient.SyncClientProtocolMapper(
-		dto.ConvertSpecToClient(&keycloakClient.Spec, ""),
+		clientDto,
 		protocolMappers, keycloakClient.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly); err != nil {
 		return errors.Wrap(err, "unable to sync protocol mapper")
 	}
@@ -0,0 +1,215 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	networkingV1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+const defaultRedirectURIPath = "/*"
+
+var routeGVK = schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+
+// setRedirectURIs sets the client redirect URIs and web origins from webUrl and the hosts
+// of the Ingress and Route objects referenced by the client.
+func (el *PutClient) setRedirectURIs(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient, clientDto *dto.Client) error {
+	if len(keycloakClient.Spec.RedirectURIsFrom) == 0 {
+		return nil
+	}
+
+	redirectURIs := newOrderedSet()
+	webOrigins := newOrderedSet()
+
+	if keycloakClient.Spec.WebUrl != "" {
+		redirectURIs.add(keycloakClient.Spec.WebUrl + defaultRedirectURIPath)
+		webOrigins.add(keycloakClient.Spec.WebUrl)
+	}
+
+	for i := range keycloakClient.Spec.RedirectURIsFrom {
+		src := &keycloakClient.Spec.RedirectURIsFrom[i]
+
+		origins, err := el.getSourceOrigins(ctx, keycloakClient.Namespace, src)
+		if err != nil {
+			return fmt.Errorf("unable to get hosts of %s %s: %w", sourceKind(src), src.Name, err)
+		}
+
+		path := src.Path
+		if path == "" {
+			path = defaultRedirectURIPath
+		}
+
+		for _, origin := range origins {
+			redirectURIs.add(origin + path)
+			webOrigins.add(origin)
+		}
+	}
+
+	clientDto.RedirectURIs = redirectURIs.items
+	clientDto.WebOrigins = webOrigins.items
+
+	return nil
+}
+
+// getSourceOrigins returns the origins (scheme and host) of the objects selected by the source.
+func (el *PutClient) getSourceOrigins(ctx context.Context, namespace string, src *keycloakApi.RedirectURISource) ([]string, error) {
+	if (src.Name == "") == (src.Selector == nil) {
+		return nil, fmt.Errorf("one of name and selector must be set")
+	}
+
+	if sourceKind(src) == keycloakApi.RedirectURISourceRoute {
+		return el.getRouteOrigins(ctx, namespace, src)
+	}
+
+	return el.getIngressOrigins(ctx, namespace, src)
+}
+
+func (el *PutClient) getIngressOrigins(ctx context.Context, namespace string, src *keycloakApi.RedirectURISource) ([]string, error) {
+	var ingresses []networkingV1.Ingress
+
+	if src.Name != "" {
+		var ingress networkingV1.Ingress
+		if err := el.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: src.Name}, &ingress); err != nil {
+			return nil, fmt.Errorf("unable to get ingress: %w", err)
+		}
+
+		ingresses = append(ingresses, ingress)
+	} else {
+		opts, err := listOptions(namespace, src.Selector)
+		if err != nil {
+			return nil, err
+		}
+
+		var list networkingV1.IngressList
+		if err := el.Client.List(ctx, &list, opts...); err != nil {
+			return nil, fmt.Errorf("unable to list ingresses: %w", err)
+		}
+
+		ingresses = list.Items
+	}
+
+	var origins []string
+
+	for i := range ingresses {
+		tlsHosts := make(map[string]struct{})
+
+		for _, tls := range ingresses[i].Spec.TLS {
+			for _, h := range tls.Hosts {
+				tlsHosts[h] = struct{}{}
+			}
+		}
+
+		for _, rule := range ingresses[i].Spec.Rules {
+			if rule.Host == "" {
+				continue
+			}
+
+			_, secure := tlsHosts[rule.Host]
+			origins = append(origins, makeOrigin(rule.Host, secure))
+		}
+	}
+
+	return origins, nil
+}
+
+func (el *PutClient) getRouteOrigins(ctx context.Context, namespace string, src *keycloakApi.RedirectURISource) ([]string, error) {
+	var routes []unstructured.Unstructured
+
+	if src.Name != "" {
+		route := unstructured.Unstructured{}
+		route.SetGroupVersionKind(routeGVK)
+
+		if err := el.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: src.Name}, &route); err != nil {
+			return nil, fmt.Errorf("unable to get route: %w", err)
+		}
+
+		routes = append(routes, route)
+	} else {
+		opts, err := listOptions(namespace, src.Selector)
+		if err != nil {
+			return nil, err
+		}
+
+		list := unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(routeGVK.GroupVersion().WithKind(routeGVK.Kind + "List"))
+
+		if err := el.Client.List(ctx, &list, opts...); err != nil {
+			return nil, fmt.Errorf("unable to list routes: %w", err)
+		}
+
+		routes = list.Items
+	}
+
+	var origins []string
+
+	for i := range routes {
+		host, _, err := unstructured.NestedString(routes[i].Object, "spec", "host")
+		if err != nil {
+			return nil, fmt.Errorf("unable to get host of route %s: %w", routes[i].GetName(), err)
+		}
+
+		if host == "" {
+			continue
+		}
+
+		_, secure, err := unstructured.NestedMap(routes[i].Object, "spec", "tls")
+		if err != nil {
+			return nil, fmt.Errorf("unable to get tls of route %s: %w", routes[i].GetName(), err)
+		}
+
+		origins = append(origins, makeOrigin(host, secure))
+	}
+
+	return origins, nil
+}
+
+func listOptions(namespace string, selector *metav1.LabelSelector) ([]client.ListOption, error) {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %w", err)
+	}
+
+	return []client.ListOption{client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: sel}}, nil
+}
+
+func sourceKind(src *keycloakApi.RedirectURISource) string {
+	if src.Kind == "" {
+		return keycloakApi.RedirectURISourceIngress
+	}
+
+	return src.Kind
+}
+
+func makeOrigin(host string, secure bool) string {
+	if secure {
+		return "https://" + host
+	}
+
+	return "http://" + host
+}
+
+type orderedSet struct {
+	items []string
+	seen  map[string]struct{}
+}
+
+func newOrderedSet() *orderedSet {
+	return &orderedSet{items: []string{}, seen: make(map[string]struct{})}
+}
+
+func (s *orderedSet) add(item string) {
+	if _, ok := s.seen[item]; ok {
+		return
+	}
+
+	s.seen[item] = struct{}{}
+	s.items = append(s.items, item)
+}
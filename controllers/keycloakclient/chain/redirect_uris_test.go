@@ -0,0 +1,96 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingV1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestPutClient_setRedirectURIs(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(networkingV1.AddToScheme(sch))
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	ingress := networkingV1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec: networkingV1.IngressSpec{
+			TLS: []networkingV1.IngressTLS{{Hosts: []string{"app.example.com"}}},
+			Rules: []networkingV1.IngressRule{
+				{Host: "app.example.com"},
+				{Host: "app.internal"},
+				{},
+			},
+		},
+	}
+	labeledIngress := networkingV1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "ns", Labels: map[string]string{"app": "api"}},
+		Spec: networkingV1.IngressSpec{
+			Rules: []networkingV1.IngressRule{{Host: "api.example.com"}},
+		},
+	}
+
+	route := unstructured.Unstructured{}
+	route.SetGroupVersionKind(routeGVK)
+	route.SetName("console")
+	route.SetNamespace("ns")
+	utilruntime.Must(unstructured.SetNestedField(route.Object, "console.apps.example.com", "spec", "host"))
+	utilruntime.Must(unstructured.SetNestedMap(route.Object, map[string]interface{}{"termination": "edge"}, "spec", "tls"))
+
+	el := PutClient{BaseElement: BaseElement{
+		Logger: mock.NewLogr(),
+		Client: fake.NewClientBuilder().WithScheme(sch).WithObjects(&ingress, &labeledIngress, &route).Build(),
+		scheme: sch,
+	}}
+
+	kc := keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			WebUrl: "https://app.example.com",
+			RedirectURIsFrom: []keycloakApi.RedirectURISource{
+				{Name: "app"},
+				{Kind: keycloakApi.RedirectURISourceIngress, Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "api"},
+				}, Path: "/callback"},
+				{Kind: keycloakApi.RedirectURISourceRoute, Name: "console"},
+			},
+		},
+	}
+
+	clientDto := dto.Client{}
+	require.NoError(t, el.setRedirectURIs(context.Background(), &kc, &clientDto))
+
+	assert.Equal(t, []string{
+		"https://app.example.com/*",
+		"http://app.internal/*",
+		"http://api.example.com/callback",
+		"https://console.apps.example.com/*",
+	}, clientDto.RedirectURIs)
+	assert.Equal(t, []string{
+		"https://app.example.com",
+		"http://app.internal",
+		"http://api.example.com",
+		"https://console.apps.example.com",
+	}, clientDto.WebOrigins)
+
+	kc.Spec.RedirectURIsFrom = []keycloakApi.RedirectURISource{{Name: "missing"}}
+	err := el.setRedirectURIs(context.Background(), &kc, &clientDto)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get hosts of Ingress missing")
+
+	kc.Spec.RedirectURIsFrom = []keycloakApi.RedirectURISource{{}}
+	err = el.setRedirectURIs(context.Background(), &kc, &clientDto)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "one of name and selector must be set")
+}
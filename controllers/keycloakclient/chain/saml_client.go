@@ -0,0 +1,107 @@
+package chain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+// convertSAMLCrToDto converts SAML client CR to dto, typed SAML fields are merged into the client attributes.
+func (el *PutClient) convertSAMLCrToDto(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient) (*dto.Client, error) {
+	res := dto.ConvertSpecToClient(&keycloakClient.Spec, "")
+
+	saml := keycloakClient.Spec.SAML
+	if saml == nil {
+		return res, nil
+	}
+
+	attributes := make(map[string]string, len(keycloakClient.Spec.Attributes))
+	for k, v := range keycloakClient.Spec.Attributes {
+		attributes[k] = v
+	}
+
+	setStringAttribute(attributes, "saml_assertion_consumer_url_post", saml.AssertionConsumerServiceURLPost)
+	setStringAttribute(attributes, "saml_assertion_consumer_url_redirect", saml.AssertionConsumerServiceURLRedirect)
+	setStringAttribute(attributes, "saml_single_logout_service_url_post", saml.SingleLogoutServiceURLPost)
+	setStringAttribute(attributes, "saml_single_logout_service_url_redirect", saml.SingleLogoutServiceURLRedirect)
+	setStringAttribute(attributes, "saml_name_id_format", saml.NameIDFormat)
+	setStringAttribute(attributes, "saml.signature.algorithm", saml.SignatureAlgorithm)
+	setBoolAttribute(attributes, "saml_force_name_id_format", saml.ForceNameIDFormat)
+	setBoolAttribute(attributes, "saml.force.post.binding", saml.ForcePostBinding)
+	setBoolAttribute(attributes, "saml.server.signature", saml.SignDocuments)
+	setBoolAttribute(attributes, "saml.assertion.signature", saml.SignAssertions)
+	setBoolAttribute(attributes, "saml.client.signature", saml.ClientSignatureRequired)
+	setBoolAttribute(attributes, "saml.encrypt", saml.EncryptAssertions)
+
+	if saml.SigningCertificateRef != nil {
+		cert, err := el.getCertificate(ctx, keycloakClient.Namespace, saml.SigningCertificateRef)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get signing certificate: %w", err)
+		}
+
+		attributes["saml.signing.certificate"] = cert
+	}
+
+	if saml.EncryptionCertificateRef != nil {
+		cert, err := el.getCertificate(ctx, keycloakClient.Namespace, saml.EncryptionCertificateRef)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get encryption certificate: %w", err)
+		}
+
+		attributes["saml.encryption.certificate"] = cert
+	}
+
+	res.Attributes = attributes
+
+	return res, nil
+}
+
+// getCertificate returns the certificate from the secret in the format expected by keycloak:
+// base64 encoded DER without PEM header and footer.
+func (el *PutClient) getCertificate(ctx context.Context, namespace string, ref *keycloakApi.SecretKeyRef) (string, error) {
+	value, err := el.getSecretValue(ctx, namespace, ref)
+	if err != nil {
+		return "", err
+	}
+
+	if block, _ := pem.Decode(value); block != nil {
+		return base64.StdEncoding.EncodeToString(block.Bytes), nil
+	}
+
+	return strings.TrimSpace(string(value)), nil
+}
+
+func (el *PutClient) getSecretValue(ctx context.Context, namespace string, ref *keycloakApi.SecretKeyRef) ([]byte, error) {
+	var secret coreV1.Secret
+	if err := el.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("unable to get secret %s: %w", ref.Name, err)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s does not contain key %s", ref.Name, ref.Key)
+	}
+
+	return value, nil
+}
+
+func setStringAttribute(attributes map[string]string, key, value string) {
+	if value != "" {
+		attributes[key] = value
+	}
+}
+
+func setBoolAttribute(attributes map[string]string, key string, value *bool) {
+	if value != nil {
+		attributes[key] = strconv.FormatBool(*value)
+	}
+}
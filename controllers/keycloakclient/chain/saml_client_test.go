@@ -0,0 +1,85 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+const testCertificatePEM = `-----BEGIN CERTIFICATE-----
+AQIDBA==
+-----END CERTIFICATE-----
+`
+
+func TestPutClient_convertCrToDto_SAML(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(coreV1.AddToScheme(sch))
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	secret := coreV1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "saml-certs", Namespace: "ns"},
+		Data: map[string][]byte{
+			"tls.crt": []byte(testCertificatePEM),
+			"enc.crt": []byte(" MIIC \n"),
+		},
+	}
+
+	el := PutClient{BaseElement: BaseElement{
+		Logger: mock.NewLogr(),
+		Client: fake.NewClientBuilder().WithScheme(sch).WithObjects(&secret).Build(),
+		scheme: sch,
+	}}
+
+	protocol := "saml"
+	forcePost := true
+	kc := keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "saml", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			ClientId:    "https://sp.example.com",
+			TargetRealm: "realm",
+			Protocol:    &protocol,
+			Attributes:  map[string]string{"saml.force.post.binding": "false", "custom": "value"},
+			SAML: &keycloakApi.SAMLClientConfig{
+				AssertionConsumerServiceURLPost: "https://sp.example.com/acs",
+				NameIDFormat:                    "email",
+				ForcePostBinding:                &forcePost,
+				SigningCertificateRef:           &keycloakApi.SecretKeyRef{Name: "saml-certs", Key: "tls.crt"},
+				EncryptionCertificateRef:        &keycloakApi.SecretKeyRef{Name: "saml-certs", Key: "enc.crt"},
+			},
+		},
+	}
+
+	cl, err := el.convertCrToDto(context.Background(), &kc)
+	require.NoError(t, err)
+	assert.Equal(t, "saml", cl.Protocol)
+	assert.Empty(t, cl.ClientSecret)
+	assert.Equal(t, map[string]string{
+		"custom":                           "value",
+		"saml.force.post.binding":          "true",
+		"saml_assertion_consumer_url_post": "https://sp.example.com/acs",
+		"saml_name_id_format":              "email",
+		"saml.signing.certificate":         "AQIDBA==",
+		"saml.encryption.certificate":      "MIIC",
+	}, cl.Attributes)
+	assert.Equal(t, "false", kc.Spec.Attributes["saml.force.post.binding"], "spec attributes must not be modified")
+
+	kc.Spec.SAML.SigningCertificateRef.Key = "missing"
+	_, err = el.convertCrToDto(context.Background(), &kc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not contain key missing")
+
+	kc.Spec.Protocol = nil
+	_, err = el.convertCrToDto(context.Background(), &kc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "saml config is allowed only for the saml protocol")
+}
@@ -0,0 +1,111 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sethvargo/go-password/password"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+const (
+	previousClientSecretKey = "previousClientSecret"
+
+	rotatedSecretAttribute               = "client.secret.rotated"
+	rotatedSecretCreationTimeAttribute   = "client.secret.rotated.creation.time"
+	rotatedSecretExpirationTimeAttribute = "client.secret.rotated.expiration.time"
+
+	secretRotatedEventReason = "SecretRotated"
+)
+
+// applySecretRotation rotates the client secret if the rotation interval is over
+// and passes the previous secret to keycloak until the overlap window is over.
+// The rotation schedule starts from the first reconciliation with the rotation policy.
+func (el *PutClient) applySecretRotation(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient,
+	clientDto *dto.Client) (*dto.Client, error) {
+	policy := keycloakClient.Spec.SecretRotation
+	if policy == nil {
+		return clientDto, nil
+	}
+
+	if policy.Interval.Duration <= 0 {
+		return nil, fmt.Errorf("secret rotation interval must be positive")
+	}
+
+	now := metav1.Now()
+
+	lastRotation := keycloakClient.Status.SecretRotationTime
+	if lastRotation == nil {
+		keycloakClient.Status.SecretRotationTime = &now
+
+		return clientDto, nil
+	}
+
+	var secret coreV1.Secret
+	if err := el.Client.Get(ctx, types.NamespacedName{Name: keycloakClient.Spec.Secret,
+		Namespace: keycloakClient.Namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("unable to get client secret %s: %w", keycloakClient.Spec.Secret, err)
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	rotate := !now.Time.Before(lastRotation.Add(policy.Interval.Duration))
+	if rotate {
+		secret.Data[previousClientSecretKey] = secret.Data[clientSecretKey]
+		secret.Data[clientSecretKey] = []byte(
+			password.MustGenerate(passwordLength, passwordDigits, passwordSymbols, true, true),
+		)
+		lastRotation = &now
+	}
+
+	overlapEnd := lastRotation.Add(policy.OverlapWindow.Duration)
+	previous, hasPrevious := secret.Data[previousClientSecretKey]
+	expired := hasPrevious && !now.Time.Before(overlapEnd)
+
+	if expired {
+		delete(secret.Data, previousClientSecretKey)
+	}
+
+	if rotate || expired {
+		if err := el.Client.Update(ctx, &secret); err != nil {
+			return nil, fmt.Errorf("unable to update client secret %s: %w", secret.Name, err)
+		}
+	}
+
+	if rotate {
+		keycloakClient.Status.SecretRotationTime = &now
+
+		el.Logger.Info("Client secret is rotated", "secret", secret.Name)
+
+		if el.recorder != nil {
+			el.recorder.Eventf(keycloakClient, coreV1.EventTypeNormal, secretRotatedEventReason,
+				"Client secret %s is rotated, previous secret is available until %s", secret.Name,
+				overlapEnd.Format(time.RFC3339))
+		}
+	}
+
+	clientDto.ClientSecret = string(secret.Data[clientSecretKey])
+
+	if hasPrevious && !expired {
+		attributes := make(map[string]string, len(clientDto.Attributes))
+		for k, v := range clientDto.Attributes {
+			attributes[k] = v
+		}
+
+		attributes[rotatedSecretAttribute] = string(previous)
+		attributes[rotatedSecretCreationTimeAttribute] = strconv.FormatInt(lastRotation.Unix(), 10)
+		attributes[rotatedSecretExpirationTimeAttribute] = strconv.FormatInt(overlapEnd.Unix(), 10)
+		clientDto.Attributes = attributes
+	}
+
+	return clientDto, nil
+}
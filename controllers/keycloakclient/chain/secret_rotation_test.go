@@ -0,0 +1,123 @@
+package chain
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestPutClient_applySecretRotation(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(coreV1.AddToScheme(sch))
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	hourAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name           string
+		data           map[string][]byte
+		lastRotation   *metav1.Time
+		wantRotated    bool
+		wantPrevious   string
+		wantAttributes bool
+	}{
+		{
+			name:         "schedule starts",
+			data:         map[string][]byte{clientSecretKey: []byte("old")},
+			lastRotation: nil,
+		},
+		{
+			name:           "secret is rotated",
+			data:           map[string][]byte{clientSecretKey: []byte("old")},
+			lastRotation:   &metav1.Time{Time: time.Now().Add(-48 * time.Hour)},
+			wantRotated:    true,
+			wantPrevious:   "old",
+			wantAttributes: true,
+		},
+		{
+			name:           "previous secret is kept during overlap",
+			data:           map[string][]byte{clientSecretKey: []byte("new"), previousClientSecretKey: []byte("old")},
+			lastRotation:   &hourAgo,
+			wantPrevious:   "old",
+			wantAttributes: true,
+		},
+		{
+			name:         "previous secret is removed after overlap",
+			data:         map[string][]byte{clientSecretKey: []byte("new"), previousClientSecretKey: []byte("old")},
+			lastRotation: &metav1.Time{Time: time.Now().Add(-3 * time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			secret := coreV1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-secret", Namespace: "ns"},
+				Data:       tt.data,
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(sch).WithObjects(&secret).Build()
+			recorder := record.NewFakeRecorder(1)
+
+			el := PutClient{BaseElement: BaseElement{
+				Logger:   mock.NewLogr(),
+				Client:   k8sClient,
+				scheme:   sch,
+				recorder: recorder,
+			}}
+
+			kc := keycloakApi.KeycloakClient{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+				Spec: keycloakApi.KeycloakClientSpec{
+					Secret: "app-secret",
+					SecretRotation: &keycloakApi.SecretRotationPolicy{
+						Interval:      metav1.Duration{Duration: 24 * time.Hour},
+						OverlapWindow: metav1.Duration{Duration: 2 * time.Hour},
+					},
+				},
+				Status: keycloakApi.KeycloakClientStatus{SecretRotationTime: tt.lastRotation},
+			}
+
+			clientDto, err := el.applySecretRotation(context.Background(), &kc,
+				&dto.Client{ClientSecret: string(tt.data[clientSecretKey])})
+			require.NoError(t, err)
+			require.NotNil(t, kc.Status.SecretRotationTime)
+
+			var res coreV1.Secret
+			require.NoError(t, k8sClient.Get(context.Background(),
+				types.NamespacedName{Name: "app-secret", Namespace: "ns"}, &res))
+
+			assert.Equal(t, string(res.Data[clientSecretKey]), clientDto.ClientSecret)
+			assert.Equal(t, tt.wantPrevious, string(res.Data[previousClientSecretKey]))
+
+			if tt.wantRotated {
+				assert.NotEqual(t, "old", clientDto.ClientSecret)
+				require.Len(t, recorder.Events, 1)
+				assert.True(t, strings.HasPrefix(<-recorder.Events, "Normal SecretRotated"))
+			} else {
+				assert.Empty(t, recorder.Events)
+			}
+
+			if tt.wantAttributes {
+				assert.Equal(t, "old", clientDto.Attributes[rotatedSecretAttribute])
+				assert.NotEmpty(t, clientDto.Attributes[rotatedSecretExpirationTimeAttribute])
+			} else {
+				assert.NotContains(t, clientDto.Attributes, rotatedSecretAttribute)
+			}
+		})
+	}
+}
@@ -19,13 +19,18 @@ func (el *ServiceAccount) Serve(ctx context.Context, keycloakClient *keycloakApi
 		return el.NextServeOrNil(ctx, el.next, keycloakClient, adapterClient)
 	}
 
-	if keycloakClient.Spec.ServiceAccount != nil && keycloakClient.Spec.Public {
+	if keycloakClient.Spec.ServiceAccount != nil && keycloakClient.IsPublic() {
 		return errors.New("service account can not be configured with public client")
 	}
 
-	clientRoles := make(map[string][]string)
+	// the client roles are not managed if the list is not set
+	var clientRoles map[string][]string
+	if keycloakClient.Spec.ServiceAccount.ClientRoles != nil {
+		clientRoles = make(map[string][]string)
+	}
+
 	for _, v := range keycloakClient.Spec.ServiceAccount.ClientRoles {
-		clientRoles[v.ClientID] = v.Roles
+		clientRoles[v.ClientID] = append(clientRoles[v.ClientID], v.Roles...)
 	}
 
 	addOnly := keycloakClient.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly
@@ -46,3 +46,32 @@ func TestServiceAccount_Serve(t *testing.T) {
 	err := sa.Serve(context.Background(), &kc, kClient)
 	require.NoError(t, err)
 }
+
+func TestServiceAccount_Serve_OtherClientRoles(t *testing.T) {
+	sa := ServiceAccount{}
+
+	kc := keycloakApi.KeycloakClient{
+		Spec: keycloakApi.KeycloakClientSpec{
+			TargetRealm:            "realm1",
+			ReconciliationStrategy: keycloakApi.ReconciliationStrategyAddOnly,
+			ServiceAccount: &keycloakApi.ServiceAccount{
+				Enabled: true,
+				ClientRoles: []keycloakApi.ClientRole{
+					{ClientID: "realm-management", Roles: []string{"view-users"}},
+					{ClientID: "account", Roles: []string{"view-profile"}},
+					{ClientID: "realm-management", Roles: []string{"manage-clients"}},
+				},
+			},
+		},
+		Status: keycloakApi.KeycloakClientStatus{ClientID: "clid1"},
+	}
+	kClient := new(adapter.Mock)
+
+	kClient.On("SyncServiceAccountRoles", "realm1", "clid1", []string(nil), map[string][]string{
+		"realm-management": {"view-users", "manage-clients"},
+		"account":          {"view-profile"},
+	}, true).Return(nil)
+
+	require.NoError(t, sa.Serve(context.Background(), &kc, kClient))
+	kClient.AssertExpectations(t)
+}
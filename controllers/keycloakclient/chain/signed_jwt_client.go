@@ -0,0 +1,83 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+// convertSignedJWTCrToDto converts the client CR which uses signed JWT authentication to dto,
+// the client keys are merged into the client attributes.
+func (el *PutClient) convertSignedJWTCrToDto(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient) (*dto.Client, error) {
+	res := dto.ConvertSpecToClient(&keycloakClient.Spec, "")
+
+	cfg := keycloakClient.Spec.SignedJWT
+	if cfg == nil {
+		return res, nil
+	}
+
+	if countSignedJWTKeySources(cfg) > 1 {
+		return nil, fmt.Errorf("only one of jwksUrl, jwksRef and certificateRef can be set")
+	}
+
+	attributes := make(map[string]string, len(keycloakClient.Spec.Attributes))
+	for k, v := range keycloakClient.Spec.Attributes {
+		attributes[k] = v
+	}
+
+	setStringAttribute(attributes, "token.endpoint.auth.signing.alg", cfg.SigningAlgorithm)
+
+	switch {
+	case cfg.JWKSURL != "":
+		attributes["use.jwks.url"] = "true"
+		attributes["use.jwks.string"] = "false"
+		attributes["jwks.url"] = cfg.JWKSURL
+	case cfg.JWKSRef != nil:
+		jwks, err := el.getSecretValue(ctx, keycloakClient.Namespace, cfg.JWKSRef)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get client JWKS: %w", err)
+		}
+
+		if !json.Valid(jwks) {
+			return nil, fmt.Errorf("client JWKS in secret %s is not a valid JSON", cfg.JWKSRef.Name)
+		}
+
+		attributes["use.jwks.url"] = "false"
+		attributes["use.jwks.string"] = "true"
+		attributes["jwks.string"] = string(jwks)
+	case cfg.CertificateRef != nil:
+		cert, err := el.getCertificate(ctx, keycloakClient.Namespace, cfg.CertificateRef)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get client certificate: %w", err)
+		}
+
+		attributes["use.jwks.url"] = "false"
+		attributes["use.jwks.string"] = "false"
+		attributes["jwt.credential.certificate"] = cert
+	}
+
+	res.Attributes = attributes
+
+	return res, nil
+}
+
+func countSignedJWTKeySources(cfg *keycloakApi.SignedJWTConfig) int {
+	count := 0
+
+	if cfg.JWKSURL != "" {
+		count++
+	}
+
+	if cfg.JWKSRef != nil {
+		count++
+	}
+
+	if cfg.CertificateRef != nil {
+		count++
+	}
+
+	return count
+}
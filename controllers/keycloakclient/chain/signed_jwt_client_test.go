@@ -0,0 +1,136 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestPutClient_convertCrToDto_SignedJWT(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(coreV1.AddToScheme(sch))
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	secret := coreV1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client-keys", Namespace: "ns"},
+		Data: map[string][]byte{
+			"jwks.json": []byte(`{"keys":[]}`),
+			"tls.crt":   []byte(testCertificatePEM),
+			"invalid":   []byte(`{keys`),
+		},
+	}
+
+	el := PutClient{BaseElement: BaseElement{
+		Logger: mock.NewLogr(),
+		Client: fake.NewClientBuilder().WithScheme(sch).WithObjects(&secret).Build(),
+		scheme: sch,
+	}}
+
+	tests := []struct {
+		name       string
+		signedJWT  *keycloakApi.SignedJWTConfig
+		public     bool
+		authType   string
+		wantAttrs  map[string]string
+		wantErrMsg string
+	}{
+		{
+			name:      "jwks url",
+			authType:  "client-jwt",
+			signedJWT: &keycloakApi.SignedJWTConfig{JWKSURL: "https://app.example.com/jwks", SigningAlgorithm: "RS256"},
+			wantAttrs: map[string]string{
+				"use.jwks.url":                    "true",
+				"use.jwks.string":                 "false",
+				"jwks.url":                        "https://app.example.com/jwks",
+				"token.endpoint.auth.signing.alg": "RS256",
+			},
+		},
+		{
+			name:      "jwks from secret",
+			authType:  "client-jwt",
+			signedJWT: &keycloakApi.SignedJWTConfig{JWKSRef: &keycloakApi.SecretKeyRef{Name: "client-keys", Key: "jwks.json"}},
+			wantAttrs: map[string]string{
+				"use.jwks.url":    "false",
+				"use.jwks.string": "true",
+				"jwks.string":     `{"keys":[]}`,
+			},
+		},
+		{
+			name:      "certificate from secret",
+			authType:  "client-jwt",
+			signedJWT: &keycloakApi.SignedJWTConfig{CertificateRef: &keycloakApi.SecretKeyRef{Name: "client-keys", Key: "tls.crt"}},
+			wantAttrs: map[string]string{
+				"use.jwks.url":               "false",
+				"use.jwks.string":            "false",
+				"jwt.credential.certificate": "AQIDBA==",
+			},
+		},
+		{
+			name:       "invalid jwks",
+			authType:   "client-jwt",
+			signedJWT:  &keycloakApi.SignedJWTConfig{JWKSRef: &keycloakApi.SecretKeyRef{Name: "client-keys", Key: "invalid"}},
+			wantErrMsg: "is not a valid JSON",
+		},
+		{
+			name:     "several key sources",
+			authType: "client-jwt",
+			signedJWT: &keycloakApi.SignedJWTConfig{
+				JWKSURL:        "https://app.example.com/jwks",
+				CertificateRef: &keycloakApi.SecretKeyRef{Name: "client-keys", Key: "tls.crt"},
+			},
+			wantErrMsg: "only one of jwksUrl, jwksRef and certificateRef can be set",
+		},
+		{
+			name:       "wrong authenticator",
+			authType:   "client-secret",
+			signedJWT:  &keycloakApi.SignedJWTConfig{JWKSURL: "https://app.example.com/jwks"},
+			wantErrMsg: "signedJwt config is allowed only for the client-jwt client authenticator",
+		},
+		{
+			name:       "public client",
+			authType:   "client-jwt",
+			public:     true,
+			wantErrMsg: "signed JWT authentication can not be used with public client",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			kc := keycloakApi.KeycloakClient{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+				Spec: keycloakApi.KeycloakClientSpec{
+					ClientId:                "app",
+					TargetRealm:             "realm",
+					Public:                  gocloak.BoolP(tt.public),
+					ClientAuthenticatorType: tt.authType,
+					SignedJWT:               tt.signedJWT,
+				},
+			}
+
+			res, err := el.convertCrToDto(context.Background(), &kc)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrMsg)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "client-jwt", res.ClientAuthenticatorType)
+			assert.Empty(t, res.ClientSecret)
+			assert.Equal(t, tt.wantAttrs, res.Attributes)
+		})
+	}
+}
@@ -10,12 +10,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain"
 )
 
 func (r *ReconcileKeycloakClient) getOrCreateRealmOwner(keycloakClient *keycloakApi.KeycloakClient) (*keycloakApi.KeycloakRealm, error) {
-	realm, err := r.helper.GetOrCreateRealmOwnerRef(&clientRealmFinder{parent: keycloakClient,
-		client: r.client},
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(NewRealmChild(r.client, keycloakClient),
 		&keycloakClient.ObjectMeta)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to GetOrCreateRealmOwnerRef")
@@ -28,6 +28,12 @@ func (r *ReconcileKeycloakClient) getOrCreateRealmOwner(keycloakClient *keycloak
 	return realm, nil
 }
 
+// NewRealmChild returns the realm child of the keycloak client, the realm is found by the target realm name
+// if the client does not reference the realm otherwise.
+func NewRealmChild(k8sClient client.Client, keycloakClient *keycloakApi.KeycloakClient) helper.RealmChild {
+	return &clientRealmFinder{parent: keycloakClient, client: k8sClient}
+}
+
 type clientRealmFinder struct {
 	client client.Client
 	parent *keycloakApi.KeycloakClient
@@ -44,7 +50,7 @@ func (c *clientRealmFinder) GetNamespace() string {
 func (c *clientRealmFinder) K8SParentRealmName() (string, error) {
 	var realmList keycloakApi.KeycloakRealmList
 
-	listOpts := client.ListOptions{Namespace: c.parent.Namespace}
+	listOpts := client.ListOptions{Namespace: c.realmNamespace()}
 
 	client.MatchingLabels(map[string]string{chain.TargetRealmLabel: c.parent.Spec.TargetRealm}).ApplyToList(&listOpts)
 
@@ -69,6 +75,31 @@ func (c *clientRealmFinder) K8SParentRealmName() (string, error) {
 	return "main", nil
 }
 
+func (c *clientRealmFinder) GetRealmNamespace() string {
+	return c.parent.Spec.RealmNamespace
+}
+
+// realmNamespace returns the namespace the realm of the client is searched in.
+func (c *clientRealmFinder) realmNamespace() string {
+	if c.parent.Spec.RealmNamespace != "" {
+		return c.parent.Spec.RealmNamespace
+	}
+
+	return c.parent.Namespace
+}
+
+func (c *clientRealmFinder) ClusterRealmName() string {
+	return c.parent.Spec.ClusterRealm
+}
+
+func (c *clientRealmFinder) GetRealmSelector() *v1.LabelSelector {
+	return c.parent.Spec.RealmSelector
+}
+
+func (c *clientRealmFinder) GetKeycloakRef() *keycloakApi.KeycloakRef {
+	return c.parent.Spec.KeycloakRef
+}
+
 func (c *clientRealmFinder) SetOwnerReferences(or []v1.OwnerReference) {
 	c.parent.SetOwnerReferences(or)
 }
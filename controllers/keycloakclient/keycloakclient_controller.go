@@ -7,14 +7,22 @@ import (
 
 	"github.com/go-logr/logr"
 	pkgErrors "github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
@@ -36,12 +44,13 @@ const (
 	keyCloakClientOperatorFinalizerName = "keycloak.client.operator.finalizer.name"
 )
 
-func NewReconcileKeycloakClient(client client.Client, log logr.Logger, helper Helper) *ReconcileKeycloakClient {
+func NewReconcileKeycloakClient(client client.Client, log logr.Logger, helper Helper,
+	recorder record.EventRecorder) *ReconcileKeycloakClient {
 	return &ReconcileKeycloakClient{
 		client: client,
 		helper: helper,
 		log:    log.WithName("keycloak-client"),
-		chain:  chain.Make(helper.GetScheme(), client, log.WithName("chain").WithName("keycloak-client")),
+		chain:  chain.Make(helper.GetScheme(), client, log.WithName("chain").WithName("keycloak-client"), recorder),
 	}
 }
 
@@ -54,16 +63,28 @@ type ReconcileKeycloakClient struct {
 	successReconcileTimeout time.Duration
 }
 
-func (r *ReconcileKeycloakClient) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+// SetupWithManager registers the controller. The drifted clients sent by the triggers are reconciled
+// along with the watched objects.
+func (r *ReconcileKeycloakClient) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration,
+	triggers ...source.Source) error {
 	r.successReconcileTimeout = successReconcileTimeout
 
 	pred := predicate.Funcs{
-		UpdateFunc: helper.IsFailuresUpdated,
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return helper.IsFailuresUpdated(e) && !helper.IsDriftConditionUpdated(e)
+		},
 	}
 
-	err := ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakApi.KeycloakClient{}, builder.WithPredicates(pred)).
-		Complete(r)
+		Watches(&source.Kind{Type: &networkingV1.Ingress{}}, handler.EnqueueRequestsFromMapFunc(r.mapIngressToClients)).
+		Watches(&source.Kind{Type: &coreV1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.mapConfigMapToClients))
+
+	for _, t := range triggers {
+		b = b.Watches(t, &handler.EnqueueRequestForObject{})
+	}
+
+	err := b.Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup KeycloakClient controller: %w", err)
 	}
@@ -71,9 +92,84 @@ func (r *ReconcileKeycloakClient) SetupWithManager(mgr ctrl.Manager, successReco
 	return nil
 }
 
+// mapIngressToClients returns reconcile requests for clients which take redirect URIs from the ingress.
+func (r *ReconcileKeycloakClient) mapIngressToClients(object client.Object) []reconcile.Request {
+	var clientList keycloakApi.KeycloakClientList
+	if err := r.client.List(context.Background(), &clientList, client.InNamespace(object.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list keycloak clients for ingress", "ingress", object.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range clientList.Items {
+		if referencesIngress(&clientList.Items[i], object) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: clientList.Items[i].Namespace,
+				Name:      clientList.Items[i].Name,
+			}})
+		}
+	}
+
+	return requests
+}
+
+// mapConfigMapToClients returns reconcile requests for clients which import the authorization settings
+// from the config map.
+func (r *ReconcileKeycloakClient) mapConfigMapToClients(object client.Object) []reconcile.Request {
+	var clientList keycloakApi.KeycloakClientList
+	if err := r.client.List(context.Background(), &clientList, client.InNamespace(object.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list keycloak clients for config map", "configMap", object.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range clientList.Items {
+		ref := clientList.Items[i].Spec.AuthorizationSettingsRef
+		if ref != nil && ref.Name == object.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: clientList.Items[i].Namespace,
+				Name:      clientList.Items[i].Name,
+			}})
+		}
+	}
+
+	return requests
+}
+
+func referencesIngress(keycloakClient *keycloakApi.KeycloakClient, ingress client.Object) bool {
+	for _, src := range keycloakClient.Spec.RedirectURIsFrom {
+		if src.Kind != "" && src.Kind != keycloakApi.RedirectURISourceIngress {
+			continue
+		}
+
+		if src.Name != "" && src.Name == ingress.GetName() {
+			return true
+		}
+
+		if src.Selector == nil {
+			continue
+		}
+
+		sel, err := v1.LabelSelectorAsSelector(src.Selector)
+		if err == nil && sel.Matches(labels.Set(ingress.GetLabels())) {
+			return true
+		}
+	}
+
+	return false
+}
+
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclients,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclients/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclients/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=networking.k8s.io,namespace=placeholder,resources=ingresses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes,verbs=get;list
 
 // Reconcile is a loop for reconciling KeycloakClient object.
 func (r *ReconcileKeycloakClient) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
@@ -94,12 +190,25 @@ func (r *ReconcileKeycloakClient) Reconcile(ctx context.Context, request reconci
 		return
 	}
 
-	if err := r.tryReconcile(ctx, &instance); err != nil {
-		instance.Status.Value = err.Error()
+	err := r.tryReconcile(ctx, &instance)
+
+	switch {
+	case helper.IsDryRunPreview(err):
+		log.Info("Dry run is enabled, changes are not applied", "preview", err.Error())
+
+		helper.SetDryRunStatus(&instance, err)
+		result.RequeueAfter = r.successReconcileTimeout
+	case helper.IsAdoptionSkipped(err):
+		log.Info("Client already exists in keycloak, skip it", "name", request.Name)
+
+		helper.SetSkippedStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	case err != nil:
+		helper.SetErrorStatus(&instance, err)
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
 
 		log.Error(err, "an error has occurred while handling keycloak client", "name", request.Name)
-	} else {
+	default:
 		helper.SetSuccessStatus(&instance)
 		result.RequeueAfter = r.successReconcileTimeout
 	}
@@ -112,6 +221,10 @@ func (r *ReconcileKeycloakClient) Reconcile(ctx context.Context, request reconci
 }
 
 func (r *ReconcileKeycloakClient) tryReconcile(ctx context.Context, keycloakClient *keycloakApi.KeycloakClient) error {
+	if released, err := helper.ReleaseDeletedDryRun(ctx, r.client, keycloakClient, keyCloakClientOperatorFinalizerName); err != nil || released {
+		return err
+	}
+
 	realm, err := r.getOrCreateRealmOwner(keycloakClient)
 	if err != nil {
 		return pkgErrors.Wrap(err, "unable to get realm for client")
@@ -122,6 +235,10 @@ func (r *ReconcileKeycloakClient) tryReconcile(ctx context.Context, keycloakClie
 		return pkgErrors.Wrap(err, "unable to create keycloak adapter client")
 	}
 
+	if helper.IsDryRunEnabled(keycloakClient) {
+		return helper.PreviewChanges(ctx, kClient, realm, keycloakClient)
+	}
+
 	if err := r.chain.Serve(ctx, keycloakClient, kClient); err != nil {
 		return pkgErrors.Wrap(err, "error during kc chain")
 	}
@@ -5,11 +5,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	networkingV1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -45,10 +50,10 @@ func TestReconcileKeycloakClient_WithoutOwnerReference(t *testing.T) {
 					Composite: "developer",
 				},
 			},
-			Public:                  true,
+			Public:                  gocloak.BoolP(true),
 			ClientId:                "fake-client",
 			WebUrl:                  "fake-url",
-			DirectAccess:            false,
+			DirectAccess:            gocloak.BoolP(false),
 			AdvancedProtocolMappers: true,
 			ClientRoles:             nil,
 		},
@@ -75,8 +80,8 @@ func TestReconcileKeycloakClient_WithoutOwnerReference(t *testing.T) {
 
 	chainMock.On("Serve", kc).Return(errors.New("fatal"))
 
-	h.On("SetFailureCount", kc).Return(time.Second)
-	h.On("UpdateStatus", kc).Return(nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Second)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
 	h.On("GetOrCreateRealmOwnerRef", &clientRealmFinder{parent: kc,
 		client: client}, &kc.ObjectMeta).Return(&realm, nil)
 	h.On("CreateKeycloakClientForRealm", &realm).Return(&kClient, nil)
@@ -111,7 +116,7 @@ func TestReconcileKeycloakClient_ReconcileWithMappers(t *testing.T) {
 		Spec: keycloakApi.KeycloakClientSpec{TargetRealm: "namespace.main", Secret: "keycloak-secret",
 			RealmRoles: &[]keycloakApi.RealmRole{{Name: "fake-client-administrators", Composite: "administrator"},
 				{Name: "fake-client-users", Composite: "developer"},
-			}, Public: true, ClientId: "fake-client", WebUrl: "fake-url", DirectAccess: false,
+			}, Public: gocloak.BoolP(true), ClientId: "fake-client", WebUrl: "fake-url", DirectAccess: gocloak.BoolP(false),
 			AdvancedProtocolMappers: true, ClientRoles: nil, ProtocolMappers: &[]keycloakApi.ProtocolMapper{
 				{Name: "bar", Config: map[string]string{"bar": "1"}},
 				{Name: "foo", Config: map[string]string{"foo": "2"}},
@@ -138,7 +143,7 @@ func TestReconcileKeycloakClient_ReconcileWithMappers(t *testing.T) {
 	h.On("TryToDelete", &kc,
 		makeTerminator(kc.Status.ClientID, kc.Spec.TargetRealm, kclient, logger),
 		keyCloakClientOperatorFinalizerName).Return(true, nil)
-	h.On("UpdateStatus", &kc).Return(nil)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
 
 	r := ReconcileKeycloakClient{
 		client:                  client,
@@ -156,3 +161,74 @@ func TestReconcileKeycloakClient_ReconcileWithMappers(t *testing.T) {
 		t.Fatal("success reconcile timeout is not set")
 	}
 }
+
+func TestReconcileKeycloakClient_mapIngressToClients(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(s))
+
+	byName := keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "by-name", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			RedirectURIsFrom: []keycloakApi.RedirectURISource{{Name: "app"}},
+		},
+	}
+	bySelector := keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "by-selector", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			RedirectURIsFrom: []keycloakApi.RedirectURISource{{
+				Kind:     keycloakApi.RedirectURISourceIngress,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			}},
+		},
+	}
+	route := keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			RedirectURIsFrom: []keycloakApi.RedirectURISource{{Kind: keycloakApi.RedirectURISourceRoute, Name: "app"}},
+		},
+	}
+
+	r := ReconcileKeycloakClient{
+		client: fake.NewClientBuilder().WithScheme(s).WithObjects(&byName, &bySelector, &route).Build(),
+		log:    mock.NewLogr(),
+	}
+
+	requests := r.mapIngressToClients(&networkingV1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name: "app", Namespace: "ns", Labels: map[string]string{"app": "web"},
+	}})
+
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "by-name"}},
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "by-selector"}},
+	}, requests)
+}
+
+func TestReconcileKeycloakClient_mapConfigMapToClients(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, keycloakApi.AddToScheme(s))
+
+	importing := keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "importing", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			AuthorizationSettingsRef: &keycloakApi.ConfigMapKeyRef{Name: "authz", Key: "settings.json"},
+		},
+	}
+	other := keycloakApi.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakClientSpec{
+			AuthorizationSettingsRef: &keycloakApi.ConfigMapKeyRef{Name: "other-authz", Key: "settings.json"},
+		},
+	}
+	plain := keycloakApi.KeycloakClient{ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "ns"}}
+
+	r := ReconcileKeycloakClient{
+		client: fake.NewClientBuilder().WithScheme(s).WithObjects(&importing, &other, &plain).Build(),
+		log:    mock.NewLogr(),
+	}
+
+	requests := r.mapConfigMapToClients(&coreV1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "authz", Namespace: "ns"}})
+
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "importing"}},
+	}, requests)
+}
@@ -0,0 +1,189 @@
+package keycloakclientrole
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+const finalizerName = "keycloak.clientrole.operator.finalizer.name"
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("keycloak-client-role"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakClientRole{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakClientRole controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakClientRole)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakClientRole)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclientroles,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclientroles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakclientroles/finalizers,verbs=update
+
+// Reconcile is a loop for reconciling KeycloakClientRole object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result,
+	resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakClientRole")
+
+	var instance keycloakApi.KeycloakClientRole
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("instance not found")
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak client role from k8s")
+
+		return
+	}
+
+	err := r.tryReconcile(ctx, &instance)
+
+	switch {
+	case helper.IsAdoptionSkipped(err):
+		log.Info("Client role already exists in keycloak, skip it", "name", request.Name)
+
+		helper.SetSkippedStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	case err != nil:
+		helper.SetErrorStatus(&instance, err)
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak client role", "name", request.Name)
+	default:
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = err
+	}
+
+	log.Info("Reconciling KeycloakClientRole done")
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.KeycloakClientRole) error {
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(instance, &instance.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	if instance.Status.ID == "" {
+		if err = checkClientRoleAdoption(kClient, realm.Spec.RealmName, instance); err != nil {
+			return err
+		}
+	}
+
+	role := dto.ConvertSpecToClientRole(instance)
+
+	if err := kClient.SyncClientRole(ctx, realm.Spec.RealmName, role); err != nil {
+		return errors.Wrap(err, "unable to sync client role")
+	}
+
+	if role.ID != nil {
+		instance.Status.ID = *role.ID
+	}
+
+	instance.Status.UnresolvedComposites = role.UnresolvedComposites
+	if len(role.UnresolvedComposites) > 0 {
+		r.log.Info("Some composite roles are not found", "unresolved", role.UnresolvedComposites)
+	}
+
+	if _, err := r.helper.TryToDelete(ctx, instance,
+		makeTerminator(kClient, realm.Spec.RealmName, instance.Spec.ClientID, instance.Spec.Name,
+			instance.IsDeletionProtected(), r.log.WithName("client-role-term")),
+		finalizerName); err != nil {
+		return errors.Wrap(err, "unable to tryToDelete client role")
+	}
+
+	return nil
+}
+
+// checkClientRoleAdoption applies the adoption policy if the client role already exists in keycloak.
+func checkClientRoleAdoption(kClient keycloak.Client, realmName string,
+	instance *keycloakApi.KeycloakClientRole) error {
+	exists, err := kClient.ExistClientRole(&dto.Client{RealmName: realmName, ClientId: instance.Spec.ClientID},
+		instance.Spec.Name)
+	if err != nil {
+		return errors.Wrap(err, "unable to check client role")
+	}
+
+	if !exists {
+		return nil
+	}
+
+	return helper.CheckAdoption(instance, keycloakApi.AdoptionPolicyAdopt, "client role",
+		instance.Spec.ClientID+"/"+instance.Spec.Name)
+}
@@ -0,0 +1,190 @@
+package keycloakclientrole
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func getTestClientRole() *keycloakApi.KeycloakClientRole {
+	return &keycloakApi.KeycloakClientRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "role1", Namespace: "ns"},
+		TypeMeta:   metav1.TypeMeta{Kind: "KeycloakClientRole", APIVersion: "v1.edp.epam.com/v1"},
+		Spec: keycloakApi.KeycloakClientRoleSpec{
+			Name:        "viewer",
+			Realm:       "test",
+			ClientID:    "app",
+			Description: "read only access",
+			Attributes:  map[string][]string{"level": {"1"}},
+			Composite:   true,
+			Composites:  []keycloakApi.Composite{{Name: "offline_access"}, {Name: "missing"}},
+		},
+	}
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "realm.test"}}
+	role := getTestClientRole()
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(role, &realm).Build()
+	logger := mock.NewLogr()
+
+	kClient := new(adapter.Mock)
+	kClient.On("ExistClientRole", &dto.Client{RealmName: "realm.test", ClientId: "app"}, "viewer").Return(false, nil)
+	kClient.On("SyncClientRole", "realm.test", &dto.ClientRole{
+		Name:        "viewer",
+		ClientID:    "app",
+		Description: "read only access",
+		Attributes:  map[string][]string{"level": {"1"}},
+		IsComposite: true,
+		Composites:  []string{"offline_access", "missing"},
+	}).Run(func(args testifyMock.Arguments) {
+		r := args.Get(1).(*dto.ClientRole)
+		r.ID = new(string)
+		*r.ID = "role-id"
+		r.UnresolvedComposites = []string{"missing"}
+	}).Return(nil)
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
+	h.On("TryToDelete", testifyMock.Anything, testifyMock.Anything, finalizerName).Return(false, nil)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := Reconcile{
+		client:                  client,
+		log:                     logger,
+		helper:                  &h,
+		successReconcileTimeout: time.Hour,
+	}
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: role.Name, Namespace: role.Namespace},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakClientRole)
+	require.True(t, ok)
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	require.Equal(t, "role-id", updated.Status.ID)
+	require.Equal(t, []string{"missing"}, updated.Status.UnresolvedComposites)
+}
+
+func TestReconcile_Reconcile_SyncFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "realm.test"}}
+	role := getTestClientRole()
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(role, &realm).Build()
+	logger := mock.NewLogr()
+
+	kClient := new(adapter.Mock)
+	kClient.On("ExistClientRole", testifyMock.Anything, "viewer").Return(false, nil)
+	kClient.On("SyncClientRole", "realm.test", testifyMock.Anything).Return(errors.New("client not found"))
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := NewReconcile(client, logger, &h)
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: role.Name, Namespace: role.Namespace},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, res.RequeueAfter)
+
+	loggerSink, ok := logger.GetSink().(*mock.Logger)
+	require.True(t, ok, "wrong logger type")
+	require.Error(t, loggerSink.LastError())
+	require.Contains(t, loggerSink.LastError().Error(), "unable to sync client role: client not found")
+}
+
+func TestReconcile_Reconcile_Adoption(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "realm.test"}}
+
+	tests := []struct {
+		name       string
+		policy     string
+		wantStatus string
+	}{
+		{name: "skip", policy: keycloakApi.AdoptionPolicySkip, wantStatus: keycloakApi.StatusSkipped},
+		{
+			name:       "fail",
+			policy:     keycloakApi.AdoptionPolicyFail,
+			wantStatus: "client role app/viewer already exists in keycloak",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			role := getTestClientRole()
+			role.Annotations = map[string]string{keycloakApi.AdoptionPolicyAnnotation: tt.policy}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(role, &realm).Build()
+
+			kClient := new(adapter.Mock)
+			kClient.On("ExistClientRole", &dto.Client{RealmName: "realm.test", ClientId: "app"}, "viewer").
+				Return(true, nil)
+
+			h := helper.Mock{}
+			h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+			h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
+			h.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+			h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+			rec := NewReconcile(client, mock.NewLogr(), &h)
+
+			_, err := rec.Reconcile(context.Background(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: role.Name, Namespace: role.Namespace},
+			})
+			require.NoError(t, err)
+
+			updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakClientRole)
+			require.True(t, ok)
+			require.Contains(t, updated.Status.Value, tt.wantStatus)
+			kClient.AssertNotCalled(t, "SyncClientRole", testifyMock.Anything, testifyMock.Anything)
+		})
+	}
+}
+
+func TestIsSpecUpdated(t *testing.T) {
+	role := getTestClientRole()
+	changed := getTestClientRole()
+	changed.Spec.Description = "changed"
+
+	require.False(t, isSpecUpdated(event.UpdateEvent{ObjectOld: role, ObjectNew: role}))
+	require.True(t, isSpecUpdated(event.UpdateEvent{ObjectOld: role, ObjectNew: changed}))
+}
@@ -0,0 +1,59 @@
+package keycloakclientrole
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+type terminator struct {
+	realmName, clientID, roleName string
+	deletionProtected             bool
+	kClient                       keycloak.Client
+	log                           logr.Logger
+}
+
+func makeTerminator(kClient keycloak.Client, realmName, clientID, roleName string, deletionProtected bool,
+	log logr.Logger) *terminator {
+	return &terminator{
+		kClient:           kClient,
+		realmName:         realmName,
+		clientID:          clientID,
+		roleName:          roleName,
+		deletionProtected: deletionProtected,
+		log:               log,
+	}
+}
+
+func (t *terminator) GetLogger() logr.Logger {
+	return t.log
+}
+
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	logger := t.log.WithValues("realm name", t.realmName, "client id", t.clientID, "role name", t.roleName)
+	logger.Info("start deleting client role")
+
+	if t.deletionProtected {
+		usage, err := t.kClient.GetClientRoleUsage(ctx, t.realmName, t.clientID, t.roleName)
+		if err != nil {
+			return errors.Wrap(err, "unable to check client role usage")
+		}
+
+		if usage.InUse() {
+			return errors.Errorf("client role is protected from deletion and still in use by %s, set the %s annotation to true to delete it",
+				usage, keycloakApi.ForceDeleteAnnotation)
+		}
+	}
+
+	if err := t.kClient.DeleteClientRole(ctx, t.realmName, t.clientID, t.roleName); err != nil {
+		return errors.Wrap(err, "unable to delete client role")
+	}
+
+	logger.Info("done deleting client role")
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package keycloakclientrole
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestTerminator_DeleteResource(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("DeleteClientRole", "realm", "client", "role").Return(nil).Once()
+
+	term := makeTerminator(kClient, "realm", "client", "role", false, mock.NewLogr())
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("DeleteClientRole", "realm", "client", "role").Return(errors.New("fatal")).Once()
+
+	err := term.DeleteResource(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to delete client role")
+}
+
+func TestTerminator_DeleteResource_DeletionProtected(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("GetClientRoleUsage", "realm", "client", "role").
+		Return(&adapter.RoleUsage{Users: []string{"user1"}, CompositeRoles: []string{"admin"}}, nil).Once()
+
+	term := makeTerminator(kClient, "realm", "client", "role", true, mock.NewLogr())
+
+	err := term.DeleteResource(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "users: user1; composite roles: admin")
+	kClient.AssertNotCalled(t, "DeleteClientRole", "realm", "client", "role")
+
+	kClient.On("GetClientRoleUsage", "realm", "client", "role").Return(nil, errors.New("fatal")).Once()
+
+	err = term.DeleteResource(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to check client role usage")
+}
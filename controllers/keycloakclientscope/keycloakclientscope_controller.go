@@ -103,12 +103,19 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 	}
 
 	scopeID, err := r.tryReconcile(ctx, &instance)
-	if err != nil {
-		instance.Status.Value = err.Error()
+
+	switch {
+	case helper.IsAdoptionSkipped(err):
+		log.Info("Client scope already exists in keycloak, skip it", "name", request.Name)
+
+		helper.SetSkippedStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	case err != nil:
+		helper.SetErrorStatus(&instance, err)
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
 
 		log.Error(err, "an error has occurred while handling keycloak client scope", "name", request.Name)
-	} else {
+	default:
 		helper.SetSuccessStatus(&instance)
 		instance.Status.ID = scopeID
 		result.RequeueAfter = r.successReconcileTimeout
@@ -158,13 +165,18 @@ func syncClientScope(ctx context.Context, instance *keycloakApi.KeycloakClientSc
 		Name:            instance.Spec.Name,
 		Attributes:      instance.Spec.Attributes,
 		Protocol:        instance.Spec.Protocol,
-		ProtocolMappers: convertProtocolMappers(instance.Spec.ProtocolMappers),
+		ProtocolMappers: helper.ConvertProtocolMappers(instance.Spec.ProtocolMappers, instance.Spec.Protocol),
 		Description:     instance.Spec.Description,
 		Default:         instance.Spec.Default,
 	}
 
 	if err == nil {
 		if instance.Status.ID == "" {
+			if err = helper.CheckAdoption(instance, keycloakApi.AdoptionPolicyAdopt, "client scope",
+				instance.Spec.Name); err != nil {
+				return "", err
+			}
+
 			instance.Status.ID = clientScope.ID
 		}
 
@@ -184,17 +196,3 @@ func syncClientScope(ctx context.Context, instance *keycloakApi.KeycloakClientSc
 
 	return instance.Status.ID, nil
 }
-
-func convertProtocolMappers(mappers []keycloakApi.ProtocolMapper) []adapter.ProtocolMapper {
-	aMappers := make([]adapter.ProtocolMapper, 0, len(mappers))
-	for _, m := range mappers {
-		aMappers = append(aMappers, adapter.ProtocolMapper{
-			Name:           m.Name,
-			Config:         m.Config,
-			ProtocolMapper: m.ProtocolMapper,
-			Protocol:       m.Protocol,
-		})
-	}
-
-	return aMappers
-}
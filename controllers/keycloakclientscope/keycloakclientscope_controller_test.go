@@ -143,20 +143,6 @@ func TestReconcile_Reconcile_NotFound(t *testing.T) {
 	}
 }
 
-func TestConvertProtocolMappers(t *testing.T) {
-	mappers := convertProtocolMappers([]keycloakApi.ProtocolMapper{
-		{Name: "test1"},
-	})
-
-	if len(mappers) == 0 {
-		t.Fatal("protocol mappers is not converted")
-	}
-
-	if mappers[0].Name != "test1" {
-		t.Fatal("protocol mappers converted wrongly")
-	}
-}
-
 func TestSyncClientScope(t *testing.T) {
 	kClient := new(adapter.Mock)
 	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns",
@@ -177,6 +163,24 @@ func TestSyncClientScope(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSyncClientScope_AdoptionPolicyFail(t *testing.T) {
+	kClient := new(adapter.Mock)
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "ns.test"}}
+	instance := getTestClientScope(realm.Name)
+	instance.Annotations = map[string]string{keycloakApi.AdoptionPolicyAnnotation: keycloakApi.AdoptionPolicyFail}
+
+	kClient.On("GetClientScope", instance.Spec.Name, realm.Spec.RealmName).Return(&adapter.ClientScope{
+		ID: "scopeID1",
+	}, nil)
+
+	_, err := syncClientScope(context.Background(), instance, &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already exists in keycloak")
+	require.Empty(t, instance.Status.ID)
+	kClient.AssertNotCalled(t, "UpdateClientScope")
+}
+
 func TestReconcile_Reconcile_FailureNoRealm(t *testing.T) {
 	scheme := runtime.NewScheme()
 	utilruntime.Must(keycloakApi.AddToScheme(scheme))
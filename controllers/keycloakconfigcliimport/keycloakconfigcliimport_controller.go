@@ -0,0 +1,387 @@
+package keycloakconfigcliimport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("keycloak-config-cli-import"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakConfigCliImport{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakConfigCliImport controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakConfigCliImport)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakConfigCliImport)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec)
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakconfigcliimports,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakconfigcliimports/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakconfigcliimports/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get
+
+// Reconcile is a loop for reconciling KeycloakConfigCliImport object.
+// The imported resources are kept in keycloak after the import is deleted.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result,
+	resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakConfigCliImport")
+
+	var instance keycloakApi.KeycloakConfigCliImport
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak config cli import from k8s")
+
+		return
+	}
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		helper.SetErrorStatus(&instance, err)
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak config cli import", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = err
+	}
+
+	log.Info("Reconciling KeycloakConfigCliImport done")
+
+	return
+}
+
+// representation is a parsed keycloak-config-cli file.
+type representation struct {
+	file string
+	data map[string]interface{}
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, cliImport *keycloakApi.KeycloakConfigCliImport) error {
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(cliImport, &cliImport.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	reps, hash, err := r.prepareFiles(ctx, cliImport, realm.Spec.RealmName)
+	if err != nil {
+		return err
+	}
+
+	if cliImport.Status.SourceHash == hash && cliImport.Status.Value == helper.StatusOK {
+		r.log.Info("Config files are not changed, skipping import", "name", cliImport.Name)
+
+		return nil
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	if err := r.importFiles(ctx, cliImport, kClient, realm.Spec.RealmName, reps); err != nil {
+		return err
+	}
+
+	cliImport.Status.SourceHash = hash
+
+	return nil
+}
+
+// prepareFiles reads, substitutes the variables in and parses the files.
+// It returns the representations and the hash of the substituted files and the settings which affect the import.
+func (r *Reconcile) prepareFiles(ctx context.Context, cliImport *keycloakApi.KeycloakConfigCliImport,
+	realmName string) ([]representation, string, error) {
+	files, err := r.getFiles(ctx, cliImport)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var vars map[string]string
+
+	substitution := cliImport.Spec.VarSubstitution != nil && cliImport.Spec.VarSubstitution.Enabled
+	if substitution {
+		if vars, err = r.getVariables(ctx, cliImport); err != nil {
+			return nil, "", err
+		}
+	}
+
+	h := sha256.New()
+	h.Write([]byte(cliImport.Spec.Realm + "\n" + ifResourceExists(cliImport) + "\n"))
+
+	reps := make([]representation, 0, len(files))
+	unsupported := make(map[string]struct{})
+
+	for _, f := range files {
+		data := f.data
+
+		if substitution {
+			substituted, err := substituteVariables(string(data), vars)
+			if err != nil {
+				return nil, "", errors.Wrapf(err, "unable to substitute variables in file %s", f.name)
+			}
+
+			data = []byte(substituted)
+		}
+
+		rep, fields, err := parseRepresentation(data, realmName)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "invalid file %s", f.name)
+		}
+
+		for _, field := range fields {
+			unsupported[field] = struct{}{}
+		}
+
+		reps = append(reps, representation{file: f.name, data: rep})
+
+		h.Write(data)
+		h.Write([]byte("\n"))
+	}
+
+	cliImport.Status.UnsupportedFields = make([]string, 0, len(unsupported))
+	for field := range unsupported {
+		cliImport.Status.UnsupportedFields = append(cliImport.Status.UnsupportedFields, field)
+	}
+
+	sort.Strings(cliImport.Status.UnsupportedFields)
+
+	if len(cliImport.Status.UnsupportedFields) > 0 {
+		r.log.Info("Config files contain fields which are not imported", "name", cliImport.Name,
+			"fields", cliImport.Status.UnsupportedFields)
+	}
+
+	return reps, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// importFiles imports the representations in order and deletes the managed resources which are not declared anymore.
+// Only the resources added by the import become managed, the overwritten and skipped resources existed before
+// and are not deleted. The resources imported before a failure are added to the managed resources,
+// nothing is deleted in this case.
+func (r *Reconcile) importFiles(ctx context.Context, cliImport *keycloakApi.KeycloakConfigCliImport,
+	kClient keycloak.Client, realmName string, reps []representation) error {
+	log := r.log.WithValues("keycloak config cli import cr", cliImport.Name)
+
+	var added, overwritten, skipped int
+
+	managed := make(map[string]struct{}, len(cliImport.Status.ManagedResources))
+	for _, res := range cliImport.Status.ManagedResources {
+		managed[managedResourceKey(res)] = struct{}{}
+	}
+
+	imported := make([]keycloakApi.ConfigCliManagedResource, 0)
+	declared := make(map[string]struct{})
+
+	for _, rep := range reps {
+		log.Info("Importing config file", "file", rep.file)
+
+		res, err := kClient.PartialImport(ctx, realmName, rep.data, ifResourceExists(cliImport))
+		if err != nil {
+			cliImport.Status.ManagedResources = mergeManagedResources(cliImport.Status.ManagedResources, imported)
+
+			return errors.Wrapf(err, "unable to import file %s", rep.file)
+		}
+
+		added += res.Added
+		overwritten += res.Overwritten
+		skipped += res.Skipped
+
+		for _, item := range res.Results {
+			if item.ResourceType == adapter.PartialImportResourceUser {
+				continue
+			}
+
+			res := keycloakApi.ConfigCliManagedResource{
+				Type: item.ResourceType,
+				Name: item.ResourceName,
+				ID:   item.ID,
+			}
+			key := managedResourceKey(res)
+			declared[key] = struct{}{}
+
+			// the resource added by the previous import is overwritten or skipped by the next one
+			if _, ok := managed[key]; ok || item.Action == adapter.PartialImportActionAdded {
+				imported = append(imported, res)
+			}
+		}
+	}
+
+	imported = mergeManagedResources(nil, imported)
+
+	deleted := 0
+
+	for _, res := range cliImport.Status.ManagedResources {
+		if _, ok := declared[managedResourceKey(res)]; ok {
+			continue
+		}
+
+		if managedMode(&cliImport.Spec.Managed, res.Type) != keycloakApi.ConfigCliManagedFull {
+			continue
+		}
+
+		err := kClient.DeleteImportedResource(ctx, realmName, &adapter.PartialImportResultItem{
+			ResourceType: res.Type,
+			ResourceName: res.Name,
+			ID:           res.ID,
+		})
+		if err != nil && !adapter.IsErrNotFound(err) {
+			cliImport.Status.ManagedResources = mergeManagedResources(cliImport.Status.ManagedResources, imported)
+
+			return errors.Wrapf(err, "unable to delete managed resource %s %s", res.Type, res.Name)
+		}
+
+		log.Info("Managed resource is deleted", "type", res.Type, "resource", res.Name)
+
+		deleted++
+	}
+
+	cliImport.Status.ManagedResources = imported
+	cliImport.Status.Added = added
+	cliImport.Status.Overwritten = overwritten
+	cliImport.Status.Skipped = skipped
+	cliImport.Status.Deleted = deleted
+
+	log.Info("Config files are imported", "added", added, "overwritten", overwritten, "skipped", skipped,
+		"deleted", deleted)
+
+	return nil
+}
+
+// ifResourceExists returns the partial import policy of the existing resources,
+// the existing resources are overwritten by default like in keycloak-config-cli.
+func ifResourceExists(cliImport *keycloakApi.KeycloakConfigCliImport) string {
+	if cliImport.Spec.IfResourceExists == "" {
+		return adapter.PartialImportIfExistsOverwrite
+	}
+
+	return cliImport.Spec.IfResourceExists
+}
+
+// managedMode returns the managed mode of the partial import resource type.
+func managedMode(managed *keycloakApi.ConfigCliManaged, resourceType string) string {
+	var mode string
+
+	switch resourceType {
+	case adapter.PartialImportResourceClient:
+		mode = managed.Client
+	case adapter.PartialImportResourceGroup:
+		mode = managed.Group
+	case adapter.PartialImportResourceRealmRole, adapter.PartialImportResourceClientRole:
+		mode = managed.Role
+	case adapter.PartialImportResourceIDP:
+		mode = managed.IdentityProvider
+	default:
+		return keycloakApi.ConfigCliManagedNoDelete
+	}
+
+	if mode == "" {
+		return keycloakApi.ConfigCliManagedFull
+	}
+
+	return mode
+}
+
+// mergeManagedResources returns the current resources with the new ones which are not in the list yet.
+func mergeManagedResources(current,
+	added []keycloakApi.ConfigCliManagedResource) []keycloakApi.ConfigCliManagedResource {
+	result := make([]keycloakApi.ConfigCliManagedResource, 0, len(current)+len(added))
+	keys := make(map[string]struct{}, len(current)+len(added))
+
+	for _, list := range [][]keycloakApi.ConfigCliManagedResource{current, added} {
+		for _, res := range list {
+			key := managedResourceKey(res)
+			if _, ok := keys[key]; ok {
+				continue
+			}
+
+			keys[key] = struct{}{}
+
+			result = append(result, res)
+		}
+	}
+
+	return result
+}
+
+func managedResourceKey(res keycloakApi.ConfigCliManagedResource) string {
+	if res.ID == "" {
+		return res.Type + "/" + res.Name
+	}
+
+	return res.Type + "/" + res.ID
+}
@@ -0,0 +1,268 @@
+package keycloakconfigcliimport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func getTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+	utilruntime.Must(coreV1.AddToScheme(scheme))
+
+	return scheme
+}
+
+func getTestRealm() *keycloakApi.KeycloakRealm {
+	return &keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "realm.test"}}
+}
+
+func getTestImport() *keycloakApi.KeycloakConfigCliImport {
+	return &keycloakApi.KeycloakConfigCliImport{
+		ObjectMeta: metav1.ObjectMeta{Name: "import", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakConfigCliImportSpec{
+			Realm: "test",
+			Files: []keycloakApi.ConfigCliFile{
+				{ConfigMapName: "files"},
+				{SecretKeyRef: &keycloakApi.SecretKeyRef{Name: "secret-files", Key: "clients.json"}},
+			},
+			VarSubstitution: &keycloakApi.ConfigCliVarSubstitution{
+				Enabled:       true,
+				Variables:     map[string]string{"ROLE": "developer"},
+				VariablesFrom: []keycloakApi.ConfigCliVariablesSource{{SecretName: "variables"}},
+			},
+			Managed: keycloakApi.ConfigCliManaged{Group: keycloakApi.ConfigCliManagedNoDelete},
+		},
+	}
+}
+
+func getTestSources() []runtime.Object {
+	return []runtime.Object{
+		&coreV1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "files", Namespace: "ns"},
+			Data: map[string]string{
+				"02-groups.yaml": "groups:\n  - name: developers\n",
+				"01-roles.yaml":  "realm: realm.test\nenabled: true\nroles:\n  realm:\n    - name: $(env:ROLE)\n",
+			}},
+		&coreV1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret-files", Namespace: "ns"},
+			Data: map[string][]byte{"clients.json": []byte(`{"clients":[{"clientId":"app","secret":"$(SECRET)"}]}`)}},
+		&coreV1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "variables", Namespace: "ns"},
+			Data: map[string][]byte{"SECRET": []byte("pass"), "ROLE": []byte("overridden")}},
+	}
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	realm := getTestRealm()
+	cliImport := getTestImport()
+	cliImport.Status.ManagedResources = []keycloakApi.ConfigCliManagedResource{
+		{Type: adapter.PartialImportResourceClient, Name: "app", ID: "app-id"},
+		{Type: adapter.PartialImportResourceClient, Name: "old-app", ID: "old-app-id"},
+		{Type: adapter.PartialImportResourceGroup, Name: "old-group", ID: "old-group-id"},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(getTestScheme()).
+		WithRuntimeObjects(append(getTestSources(), cliImport, realm)...).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("PartialImport", "realm.test", map[string]interface{}{
+		"realm":   "realm.test",
+		"enabled": true,
+		"roles": map[string]interface{}{
+			"realm": []interface{}{map[string]interface{}{"name": "developer"}},
+		},
+	}, adapter.PartialImportIfExistsOverwrite).Return(&adapter.PartialImportResult{
+		Added: 1,
+		Results: []adapter.PartialImportResultItem{
+			{Action: "ADDED", ResourceType: "REALM_ROLE", ResourceName: "developer", ID: "role-id"},
+		},
+	}, nil)
+	kClient.On("PartialImport", "realm.test", map[string]interface{}{
+		"groups": []interface{}{map[string]interface{}{"name": "developers"}},
+	}, adapter.PartialImportIfExistsOverwrite).Return(&adapter.PartialImportResult{
+		Added: 1,
+		Results: []adapter.PartialImportResultItem{
+			{Action: "ADDED", ResourceType: "GROUP", ResourceName: "developers", ID: "group-id"},
+		},
+	}, nil)
+	kClient.On("PartialImport", "realm.test", map[string]interface{}{
+		"clients": []interface{}{map[string]interface{}{"clientId": "app", "secret": "pass"}},
+	}, adapter.PartialImportIfExistsOverwrite).Return(&adapter.PartialImportResult{
+		Overwritten: 2,
+		Results: []adapter.PartialImportResultItem{
+			{Action: "OVERWRITTEN", ResourceType: "CLIENT", ResourceName: "app", ID: "app-id"},
+			// the client existed before the import, so it is not managed
+			{Action: "OVERWRITTEN", ResourceType: "CLIENT", ResourceName: "existing-app", ID: "existing-app-id"},
+		},
+	}, nil)
+	kClient.On("DeleteImportedResource", "realm.test", &adapter.PartialImportResultItem{
+		ResourceType: adapter.PartialImportResourceClient, ResourceName: "old-app", ID: "old-app-id",
+	}).Return(nil)
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(realm, nil)
+	h.On("CreateKeycloakClientForRealm", realm).Return(kClient, nil)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := Reconcile{
+		client:                  client,
+		log:                     mock.NewLogr(),
+		helper:                  &h,
+		successReconcileTimeout: time.Hour,
+	}
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: cliImport.Name, Namespace: cliImport.Namespace},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakConfigCliImport)
+	require.True(t, ok)
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	require.Equal(t, 2, updated.Status.Added)
+	require.Equal(t, 2, updated.Status.Overwritten)
+	require.Equal(t, 1, updated.Status.Deleted)
+	require.Equal(t, []string{"enabled"}, updated.Status.UnsupportedFields)
+	require.Equal(t, []keycloakApi.ConfigCliManagedResource{
+		{Type: "REALM_ROLE", Name: "developer", ID: "role-id"},
+		{Type: "GROUP", Name: "developers", ID: "group-id"},
+		{Type: "CLIENT", Name: "app", ID: "app-id"},
+	}, updated.Status.ManagedResources)
+	require.NotEmpty(t, updated.Status.SourceHash)
+	kClient.AssertExpectations(t)
+
+	// the unchanged files are not imported again.
+	require.NoError(t, rec.tryReconcile(context.Background(), updated))
+	kClient.AssertNumberOfCalls(t, "PartialImport", 3)
+}
+
+func TestReconcile_Reconcile_ImportFailure(t *testing.T) {
+	realm := getTestRealm()
+	cliImport := getTestImport()
+	cliImport.Status.ManagedResources = []keycloakApi.ConfigCliManagedResource{
+		{Type: adapter.PartialImportResourceClient, Name: "old-app", ID: "old-app-id"},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(getTestScheme()).
+		WithRuntimeObjects(append(getTestSources(), cliImport, realm)...).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("PartialImport", "realm.test", testifyMock.Anything, adapter.PartialImportIfExistsOverwrite).
+		Return(&adapter.PartialImportResult{
+			Added: 1,
+			Results: []adapter.PartialImportResultItem{
+				{Action: "ADDED", ResourceType: "REALM_ROLE", ResourceName: "developer", ID: "role-id"},
+			},
+		}, nil).Once()
+	kClient.On("PartialImport", "realm.test", testifyMock.Anything, adapter.PartialImportIfExistsOverwrite).
+		Return(nil, errors.New("conflict"))
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(realm, nil)
+	h.On("CreateKeycloakClientForRealm", realm).Return(kClient, nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := Reconcile{
+		client: client,
+		log:    mock.NewLogr(),
+		helper: &h,
+	}
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: cliImport.Name, Namespace: cliImport.Namespace},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakConfigCliImport)
+	require.True(t, ok)
+	require.Contains(t, updated.Status.Value, "unable to import file files/02-groups.yaml: conflict")
+	require.Empty(t, updated.Status.SourceHash)
+	require.Equal(t, []keycloakApi.ConfigCliManagedResource{
+		{Type: adapter.PartialImportResourceClient, Name: "old-app", ID: "old-app-id"},
+		{Type: "REALM_ROLE", Name: "developer", ID: "role-id"},
+	}, updated.Status.ManagedResources)
+	kClient.AssertNotCalled(t, "DeleteImportedResource", testifyMock.Anything, testifyMock.Anything)
+}
+
+func TestReconcile_prepareFiles_RealmMismatch(t *testing.T) {
+	cliImport := getTestImport()
+	cliImport.Spec.Files = []keycloakApi.ConfigCliFile{
+		{ConfigMapKeyRef: &keycloakApi.ConfigMapKeyRef{Name: "files", Key: "01-roles.yaml"}},
+	}
+
+	rec := Reconcile{
+		client: fake.NewClientBuilder().WithScheme(getTestScheme()).WithRuntimeObjects(getTestSources()...).Build(),
+		log:    mock.NewLogr(),
+	}
+
+	_, _, err := rec.prepareFiles(context.Background(), cliImport, "other")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid file files/01-roles.yaml: file realm realm.test does not match realm other")
+}
+
+func TestSubstituteVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "variables",
+			data: `{"a":"$(A)","b":"$(env:B)","c":"$(C:-default)","d":"$(A:-unused)"}`,
+			want: `{"a":"1","b":"2","c":"default","d":"1"}`,
+		},
+		{
+			name: "escaped variable",
+			data: `value: $$(A)`,
+			want: `value: $(A)`,
+		},
+		{
+			name:    "undefined variable",
+			data:    `value: $(MISSING)`,
+			wantErr: "variable MISSING is not defined",
+		},
+		{
+			name:    "unsupported lookup",
+			data:    `value: $(file:UTF-8:/tmp/value)`,
+			wantErr: "unsupported variable lookup file:UTF-8:/tmp/value",
+		},
+	}
+
+	vars := map[string]string{"A": "1", "B": "2"}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := substituteVariables(tt.data, vars)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package keycloakconfigcliimport
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+)
+
+const (
+	envVariablePrefix     = "env:"
+	variableDefaultMarker = ":-"
+)
+
+// variableRegexp matches the keycloak-config-cli variables, $$( is an escaped variable prefix.
+var variableRegexp = regexp.MustCompile(`\$(\$?)\(([^()]*)\)`)
+
+// importedFields are the top level fields of the realm representation which are imported by the partial import.
+var importedFields = map[string]struct{}{
+	"realm":             {},
+	"id":                {},
+	"users":             {},
+	"clients":           {},
+	"groups":            {},
+	"roles":             {},
+	"identityProviders": {},
+}
+
+// configFile is a keycloak-config-cli file.
+type configFile struct {
+	name string
+	data []byte
+}
+
+// getFiles reads the files referenced by the import in the declared order.
+func (r *Reconcile) getFiles(ctx context.Context,
+	cliImport *keycloakApi.KeycloakConfigCliImport) ([]configFile, error) {
+	files := make([]configFile, 0, len(cliImport.Spec.Files))
+
+	for i, f := range cliImport.Spec.Files {
+		refs := 0
+
+		for _, set := range []bool{f.ConfigMapKeyRef != nil, f.SecretKeyRef != nil, f.ConfigMapName != ""} {
+			if set {
+				refs++
+			}
+		}
+
+		if refs != 1 {
+			return nil, errors.Errorf("exactly one of configMapKeyRef, secretKeyRef or configMapName must be set in file #%d",
+				i+1)
+		}
+
+		switch {
+		case f.ConfigMapKeyRef != nil:
+			cm, err := r.getConfigMap(ctx, cliImport.Namespace, f.ConfigMapKeyRef.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			data, ok := cm.Data[f.ConfigMapKeyRef.Key]
+			if !ok {
+				return nil, errors.Errorf("config map %s does not contain key %s", f.ConfigMapKeyRef.Name,
+					f.ConfigMapKeyRef.Key)
+			}
+
+			files = append(files, configFile{name: f.ConfigMapKeyRef.Name + "/" + f.ConfigMapKeyRef.Key, data: []byte(data)})
+		case f.SecretKeyRef != nil:
+			secret, err := r.getSecret(ctx, cliImport.Namespace, f.SecretKeyRef.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			data, ok := secret.Data[f.SecretKeyRef.Key]
+			if !ok {
+				return nil, errors.Errorf("secret %s does not contain key %s", f.SecretKeyRef.Name, f.SecretKeyRef.Key)
+			}
+
+			files = append(files, configFile{name: f.SecretKeyRef.Name + "/" + f.SecretKeyRef.Key, data: data})
+		default:
+			cm, err := r.getConfigMap(ctx, cliImport.Namespace, f.ConfigMapName)
+			if err != nil {
+				return nil, err
+			}
+
+			keys := make([]string, 0, len(cm.Data))
+			for k := range cm.Data {
+				keys = append(keys, k)
+			}
+
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				files = append(files, configFile{name: f.ConfigMapName + "/" + k, data: []byte(cm.Data[k])})
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// getVariables returns the substitution variables, the later sources override the earlier ones.
+func (r *Reconcile) getVariables(ctx context.Context,
+	cliImport *keycloakApi.KeycloakConfigCliImport) (map[string]string, error) {
+	spec := cliImport.Spec.VarSubstitution
+	vars := make(map[string]string)
+
+	for _, from := range spec.VariablesFrom {
+		switch {
+		case from.ConfigMapName != "" && from.SecretName != "":
+			return nil, errors.New("configMapName and secretName can not be used together in variablesFrom")
+		case from.ConfigMapName != "":
+			cm, err := r.getConfigMap(ctx, cliImport.Namespace, from.ConfigMapName)
+			if err != nil {
+				return nil, err
+			}
+
+			for k, v := range cm.Data {
+				vars[k] = v
+			}
+		case from.SecretName != "":
+			secret, err := r.getSecret(ctx, cliImport.Namespace, from.SecretName)
+			if err != nil {
+				return nil, err
+			}
+
+			for k, v := range secret.Data {
+				vars[k] = string(v)
+			}
+		default:
+			return nil, errors.New("configMapName or secretName must be set in variablesFrom")
+		}
+	}
+
+	for k, v := range spec.Variables {
+		vars[k] = v
+	}
+
+	return vars, nil
+}
+
+func (r *Reconcile) getConfigMap(ctx context.Context, namespace, name string) (*coreV1.ConfigMap, error) {
+	var cm coreV1.ConfigMap
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &cm); err != nil {
+		return nil, errors.Wrapf(err, "unable to get config map %s", name)
+	}
+
+	return &cm, nil
+}
+
+func (r *Reconcile) getSecret(ctx context.Context, namespace, name string) (*coreV1.Secret, error) {
+	var secret coreV1.Secret
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &secret); err != nil {
+		return nil, errors.Wrapf(err, "unable to get secret %s", name)
+	}
+
+	return &secret, nil
+}
+
+// substituteVariables replaces the $(NAME), $(env:NAME) and $(NAME:-default) variables with their values.
+// The undefined variables without a default value are an error as in keycloak-config-cli.
+func substituteVariables(data string, vars map[string]string) (string, error) {
+	var resultErr error
+
+	result := variableRegexp.ReplaceAllStringFunc(data, func(match string) string {
+		groups := variableRegexp.FindStringSubmatch(match)
+		if groups[1] != "" {
+			return "$(" + groups[2] + ")"
+		}
+
+		name, def, hasDef := strings.Cut(groups[2], variableDefaultMarker)
+		name = strings.TrimPrefix(name, envVariablePrefix)
+
+		if strings.Contains(name, ":") {
+			if resultErr == nil {
+				resultErr = errors.Errorf("unsupported variable lookup %s", groups[2])
+			}
+
+			return match
+		}
+
+		if value, ok := vars[name]; ok {
+			return value
+		}
+
+		if hasDef {
+			return def
+		}
+
+		if resultErr == nil {
+			resultErr = errors.Errorf("variable %s is not defined", name)
+		}
+
+		return match
+	})
+
+	return result, resultErr
+}
+
+// parseRepresentation converts the JSON or YAML file to the realm representation.
+// It returns the top level fields which are not imported by the partial import.
+func parseRepresentation(data []byte, realmName string) (map[string]interface{}, []string, error) {
+	jsonData, err := yaml.ToJSON(data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to convert file to json")
+	}
+
+	var representation map[string]interface{}
+	if err := json.Unmarshal(jsonData, &representation); err != nil {
+		return nil, nil, errors.Wrap(err, "file must contain a realm representation object")
+	}
+
+	if realm, ok := representation["realm"]; ok && realm != realmName {
+		return nil, nil, errors.Errorf("file realm %v does not match realm %s", realm, realmName)
+	}
+
+	unsupported := make([]string, 0)
+
+	for k := range representation {
+		if _, ok := importedFields[k]; !ok {
+			unsupported = append(unsupported, k)
+		}
+	}
+
+	return representation, unsupported, nil
+}
@@ -0,0 +1,185 @@
+package keycloakidentityprovidermapper
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const finalizerName = "keycloak.idpmapper.operator.finalizer.name"
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("keycloak-identity-provider-mapper"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakIdentityProviderMapper{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup keycloakIdentityProviderMapper controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakIdentityProviderMapper)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakIdentityProviderMapper)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakidentityprovidermappers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakidentityprovidermappers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakidentityprovidermappers/finalizers,verbs=update
+
+// Reconcile is a loop for reconciling KeycloakIdentityProviderMapper object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakIdentityProviderMapper")
+
+	var instance keycloakApi.KeycloakIdentityProviderMapper
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak idp mapper from k8s")
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		helper.SetErrorStatus(&instance, err)
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak idp mapper", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = errors.Wrap(err, "unable to update status")
+	}
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, idpMapper *keycloakApi.KeycloakIdentityProviderMapper) error {
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(idpMapper, &idpMapper.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	keycloakMapper := createKeycloakIDPMapperFromSpec(&idpMapper.Spec)
+
+	existing, err := getIDPMapperByName(ctx, kClient, realm.Spec.RealmName, idpMapper.Spec.IdentityProviderAlias,
+		idpMapper.Spec.Name)
+	if err != nil && !adapter.IsErrNotFound(err) {
+		return errors.Wrap(err, "unable to get idp mapper, unexpected error")
+	}
+
+	if err == nil {
+		keycloakMapper.ID = existing.ID
+
+		if err := kClient.UpdateIDPMapper(ctx, realm.Spec.RealmName, idpMapper.Spec.IdentityProviderAlias,
+			keycloakMapper); err != nil {
+			return errors.Wrap(err, "unable to update idp mapper")
+		}
+	} else {
+		if _, err := kClient.CreateIDPMapper(ctx, realm.Spec.RealmName, idpMapper.Spec.IdentityProviderAlias,
+			keycloakMapper); err != nil {
+			return errors.Wrap(err, "unable to create idp mapper")
+		}
+	}
+
+	term := makeTerminator(realm.Spec.RealmName, idpMapper.Spec.IdentityProviderAlias, idpMapper.Spec.Name, kClient,
+		r.log.WithName("idp-mapper-term"))
+	if _, err := r.helper.TryToDelete(ctx, idpMapper, term, finalizerName); err != nil {
+		return errors.Wrap(err, "unable to tryToDelete idp mapper")
+	}
+
+	return nil
+}
+
+func getIDPMapperByName(ctx context.Context, kClient keycloak.Client, realmName, idpAlias,
+	mapperName string) (*adapter.IdentityProviderMapper, error) {
+	mappers, err := kClient.GetIDPMappers(ctx, realmName, idpAlias)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get idp mappers")
+	}
+
+	for i := range mappers {
+		if mappers[i].Name == mapperName {
+			return &mappers[i], nil
+		}
+	}
+
+	return nil, adapter.NotFoundError("idp mapper not found")
+}
+
+func createKeycloakIDPMapperFromSpec(spec *keycloakApi.KeycloakIdentityProviderMapperSpec) *adapter.IdentityProviderMapper {
+	return &adapter.IdentityProviderMapper{
+		IdentityProviderAlias:  spec.IdentityProviderAlias,
+		IdentityProviderMapper: spec.IdentityProviderMapper,
+		Name:                   spec.Name,
+		Config:                 spec.Config,
+	}
+}
@@ -0,0 +1,110 @@
+package keycloakidentityprovidermapper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestReconcile_Reconcile(t *testing.T) {
+	logger := mock.NewLogr()
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	var (
+		hlp       helper.Mock
+		kcAdapter adapter.Mock
+		idpMapper = keycloakApi.KeycloakIdentityProviderMapper{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-mapper", Namespace: "ns"},
+			TypeMeta:   metav1.TypeMeta{Kind: "KeycloakIdentityProviderMapper", APIVersion: "v1.edp.epam.com/v1"},
+			Spec: keycloakApi.KeycloakIdentityProviderMapperSpec{
+				IdentityProviderAlias:  "idp1",
+				Name:                   "mapper1",
+				IdentityProviderMapper: "hardcoded-role-idp-mapper",
+				Config:                 map[string]string{"role": "developer"},
+			},
+			Status: keycloakApi.KeycloakIdentityProviderMapperStatus{Value: helper.StatusOK},
+		}
+		realm = keycloakApi.KeycloakRealm{TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1.edp.epam.com/v1", Kind: "KeycloakRealm",
+		},
+			ObjectMeta: metav1.ObjectMeta{Name: "realm1", Namespace: "ns",
+				OwnerReferences: []metav1.OwnerReference{{Name: "keycloak1", Kind: "Keycloak"}}},
+			Spec: keycloakApi.KeycloakRealmSpec{RealmName: "ns.realm1"}}
+	)
+
+	updateMapper := createKeycloakIDPMapperFromSpec(&idpMapper.Spec)
+	updateMapper.ID = "mapper-id1"
+
+	client := fake.NewClientBuilder().WithScheme(sch).WithRuntimeObjects(&idpMapper).Build()
+	hlp.On("GetOrCreateRealmOwnerRef", &idpMapper, &idpMapper.ObjectMeta).Return(&realm, nil)
+	hlp.On("CreateKeycloakClientForRealm", &realm).Return(&kcAdapter, nil)
+	kcAdapter.On("GetIDPMappers", realm.Spec.RealmName, "idp1").Return([]adapter.IdentityProviderMapper{
+		{ID: "mapper-id2", Name: "mapper2"},
+		{ID: "mapper-id1", Name: "mapper1"},
+	}, nil).Once()
+	kcAdapter.On("UpdateIDPMapper", realm.Spec.RealmName, "idp1", updateMapper).Return(nil)
+	hlp.On("TryToDelete", &idpMapper,
+		makeTerminator(realm.Spec.RealmName, "idp1", "mapper1", &kcAdapter, logger), finalizerName).
+		Return(false, nil)
+	hlp.On("UpdateStatus", &idpMapper).Return(nil)
+	r := NewReconcile(client, logger, &hlp)
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Name:      idpMapper.Name,
+		Namespace: idpMapper.Namespace,
+	}})
+	require.NoError(t, err)
+
+	loggerSink, ok := logger.GetSink().(*mock.Logger)
+	require.True(t, ok, "wrong logger type")
+	require.NoError(t, loggerSink.LastError())
+
+	if res.RequeueAfter != r.successReconcileTimeout {
+		t.Fatalf("wrong RequeueAfter: %d", res.RequeueAfter)
+	}
+
+	kcAdapter.On("GetIDPMappers", realm.Spec.RealmName, "idp1").
+		Return([]adapter.IdentityProviderMapper{}, nil).Once()
+	kcAdapter.On("CreateIDPMapper", realm.Spec.RealmName, "idp1",
+		createKeycloakIDPMapperFromSpec(&idpMapper.Spec)).Return("", errors.New("create fatal"))
+
+	failureMapper := idpMapper.DeepCopy()
+	failureMapper.Status.Value = "unable to create idp mapper: create fatal"
+	hlp.On("SetFailureCount", failureMapper).Return(time.Minute)
+	hlp.On("UpdateStatus", failureMapper).Return(nil)
+
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Name:      idpMapper.Name,
+		Namespace: idpMapper.Namespace,
+	}})
+	require.NoError(t, err)
+	require.Error(t, loggerSink.LastError())
+	assert.Equal(t, "unable to create idp mapper: create fatal", loggerSink.LastError().Error())
+}
+
+func TestIsSpecUpdated(t *testing.T) {
+	idpMapper := keycloakApi.KeycloakIdentityProviderMapper{}
+
+	if isSpecUpdated(event.UpdateEvent{ObjectNew: &idpMapper, ObjectOld: &idpMapper}) {
+		t.Fatal("spec is updated")
+	}
+}
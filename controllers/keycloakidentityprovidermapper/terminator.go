@@ -0,0 +1,57 @@
+package keycloakidentityprovidermapper
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type terminator struct {
+	realmName  string
+	idpAlias   string
+	mapperName string
+	kClient    keycloak.Client
+	log        logr.Logger
+}
+
+func makeTerminator(realmName, idpAlias, mapperName string, kClient keycloak.Client, log logr.Logger) *terminator {
+	return &terminator{
+		realmName:  realmName,
+		idpAlias:   idpAlias,
+		mapperName: mapperName,
+		kClient:    kClient,
+		log:        log,
+	}
+}
+
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := t.log.WithValues("keycloak idp mapper name", t.mapperName)
+	log.Info("Start deleting keycloak idp mapper...")
+
+	mapper, err := getIDPMapperByName(ctx, t.kClient, t.realmName, t.idpAlias, t.mapperName)
+	if err != nil {
+		if adapter.IsErrNotFound(err) {
+			log.Info("idp mapper is already deleted")
+
+			return nil
+		}
+
+		return errors.Wrap(err, "unable to get idp mapper")
+	}
+
+	if err := t.kClient.DeleteIDPMapper(ctx, t.realmName, t.idpAlias, mapper.ID); err != nil {
+		return errors.Wrap(err, "unable to delete idp mapper")
+	}
+
+	log.Info("idp mapper deletion done")
+
+	return nil
+}
+
+func (t *terminator) GetLogger() logr.Logger {
+	return t.log
+}
@@ -0,0 +1,37 @@
+package keycloakidentityprovidermapper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestTerminator_DeleteResource(t *testing.T) {
+	kClient := adapter.Mock{}
+	term := makeTerminator("realm", "idp1", "mapper1", &kClient, mock.NewLogr())
+
+	kClient.On("GetIDPMappers", "realm", "idp1").
+		Return([]adapter.IdentityProviderMapper{{ID: "id1", Name: "mapper1"}}, nil).Once()
+	kClient.On("DeleteIDPMapper", "realm", "idp1", "id1").Return(nil).Once()
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("GetIDPMappers", "realm", "idp1").
+		Return([]adapter.IdentityProviderMapper{}, nil).Once()
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("GetIDPMappers", "realm", "idp1").
+		Return([]adapter.IdentityProviderMapper{{ID: "id1", Name: "mapper1"}}, nil).Once()
+	kClient.On("DeleteIDPMapper", "realm", "idp1", "id1").Return(errors.New("delete fatal")).Once()
+
+	err := term.DeleteResource(context.Background())
+	require.Error(t, err)
+
+	if err.Error() != "unable to delete idp mapper: delete fatal" {
+		t.Fatalf("wrong error returned: %s", err.Error())
+	}
+}
@@ -0,0 +1,330 @@
+package keycloakldapfederation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const (
+	finalizerName = "keycloak.ldapfederation.operator.finalizer.name"
+
+	ldapProviderID          = "ldap"
+	userStorageProviderType = "org.keycloak.storage.UserStorageProvider"
+)
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("keycloak-ldap-federation"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakLDAPFederation{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup keycloakLDAPFederation controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakLDAPFederation)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakLDAPFederation)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+		helper.UserStorageSyncRequested(oo, no) ||
+		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakldapfederations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakldapfederations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakldapfederations/finalizers,verbs=update
+
+// Reconcile is a loop for reconciling KeycloakLDAPFederation object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakLDAPFederation")
+
+	var instance keycloakApi.KeycloakLDAPFederation
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak ldap federation from k8s")
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		helper.SetErrorStatus(&instance, err)
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak ldap federation", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+
+		if periodicSyncEnabled(&instance) {
+			if next := timeToNextSync(&instance); next < result.RequeueAfter {
+				result.RequeueAfter = next
+			}
+		}
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = errors.Wrap(err, "unable to update status")
+	}
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, federation *keycloakApi.KeycloakLDAPFederation) error {
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(federation, &federation.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	bindCredential, err := r.getBindCredential(ctx, federation)
+	if err != nil {
+		return err
+	}
+
+	keycloakComponent := createKeycloakComponentFromSpec(&federation.Spec, bindCredential)
+
+	cmp, err := kClient.GetComponent(ctx, realm.Spec.RealmName, federation.Spec.Name)
+	if err != nil && !adapter.IsErrNotFound(err) {
+		return errors.Wrap(err, "unable to get ldap federation, unexpected error")
+	}
+
+	if err == nil {
+		keycloakComponent.ID = cmp.ID
+
+		if err := kClient.UpdateComponent(ctx, realm.Spec.RealmName, keycloakComponent); err != nil {
+			return errors.Wrap(err, "unable to update ldap federation")
+		}
+	} else {
+		if err := kClient.CreateComponent(ctx, realm.Spec.RealmName, keycloakComponent); err != nil {
+			return errors.Wrap(err, "unable to create ldap federation")
+		}
+	}
+
+	syncStatus, err := helper.SyncUserStorageByAnnotation(
+		ctx, r.client, kClient, federation, realm.Spec.RealmName, federation.Spec.Name,
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to sync ldap federation users")
+	}
+
+	if syncStatus != nil {
+		setLastSync(federation, syncStatus)
+	}
+
+	if err := syncByPeriod(ctx, kClient, realm.Spec.RealmName, federation); err != nil {
+		return errors.Wrap(err, "unable to run periodic ldap federation users sync")
+	}
+
+	term := makeTerminator(realm.Spec.RealmName, federation.Spec.Name, kClient, r.log.WithName("ldap-federation-term"))
+	if _, err := r.helper.TryToDelete(ctx, federation, term, finalizerName); err != nil {
+		return errors.Wrap(err, "unable to tryToDelete ldap federation")
+	}
+
+	return nil
+}
+
+func (r *Reconcile) getBindCredential(ctx context.Context, federation *keycloakApi.KeycloakLDAPFederation) (string, error) {
+	ref := federation.Spec.BindCredential
+	if ref == nil {
+		return "", nil
+	}
+
+	var secret coreV1.Secret
+	if err := r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: federation.Namespace}, &secret); err != nil {
+		return "", errors.Wrapf(err, "unable to get bind credential secret %s", ref.Name)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("bind credential secret %s does not contain key %s", ref.Name, ref.Key)
+	}
+
+	return string(value), nil
+}
+
+func syncByPeriod(ctx context.Context, kClient keycloak.Client, realmName string, federation *keycloakApi.KeycloakLDAPFederation) error {
+	if !periodicSyncEnabled(federation) || timeToNextSync(federation) > 0 || !federation.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	mode := federation.Spec.SyncPeriodMode
+	if mode == "" {
+		mode = keycloakApi.UserStorageSyncChanged
+	}
+
+	status, err := helper.SyncUserStorage(ctx, kClient, realmName, federation.Spec.Name, mode)
+	if err != nil {
+		return errors.Wrap(err, "unable to sync users")
+	}
+
+	setLastSync(federation, status)
+
+	return nil
+}
+
+func periodicSyncEnabled(federation *keycloakApi.KeycloakLDAPFederation) bool {
+	return federation.Spec.SyncPeriod != nil && federation.Spec.SyncPeriod.Duration > 0
+}
+
+// timeToNextSync returns duration until the next periodic sync, zero means that the sync is due.
+func timeToNextSync(federation *keycloakApi.KeycloakLDAPFederation) time.Duration {
+	if federation.Status.LastSyncTime == nil {
+		return 0
+	}
+
+	next := time.Until(federation.Status.LastSyncTime.Add(federation.Spec.SyncPeriod.Duration))
+	if next < 0 {
+		return 0
+	}
+
+	return next
+}
+
+func setLastSync(federation *keycloakApi.KeycloakLDAPFederation, status *keycloakApi.UserStorageSyncStatus) {
+	federation.Status.LastSync = status
+	federation.Status.LastSyncTime = status.Time.DeepCopy()
+}
+
+func createKeycloakComponentFromSpec(spec *keycloakApi.KeycloakLDAPFederationSpec, bindCredential string) *adapter.Component {
+	config := make(map[string][]string, len(spec.Config))
+	for k, v := range spec.Config {
+		config[k] = v
+	}
+
+	setConfigValue(config, "connectionUrl", spec.ConnectionURL)
+	setConfigValue(config, "usersDn", spec.UsersDN)
+	setConfigValue(config, "vendor", spec.Vendor)
+	setConfigValue(config, "editMode", spec.EditMode)
+	setConfigValue(config, "usernameLDAPAttribute", spec.UsernameLDAPAttribute)
+	setConfigValue(config, "rdnLDAPAttribute", spec.RdnLDAPAttribute)
+	setConfigValue(config, "uuidLDAPAttribute", spec.UUIDLDAPAttribute)
+	setConfigValue(config, "userObjectClasses", strings.Join(spec.UserObjectClasses, ", "))
+	setConfigValue(config, "customUserSearchFilter", spec.CustomUserSearchFilter)
+	setConfigValue(config, "searchScope", searchScopeValue(spec.SearchScope))
+	setConfigValue(config, "connectionTimeout", spec.ConnectionTimeout)
+	config["startTls"] = []string{strconv.FormatBool(spec.StartTLS)}
+	config["pagination"] = []string{strconv.FormatBool(spec.Pagination)}
+	config["priority"] = []string{strconv.Itoa(spec.Priority)}
+	config["enabled"] = []string{strconv.FormatBool(spec.Enabled == nil || *spec.Enabled)}
+
+	if spec.BindDN != "" {
+		config["authType"] = []string{"simple"}
+		config["bindDn"] = []string{spec.BindDN}
+		config["bindCredential"] = []string{bindCredential}
+	} else {
+		config["authType"] = []string{"none"}
+	}
+
+	if spec.Sync != nil {
+		setSyncConfig(config, spec.Sync)
+	}
+
+	return &adapter.Component{
+		Name:         spec.Name,
+		Config:       config,
+		ProviderID:   ldapProviderID,
+		ProviderType: userStorageProviderType,
+	}
+}
+
+func setSyncConfig(config map[string][]string, sync *keycloakApi.LDAPSyncSettings) {
+	config["importEnabled"] = []string{strconv.FormatBool(sync.ImportEnabled == nil || *sync.ImportEnabled)}
+	config["syncRegistrations"] = []string{strconv.FormatBool(sync.SyncRegistrations)}
+
+	if sync.BatchSize > 0 {
+		config["batchSizeForSync"] = []string{strconv.Itoa(sync.BatchSize)}
+	}
+
+	if sync.FullSyncPeriod != 0 {
+		config["fullSyncPeriod"] = []string{strconv.Itoa(sync.FullSyncPeriod)}
+	}
+
+	if sync.ChangedSyncPeriod != 0 {
+		config["changedSyncPeriod"] = []string{strconv.Itoa(sync.ChangedSyncPeriod)}
+	}
+}
+
+func setConfigValue(config map[string][]string, key, value string) {
+	if value != "" {
+		config[key] = []string{value}
+	}
+}
+
+func searchScopeValue(scope string) string {
+	switch scope {
+	case "OneLevel":
+		return "1"
+	case "Subtree":
+		return "2"
+	default:
+		return ""
+	}
+}
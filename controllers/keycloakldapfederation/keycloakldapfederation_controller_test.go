@@ -0,0 +1,218 @@
+package keycloakldapfederation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestReconcile_Reconcile(t *testing.T) {
+	logger := mock.NewLogr()
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	var (
+		hlp        helper.Mock
+		kcAdapter  adapter.Mock
+		federation = keycloakApi.KeycloakLDAPFederation{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ldap", Namespace: "ns"},
+			TypeMeta:   metav1.TypeMeta{Kind: "KeycloakLDAPFederation", APIVersion: "v1.edp.epam.com/v1"},
+			Spec: keycloakApi.KeycloakLDAPFederationSpec{
+				Name:           "ldap",
+				ConnectionURL:  "ldap://ldap.example.com",
+				UsersDN:        "ou=users,dc=example,dc=com",
+				BindDN:         "cn=admin,dc=example,dc=com",
+				BindCredential: &keycloakApi.SecretKeyRef{Name: "ldap-secret", Key: "password"},
+			},
+			Status: keycloakApi.KeycloakLDAPFederationStatus{Value: helper.StatusOK},
+		}
+		secret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ldap-secret", Namespace: "ns"},
+			Data:       map[string][]byte{"password": []byte("pass")},
+		}
+		realm = keycloakApi.KeycloakRealm{TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1.edp.epam.com/v1", Kind: "KeycloakRealm",
+		},
+			ObjectMeta: metav1.ObjectMeta{Name: "realm1", Namespace: "ns",
+				OwnerReferences: []metav1.OwnerReference{{Name: "keycloak1", Kind: "Keycloak"}}},
+			Spec: keycloakApi.KeycloakRealmSpec{RealmName: "ns.realm1"}}
+	)
+
+	testComp := createKeycloakComponentFromSpec(&federation.Spec, "pass")
+	testComp.ID = "component-id1"
+
+	client := fake.NewClientBuilder().WithScheme(sch).WithRuntimeObjects(&federation, &secret).Build()
+	hlp.On("GetOrCreateRealmOwnerRef", &federation, &federation.ObjectMeta).Return(&realm, nil)
+	hlp.On("CreateKeycloakClientForRealm", &realm).Return(&kcAdapter, nil)
+	kcAdapter.On("GetComponent", realm.Spec.RealmName, federation.Spec.Name).
+		Return(&adapter.Component{ID: "component-id1", Name: federation.Spec.Name}, nil).Once()
+	kcAdapter.On("UpdateComponent", realm.Spec.RealmName, testComp).Return(nil)
+	hlp.On("TryToDelete", &federation, makeTerminator(realm.Spec.RealmName, federation.Spec.Name, &kcAdapter, logger),
+		finalizerName).Return(false, nil)
+	hlp.On("UpdateStatus", &federation).Return(nil)
+	r := NewReconcile(client, logger, &hlp)
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Name:      federation.Name,
+		Namespace: federation.Namespace,
+	}})
+	require.NoError(t, err)
+
+	loggerSink, ok := logger.GetSink().(*mock.Logger)
+	require.True(t, ok, "wrong logger type")
+	require.NoError(t, loggerSink.LastError())
+
+	if res.RequeueAfter != r.successReconcileTimeout {
+		t.Fatalf("wrong RequeueAfter: %d", res.RequeueAfter)
+	}
+
+	kcAdapter.On("GetComponent", realm.Spec.RealmName, federation.Spec.Name).Return(nil,
+		adapter.NotFoundError("not found")).Once()
+	kcAdapter.On("CreateComponent", realm.Spec.RealmName,
+		createKeycloakComponentFromSpec(&federation.Spec, "pass")).Return(errors.New("create fatal"))
+
+	failureFederation := federation.DeepCopy()
+	failureFederation.Status.Value = "unable to create ldap federation: create fatal"
+	hlp.On("SetFailureCount", failureFederation).Return(time.Minute)
+	hlp.On("UpdateStatus", failureFederation).Return(nil)
+
+	_, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Name:      federation.Name,
+		Namespace: federation.Namespace,
+	}})
+	require.NoError(t, err)
+	require.Error(t, loggerSink.LastError())
+	assert.Equal(t, "unable to create ldap federation: create fatal", loggerSink.LastError().Error())
+}
+
+func TestReconcile_getBindCredential(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ldap-secret", Namespace: "ns"},
+		Data:       map[string][]byte{"password": []byte("pass")},
+	}
+	r := NewReconcile(fake.NewClientBuilder().WithScheme(sch).WithObjects(&secret).Build(), mock.NewLogr(), nil)
+
+	federation := keycloakApi.KeycloakLDAPFederation{ObjectMeta: metav1.ObjectMeta{Namespace: "ns"}}
+
+	cred, err := r.getBindCredential(context.Background(), &federation)
+	require.NoError(t, err)
+	assert.Empty(t, cred)
+
+	federation.Spec.BindCredential = &keycloakApi.SecretKeyRef{Name: "ldap-secret", Key: "password"}
+	cred, err = r.getBindCredential(context.Background(), &federation)
+	require.NoError(t, err)
+	assert.Equal(t, "pass", cred)
+
+	federation.Spec.BindCredential.Key = "wrong"
+	_, err = r.getBindCredential(context.Background(), &federation)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not contain key wrong")
+
+	federation.Spec.BindCredential.Name = "missing"
+	_, err = r.getBindCredential(context.Background(), &federation)
+	require.Error(t, err)
+}
+
+func TestCreateKeycloakComponentFromSpec(t *testing.T) {
+	disabled := false
+	spec := keycloakApi.KeycloakLDAPFederationSpec{
+		Name:              "ldap",
+		ConnectionURL:     "ldap://ldap.example.com",
+		UsersDN:           "ou=users",
+		EditMode:          "WRITABLE",
+		UserObjectClasses: []string{"inetOrgPerson", "organizationalPerson"},
+		SearchScope:       "Subtree",
+		Sync: &keycloakApi.LDAPSyncSettings{
+			ImportEnabled:  &disabled,
+			BatchSize:      500,
+			FullSyncPeriod: 3600,
+		},
+		Config: map[string][]string{"debug": {"true"}},
+	}
+
+	cmp := createKeycloakComponentFromSpec(&spec, "")
+
+	assert.Equal(t, "ldap", cmp.ProviderID)
+	assert.Equal(t, "org.keycloak.storage.UserStorageProvider", cmp.ProviderType)
+	assert.Equal(t, []string{"none"}, cmp.Config["authType"])
+	assert.Equal(t, []string{"WRITABLE"}, cmp.Config["editMode"])
+	assert.Equal(t, []string{"inetOrgPerson, organizationalPerson"}, cmp.Config["userObjectClasses"])
+	assert.Equal(t, []string{"2"}, cmp.Config["searchScope"])
+	assert.Equal(t, []string{"false"}, cmp.Config["importEnabled"])
+	assert.Equal(t, []string{"500"}, cmp.Config["batchSizeForSync"])
+	assert.Equal(t, []string{"3600"}, cmp.Config["fullSyncPeriod"])
+	assert.Equal(t, []string{"true"}, cmp.Config["enabled"])
+	assert.Equal(t, []string{"true"}, cmp.Config["debug"])
+	assert.NotContains(t, cmp.Config, "bindCredential")
+	assert.NotContains(t, cmp.Config, "changedSyncPeriod")
+	assert.NotContains(t, spec.Config, "authType", "spec config must not be modified")
+}
+
+func TestSyncByPeriod(t *testing.T) {
+	var kcAdapter adapter.Mock
+
+	federation := keycloakApi.KeycloakLDAPFederation{
+		Spec: keycloakApi.KeycloakLDAPFederationSpec{Name: "ldap"},
+	}
+
+	require.NoError(t, syncByPeriod(context.Background(), &kcAdapter, "realm", &federation))
+	assert.Nil(t, federation.Status.LastSync, "sync must not be triggered without period")
+
+	federation.Spec.SyncPeriod = &metav1.Duration{Duration: time.Hour}
+	federation.Spec.SyncPeriodMode = keycloakApi.UserStorageSyncFull
+
+	kcAdapter.On("GetComponent", "realm", "ldap").Return(&adapter.Component{ID: "cmp-id"}, nil)
+	kcAdapter.On("SyncUserStorage", "realm", "cmp-id", adapter.UserStorageFullSync).
+		Return(&adapter.UserStorageSyncResult{Updated: 5}, nil).Once()
+
+	require.NoError(t, syncByPeriod(context.Background(), &kcAdapter, "realm", &federation))
+	require.NotNil(t, federation.Status.LastSync)
+	require.NotNil(t, federation.Status.LastSyncTime)
+	assert.Equal(t, 5, federation.Status.LastSync.Updated)
+
+	next := timeToNextSync(&federation)
+	assert.True(t, next > 59*time.Minute && next <= time.Hour, "wrong next sync duration: %s", next)
+
+	require.NoError(t, syncByPeriod(context.Background(), &kcAdapter, "realm", &federation))
+	kcAdapter.AssertNumberOfCalls(t, "SyncUserStorage", 1)
+
+	lastSync := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	federation.Status.LastSyncTime = &lastSync
+	assert.Equal(t, time.Duration(0), timeToNextSync(&federation))
+
+	kcAdapter.On("SyncUserStorage", "realm", "cmp-id", adapter.UserStorageFullSync).
+		Return(nil, errors.New("sync fatal")).Once()
+
+	err := syncByPeriod(context.Background(), &kcAdapter, "realm", &federation)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sync fatal")
+}
+
+func TestIsSpecUpdated(t *testing.T) {
+	federation := keycloakApi.KeycloakLDAPFederation{}
+
+	if isSpecUpdated(event.UpdateEvent{ObjectNew: &federation, ObjectOld: &federation}) {
+		t.Fatal("spec is updated")
+	}
+}
@@ -0,0 +1,50 @@
+package keycloakldapfederation
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type terminator struct {
+	realmName      string
+	federationName string
+	kClient        keycloak.Client
+	log            logr.Logger
+}
+
+func makeTerminator(realmName, federationName string, kClient keycloak.Client, log logr.Logger) *terminator {
+	return &terminator{
+		realmName:      realmName,
+		federationName: federationName,
+		kClient:        kClient,
+		log:            log,
+	}
+}
+
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := t.log.WithValues("keycloak ldap federation name", t.federationName)
+	log.Info("Start deleting keycloak ldap federation...")
+
+	if err := t.kClient.DeleteComponent(ctx, t.realmName, t.federationName); err != nil {
+		if adapter.IsErrNotFound(err) {
+			log.Info("ldap federation is already deleted")
+
+			return nil
+		}
+
+		return errors.Wrap(err, "unable to delete ldap federation")
+	}
+
+	log.Info("ldap federation deletion done")
+
+	return nil
+}
+
+func (t *terminator) GetLogger() logr.Logger {
+	return t.log
+}
@@ -0,0 +1,32 @@
+package keycloakldapfederation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestTerminator_DeleteResource(t *testing.T) {
+	var (
+		kcAdapter adapter.Mock
+	)
+
+	kcAdapter.On("DeleteComponent", "foo", "bar").Return(nil).Once()
+	term := makeTerminator("foo", "bar", &kcAdapter, mock.NewLogr())
+	err := term.DeleteResource(context.Background())
+	require.NoError(t, err)
+
+	kcAdapter.On("DeleteComponent", "foo", "bar").
+		Return(errors.Wrap(adapter.NotFoundError("component not found"), "unable to get component id")).Once()
+	err = term.DeleteResource(context.Background())
+	require.NoError(t, err)
+
+	kcAdapter.On("DeleteComponent", "foo", "bar").Return(errors.New("fatal")).Once()
+	err = term.DeleteResource(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,256 @@
+package keycloakorganization
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const finalizerName = "keycloak.organization.operator.finalizer.name"
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("keycloak-organization"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: helper.IsFailuresUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakOrganization{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakOrganization controller: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakorganizations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakorganizations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakorganizations/finalizers,verbs=update
+
+// Reconcile is a loop for reconciling KeycloakOrganization object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakOrganization")
+
+	var instance keycloakApi.KeycloakOrganization
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("instance not found")
+
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak organization from k8s")
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		helper.SetErrorStatus(&instance, err)
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak organization", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = errors.Wrap(err, "unable to update status")
+	}
+
+	log.Info("Reconciling KeycloakOrganization done")
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, organization *keycloakApi.KeycloakOrganization) error {
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(organization, &organization.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	if err := checkOrganizationsSupport(ctx, kClient); err != nil {
+		return err
+	}
+
+	realmName := realm.Spec.RealmName
+
+	id, err := r.putOrganization(ctx, kClient, realmName, organization)
+	if err != nil {
+		return err
+	}
+
+	organization.Status.ID = id
+
+	if err := syncOrganizationLinks(ctx, kClient, realmName, id, &organization.Spec); err != nil {
+		return err
+	}
+
+	term := makeTerminator(realmName, id, kClient, r.log.WithName("organization-term"))
+	if _, err := r.helper.TryToDelete(ctx, organization, term, finalizerName); err != nil {
+		return errors.Wrap(err, "unable to delete organization")
+	}
+
+	return nil
+}
+
+// syncOrganizationLinks syncs the identity providers and the members of the organization,
+// the lists which are not set are not managed.
+func syncOrganizationLinks(ctx context.Context, kClient keycloak.Client, realmName, id string,
+	spec *keycloakApi.KeycloakOrganizationSpec) error {
+	if spec.IdentityProviders != nil {
+		aliases := make([]string, 0, len(spec.IdentityProviders))
+		for _, idp := range spec.IdentityProviders {
+			aliases = append(aliases, idp.Alias)
+		}
+
+		if err := kClient.SyncOrganizationIdentityProviders(ctx, realmName, id, aliases); err != nil {
+			return errors.Wrap(err, "unable to sync organization identity providers")
+		}
+	}
+
+	if spec.Members != nil {
+		if err := kClient.SyncOrganizationMembers(ctx, realmName, id, spec.Members); err != nil {
+			return errors.Wrap(err, "unable to sync organization members")
+		}
+	}
+
+	return nil
+}
+
+// putOrganization creates or updates the organization and returns its id.
+// The organization is looked up by the id from the status and by the name if it is not found.
+func (r *Reconcile) putOrganization(ctx context.Context, kClient keycloak.Client, realmName string,
+	organization *keycloakApi.KeycloakOrganization) (string, error) {
+	org := makeOrganization(&organization.Spec)
+
+	current, err := r.getOrganization(ctx, kClient, realmName, organization)
+	if err != nil {
+		if !adapter.IsErrNotFound(err) {
+			return "", err
+		}
+
+		id, err := kClient.CreateOrganization(ctx, realmName, org)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to create organization")
+		}
+
+		return id, nil
+	}
+
+	org.ID = current.ID
+	if org.Alias == "" {
+		org.Alias = current.Alias
+	}
+
+	if err := kClient.UpdateOrganization(ctx, realmName, org); err != nil {
+		return "", errors.Wrap(err, "unable to update organization")
+	}
+
+	return current.ID, nil
+}
+
+func (r *Reconcile) getOrganization(ctx context.Context, kClient keycloak.Client, realmName string,
+	organization *keycloakApi.KeycloakOrganization) (*adapter.Organization, error) {
+	if organization.Status.ID != "" {
+		current, err := kClient.GetOrganization(ctx, realmName, organization.Status.ID)
+		if err == nil {
+			return current, nil
+		}
+
+		if !adapter.IsErrNotFound(err) {
+			return nil, errors.Wrap(err, "unable to get organization")
+		}
+	}
+
+	current, err := kClient.GetOrganizationByName(ctx, realmName, organization.Spec.Name)
+	if err != nil && !adapter.IsErrNotFound(err) {
+		return nil, errors.Wrap(err, "unable to get organization by name")
+	}
+
+	return current, err
+}
+
+// checkOrganizationsSupport checks that the connected keycloak supports the organizations.
+func checkOrganizationsSupport(ctx context.Context, kClient keycloak.Client) error {
+	version, err := kClient.GetServerVersion(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to get keycloak version")
+	}
+
+	major, err := adapter.ServerMajorVersion(version)
+	if err != nil {
+		return err
+	}
+
+	if major < adapter.OrganizationsMinKCVersion {
+		return errors.Errorf("organizations require keycloak %d or newer, connected keycloak version is %s",
+			adapter.OrganizationsMinKCVersion, version)
+	}
+
+	return nil
+}
+
+func makeOrganization(spec *keycloakApi.KeycloakOrganizationSpec) *adapter.Organization {
+	org := adapter.Organization{
+		Name:        spec.Name,
+		Alias:       spec.Alias,
+		Enabled:     spec.Enabled == nil || *spec.Enabled,
+		Description: spec.Description,
+		RedirectURL: spec.RedirectURL,
+		Attributes:  spec.Attributes,
+		Domains:     make([]adapter.OrganizationDomain, 0, len(spec.Domains)),
+	}
+
+	for _, d := range spec.Domains {
+		org.Domains = append(org.Domains, adapter.OrganizationDomain{Name: d.Name, Verified: d.Verified})
+	}
+
+	return &org
+}
@@ -0,0 +1,199 @@
+package keycloakorganization
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func getTestOrganization() *keycloakApi.KeycloakOrganization {
+	return &keycloakApi.KeycloakOrganization{
+		ObjectMeta: metav1.ObjectMeta{Name: "acme", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakOrganizationSpec{
+			Realm:             "realm",
+			Name:              "acme",
+			Domains:           []keycloakApi.OrganizationDomain{{Name: "acme.example.com", Verified: true}},
+			Attributes:        map[string][]string{"region": {"eu"}},
+			IdentityProviders: []keycloakApi.OrganizationIdentityProvider{{Alias: "acme-saml"}},
+			Members:           []string{"john.doe"},
+		},
+	}
+}
+
+func reconcileOrganization(t *testing.T, org *keycloakApi.KeycloakOrganization,
+	kClient *adapter.Mock) (*keycloakApi.KeycloakOrganization, reconcile.Result) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec:       keycloakApi.KeycloakRealmSpec{RealmName: "realm1"},
+	}
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
+	h.On("TryToDelete", testifyMock.Anything, testifyMock.Anything, finalizerName).Return(false, nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := Reconcile{
+		client:                  fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(org).Build(),
+		log:                     mock.NewLogr(),
+		helper:                  &h,
+		successReconcileTimeout: time.Hour,
+	}
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: org.Name, Namespace: org.Namespace},
+	})
+	require.NoError(t, err)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakOrganization)
+	require.True(t, ok)
+
+	return updated, res
+}
+
+func TestReconcile_Reconcile_Create(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("GetServerVersion").Return("26.0.5", nil)
+	kClient.On("GetOrganizationByName", "realm1", "acme").
+		Return(nil, adapter.NotFoundError("organization not found"))
+	kClient.On("CreateOrganization", "realm1", &adapter.Organization{
+		Name:       "acme",
+		Enabled:    true,
+		Attributes: map[string][]string{"region": {"eu"}},
+		Domains:    []adapter.OrganizationDomain{{Name: "acme.example.com", Verified: true}},
+	}).Return("org-id", nil)
+	kClient.On("SyncOrganizationIdentityProviders", "realm1", "org-id", []string{"acme-saml"}).Return(nil)
+	kClient.On("SyncOrganizationMembers", "realm1", "org-id", []string{"john.doe"}).Return(nil)
+
+	updated, res := reconcileOrganization(t, getTestOrganization(), kClient)
+
+	require.Equal(t, time.Hour, res.RequeueAfter)
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	require.Equal(t, "org-id", updated.Status.ID)
+	kClient.AssertExpectations(t)
+}
+
+func TestReconcile_Reconcile_Update(t *testing.T) {
+	org := getTestOrganization()
+	org.Status.ID = "old-id"
+	disabled := false
+	org.Spec.Enabled = &disabled
+	org.Spec.IdentityProviders = nil
+	org.Spec.Members = nil
+
+	kClient := new(adapter.Mock)
+	kClient.On("GetServerVersion").Return("26.1.0", nil)
+	kClient.On("GetOrganization", "realm1", "old-id").Return(nil, adapter.NotFoundError("organization not found"))
+	kClient.On("GetOrganizationByName", "realm1", "acme").
+		Return(&adapter.Organization{ID: "org-id", Name: "acme", Alias: "acme"}, nil)
+	kClient.On("UpdateOrganization", "realm1", &adapter.Organization{
+		ID:         "org-id",
+		Name:       "acme",
+		Alias:      "acme",
+		Attributes: map[string][]string{"region": {"eu"}},
+		Domains:    []adapter.OrganizationDomain{{Name: "acme.example.com", Verified: true}},
+	}).Return(nil)
+
+	updated, _ := reconcileOrganization(t, org, kClient)
+
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	require.Equal(t, "org-id", updated.Status.ID)
+	kClient.AssertExpectations(t)
+	kClient.AssertNotCalled(t, "SyncOrganizationIdentityProviders", testifyMock.Anything, testifyMock.Anything,
+		testifyMock.Anything)
+	kClient.AssertNotCalled(t, "SyncOrganizationMembers", testifyMock.Anything, testifyMock.Anything,
+		testifyMock.Anything)
+}
+
+func TestReconcile_Reconcile_EmptyLinks(t *testing.T) {
+	org := getTestOrganization()
+	org.Status.ID = "org-id"
+	org.Spec.IdentityProviders = []keycloakApi.OrganizationIdentityProvider{}
+	org.Spec.Members = []string{}
+
+	kClient := new(adapter.Mock)
+	kClient.On("GetServerVersion").Return("26.1.0", nil)
+	kClient.On("GetOrganization", "realm1", "org-id").
+		Return(&adapter.Organization{ID: "org-id", Name: "acme", Alias: "acme"}, nil)
+	kClient.On("UpdateOrganization", "realm1", testifyMock.Anything).Return(nil)
+	kClient.On("SyncOrganizationIdentityProviders", "realm1", "org-id", []string{}).Return(nil)
+	kClient.On("SyncOrganizationMembers", "realm1", "org-id", []string{}).Return(nil)
+
+	updated, _ := reconcileOrganization(t, org, kClient)
+
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	kClient.AssertExpectations(t)
+}
+
+func TestReconcile_Reconcile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(kClient *adapter.Mock)
+		wantErr string
+	}{
+		{
+			name: "unsupported keycloak version",
+			prepare: func(kClient *adapter.Mock) {
+				kClient.On("GetServerVersion").Return("25.0.6", nil)
+			},
+			wantErr: "organizations require keycloak 26 or newer, connected keycloak version is 25.0.6",
+		},
+		{
+			name: "unable to create organization",
+			prepare: func(kClient *adapter.Mock) {
+				kClient.On("GetServerVersion").Return("26.0.5", nil)
+				kClient.On("GetOrganizationByName", "realm1", "acme").
+					Return(nil, adapter.NotFoundError("organization not found"))
+				kClient.On("CreateOrganization", "realm1", testifyMock.Anything).Return("", errors.New("fatal"))
+			},
+			wantErr: "unable to create organization: fatal",
+		},
+		{
+			name: "unable to sync members",
+			prepare: func(kClient *adapter.Mock) {
+				kClient.On("GetServerVersion").Return("26.0.5", nil)
+				kClient.On("GetOrganizationByName", "realm1", "acme").
+					Return(&adapter.Organization{ID: "org-id", Name: "acme"}, nil)
+				kClient.On("UpdateOrganization", "realm1", testifyMock.Anything).Return(nil)
+				kClient.On("SyncOrganizationIdentityProviders", "realm1", "org-id", testifyMock.Anything).Return(nil)
+				kClient.On("SyncOrganizationMembers", "realm1", "org-id", testifyMock.Anything).
+					Return(errors.New("user john.doe does not exist"))
+			},
+			wantErr: "unable to sync organization members: user john.doe does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			kClient := new(adapter.Mock)
+			tt.prepare(kClient)
+
+			updated, res := reconcileOrganization(t, getTestOrganization(), kClient)
+
+			require.Equal(t, time.Minute, res.RequeueAfter)
+			require.Equal(t, tt.wantErr, updated.Status.Value)
+		})
+	}
+}
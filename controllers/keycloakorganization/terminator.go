@@ -0,0 +1,48 @@
+package keycloakorganization
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type terminator struct {
+	realmName string
+	orgID     string
+	kClient   keycloak.Client
+	log       logr.Logger
+}
+
+func makeTerminator(realmName, orgID string, kClient keycloak.Client, log logr.Logger) *terminator {
+	return &terminator{
+		realmName: realmName,
+		orgID:     orgID,
+		kClient:   kClient,
+		log:       log,
+	}
+}
+
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := t.log.WithValues("keycloak organization id", t.orgID)
+	log.Info("Start deleting keycloak organization...")
+
+	if err := t.kClient.DeleteOrganization(ctx, t.realmName, t.orgID); err != nil {
+		if !adapter.IsErrNotFound(err) {
+			return errors.Wrap(err, "unable to delete organization")
+		}
+
+		log.Info("Organization does not exist in keycloak")
+	}
+
+	log.Info("Organization deletion done")
+
+	return nil
+}
+
+func (t *terminator) GetLogger() logr.Logger {
+	return t.log
+}
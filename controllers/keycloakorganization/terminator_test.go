@@ -0,0 +1,27 @@
+package keycloakorganization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestTerminator_DeleteResource(t *testing.T) {
+	kClient := new(adapter.Mock)
+	term := makeTerminator("realm1", "org-id", kClient, mock.NewLogr())
+
+	kClient.On("DeleteOrganization", "realm1", "org-id").Return(nil).Once()
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("DeleteOrganization", "realm1", "org-id").
+		Return(adapter.NotFoundError("organization not found")).Once()
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("DeleteOrganization", "realm1", "org-id").Return(errors.New("fatal")).Once()
+	require.Error(t, term.DeleteResource(context.Background()))
+}
@@ -0,0 +1,243 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// PutClientPolicies syncs the realm client profiles and client policies,
+// they are updated only if they differ from the keycloak ones.
+type PutClientPolicies struct {
+	next handler.RealmHandler
+}
+
+func (h PutClientPolicies) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm,
+	kClient keycloak.Client) error {
+	spec := realm.Spec.ClientPolicies
+	if spec == nil || (spec.Profiles == nil && spec.Policies == nil) {
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+	rLog.Info("Start putting realm client policies")
+
+	profiles, err := makeClientProfiles(spec.Profiles)
+	if err != nil {
+		return err
+	}
+
+	policies, err := makeClientPolicies(spec.Policies)
+	if err != nil {
+		return err
+	}
+
+	if err := syncClientPolicies(ctx, kClient, realm.Spec.RealmName, profiles, policies); err != nil {
+		return err
+	}
+
+	rLog.Info("End putting realm client policies")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+// syncClientPolicies updates the client profiles and the client policies, the nil ones are not managed.
+// Keycloak rejects removal of the profiles used by the policies and the policies with unknown profiles,
+// so the removed profiles which are used by the current policies are kept until the policies are updated.
+func syncClientPolicies(ctx context.Context, kClient keycloak.Client, realmName string,
+	profiles *adapter.ClientProfiles, policies *adapter.ClientPolicies) error {
+	currentPolicies, err := kClient.GetClientPolicies(ctx, realmName)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm client policies")
+	}
+
+	var interimProfiles *adapter.ClientProfiles
+
+	if profiles != nil {
+		currentProfiles, err := kClient.GetClientProfiles(ctx, realmName)
+		if err != nil {
+			return errors.Wrap(err, "unable to get realm client profiles")
+		}
+
+		interimProfiles = profiles
+		if policies != nil {
+			interimProfiles = withUsedClientProfiles(profiles, currentProfiles, currentPolicies)
+		}
+
+		if err := updateIfChanged(currentProfiles, interimProfiles, func() error {
+			return kClient.UpdateClientProfiles(ctx, realmName, interimProfiles)
+		}); err != nil {
+			return errors.Wrap(err, "unable to update realm client profiles")
+		}
+	}
+
+	if policies != nil {
+		if err := updateIfChanged(currentPolicies, policies, func() error {
+			return kClient.UpdateClientPolicies(ctx, realmName, policies)
+		}); err != nil {
+			return errors.Wrap(err, "unable to update realm client policies")
+		}
+	}
+
+	if profiles != nil && len(interimProfiles.Profiles) != len(profiles.Profiles) {
+		if err := kClient.UpdateClientProfiles(ctx, realmName, profiles); err != nil {
+			return errors.Wrap(err, "unable to remove unused realm client profiles")
+		}
+	}
+
+	return nil
+}
+
+// withUsedClientProfiles adds the undeclared current profiles used by the current policies to the declared ones.
+func withUsedClientProfiles(declared, current *adapter.ClientProfiles,
+	policies *adapter.ClientPolicies) *adapter.ClientProfiles {
+	used := make(map[string]struct{})
+
+	for i := range policies.Policies {
+		for _, name := range policies.Policies[i].Profiles {
+			used[name] = struct{}{}
+		}
+	}
+
+	for i := range declared.Profiles {
+		delete(used, declared.Profiles[i].Name)
+	}
+
+	if len(used) == 0 {
+		return declared
+	}
+
+	result := adapter.ClientProfiles{Profiles: append([]adapter.ClientProfile{}, declared.Profiles...)}
+
+	for i := range current.Profiles {
+		if _, ok := used[current.Profiles[i].Name]; ok {
+			result.Profiles = append(result.Profiles, current.Profiles[i])
+		}
+	}
+
+	return &result
+}
+
+// updateIfChanged calls the update if the json representations of the current and the desired values differ.
+func updateIfChanged(current, desired interface{}, update func() error) error {
+	currentData, err := normalizeJSON(current)
+	if err != nil {
+		return err
+	}
+
+	desiredData, err := normalizeJSON(desired)
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(currentData, desiredData) {
+		return nil
+	}
+
+	return update()
+}
+
+func makeClientProfiles(spec []keycloakApi.ClientProfile) (*adapter.ClientProfiles, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	profiles := adapter.ClientProfiles{Profiles: make([]adapter.ClientProfile, 0, len(spec))}
+	declared := make(map[string]struct{}, len(spec))
+
+	for i := range spec {
+		if _, ok := declared[spec[i].Name]; ok {
+			return nil, errors.Errorf("client profile %s is declared more than once", spec[i].Name)
+		}
+
+		declared[spec[i].Name] = struct{}{}
+
+		profile := adapter.ClientProfile{
+			Name:        spec[i].Name,
+			Description: spec[i].Description,
+			Executors:   make([]adapter.ClientPolicyExecutor, 0, len(spec[i].Executors)),
+		}
+
+		for _, e := range spec[i].Executors {
+			cfg, err := decodeJSONConfig(e.Configuration)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid configuration of the executor %s of the client profile %s",
+					e.Executor, spec[i].Name)
+			}
+
+			profile.Executors = append(profile.Executors, adapter.ClientPolicyExecutor{
+				Executor:      e.Executor,
+				Configuration: cfg,
+			})
+		}
+
+		profiles.Profiles = append(profiles.Profiles, profile)
+	}
+
+	return &profiles, nil
+}
+
+func makeClientPolicies(spec []keycloakApi.ClientPolicy) (*adapter.ClientPolicies, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	policies := adapter.ClientPolicies{Policies: make([]adapter.ClientPolicy, 0, len(spec))}
+	declared := make(map[string]struct{}, len(spec))
+
+	for i := range spec {
+		if _, ok := declared[spec[i].Name]; ok {
+			return nil, errors.Errorf("client policy %s is declared more than once", spec[i].Name)
+		}
+
+		declared[spec[i].Name] = struct{}{}
+
+		policy := adapter.ClientPolicy{
+			Name:        spec[i].Name,
+			Description: spec[i].Description,
+			Enabled:     spec[i].Enabled == nil || *spec[i].Enabled,
+			Conditions:  make([]adapter.ClientPolicyCondition, 0, len(spec[i].Conditions)),
+			Profiles:    append([]string{}, spec[i].Profiles...),
+		}
+
+		for _, c := range spec[i].Conditions {
+			cfg, err := decodeJSONConfig(c.Configuration)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid configuration of the condition %s of the client policy %s",
+					c.Condition, spec[i].Name)
+			}
+
+			policy.Conditions = append(policy.Conditions, adapter.ClientPolicyCondition{
+				Condition:     c.Condition,
+				Configuration: cfg,
+			})
+		}
+
+		policies.Policies = append(policies.Policies, policy)
+	}
+
+	return &policies, nil
+}
+
+func decodeJSONConfig(spec map[string]apiextensionsv1.JSON) (map[string]interface{}, error) {
+	cfg := make(map[string]interface{}, len(spec))
+
+	for k, v := range spec {
+		var value interface{}
+		if err := json.Unmarshal(v.Raw, &value); err != nil {
+			return nil, errors.Wrapf(err, "unable to decode %s", k)
+		}
+
+		cfg[k] = value
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,115 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func testClientPoliciesRealm() *keycloakApi.KeycloakRealm {
+	return &keycloakApi.KeycloakRealm{Spec: keycloakApi.KeycloakRealmSpec{
+		RealmName: "realm1",
+		ClientPolicies: &keycloakApi.RealmClientPolicies{
+			Profiles: []keycloakApi.ClientProfile{{
+				Name: "fapi",
+				Executors: []keycloakApi.ClientPolicyExecutor{{
+					Executor: "secure-client-authenticator",
+					Configuration: map[string]apiextensionsv1.JSON{
+						"allowed-client-authenticators": {Raw: []byte(`["client-jwt"]`)},
+					},
+				}},
+			}},
+			Policies: []keycloakApi.ClientPolicy{{
+				Name: "confidential",
+				Conditions: []keycloakApi.ClientPolicyCondition{{
+					Condition: "client-access-type",
+					Configuration: map[string]apiextensionsv1.JSON{
+						"type": {Raw: []byte(`["confidential"]`)},
+					},
+				}},
+				Profiles: []string{"fapi", "fapi-1-baseline"},
+			}},
+		},
+	}}
+}
+
+func testClientProfiles() *adapter.ClientProfiles {
+	return &adapter.ClientProfiles{Profiles: []adapter.ClientProfile{{
+		Name: "fapi",
+		Executors: []adapter.ClientPolicyExecutor{{
+			Executor:      "secure-client-authenticator",
+			Configuration: map[string]interface{}{"allowed-client-authenticators": []interface{}{"client-jwt"}},
+		}},
+	}}}
+}
+
+func testClientPolicies(profiles ...string) *adapter.ClientPolicies {
+	return &adapter.ClientPolicies{Policies: []adapter.ClientPolicy{{
+		Name:    "confidential",
+		Enabled: true,
+		Conditions: []adapter.ClientPolicyCondition{{
+			Condition:     "client-access-type",
+			Configuration: map[string]interface{}{"type": []interface{}{"confidential"}},
+		}},
+		Profiles: profiles,
+	}}}
+}
+
+func TestPutClientPolicies_ServeRequest(t *testing.T) {
+	h := PutClientPolicies{}
+	ctx := context.Background()
+
+	kClient := new(adapter.Mock)
+	require.NoError(t, h.ServeRequest(ctx, &keycloakApi.KeycloakRealm{}, kClient), "client policies are not managed")
+
+	kClient.On("GetClientPolicies", "realm1").Return(&adapter.ClientPolicies{}, nil).Once()
+	kClient.On("GetClientProfiles", "realm1").Return(&adapter.ClientProfiles{}, nil).Once()
+	kClient.On("UpdateClientProfiles", "realm1", testClientProfiles()).Return(nil).Once()
+	kClient.On("UpdateClientPolicies", "realm1", testClientPolicies("fapi", "fapi-1-baseline")).Return(nil).Once()
+
+	require.NoError(t, h.ServeRequest(ctx, testClientPoliciesRealm(), kClient))
+	kClient.AssertExpectations(t)
+
+	// nothing is updated if the keycloak policies are not changed.
+	kClient = new(adapter.Mock)
+	kClient.On("GetClientPolicies", "realm1").Return(testClientPolicies("fapi", "fapi-1-baseline"), nil)
+	kClient.On("GetClientProfiles", "realm1").Return(testClientProfiles(), nil)
+
+	require.NoError(t, h.ServeRequest(ctx, testClientPoliciesRealm(), kClient))
+	kClient.AssertNotCalled(t, "UpdateClientProfiles", "realm1", testClientProfiles())
+}
+
+func TestPutClientPolicies_ServeRequest_RemoveUsedProfile(t *testing.T) {
+	h := PutClientPolicies{}
+	kClient := new(adapter.Mock)
+
+	oldProfile := adapter.ClientProfile{Name: "old", Executors: []adapter.ClientPolicyExecutor{}}
+	interim := testClientProfiles()
+	interim.Profiles = append(interim.Profiles, oldProfile)
+
+	kClient.On("GetClientPolicies", "realm1").Return(testClientPolicies("old"), nil)
+	kClient.On("GetClientProfiles", "realm1").Return(&adapter.ClientProfiles{
+		Profiles: []adapter.ClientProfile{oldProfile},
+	}, nil)
+	kClient.On("UpdateClientProfiles", "realm1", interim).Return(nil).Once()
+	kClient.On("UpdateClientPolicies", "realm1", testClientPolicies("fapi", "fapi-1-baseline")).Return(nil).Once()
+	kClient.On("UpdateClientProfiles", "realm1", testClientProfiles()).Return(nil).Once()
+
+	require.NoError(t, h.ServeRequest(context.Background(), testClientPoliciesRealm(), kClient))
+	kClient.AssertExpectations(t)
+}
+
+func TestPutClientPolicies_ServeRequest_DuplicateProfile(t *testing.T) {
+	realm := testClientPoliciesRealm()
+	realm.Spec.ClientPolicies.Profiles = append(realm.Spec.ClientPolicies.Profiles,
+		keycloakApi.ClientProfile{Name: "fapi"})
+
+	err := PutClientPolicies{}.ServeRequest(context.Background(), realm, new(adapter.Mock))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "client profile fapi is declared more than once")
+}
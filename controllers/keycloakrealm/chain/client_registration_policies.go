@@ -0,0 +1,162 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const (
+	clientRegistrationPolicyProviderType = "org.keycloak.services.clientregistration.policy.ClientRegistrationPolicy"
+	clientRegistrationAnonymous          = "anonymous"
+	clientRegistrationAuthenticated      = "authenticated"
+)
+
+type PutClientRegistrationPolicies struct {
+	next handler.RealmHandler
+}
+
+func (h PutClientRegistrationPolicies) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	policies := realm.Spec.ClientRegistrationPolicies
+	if policies == nil {
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+	rLog.Info("Start putting client registration policies")
+
+	current, err := kClient.GetComponents(ctx, realm.Spec.RealmName, clientRegistrationPolicyProviderType)
+	if err != nil {
+		return errors.Wrap(err, "unable to get client registration policies")
+	}
+
+	if err := syncClientRegistrationPolicies(ctx, kClient, realm.Spec.RealmName, clientRegistrationAnonymous,
+		policies.Anonymous, current); err != nil {
+		return err
+	}
+
+	if err := syncClientRegistrationPolicies(ctx, kClient, realm.Spec.RealmName, clientRegistrationAuthenticated,
+		policies.Authenticated, current); err != nil {
+		return err
+	}
+
+	rLog.Info("End putting client registration policies")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+// syncClientRegistrationPolicies syncs policies of the registration sub type by name.
+// Policies of the sub type are not managed if the declared list is nil, otherwise undeclared policies are deleted.
+func syncClientRegistrationPolicies(ctx context.Context, kClient keycloak.Client, realmName, subType string,
+	declared []keycloakApi.ClientRegistrationPolicy, current []adapter.Component) error {
+	if declared == nil {
+		return nil
+	}
+
+	existing := make(map[string]string)
+
+	for i := range current {
+		if current[i].SubType == subType {
+			existing[current[i].Name] = current[i].ID
+		}
+	}
+
+	for i := range declared {
+		policy, err := makeClientRegistrationPolicy(&declared[i], subType)
+		if err != nil {
+			return err
+		}
+
+		if id, ok := existing[policy.Name]; ok {
+			delete(existing, policy.Name)
+
+			policy.ID = id
+			if err := kClient.UpdateComponent(ctx, realmName, policy); err != nil {
+				return errors.Wrapf(err, "unable to update %s client registration policy %s", subType, policy.Name)
+			}
+
+			continue
+		}
+
+		if err := kClient.CreateComponent(ctx, realmName, policy); err != nil {
+			return errors.Wrapf(err, "unable to create %s client registration policy %s", subType, policy.Name)
+		}
+	}
+
+	for name, id := range existing {
+		if err := kClient.DeleteComponentByID(ctx, realmName, id); err != nil {
+			return errors.Wrapf(err, "unable to delete %s client registration policy %s", subType, name)
+		}
+	}
+
+	return nil
+}
+
+func makeClientRegistrationPolicy(spec *keycloakApi.ClientRegistrationPolicy, subType string) (*adapter.Component, error) {
+	policy := &adapter.Component{
+		Name:         spec.Name,
+		ProviderType: clientRegistrationPolicyProviderType,
+		SubType:      subType,
+		Config:       map[string][]string{},
+	}
+
+	providers := 0
+
+	if spec.TrustedHosts != nil {
+		providers++
+		policy.ProviderID = "trusted-hosts"
+		policy.Config["trusted-hosts"] = append([]string{}, spec.TrustedHosts.Hosts...)
+		setBoolConfig(policy.Config, "host-sending-registration-request-must-match",
+			spec.TrustedHosts.HostSendingRegistrationRequestMustMatch)
+		setBoolConfig(policy.Config, "client-uris-must-match", spec.TrustedHosts.ClientURIsMustMatch)
+	}
+
+	if spec.AllowedProtocolMappers != nil {
+		providers++
+		policy.ProviderID = "allowed-protocol-mappers"
+		policy.Config["allowed-protocol-mapper-types"] = append([]string{}, spec.AllowedProtocolMappers.ProtocolMapperTypes...)
+	}
+
+	if spec.AllowedClientScopes != nil {
+		providers++
+		policy.ProviderID = "allowed-client-templates"
+		policy.Config["allowed-client-scopes"] = append([]string{}, spec.AllowedClientScopes.Scopes...)
+		setBoolConfig(policy.Config, "allow-default-scopes", spec.AllowedClientScopes.AllowDefaultScopes)
+	}
+
+	if spec.MaxClients != nil {
+		providers++
+		policy.ProviderID = "max-clients"
+		policy.Config["max-clients"] = []string{strconv.Itoa(spec.MaxClients.Limit)}
+	}
+
+	for providerID, enabled := range map[string]bool{
+		"consent-required": spec.ConsentRequired,
+		"scope":            spec.FullScopeDisabled,
+		"client-disabled":  spec.ClientDisabled,
+	} {
+		if enabled {
+			providers++
+			policy.ProviderID = providerID
+		}
+	}
+
+	if providers != 1 {
+		return nil, fmt.Errorf("client registration policy %s must have exactly one policy type, got %d", spec.Name, providers)
+	}
+
+	return policy, nil
+}
+
+func setBoolConfig(config map[string][]string, key string, value *bool) {
+	if value != nil {
+		config[key] = []string{strconv.FormatBool(*value)}
+	}
+}
@@ -0,0 +1,85 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutClientRegistrationPolicies_ServeRequest(t *testing.T) {
+	h := PutClientRegistrationPolicies{}
+	kClient := new(adapter.Mock)
+	ctx := context.Background()
+
+	require.NoError(t, h.ServeRequest(ctx, &keycloakApi.KeycloakRealm{}, kClient), "policies are not managed")
+
+	mustMatch := false
+	realm := keycloakApi.KeycloakRealm{Spec: keycloakApi.KeycloakRealmSpec{
+		RealmName: "realm1",
+		ClientRegistrationPolicies: &keycloakApi.ClientRegistrationPolicies{
+			Anonymous: []keycloakApi.ClientRegistrationPolicy{
+				{
+					Name: "Trusted Hosts",
+					TrustedHosts: &keycloakApi.TrustedHostsPolicy{
+						Hosts:                                   []string{"*.example.com"},
+						HostSendingRegistrationRequestMustMatch: &mustMatch,
+					},
+				},
+				{Name: "Consent Required", ConsentRequired: true},
+			},
+		},
+	}}
+
+	kClient.On("GetComponents", "realm1", clientRegistrationPolicyProviderType).Return([]adapter.Component{
+		{ID: "id1", Name: "Trusted Hosts", SubType: clientRegistrationAnonymous},
+		{ID: "id2", Name: "Max Clients Limit", SubType: clientRegistrationAnonymous},
+		{ID: "id3", Name: "Max Clients Limit", SubType: clientRegistrationAuthenticated},
+	}, nil)
+	kClient.On("UpdateComponent", "realm1", &adapter.Component{
+		ID:           "id1",
+		Name:         "Trusted Hosts",
+		ProviderID:   "trusted-hosts",
+		ProviderType: clientRegistrationPolicyProviderType,
+		SubType:      clientRegistrationAnonymous,
+		Config: map[string][]string{
+			"trusted-hosts": {"*.example.com"},
+			"host-sending-registration-request-must-match": {"false"},
+		},
+	}).Return(nil)
+	kClient.On("CreateComponent", "realm1", &adapter.Component{
+		Name:         "Consent Required",
+		ProviderID:   "consent-required",
+		ProviderType: clientRegistrationPolicyProviderType,
+		SubType:      clientRegistrationAnonymous,
+		Config:       map[string][]string{},
+	}).Return(nil)
+	kClient.On("DeleteComponentByID", "realm1", "id2").Return(nil)
+
+	require.NoError(t, h.ServeRequest(ctx, &realm, kClient))
+	kClient.AssertExpectations(t)
+}
+
+func TestMakeClientRegistrationPolicy(t *testing.T) {
+	_, err := makeClientRegistrationPolicy(&keycloakApi.ClientRegistrationPolicy{Name: "empty"}, clientRegistrationAnonymous)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exactly one policy type")
+
+	_, err = makeClientRegistrationPolicy(&keycloakApi.ClientRegistrationPolicy{
+		Name:            "both",
+		ConsentRequired: true,
+		MaxClients:      &keycloakApi.MaxClientsPolicy{Limit: 10},
+	}, clientRegistrationAnonymous)
+	require.Error(t, err)
+
+	policy, err := makeClientRegistrationPolicy(&keycloakApi.ClientRegistrationPolicy{
+		Name:       "Max Clients Limit",
+		MaxClients: &keycloakApi.MaxClientsPolicy{Limit: 10},
+	}, clientRegistrationAuthenticated)
+	require.NoError(t, err)
+	require.Equal(t, "max-clients", policy.ProviderID)
+	require.Equal(t, []string{"10"}, policy.Config["max-clients"])
+}
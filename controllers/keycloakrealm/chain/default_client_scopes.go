@@ -0,0 +1,36 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+// PutDefaultClientScopes syncs the realm client scopes assigned to the new clients.
+type PutDefaultClientScopes struct {
+	next handler.RealmHandler
+}
+
+func (h PutDefaultClientScopes) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm,
+	kClient keycloak.Client) error {
+	scopes := realm.Spec.DefaultClientScopes
+	if scopes == nil || (scopes.Default == nil && scopes.Optional == nil) {
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+	rLog.Info("Start putting realm default client scopes")
+
+	if err := kClient.SyncRealmDefaultClientScopes(ctx, realm.Spec.RealmName, scopes.Default,
+		scopes.Optional); err != nil {
+		return errors.Wrap(err, "unable to sync realm default client scopes")
+	}
+
+	rLog.Info("End putting realm default client scopes")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
@@ -0,0 +1,39 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutDefaultClientScopes_ServeRequest(t *testing.T) {
+	h := PutDefaultClientScopes{}
+	kClient := new(adapter.Mock)
+	ctx := context.Background()
+
+	require.NoError(t, h.ServeRequest(ctx, &keycloakApi.KeycloakRealm{}, kClient),
+		"default client scopes are not managed")
+
+	realm := keycloakApi.KeycloakRealm{Spec: keycloakApi.KeycloakRealmSpec{
+		RealmName: "realm1",
+		DefaultClientScopes: &keycloakApi.RealmDefaultClientScopes{
+			Default: []string{"profile", "email"},
+		},
+	}}
+
+	kClient.On("SyncRealmDefaultClientScopes", "realm1", []string{"profile", "email"}, []string(nil)).
+		Return(nil).Once()
+	require.NoError(t, h.ServeRequest(ctx, &realm, kClient))
+
+	kClient.On("SyncRealmDefaultClientScopes", "realm1", []string{"profile", "email"}, []string(nil)).
+		Return(errors.New("failed to get 'email' keycloak client scopes")).Once()
+
+	err := h.ServeRequest(ctx, &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to sync realm default client scopes")
+}
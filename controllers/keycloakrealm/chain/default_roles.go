@@ -0,0 +1,41 @@
+package chain
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+type PutDefaultRoles struct {
+	next handler.RealmHandler
+}
+
+func (h PutDefaultRoles) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	defaultRoles := realm.Spec.DefaultRoles
+	if defaultRoles == nil {
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+	rLog.Info("Start putting realm default roles")
+
+	unresolved, err := kClient.SyncRealmDefaultRoles(ctx, realm.Spec.RealmName, defaultRoles.RealmRoles,
+		defaultRoles.ClientRoles)
+	if err != nil {
+		return errors.Wrap(err, "unable to sync realm default roles")
+	}
+
+	// roles can be created later by their own custom resources, so the realm is requeued until they exist.
+	if len(unresolved) > 0 {
+		return errors.Errorf("default roles not found: %s", strings.Join(unresolved, ", "))
+	}
+
+	rLog.Info("End putting realm default roles")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
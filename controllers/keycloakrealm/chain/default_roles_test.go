@@ -0,0 +1,38 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutDefaultRoles_ServeRequest(t *testing.T) {
+	h := PutDefaultRoles{}
+	kClient := new(adapter.Mock)
+	ctx := context.Background()
+
+	require.NoError(t, h.ServeRequest(ctx, &keycloakApi.KeycloakRealm{}, kClient), "default roles are not managed")
+
+	realm := keycloakApi.KeycloakRealm{Spec: keycloakApi.KeycloakRealmSpec{
+		RealmName: "realm1",
+		DefaultRoles: &keycloakApi.DefaultRoles{
+			RealmRoles:  []string{"offline_access"},
+			ClientRoles: map[string][]string{"account": {"view-profile"}},
+		},
+	}}
+
+	kClient.On("SyncRealmDefaultRoles", "realm1", []string{"offline_access"},
+		map[string][]string{"account": {"view-profile"}}).Return([]string{}, nil).Once()
+	require.NoError(t, h.ServeRequest(ctx, &realm, kClient))
+
+	kClient.On("SyncRealmDefaultRoles", "realm1", []string{"offline_access"},
+		map[string][]string{"account": {"view-profile"}}).Return([]string{"account/view-profile"}, nil).Once()
+
+	err := h.ServeRequest(ctx, &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "default roles not found: account/view-profile")
+}
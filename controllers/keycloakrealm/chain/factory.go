@@ -28,7 +28,26 @@ func CreateDefChain(client client.Client, scheme *runtime.Scheme, hlp Helper) ha
 								next: PutIdentityProvider{
 									next: PutDefaultIdP{
 										next: RealmSettings{
-											next: AuthFlow{},
+											next: PutRealmLocalization{
+												next: PutUserProfile{
+													next: PutKeyProviders{
+														next: PutClientRegistrationPolicies{
+															next: AuthFlow{
+																next: PutDefaultRoles{
+																	next: PutDefaultClientScopes{
+																		next: PutClientPolicies{
+																			next: PruneRealmChildren{client: client},
+																		},
+																	},
+																},
+															},
+														},
+														client: client,
+													},
+												},
+												client: client,
+											},
+											client: client,
 										},
 									},
 									client: client,
@@ -49,6 +68,18 @@ func CreateDefChain(client client.Client, scheme *runtime.Scheme, hlp Helper) ha
 	}
 }
 
+// CreateClusterRealmChain creates the chain for the ClusterKeycloakRealm converted to the KeycloakRealm.
+// It only puts the realm and its settings, the handlers which update the realm resource are not included.
+func CreateClusterRealmChain(client client.Client, hlp Helper) handler.RealmHandler {
+	return PutRealm{
+		hlp: hlp,
+		next: RealmSettings{
+			client: client,
+		},
+		client: client,
+	}
+}
+
 func nextServeOrNil(ctx context.Context, next handler.RealmHandler, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
 	if next != nil {
 		err := next.ServeRequest(ctx, realm, kClient)
@@ -0,0 +1,262 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const keyProviderType = "org.keycloak.keys.KeyProvider"
+
+// PutKeyProviders syncs the realm key providers and rotates their keys.
+type PutKeyProviders struct {
+	next   handler.RealmHandler
+	client client.Client
+}
+
+// keyGeneration is a key provider component of the declared provider generation.
+type keyGeneration struct {
+	generation int
+	component  adapter.Component
+}
+
+func (h PutKeyProviders) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	if len(realm.Spec.KeyProviders) == 0 {
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+	rLog.Info("Start putting realm key providers")
+
+	current, err := kClient.GetComponents(ctx, realm.Spec.RealmName, keyProviderType)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm key providers")
+	}
+
+	declared := make(map[string]struct{}, len(realm.Spec.KeyProviders))
+
+	for i := range realm.Spec.KeyProviders {
+		name := keyProviderComponentName(&realm.Spec.KeyProviders[i])
+		if _, ok := declared[name]; ok {
+			return errors.Errorf("key provider %s is declared more than once", name)
+		}
+
+		declared[name] = struct{}{}
+	}
+
+	for i := range realm.Spec.KeyProviders {
+		if err := h.syncKeyProvider(ctx, kClient, realm, &realm.Spec.KeyProviders[i], current, declared); err != nil {
+			return err
+		}
+	}
+
+	rLog.Info("End putting realm key providers")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+func (h PutKeyProviders) syncKeyProvider(ctx context.Context, kClient keycloak.Client, realm *keycloakApi.KeycloakRealm,
+	spec *keycloakApi.RealmKeyProvider, current []adapter.Component, declared map[string]struct{}) error {
+	component, err := h.makeKeyProvider(ctx, realm.Namespace, spec)
+	if err != nil {
+		return err
+	}
+
+	realmName := realm.Spec.RealmName
+	currentGeneration := keyProviderGeneration(spec)
+	previous := make([]keyGeneration, 0)
+
+	for i := range current {
+		if current[i].Name == component.Name {
+			component.ID = current[i].ID
+			continue
+		}
+
+		// components of the other declared providers are never treated as previous generations.
+		if _, ok := declared[current[i].Name]; ok {
+			continue
+		}
+
+		// the newer generations, e.g. left after the generation is decreased in the spec, are not touched.
+		if generation, ok := parseKeyGeneration(spec.Name, current[i].Name); ok && generation < currentGeneration {
+			previous = append(previous, keyGeneration{generation: generation, component: current[i]})
+		}
+	}
+
+	if component.ID != "" {
+		if err := kClient.UpdateComponent(ctx, realmName, component); err != nil {
+			return errors.Wrapf(err, "unable to update key provider %s", component.Name)
+		}
+	} else {
+		if err := kClient.CreateComponent(ctx, realmName, component); err != nil {
+			return errors.Wrapf(err, "unable to create key provider %s", component.Name)
+		}
+	}
+
+	if spec.Rotation == nil || len(previous) == 0 {
+		return nil
+	}
+
+	return rotateKeyGenerations(ctx, kClient, realmName, previous, spec.Rotation.GetPassiveGenerations())
+}
+
+// rotateKeyGenerations makes the latest previous generations passive and deletes the older ones.
+func rotateKeyGenerations(ctx context.Context, kClient keycloak.Client, realmName string, previous []keyGeneration,
+	passiveGenerations int) error {
+	sort.Slice(previous, func(i, j int) bool {
+		return previous[i].generation > previous[j].generation
+	})
+
+	for i := range previous {
+		cmp := previous[i].component
+
+		if i >= passiveGenerations {
+			if err := kClient.DeleteComponentByID(ctx, realmName, cmp.ID); err != nil {
+				return errors.Wrapf(err, "unable to delete key provider %s", cmp.Name)
+			}
+
+			continue
+		}
+
+		if len(cmp.Config["active"]) > 0 && cmp.Config["active"][0] == "false" {
+			continue
+		}
+
+		if cmp.Config == nil {
+			cmp.Config = make(map[string][]string)
+		}
+
+		cmp.Config["active"] = []string{"false"}
+
+		if err := kClient.UpdateComponent(ctx, realmName, &cmp); err != nil {
+			return errors.Wrapf(err, "unable to make key provider %s passive", cmp.Name)
+		}
+	}
+
+	return nil
+}
+
+func (h PutKeyProviders) makeKeyProvider(ctx context.Context, namespace string,
+	spec *keycloakApi.RealmKeyProvider) (*adapter.Component, error) {
+	component := adapter.Component{
+		Name:         keyProviderComponentName(spec),
+		ProviderID:   spec.Type,
+		ProviderType: keyProviderType,
+		Config: map[string][]string{
+			"priority": {strconv.FormatInt(spec.Priority, 10)},
+			"enabled":  {strconv.FormatBool(spec.Enabled == nil || *spec.Enabled)},
+			"active":   {strconv.FormatBool(spec.Active == nil || *spec.Active)},
+		},
+	}
+
+	if spec.Algorithm != "" {
+		component.Config["algorithm"] = []string{spec.Algorithm}
+	}
+
+	switch spec.Type {
+	case keycloakApi.KeyProviderRSAGenerated, keycloakApi.KeyProviderRSAEncGenerated:
+		if spec.KeySize > 0 {
+			component.Config["keySize"] = []string{strconv.Itoa(spec.KeySize)}
+		}
+	case keycloakApi.KeyProviderHMACGenerated, keycloakApi.KeyProviderAESGenerated:
+		if spec.SecretSize > 0 {
+			component.Config["secretSize"] = []string{strconv.Itoa(spec.SecretSize)}
+		}
+	case keycloakApi.KeyProviderRSA:
+		if spec.PrivateKey == nil {
+			return nil, errors.Errorf("private key of the key provider %s is not set", spec.Name)
+		}
+
+		privateKey, err := h.getSecretValue(ctx, namespace, spec.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		component.Config["privateKey"] = []string{privateKey}
+
+		if spec.Certificate != nil {
+			certificate, err := h.getSecretValue(ctx, namespace, spec.Certificate)
+			if err != nil {
+				return nil, err
+			}
+
+			component.Config["certificate"] = []string{certificate}
+		}
+
+		return &component, nil
+	default:
+		return nil, errors.Errorf("unsupported type %s of the key provider %s", spec.Type, spec.Name)
+	}
+
+	if spec.PrivateKey != nil || spec.Certificate != nil {
+		return nil, errors.Errorf("key provider %s of type %s generates the keys, the private key can not be set",
+			spec.Name, spec.Type)
+	}
+
+	return &component, nil
+}
+
+func (h PutKeyProviders) getSecretValue(ctx context.Context, namespace string, ref *keycloakApi.SecretKeyRef) (string, error) {
+	var secret coreV1.Secret
+	if err := h.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &secret); err != nil {
+		return "", errors.Wrapf(err, "unable to get key secret %s", ref.Name)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("key secret %s does not contain key %s", ref.Name, ref.Key)
+	}
+
+	return string(value), nil
+}
+
+// keyProviderGeneration is the current generation of the provider.
+func keyProviderGeneration(spec *keycloakApi.RealmKeyProvider) int {
+	if spec.Rotation == nil {
+		return 0
+	}
+
+	return spec.Rotation.Generation
+}
+
+// keyProviderComponentName is the name of the component of the current provider generation.
+func keyProviderComponentName(spec *keycloakApi.RealmKeyProvider) string {
+	generation := keyProviderGeneration(spec)
+	if generation == 0 {
+		return spec.Name
+	}
+
+	return fmt.Sprintf("%s-%d", spec.Name, generation)
+}
+
+// parseKeyGeneration returns the generation of the provider component, the component named as the provider
+// is the generation 0.
+func parseKeyGeneration(providerName, componentName string) (int, bool) {
+	if componentName == providerName {
+		return 0, true
+	}
+
+	suffix := strings.TrimPrefix(componentName, providerName+"-")
+	if suffix == componentName {
+		return 0, false
+	}
+
+	generation, err := strconv.Atoi(suffix)
+	if err != nil || generation < 0 {
+		return 0, false
+	}
+
+	return generation, true
+}
@@ -0,0 +1,127 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutKeyProviders_ServeRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(coreV1.AddToScheme(scheme))
+
+	secret := coreV1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "realm-key", Namespace: "ns"},
+		Data: map[string][]byte{"tls.key": []byte("private-key")}}
+	h := PutKeyProviders{client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&secret).Build()}
+	kClient := new(adapter.Mock)
+
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			KeyProviders: []keycloakApi.RealmKeyProvider{
+				{
+					Name:     "rsa-generated",
+					Type:     keycloakApi.KeyProviderRSAGenerated,
+					Priority: 100,
+					KeySize:  2048,
+					Rotation: &keycloakApi.KeyProviderRotation{Generation: 2},
+				},
+				{
+					Name:       "imported",
+					Type:       keycloakApi.KeyProviderRSA,
+					PrivateKey: &keycloakApi.SecretKeyRef{Name: "realm-key", Key: "tls.key"},
+				},
+			},
+		},
+	}
+
+	kClient.On("GetComponents", "realm1", keyProviderType).Return([]adapter.Component{
+		{ID: "gen0", Name: "rsa-generated", Config: map[string][]string{"active": {"false"}}},
+		{ID: "gen1", Name: "rsa-generated-1", Config: map[string][]string{"active": {"true"}, "keySize": {"2048"}}},
+		{ID: "gen3", Name: "rsa-generated-3", Config: map[string][]string{"active": {"true"}}},
+		{ID: "imported-id", Name: "imported"},
+		{ID: "hmac", Name: "hmac-generated"},
+	}, nil)
+	kClient.On("CreateComponent", "realm1", &adapter.Component{
+		Name:         "rsa-generated-2",
+		ProviderID:   keycloakApi.KeyProviderRSAGenerated,
+		ProviderType: keyProviderType,
+		Config: map[string][]string{
+			"priority": {"100"},
+			"enabled":  {"true"},
+			"active":   {"true"},
+			"keySize":  {"2048"},
+		},
+	}).Return(nil)
+	kClient.On("UpdateComponent", "realm1", &adapter.Component{
+		ID:     "gen1",
+		Name:   "rsa-generated-1",
+		Config: map[string][]string{"active": {"false"}, "keySize": {"2048"}},
+	}).Return(nil)
+	kClient.On("DeleteComponentByID", "realm1", "gen0").Return(nil)
+	kClient.On("UpdateComponent", "realm1", &adapter.Component{
+		ID:           "imported-id",
+		Name:         "imported",
+		ProviderID:   keycloakApi.KeyProviderRSA,
+		ProviderType: keyProviderType,
+		Config: map[string][]string{
+			"priority":   {"0"},
+			"enabled":    {"true"},
+			"active":     {"true"},
+			"privateKey": {"private-key"},
+		},
+	}).Return(nil)
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+	kClient.AssertNotCalled(t, "DeleteComponentByID", "realm1", "gen3")
+	kClient.AssertNumberOfCalls(t, "UpdateComponent", 2)
+}
+
+func TestPutKeyProviders_makeKeyProvider(t *testing.T) {
+	h := PutKeyProviders{}
+
+	_, err := h.makeKeyProvider(context.Background(), "ns", &keycloakApi.RealmKeyProvider{
+		Name: "imported",
+		Type: keycloakApi.KeyProviderRSA,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "private key of the key provider imported is not set")
+
+	_, err = h.makeKeyProvider(context.Background(), "ns", &keycloakApi.RealmKeyProvider{
+		Name:       "hmac",
+		Type:       keycloakApi.KeyProviderHMACGenerated,
+		PrivateKey: &keycloakApi.SecretKeyRef{Name: "key", Key: "key"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the private key can not be set")
+}
+
+func TestParseKeyGeneration(t *testing.T) {
+	tests := []struct {
+		component string
+		want      int
+		wantOK    bool
+	}{
+		{component: "rsa", want: 0, wantOK: true},
+		{component: "rsa-3", want: 3, wantOK: true},
+		{component: "rsa-enc", wantOK: false},
+		{component: "hmac", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseKeyGeneration("rsa", tt.component)
+		require.Equal(t, tt.wantOK, ok, tt.component)
+		require.Equal(t, tt.want, got, tt.component)
+	}
+}
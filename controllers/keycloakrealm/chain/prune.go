@@ -0,0 +1,509 @@
+package chain
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+var (
+	// builtInClients are the clients created by keycloak for every realm.
+	builtInClients = makeSet("account", "account-console", "admin-cli", "broker", "realm-management",
+		"security-admin-console")
+
+	// builtInClientScopes are the client scopes created by keycloak for every realm.
+	builtInClientScopes = makeSet("acr", "address", "basic", "email", "microprofile-jwt", "offline_access",
+		"organization", "phone", "profile", "role_list", "roles", "saml_organization", "service_account",
+		"web-origins")
+)
+
+// PruneRealmChildren deletes the realm clients, roles, groups and client scopes
+// which are not declared by the custom resources of the realm if the prune policy enables them.
+type PruneRealmChildren struct {
+	next   handler.RealmHandler
+	client client.Client
+}
+
+// declaredChildren are the realm children declared by the custom resources and the realm spec.
+// The subtrees of the group paths in the groupTrees are declared as a whole.
+type declaredChildren struct {
+	clients      map[string]struct{}
+	roles        map[string]struct{}
+	groups       map[string]struct{}
+	groupTrees   map[string]struct{}
+	clientScopes map[string]struct{}
+}
+
+func (h PruneRealmChildren) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm,
+	kClient keycloak.Client) error {
+	prune := realm.Spec.Prune
+	if prune == nil || !(prune.Clients || prune.RealmRoles || prune.Groups || prune.ClientScopes) {
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+	rLog.Info("Start pruning undeclared realm children")
+
+	declared, err := h.getDeclaredChildren(ctx, realm)
+	if err != nil {
+		return err
+	}
+
+	live, err := kClient.ExportRealm(ctx, realm.Spec.RealmName)
+	if err != nil {
+		return errors.Wrap(err, "unable to export realm")
+	}
+
+	exclude := prune.Exclude
+	if exclude == nil {
+		exclude = &keycloakApi.RealmPruneExclusions{}
+	}
+
+	p := pruner{ctx: ctx, kClient: kClient, realmName: realm.Spec.RealmName, dryRun: prune.DryRun}
+
+	if prune.Clients {
+		if err := p.pruneClients(live.Clients, declared.clients, exclude.Clients); err != nil {
+			return err
+		}
+	}
+
+	if prune.ClientScopes {
+		if err := p.pruneClientScopes(live.ClientScopes, declared.clientScopes, exclude.ClientScopes); err != nil {
+			return err
+		}
+	}
+
+	if prune.Groups {
+		if err := p.pruneGroups(live.Groups, declared, exclude.Groups); err != nil {
+			return err
+		}
+	}
+
+	if prune.RealmRoles {
+		if err := p.pruneRoles(live.Roles.Realm, declared.roles, exclude.RealmRoles); err != nil {
+			return err
+		}
+	}
+
+	rLog.Info("End pruning undeclared realm children")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+// getDeclaredChildren collects the realm children declared in the namespace of the realm
+// and in the namespaces allowed to reference the realm.
+// The clients without the target realm are treated as declared because their realm is not resolved yet.
+func (h PruneRealmChildren) getDeclaredChildren(ctx context.Context,
+	realm *keycloakApi.KeycloakRealm) (*declaredChildren, error) {
+	declared := declaredChildren{
+		clients:      make(map[string]struct{}),
+		roles:        make(map[string]struct{}),
+		groups:       make(map[string]struct{}),
+		groupTrees:   make(map[string]struct{}),
+		clientScopes: make(map[string]struct{}),
+	}
+
+	inNamespace := childrenListOptions(realm)
+
+	var clients keycloakApi.KeycloakClientList
+	if err := h.client.List(ctx, &clients, inNamespace); err != nil {
+		return nil, errors.Wrap(err, "unable to list keycloak clients")
+	}
+
+	for i := range clients.Items {
+		if !isRealmNamespace(realm, &clients.Items[i]) {
+			continue
+		}
+
+		if selector := clients.Items[i].Spec.RealmSelector; selector != nil {
+			if selectsRealm(realm, selector) {
+				declared.clients[clients.Items[i].Spec.ClientId] = struct{}{}
+			}
+
+			continue
+		}
+
+		if t := clients.Items[i].Spec.TargetRealm; t == "" || t == realm.Spec.RealmName {
+			declared.clients[clients.Items[i].Spec.ClientId] = struct{}{}
+		}
+	}
+
+	var roles keycloakApi.KeycloakRealmRoleList
+	if err := h.client.List(ctx, &roles, inNamespace); err != nil {
+		return nil, errors.Wrap(err, "unable to list keycloak realm roles")
+	}
+
+	for i := range roles.Items {
+		if belongsToRealm(realm, &roles.Items[i], roles.Items[i].Spec.Realm) {
+			declared.roles[roles.Items[i].Spec.Name] = struct{}{}
+		}
+	}
+
+	if err := h.addDeclaredGroups(ctx, realm, &declared); err != nil {
+		return nil, err
+	}
+
+	var scopes keycloakApi.KeycloakClientScopeList
+	if err := h.client.List(ctx, &scopes, inNamespace); err != nil {
+		return nil, errors.Wrap(err, "unable to list keycloak client scopes")
+	}
+
+	for i := range scopes.Items {
+		if belongsToRealm(realm, &scopes.Items[i], scopes.Items[i].Spec.Realm) {
+			declared.clientScopes[scopes.Items[i].Spec.Name] = struct{}{}
+		}
+	}
+
+	if err := h.addImportedChildren(ctx, realm, &declared); err != nil {
+		return nil, err
+	}
+
+	if realm.Spec.DefaultClientScopes != nil {
+		addToSet(declared.clientScopes, realm.Spec.DefaultClientScopes.Default...)
+		addToSet(declared.clientScopes, realm.Spec.DefaultClientScopes.Optional...)
+	}
+
+	if realm.Spec.DefaultRoles != nil {
+		addToSet(declared.roles, realm.Spec.DefaultRoles.RealmRoles...)
+	}
+
+	// the roles of the realm users are created with the users
+	for _, u := range realm.Spec.Users {
+		addToSet(declared.roles, u.RealmRoles...)
+	}
+
+	return &declared, nil
+}
+
+func (h PruneRealmChildren) addDeclaredGroups(ctx context.Context, realm *keycloakApi.KeycloakRealm,
+	declared *declaredChildren) error {
+	var groupList keycloakApi.KeycloakRealmGroupList
+	if err := h.client.List(ctx, &groupList, childrenListOptions(realm)); err != nil {
+		return errors.Wrap(err, "unable to list keycloak realm groups")
+	}
+
+	groups := make(map[string]*keycloakApi.KeycloakRealmGroup, len(groupList.Items))
+
+	for i := range groupList.Items {
+		if belongsToRealm(realm, &groupList.Items[i], groupList.Items[i].Spec.Realm) {
+			groups[groupList.Items[i].Name] = &groupList.Items[i]
+		}
+	}
+
+	for _, g := range groups {
+		groupPath := declaredGroupPath(g, groups, 0)
+		declared.groups[groupPath] = struct{}{}
+
+		for _, sub := range g.Spec.SubGroups {
+			declared.groups[groupPath+"/"+sub] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// addImportedChildren adds the resources managed by the keycloak-config-cli imports of the realm.
+func (h PruneRealmChildren) addImportedChildren(ctx context.Context, realm *keycloakApi.KeycloakRealm,
+	declared *declaredChildren) error {
+	var imports keycloakApi.KeycloakConfigCliImportList
+	if err := h.client.List(ctx, &imports, childrenListOptions(realm)); err != nil {
+		return errors.Wrap(err, "unable to list keycloak config cli imports")
+	}
+
+	for i := range imports.Items {
+		if !belongsToRealm(realm, &imports.Items[i], imports.Items[i].Spec.Realm) {
+			continue
+		}
+
+		for _, res := range imports.Items[i].Status.ManagedResources {
+			switch res.Type {
+			case adapter.PartialImportResourceClient:
+				declared.clients[res.Name] = struct{}{}
+			case adapter.PartialImportResourceRealmRole:
+				declared.roles[res.Name] = struct{}{}
+			case adapter.PartialImportResourceGroup:
+				declared.groupTrees["/"+res.Name] = struct{}{}
+			}
+		}
+	}
+
+	return nil
+}
+
+// declaredGroupPath returns the path of the group custom resource.
+// The path is taken from the spec or made from the parent group custom resources.
+func declaredGroupPath(group *keycloakApi.KeycloakRealmGroup, groups map[string]*keycloakApi.KeycloakRealmGroup,
+	depth int) string {
+	if segments := splitPath(group.Spec.Path); len(segments) > 1 {
+		return "/" + strings.Join(segments, "/")
+	}
+
+	if group.Spec.ParentGroup == nil || depth > len(groups) {
+		return "/" + group.Spec.Name
+	}
+
+	parent, ok := groups[group.Spec.ParentGroup.Name]
+	if !ok {
+		return "/" + group.Spec.ParentGroup.Name + "/" + group.Spec.Name
+	}
+
+	return declaredGroupPath(parent, groups, depth+1) + "/" + group.Spec.Name
+}
+
+func splitPath(p string) []string {
+	return strings.FieldsFunc(p, func(r rune) bool {
+		return r == '/'
+	})
+}
+
+// pruner deletes the undeclared realm children or only logs them in the dry run mode.
+type pruner struct {
+	ctx       context.Context
+	kClient   keycloak.Client
+	realmName string
+	dryRun    bool
+}
+
+func (p *pruner) delete(kind, name string, del func() error) error {
+	rLog := log.WithValues("realm name", p.realmName, "kind", kind, "name", name)
+
+	if p.dryRun {
+		rLog.Info("Undeclared realm child would be pruned")
+
+		return nil
+	}
+
+	rLog.Info("Pruning undeclared realm child")
+
+	if err := del(); err != nil {
+		return errors.Wrapf(err, "unable to prune %s %s", kind, name)
+	}
+
+	return nil
+}
+
+func (p *pruner) pruneClients(clients []gocloak.Client, declared map[string]struct{}, exclude []string) error {
+	for i := range clients {
+		clientID := gocloak.PString(clients[i].ClientID)
+
+		if _, ok := declared[clientID]; ok || p.isBuiltInClient(clientID) || matchesAny(clientID, exclude) {
+			continue
+		}
+
+		id := gocloak.PString(clients[i].ID)
+
+		if err := p.delete("client", clientID, func() error {
+			return p.kClient.DeleteClient(p.ctx, id, p.realmName)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isBuiltInClient checks whether the client is created by keycloak,
+// the master realm also has the <realm>-realm clients of all the realms.
+func (p *pruner) isBuiltInClient(clientID string) bool {
+	if _, ok := builtInClients[clientID]; ok {
+		return true
+	}
+
+	return p.realmName == "master" && strings.HasSuffix(clientID, "-realm")
+}
+
+func (p *pruner) pruneClientScopes(scopes []adapter.ClientScope, declared map[string]struct{},
+	exclude []string) error {
+	for i := range scopes {
+		name := scopes[i].Name
+
+		if _, ok := declared[name]; ok || matchesAny(name, exclude) {
+			continue
+		}
+
+		if _, ok := builtInClientScopes[name]; ok {
+			continue
+		}
+
+		id := scopes[i].ID
+
+		if err := p.delete("client scope", name, func() error {
+			return p.kClient.DeleteClientScope(p.ctx, p.realmName, id)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pruneGroups deletes the undeclared groups which have no declared subgroups,
+// the subgroups of the deleted groups are deleted by keycloak.
+func (p *pruner) pruneGroups(groups []adapter.ExportGroup, declared *declaredChildren, exclude []string) error {
+	for i := range groups {
+		groupPath := groups[i].Path
+		if groupPath == "" {
+			groupPath = "/" + groups[i].Name
+		}
+
+		if _, ok := declared.groupTrees[groupPath]; ok || matchesAny(groupPath, exclude) {
+			continue
+		}
+
+		_, ok := declared.groups[groupPath]
+		if ok || hasDeclaredSubGroups(groupPath, declared) || hasExcludedSubGroups(groupPath, exclude) {
+			if err := p.pruneGroups(groups[i].SubGroups, declared, exclude); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := p.delete("group", groupPath, func() error {
+			return p.kClient.DeleteGroup(p.ctx, p.realmName, groupPath)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasDeclaredSubGroups(groupPath string, declared *declaredChildren) bool {
+	for _, set := range []map[string]struct{}{declared.groups, declared.groupTrees} {
+		for p := range set {
+			if strings.HasPrefix(p, groupPath+"/") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasExcludedSubGroups checks whether the patterns can match the subgroups of the group.
+func hasExcludedSubGroups(groupPath string, exclude []string) bool {
+	for _, pattern := range exclude {
+		if strings.HasPrefix(pattern, groupPath+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *pruner) pruneRoles(roles []gocloak.Role, declared map[string]struct{}, exclude []string) error {
+	for i := range roles {
+		name := gocloak.PString(roles[i].Name)
+
+		if _, ok := declared[name]; ok || p.isBuiltInRole(name) || matchesAny(name, exclude) {
+			continue
+		}
+
+		if err := p.delete("realm role", name, func() error {
+			return p.kClient.DeleteRealmRole(p.ctx, p.realmName, name)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isBuiltInRole checks whether the realm role is created by keycloak,
+// the master realm also has the admin and create-realm roles.
+func (p *pruner) isBuiltInRole(name string) bool {
+	switch name {
+	case "offline_access", "uma_authorization", "default-roles-" + strings.ToLower(p.realmName):
+		return true
+	case "admin", "create-realm":
+		return p.realmName == "master"
+	default:
+		return false
+	}
+}
+
+// matchesAny checks whether the name matches any of the glob patterns.
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func makeSet(items ...string) map[string]struct{} {
+	set := make(map[string]struct{}, len(items))
+	addToSet(set, items...)
+
+	return set
+}
+
+func addToSet(set map[string]struct{}, items ...string) {
+	for _, item := range items {
+		set[item] = struct{}{}
+	}
+}
+
+// childrenListOptions lists the children in the namespace of the realm,
+// or in all namespaces if the realm allows the cross namespace references.
+func childrenListOptions(realm *keycloakApi.KeycloakRealm) client.ListOption {
+	if _, ok := realm.GetAnnotations()[keycloakApi.AllowedNamespacesAnnotation]; ok {
+		return &client.ListOptions{}
+	}
+
+	return client.InNamespace(realm.Namespace)
+}
+
+type realmNamespaceChild interface {
+	GetNamespace() string
+	GetRealmNamespace() string
+	GetRealmSelector() *metav1.LabelSelector
+}
+
+// isRealmNamespace checks whether the child references the realm from the namespace of the realm
+// or from a namespace allowed by the realm.
+func isRealmNamespace(realm *keycloakApi.KeycloakRealm, child realmNamespaceChild) bool {
+	realmNamespace := child.GetRealmNamespace()
+	if realmNamespace == "" {
+		realmNamespace = child.GetNamespace()
+	}
+
+	return realmNamespace == realm.Namespace && realm.IsNamespaceAllowed(child.GetNamespace())
+}
+
+// belongsToRealm checks whether the child references the realm by the realm name or by the realm selector.
+func belongsToRealm(realm *keycloakApi.KeycloakRealm, child realmNamespaceChild, realmName string) bool {
+	if !isRealmNamespace(realm, child) {
+		return false
+	}
+
+	if selector := child.GetRealmSelector(); selector != nil {
+		return selectsRealm(realm, selector)
+	}
+
+	return realmName == realm.Name
+}
+
+// selectsRealm checks whether the realm matches the selector, the invalid selector matches nothing.
+func selectsRealm(realm *keycloakApi.KeycloakRealm, selector *metav1.LabelSelector) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+
+	return s.Matches(labels.Set(realm.Labels))
+}
@@ -0,0 +1,230 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func getPruneTestRealmExport() *adapter.RealmExport {
+	return &adapter.RealmExport{
+		Realm: "realm1",
+		Clients: []gocloak.Client{
+			{ID: gocloak.StringP("id-account"), ClientID: gocloak.StringP("account")},
+			{ID: gocloak.StringP("id-app"), ClientID: gocloak.StringP("app")},
+			{ID: gocloak.StringP("id-stale"), ClientID: gocloak.StringP("stale-app")},
+			{ID: gocloak.StringP("id-legacy"), ClientID: gocloak.StringP("legacy-app")},
+			{ID: gocloak.StringP("id-imported"), ClientID: gocloak.StringP("imported-app")},
+		},
+		Groups: []adapter.ExportGroup{
+			{Name: "team", Path: "/team", SubGroups: []adapter.ExportGroup{
+				{Name: "dev", Path: "/team/dev"},
+				{Name: "stale", Path: "/team/stale"},
+			}},
+			{Name: "old", Path: "/old", SubGroups: []adapter.ExportGroup{{Name: "sub", Path: "/old/sub"}}},
+			{Name: "external", Path: "/external", SubGroups: []adapter.ExportGroup{{Name: "a", Path: "/external/a"}}},
+		},
+		Roles: adapter.ExportRoles{Realm: []gocloak.Role{
+			{Name: gocloak.StringP("offline_access")},
+			{Name: gocloak.StringP("default-roles-realm1")},
+			{Name: gocloak.StringP("developer")},
+			{Name: gocloak.StringP("viewer")},
+			{Name: gocloak.StringP("stale-role")},
+		}},
+		ClientScopes: []adapter.ClientScope{
+			{ID: "id-profile", Name: "profile"},
+			{ID: "id-edp", Name: "edp"},
+			{ID: "id-custom", Name: "custom"},
+			{ID: "id-stale-scope", Name: "stale-scope"},
+		},
+	}
+}
+
+func getPruneTestHandler(t *testing.T) PruneRealmChildren {
+	t.Helper()
+
+	s := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(s))
+
+	ns := "ns"
+	k8sClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&keycloakApi.KeycloakClient{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: ns},
+			Spec: keycloakApi.KeycloakClientSpec{ClientId: "app", TargetRealm: "realm1"}},
+		&keycloakApi.KeycloakClient{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: ns},
+			Spec: keycloakApi.KeycloakClientSpec{ClientId: "stale-app", TargetRealm: "realm2"}},
+		&keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Name: "developer", Namespace: ns},
+			Spec: keycloakApi.KeycloakRealmRoleSpec{Realm: "realm", Name: "developer"}},
+		&keycloakApi.KeycloakRealmGroup{ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: ns},
+			Spec: keycloakApi.KeycloakRealmGroupSpec{Realm: "realm", Name: "team"}},
+		&keycloakApi.KeycloakRealmGroup{ObjectMeta: metav1.ObjectMeta{Name: "team-dev", Namespace: ns},
+			Spec: keycloakApi.KeycloakRealmGroupSpec{Realm: "realm", Name: "dev",
+				ParentGroup: &keycloakApi.ParentGroup{Name: "team"}}},
+		&keycloakApi.KeycloakClientScope{ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: ns},
+			Spec: keycloakApi.KeycloakClientScopeSpec{Realm: "realm", Name: "custom"}},
+		&keycloakApi.KeycloakConfigCliImport{ObjectMeta: metav1.ObjectMeta{Name: "import", Namespace: ns},
+			Spec: keycloakApi.KeycloakConfigCliImportSpec{Realm: "realm"},
+			Status: keycloakApi.KeycloakConfigCliImportStatus{ManagedResources: []keycloakApi.ConfigCliManagedResource{
+				{Type: adapter.PartialImportResourceClient, Name: "imported-app"},
+			}}},
+	).Build()
+
+	return PruneRealmChildren{client: k8sClient}
+}
+
+func getPruneTestRealm() *keycloakApi.KeycloakRealm {
+	return &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:           "realm1",
+			DefaultClientScopes: &keycloakApi.RealmDefaultClientScopes{Default: []string{"edp"}},
+			DefaultRoles:        &keycloakApi.DefaultRoles{RealmRoles: []string{"viewer"}},
+			Prune: &keycloakApi.RealmPrune{
+				Clients:      true,
+				RealmRoles:   true,
+				Groups:       true,
+				ClientScopes: true,
+				Exclude: &keycloakApi.RealmPruneExclusions{
+					Clients: []string{"legacy-*"},
+					Groups:  []string{"/external/*"},
+				},
+			},
+		},
+	}
+}
+
+func TestPruneRealmChildren_ServeRequest(t *testing.T) {
+	h := getPruneTestHandler(t)
+	ctx := context.Background()
+
+	kClient := new(adapter.Mock)
+	require.NoError(t, h.ServeRequest(ctx, &keycloakApi.KeycloakRealm{}, kClient), "prune is not enabled")
+
+	kClient.On("ExportRealm", "realm1").Return(getPruneTestRealmExport(), nil)
+	kClient.On("DeleteClient", "id-stale", "realm1").Return(nil).Once()
+	kClient.On("DeleteClientScope", "realm1", "id-stale-scope").Return(nil).Once()
+	kClient.On("DeleteGroup", "realm1", "/team/stale").Return(nil).Once()
+	kClient.On("DeleteGroup", "realm1", "/old").Return(nil).Once()
+	kClient.On("DeleteRealmRole", "realm1", "stale-role").Return(nil).Once()
+
+	require.NoError(t, h.ServeRequest(ctx, getPruneTestRealm(), kClient))
+	kClient.AssertExpectations(t)
+	kClient.AssertNumberOfCalls(t, "DeleteGroup", 2)
+}
+
+func TestPruneRealmChildren_ServeRequest_DryRun(t *testing.T) {
+	h := getPruneTestHandler(t)
+	realm := getPruneTestRealm()
+	realm.Spec.Prune.DryRun = true
+
+	kClient := new(adapter.Mock)
+	kClient.On("ExportRealm", "realm1").Return(getPruneTestRealmExport(), nil)
+
+	require.NoError(t, h.ServeRequest(context.Background(), realm, kClient))
+	kClient.AssertExpectations(t)
+}
+
+func TestPruneRealmChildren_ServeRequest_Failure(t *testing.T) {
+	h := getPruneTestHandler(t)
+	realm := getPruneTestRealm()
+	realm.Spec.Prune = &keycloakApi.RealmPrune{RealmRoles: true}
+
+	kClient := new(adapter.Mock)
+	kClient.On("ExportRealm", "realm1").Return(getPruneTestRealmExport(), nil)
+	kClient.On("DeleteRealmRole", "realm1", "stale-role").Return(errors.New("fatal"))
+
+	err := h.ServeRequest(context.Background(), realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to prune realm role stale-role")
+
+	kClient = new(adapter.Mock)
+	kClient.On("ExportRealm", "realm1").Return(nil, errors.New("fatal"))
+
+	err = h.ServeRequest(context.Background(), realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to export realm")
+}
+
+func TestPruneRealmChildren_ServeRequest_UserRoles(t *testing.T) {
+	h := getPruneTestHandler(t)
+	realm := getPruneTestRealm()
+	realm.Spec.Prune = &keycloakApi.RealmPrune{RealmRoles: true}
+	realm.Spec.Users = []keycloakApi.User{{Username: "admin", RealmRoles: []string{"stale-role"}}}
+
+	kClient := new(adapter.Mock)
+	kClient.On("ExportRealm", "realm1").Return(getPruneTestRealmExport(), nil)
+
+	require.NoError(t, h.ServeRequest(context.Background(), realm, kClient))
+	kClient.AssertNotCalled(t, "DeleteRealmRole", "realm1", "stale-role")
+}
+
+func TestPruneRealmChildren_ServeRequest_CrossNamespace(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(s))
+
+	h := PruneRealmChildren{client: fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Name: "developer", Namespace: "ns"},
+			Spec: keycloakApi.KeycloakRealmRoleSpec{Realm: "realm", Name: "developer"}},
+		&keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Name: "stale-role", Namespace: "team-a"},
+			Spec: keycloakApi.KeycloakRealmRoleSpec{Realm: "realm", RealmNamespace: "ns", Name: "stale-role"}},
+		&keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Namespace: "team-b"},
+			Spec: keycloakApi.KeycloakRealmRoleSpec{Realm: "realm", RealmNamespace: "ns", Name: "team-b-role"}},
+		&keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Name: "viewer", Namespace: "team-a"},
+			Spec: keycloakApi.KeycloakRealmRoleSpec{Realm: "realm", Name: "viewer"}},
+	).Build()}
+
+	realm := getPruneTestRealm()
+	realm.Annotations = map[string]string{keycloakApi.AllowedNamespacesAnnotation: "team-a"}
+	realm.Spec.DefaultRoles = nil
+	realm.Spec.Prune = &keycloakApi.RealmPrune{RealmRoles: true}
+
+	export := getPruneTestRealmExport()
+	export.Roles.Realm = append(export.Roles.Realm, gocloak.Role{Name: gocloak.StringP("team-b-role")})
+
+	kClient := new(adapter.Mock)
+	kClient.On("ExportRealm", "realm1").Return(export, nil)
+	kClient.On("DeleteRealmRole", "realm1", "viewer").Return(nil).Once()
+	kClient.On("DeleteRealmRole", "realm1", "team-b-role").Return(nil).Once()
+
+	require.NoError(t, h.ServeRequest(context.Background(), realm, kClient))
+	kClient.AssertExpectations(t)
+	kClient.AssertNumberOfCalls(t, "DeleteRealmRole", 2)
+}
+
+func TestPruneRealmChildren_ServeRequest_RealmSelector(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(s))
+
+	h := PruneRealmChildren{client: fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Name: "developer", Namespace: "ns"},
+			Spec: keycloakApi.KeycloakRealmRoleSpec{Name: "developer",
+				RealmSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "shared"}}}},
+		&keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Name: "stale-role", Namespace: "ns"},
+			Spec: keycloakApi.KeycloakRealmRoleSpec{Realm: "realm", Name: "stale-role",
+				RealmSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "dev"}}}},
+		&keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Name: "viewer", Namespace: "ns"},
+			Spec: keycloakApi.KeycloakRealmRoleSpec{Realm: "realm", Name: "viewer"}},
+	).Build()}
+
+	realm := getPruneTestRealm()
+	realm.Labels = map[string]string{"tier": "shared"}
+	realm.Spec.DefaultRoles = nil
+	realm.Spec.Prune = &keycloakApi.RealmPrune{RealmRoles: true}
+
+	kClient := new(adapter.Mock)
+	kClient.On("ExportRealm", "realm1").Return(getPruneTestRealmExport(), nil)
+	kClient.On("DeleteRealmRole", "realm1", "stale-role").Return(nil).Once()
+
+	require.NoError(t, h.ServeRequest(context.Background(), realm, kClient))
+	kClient.AssertExpectations(t)
+	kClient.AssertNumberOfCalls(t, "DeleteRealmRole", 1)
+}
@@ -0,0 +1,78 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+)
+
+// PutRealmLocalization syncs the message bundle overrides of the realm locales.
+type PutRealmLocalization struct {
+	next   handler.RealmHandler
+	client client.Client
+}
+
+func (h PutRealmLocalization) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm,
+	kClient keycloak.Client) error {
+	if realm.Spec.Localization == nil || len(realm.Spec.Localization.Messages) == 0 {
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+	rLog.Info("Start putting realm localization messages")
+
+	locales := make(map[string]struct{}, len(realm.Spec.Localization.Messages))
+
+	for i := range realm.Spec.Localization.Messages {
+		bundle := &realm.Spec.Localization.Messages[i]
+
+		if _, ok := locales[bundle.Locale]; ok {
+			return errors.Errorf("messages of the locale %s are declared more than once", bundle.Locale)
+		}
+
+		locales[bundle.Locale] = struct{}{}
+
+		texts, err := h.getTexts(ctx, realm.Namespace, bundle)
+		if err != nil {
+			return err
+		}
+
+		if err := kClient.SyncRealmLocalizationTexts(ctx, realm.Spec.RealmName, bundle.Locale, texts); err != nil {
+			return errors.Wrapf(err, "unable to sync localization texts of the locale %s", bundle.Locale)
+		}
+	}
+
+	rLog.Info("End putting realm localization messages")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+// getTexts merges the config map messages with the inline texts of the bundle.
+func (h PutRealmLocalization) getTexts(ctx context.Context, namespace string,
+	bundle *keycloakApi.RealmMessageBundle) (map[string]string, error) {
+	texts := make(map[string]string)
+
+	if bundle.ConfigMap != "" {
+		var cm coreV1.ConfigMap
+		if err := h.client.Get(ctx, types.NamespacedName{Name: bundle.ConfigMap, Namespace: namespace}, &cm); err != nil {
+			return nil, errors.Wrapf(err, "unable to get messages config map %s", bundle.ConfigMap)
+		}
+
+		for k, v := range cm.Data {
+			texts[k] = v
+		}
+	}
+
+	for k, v := range bundle.Texts {
+		texts[k] = v
+	}
+
+	return texts, nil
+}
@@ -0,0 +1,62 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutRealmLocalization_ServeRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(coreV1.AddToScheme(scheme))
+
+	cm := coreV1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "messages-de", Namespace: "ns"},
+		Data: map[string]string{"loginTitle": "Anmelden", "doLogIn": "Einloggen"}}
+	h := PutRealmLocalization{client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&cm).Build()}
+	kClient := new(adapter.Mock)
+
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			Localization: &keycloakApi.RealmLocalization{
+				SupportedLocales: []string{"en", "de"},
+				Messages: []keycloakApi.RealmMessageBundle{
+					{Locale: "en", Texts: map[string]string{"loginTitle": "Sign in"}},
+					{Locale: "de", ConfigMap: "messages-de", Texts: map[string]string{"doLogIn": "Anmelden"}},
+				},
+			},
+		},
+	}
+
+	kClient.On("SyncRealmLocalizationTexts", "realm1", "en",
+		map[string]string{"loginTitle": "Sign in"}).Return(nil)
+	kClient.On("SyncRealmLocalizationTexts", "realm1", "de",
+		map[string]string{"loginTitle": "Anmelden", "doLogIn": "Anmelden"}).Return(nil).Once()
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+
+	kClient.On("SyncRealmLocalizationTexts", "realm1", "de", map[string]string{"loginTitle": "Anmelden",
+		"doLogIn": "Anmelden"}).Return(errors.New("fatal")).Once()
+
+	err := h.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to sync localization texts of the locale de")
+
+	realm.Spec.Localization.Messages[1].ConfigMap = "missing"
+
+	err = h.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to get messages config map missing")
+}
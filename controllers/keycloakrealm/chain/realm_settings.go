@@ -2,17 +2,25 @@ package chain
 
 import (
 	"context"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
 type RealmSettings struct {
-	next handler.RealmHandler
+	next   handler.RealmHandler
+	client client.Client
 }
 
 func (h RealmSettings) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
@@ -27,18 +35,23 @@ func (h RealmSettings) ServeRequest(ctx context.Context, realm *keycloakApi.Keyc
 			EventsEnabled:             realm.Spec.RealmEventConfig.EventsEnabled,
 			EventsExpiration:          realm.Spec.RealmEventConfig.EventsExpiration,
 			EventsListeners:           realm.Spec.RealmEventConfig.EventsListeners,
+			AdminEventsExpiration:     realm.Spec.RealmEventConfig.AdminEventsExpiration,
 		}); err != nil {
 			return errors.Wrap(err, "unable to set realm event config")
 		}
 	}
 
-	if realm.Spec.BrowserSecurityHeaders == nil && realm.Spec.Themes == nil && len(realm.Spec.PasswordPolicies) == 0 {
+	if !hasRealmSettings(&realm.Spec) {
 		rLog.Info("Realm settings is not set, exit.")
 		return nextServeOrNil(ctx, h.next, realm, kClient)
 	}
 
 	settings := adapter.RealmSettings{}
 	if realm.Spec.Themes != nil {
+		if err := validateRealmThemes(ctx, kClient, realm.Spec.Themes); err != nil {
+			return err
+		}
+
 		settings.Themes = &adapter.RealmThemes{
 			InternationalizationEnabled: realm.Spec.Themes.InternationalizationEnabled,
 			EmailTheme:                  realm.Spec.Themes.EmailTheme,
@@ -48,12 +61,104 @@ func (h RealmSettings) ServeRequest(ctx context.Context, realm *keycloakApi.Keyc
 		}
 	}
 
-	if realm.Spec.BrowserSecurityHeaders != nil {
-		settings.BrowserSecurityHeaders = realm.Spec.BrowserSecurityHeaders
+	if realm.Spec.BrowserSecurityHeaders != nil || realm.Spec.SecurityDefenses != nil {
+		headers, err := makeBrowserSecurityHeaders(realm.Spec.BrowserSecurityHeaders, realm.Spec.SecurityDefenses)
+		if err != nil {
+			return err
+		}
+
+		settings.BrowserSecurityHeaders = headers
 	}
 
-	if len(realm.Spec.PasswordPolicies) > 0 {
-		settings.PasswordPolicies = h.makePasswordPolicies(realm.Spec.PasswordPolicies)
+	if len(realm.Spec.PasswordPolicies) > 0 || realm.Spec.PasswordPolicySettings != nil {
+		policies, err := h.makePasswordPolicies(ctx, kClient, realm.Spec.PasswordPolicies,
+			realm.Spec.PasswordPolicySettings)
+		if err != nil {
+			return err
+		}
+
+		settings.PasswordPolicies = policies
+	}
+
+	if realm.Spec.SMTP != nil {
+		smtp, err := h.makeSMTP(ctx, realm)
+		if err != nil {
+			return err
+		}
+
+		settings.SMTP = smtp
+	}
+
+	if bf := realm.Spec.BruteForceProtection; bf != nil {
+		settings.BruteForceProtection = &adapter.RealmBruteForceProtection{
+			Enabled:                      bf.Enabled,
+			PermanentLockout:             bf.PermanentLockout,
+			MaxLoginFailures:             bf.MaxLoginFailures,
+			WaitIncrementSeconds:         bf.WaitIncrementSeconds,
+			MaxFailureWaitSeconds:        bf.MaxFailureWaitSeconds,
+			FailureResetTimeSeconds:      bf.FailureResetTimeSeconds,
+			QuickLoginCheckMilliSeconds:  bf.QuickLoginCheckMilliSeconds,
+			MinimumQuickLoginWaitSeconds: bf.MinimumQuickLoginWaitSeconds,
+		}
+	}
+
+	if ts := realm.Spec.TokenSettings; ts != nil {
+		settings.TokenSettings = &adapter.RealmTokenSettings{
+			AccessTokenLifespan:              ts.AccessTokenLifespan,
+			SsoSessionIdleTimeout:            ts.SsoSessionIdleTimeout,
+			SsoSessionMaxLifespan:            ts.SsoSessionMaxLifespan,
+			OfflineSessionIdleTimeout:        ts.OfflineSessionIdleTimeout,
+			OfflineSessionMaxLifespanEnabled: ts.OfflineSessionMaxLifespanEnabled,
+			OfflineSessionMaxLifespan:        ts.OfflineSessionMaxLifespan,
+			RevokeRefreshToken:               ts.RevokeRefreshToken,
+			RefreshTokenMaxReuse:             ts.RefreshTokenMaxReuse,
+		}
+	}
+
+	if realm.Spec.Localization != nil {
+		localization, err := makeLocalization(&realm.Spec)
+		if err != nil {
+			return err
+		}
+
+		settings.Localization = localization
+	}
+
+	if realm.Spec.FrontendURL != nil {
+		if err := validateRealmURL("frontend", *realm.Spec.FrontendURL); err != nil {
+			return err
+		}
+
+		settings.FrontendURL = realm.Spec.FrontendURL
+	}
+
+	if realm.Spec.AdminURL != nil {
+		if err := validateRealmURL("admin", *realm.Spec.AdminURL); err != nil {
+			return err
+		}
+
+		settings.AdminURL = realm.Spec.AdminURL
+	}
+
+	if ciba := realm.Spec.CIBAPolicy; ciba != nil {
+		settings.CIBAPolicy = &adapter.RealmCIBAPolicy{
+			BackchannelTokenDeliveryMode: ciba.BackchannelTokenDeliveryMode,
+			ExpiresIn:                    ciba.ExpiresIn,
+			Interval:                     ciba.Interval,
+			AuthRequestedUserHint:        ciba.AuthRequestedUserHint,
+		}
+	}
+
+	if hasLoginSettings(&realm.Spec) {
+		settings.LoginSettings = &adapter.RealmLoginSettings{
+			RegistrationAllowed:         realm.Spec.RegistrationAllowed,
+			RegistrationEmailAsUsername: realm.Spec.RegistrationEmailAsUsername,
+			VerifyEmail:                 realm.Spec.VerifyEmail,
+			LoginWithEmailAllowed:       realm.Spec.LoginWithEmail,
+			RememberMe:                  realm.Spec.RememberMe,
+			ResetPasswordAllowed:        realm.Spec.ResetPasswordAllowed,
+			EditUsernameAllowed:         realm.Spec.EditUsernameAllowed,
+		}
 	}
 
 	if err := kClient.UpdateRealmSettings(realm.Spec.RealmName, &settings); err != nil {
@@ -65,11 +170,255 @@ func (h RealmSettings) ServeRequest(ctx context.Context, realm *keycloakApi.Keyc
 	return nextServeOrNil(ctx, h.next, realm, kClient)
 }
 
-func (h RealmSettings) makePasswordPolicies(policiesSpec []keycloakApi.PasswordPolicy) []adapter.PasswordPolicy {
+// hasRealmSettings checks if any of the settings updated by the realm settings handler is set.
+func hasRealmSettings(spec *keycloakApi.KeycloakRealmSpec) bool {
+	return spec.BrowserSecurityHeaders != nil ||
+		spec.SecurityDefenses != nil ||
+		spec.Themes != nil ||
+		len(spec.PasswordPolicies) > 0 ||
+		spec.PasswordPolicySettings != nil ||
+		spec.SMTP != nil ||
+		spec.BruteForceProtection != nil ||
+		spec.TokenSettings != nil ||
+		spec.Localization != nil ||
+		spec.FrontendURL != nil ||
+		spec.AdminURL != nil ||
+		spec.CIBAPolicy != nil ||
+		hasLoginSettings(spec)
+}
+
+func hasLoginSettings(spec *keycloakApi.KeycloakRealmSpec) bool {
+	return spec.RegistrationAllowed != nil ||
+		spec.RegistrationEmailAsUsername != nil ||
+		spec.VerifyEmail != nil ||
+		spec.LoginWithEmail != nil ||
+		spec.RememberMe != nil ||
+		spec.ResetPasswordAllowed != nil ||
+		spec.EditUsernameAllowed != nil
+}
+
+// makeBrowserSecurityHeaders merges the browser security headers map with the typed security defenses headers.
+func makeBrowserSecurityHeaders(headersSpec *map[string]string,
+	defenses *keycloakApi.RealmSecurityDefenses) (*map[string]string, error) {
+	headers := make(map[string]string)
+
+	if headersSpec != nil {
+		for k, v := range *headersSpec {
+			headers[k] = v
+		}
+	}
+
+	if defenses == nil || defenses.Headers == nil {
+		return &headers, nil
+	}
+
+	typed := defenses.Headers
+
+	for name, value := range map[string]*string{
+		"contentSecurityPolicy":           typed.ContentSecurityPolicy,
+		"contentSecurityPolicyReportOnly": typed.ContentSecurityPolicyReportOnly,
+		"xFrameOptions":                   typed.XFrameOptions,
+		"strictTransportSecurity":         typed.StrictTransportSecurity,
+		"xContentTypeOptions":             typed.XContentTypeOptions,
+		"xRobotsTag":                      typed.XRobotsTag,
+		"xXSSProtection":                  typed.XXSSProtection,
+		"referrerPolicy":                  typed.ReferrerPolicy,
+	} {
+		if value == nil {
+			continue
+		}
+
+		if current, ok := headers[name]; ok && current != *value {
+			return nil, errors.Errorf("browser security header %s has different values in browserSecurityHeaders "+
+				"and securityDefenses", name)
+		}
+
+		headers[name] = *value
+	}
+
+	return &headers, nil
+}
+
+// makeLocalization converts the localization spec to the adapter settings, the internationalization is enabled by it.
+func makeLocalization(spec *keycloakApi.KeycloakRealmSpec) (*adapter.RealmLocalization, error) {
+	if spec.Themes != nil && spec.Themes.InternationalizationEnabled != nil && !*spec.Themes.InternationalizationEnabled {
+		return nil, errors.New("localization can not be set if internationalization is disabled in themes")
+	}
+
+	localization := spec.Localization
+	if localization.DefaultLocale != "" && !helper.ContainsString(localization.SupportedLocales, localization.DefaultLocale) {
+		return nil, errors.Errorf("default locale %s is not in the supported locales", localization.DefaultLocale)
+	}
+
+	return &adapter.RealmLocalization{
+		SupportedLocales: localization.SupportedLocales,
+		DefaultLocale:    localization.DefaultLocale,
+	}, nil
+}
+
+// validateRealmThemes checks that the realm themes are available in keycloak, empty themes use the keycloak defaults.
+func validateRealmThemes(ctx context.Context, kClient keycloak.Client, themes *keycloakApi.RealmThemes) error {
+	declared := []struct {
+		themeType string
+		name      *string
+	}{
+		{themeType: adapter.ThemeTypeLogin, name: themes.LoginTheme},
+		{themeType: adapter.ThemeTypeAccount, name: themes.AccountTheme},
+		{themeType: adapter.ThemeTypeAdmin, name: themes.AdminConsoleTheme},
+		{themeType: adapter.ThemeTypeEmail, name: themes.EmailTheme},
+	}
+
+	var serverThemes adapter.ServerThemes
+
+	for _, d := range declared {
+		if d.name == nil || *d.name == "" {
+			continue
+		}
+
+		if serverThemes == nil {
+			var err error
+			if serverThemes, err = kClient.GetServerThemes(ctx); err != nil {
+				return errors.Wrap(err, "unable to get keycloak themes")
+			}
+		}
+
+		if !serverThemes.Has(d.themeType, *d.name) {
+			return errors.Errorf("%s theme %s is not available in keycloak, available themes: %s", d.themeType,
+				*d.name, strings.Join(serverThemes.Names(d.themeType), ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateRealmURL checks that the realm URL is empty or an absolute http or https URL.
+func validateRealmURL(name, realmURL string) error {
+	if realmURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(realmURL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid %s url %s", name, realmURL)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("invalid %s url %s, it must be an absolute http or https url", name, realmURL)
+	}
+
+	return nil
+}
+
+// makePasswordPolicies converts either the password policy list or the typed password policy to the adapter policies.
+// The result is never nil, so the empty typed policy removes all the realm password policies.
+// The policies of the list are validated against the policies available in keycloak, including the custom ones.
+func (h RealmSettings) makePasswordPolicies(ctx context.Context, kClient keycloak.Client,
+	policiesSpec []keycloakApi.PasswordPolicy,
+	settings *keycloakApi.RealmPasswordPolicy) ([]adapter.PasswordPolicy, error) {
+	if settings != nil {
+		if len(policiesSpec) > 0 {
+			return nil, errors.New("passwordPolicy and passwordPolicySettings can not be used together")
+		}
+
+		return makeTypedPasswordPolicies(settings), nil
+	}
+
+	serverPolicies, err := kClient.GetServerPasswordPolicies(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get keycloak password policies")
+	}
+
+	known := make(map[string]struct{}, len(serverPolicies))
+	for _, id := range serverPolicies {
+		known[id] = struct{}{}
+	}
+
 	policies := make([]adapter.PasswordPolicy, len(policiesSpec))
 	for i, v := range policiesSpec {
+		if _, ok := known[v.Type]; !ok {
+			return nil, errors.Errorf("unknown password policy %s, available policies: %s", v.Type,
+				strings.Join(serverPolicies, ", "))
+		}
+
 		policies[i] = adapter.PasswordPolicy{Type: v.Type, Value: v.Value}
 	}
 
+	return policies, nil
+}
+
+func makeTypedPasswordPolicies(settings *keycloakApi.RealmPasswordPolicy) []adapter.PasswordPolicy {
+	policies := make([]adapter.PasswordPolicy, 0)
+
+	addInt := func(policyType string, value *int) {
+		if value != nil {
+			policies = append(policies, adapter.PasswordPolicy{Type: policyType, Value: strconv.Itoa(*value)})
+		}
+	}
+
+	addString := func(policyType, value string) {
+		if value != "" {
+			policies = append(policies, adapter.PasswordPolicy{Type: policyType, Value: value})
+		}
+	}
+
+	addBool := func(policyType string, value bool) {
+		if value {
+			policies = append(policies, adapter.PasswordPolicy{Type: policyType, Value: "undefined"})
+		}
+	}
+
+	addInt("length", settings.Length)
+	addInt("maxLength", settings.MaxLength)
+	addInt("digits", settings.Digits)
+	addInt("lowerCase", settings.LowerCase)
+	addInt("upperCase", settings.UpperCase)
+	addInt("specialChars", settings.SpecialChars)
+	addBool("notUsername", settings.NotUsername)
+	addBool("notEmail", settings.NotEmail)
+	addInt("passwordHistory", settings.PasswordHistory)
+	addInt("forceExpiredPasswordChange", settings.ForceExpiredPasswordChange)
+	addInt("hashIterations", settings.HashIterations)
+	addString("hashAlgorithm", settings.HashAlgorithm)
+	addString("regexPattern", settings.RegexPattern)
+	addString("passwordBlacklist", settings.PasswordBlacklist)
+
 	return policies
 }
+
+// makeSMTP converts the smtp spec to the adapter settings, the password is read from the referenced secret.
+func (h RealmSettings) makeSMTP(ctx context.Context, realm *keycloakApi.KeycloakRealm) (*adapter.RealmSMTP, error) {
+	spec := realm.Spec.SMTP
+	smtp := adapter.RealmSMTP{
+		Host:               spec.Host,
+		Port:               spec.Port,
+		From:               spec.From,
+		FromDisplayName:    spec.FromDisplayName,
+		ReplyTo:            spec.ReplyTo,
+		ReplyToDisplayName: spec.ReplyToDisplayName,
+		EnvelopeFrom:       spec.EnvelopeFrom,
+		SSL:                spec.SSL,
+		StartTLS:           spec.StartTLS,
+	}
+
+	if spec.Auth == nil {
+		return &smtp, nil
+	}
+
+	ref := spec.Auth.PasswordSecret
+
+	var secret coreV1.Secret
+	if err := h.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: realm.Namespace}, &secret); err != nil {
+		return nil, errors.Wrapf(err, "unable to get smtp password secret %s", ref.Name)
+	}
+
+	password, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, errors.Errorf("smtp password secret %s does not contain key %s", ref.Name, ref.Key)
+	}
+
+	smtp.Auth = true
+	smtp.User = spec.Auth.Username
+	smtp.Password = string(password)
+
+	return &smtp, nil
+}
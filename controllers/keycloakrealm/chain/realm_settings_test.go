@@ -5,8 +5,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
@@ -36,11 +42,15 @@ func TestRealmSettings_ServeRequest(t *testing.T) {
 				EventsListeners: []string{"foo", "bar"},
 			},
 			PasswordPolicies: []keycloakApi.PasswordPolicy{
-				{Type: "foo", Value: "bar"},
+				{Type: "length", Value: "8"},
 			},
 		},
 	}
 
+	kClient.On("GetServerThemes").Return(adapter.ServerThemes{
+		adapter.ThemeTypeLogin: {"keycloak": {}, "LoginTheme test": {}},
+	}, nil)
+	kClient.On("GetServerPasswordPolicies").Return([]string{"digits", "length"}, nil)
 	kClient.On("UpdateRealmSettings", realm.Spec.RealmName, &adapter.RealmSettings{
 		Themes: &adapter.RealmThemes{
 			LoginTheme: &theme,
@@ -49,7 +59,7 @@ func TestRealmSettings_ServeRequest(t *testing.T) {
 			"foo": "bar",
 		},
 		PasswordPolicies: []adapter.PasswordPolicy{
-			{Type: "foo", Value: "bar"},
+			{Type: "length", Value: "8"},
 		},
 	}).Return(nil)
 
@@ -73,3 +83,342 @@ func TestRealmSettings_ServeRequest(t *testing.T) {
 
 	kClient.AssertExpectations(t)
 }
+
+func TestRealmSettings_ServeRequest_SMTP(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(coreV1.AddToScheme(scheme))
+
+	secret := coreV1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "smtp", Namespace: "ns"},
+		Data: map[string][]byte{"password": []byte("secret")}}
+	rs := RealmSettings{client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&secret).Build()}
+	kClient := new(adapter.Mock)
+	ctx := context.Background()
+
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			SMTP: &keycloakApi.RealmSMTP{
+				Host:     "smtp.example.com",
+				Port:     587,
+				From:     "noreply@example.com",
+				StartTLS: true,
+				Auth: &keycloakApi.SMTPAuth{
+					Username:       "mailer",
+					PasswordSecret: keycloakApi.SecretKeyRef{Name: "smtp", Key: "password"},
+				},
+			},
+		},
+	}
+
+	kClient.On("UpdateRealmSettings", "realm1", &adapter.RealmSettings{
+		SMTP: &adapter.RealmSMTP{
+			Host:     "smtp.example.com",
+			Port:     587,
+			From:     "noreply@example.com",
+			StartTLS: true,
+			Auth:     true,
+			User:     "mailer",
+			Password: "secret",
+		},
+	}).Return(nil).Once()
+
+	require.NoError(t, rs.ServeRequest(ctx, &realm, kClient))
+	kClient.AssertExpectations(t)
+
+	realm.Spec.SMTP.Auth.PasswordSecret.Key = "missing"
+
+	err := rs.ServeRequest(ctx, &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "smtp password secret smtp does not contain key missing")
+}
+
+func TestRealmSettings_ServeRequest_PasswordPolicySettings(t *testing.T) {
+	rs := RealmSettings{}
+	kClient := new(adapter.Mock)
+	ctx := context.Background()
+	length, history := 12, 3
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			PasswordPolicySettings: &keycloakApi.RealmPasswordPolicy{
+				Length:          &length,
+				NotUsername:     true,
+				PasswordHistory: &history,
+				HashAlgorithm:   "pbkdf2-sha256",
+			},
+		},
+	}
+
+	kClient.On("UpdateRealmSettings", "realm1", &adapter.RealmSettings{
+		PasswordPolicies: []adapter.PasswordPolicy{
+			{Type: "length", Value: "12"},
+			{Type: "notUsername", Value: "undefined"},
+			{Type: "passwordHistory", Value: "3"},
+			{Type: "hashAlgorithm", Value: "pbkdf2-sha256"},
+		},
+	}).Return(nil).Once()
+
+	require.NoError(t, rs.ServeRequest(ctx, &realm, kClient))
+
+	realm.Spec.PasswordPolicySettings = &keycloakApi.RealmPasswordPolicy{}
+
+	kClient.On("UpdateRealmSettings", "realm1", &adapter.RealmSettings{
+		PasswordPolicies: []adapter.PasswordPolicy{},
+	}).Return(nil).Once()
+
+	require.NoError(t, rs.ServeRequest(ctx, &realm, kClient))
+	kClient.AssertExpectations(t)
+
+	realm.Spec.PasswordPolicies = []keycloakApi.PasswordPolicy{{Type: "length", Value: "8"}}
+
+	err := rs.ServeRequest(ctx, &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "passwordPolicy and passwordPolicySettings can not be used together")
+
+	realm.Spec.PasswordPolicySettings = nil
+	realm.Spec.PasswordPolicies = []keycloakApi.PasswordPolicy{
+		{Type: "custom-policy", Value: "8"},
+		{Type: "minLength", Value: "8"},
+	}
+
+	kClient.On("GetServerPasswordPolicies").Return([]string{"custom-policy", "length"}, nil).Once()
+
+	err = rs.ServeRequest(ctx, &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown password policy minLength, available policies: custom-policy, length")
+
+	kClient.On("GetServerPasswordPolicies").Return(nil, errors.New("connection refused")).Once()
+
+	err = rs.ServeRequest(ctx, &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to get keycloak password policies")
+}
+
+func TestRealmSettings_ServeRequest_BruteForceProtection(t *testing.T) {
+	rs := RealmSettings{}
+	kClient := new(adapter.Mock)
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			BruteForceProtection: &keycloakApi.RealmBruteForceProtection{
+				Enabled:              true,
+				PermanentLockout:     true,
+				MaxLoginFailures:     3,
+				WaitIncrementSeconds: 60,
+			},
+		},
+	}
+
+	kClient.On("UpdateRealmSettings", "realm1", &adapter.RealmSettings{
+		BruteForceProtection: &adapter.RealmBruteForceProtection{
+			Enabled:              true,
+			PermanentLockout:     true,
+			MaxLoginFailures:     3,
+			WaitIncrementSeconds: 60,
+		},
+	}).Return(nil)
+
+	require.NoError(t, rs.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+}
+
+func TestRealmSettings_ServeRequest_LoginSettings(t *testing.T) {
+	rs := RealmSettings{}
+	kClient := new(adapter.Mock)
+	enabled, disabled := true, false
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:      "realm1",
+			LoginWithEmail: &enabled,
+			RememberMe:     &disabled,
+		},
+	}
+
+	kClient.On("UpdateRealmSettings", "realm1", &adapter.RealmSettings{
+		LoginSettings: &adapter.RealmLoginSettings{
+			LoginWithEmailAllowed: &enabled,
+			RememberMe:            &disabled,
+		},
+	}).Return(nil)
+
+	require.NoError(t, rs.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+}
+
+func TestRealmSettings_ServeRequest_SecurityDefenses(t *testing.T) {
+	rs := RealmSettings{}
+	kClient := new(adapter.Mock)
+	frameOptions, hsts := "DENY", "max-age=31536000"
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:              "realm1",
+			BrowserSecurityHeaders: &map[string]string{"xRobotsTag": "none"},
+			SecurityDefenses: &keycloakApi.RealmSecurityDefenses{
+				Headers: &keycloakApi.RealmSecurityHeaders{
+					XFrameOptions:           &frameOptions,
+					StrictTransportSecurity: &hsts,
+				},
+			},
+		},
+	}
+
+	kClient.On("UpdateRealmSettings", "realm1", &adapter.RealmSettings{
+		BrowserSecurityHeaders: &map[string]string{
+			"xRobotsTag":              "none",
+			"xFrameOptions":           "DENY",
+			"strictTransportSecurity": "max-age=31536000",
+		},
+	}).Return(nil)
+
+	require.NoError(t, rs.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+	require.Equal(t, map[string]string{"xRobotsTag": "none"}, *realm.Spec.BrowserSecurityHeaders)
+
+	realm.Spec.BrowserSecurityHeaders = &map[string]string{"xFrameOptions": "SAMEORIGIN"}
+
+	err := rs.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "browser security header xFrameOptions has different values")
+}
+
+func TestRealmSettings_ServeRequest_Localization(t *testing.T) {
+	rs := RealmSettings{}
+	kClient := new(adapter.Mock)
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			Localization: &keycloakApi.RealmLocalization{
+				SupportedLocales: []string{"en", "de"},
+				DefaultLocale:    "en",
+			},
+		},
+	}
+
+	kClient.On("UpdateRealmSettings", "realm1", &adapter.RealmSettings{
+		Localization: &adapter.RealmLocalization{
+			SupportedLocales: []string{"en", "de"},
+			DefaultLocale:    "en",
+		},
+	}).Return(nil)
+
+	require.NoError(t, rs.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+
+	realm.Spec.Localization.DefaultLocale = "fr"
+
+	err := rs.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "default locale fr is not in the supported locales")
+}
+
+func TestRealmSettings_ServeRequest_FrontendURL(t *testing.T) {
+	rs := RealmSettings{}
+	kClient := new(adapter.Mock)
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:   "realm1",
+			FrontendURL: gocloak.StringP("https://sso.example.com/auth"),
+			AdminURL:    gocloak.StringP(""),
+		},
+	}
+
+	kClient.On("UpdateRealmSettings", "realm1", &adapter.RealmSettings{
+		FrontendURL: gocloak.StringP("https://sso.example.com/auth"),
+		AdminURL:    gocloak.StringP(""),
+	}).Return(nil)
+
+	require.NoError(t, rs.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+
+	for _, frontendURL := range []string{"sso.example.com", "ftp://sso.example.com", "https://"} {
+		realm.Spec.FrontendURL = gocloak.StringP(frontendURL)
+
+		err := rs.ServeRequest(context.Background(), &realm, kClient)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid frontend url")
+	}
+
+	realm.Spec.FrontendURL = nil
+	realm.Spec.AdminURL = gocloak.StringP("admin.example.com")
+
+	err := rs.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid admin url")
+}
+
+func TestRealmSettings_ServeRequest_CIBAPolicy(t *testing.T) {
+	rs := RealmSettings{}
+	kClient := new(adapter.Mock)
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			CIBAPolicy: &keycloakApi.RealmCIBAPolicy{
+				BackchannelTokenDeliveryMode: "ping",
+				ExpiresIn:                    300,
+				Interval:                     10,
+				AuthRequestedUserHint:        "login_hint",
+			},
+		},
+	}
+
+	kClient.On("UpdateRealmSettings", "realm1", &adapter.RealmSettings{
+		CIBAPolicy: &adapter.RealmCIBAPolicy{
+			BackchannelTokenDeliveryMode: "ping",
+			ExpiresIn:                    300,
+			Interval:                     10,
+			AuthRequestedUserHint:        "login_hint",
+		},
+	}).Return(nil)
+
+	require.NoError(t, rs.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+}
+
+func TestRealmSettings_ServeRequest_Themes(t *testing.T) {
+	rs := RealmSettings{}
+	kClient := new(adapter.Mock)
+	kClient.On("GetServerThemes").Return(adapter.ServerThemes{
+		adapter.ThemeTypeLogin:   {"keycloak": {}, "custom": {}},
+		adapter.ThemeTypeAccount: {"keycloak.v2": {}},
+		adapter.ThemeTypeEmail:   {"keycloak": {}},
+	}, nil)
+
+	loginTheme, accountTheme, adminTheme, emailTheme := "custom", "keycloak.v2", "", "unknown"
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			Themes: &keycloakApi.RealmThemes{
+				LoginTheme:        &loginTheme,
+				AccountTheme:      &accountTheme,
+				AdminConsoleTheme: &adminTheme,
+				EmailTheme:        &emailTheme,
+			},
+		},
+	}
+
+	err := rs.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "email theme unknown is not available in keycloak, available themes: keycloak")
+
+	emailTheme = "keycloak"
+
+	kClient.On("UpdateRealmSettings", "realm1", &adapter.RealmSettings{
+		Themes: &adapter.RealmThemes{
+			LoginTheme:        &loginTheme,
+			AccountTheme:      &accountTheme,
+			AdminConsoleTheme: &adminTheme,
+			EmailTheme:        &emailTheme,
+		},
+	}).Return(nil)
+
+	require.NoError(t, rs.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+}
@@ -0,0 +1,219 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm/chain/handler"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// requiredUserProfileAttributes are the attributes which keycloak requires in the user profile.
+var requiredUserProfileAttributes = []string{"username", "email"}
+
+// PutUserProfile syncs the realm user profile, the profile is updated only if it differs from the keycloak one.
+type PutUserProfile struct {
+	next handler.RealmHandler
+}
+
+func (h PutUserProfile) ServeRequest(ctx context.Context, realm *keycloakApi.KeycloakRealm, kClient keycloak.Client) error {
+	if realm.Spec.UserProfileConfig == nil {
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	rLog := log.WithValues("realm name", realm.Spec.RealmName)
+	rLog.Info("Start putting realm user profile")
+
+	cfg, err := makeUserProfileConfig(realm.Spec.UserProfileConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := checkUserProfileSupport(ctx, kClient); err != nil {
+		return err
+	}
+
+	current, err := kClient.GetUserProfileConfig(ctx, realm.Spec.RealmName)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm user profile")
+	}
+
+	changed, err := isUserProfileChanged(current, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		rLog.Info("Realm user profile is not changed")
+
+		return nextServeOrNil(ctx, h.next, realm, kClient)
+	}
+
+	if err := kClient.UpdateUserProfileConfig(ctx, realm.Spec.RealmName, cfg); err != nil {
+		return errors.Wrap(err, "unable to update realm user profile")
+	}
+
+	rLog.Info("End putting realm user profile")
+
+	return nextServeOrNil(ctx, h.next, realm, kClient)
+}
+
+// checkUserProfileSupport checks that the connected keycloak supports the declarative user profile.
+func checkUserProfileSupport(ctx context.Context, kClient keycloak.Client) error {
+	version, err := kClient.GetServerVersion(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to get keycloak version")
+	}
+
+	major, err := adapter.ServerMajorVersion(version)
+	if err != nil {
+		return err
+	}
+
+	if major < adapter.UserProfileMinKCVersion {
+		return errors.Errorf("user profile requires keycloak %d or newer, connected keycloak version is %s",
+			adapter.UserProfileMinKCVersion, version)
+	}
+
+	return nil
+}
+
+func makeUserProfileConfig(spec *keycloakApi.UserProfileConfig) (*adapter.UserProfileConfig, error) {
+	cfg := adapter.UserProfileConfig{
+		UnmanagedAttributePolicy: spec.UnmanagedAttributePolicy,
+		Attributes:               make([]adapter.UserProfileAttribute, 0, len(spec.Attributes)),
+	}
+
+	declared := make(map[string]struct{}, len(spec.Attributes))
+
+	for i := range spec.Attributes {
+		attr := &spec.Attributes[i]
+
+		if _, ok := declared[attr.Name]; ok {
+			return nil, errors.Errorf("user profile attribute %s is declared more than once", attr.Name)
+		}
+
+		declared[attr.Name] = struct{}{}
+
+		validations, err := makeUserProfileValidations(attr.Validations)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validations of the user profile attribute %s", attr.Name)
+		}
+
+		a := adapter.UserProfileAttribute{
+			Name:        attr.Name,
+			DisplayName: attr.DisplayName,
+			Group:       attr.Group,
+			Multivalued: attr.Multivalued,
+			Validations: validations,
+			Annotations: makeUserProfileAnnotations(attr.Annotations),
+		}
+
+		if attr.Required != nil {
+			a.Required = &adapter.UserProfileAttributeRequired{Roles: attr.Required.Roles, Scopes: attr.Required.Scopes}
+		}
+
+		if attr.Permissions != nil {
+			a.Permissions = &adapter.UserProfileAttributePermissions{
+				View: attr.Permissions.View,
+				Edit: attr.Permissions.Edit,
+			}
+		}
+
+		if attr.Selector != nil {
+			a.Selector = &adapter.UserProfileAttributeSelector{Scopes: attr.Selector.Scopes}
+		}
+
+		cfg.Attributes = append(cfg.Attributes, a)
+	}
+
+	for _, name := range requiredUserProfileAttributes {
+		if _, ok := declared[name]; !ok {
+			return nil, errors.Errorf("user profile must contain the %s attribute", name)
+		}
+	}
+
+	for i := range spec.Groups {
+		cfg.Groups = append(cfg.Groups, adapter.UserProfileGroup{
+			Name:               spec.Groups[i].Name,
+			DisplayHeader:      spec.Groups[i].DisplayHeader,
+			DisplayDescription: spec.Groups[i].DisplayDescription,
+			Annotations:        makeUserProfileAnnotations(spec.Groups[i].Annotations),
+		})
+	}
+
+	return &cfg, nil
+}
+
+func makeUserProfileValidations(
+	spec map[string]map[string]apiextensionsv1.JSON,
+) (map[string]map[string]interface{}, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	validations := make(map[string]map[string]interface{}, len(spec))
+
+	for validator, config := range spec {
+		validations[validator] = make(map[string]interface{}, len(config))
+
+		for k, v := range config {
+			var value interface{}
+			if err := json.Unmarshal(v.Raw, &value); err != nil {
+				return nil, errors.Wrapf(err, "unable to decode %s of the validator %s", k, validator)
+			}
+
+			validations[validator][k] = value
+		}
+	}
+
+	return validations, nil
+}
+
+func makeUserProfileAnnotations(spec map[string]string) map[string]interface{} {
+	if spec == nil {
+		return nil
+	}
+
+	annotations := make(map[string]interface{}, len(spec))
+	for k, v := range spec {
+		annotations[k] = v
+	}
+
+	return annotations
+}
+
+// isUserProfileChanged compares the json representations of the user profiles.
+func isUserProfileChanged(current, desired *adapter.UserProfileConfig) (bool, error) {
+	currentData, err := normalizeJSON(current)
+	if err != nil {
+		return false, err
+	}
+
+	desiredData, err := normalizeJSON(desired)
+	if err != nil {
+		return false, err
+	}
+
+	return !reflect.DeepEqual(currentData, desiredData), nil
+}
+
+func normalizeJSON(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode json")
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, errors.Wrap(err, "unable to decode json")
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,113 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestPutUserProfile_ServeRequest(t *testing.T) {
+	h := PutUserProfile{}
+	kClient := new(adapter.Mock)
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			UserProfileConfig: &keycloakApi.UserProfileConfig{
+				UnmanagedAttributePolicy: "ADMIN_VIEW",
+				Attributes: []keycloakApi.UserProfileAttribute{
+					{Name: "username"},
+					{Name: "email"},
+					{
+						Name:  "department",
+						Group: "work",
+						Validations: map[string]map[string]apiextensionsv1.JSON{
+							"length":  {"max": {Raw: []byte("64")}},
+							"options": {"options": {Raw: []byte(`["dev","ops"]`)}},
+						},
+						Annotations: map[string]string{"inputType": "select"},
+						Permissions: &keycloakApi.UserProfileAttributePermissions{View: []string{"admin", "user"}},
+					},
+				},
+				Groups: []keycloakApi.UserProfileGroup{{Name: "work", DisplayHeader: "Work"}},
+			},
+		},
+	}
+
+	desired := &adapter.UserProfileConfig{
+		UnmanagedAttributePolicy: "ADMIN_VIEW",
+		Attributes: []adapter.UserProfileAttribute{
+			{Name: "username"},
+			{Name: "email"},
+			{
+				Name:  "department",
+				Group: "work",
+				Validations: map[string]map[string]interface{}{
+					"length":  {"max": float64(64)},
+					"options": {"options": []interface{}{"dev", "ops"}},
+				},
+				Annotations: map[string]interface{}{"inputType": "select"},
+				Permissions: &adapter.UserProfileAttributePermissions{View: []string{"admin", "user"}},
+			},
+		},
+		Groups: []adapter.UserProfileGroup{{Name: "work", DisplayHeader: "Work"}},
+	}
+
+	kClient.On("GetServerVersion").Return("24.0.5", nil)
+	kClient.On("GetUserProfileConfig", "realm1").Return(&adapter.UserProfileConfig{
+		Attributes: []adapter.UserProfileAttribute{{Name: "username"}, {Name: "email"}},
+	}, nil).Once()
+	kClient.On("UpdateUserProfileConfig", "realm1", desired).Return(nil).Once()
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+
+	// the profile is not updated if it is not changed.
+	kClient.On("GetUserProfileConfig", "realm1").Return(desired, nil).Once()
+
+	require.NoError(t, h.ServeRequest(context.Background(), &realm, kClient))
+	kClient.AssertExpectations(t)
+	kClient.AssertNumberOfCalls(t, "UpdateUserProfileConfig", 1)
+}
+
+func TestPutUserProfile_ServeRequest_UnsupportedVersion(t *testing.T) {
+	h := PutUserProfile{}
+	kClient := new(adapter.Mock)
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			UserProfileConfig: &keycloakApi.UserProfileConfig{
+				Attributes: []keycloakApi.UserProfileAttribute{{Name: "username"}, {Name: "email"}},
+			},
+		},
+	}
+
+	kClient.On("GetServerVersion").Return("23.0.7", nil)
+
+	err := h.ServeRequest(context.Background(), &realm, kClient)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "user profile requires keycloak 24 or newer, connected keycloak version is 23.0.7")
+	kClient.AssertNotCalled(t, "GetUserProfileConfig", "realm1")
+}
+
+func TestPutUserProfile_ServeRequest_MissingRequiredAttribute(t *testing.T) {
+	h := PutUserProfile{}
+
+	realm := keycloakApi.KeycloakRealm{
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName: "realm1",
+			UserProfileConfig: &keycloakApi.UserProfileConfig{
+				Attributes: []keycloakApi.UserProfileAttribute{{Name: "username"}},
+			},
+		},
+	}
+
+	err := h.ServeRequest(context.Background(), &realm, new(adapter.Mock))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "user profile must contain the email attribute")
+}
@@ -12,8 +12,11 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	ctrlHandler "sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
@@ -50,15 +53,24 @@ type ReconcileKeycloakRealm struct {
 	successReconcileTimeout time.Duration
 }
 
-func (r *ReconcileKeycloakRealm) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+// SetupWithManager registers the realm controller, the triggers enqueue the realms which have to be healed.
+func (r *ReconcileKeycloakRealm) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration,
+	triggers ...source.Source) error {
 	r.successReconcileTimeout = successReconcileTimeout
 	pred := predicate.Funcs{
-		UpdateFunc: helper.IsFailuresUpdated,
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return helper.IsFailuresUpdated(e) && !helper.IsDriftConditionUpdated(e)
+		},
 	}
 
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&keycloakApi.KeycloakRealm{}, builder.WithPredicates(pred)).
-		Complete(r)
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealm{}, builder.WithPredicates(pred))
+
+	for _, t := range triggers {
+		b = b.Watches(t, &ctrlHandler.EnqueueRequestForObject{})
+	}
+
+	err := b.Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup KeycloakRealm controller: %w", err)
 	}
@@ -70,6 +82,7 @@ func (r *ReconcileKeycloakRealm) SetupWithManager(mgr ctrl.Manager, successRecon
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealms/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealms/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get
 
 // Reconcile is a loop for reconciling KeycloakRealm object.
 func (r *ReconcileKeycloakRealm) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
@@ -90,16 +103,26 @@ func (r *ReconcileKeycloakRealm) Reconcile(ctx context.Context, request reconcil
 		return
 	}
 
-	if err := r.tryReconcile(ctx, instance); err != nil {
+	err := r.tryReconcile(ctx, instance)
+
+	switch {
+	case helper.IsDryRunPreview(err):
+		log.Info("Dry run is enabled, changes are not applied", "preview", err.Error())
+
+		helper.SetDryRunStatus(instance, err)
+		result.RequeueAfter = r.successReconcileTimeout
+	case err != nil:
 		instance.Status.Available = false
-		instance.Status.Value = err.Error()
+		helper.SetErrorStatus(instance, err)
 		result.RequeueAfter = r.helper.SetFailureCount(instance)
 
 		log.Error(err, "an error has occurred while handling keycloak realm", "name", request.Name)
-	} else {
+	default:
 		instance.Status.Available = true
 		instance.Status.Value = helper.StatusOK
 		instance.Status.FailureCount = 0
+		instance.Status.AppliedGeneration = instance.Generation
+		helper.SetReconciledCondition(instance, nil)
 		result.RequeueAfter = r.successReconcileTimeout
 	}
 
@@ -111,11 +134,19 @@ func (r *ReconcileKeycloakRealm) Reconcile(ctx context.Context, request reconcil
 }
 
 func (r *ReconcileKeycloakRealm) tryReconcile(ctx context.Context, realm *keycloakApi.KeycloakRealm) error {
+	if released, err := helper.ReleaseDeletedDryRun(ctx, r.client, realm, keyCloakRealmOperatorFinalizerName); err != nil || released {
+		return err
+	}
+
 	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
 	if err != nil {
 		return fmt.Errorf("failed to create keycloak client for realm: %w", err)
 	}
 
+	if helper.IsDryRunEnabled(realm) {
+		return helper.PreviewChanges(ctx, kClient, realm, realm)
+	}
+
 	deleted, err := r.helper.TryToDelete(ctx, realm,
 		makeTerminator(realm.Spec.RealmName, kClient, r.log.WithName("realm-group-term")),
 		keyCloakRealmOperatorFinalizerName)
@@ -77,6 +77,7 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 	}
 
 	return !reflect.DeepEqual(oo.Spec, no.Spec) ||
+		helper.UserStorageSyncRequested(oo, no) ||
 		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
 }
 
@@ -101,7 +102,7 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 	}
 
 	if err := r.tryReconcile(ctx, &instance); err != nil {
-		instance.Status.Value = err.Error()
+		helper.SetErrorStatus(&instance, err)
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
 
 		log.Error(err, "an error has occurred while handling keycloak realm component", "name", request.Name)
@@ -147,6 +148,17 @@ func (r *Reconcile) tryReconcile(ctx context.Context, keycloakRealmComponent *ke
 		}
 	}
 
+	syncStatus, err := helper.SyncUserStorageByAnnotation(
+		ctx, r.client, kClient, keycloakRealmComponent, realm.Spec.RealmName, keycloakRealmComponent.Spec.Name,
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to sync realm component users")
+	}
+
+	if syncStatus != nil {
+		keycloakRealmComponent.Status.LastSync = syncStatus
+	}
+
 	term := makeTerminator(realm.Spec.RealmName, keycloakRealmComponent.Spec.Name, kClient, r.log.WithName("realm-component-term"))
 	if _, err := r.helper.TryToDelete(ctx, keycloakRealmComponent, term, finalizerName); err != nil {
 		return errors.Wrap(err, "unable to tryToDelete realm component")
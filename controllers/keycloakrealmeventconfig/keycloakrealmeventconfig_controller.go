@@ -0,0 +1,219 @@
+package keycloakrealmeventconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("keycloak-realm-event-config"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: helper.IsFailuresUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmEventConfig{}, builder.WithPredicates(pred)).
+		Watches(&source.Kind{Type: &keycloakApi.KeycloakRealm{}},
+			handler.EnqueueRequestsFromMapFunc(r.mapRealmToEventConfigs)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmEventConfig controller: %w", err)
+	}
+
+	return nil
+}
+
+// mapRealmToEventConfigs returns reconcile requests for event configs which select the realm.
+func (r *Reconcile) mapRealmToEventConfigs(object client.Object) []reconcile.Request {
+	var configList keycloakApi.KeycloakRealmEventConfigList
+	if err := r.client.List(context.Background(), &configList, client.InNamespace(object.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list keycloak realm event configs for realm", "realm", object.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range configList.Items {
+		sel, err := realmSelector(&configList.Items[i])
+		if err != nil || !sel.Matches(labels.Set(object.GetLabels())) {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: configList.Items[i].Namespace,
+			Name:      configList.Items[i].Name,
+		}})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmeventconfigs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmeventconfigs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmeventconfigs/finalizers,verbs=update
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealms,verbs=get;list;watch
+
+// Reconcile is a loop for reconciling KeycloakRealmEventConfig object.
+// The event config is kept in the realms after the object is deleted.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result,
+	resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakRealmEventConfig")
+
+	var instance keycloakApi.KeycloakRealmEventConfig
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak realm event config from k8s")
+
+		return
+	}
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		helper.SetErrorStatus(&instance, err)
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak realm event config", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = err
+	}
+
+	log.Info("Reconciling KeycloakRealmEventConfig done")
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, eventConfig *keycloakApi.KeycloakRealmEventConfig) error {
+	sel, err := realmSelector(eventConfig)
+	if err != nil {
+		return errors.Wrap(err, "invalid realm selector")
+	}
+
+	var realmList keycloakApi.KeycloakRealmList
+	if err := r.client.List(ctx, &realmList, client.InNamespace(eventConfig.Namespace),
+		client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return errors.Wrap(err, "unable to list keycloak realms")
+	}
+
+	realms := realmList.Items
+	sort.Slice(realms, func(i, j int) bool {
+		return realms[i].Name < realms[j].Name
+	})
+
+	applied := make([]string, 0, len(realms))
+	skipped := make([]string, 0)
+	failed := make([]string, 0)
+
+	for i := range realms {
+		if realms[i].Spec.RealmEventConfig != nil {
+			skipped = append(skipped, realms[i].Name)
+			continue
+		}
+
+		if err := r.applyEventConfig(ctx, &realms[i], &eventConfig.Spec.RealmEventConfig); err != nil {
+			r.log.Error(err, "unable to apply event config to realm", "name", eventConfig.Name, "realm", realms[i].Name)
+			failed = append(failed, realms[i].Name)
+
+			continue
+		}
+
+		applied = append(applied, realms[i].Name)
+	}
+
+	eventConfig.Status.Realms = applied
+	eventConfig.Status.SkippedRealms = skipped
+
+	if len(failed) > 0 {
+		return errors.Errorf("unable to apply event config to %d of %d realms: %s", len(failed),
+			len(realms)-len(skipped), strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func (r *Reconcile) applyEventConfig(ctx context.Context, realm *keycloakApi.KeycloakRealm,
+	spec *keycloakApi.RealmEventConfig) error {
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	if err := kClient.SetRealmEventConfig(realm.Spec.RealmName, &adapter.RealmEventConfig{
+		AdminEventsDetailsEnabled: spec.AdminEventsDetailsEnabled,
+		AdminEventsEnabled:        spec.AdminEventsEnabled,
+		EnabledEventTypes:         spec.EnabledEventTypes,
+		EventsEnabled:             spec.EventsEnabled,
+		EventsExpiration:          spec.EventsExpiration,
+		EventsListeners:           spec.EventsListeners,
+		AdminEventsExpiration:     spec.AdminEventsExpiration,
+	}); err != nil {
+		return errors.Wrap(err, "unable to set realm event config")
+	}
+
+	return nil
+}
+
+// realmSelector returns the selector of the event config realms, all realms are selected if it is not set.
+func realmSelector(eventConfig *keycloakApi.KeycloakRealmEventConfig) (labels.Selector, error) {
+	if eventConfig.Spec.RealmSelector == nil {
+		return labels.Everything(), nil
+	}
+
+	return v1.LabelSelectorAsSelector(eventConfig.Spec.RealmSelector)
+}
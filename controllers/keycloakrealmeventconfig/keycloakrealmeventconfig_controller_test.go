@@ -0,0 +1,152 @@
+package keycloakrealmeventconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func getTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	return scheme
+}
+
+func getTestRealm(name string, lbs map[string]string) *keycloakApi.KeycloakRealm {
+	return &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: lbs},
+		Spec:       keycloakApi.KeycloakRealmSpec{RealmName: "realm." + name},
+	}
+}
+
+func getTestEventConfig() *keycloakApi.KeycloakRealmEventConfig {
+	return &keycloakApi.KeycloakRealmEventConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "audit", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmEventConfigSpec{
+			RealmSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"audit": "true"}},
+			RealmEventConfig: keycloakApi.RealmEventConfig{
+				EventsEnabled:         true,
+				EventsListeners:       []string{"jboss-logging"},
+				EnabledEventTypes:     []string{"LOGIN", "LOGIN_ERROR"},
+				EventsExpiration:      3600,
+				AdminEventsEnabled:    true,
+				AdminEventsExpiration: gocloak.IntP(7200),
+			},
+		},
+	}
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	realm1 := getTestRealm("test1", map[string]string{"audit": "true"})
+	realm2 := getTestRealm("test2", map[string]string{"audit": "true"})
+	realm2.Spec.RealmEventConfig = &keycloakApi.RealmEventConfig{EventsEnabled: true}
+	realm3 := getTestRealm("test3", nil)
+	eventConfig := getTestEventConfig()
+
+	client := fake.NewClientBuilder().WithScheme(getTestScheme()).
+		WithRuntimeObjects(eventConfig, realm1, realm2, realm3).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("SetRealmEventConfig", "realm.test1", &adapter.RealmEventConfig{
+		EventsEnabled:         true,
+		EventsListeners:       []string{"jboss-logging"},
+		EnabledEventTypes:     []string{"LOGIN", "LOGIN_ERROR"},
+		EventsExpiration:      3600,
+		AdminEventsEnabled:    true,
+		AdminEventsExpiration: gocloak.IntP(7200),
+	}).Return(nil)
+
+	h := helper.Mock{}
+	h.On("CreateKeycloakClientForRealm", testifyMock.Anything).Return(kClient, nil)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := Reconcile{
+		client:                  client,
+		log:                     mock.NewLogr(),
+		helper:                  &h,
+		successReconcileTimeout: time.Hour,
+	}
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: eventConfig.Name, Namespace: eventConfig.Namespace},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakRealmEventConfig)
+	require.True(t, ok)
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	require.Equal(t, []string{"test1"}, updated.Status.Realms)
+	require.Equal(t, []string{"test2"}, updated.Status.SkippedRealms)
+	kClient.AssertExpectations(t)
+}
+
+func TestReconcile_Reconcile_PartialFailure(t *testing.T) {
+	realm1 := getTestRealm("test1", nil)
+	realm2 := getTestRealm("test2", nil)
+	eventConfig := getTestEventConfig()
+	eventConfig.Spec.RealmSelector = nil
+
+	client := fake.NewClientBuilder().WithScheme(getTestScheme()).
+		WithRuntimeObjects(eventConfig, realm1, realm2).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("SetRealmEventConfig", "realm.test1", testifyMock.Anything).Return(nil)
+	kClient.On("SetRealmEventConfig", "realm.test2", testifyMock.Anything).Return(errors.New("fatal"))
+
+	h := helper.Mock{}
+	h.On("CreateKeycloakClientForRealm", testifyMock.Anything).Return(kClient, nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := Reconcile{
+		client: client,
+		log:    mock.NewLogr(),
+		helper: &h,
+	}
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: eventConfig.Name, Namespace: eventConfig.Namespace},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakRealmEventConfig)
+	require.True(t, ok)
+	require.Equal(t, "unable to apply event config to 1 of 2 realms: test2", updated.Status.Value)
+	require.Equal(t, []string{"test1"}, updated.Status.Realms)
+}
+
+func TestReconcile_mapRealmToEventConfigs(t *testing.T) {
+	eventConfig := getTestEventConfig()
+	all := getTestEventConfig()
+	all.Name = "all"
+	all.Spec.RealmSelector = nil
+
+	client := fake.NewClientBuilder().WithScheme(getTestScheme()).WithRuntimeObjects(eventConfig, all).Build()
+	rec := Reconcile{client: client, log: mock.NewLogr()}
+
+	requests := rec.mapRealmToEventConfigs(getTestRealm("test1", map[string]string{"audit": "true"}))
+	require.Len(t, requests, 2)
+
+	requests = rec.mapRealmToEventConfigs(getTestRealm("test2", nil))
+	require.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "all", Namespace: "ns"}}},
+		requests)
+}
@@ -3,21 +3,27 @@ package keycloakrealmgroup
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
 const keyCloakRealmGroupOperatorFinalizerName = "keycloak.realmgroup.operator.finalizer.name"
@@ -46,16 +52,25 @@ type ReconcileKeycloakRealmGroup struct {
 	successReconcileTimeout time.Duration
 }
 
-func (r *ReconcileKeycloakRealmGroup) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+// SetupWithManager registers the controller. Besides the group updates, it reconciles the groups from the triggers.
+func (r *ReconcileKeycloakRealmGroup) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration,
+	triggers ...source.Source) error {
 	r.successReconcileTimeout = successReconcileTimeout
 
 	pred := predicate.Funcs{
-		UpdateFunc: helper.IsFailuresUpdated,
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return helper.IsFailuresUpdated(e) && !helper.IsDriftConditionUpdated(e)
+		},
 	}
 
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&keycloakApi.KeycloakRealmGroup{}, builder.WithPredicates(pred)).
-		Complete(r)
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmGroup{}, builder.WithPredicates(pred))
+
+	for _, t := range triggers {
+		b = b.Watches(t, &handler.EnqueueRequestForObject{})
+	}
+
+	err := b.Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup KeycloakRealmGroup controller: %w", err)
 	}
@@ -83,12 +98,25 @@ func (r *ReconcileKeycloakRealmGroup) Reconcile(ctx context.Context, request rec
 		return
 	}
 
-	if err := r.tryReconcile(ctx, &instance); err != nil {
-		instance.Status.Value = err.Error()
+	err := r.tryReconcile(ctx, &instance)
+
+	switch {
+	case helper.IsDryRunPreview(err):
+		log.Info("Dry run is enabled, changes are not applied", "preview", err.Error())
+
+		helper.SetDryRunStatus(&instance, err)
+		result.RequeueAfter = r.successReconcileTimeout
+	case helper.IsAdoptionSkipped(err):
+		log.Info("Group already exists in keycloak, skip it", "name", request.Name)
+
+		helper.SetSkippedStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	case err != nil:
+		helper.SetErrorStatus(&instance, err)
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
 
 		log.Error(err, "an error has occurred while handling keycloak realm group", "name", request.Name)
-	} else {
+	default:
 		helper.SetSuccessStatus(&instance)
 		result.RequeueAfter = r.successReconcileTimeout
 	}
@@ -103,6 +131,10 @@ func (r *ReconcileKeycloakRealmGroup) Reconcile(ctx context.Context, request rec
 }
 
 func (r *ReconcileKeycloakRealmGroup) tryReconcile(ctx context.Context, keycloakRealmGroup *keycloakApi.KeycloakRealmGroup) error {
+	if released, err := helper.ReleaseDeletedDryRun(ctx, r.client, keycloakRealmGroup, keyCloakRealmGroupOperatorFinalizerName); err != nil || released {
+		return err
+	}
+
 	realm, err := r.helper.GetOrCreateRealmOwnerRef(keycloakRealmGroup, &keycloakRealmGroup.ObjectMeta)
 	if err != nil {
 		return errors.Wrap(err, "unable to get realm owner ref")
@@ -113,15 +145,34 @@ func (r *ReconcileKeycloakRealmGroup) tryReconcile(ctx context.Context, keycloak
 		return errors.Wrap(err, "unable to create keycloak client")
 	}
 
-	id, err := kClient.SyncRealmGroup(realm.Spec.RealmName, &keycloakRealmGroup.Spec)
+	if helper.IsDryRunEnabled(keycloakRealmGroup) {
+		return helper.PreviewChanges(ctx, kClient, realm, keycloakRealmGroup)
+	}
+
+	groupPath, err := r.groupPath(ctx, keycloakRealmGroup)
+	if err != nil {
+		return err
+	}
+
+	if keycloakRealmGroup.Status.ID == "" {
+		if err = checkGroupAdoption(kClient, realm.Spec.RealmName, groupPath, keycloakRealmGroup); err != nil {
+			return err
+		}
+	}
+
+	spec := keycloakRealmGroup.Spec
+	spec.Path = groupPath
+
+	id, err := kClient.SyncRealmGroup(realm.Spec.RealmName, &spec, keycloakRealmGroup.Status.ID)
 	if err != nil {
 		return errors.Wrap(err, "unable to sync realm role")
 	}
 
 	keycloakRealmGroup.Status.ID = id
+	keycloakRealmGroup.Status.Path = groupPath
 
 	if _, err := r.helper.TryToDelete(ctx, keycloakRealmGroup,
-		makeTerminator(kClient, realm.Spec.RealmName, keycloakRealmGroup.Spec.Name,
+		makeTerminator(kClient, realm.Spec.RealmName, groupPath,
 			r.log.WithName("realm-group-term")),
 		keyCloakRealmGroupOperatorFinalizerName); err != nil {
 		return errors.Wrap(err, "unable to tryToDelete realm role")
@@ -129,3 +180,42 @@ func (r *ReconcileKeycloakRealmGroup) tryReconcile(ctx context.Context, keycloak
 
 	return nil
 }
+
+// checkGroupAdoption applies the adoption policy if the group with the path already exists in keycloak.
+func checkGroupAdoption(kClient keycloak.Client, realmName, groupPath string,
+	keycloakRealmGroup *keycloakApi.KeycloakRealmGroup) error {
+	_, err := kClient.GetGroupByPath(realmName, groupPath)
+	if adapter.IsErrNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "unable to check group")
+	}
+
+	return helper.CheckAdoption(keycloakRealmGroup, keycloakApi.AdoptionPolicyAdopt, "group", groupPath)
+}
+
+// groupPath returns the full path of the group in the realm.
+// The path is built from the path of the parent group if the parentGroup is set.
+func (r *ReconcileKeycloakRealmGroup) groupPath(ctx context.Context, group *keycloakApi.KeycloakRealmGroup) (string, error) {
+	if group.Spec.ParentGroup != nil {
+		if strings.Contains(strings.Trim(group.Spec.Path, "/"), "/") {
+			return "", errors.New("parentGroup and nested path can not be used together")
+		}
+
+		var parent keycloakApi.KeycloakRealmGroup
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: group.Namespace, Name: group.Spec.ParentGroup.Name},
+			&parent); err != nil {
+			return "", errors.Wrapf(err, "unable to get parent group %s", group.Spec.ParentGroup.Name)
+		}
+
+		if parent.Status.Path == "" {
+			return "", errors.Errorf("parent group %s is not ready", group.Spec.ParentGroup.Name)
+		}
+
+		return parent.Status.Path + "/" + group.Spec.Name, nil
+	}
+
+	return adapter.GroupTargetPath(&group.Spec)
+}
@@ -5,8 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Nerzal/gocloak/v12"
+	testifymock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -39,15 +42,14 @@ func TestReconcileKeycloakRealmGroup_Reconcile(t *testing.T) {
 		APIVersion: "v1.edp.epam.com/v1", Kind: "KeycloakRealmGroup",
 	}, ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "group1" /*, DeletionTimestamp: &now*/},
 		Spec:   keycloakApi.KeycloakRealmGroupSpec{Realm: "realm1", RealmRoles: []string{"role1", "role2"}, Name: "group1"},
-		Status: keycloakApi.KeycloakRealmGroupStatus{ID: "id11", Value: helper.StatusOK}}
+		Status: keycloakApi.KeycloakRealmGroupStatus{ID: "id11", Path: "/group1", Value: helper.StatusOK}}
 	secret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "keycloak-secret", Namespace: ns},
 		Data: map[string][]byte{"username": []byte("user"), "password": []byte("pass")}}
 
 	client := fake.NewClientBuilder().WithScheme(sch).WithRuntimeObjects(&group, &realm, &keycloak, &secret).Build()
-	kClient := new(adapter.Mock)
 
-	kClient.On("SyncRealmGroup", "ns.realm1", &group.Spec).Return("gid1", nil)
-	kClient.On("DeleteGroup", "ns.realm1", group.Spec.Name).Return(nil)
+	syncSpec := group.Spec
+	syncSpec.Path = "/group1"
 
 	logger := mock.NewLogr()
 	h := helper.Mock{}
@@ -55,10 +57,10 @@ func TestReconcileKeycloakRealmGroup_Reconcile(t *testing.T) {
 
 	h.On("GetOrCreateRealmOwnerRef", &group, &group.ObjectMeta).Return(&realm, nil)
 	h.On("CreateKeycloakClientForRealm", &realm).Return(&kcMock, nil)
-	kcMock.On("SyncRealmGroup", "ns.realm1", &group.Spec).Return("id11", nil)
-	h.On("TryToDelete", &group, makeTerminator(&kcMock, realm.Spec.RealmName, group.Spec.Name, logger),
+	kcMock.On("SyncRealmGroup", "ns.realm1", &syncSpec, "id11").Return("id11", nil)
+	h.On("TryToDelete", &group, makeTerminator(&kcMock, realm.Spec.RealmName, "/group1", logger),
 		keyCloakRealmGroupOperatorFinalizerName).Return(true, nil)
-	h.On("UpdateStatus", &group).Return(nil)
+	h.On("UpdateStatus", testifymock.Anything).Return(nil)
 
 	r := ReconcileKeycloakRealmGroup{
 		client:                  client,
@@ -83,3 +85,203 @@ func TestReconcileKeycloakRealmGroup_Reconcile(t *testing.T) {
 		t.Fatal("success reconcile timeout is not set")
 	}
 }
+
+func TestReconcileKeycloakRealmGroup_ReconcileAdoptionSkipped(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	ns := "security"
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "realm1", Namespace: ns},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "ns.realm1"}}
+	group := keycloakApi.KeycloakRealmGroup{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "group1",
+		Annotations: map[string]string{keycloakApi.AdoptionPolicyAnnotation: keycloakApi.AdoptionPolicySkip}},
+		Spec: keycloakApi.KeycloakRealmGroupSpec{Realm: "realm1", Name: "group1"}}
+
+	client := fake.NewClientBuilder().WithScheme(sch).WithObjects(&group).Build()
+
+	h := helper.Mock{}
+	kcMock := adapter.Mock{}
+
+	h.On("GetOrCreateRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(&realm, nil)
+	h.On("CreateKeycloakClientForRealm", &realm).Return(&kcMock, nil)
+	kcMock.On("GetGroupByPath", "ns.realm1", "/group1").Return(&gocloak.Group{ID: gocloak.StringP("id1")}, nil)
+	h.On("UpdateStatus", testifymock.Anything).Return(nil)
+
+	r := ReconcileKeycloakRealmGroup{
+		client:                  client,
+		helper:                  &h,
+		log:                     mock.NewLogr(),
+		successReconcileTimeout: time.Hour,
+	}
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: ns,
+		Name:      "group1",
+	}})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakRealmGroup)
+	require.True(t, ok)
+	require.Equal(t, keycloakApi.StatusSkipped, updated.Status.Value)
+	require.Empty(t, updated.Status.ID)
+	kcMock.AssertNotCalled(t, "SyncRealmGroup", testifymock.Anything, testifymock.Anything, testifymock.Anything)
+}
+
+func TestReconcileKeycloakRealmGroup_ReconcileDryRun(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	ns := "security"
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "realm1", Namespace: ns},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "ns.realm1"}}
+	group := keycloakApi.KeycloakRealmGroup{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "group1",
+		Annotations: map[string]string{keycloakApi.DryRunAnnotation: "true"}},
+		Spec: keycloakApi.KeycloakRealmGroupSpec{Realm: "realm1", Name: "group1"}}
+
+	client := fake.NewClientBuilder().WithScheme(sch).WithObjects(&group).Build()
+
+	h := helper.Mock{}
+	kcMock := adapter.Mock{}
+
+	h.On("GetOrCreateRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(&realm, nil)
+	h.On("CreateKeycloakClientForRealm", &realm).Return(&kcMock, nil)
+	kcMock.On("ExportRealm", "ns.realm1").Return(&adapter.RealmExport{Realm: "ns.realm1"}, nil)
+	h.On("UpdateStatus", testifymock.Anything).Return(nil)
+
+	r := ReconcileKeycloakRealmGroup{
+		client:                  client,
+		helper:                  &h,
+		log:                     mock.NewLogr(),
+		successReconcileTimeout: time.Hour,
+	}
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: ns,
+		Name:      "group1",
+	}})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakRealmGroup)
+	require.True(t, ok)
+	require.Equal(t, "dry run: KeycloakRealmGroup group1 would be created", updated.Status.Value)
+	kcMock.AssertNotCalled(t, "SyncRealmGroup", testifymock.Anything, testifymock.Anything, testifymock.Anything)
+	h.AssertNotCalled(t, "TryToDelete", testifymock.Anything, testifymock.Anything, testifymock.Anything)
+}
+
+func TestReconcileKeycloakRealmGroup_ReconcileDryRunDeleted(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	ns := "security"
+	now := metav1.Now()
+	group := keycloakApi.KeycloakRealmGroup{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "group1",
+		Annotations:       map[string]string{keycloakApi.DryRunAnnotation: "true"},
+		Finalizers:        []string{keyCloakRealmGroupOperatorFinalizerName},
+		DeletionTimestamp: &now},
+		Spec: keycloakApi.KeycloakRealmGroupSpec{Realm: "realm1", Name: "group1"}}
+
+	client := fake.NewClientBuilder().WithScheme(sch).WithObjects(&group).Build()
+
+	h := helper.Mock{}
+	h.On("UpdateStatus", testifymock.Anything).Return(nil)
+
+	r := ReconcileKeycloakRealmGroup{
+		client:                  client,
+		helper:                  &h,
+		log:                     mock.NewLogr(),
+		successReconcileTimeout: time.Hour,
+	}
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: ns,
+		Name:      "group1",
+	}})
+	require.NoError(t, err)
+
+	err = client.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: "group1"},
+		&keycloakApi.KeycloakRealmGroup{})
+	require.True(t, k8sErrors.IsNotFound(err), "the deletion of the dry run object must not be blocked")
+	h.AssertNotCalled(t, "CreateKeycloakClientForRealm", testifymock.Anything)
+}
+
+func TestReconcileKeycloakRealmGroup_groupPath(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	parent := keycloakApi.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "platform"},
+		Status:     keycloakApi.KeycloakRealmGroupStatus{Path: "/platform"},
+	}
+	notReadyParent := keycloakApi.KeycloakRealmGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "not-ready"},
+	}
+
+	r := ReconcileKeycloakRealmGroup{
+		client: fake.NewClientBuilder().WithScheme(sch).WithRuntimeObjects(&parent, &notReadyParent).Build(),
+	}
+
+	tests := []struct {
+		name    string
+		spec    keycloakApi.KeycloakRealmGroupSpec
+		want    string
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "top-level group",
+			spec:    keycloakApi.KeycloakRealmGroupSpec{Name: "admins"},
+			want:    "/admins",
+			wantErr: require.NoError,
+		},
+		{
+			name:    "nested path",
+			spec:    keycloakApi.KeycloakRealmGroupSpec{Name: "admins", Path: "/platform/admins/"},
+			want:    "/platform/admins",
+			wantErr: require.NoError,
+		},
+		{
+			name:    "parent group",
+			spec:    keycloakApi.KeycloakRealmGroupSpec{Name: "admins", ParentGroup: &keycloakApi.ParentGroup{Name: "platform"}},
+			want:    "/platform/admins",
+			wantErr: require.NoError,
+		},
+		{
+			name:    "parent group is not ready",
+			spec:    keycloakApi.KeycloakRealmGroupSpec{Name: "admins", ParentGroup: &keycloakApi.ParentGroup{Name: "not-ready"}},
+			wantErr: require.Error,
+		},
+		{
+			name:    "parent group not found",
+			spec:    keycloakApi.KeycloakRealmGroupSpec{Name: "admins", ParentGroup: &keycloakApi.ParentGroup{Name: "missing"}},
+			wantErr: require.Error,
+		},
+		{
+			name: "parent group with nested path",
+			spec: keycloakApi.KeycloakRealmGroupSpec{Name: "admins", Path: "/other/admins",
+				ParentGroup: &keycloakApi.ParentGroup{Name: "platform"}},
+			wantErr: require.Error,
+		},
+		{
+			name:    "path with different name",
+			spec:    keycloakApi.KeycloakRealmGroupSpec{Name: "admins", Path: "/platform/users"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "relative path",
+			spec:    keycloakApi.KeycloakRealmGroupSpec{Name: "admins", Path: "platform/admins"},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			group := keycloakApi.KeycloakRealmGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "admins"}, Spec: tt.spec}
+
+			got, err := r.groupPath(context.Background(), &group)
+			tt.wantErr(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -4,18 +4,24 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
@@ -23,7 +29,11 @@ import (
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
-const finalizerName = "keycloak.realmidp.operator.finalizer.name"
+const (
+	finalizerName         = "keycloak.realmidp.operator.finalizer.name"
+	samlProviderID        = "saml"
+	clientSecretConfigKey = "clientSecret"
+)
 
 type Helper interface {
 	SetFailureCount(fc helper.FailureCountable) time.Duration
@@ -57,6 +67,7 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout t
 
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&keycloakApi.KeycloakRealmIdentityProvider{}, builder.WithPredicates(pred)).
+		Watches(&source.Kind{Type: &coreV1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.mapSecretToIdentityProviders)).
 		Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup KeycloakRealmIdentityProvider controller: %w", err)
@@ -65,6 +76,30 @@ func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout t
 	return nil
 }
 
+// mapSecretToIdentityProviders returns reconcile requests for identity providers which reference the secret.
+func (r *Reconcile) mapSecretToIdentityProviders(object client.Object) []reconcile.Request {
+	var idpList keycloakApi.KeycloakRealmIdentityProviderList
+	if err := r.client.List(context.Background(), &idpList, client.InNamespace(object.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list identity providers for secret", "secret", object.GetName())
+
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range idpList.Items {
+		ref := idpList.Items[i].Spec.ClientSecretRef
+		if ref != nil && ref.Name == object.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: idpList.Items[i].Namespace,
+				Name:      idpList.Items[i].Name,
+			}})
+		}
+	}
+
+	return requests
+}
+
 func isSpecUpdated(e event.UpdateEvent) bool {
 	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmIdentityProvider)
 	if !ok {
@@ -103,7 +138,7 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 	}
 
 	if err := r.tryReconcile(ctx, &instance); err != nil {
-		instance.Status.Value = err.Error()
+		helper.SetErrorStatus(&instance, err)
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
 
 		log.Error(err, "an error has occurred while handling keycloak realm idp", "name", request.Name)
@@ -130,7 +165,25 @@ func (r *Reconcile) tryReconcile(ctx context.Context, keycloakRealmIDP *keycloak
 		return errors.Wrap(err, "unable to create keycloak client")
 	}
 
-	keycloakIDP := createKeycloakIDPFromSpec(&keycloakRealmIDP.Spec)
+	idpConfig, err := makeIDPConfig(ctx, kClient, realm.Spec.RealmName, &keycloakRealmIDP.Spec)
+	if err != nil {
+		return errors.Wrap(err, "unable to make idp config")
+	}
+
+	if keycloakRealmIDP.Spec.ClientSecretRef != nil {
+		clientSecret, err := r.getClientSecret(ctx, keycloakRealmIDP)
+		if err != nil {
+			return err
+		}
+
+		idpConfig = withConfigValue(idpConfig, clientSecretConfigKey, clientSecret)
+	}
+
+	keycloakIDP := createKeycloakIDPFromSpec(&keycloakRealmIDP.Spec, idpConfig)
+
+	if err := r.setBrokerLoginFlows(ctx, kClient, realm.Spec.RealmName, keycloakRealmIDP, keycloakIDP); err != nil {
+		return err
+	}
 
 	providerExists, err := kClient.IdentityProviderExists(ctx, realm.Spec.RealmName, keycloakRealmIDP.Spec.Alias)
 	if err != nil {
@@ -161,7 +214,7 @@ func (r *Reconcile) tryReconcile(ctx context.Context, keycloakRealmIDP *keycloak
 
 func syncIDPMappers(ctx context.Context, idpSpec *keycloakApi.KeycloakRealmIdentityProviderSpec,
 	kClient keycloak.Client, targetRealm string) error {
-	if len(idpSpec.Mappers) == 0 {
+	if idpSpec.Mappers == nil {
 		return nil
 	}
 
@@ -170,10 +223,9 @@ func syncIDPMappers(ctx context.Context, idpSpec *keycloakApi.KeycloakRealmIdent
 		return errors.Wrap(err, "unable to get idp mappers")
 	}
 
+	existing := make(map[string]string, len(mappers))
 	for _, m := range mappers {
-		if err := kClient.DeleteIDPMapper(ctx, targetRealm, idpSpec.Alias, m.ID); err != nil {
-			return errors.Wrap(err, "unable to delete idp mapper")
-		}
+		existing[m.Name] = m.ID
 	}
 
 	for _, m := range idpSpec.Mappers {
@@ -181,12 +233,30 @@ func syncIDPMappers(ctx context.Context, idpSpec *keycloakApi.KeycloakRealmIdent
 			m.IdentityProviderAlias = idpSpec.Alias
 		}
 
-		if _, err := kClient.CreateIDPMapper(ctx, targetRealm, idpSpec.Alias,
-			createKeycloakIDPMapperFromSpec(&m)); err != nil {
+		mapper := createKeycloakIDPMapperFromSpec(&m)
+
+		if id, ok := existing[m.Name]; ok {
+			mapper.ID = id
+			delete(existing, m.Name)
+
+			if err := kClient.UpdateIDPMapper(ctx, targetRealm, idpSpec.Alias, mapper); err != nil {
+				return errors.Wrap(err, "unable to update idp mapper")
+			}
+
+			continue
+		}
+
+		if _, err := kClient.CreateIDPMapper(ctx, targetRealm, idpSpec.Alias, mapper); err != nil {
 			return errors.Wrap(err, "unable to create idp mapper")
 		}
 	}
 
+	for _, id := range existing {
+		if err := kClient.DeleteIDPMapper(ctx, targetRealm, idpSpec.Alias, id); err != nil {
+			return errors.Wrap(err, "unable to delete idp mapper")
+		}
+	}
+
 	return nil
 }
 
@@ -199,9 +269,9 @@ func createKeycloakIDPMapperFromSpec(spec *keycloakApi.IdentityProviderMapper) *
 	}
 }
 
-func createKeycloakIDPFromSpec(spec *keycloakApi.KeycloakRealmIdentityProviderSpec) *adapter.IdentityProvider {
+func createKeycloakIDPFromSpec(spec *keycloakApi.KeycloakRealmIdentityProviderSpec, config map[string]string) *adapter.IdentityProvider {
 	return &adapter.IdentityProvider{
-		Config:                    spec.Config,
+		Config:                    config,
 		ProviderID:                spec.ProviderID,
 		Alias:                     spec.Alias,
 		Enabled:                   spec.Enabled,
@@ -209,8 +279,229 @@ func createKeycloakIDPFromSpec(spec *keycloakApi.KeycloakRealmIdentityProviderSp
 		AuthenticateByDefault:     spec.AuthenticateByDefault,
 		DisplayName:               spec.DisplayName,
 		FirstBrokerLoginFlowAlias: spec.FirstBrokerLoginFlowAlias,
+		PostBrokerLoginFlowAlias:  spec.PostBrokerLoginFlowAlias,
 		LinkOnly:                  spec.LinkOnly,
 		StoreToken:                spec.StoreToken,
 		TrustEmail:                spec.TrustEmail,
 	}
 }
+
+// setBrokerLoginFlows resolves first and post broker login flows of the identity provider
+// and checks that they exist in the keycloak realm.
+func (r *Reconcile) setBrokerLoginFlows(ctx context.Context, kClient keycloak.Client, realmName string,
+	idp *keycloakApi.KeycloakRealmIdentityProvider, keycloakIDP *adapter.IdentityProvider) error {
+	firstBrokerLoginFlow, err := r.resolveAuthFlowAlias(ctx, idp, idp.Spec.FirstBrokerLoginFlowRef,
+		idp.Spec.FirstBrokerLoginFlowAlias)
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve first broker login flow")
+	}
+
+	// the post broker login flow is not managed if neither the ref nor the alias is set
+	postBrokerLoginFlow := idp.Spec.PostBrokerLoginFlowAlias
+
+	if idp.Spec.PostBrokerLoginFlowRef != "" {
+		alias, err := r.resolveAuthFlowAlias(ctx, idp, idp.Spec.PostBrokerLoginFlowRef, "")
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve post broker login flow")
+		}
+
+		postBrokerLoginFlow = &alias
+	}
+
+	aliases := []string{firstBrokerLoginFlow}
+	if postBrokerLoginFlow != nil {
+		aliases = append(aliases, *postBrokerLoginFlow)
+	}
+
+	for _, alias := range aliases {
+		if alias == "" {
+			continue
+		}
+
+		exists, err := kClient.AuthFlowExists(realmName, alias)
+		if err != nil {
+			return errors.Wrapf(err, "unable to check auth flow %s", alias)
+		}
+
+		if !exists {
+			return errors.Errorf("auth flow %s does not exist in realm %s", alias, realmName)
+		}
+	}
+
+	keycloakIDP.FirstBrokerLoginFlowAlias = firstBrokerLoginFlow
+	keycloakIDP.PostBrokerLoginFlowAlias = postBrokerLoginFlow
+
+	return nil
+}
+
+// resolveAuthFlowAlias returns the alias of the referenced KeycloakAuthFlow resource or the given alias if ref is empty.
+func (r *Reconcile) resolveAuthFlowAlias(ctx context.Context, idp *keycloakApi.KeycloakRealmIdentityProvider,
+	ref, alias string) (string, error) {
+	if ref == "" {
+		return alias, nil
+	}
+
+	var flow keycloakApi.KeycloakAuthFlow
+	if err := r.client.Get(ctx, types.NamespacedName{Name: ref, Namespace: idp.Namespace}, &flow); err != nil {
+		return "", errors.Wrapf(err, "unable to get auth flow %s", ref)
+	}
+
+	if flow.Spec.Realm != idp.Spec.Realm {
+		return "", errors.Errorf("auth flow %s belongs to realm %s, expected realm %s", ref, flow.Spec.Realm, idp.Spec.Realm)
+	}
+
+	return flow.Spec.Alias, nil
+}
+
+func (r *Reconcile) getClientSecret(ctx context.Context, idp *keycloakApi.KeycloakRealmIdentityProvider) (string, error) {
+	ref := idp.Spec.ClientSecretRef
+
+	var secret coreV1.Secret
+	if err := r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: idp.Namespace}, &secret); err != nil {
+		return "", errors.Wrapf(err, "unable to get client secret %s", ref.Name)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("client secret %s does not contain key %s", ref.Name, ref.Key)
+	}
+
+	return string(value), nil
+}
+
+// withConfigValue returns a copy of the config with the given key set, the source config is not modified.
+func withConfigValue(config map[string]string, key, value string) map[string]string {
+	res := make(map[string]string, len(config)+1)
+	for k, v := range config {
+		res[k] = v
+	}
+
+	res[key] = value
+
+	return res
+}
+
+// makeIDPConfig builds identity provider config from the raw config, typed provider and login settings.
+func makeIDPConfig(ctx context.Context, kClient keycloak.Client, realmName string,
+	spec *keycloakApi.KeycloakRealmIdentityProviderSpec) (map[string]string, error) {
+	config, err := makeProviderConfig(ctx, kClient, realmName, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return withLoginSettings(config, spec), nil
+}
+
+func makeProviderConfig(ctx context.Context, kClient keycloak.Client, realmName string,
+	spec *keycloakApi.KeycloakRealmIdentityProviderSpec) (map[string]string, error) {
+	social := makeSocialConfigs(spec)
+
+	if len(social) > 1 || len(social) == 1 && spec.SAML != nil {
+		return nil, errors.New("only one typed identity provider config can be set")
+	}
+
+	if spec.SAML != nil {
+		return makeSAMLConfig(ctx, kClient, realmName, spec)
+	}
+
+	for providerID, typed := range social {
+		if spec.ProviderID != providerID {
+			return nil, fmt.Errorf("%s config is allowed only for the %s provider, got: %s", providerID, providerID, spec.ProviderID)
+		}
+
+		config := make(map[string]string, len(spec.Config)+len(typed))
+		for k, v := range spec.Config {
+			config[k] = v
+		}
+
+		for k, v := range typed {
+			setStringValue(config, k, v)
+		}
+
+		return config, nil
+	}
+
+	return spec.Config, nil
+}
+
+func makeSAMLConfig(ctx context.Context, kClient keycloak.Client, realmName string,
+	spec *keycloakApi.KeycloakRealmIdentityProviderSpec) (map[string]string, error) {
+	if spec.ProviderID != samlProviderID {
+		return nil, fmt.Errorf("saml config is allowed only for the %s provider, got: %s", samlProviderID, spec.ProviderID)
+	}
+
+	config := make(map[string]string)
+
+	if spec.SAML.MetadataURL != "" {
+		imported, err := kClient.ImportIdentityProviderConfig(ctx, realmName, samlProviderID, spec.SAML.MetadataURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to import saml metadata")
+		}
+
+		for k, v := range imported {
+			config[k] = v
+		}
+	}
+
+	for k, v := range spec.Config {
+		config[k] = v
+	}
+
+	setSAMLConfig(config, spec.SAML)
+
+	return config, nil
+}
+
+// withLoginSettings returns a copy of the config with the brokered login settings of the spec applied.
+// The source config is returned as is if none of the settings are set.
+func withLoginSettings(config map[string]string, spec *keycloakApi.KeycloakRealmIdentityProviderSpec) map[string]string {
+	if spec.SyncMode == "" && spec.HideOnLoginPage == nil && spec.GUIOrder == nil {
+		return config
+	}
+
+	res := make(map[string]string, len(config)+3)
+	for k, v := range config {
+		res[k] = v
+	}
+
+	setStringValue(res, adapter.IdentityProviderSyncModeKey, spec.SyncMode)
+	setBoolValue(res, adapter.IdentityProviderHideOnLoginPageKey, spec.HideOnLoginPage)
+
+	if spec.GUIOrder != nil {
+		res[adapter.IdentityProviderGUIOrderKey] = strconv.Itoa(*spec.GUIOrder)
+	}
+
+	return res
+}
+
+func setSAMLConfig(config map[string]string, saml *keycloakApi.SAMLIdentityProviderConfig) {
+	setStringValue(config, "singleSignOnServiceUrl", saml.SingleSignOnServiceURL)
+	setStringValue(config, "singleLogoutServiceUrl", saml.SingleLogoutServiceURL)
+	setStringValue(config, "idpEntityId", saml.IDPEntityID)
+	setStringValue(config, "entityId", saml.EntityID)
+	setStringValue(config, "signingCertificate", strings.Join(saml.SigningCertificates, ","))
+	setStringValue(config, "nameIDPolicyFormat", saml.NameIDPolicyFormat)
+	setStringValue(config, "principalType", saml.PrincipalType)
+	setStringValue(config, "principalAttribute", saml.PrincipalAttribute)
+	setStringValue(config, "signatureAlgorithm", saml.SignatureAlgorithm)
+	setBoolValue(config, "wantAssertionsSigned", saml.WantAssertionsSigned)
+	setBoolValue(config, "wantAssertionsEncrypted", saml.WantAssertionsEncrypted)
+	setBoolValue(config, "wantAuthnRequestsSigned", saml.WantAuthnRequestsSigned)
+	setBoolValue(config, "validateSignature", saml.ValidateSignature)
+	setBoolValue(config, "postBindingResponse", saml.PostBindingResponse)
+	setBoolValue(config, "postBindingAuthnRequest", saml.PostBindingAuthnRequest)
+	setBoolValue(config, "postBindingLogout", saml.PostBindingLogout)
+	setBoolValue(config, "forceAuthn", saml.ForceAuthn)
+}
+
+func setStringValue(config map[string]string, key, value string) {
+	if value != "" {
+		config[key] = value
+	}
+}
+
+func setBoolValue(config map[string]string, key string, value *bool) {
+	if value != nil {
+		config[key] = strconv.FormatBool(*value)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -176,3 +177,244 @@ func TestIsSpecUpdated(t *testing.T) {
 		t.Fatal("spec updated")
 	}
 }
+
+func TestMakeIDPConfig(t *testing.T) {
+	kcAdapter := adapter.Mock{}
+	signed := true
+	postBinding := false
+
+	spec := keycloakApi.KeycloakRealmIdentityProviderSpec{
+		ProviderID: "saml",
+		Alias:      "saml-idp",
+		Config:     map[string]string{"syncMode": "IMPORT", "postBindingResponse": "true"},
+		SAML: &keycloakApi.SAMLIdentityProviderConfig{
+			MetadataURL:            "https://idp.example.com/metadata",
+			SingleSignOnServiceURL: "https://idp.example.com/sso",
+			SigningCertificates:    []string{"cert1", "cert2"},
+			WantAssertionsSigned:   &signed,
+			PostBindingResponse:    &postBinding,
+		},
+	}
+
+	kcAdapter.On("ImportIdentityProviderConfig", "realm1", "saml", "https://idp.example.com/metadata").
+		Return(map[string]string{
+			"singleSignOnServiceUrl":  "https://idp.example.com/imported-sso",
+			"wantAuthnRequestsSigned": "true",
+		}, nil)
+
+	config, err := makeIDPConfig(context.Background(), &kcAdapter, "realm1", &spec)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"syncMode":                "IMPORT",
+		"singleSignOnServiceUrl":  "https://idp.example.com/sso",
+		"wantAuthnRequestsSigned": "true",
+		"signingCertificate":      "cert1,cert2",
+		"wantAssertionsSigned":    "true",
+		"postBindingResponse":     "false",
+	}, config)
+
+	spec.ProviderID = "oidc"
+	_, err = makeIDPConfig(context.Background(), &kcAdapter, "realm1", &spec)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "saml config is allowed only for the saml provider")
+
+	spec.SAML = nil
+	config, err = makeIDPConfig(context.Background(), &kcAdapter, "realm1", &spec)
+	require.NoError(t, err)
+	assert.Equal(t, spec.Config, config)
+}
+
+func TestSyncIDPMappers(t *testing.T) {
+	kcAdapter := adapter.Mock{}
+	spec := keycloakApi.KeycloakRealmIdentityProviderSpec{
+		Alias: "alias1",
+		Mappers: []keycloakApi.IdentityProviderMapper{
+			{Name: "keep", IdentityProviderMapper: "hardcoded-role-idp-mapper"},
+			{Name: "new", IdentityProviderMapper: "oidc-username-idp-mapper"},
+		},
+	}
+
+	kcAdapter.On("GetIDPMappers", "realm1", "alias1").Return([]adapter.IdentityProviderMapper{
+		{ID: "id-keep", Name: "keep"},
+		{ID: "id-old", Name: "old"},
+	}, nil)
+	kcAdapter.On("UpdateIDPMapper", "realm1", "alias1", &adapter.IdentityProviderMapper{
+		ID: "id-keep", Name: "keep", IdentityProviderAlias: "alias1", IdentityProviderMapper: "hardcoded-role-idp-mapper",
+	}).Return(nil)
+	kcAdapter.On("CreateIDPMapper", "realm1", "alias1", &adapter.IdentityProviderMapper{
+		Name: "new", IdentityProviderAlias: "alias1", IdentityProviderMapper: "oidc-username-idp-mapper",
+	}).Return("id-new", nil)
+	kcAdapter.On("DeleteIDPMapper", "realm1", "alias1", "id-old").Return(nil)
+
+	require.NoError(t, syncIDPMappers(context.Background(), &spec, &kcAdapter, "realm1"))
+	kcAdapter.AssertExpectations(t)
+}
+
+func TestSyncIDPMappers_EmptyList(t *testing.T) {
+	kcAdapter := adapter.Mock{}
+	spec := keycloakApi.KeycloakRealmIdentityProviderSpec{Alias: "alias1"}
+
+	require.NoError(t, syncIDPMappers(context.Background(), &spec, &kcAdapter, "realm1"))
+	kcAdapter.AssertNotCalled(t, "GetIDPMappers", "realm1", "alias1")
+
+	spec.Mappers = []keycloakApi.IdentityProviderMapper{}
+
+	kcAdapter.On("GetIDPMappers", "realm1", "alias1").Return([]adapter.IdentityProviderMapper{
+		{ID: "id-last", Name: "last"},
+	}, nil)
+	kcAdapter.On("DeleteIDPMapper", "realm1", "alias1", "id-last").Return(nil)
+
+	require.NoError(t, syncIDPMappers(context.Background(), &spec, &kcAdapter, "realm1"))
+	kcAdapter.AssertExpectations(t)
+}
+
+func TestReconcile_getClientSecret(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idp-secret", Namespace: "ns"},
+		Data:       map[string][]byte{"secret": []byte("client-secret")},
+	}
+	r := NewReconcile(fake.NewClientBuilder().WithScheme(sch).WithObjects(&secret).Build(), mock.NewLogr(), nil)
+
+	idp := keycloakApi.KeycloakRealmIdentityProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+			ClientSecretRef: &keycloakApi.SecretKeyRef{Name: "idp-secret", Key: "secret"},
+		},
+	}
+
+	value, err := r.getClientSecret(context.Background(), &idp)
+	require.NoError(t, err)
+	assert.Equal(t, "client-secret", value)
+
+	idp.Spec.ClientSecretRef.Key = "wrong"
+	_, err = r.getClientSecret(context.Background(), &idp)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not contain key wrong")
+
+	idp.Spec.ClientSecretRef.Name = "missing"
+	_, err = r.getClientSecret(context.Background(), &idp)
+	require.Error(t, err)
+}
+
+func TestReconcile_mapSecretToIdentityProviders(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+	utilruntime.Must(corev1.AddToScheme(sch))
+
+	withRef := keycloakApi.KeycloakRealmIdentityProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "with-ref", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+			ClientSecretRef: &keycloakApi.SecretKeyRef{Name: "idp-secret", Key: "secret"},
+		},
+	}
+	withoutRef := keycloakApi.KeycloakRealmIdentityProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "without-ref", Namespace: "ns"},
+	}
+	r := NewReconcile(fake.NewClientBuilder().WithScheme(sch).WithObjects(&withRef, &withoutRef).Build(),
+		mock.NewLogr(), nil)
+
+	requests := r.mapSecretToIdentityProviders(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idp-secret", Namespace: "ns"},
+	})
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "with-ref", Namespace: "ns"}},
+	}, requests)
+
+	assert.Empty(t, r.mapSecretToIdentityProviders(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"},
+	}))
+}
+
+func TestWithConfigValue(t *testing.T) {
+	config := map[string]string{"clientId": "foo"}
+
+	res := withConfigValue(config, "clientSecret", "bar")
+	assert.Equal(t, map[string]string{"clientId": "foo", "clientSecret": "bar"}, res)
+	assert.NotContains(t, config, "clientSecret", "source config must not be modified")
+}
+
+func TestReconcile_setBrokerLoginFlows(t *testing.T) {
+	sch := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(sch))
+
+	flow := keycloakApi.KeycloakAuthFlow{
+		ObjectMeta: metav1.ObjectMeta{Name: "first-login", Namespace: "ns"},
+		Spec:       keycloakApi.KeycloakAuthFlowSpec{Realm: "realm1", Alias: "custom first broker login"},
+	}
+	otherRealmFlow := keycloakApi.KeycloakAuthFlow{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-realm", Namespace: "ns"},
+		Spec:       keycloakApi.KeycloakAuthFlowSpec{Realm: "realm2", Alias: "other"},
+	}
+	r := NewReconcile(fake.NewClientBuilder().WithScheme(sch).WithObjects(&flow, &otherRealmFlow).Build(),
+		mock.NewLogr(), nil)
+
+	kcAdapter := adapter.Mock{}
+	kcAdapter.On("AuthFlowExists", "ns.realm1", "custom first broker login").Return(true, nil)
+	kcAdapter.On("AuthFlowExists", "ns.realm1", "post login").Return(true, nil)
+	kcAdapter.On("AuthFlowExists", "ns.realm1", "missing").Return(false, nil)
+
+	idp := keycloakApi.KeycloakRealmIdentityProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+			Realm:                     "realm1",
+			FirstBrokerLoginFlowAlias: "ignored",
+			FirstBrokerLoginFlowRef:   "first-login",
+			PostBrokerLoginFlowAlias:  gocloak.StringP("post login"),
+		},
+	}
+	keycloakIDP := adapter.IdentityProvider{}
+
+	err := r.setBrokerLoginFlows(context.Background(), &kcAdapter, "ns.realm1", &idp, &keycloakIDP)
+	require.NoError(t, err)
+	assert.Equal(t, "custom first broker login", keycloakIDP.FirstBrokerLoginFlowAlias)
+	assert.Equal(t, gocloak.StringP("post login"), keycloakIDP.PostBrokerLoginFlowAlias)
+
+	idp.Spec.PostBrokerLoginFlowAlias = gocloak.StringP("")
+	err = r.setBrokerLoginFlows(context.Background(), &kcAdapter, "ns.realm1", &idp, &keycloakIDP)
+	require.NoError(t, err)
+	assert.Equal(t, gocloak.StringP(""), keycloakIDP.PostBrokerLoginFlowAlias, "empty alias removes the flow")
+
+	idp.Spec.PostBrokerLoginFlowAlias = nil
+	err = r.setBrokerLoginFlows(context.Background(), &kcAdapter, "ns.realm1", &idp, &keycloakIDP)
+	require.NoError(t, err)
+	assert.Nil(t, keycloakIDP.PostBrokerLoginFlowAlias, "unset alias is not managed")
+
+	idp.Spec.PostBrokerLoginFlowAlias = gocloak.StringP("missing")
+	err = r.setBrokerLoginFlows(context.Background(), &kcAdapter, "ns.realm1", &idp, &keycloakIDP)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "auth flow missing does not exist in realm ns.realm1")
+
+	idp.Spec.FirstBrokerLoginFlowRef = "other-realm"
+	err = r.setBrokerLoginFlows(context.Background(), &kcAdapter, "ns.realm1", &idp, &keycloakIDP)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "belongs to realm realm2")
+
+	idp.Spec.FirstBrokerLoginFlowRef = "not-found"
+	err = r.setBrokerLoginFlows(context.Background(), &kcAdapter, "ns.realm1", &idp, &keycloakIDP)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get auth flow not-found")
+}
+
+func TestWithLoginSettings(t *testing.T) {
+	config := map[string]string{"clientId": "foo", "syncMode": "LEGACY"}
+	spec := keycloakApi.KeycloakRealmIdentityProviderSpec{}
+
+	assert.Equal(t, config, withLoginSettings(config, &spec))
+
+	hide := true
+	order := 2
+	spec.SyncMode = "FORCE"
+	spec.HideOnLoginPage = &hide
+	spec.GUIOrder = &order
+
+	assert.Equal(t, map[string]string{
+		"clientId":        "foo",
+		"syncMode":        "FORCE",
+		"hideOnLoginPage": "true",
+		"guiOrder":        "2",
+	}, withLoginSettings(config, &spec))
+	assert.Equal(t, "LEGACY", config["syncMode"], "source config must not be modified")
+}
@@ -0,0 +1,67 @@
+package keycloakrealmidentityprovider
+
+import (
+	"strconv"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+)
+
+const (
+	githubProviderID    = "github"
+	googleProviderID    = "google"
+	microsoftProviderID = "microsoft"
+	gitlabProviderID    = "gitlab"
+
+	clientIDConfigKey     = "clientId"
+	defaultScopeConfigKey = "defaultScope"
+)
+
+// makeSocialConfigs returns configs of the typed social providers set in the spec, keyed by provider id.
+// Empty values of the configs are not applied.
+func makeSocialConfigs(spec *keycloakApi.KeycloakRealmIdentityProviderSpec) map[string]map[string]string {
+	configs := make(map[string]map[string]string)
+
+	if c := spec.GitHub; c != nil {
+		configs[githubProviderID] = map[string]string{
+			clientIDConfigKey:     c.ClientID,
+			defaultScopeConfigKey: c.DefaultScope,
+			"baseUrl":             c.BaseURL,
+			"apiUrl":              c.APIURL,
+		}
+	}
+
+	if c := spec.Google; c != nil {
+		configs[googleProviderID] = map[string]string{
+			clientIDConfigKey:     c.ClientID,
+			defaultScopeConfigKey: c.DefaultScope,
+			"hostedDomain":        c.HostedDomain,
+			"userIp":              formatBool(c.UseUserIPParam),
+			"offlineAccess":       formatBool(c.OfflineAccess),
+		}
+	}
+
+	if c := spec.Microsoft; c != nil {
+		configs[microsoftProviderID] = map[string]string{
+			clientIDConfigKey:     c.ClientID,
+			defaultScopeConfigKey: c.DefaultScope,
+			"tenantId":            c.TenantID,
+		}
+	}
+
+	if c := spec.GitLab; c != nil {
+		configs[gitlabProviderID] = map[string]string{
+			clientIDConfigKey:     c.ClientID,
+			defaultScopeConfigKey: c.DefaultScope,
+		}
+	}
+
+	return configs
+}
+
+func formatBool(value *bool) string {
+	if value == nil {
+		return ""
+	}
+
+	return strconv.FormatBool(*value)
+}
@@ -0,0 +1,107 @@
+package keycloakrealmidentityprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestMakeIDPConfig_Social(t *testing.T) {
+	offline := true
+
+	tests := []struct {
+		name    string
+		spec    keycloakApi.KeycloakRealmIdentityProviderSpec
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "github",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "github",
+				Config:     map[string]string{"clientId": "raw", "useJwksUrl": "true"},
+				GitHub: &keycloakApi.GitHubIdentityProviderConfig{
+					ClientID: "gh-client",
+					BaseURL:  "https://github.example.com",
+					APIURL:   "https://github.example.com/api/v3",
+				},
+			},
+			want: map[string]string{
+				"clientId":   "gh-client",
+				"useJwksUrl": "true",
+				"baseUrl":    "https://github.example.com",
+				"apiUrl":     "https://github.example.com/api/v3",
+			},
+		},
+		{
+			name: "google",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "google",
+				Google: &keycloakApi.GoogleIdentityProviderConfig{
+					ClientID:      "google-client",
+					HostedDomain:  "example.com",
+					OfflineAccess: &offline,
+				},
+			},
+			want: map[string]string{
+				"clientId":      "google-client",
+				"hostedDomain":  "example.com",
+				"offlineAccess": "true",
+			},
+		},
+		{
+			name: "microsoft",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "microsoft",
+				Microsoft:  &keycloakApi.MicrosoftIdentityProviderConfig{ClientID: "ms-client", TenantID: "tenant"},
+			},
+			want: map[string]string{"clientId": "ms-client", "tenantId": "tenant"},
+		},
+		{
+			name: "gitlab",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "gitlab",
+				GitLab:     &keycloakApi.GitLabIdentityProviderConfig{ClientID: "gl-client", DefaultScope: "openid read_user"},
+			},
+			want: map[string]string{"clientId": "gl-client", "defaultScope": "openid read_user"},
+		},
+		{
+			name: "wrong provider id",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "oidc",
+				GitLab:     &keycloakApi.GitLabIdentityProviderConfig{ClientID: "gl-client"},
+			},
+			wantErr: "gitlab config is allowed only for the gitlab provider, got: oidc",
+		},
+		{
+			name: "several typed configs",
+			spec: keycloakApi.KeycloakRealmIdentityProviderSpec{
+				ProviderID: "gitlab",
+				GitLab:     &keycloakApi.GitLabIdentityProviderConfig{ClientID: "gl-client"},
+				SAML:       &keycloakApi.SAMLIdentityProviderConfig{},
+			},
+			wantErr: "only one typed identity provider config can be set",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := makeIDPConfig(context.Background(), &adapter.Mock{}, "realm1", &tt.spec)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, config)
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package keycloakrealmimport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/artifact"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// downloadTimeout is a timeout of the realm representation download from the URL or the OCI registry.
+const downloadTimeout = time.Minute
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	httpClient              *http.Client
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client:     client,
+		helper:     helper,
+		httpClient: &http.Client{Timeout: downloadTimeout},
+		log:        log.WithName("keycloak-realm-import"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmImport{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmImport controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmImport)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakRealmImport)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec)
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmimports,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmimports/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmimports/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get
+
+// Reconcile is a loop for reconciling KeycloakRealmImport object.
+// The imported resources are kept in keycloak after the import is deleted.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result,
+	resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakRealmImport")
+
+	var instance keycloakApi.KeycloakRealmImport
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak realm import from k8s")
+
+		return
+	}
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		helper.SetErrorStatus(&instance, err)
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak realm import", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = err
+	}
+
+	log.Info("Reconciling KeycloakRealmImport done")
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, realmImport *keycloakApi.KeycloakRealmImport) error {
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(realmImport, &realmImport.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	data, err := r.getRepresentationData(ctx, realmImport)
+	if err != nil {
+		return err
+	}
+
+	var representation map[string]interface{}
+	if err := json.Unmarshal(data, &representation); err != nil {
+		return errors.Wrap(err, "realm representation must be a json object")
+	}
+
+	hash := sourceHash(realmImport, data)
+	if realmImport.Status.SourceHash == hash && realmImport.Status.Value == helper.StatusOK {
+		r.log.Info("Realm representation is not changed, skipping import", "name", realmImport.Name)
+
+		return nil
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	res, err := kClient.PartialImport(ctx, realm.Spec.RealmName, representation, ifResourceExists(realmImport))
+	if err != nil {
+		return err
+	}
+
+	r.log.Info("Realm representation is imported", "name", realmImport.Name,
+		"added", res.Added, "overwritten", res.Overwritten, "skipped", res.Skipped)
+
+	realmImport.Status.SourceHash = hash
+	realmImport.Status.Added = res.Added
+	realmImport.Status.Overwritten = res.Overwritten
+	realmImport.Status.Skipped = res.Skipped
+
+	return nil
+}
+
+// getRepresentationData returns the inline realm representation
+// or reads it from the config map, the secret, the URL or the OCI artifact referenced by the import.
+func (r *Reconcile) getRepresentationData(ctx context.Context,
+	realmImport *keycloakApi.KeycloakRealmImport) ([]byte, error) {
+	source := realmImport.Spec.Source
+	if source == nil {
+		source = &keycloakApi.RealmImportSource{}
+	}
+
+	refs := 0
+
+	for _, set := range []bool{source.ConfigMapKeyRef != nil, source.SecretKeyRef != nil, source.URL != nil,
+		source.OCI != nil} {
+		if set {
+			refs++
+		}
+	}
+
+	inline := realmImport.Spec.Representation != nil
+
+	switch {
+	case inline && refs > 0:
+		return nil, errors.New("representation and source can not be used together")
+	case inline:
+		return realmImport.Spec.Representation.Raw, nil
+	case refs > 1:
+		return nil, errors.New("only one of configMapKeyRef, secretKeyRef, url and oci can be set")
+	case source.ConfigMapKeyRef != nil:
+		var cm coreV1.ConfigMap
+		if err := r.client.Get(ctx, types.NamespacedName{Name: source.ConfigMapKeyRef.Name,
+			Namespace: realmImport.Namespace}, &cm); err != nil {
+			return nil, errors.Wrapf(err, "unable to get realm representation config map %s", source.ConfigMapKeyRef.Name)
+		}
+
+		data, ok := cm.Data[source.ConfigMapKeyRef.Key]
+		if !ok {
+			return nil, errors.Errorf("realm representation config map %s does not contain key %s",
+				source.ConfigMapKeyRef.Name, source.ConfigMapKeyRef.Key)
+		}
+
+		return []byte(data), nil
+	case source.SecretKeyRef != nil:
+		var secret coreV1.Secret
+		if err := r.client.Get(ctx, types.NamespacedName{Name: source.SecretKeyRef.Name,
+			Namespace: realmImport.Namespace}, &secret); err != nil {
+			return nil, errors.Wrapf(err, "unable to get realm representation secret %s", source.SecretKeyRef.Name)
+		}
+
+		data, ok := secret.Data[source.SecretKeyRef.Key]
+		if !ok {
+			return nil, errors.Errorf("realm representation secret %s does not contain key %s",
+				source.SecretKeyRef.Name, source.SecretKeyRef.Key)
+		}
+
+		return data, nil
+	case source.URL != nil:
+		return r.fetchURL(ctx, realmImport.Namespace, source.URL)
+	case source.OCI != nil:
+		return r.fetchOCI(ctx, realmImport.Namespace, source.OCI)
+	default:
+		return nil, errors.New("representation or source must be set")
+	}
+}
+
+func (r *Reconcile) fetchURL(ctx context.Context, namespace string,
+	source *keycloakApi.RealmImportURLSource) ([]byte, error) {
+	var creds *artifact.Credentials
+
+	if source.AuthSecret != "" {
+		secret, err := r.getAuthSecret(ctx, namespace, source.AuthSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		creds = &artifact.Credentials{
+			Username: string(secret.Data["username"]),
+			Password: string(secret.Data["password"]),
+			Token:    string(secret.Data["token"]),
+		}
+	}
+
+	data, err := artifact.FetchURL(ctx, r.httpClient, source.URL, source.SHA256, creds)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to download realm representation")
+	}
+
+	return data, nil
+}
+
+func (r *Reconcile) fetchOCI(ctx context.Context, namespace string,
+	source *keycloakApi.RealmImportOCISource) ([]byte, error) {
+	var creds *artifact.Credentials
+
+	if source.AuthSecret != "" {
+		ref, err := artifact.ParseReference(source.Reference)
+		if err != nil {
+			return nil, err
+		}
+
+		secret, err := r.getAuthSecret(ctx, namespace, source.AuthSecret)
+		if err != nil {
+			return nil, err
+		}
+
+		if dockerConfig, ok := secret.Data[coreV1.DockerConfigJsonKey]; ok {
+			if creds, err = artifact.CredentialsFromDockerConfig(dockerConfig, ref.Registry); err != nil {
+				return nil, errors.Wrapf(err, "invalid registry credentials secret %s", source.AuthSecret)
+			}
+		} else {
+			creds = &artifact.Credentials{
+				Username: string(secret.Data["username"]),
+				Password: string(secret.Data["password"]),
+			}
+		}
+	}
+
+	data, err := artifact.FetchOCI(ctx, r.httpClient, source.Reference, source.File, creds)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to pull realm representation")
+	}
+
+	return data, nil
+}
+
+func (r *Reconcile) getAuthSecret(ctx context.Context, namespace, name string) (*coreV1.Secret, error) {
+	var secret coreV1.Secret
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &secret); err != nil {
+		return nil, errors.Wrapf(err, "unable to get auth secret %s", name)
+	}
+
+	return &secret, nil
+}
+
+// ifResourceExists returns the partial import policy of the existing resources, the import fails by default.
+func ifResourceExists(realmImport *keycloakApi.KeycloakRealmImport) string {
+	if realmImport.Spec.IfResourceExists == "" {
+		return adapter.PartialImportIfExistsFail
+	}
+
+	return realmImport.Spec.IfResourceExists
+}
+
+// sourceHash is a hash of the realm representation and the settings which affect the import.
+func sourceHash(realmImport *keycloakApi.KeycloakRealmImport, data []byte) string {
+	h := sha256.New()
+	h.Write([]byte(realmImport.Spec.Realm + "\n" + ifResourceExists(realmImport) + "\n"))
+	h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,251 @@
+package keycloakrealmimport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v12"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func getTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+	utilruntime.Must(coreV1.AddToScheme(scheme))
+
+	return scheme
+}
+
+func getTestRealm() *keycloakApi.KeycloakRealm {
+	return &keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "realm.test"}}
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	realm := getTestRealm()
+	realmImport := &keycloakApi.KeycloakRealmImport{
+		ObjectMeta: metav1.ObjectMeta{Name: "import", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmImportSpec{
+			Realm: "test",
+			Source: &keycloakApi.RealmImportSource{
+				SecretKeyRef: &keycloakApi.SecretKeyRef{Name: "realm-export", Key: "realm.json"},
+			},
+			IfResourceExists: adapter.PartialImportIfExistsOverwrite,
+		},
+	}
+	secret := coreV1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "realm-export", Namespace: "ns"},
+		Data: map[string][]byte{"realm.json": []byte(`{"realm":"old","groups":[{"name":"developers"}]}`)}}
+
+	client := fake.NewClientBuilder().WithScheme(getTestScheme()).WithRuntimeObjects(realmImport, realm, &secret).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("PartialImport", "realm.test", map[string]interface{}{
+		"realm":  "old",
+		"groups": []interface{}{map[string]interface{}{"name": "developers"}},
+	}, adapter.PartialImportIfExistsOverwrite).Return(&adapter.PartialImportResult{Added: 1, Overwritten: 2}, nil)
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(realm, nil)
+	h.On("CreateKeycloakClientForRealm", realm).Return(kClient, nil)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := Reconcile{
+		client:                  client,
+		log:                     mock.NewLogr(),
+		helper:                  &h,
+		successReconcileTimeout: time.Hour,
+	}
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: realmImport.Name, Namespace: realmImport.Namespace},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakRealmImport)
+	require.True(t, ok)
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	require.Equal(t, 1, updated.Status.Added)
+	require.Equal(t, 2, updated.Status.Overwritten)
+	require.Equal(t, 0, updated.Status.Skipped)
+	require.NotEmpty(t, updated.Status.SourceHash)
+	kClient.AssertExpectations(t)
+
+	// the unchanged representation is not imported again.
+	require.NoError(t, rec.tryReconcile(context.Background(), updated))
+	kClient.AssertNumberOfCalls(t, "PartialImport", 1)
+}
+
+func TestReconcile_Reconcile_ImportFailure(t *testing.T) {
+	realm := getTestRealm()
+	realmImport := &keycloakApi.KeycloakRealmImport{
+		ObjectMeta: metav1.ObjectMeta{Name: "import", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmImportSpec{
+			Realm:          "test",
+			Representation: &apiextensionsv1.JSON{Raw: []byte(`{"users":[{"username":"user1"}]}`)},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(getTestScheme()).WithRuntimeObjects(realmImport, realm).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("PartialImport", "realm.test", testifyMock.Anything, adapter.PartialImportIfExistsFail).
+		Return(nil, &gocloak.APIError{Code: http.StatusConflict, Message: "User exists with same username"})
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(realm, nil)
+	h.On("CreateKeycloakClientForRealm", realm).Return(kClient, nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := Reconcile{
+		client: client,
+		log:    mock.NewLogr(),
+		helper: &h,
+	}
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: realmImport.Name, Namespace: realmImport.Namespace},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakRealmImport)
+	require.True(t, ok)
+	require.Contains(t, updated.Status.Value, "User exists with same username")
+	require.Empty(t, updated.Status.SourceHash)
+}
+
+func TestReconcile_getRepresentationData(t *testing.T) {
+	cm := coreV1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "realm-export", Namespace: "ns"},
+		Data: map[string]string{"realm.json": `{"clients":[]}`}}
+	authSecret := coreV1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "realm-auth", Namespace: "ns"},
+		Data: map[string][]byte{"username": []byte("user"), "password": []byte("pass")}}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"roles":{}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		spec    keycloakApi.KeycloakRealmImportSpec
+		want    string
+		wantErr string
+	}{
+		{
+			name: "inline",
+			spec: keycloakApi.KeycloakRealmImportSpec{
+				Representation: &apiextensionsv1.JSON{Raw: []byte(`{"groups":[]}`)},
+			},
+			want: `{"groups":[]}`,
+		},
+		{
+			name: "config map",
+			spec: keycloakApi.KeycloakRealmImportSpec{Source: &keycloakApi.RealmImportSource{
+				ConfigMapKeyRef: &keycloakApi.ConfigMapKeyRef{Name: "realm-export", Key: "realm.json"},
+			}},
+			want: `{"clients":[]}`,
+		},
+		{
+			name: "missing config map key",
+			spec: keycloakApi.KeycloakRealmImportSpec{Source: &keycloakApi.RealmImportSource{
+				ConfigMapKeyRef: &keycloakApi.ConfigMapKeyRef{Name: "realm-export", Key: "missing"},
+			}},
+			wantErr: "realm representation config map realm-export does not contain key missing",
+		},
+		{
+			name: "inline and source",
+			spec: keycloakApi.KeycloakRealmImportSpec{
+				Representation: &apiextensionsv1.JSON{Raw: []byte(`{}`)},
+				Source: &keycloakApi.RealmImportSource{
+					ConfigMapKeyRef: &keycloakApi.ConfigMapKeyRef{Name: "realm-export", Key: "realm.json"},
+				},
+			},
+			wantErr: "representation and source can not be used together",
+		},
+		{
+			name: "url",
+			spec: keycloakApi.KeycloakRealmImportSpec{Source: &keycloakApi.RealmImportSource{
+				URL: &keycloakApi.RealmImportURLSource{URL: server.URL + "/realm.json", AuthSecret: "realm-auth"},
+			}},
+			want: `{"roles":{}}`,
+		},
+		{
+			name: "url without auth",
+			spec: keycloakApi.KeycloakRealmImportSpec{Source: &keycloakApi.RealmImportSource{
+				URL: &keycloakApi.RealmImportURLSource{URL: server.URL + "/realm.json"},
+			}},
+			wantErr: "unexpected status 401",
+		},
+		{
+			name: "oci with missing auth secret",
+			spec: keycloakApi.KeycloakRealmImportSpec{Source: &keycloakApi.RealmImportSource{
+				OCI: &keycloakApi.RealmImportOCISource{Reference: "ghcr.io/org/realm:v1", AuthSecret: "missing"},
+			}},
+			wantErr: "unable to get auth secret missing",
+		},
+		{
+			name: "several sources",
+			spec: keycloakApi.KeycloakRealmImportSpec{Source: &keycloakApi.RealmImportSource{
+				ConfigMapKeyRef: &keycloakApi.ConfigMapKeyRef{Name: "realm-export", Key: "realm.json"},
+				URL:             &keycloakApi.RealmImportURLSource{URL: server.URL},
+			}},
+			wantErr: "only one of configMapKeyRef, secretKeyRef, url and oci can be set",
+		},
+		{
+			name:    "nothing set",
+			wantErr: "representation or source must be set",
+		},
+	}
+
+	rec := Reconcile{
+		client:     fake.NewClientBuilder().WithScheme(getTestScheme()).WithRuntimeObjects(&cm, &authSecret).Build(),
+		log:        mock.NewLogr(),
+		httpClient: server.Client(),
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			realmImport := &keycloakApi.KeycloakRealmImport{
+				ObjectMeta: metav1.ObjectMeta{Name: "import", Namespace: "ns"},
+				Spec:       tt.spec,
+			}
+
+			got, err := rec.getRepresentationData(context.Background(), realmImport)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(got))
+		})
+	}
+}
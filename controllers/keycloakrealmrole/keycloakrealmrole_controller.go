@@ -14,8 +14,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
@@ -49,16 +51,23 @@ type ReconcileKeycloakRealmRole struct {
 	successReconcileTimeout time.Duration
 }
 
-func (r *ReconcileKeycloakRealmRole) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+// SetupWithManager registers the controller, it also watches the triggers of the drifted roles.
+func (r *ReconcileKeycloakRealmRole) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration,
+	triggers ...source.Source) error {
 	r.successReconcileTimeout = successReconcileTimeout
 
 	pred := predicate.Funcs{
 		UpdateFunc: isSpecUpdated,
 	}
 
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&keycloakApi.KeycloakRealmRole{}, builder.WithPredicates(pred)).
-		Complete(r)
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmRole{}, builder.WithPredicates(pred))
+
+	for _, t := range triggers {
+		b = b.Watches(t, &handler.EnqueueRequestForObject{})
+	}
+
+	err := b.Complete(r)
 	if err != nil {
 		return fmt.Errorf("failed to setup KeycloakRealmRole controller: %w", err)
 	}
@@ -101,7 +110,9 @@ func (r *ReconcileKeycloakRealmRole) Reconcile(ctx context.Context, request reco
 		return
 	}
 
-	if instance.Status.Value == keycloakApi.StatusDuplicated {
+	policy := helper.GetAdoptionPolicy(&instance, "")
+
+	if instance.Status.Value == keycloakApi.StatusDuplicated && policy == "" && !helper.IsDryRunEnabled(&instance) {
 		log.Info("Role is duplicated, exit.")
 		return
 	}
@@ -114,7 +125,16 @@ func (r *ReconcileKeycloakRealmRole) Reconcile(ctx context.Context, request reco
 
 	roleID, err := r.tryReconcile(ctx, &instance)
 	if err != nil {
-		if adapter.IsErrDuplicated(err) {
+		if helper.IsDryRunPreview(err) {
+			helper.SetDryRunStatus(&instance, err)
+			result.RequeueAfter = r.successReconcileTimeout
+
+			log.Info("Dry run is enabled, changes are not applied", "preview", err.Error())
+
+			return
+		}
+
+		if adapter.IsErrDuplicated(err) && policy == "" {
 			instance.Status.Value = keycloakApi.StatusDuplicated
 
 			log.Info("Role is duplicated", "name", instance.Name)
@@ -122,7 +142,20 @@ func (r *ReconcileKeycloakRealmRole) Reconcile(ctx context.Context, request reco
 			return
 		}
 
-		instance.Status.Value = err.Error()
+		if adapter.IsErrDuplicated(err) {
+			err = helper.CheckAdoption(&instance, policy, "realm role", instance.Spec.Name)
+		}
+
+		if helper.IsAdoptionSkipped(err) {
+			helper.SetSkippedStatus(&instance)
+			result.RequeueAfter = r.successReconcileTimeout
+
+			log.Info("Role already exists in keycloak, skip it", "name", instance.Name)
+
+			return
+		}
+
+		helper.SetErrorStatus(&instance, err)
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
 
 		log.Error(err, "an error has occurred while handling keycloak realm role", "name",
@@ -141,6 +174,10 @@ func (r *ReconcileKeycloakRealmRole) Reconcile(ctx context.Context, request reco
 }
 
 func (r *ReconcileKeycloakRealmRole) tryReconcile(ctx context.Context, keycloakRealmRole *keycloakApi.KeycloakRealmRole) (string, error) {
+	if released, err := helper.ReleaseDeletedDryRun(ctx, r.client, keycloakRealmRole, keyCloakRealmRoleOperatorFinalizerName); err != nil || released {
+		return "", err
+	}
+
 	realm, err := r.helper.GetOrCreateRealmOwnerRef(keycloakRealmRole, &keycloakRealmRole.ObjectMeta)
 	if err != nil {
 		return "", errors.Wrap(err, "unable to get realm owner ref")
@@ -151,13 +188,18 @@ func (r *ReconcileKeycloakRealmRole) tryReconcile(ctx context.Context, keycloakR
 		return "", errors.Wrap(err, "unable to create keycloak client")
 	}
 
+	if helper.IsDryRunEnabled(keycloakRealmRole) {
+		return "", helper.PreviewChanges(ctx, kClient, realm, keycloakRealmRole)
+	}
+
 	roleID, err := r.putRole(realm, keycloakRealmRole, kClient)
 	if err != nil {
 		return "", errors.Wrap(err, "unable to put role")
 	}
 
 	if _, err := r.helper.TryToDelete(ctx, keycloakRealmRole,
-		makeTerminator(realm.Spec.RealmName, keycloakRealmRole.Spec.Name, kClient, r.log.WithName("realm-role-term")),
+		makeTerminator(realm.Spec.RealmName, keycloakRealmRole.Spec.Name, keycloakRealmRole.IsDeletionProtected(), kClient,
+			r.log.WithName("realm-role-term")),
 		keyCloakRealmRoleOperatorFinalizerName); err != nil {
 		return "", errors.Wrap(err, "unable to tryToDelete realm role")
 	}
@@ -174,11 +216,17 @@ func (r *ReconcileKeycloakRealmRole) putRole(
 	log.Info("Start put keycloak cr role...")
 
 	role := dto.ConvertSpecToRole(keycloakRealmRole)
+	role.Adopt = helper.GetAdoptionPolicy(keycloakRealmRole, "") == keycloakApi.AdoptionPolicyAdopt
 
 	if err := kClient.SyncRealmRole(keycloakRealm.Spec.RealmName, role); err != nil {
 		return "", errors.Wrap(err, "unable to sync realm role CR")
 	}
 
+	keycloakRealmRole.Status.UnresolvedComposites = role.UnresolvedComposites
+	if len(role.UnresolvedComposites) > 0 {
+		log.Info("Some composite roles are not found", "unresolved", role.UnresolvedComposites)
+	}
+
 	var roleID string
 
 	if role.ID != nil {
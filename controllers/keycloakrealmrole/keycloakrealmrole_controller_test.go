@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -59,8 +60,8 @@ func TestReconcileKeycloakRealmRole_Reconcile(t *testing.T) {
 	h := helper.Mock{}
 	h.On("GetOrCreateRealmOwnerRef", &role, &role.ObjectMeta).Return(&realm, nil)
 	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
-	h.On("UpdateStatus", &role).Return(nil)
-	h.On("TryToDelete", &role, makeTerminator(realm.Spec.RealmName, role.Spec.Name, kClient, logger),
+	h.On("UpdateStatus", testifymock.Anything).Return(nil)
+	h.On("TryToDelete", &role, makeTerminator(realm.Spec.RealmName, role.Spec.Name, false, kClient, logger),
 		keyCloakRealmRoleOperatorFinalizerName).Return(true, nil)
 
 	rkr := ReconcileKeycloakRealmRole{
@@ -187,6 +188,54 @@ func TestReconcileRoleMarkDuplicated(t *testing.T) {
 	}
 }
 
+func TestReconcileRoleAdoptionPolicyFail(t *testing.T) {
+	ns := "namespace"
+	role := keycloakApi.KeycloakRealmRole{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: ns,
+		Annotations: map[string]string{keycloakApi.AdoptionPolicyAnnotation: keycloakApi.AdoptionPolicyFail}},
+		Spec:   keycloakApi.KeycloakRealmRoleSpec{Name: "test"},
+		Status: keycloakApi.KeycloakRealmRoleStatus{Value: keycloakApi.StatusDuplicated},
+	}
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: ns},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "test"}}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&role).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("SyncRealmRole", "test", testifymock.Anything).
+		Return(errors.Wrap(adapter.DuplicatedError("dup"), "test unwrap"))
+
+	h := helper.Mock{}
+	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
+	h.On("GetOrCreateRealmOwnerRef", testifymock.Anything, testifymock.Anything).Return(&realm, nil)
+	h.On("SetFailureCount", testifymock.Anything).Return(time.Minute)
+	h.On("UpdateStatus", testifymock.Anything).Return(nil)
+
+	rkr := ReconcileKeycloakRealmRole{
+		log:    mock.NewLogr(),
+		client: client,
+		helper: &h,
+	}
+
+	res, err := rkr.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      role.Name,
+			Namespace: role.Namespace,
+		}})
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakRealmRole)
+	require.True(t, ok)
+	require.Contains(t, updated.Status.Value, "realm role test already exists in keycloak")
+
+	synced, ok := kClient.Calls[0].Arguments.Get(1).(*dto.PrimaryRealmRole)
+	require.True(t, ok)
+	require.False(t, synced.Adopt)
+}
+
 func TestReconcileKeycloakRealmRole_ReconcileFailure(t *testing.T) {
 	scheme := runtime.NewScheme()
 	utilruntime.Must(keycloakApi.AddToScheme(scheme))
@@ -225,8 +274,8 @@ func TestReconcileKeycloakRealmRole_ReconcileFailure(t *testing.T) {
 
 	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
 	h.On("GetOrCreateRealmOwnerRef", &role, &role.ObjectMeta).Return(&realm, nil)
-	h.On("SetFailureCount", &role).Return(time.Second)
-	h.On("UpdateStatus", &role).Return(nil)
+	h.On("SetFailureCount", testifymock.Anything).Return(time.Second)
+	h.On("UpdateStatus", testifymock.Anything).Return(nil)
 
 	rkr := ReconcileKeycloakRealmRole{
 		client: client,
@@ -6,11 +6,13 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
 )
 
 type terminator struct {
 	realmName, realmRoleName string
+	deletionProtected        bool
 	kClient                  keycloak.Client
 	log                      logr.Logger
 }
@@ -19,6 +21,18 @@ func (t *terminator) DeleteResource(ctx context.Context) error {
 	log := t.log.WithValues("keycloak realm role cr", t.realmRoleName)
 	log.Info("Start deleting keycloak realm role...")
 
+	if t.deletionProtected {
+		usage, err := t.kClient.GetRealmRoleUsage(ctx, t.realmName, t.realmRoleName)
+		if err != nil {
+			return errors.Wrap(err, "unable to check realm role usage")
+		}
+
+		if usage.InUse() {
+			return errors.Errorf("realm role is protected from deletion and still in use by %s, set the %s annotation to true to delete it",
+				usage, keycloakApi.ForceDeleteAnnotation)
+		}
+	}
+
 	if err := t.kClient.DeleteRealmRole(ctx, t.realmName, t.realmRoleName); err != nil {
 		return errors.Wrap(err, "unable to delete realm role")
 	}
@@ -32,11 +46,13 @@ func (t *terminator) GetLogger() logr.Logger {
 	return t.log
 }
 
-func makeTerminator(realmName, realmRoleName string, kClient keycloak.Client, log logr.Logger) *terminator {
+func makeTerminator(realmName, realmRoleName string, deletionProtected bool, kClient keycloak.Client,
+	log logr.Logger) *terminator {
 	return &terminator{
-		realmRoleName: realmRoleName,
-		realmName:     realmName,
-		kClient:       kClient,
-		log:           log,
+		realmRoleName:     realmRoleName,
+		realmName:         realmName,
+		deletionProtected: deletionProtected,
+		kClient:           kClient,
+		log:               log,
 	}
 }
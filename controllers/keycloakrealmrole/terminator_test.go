@@ -16,7 +16,7 @@ func TestTerminator(t *testing.T) {
 	lg := mock.NewLogr()
 	kClient := new(adapter.Mock)
 
-	term := makeTerminator("foo", "bar", kClient, lg)
+	term := makeTerminator("foo", "bar", false, kClient, lg)
 	kClient.On("DeleteRealmRole", "foo", "bar").Return(nil).Once()
 
 	err := term.DeleteResource(context.Background())
@@ -32,3 +32,22 @@ func TestTerminator(t *testing.T) {
 
 	assert.NotEmpty(t, loggerSink.InfoMessages(), "no info messages logged")
 }
+
+func TestTerminator_DeletionProtected(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("GetRealmRoleUsage", "foo", "bar").
+		Return(&adapter.RoleUsage{Groups: []string{"/admins"}}, nil).Once()
+
+	term := makeTerminator("foo", "bar", true, kClient, mock.NewLogr())
+
+	err := term.DeleteResource(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "groups: /admins")
+	kClient.AssertNotCalled(t, "DeleteRealmRole", "foo", "bar")
+
+	kClient.On("GetRealmRoleUsage", "foo", "bar").Return(&adapter.RoleUsage{}, nil).Once()
+	kClient.On("DeleteRealmRole", "foo", "bar").Return(nil).Once()
+
+	require.NoError(t, term.DeleteResource(context.Background()))
+	kClient.AssertExpectations(t)
+}
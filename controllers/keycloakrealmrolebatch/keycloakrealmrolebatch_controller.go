@@ -3,6 +3,8 @@ package keycloakrealmrolebatch
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Nerzal/gocloak/v12"
@@ -23,7 +25,11 @@ import (
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 )
 
-const keyCloakRealmRoleBatchOperatorFinalizerName = "keycloak.realmrolebatch.operator.finalizer.name"
+const (
+	keyCloakRealmRoleBatchOperatorFinalizerName = "keycloak.realmrolebatch.operator.finalizer.name"
+	// batchWorkers is a max number of the batch roles which are put concurrently.
+	batchWorkers = 10
+)
 
 type Helper interface {
 	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
@@ -87,7 +93,7 @@ func (r *ReconcileKeycloakRealmRoleBatch) Reconcile(ctx context.Context, request
 	}
 
 	if err := r.tryReconcile(ctx, &instance); err != nil {
-		instance.Status.Value = err.Error()
+		helper.SetErrorStatus(&instance, err)
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
 		r.log.Error(err, "an error has occurred while handling keycloak realm role batch", "name",
 			request.Name)
@@ -145,58 +151,121 @@ func (r *ReconcileKeycloakRealmRoleBatch) removeRoles(ctx context.Context, batch
 	return nil
 }
 
+// putRoles creates the KeycloakRealmRole custom resources of the batch roles using a bounded number of workers.
+// Failure of a role doesn't stop processing of the other roles, the status of each role is set to the batch status.
 func (r *ReconcileKeycloakRealmRoleBatch) putRoles(ctx context.Context, batch *keycloakApi.KeycloakRealmRoleBatch,
-	realm *keycloakApi.KeycloakRealm) (roles []keycloakApi.KeycloakRealmRole, resultErr error) {
-	log := r.log.WithValues("keycloak role batch cr", batch)
+	realm *keycloakApi.KeycloakRealm) ([]keycloakApi.KeycloakRealmRole, error) {
+	log := r.log.WithValues("keycloak role batch cr", batch.Name)
 	log.Info("Start putting keycloak cr role batch...")
 
-	for _, role := range batch.Spec.Roles {
-		roleName := batch.FormattedRoleName(role.Name)
+	type result struct {
+		role *keycloakApi.KeycloakRealmRole
+		err  error
+	}
+
+	results := make([]result, len(batch.Spec.Roles))
+	workers := make(chan struct{}, batchWorkers)
 
-		var crRole keycloakApi.KeycloakRealmRole
-		err := r.client.Get(ctx, types.NamespacedName{Namespace: batch.Namespace, Name: roleName},
-			&crRole)
+	var wg sync.WaitGroup
 
-		if err != nil && !k8sErrors.IsNotFound(err) {
-			return nil, errors.Wrap(err, "unable to check batch role")
-		} else if err == nil {
-			if r.isOwner(batch, &crRole) {
-				log.Info("role already created")
-				roles = append(roles, crRole)
-				continue
-			}
+	for i := range batch.Spec.Roles {
+		wg.Add(1)
+
+		workers <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			role, err := r.putRole(ctx, batch, realm, &batch.Spec.Roles[i])
+			results[i] = result{role: role, err: err}
+		}(i)
+	}
 
-			return nil, errors.New("one of batch role already exists")
+	wg.Wait()
+
+	roles := make([]keycloakApi.KeycloakRealmRole, 0, len(results))
+	batch.Status.Roles = make([]keycloakApi.BatchRoleStatus, 0, len(results))
+	failed := make([]string, 0)
+
+	for i, res := range results {
+		status := keycloakApi.BatchRoleStatus{Name: batch.Spec.Roles[i].Name}
+
+		if res.err != nil {
+			status.Value, status.Failed = res.err.Error(), true
+			failed = append(failed, fmt.Sprintf("%s: %s", status.Name, res.err.Error()))
+		} else {
+			status.Value = helper.StatusOK
+			roles = append(roles, *res.role)
 		}
 
-		newRole := keycloakApi.KeycloakRealmRole{
-			ObjectMeta: metav1.ObjectMeta{Name: roleName,
-				Namespace: batch.Namespace,
-				OwnerReferences: []metav1.OwnerReference{
-					{Name: realm.Name, Kind: realm.Kind, BlockOwnerDeletion: gocloak.BoolP(true), UID: realm.UID,
-						APIVersion: realm.APIVersion},
-					{Name: batch.Name, Kind: batch.Kind, BlockOwnerDeletion: gocloak.BoolP(true), UID: batch.UID,
-						APIVersion: batch.APIVersion},
-				}},
-			Spec: keycloakApi.KeycloakRealmRoleSpec{
-				Name:        role.Name,
-				Realm:       realm.Name,
-				Composite:   role.Composite,
-				Composites:  role.Composites,
-				Description: role.Description,
-				Attributes:  role.Attributes,
-				IsDefault:   role.IsDefault,
-			}}
-		if err := r.client.Create(ctx, &newRole); err != nil {
-			return nil, errors.Wrap(err, "unable to create child role from batch")
+		batch.Status.Roles = append(batch.Status.Roles, status)
+	}
+
+	log.Info("Done putting keycloak cr role batch...", "failed", len(failed))
+
+	if len(failed) > 0 {
+		return roles, fmt.Errorf("unable to put %d of %d roles: %s", len(failed), len(results),
+			strings.Join(failed, "; "))
+	}
+
+	return roles, nil
+}
+
+func (r *ReconcileKeycloakRealmRoleBatch) putRole(ctx context.Context, batch *keycloakApi.KeycloakRealmRoleBatch,
+	realm *keycloakApi.KeycloakRealm, role *keycloakApi.BatchRole) (*keycloakApi.KeycloakRealmRole, error) {
+	roleName := batch.FormattedRoleName(role.Name)
+
+	var crRole keycloakApi.KeycloakRealmRole
+
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: batch.Namespace, Name: roleName}, &crRole)
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, "unable to check batch role")
+	}
+
+	if err == nil {
+		if r.isOwner(batch, &crRole) {
+			return &crRole, nil
 		}
 
-		roles = append(roles, newRole)
+		return nil, errors.Errorf("role %s already exists and is not owned by the batch", roleName)
 	}
 
-	log.Info("Done putting keycloak cr role batch...")
+	newRole := keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Name: roleName,
+			Namespace: batch.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{Name: batch.Name, Kind: batch.Kind, BlockOwnerDeletion: gocloak.BoolP(true), UID: batch.UID,
+					APIVersion: batch.APIVersion},
+			}},
+		Spec: keycloakApi.KeycloakRealmRoleSpec{
+			Name:           role.Name,
+			Realm:          realm.Name,
+			KeycloakRef:    batch.Spec.KeycloakRef,
+			RealmNamespace: batch.Spec.RealmNamespace,
+			RealmSelector:  batch.Spec.RealmSelector,
+			Composite:      role.Composite,
+			Composites:     role.Composites,
+			Description:    role.Description,
+			Attributes:     role.Attributes,
+			IsDefault:      role.IsDefault,
+		}}
+
+	// the cross namespace owner references are not supported, so the realm owns the role only in the same namespace,
+	// the role follows the batch selector instead of the realm if it is set
+	if realm.Namespace == batch.Namespace && batch.Spec.RealmSelector == nil {
+		newRole.OwnerReferences = append([]metav1.OwnerReference{
+			{Name: realm.Name, Kind: realm.Kind, BlockOwnerDeletion: gocloak.BoolP(true), UID: realm.UID,
+				APIVersion: realm.APIVersion},
+		}, newRole.OwnerReferences...)
+	}
+	if err := r.client.Create(ctx, &newRole); err != nil {
+		return nil, errors.Wrap(err, "unable to create child role from batch")
+	}
 
-	return
+	return &newRole, nil
 }
 
 func (r *ReconcileKeycloakRealmRoleBatch) tryReconcile(ctx context.Context, batch *keycloakApi.KeycloakRealmRoleBatch) error {
@@ -205,10 +274,8 @@ func (r *ReconcileKeycloakRealmRoleBatch) tryReconcile(ctx context.Context, batc
 		return errors.Wrap(err, "unable to get realm owner ref")
 	}
 
-	createdRoles, err := r.putRoles(ctx, batch, realm)
-	if err != nil {
-		return errors.Wrap(err, "unable to put roles batch")
-	}
+	// roles which failed are reported after the rest of the batch is processed.
+	createdRoles, putErr := r.putRoles(ctx, batch, realm)
 
 	if err := r.removeRoles(ctx, batch); err != nil {
 		return errors.Wrap(err, "unable to delete roles")
@@ -220,5 +287,9 @@ func (r *ReconcileKeycloakRealmRoleBatch) tryReconcile(ctx context.Context, batc
 		return errors.Wrap(err, "unable to remove child entity")
 	}
 
+	if putErr != nil {
+		return errors.Wrap(putErr, "unable to put roles batch")
+	}
+
 	return nil
 }
@@ -227,7 +227,7 @@ func TestReconcileKeycloakRealmRoleBatch_ReconcileFailure(t *testing.T) {
 	require.True(t, ok, "wrong logger type")
 
 	require.Error(t, loggerSink.LastError())
-	assert.Contains(t, loggerSink.LastError().Error(), "one of batch role already exists")
+	assert.Contains(t, loggerSink.LastError().Error(), "unable to put 1 of 2 roles")
 
 	var checkBatch keycloakApi.KeycloakRealmRoleBatch
 	err = client.Get(context.Background(), types.NamespacedName{
@@ -236,8 +236,19 @@ func TestReconcileKeycloakRealmRoleBatch_ReconcileFailure(t *testing.T) {
 	}, &checkBatch)
 	require.NoError(t, err)
 
-	if !strings.Contains(checkBatch.Status.Value, "one of batch role already exists") {
+	if !strings.Contains(checkBatch.Status.Value, "role batch1-role2 already exists and is not owned by the batch") {
 		t.Log(checkBatch.Status.Value)
 		t.Fatal("batch status not updated on failure")
 	}
+
+	require.Len(t, checkBatch.Status.Roles, 2)
+	assert.Equal(t, "role1", checkBatch.Status.Roles[0].Name)
+	assert.False(t, checkBatch.Status.Roles[0].Failed)
+	assert.Equal(t, helper.StatusOK, checkBatch.Status.Roles[0].Value)
+	assert.Equal(t, "role2", checkBatch.Status.Roles[1].Name)
+	assert.True(t, checkBatch.Status.Roles[1].Failed)
+
+	var created keycloakApi.KeycloakRealmRole
+	require.NoError(t, client.Get(context.Background(), types.NamespacedName{Namespace: ns, Name: "batch1-role1"}, &created),
+		"roles which don't fail must be created")
 }
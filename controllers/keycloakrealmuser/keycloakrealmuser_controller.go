@@ -4,12 +4,18 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"github.com/sethvargo/go-password/password"
+	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,7 +29,34 @@ import (
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
 )
 
-const finalizer = "keycloak.realmuser.operator.finalizer.name"
+const (
+	finalizer       = "keycloak.realmuser.operator.finalizer.name"
+	passwordLength  = 20
+	passwordDigits  = 4
+	passwordSymbols = 2
+
+	actionsEmailSentEventReason   = "ActionsEmailSent"
+	actionsEmailFailedEventReason = "ActionsEmailFailed"
+
+	credentialsRemovedEventReason      = "CredentialsRemoved"
+	credentialsRemoveFailedEventReason = "CredentialsRemoveFailed"
+	credentialsResetEventReason        = "CredentialsReset"
+	credentialsResetFailedEventReason  = "CredentialsResetFailed"
+	credentialsListedEventReason       = "CredentialsListed"
+	credentialsListFailedEventReason   = "CredentialsListFailed"
+)
+
+// emailActionAnnotations maps the annotations requesting the email to the actions which are sent in the email.
+var emailActionAnnotations = []struct {
+	annotation, action string
+}{
+	{annotation: keycloakApi.SendResetPasswordEmailAnnotation, action: "UPDATE_PASSWORD"},
+	{annotation: keycloakApi.SendVerifyEmailAnnotation, action: "VERIFY_EMAIL"},
+}
+
+// secondFactorCredentialTypes are the credential types which are removed or reset
+// if the remove or reset credentials annotation is true.
+var secondFactorCredentialTypes = []string{"otp", "webauthn", "webauthn-passwordless"}
 
 type Helper interface {
 	SetFailureCount(fc helper.FailureCountable) time.Duration
@@ -34,22 +67,26 @@ type Helper interface {
 }
 
 type Reconcile struct {
-	client client.Client
-	helper Helper
-	log    logr.Logger
+	client   client.Client
+	helper   Helper
+	log      logr.Logger
+	recorder record.EventRecorder
 }
 
-func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+func NewReconcile(client client.Client, log logr.Logger, helper Helper, recorder record.EventRecorder) *Reconcile {
 	return &Reconcile{
-		client: client,
-		helper: helper,
-		log:    log.WithName("keycloak-realm-user"),
+		client:   client,
+		helper:   helper,
+		log:      log.WithName("keycloak-realm-user"),
+		recorder: recorder,
 	}
 }
 
 func (r *Reconcile) SetupWithManager(mgr ctrl.Manager) error {
 	pred := predicate.Funcs{
-		UpdateFunc: isSpecUpdated,
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isSpecUpdated(e) || isOperationRequested(e)
+		},
 		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
 			return false
 		},
@@ -80,9 +117,35 @@ func isSpecUpdated(e event.UpdateEvent) bool {
 		(oo.GetDeletionTimestamp().IsZero() && !no.GetDeletionTimestamp().IsZero())
 }
 
+// isOperationRequested checks if any of the email or credentials annotations was added between object versions.
+func isOperationRequested(e event.UpdateEvent) bool {
+	annotations := []string{
+		keycloakApi.RemoveCredentialsAnnotation,
+		keycloakApi.ResetCredentialsAnnotation,
+		keycloakApi.ListCredentialsAnnotation,
+	}
+	for _, a := range emailActionAnnotations {
+		annotations = append(annotations, a.annotation)
+	}
+
+	for _, a := range annotations {
+		if _, ok := e.ObjectOld.GetAnnotations()[a]; ok {
+			continue
+		}
+
+		if _, ok := e.ObjectNew.GetAnnotations()[a]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmusers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmusers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmusers/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;create;update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=events,verbs=create;patch
 
 // Reconcile is a loop for reconciling KeycloakRealmUser object.
 func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result,
@@ -102,12 +165,13 @@ func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (r
 	}
 
 	if err := r.tryReconcile(ctx, &instance); err != nil {
-		instance.Status.Value = err.Error()
+		helper.SetErrorStatus(&instance, err)
 		result.RequeueAfter = r.helper.SetFailureCount(&instance)
 
 		log.Error(err, "an error has occurred while handling keycloak auth flow", "name", request.Name)
 	} else {
 		helper.SetSuccessStatus(&instance)
+		instance.Status.RequiredUserActions = instance.Spec.RequiredUserActions
 	}
 
 	if err := r.helper.UpdateStatus(&instance); err != nil {
@@ -130,27 +194,83 @@ func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.Keyc
 		return errors.Wrap(err, "unable to create keycloak client")
 	}
 
+	undeclared, err := undeclaredUserProfileAttributes(ctx, instance, kClient, realm.Spec.RealmName)
+	if err != nil {
+		return err
+	}
+
+	instance.Status.UndeclaredAttributes = undeclared
+
+	if len(undeclared) > 0 {
+		return errors.Errorf("attributes %s are not declared in the user profile of the realm %s",
+			strings.Join(undeclared, ", "), realm.Spec.RealmName)
+	}
+
+	password, generated, err := r.getPassword(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	// the client roles are not managed if the list is not set
+	var clientRoles map[string][]string
+	if instance.Spec.ClientRoles != nil {
+		clientRoles = make(map[string][]string)
+	}
+
+	for _, v := range instance.Spec.ClientRoles {
+		clientRoles[v.ClientID] = append(clientRoles[v.ClientID], v.Roles...)
+	}
+
 	if err := kClient.SyncRealmUser(ctx, realm.Spec.RealmName, &adapter.KeycloakUser{
-		Username:            instance.Spec.Username,
-		Groups:              instance.Spec.Groups,
-		Roles:               instance.Spec.Roles,
-		RequiredUserActions: instance.Spec.RequiredUserActions,
-		LastName:            instance.Spec.LastName,
-		FirstName:           instance.Spec.FirstName,
-		EmailVerified:       instance.Spec.EmailVerified,
-		Enabled:             instance.Spec.Enabled,
-		Email:               instance.Spec.Email,
-		Attributes:          instance.Spec.Attributes,
-		Password:            instance.Spec.Password,
+		Username:                 instance.Spec.Username,
+		Groups:                   instance.Spec.Groups,
+		PruneGroups:              instance.Spec.PruneGroups,
+		Roles:                    instance.Spec.Roles,
+		ClientRoles:              clientRoles,
+		RequiredUserActions:      instance.Spec.RequiredUserActions,
+		AddedRequiredUserActions: addedRequiredActions(instance),
+		LastName:                 instance.Spec.LastName,
+		FirstName:                instance.Spec.FirstName,
+		EmailVerified:            instance.Spec.EmailVerified,
+		Enabled:                  instance.Spec.Enabled,
+		Email:                    instance.Spec.Email,
+		Attributes:               instance.Spec.Attributes,
+		Password:                 password,
+		ResetPassword:            generated,
+		PasswordTemporary:        instance.Spec.TemporaryPassword == nil || *instance.Spec.TemporaryPassword,
 	}, instance.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly); err != nil {
 		return errors.Wrap(err, "unable to sync realm user")
 	}
 
+	// The generated password is stored after it is set in keycloak,
+	// so the secret never holds a password which the user can not log in with.
+	if generated {
+		if err := r.storePassword(ctx, instance, password); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range []string{keycloakApi.ResetCredentialsAnnotation, keycloakApi.RemoveCredentialsAnnotation} {
+		if err := r.removeCredentials(ctx, instance, kClient, realm.Spec.RealmName, a); err != nil {
+			return err
+		}
+	}
+
+	if err := r.sendActionsEmail(ctx, instance, kClient, realm.Spec.RealmName); err != nil {
+		return err
+	}
+
 	if instance.Spec.KeepResource {
 		if _, err := r.helper.TryToDelete(ctx, instance,
-			makeTerminator(realm.Spec.RealmName, instance.Spec.Username, kClient, r.log), finalizer); err != nil {
+			makeTerminator(realm.Spec.RealmName, instance.Spec.Username, instance.IsUserRetained(), kClient, r.log),
+			finalizer); err != nil {
 			return errors.Wrap(err, "unable to set finalizers")
 		}
+
+		// the credentials are listed after the updates of the resource which overwrite the status
+		if err := r.listCredentials(ctx, instance, kClient, realm.Spec.RealmName); err != nil {
+			return err
+		}
 	} else {
 		if err := r.client.Delete(ctx, instance); err != nil {
 			return errors.Wrap(err, "unable to delete instance of keycloak realm user")
@@ -159,3 +279,299 @@ func (r *Reconcile) tryReconcile(ctx context.Context, instance *keycloakApi.Keyc
 
 	return nil
 }
+
+// addedRequiredActions returns the required actions of the spec which are not applied to the user yet,
+// so the actions are not required again after the user completes them.
+func addedRequiredActions(instance *keycloakApi.KeycloakRealmUser) []string {
+	applied := make(map[string]struct{}, len(instance.Status.RequiredUserActions))
+	for _, a := range instance.Status.RequiredUserActions {
+		applied[a] = struct{}{}
+	}
+
+	var added []string
+
+	for _, a := range instance.Spec.RequiredUserActions {
+		if _, ok := applied[a]; !ok {
+			added = append(added, a)
+		}
+	}
+
+	return added
+}
+
+// undeclaredUserProfileAttributes returns the attributes of the user which are not declared in the realm
+// user profile, keycloak 24+ does not set them.
+func undeclaredUserProfileAttributes(ctx context.Context, instance *keycloakApi.KeycloakRealmUser,
+	kClient keycloak.Client, realmName string) ([]string, error) {
+	if len(instance.Spec.Attributes) == 0 {
+		return nil, nil
+	}
+
+	version, err := kClient.GetServerVersion(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get keycloak version")
+	}
+
+	major, err := adapter.ServerMajorVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if major < adapter.UserProfileMinKCVersion {
+		return nil, nil
+	}
+
+	cfg, err := kClient.GetUserProfileConfig(ctx, realmName)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get realm user profile")
+	}
+
+	names := make([]string, 0, len(instance.Spec.Attributes))
+	for name := range instance.Spec.Attributes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return cfg.UndeclaredAttributes(names), nil
+}
+
+// sendActionsEmail sends the email with the actions requested by the annotations to the user once.
+// The result is recorded as an event, the annotations are removed regardless of the result.
+func (r *Reconcile) sendActionsEmail(ctx context.Context, instance *keycloakApi.KeycloakRealmUser,
+	kClient keycloak.Client, realmName string) error {
+	annotations := instance.GetAnnotations()
+	actions := make([]string, 0, len(emailActionAnnotations))
+	requested := false
+
+	for _, a := range emailActionAnnotations {
+		value, ok := annotations[a.annotation]
+		if !ok {
+			continue
+		}
+
+		requested = true
+
+		if value == "true" {
+			actions = append(actions, a.action)
+		}
+
+		delete(annotations, a.annotation)
+	}
+
+	if !requested {
+		return nil
+	}
+
+	if len(actions) > 0 {
+		if err := kClient.ExecuteActionsEmail(ctx, realmName, instance.Spec.Username, actions); err != nil {
+			r.log.Error(err, "unable to send actions email", "user", instance.Spec.Username)
+			r.recordEvent(instance, coreV1.EventTypeWarning, actionsEmailFailedEventReason,
+				"Unable to send email with actions %v: %s", actions, err.Error())
+		} else {
+			r.recordEvent(instance, coreV1.EventTypeNormal, actionsEmailSentEventReason,
+				"Email with actions %v is sent", actions)
+		}
+	}
+
+	instance.SetAnnotations(annotations)
+
+	if err := r.client.Update(ctx, instance); err != nil {
+		return errors.Wrap(err, "unable to remove email annotations")
+	}
+
+	return nil
+}
+
+// removeCredentials removes or resets the credentials requested by the remove or reset annotation
+// from the user once. The result is recorded as an event, the annotation is removed regardless of the result.
+func (r *Reconcile) removeCredentials(ctx context.Context, instance *keycloakApi.KeycloakRealmUser,
+	kClient keycloak.Client, realmName, annotation string) error {
+	annotations := instance.GetAnnotations()
+
+	value, ok := annotations[annotation]
+	if !ok {
+		return nil
+	}
+
+	remove, verb, done := kClient.RemoveUserCredentials, "remove", "removed"
+	doneReason, failedReason := credentialsRemovedEventReason, credentialsRemoveFailedEventReason
+
+	if annotation == keycloakApi.ResetCredentialsAnnotation {
+		remove, verb, done = kClient.ResetUserCredentials, "reset", "reset"
+		doneReason, failedReason = credentialsResetEventReason, credentialsResetFailedEventReason
+	}
+
+	if credentialTypes := parseCredentialTypes(value); len(credentialTypes) > 0 {
+		removed, err := remove(ctx, realmName, instance.Spec.Username, credentialTypes)
+		if err != nil {
+			r.log.Error(err, "unable to "+verb+" user credentials", "user", instance.Spec.Username)
+			r.recordEvent(instance, coreV1.EventTypeWarning, failedReason,
+				"Unable to %s credentials %v: %s", verb, credentialTypes, err.Error())
+		} else {
+			r.recordEvent(instance, coreV1.EventTypeNormal, doneReason, "Credentials %v are %s", removed, done)
+		}
+	}
+
+	delete(annotations, annotation)
+	instance.SetAnnotations(annotations)
+
+	if err := r.client.Update(ctx, instance); err != nil {
+		return errors.Wrapf(err, "unable to remove %s credentials annotation", verb)
+	}
+
+	return nil
+}
+
+// parseCredentialTypes returns the credential types of the remove or reset credentials annotation value.
+func parseCredentialTypes(value string) []string {
+	switch value {
+	case "true":
+		return secondFactorCredentialTypes
+	case "false":
+		return nil
+	}
+
+	var credentialTypes []string
+
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			credentialTypes = append(credentialTypes, t)
+		}
+	}
+
+	return credentialTypes
+}
+
+// listCredentials lists the credentials of the user in the status once it is requested by the annotation.
+// The failure is recorded as an event, the annotation is removed regardless of the result.
+func (r *Reconcile) listCredentials(ctx context.Context, instance *keycloakApi.KeycloakRealmUser,
+	kClient keycloak.Client, realmName string) error {
+	annotations := instance.GetAnnotations()
+	if _, ok := annotations[keycloakApi.ListCredentialsAnnotation]; !ok {
+		return nil
+	}
+
+	credentials, err := kClient.ListUserCredentials(ctx, realmName, instance.Spec.Username)
+	listed := err == nil
+
+	if !listed {
+		r.log.Error(err, "unable to list user credentials", "user", instance.Spec.Username)
+		r.recordEvent(instance, coreV1.EventTypeWarning, credentialsListFailedEventReason,
+			"Unable to list credentials: %s", err.Error())
+	} else {
+		r.recordEvent(instance, coreV1.EventTypeNormal, credentialsListedEventReason, "Credentials %v", credentials)
+	}
+
+	delete(annotations, keycloakApi.ListCredentialsAnnotation)
+	instance.SetAnnotations(annotations)
+
+	status := instance.Status
+
+	if err := r.client.Update(ctx, instance); err != nil {
+		return errors.Wrap(err, "unable to remove list credentials annotation")
+	}
+
+	instance.Status = status
+
+	if listed {
+		instance.Status.Credentials = credentials
+	}
+
+	return nil
+}
+
+func (r *Reconcile) recordEvent(instance *keycloakApi.KeycloakRealmUser, eventType, reason, messageFmt string,
+	args ...interface{}) {
+	if r.recorder != nil {
+		r.recorder.Eventf(instance, eventType, reason, messageFmt, args...)
+	}
+}
+
+// getPassword returns the password of the user, generated is true if the password is generated
+// and must be stored to the password secret after it is set in keycloak.
+func (r *Reconcile) getPassword(ctx context.Context, instance *keycloakApi.KeycloakRealmUser) (pass string,
+	generated bool, err error) {
+	ref := instance.Spec.PasswordSecret
+	if ref == nil {
+		if instance.Spec.GeneratePassword {
+			return "", false, errors.New("passwordSecret is required to store the generated password")
+		}
+
+		return instance.Spec.Password, false, nil
+	}
+
+	if instance.Spec.Password != "" {
+		return "", false, errors.New("password and passwordSecret can not be used together")
+	}
+
+	var secret coreV1.Secret
+
+	err = r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: instance.Namespace}, &secret)
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) || !instance.Spec.GeneratePassword {
+			return "", false, errors.Wrapf(err, "unable to get password secret %s", ref.Name)
+		}
+
+		return generatePassword()
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		if instance.Spec.GeneratePassword {
+			return generatePassword()
+		}
+
+		return "", false, errors.Errorf("password secret %s does not contain key %s", ref.Name, ref.Key)
+	}
+
+	return string(value), false, nil
+}
+
+func generatePassword() (string, bool, error) {
+	pass, err := password.Generate(passwordLength, passwordDigits, passwordSymbols, false, true)
+	if err != nil {
+		return "", false, errors.Wrap(err, "unable to generate password")
+	}
+
+	return pass, true, nil
+}
+
+// storePassword writes the generated password to the password secret.
+// The secret is created if it doesn't exist. The secret isn't owned by the user resource,
+// so the password is kept after the resource is deleted.
+func (r *Reconcile) storePassword(ctx context.Context, instance *keycloakApi.KeycloakRealmUser, pass string) error {
+	ref := instance.Spec.PasswordSecret
+
+	var secret coreV1.Secret
+
+	err := r.client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: instance.Namespace}, &secret)
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return errors.Wrapf(err, "unable to get password secret %s", ref.Name)
+		}
+
+		secret = coreV1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: ref.Name, Namespace: instance.Namespace},
+			Data:       map[string][]byte{ref.Key: []byte(pass)},
+		}
+
+		if err := r.client.Create(ctx, &secret); err != nil {
+			return errors.Wrapf(err, "unable to create password secret %s", ref.Name)
+		}
+
+		return nil
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	secret.Data[ref.Key] = []byte(pass)
+
+	if err := r.client.Update(ctx, &secret); err != nil {
+		return errors.Wrapf(err, "unable to update password secret %s", ref.Name)
+	}
+
+	return nil
+}
@@ -3,14 +3,19 @@ package keycloakrealmuser
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -22,7 +27,7 @@ import (
 )
 
 func TestNewReconcile_Init(t *testing.T) {
-	c := NewReconcile(nil, mock.NewLogr(), &helper.Mock{})
+	c := NewReconcile(nil, mock.NewLogr(), &helper.Mock{}, nil)
 	if c.client != nil {
 		t.Fatal("something went wrong")
 	}
@@ -59,6 +64,10 @@ func (e *TestControllerSuite) SetupTest() {
 			Email:    "usr@gmail.com",
 			Username: "user.g1",
 			Realm:    e.realmName,
+			ClientRoles: []keycloakApi.ClientRole{
+				{ClientID: "app", Roles: []string{"viewer"}},
+				{ClientID: "app", Roles: []string{"editor"}},
+			},
 		},
 		Status: keycloakApi.KeycloakRealmUserStatus{
 			Value: helper.StatusOK,
@@ -76,12 +85,14 @@ func (e *TestControllerSuite) SetupTest() {
 		Username:            e.kcRealmUser.Spec.Username,
 		Groups:              e.kcRealmUser.Spec.Groups,
 		Roles:               e.kcRealmUser.Spec.Roles,
+		ClientRoles:         map[string][]string{"app": {"viewer", "editor"}},
 		RequiredUserActions: e.kcRealmUser.Spec.RequiredUserActions,
 		LastName:            e.kcRealmUser.Spec.LastName,
 		FirstName:           e.kcRealmUser.Spec.FirstName,
 		EmailVerified:       e.kcRealmUser.Spec.EmailVerified,
 		Enabled:             e.kcRealmUser.Spec.Enabled,
 		Email:               e.kcRealmUser.Spec.Email,
+		PasswordTemporary:   true,
 	}
 }
 
@@ -120,7 +131,7 @@ func (e *TestControllerSuite) TestReconcileKeep() {
 	e.helper.On("GetOrCreateRealmOwnerRef", e.kcRealmUser, &e.kcRealmUser.ObjectMeta).Return(e.kcRealm, nil)
 	e.helper.On("CreateKeycloakClientForRealm", e.kcRealm).Return(e.kClient, nil)
 	e.helper.On("TryToDelete", e.kcRealmUser,
-		makeTerminator(e.realmName, e.kcRealmUser.Spec.Username, e.kClient, logger), finalizer).
+		makeTerminator(e.realmName, e.kcRealmUser.Spec.Username, false, e.kClient, logger), finalizer).
 		Return(false, nil)
 	e.helper.On("UpdateStatus", e.kcRealmUser).Return(nil)
 
@@ -144,6 +155,443 @@ func (e *TestControllerSuite) TestReconcileKeep() {
 	assert.NoError(e.T(), err)
 }
 
+func (e *TestControllerSuite) TestReconcileRequiredActionsAppliedOnce() {
+	e.kcRealmUser.Spec.KeepResource = true
+	e.kcRealmUser.Spec.RequiredUserActions = []string{"VERIFY_EMAIL", "CONFIGURE_TOTP"}
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+
+	e.helper.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(e.kcRealm, nil)
+	e.helper.On("CreateKeycloakClientForRealm", e.kcRealm).Return(e.kClient, nil)
+	e.helper.On("TryToDelete", testifyMock.Anything, testifyMock.Anything, finalizer).Return(false, nil)
+	e.helper.On("UpdateStatus", testifyMock.Anything).Run(func(args testifyMock.Arguments) {
+		e.Require().NoError(e.k8sClient.Status().Update(context.Background(), args.Get(0).(client.Object)))
+	}).Return(nil)
+
+	e.kClient.On("SyncRealmUser", e.realmName, testifyMock.MatchedBy(func(u *adapter.KeycloakUser) bool {
+		return assert.ObjectsAreEqual([]string{"VERIFY_EMAIL", "CONFIGURE_TOTP"}, u.AddedRequiredUserActions)
+	}), false).Return(nil).Once()
+	e.kClient.On("SyncRealmUser", e.realmName, testifyMock.MatchedBy(func(u *adapter.KeycloakUser) bool {
+		return u.AddedRequiredUserActions == nil &&
+			assert.ObjectsAreEqual([]string{"VERIFY_EMAIL", "CONFIGURE_TOTP"}, u.RequiredUserActions)
+	}), false).Return(nil).Once()
+
+	r := Reconcile{
+		helper: e.helper,
+		log:    mock.NewLogr(),
+		client: e.k8sClient,
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: e.namespace, Name: e.kcRealmUser.Name}}
+
+	for i := 0; i < 2; i++ {
+		_, err := r.Reconcile(context.Background(), req)
+		e.Require().NoError(err)
+	}
+
+	e.kClient.AssertExpectations(e.T())
+
+	var checkUser keycloakApi.KeycloakRealmUser
+	e.Require().NoError(e.k8sClient.Get(context.Background(), req.NamespacedName, &checkUser))
+	e.Equal([]string{"VERIFY_EMAIL", "CONFIGURE_TOTP"}, checkUser.Status.RequiredUserActions)
+}
+
+func (e *TestControllerSuite) TestReconcilePasswordSecret() {
+	utilruntime.Must(coreV1.AddToScheme(e.scheme))
+
+	e.kcRealmUser.Spec.PasswordSecret = &keycloakApi.SecretKeyRef{Name: "user-secret", Key: "password"}
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser,
+		&coreV1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-secret", Namespace: e.namespace},
+			Data:       map[string][]byte{"password": []byte("pass123")},
+		}).Build()
+	e.adapterUser.Password = "pass123"
+
+	e.helper.On("GetOrCreateRealmOwnerRef", e.kcRealmUser, &e.kcRealmUser.ObjectMeta).Return(e.kcRealm, nil)
+	e.helper.On("CreateKeycloakClientForRealm", e.kcRealm).Return(e.kClient, nil)
+	e.kClient.On("SyncRealmUser", e.realmName, e.adapterUser, false).Return(nil)
+	e.helper.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	r := Reconcile{
+		helper: e.helper,
+		log:    mock.NewLogr(),
+		client: e.k8sClient,
+	}
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: e.namespace,
+		Name:      e.kcRealmUser.Name,
+	}})
+	assert.NoError(e.T(), err)
+	e.kClient.AssertExpectations(e.T())
+}
+
+func (e *TestControllerSuite) TestReconcilePasswordSecretNotFound() {
+	e.kcRealmUser.Spec.PasswordSecret = &keycloakApi.SecretKeyRef{Name: "user-secret", Key: "password"}
+
+	r := Reconcile{
+		helper: e.helper,
+		log:    mock.NewLogr(),
+		client: fake.NewClientBuilder().WithScheme(e.scheme).Build(),
+	}
+
+	e.helper.On("GetOrCreateRealmOwnerRef", e.kcRealmUser, &e.kcRealmUser.ObjectMeta).Return(e.kcRealm, nil)
+	e.helper.On("CreateKeycloakClientForRealm", e.kcRealm).Return(e.kClient, nil)
+
+	err := r.tryReconcile(context.Background(), e.kcRealmUser)
+	assert.Error(e.T(), err)
+	assert.Contains(e.T(), err.Error(), "unable to get password secret")
+}
+
+func (e *TestControllerSuite) TestReconcileGeneratePassword() {
+	utilruntime.Must(coreV1.AddToScheme(e.scheme))
+
+	e.kcRealmUser.Spec.PasswordSecret = &keycloakApi.SecretKeyRef{Name: "user-secret", Key: "password"}
+	e.kcRealmUser.Spec.GeneratePassword = true
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+
+	var syncedPassword string
+
+	e.helper.On("GetOrCreateRealmOwnerRef", e.kcRealmUser, &e.kcRealmUser.ObjectMeta).Return(e.kcRealm, nil)
+	e.helper.On("CreateKeycloakClientForRealm", e.kcRealm).Return(e.kClient, nil)
+	e.kClient.On("SyncRealmUser", e.realmName, testifyMock.MatchedBy(func(u *adapter.KeycloakUser) bool {
+		syncedPassword = u.Password
+		return u.Password != "" && u.ResetPassword
+	}), false).Return(nil)
+	e.helper.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	r := Reconcile{
+		helper: e.helper,
+		log:    mock.NewLogr(),
+		client: e.k8sClient,
+	}
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: e.namespace,
+		Name:      e.kcRealmUser.Name,
+	}})
+	assert.NoError(e.T(), err)
+	e.kClient.AssertExpectations(e.T())
+
+	var secret coreV1.Secret
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: "user-secret", Namespace: e.namespace}, &secret))
+	assert.Equal(e.T(), syncedPassword, string(secret.Data["password"]))
+}
+
+func (e *TestControllerSuite) TestReconcileGeneratePasswordSyncFailure() {
+	utilruntime.Must(coreV1.AddToScheme(e.scheme))
+
+	e.kcRealmUser.Spec.PasswordSecret = &keycloakApi.SecretKeyRef{Name: "user-secret", Key: "password"}
+	e.kcRealmUser.Spec.GeneratePassword = true
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+
+	e.helper.On("GetOrCreateRealmOwnerRef", e.kcRealmUser, &e.kcRealmUser.ObjectMeta).Return(e.kcRealm, nil)
+	e.helper.On("CreateKeycloakClientForRealm", e.kcRealm).Return(e.kClient, nil)
+	e.kClient.On("SyncRealmUser", e.realmName, testifyMock.Anything, false).Return(errors.New("fatal"))
+	e.helper.On("SetFailureCount", testifyMock.Anything).Return(time.Second)
+	e.helper.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	r := Reconcile{
+		helper: e.helper,
+		log:    mock.NewLogr(),
+		client: e.k8sClient,
+	}
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: e.namespace,
+		Name:      e.kcRealmUser.Name,
+	}})
+	assert.NoError(e.T(), err)
+
+	var secret coreV1.Secret
+	err = e.k8sClient.Get(context.Background(), types.NamespacedName{Name: "user-secret", Namespace: e.namespace}, &secret)
+	assert.True(e.T(), k8sErrors.IsNotFound(err), "the password is stored before it is set in keycloak")
+}
+
+func (e *TestControllerSuite) TestGetPasswordGenerateMissingKey() {
+	utilruntime.Must(coreV1.AddToScheme(e.scheme))
+
+	e.kcRealmUser.Spec.PasswordSecret = &keycloakApi.SecretKeyRef{Name: "user-secret", Key: "password"}
+	e.kcRealmUser.Spec.GeneratePassword = true
+
+	r := Reconcile{
+		helper: e.helper,
+		log:    mock.NewLogr(),
+		client: fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(&coreV1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-secret", Namespace: e.namespace},
+			Data:       map[string][]byte{"username": []byte("user.g1")},
+		}).Build(),
+	}
+
+	pass, generated, err := r.getPassword(context.Background(), e.kcRealmUser)
+	assert.NoError(e.T(), err)
+	assert.NotEmpty(e.T(), pass)
+	assert.True(e.T(), generated)
+
+	assert.NoError(e.T(), r.storePassword(context.Background(), e.kcRealmUser, pass))
+
+	var secret coreV1.Secret
+	assert.NoError(e.T(), r.client.Get(context.Background(),
+		types.NamespacedName{Name: "user-secret", Namespace: e.namespace}, &secret))
+	assert.Equal(e.T(), pass, string(secret.Data["password"]))
+	assert.Equal(e.T(), "user.g1", string(secret.Data["username"]))
+}
+
+func (e *TestControllerSuite) TestSendActionsEmail() {
+	e.kcRealmUser.Annotations = map[string]string{
+		keycloakApi.SendResetPasswordEmailAnnotation: "true",
+		keycloakApi.SendVerifyEmailAnnotation:        "true",
+		"foo":                                        "bar",
+	}
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+	recorder := record.NewFakeRecorder(1)
+
+	r := Reconcile{
+		helper:   e.helper,
+		log:      mock.NewLogr(),
+		client:   e.k8sClient,
+		recorder: recorder,
+	}
+
+	var instance keycloakApi.KeycloakRealmUser
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.namespace}, &instance))
+
+	e.kClient.On("ExecuteActionsEmail", e.realmName, "user.g1", []string{"UPDATE_PASSWORD", "VERIFY_EMAIL"}).
+		Return(nil)
+
+	assert.NoError(e.T(), r.sendActionsEmail(context.Background(), &instance, e.kClient, e.realmName))
+	e.kClient.AssertExpectations(e.T())
+	assert.Contains(e.T(), <-recorder.Events, actionsEmailSentEventReason)
+
+	var checkUser keycloakApi.KeycloakRealmUser
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.namespace}, &checkUser))
+	assert.Equal(e.T(), map[string]string{"foo": "bar"}, checkUser.Annotations)
+}
+
+func (e *TestControllerSuite) TestSendActionsEmailFailure() {
+	e.kcRealmUser.Annotations = map[string]string{keycloakApi.SendResetPasswordEmailAnnotation: "true"}
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+	recorder := record.NewFakeRecorder(1)
+
+	r := Reconcile{
+		helper:   e.helper,
+		log:      mock.NewLogr(),
+		client:   e.k8sClient,
+		recorder: recorder,
+	}
+
+	var instance keycloakApi.KeycloakRealmUser
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.namespace}, &instance))
+
+	e.kClient.On("ExecuteActionsEmail", e.realmName, "user.g1", []string{"UPDATE_PASSWORD"}).
+		Return(errors.New("smtp is not configured"))
+
+	assert.NoError(e.T(), r.sendActionsEmail(context.Background(), &instance, e.kClient, e.realmName))
+	assert.Contains(e.T(), <-recorder.Events, "smtp is not configured")
+
+	var checkUser keycloakApi.KeycloakRealmUser
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.namespace}, &checkUser))
+	assert.Empty(e.T(), checkUser.Annotations)
+}
+
+func (e *TestControllerSuite) TestRemoveCredentials() {
+	e.kcRealmUser.Annotations = map[string]string{keycloakApi.RemoveCredentialsAnnotation: "otp, webauthn"}
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+	recorder := record.NewFakeRecorder(1)
+
+	r := Reconcile{
+		helper:   e.helper,
+		log:      mock.NewLogr(),
+		client:   e.k8sClient,
+		recorder: recorder,
+	}
+
+	var instance keycloakApi.KeycloakRealmUser
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.namespace}, &instance))
+
+	e.kClient.On("RemoveUserCredentials", e.realmName, "user.g1", []string{"otp", "webauthn"}).
+		Return([]string{"otp (phone)"}, nil)
+
+	assert.NoError(e.T(), r.removeCredentials(context.Background(), &instance, e.kClient, e.realmName,
+		keycloakApi.RemoveCredentialsAnnotation))
+	e.kClient.AssertExpectations(e.T())
+	assert.Contains(e.T(), <-recorder.Events, "Credentials [otp (phone)] are removed")
+
+	var checkUser keycloakApi.KeycloakRealmUser
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.namespace}, &checkUser))
+	assert.Empty(e.T(), checkUser.Annotations)
+}
+
+func (e *TestControllerSuite) TestRemoveCredentialsSecondFactor() {
+	e.kcRealmUser.Annotations = map[string]string{keycloakApi.RemoveCredentialsAnnotation: "true"}
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+	recorder := record.NewFakeRecorder(1)
+
+	r := Reconcile{
+		helper:   e.helper,
+		log:      mock.NewLogr(),
+		client:   e.k8sClient,
+		recorder: recorder,
+	}
+
+	var instance keycloakApi.KeycloakRealmUser
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.namespace}, &instance))
+
+	e.kClient.On("RemoveUserCredentials", e.realmName, "user.g1", secondFactorCredentialTypes).
+		Return(nil, errors.New("user not found"))
+
+	assert.NoError(e.T(), r.removeCredentials(context.Background(), &instance, e.kClient, e.realmName,
+		keycloakApi.RemoveCredentialsAnnotation))
+	assert.Contains(e.T(), <-recorder.Events, credentialsRemoveFailedEventReason)
+}
+
+func (e *TestControllerSuite) TestResetCredentials() {
+	e.kcRealmUser.Annotations = map[string]string{keycloakApi.ResetCredentialsAnnotation: "otp"}
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+	recorder := record.NewFakeRecorder(1)
+
+	r := Reconcile{
+		helper:   e.helper,
+		log:      mock.NewLogr(),
+		client:   e.k8sClient,
+		recorder: recorder,
+	}
+
+	var instance keycloakApi.KeycloakRealmUser
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.namespace}, &instance))
+
+	e.kClient.On("ResetUserCredentials", e.realmName, "user.g1", []string{"otp"}).
+		Return([]string{"otp (phone)"}, nil)
+
+	// the other annotation is not handled
+	assert.NoError(e.T(), r.removeCredentials(context.Background(), &instance, e.kClient, e.realmName,
+		keycloakApi.RemoveCredentialsAnnotation))
+	assert.NoError(e.T(), r.removeCredentials(context.Background(), &instance, e.kClient, e.realmName,
+		keycloakApi.ResetCredentialsAnnotation))
+	e.kClient.AssertExpectations(e.T())
+	e.kClient.AssertNotCalled(e.T(), "RemoveUserCredentials", testifyMock.Anything, testifyMock.Anything,
+		testifyMock.Anything)
+	assert.Contains(e.T(), <-recorder.Events, "Credentials [otp (phone)] are reset")
+
+	var checkUser keycloakApi.KeycloakRealmUser
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.namespace}, &checkUser))
+	assert.Empty(e.T(), checkUser.Annotations)
+}
+
+func (e *TestControllerSuite) TestListCredentials() {
+	e.kcRealmUser.Annotations = map[string]string{keycloakApi.ListCredentialsAnnotation: "true"}
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+	recorder := record.NewFakeRecorder(2)
+
+	r := Reconcile{
+		helper:   e.helper,
+		log:      mock.NewLogr(),
+		client:   e.k8sClient,
+		recorder: recorder,
+	}
+
+	var instance keycloakApi.KeycloakRealmUser
+	assert.NoError(e.T(), e.k8sClient.Get(context.Background(),
+		types.NamespacedName{Name: e.kcRealmUser.Name, Namespace: e.namespace}, &instance))
+
+	e.kClient.On("ListUserCredentials", e.realmName, "user.g1").
+		Return([]string{"password", "otp (phone)"}, nil).Once()
+
+	assert.NoError(e.T(), r.listCredentials(context.Background(), &instance, e.kClient, e.realmName))
+	assert.Equal(e.T(), []string{"password", "otp (phone)"}, instance.Status.Credentials)
+	assert.Empty(e.T(), instance.Annotations)
+	assert.Contains(e.T(), <-recorder.Events, credentialsListedEventReason)
+
+	// the credentials are listed once
+	assert.NoError(e.T(), r.listCredentials(context.Background(), &instance, e.kClient, e.realmName))
+	e.kClient.AssertExpectations(e.T())
+
+	instance.Annotations = map[string]string{keycloakApi.ListCredentialsAnnotation: "true"}
+	e.kClient.On("ListUserCredentials", e.realmName, "user.g1").Return(nil, errors.New("user not found"))
+
+	assert.NoError(e.T(), r.listCredentials(context.Background(), &instance, e.kClient, e.realmName))
+	assert.Equal(e.T(), []string{"password", "otp (phone)"}, instance.Status.Credentials,
+		"the failure must not clear the listed credentials")
+	assert.Contains(e.T(), <-recorder.Events, credentialsListFailedEventReason)
+}
+
+func (e *TestControllerSuite) TestUndeclaredUserProfileAttributes() {
+	e.kcRealmUser.Spec.Attributes = map[string]string{"department": "dev", "team": "a", "floor": "1"}
+
+	e.kClient.On("GetServerVersion").Return("23.0.7", nil).Once()
+
+	undeclared, err := undeclaredUserProfileAttributes(context.Background(), e.kcRealmUser, e.kClient, e.realmName)
+	assert.NoError(e.T(), err)
+	assert.Empty(e.T(), undeclared)
+
+	e.kClient.On("GetServerVersion").Return("24.0.1", nil)
+	e.kClient.On("GetUserProfileConfig", e.realmName).Return(&adapter.UserProfileConfig{
+		Attributes: []adapter.UserProfileAttribute{{Name: "username"}, {Name: "department"}},
+	}, nil).Once()
+
+	undeclared, err = undeclaredUserProfileAttributes(context.Background(), e.kcRealmUser, e.kClient, e.realmName)
+	assert.NoError(e.T(), err)
+	assert.Equal(e.T(), []string{"floor", "team"}, undeclared)
+
+	e.kClient.On("GetUserProfileConfig", e.realmName).Return(&adapter.UserProfileConfig{
+		UnmanagedAttributePolicy: "ADMIN_VIEW",
+		Attributes:               []adapter.UserProfileAttribute{{Name: "department"}},
+	}, nil).Once()
+
+	undeclared, err = undeclaredUserProfileAttributes(context.Background(), e.kcRealmUser, e.kClient, e.realmName)
+	assert.NoError(e.T(), err)
+	assert.Equal(e.T(), []string{"floor", "team"}, undeclared, "the admins can not change the unmanaged attributes")
+
+	e.kClient.On("GetUserProfileConfig", e.realmName).Return(&adapter.UserProfileConfig{
+		UnmanagedAttributePolicy: "ADMIN_EDIT",
+	}, nil).Once()
+
+	undeclared, err = undeclaredUserProfileAttributes(context.Background(), e.kcRealmUser, e.kClient, e.realmName)
+	assert.NoError(e.T(), err)
+	assert.Empty(e.T(), undeclared)
+}
+
+func (e *TestControllerSuite) TestReconcileUndeclaredAttributes() {
+	e.kcRealmUser.Spec.Attributes = map[string]string{"department": "dev", "team": "a"}
+	e.k8sClient = fake.NewClientBuilder().WithScheme(e.scheme).WithRuntimeObjects(e.kcRealmUser).Build()
+
+	e.helper.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(e.kcRealm, nil)
+	e.helper.On("CreateKeycloakClientForRealm", e.kcRealm).Return(e.kClient, nil)
+	e.helper.On("SetFailureCount", testifyMock.Anything).Return(time.Second)
+	e.helper.On("UpdateStatus", testifyMock.MatchedBy(func(u *keycloakApi.KeycloakRealmUser) bool {
+		return assert.ObjectsAreEqual([]string{"team"}, u.Status.UndeclaredAttributes)
+	})).Return(nil)
+
+	e.kClient.On("GetServerVersion").Return("24.0.1", nil)
+	e.kClient.On("GetUserProfileConfig", e.realmName).Return(&adapter.UserProfileConfig{
+		Attributes: []adapter.UserProfileAttribute{{Name: "department"}},
+	}, nil)
+
+	r := Reconcile{
+		helper: e.helper,
+		log:    mock.NewLogr(),
+		client: e.k8sClient,
+	}
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: e.namespace,
+		Name:      e.kcRealmUser.Name,
+	}})
+	assert.NoError(e.T(), err)
+
+	e.helper.AssertExpectations(e.T())
+	e.kClient.AssertNotCalled(e.T(), "SyncRealmUser", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything)
+}
+
 func TestAdapterTestSuite(t *testing.T) {
 	suite.Run(t, new(TestControllerSuite))
 }
@@ -13,9 +13,16 @@ type terminator struct {
 	kClient             keycloak.Client
 	log                 logr.Logger
 	realmName, userName string
+	retain              bool
 }
 
 func (t *terminator) DeleteResource(ctx context.Context) error {
+	if t.retain {
+		t.log.Info("Realm user is retained by the deletion policy", "user", t.userName)
+
+		return nil
+	}
+
 	if err := t.kClient.DeleteRealmUser(ctx, t.realmName, t.userName); err != nil {
 		return errors.Wrap(err, "unable to delete realm user")
 	}
@@ -27,11 +34,13 @@ func (t *terminator) GetLogger() logr.Logger {
 	return t.log
 }
 
-func makeTerminator(realmName, userName string, kClient keycloak.Client, log logr.Logger) *terminator {
+func makeTerminator(realmName, userName string, retain bool, kClient keycloak.Client,
+	log logr.Logger) *terminator {
 	return &terminator{
 		kClient:   kClient,
 		log:       log,
 		realmName: realmName,
 		userName:  userName,
+		retain:    retain,
 	}
 }
@@ -0,0 +1,33 @@
+package keycloakrealmuser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestTerminator(t *testing.T) {
+	kClient := new(adapter.Mock)
+
+	term := makeTerminator("realm", "user", false, kClient, mock.NewLogr())
+
+	kClient.On("DeleteRealmUser", "realm", "user").Return(nil).Once()
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("DeleteRealmUser", "realm", "user").Return(errors.New("fatal")).Once()
+	require.Error(t, term.DeleteResource(context.Background()))
+}
+
+func TestTerminator_Retain(t *testing.T) {
+	kClient := new(adapter.Mock)
+
+	term := makeTerminator("realm", "user", true, kClient, mock.NewLogr())
+
+	require.NoError(t, term.DeleteResource(context.Background()))
+	kClient.AssertNotCalled(t, "DeleteRealmUser", "realm", "user")
+}
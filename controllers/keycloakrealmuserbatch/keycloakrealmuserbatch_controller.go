@@ -0,0 +1,327 @@
+package keycloakrealmuserbatch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+// defaultConcurrency is a max number of the batch users which are synced concurrently if it is not set in the spec.
+const defaultConcurrency = 5
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("keycloak-realm-user-batch"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: isSpecUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRealmUserBatch{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakRealmUserBatch controller: %w", err)
+	}
+
+	return nil
+}
+
+func isSpecUpdated(e event.UpdateEvent) bool {
+	oo, ok := e.ObjectOld.(*keycloakApi.KeycloakRealmUserBatch)
+	if !ok {
+		return false
+	}
+
+	no, ok := e.ObjectNew.(*keycloakApi.KeycloakRealmUserBatch)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oo.Spec, no.Spec)
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmuserbatches,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmuserbatches/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrealmuserbatches/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get
+//+kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get
+
+// Reconcile is a loop for reconciling KeycloakRealmUserBatch object.
+// The users are imported into keycloak and are kept after the batch is deleted.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result,
+	resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakRealmUserBatch")
+
+	var instance keycloakApi.KeycloakRealmUserBatch
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak realm user batch from k8s")
+
+		return
+	}
+
+	if !instance.GetDeletionTimestamp().IsZero() {
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		helper.SetErrorStatus(&instance, err)
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak realm user batch", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = err
+	}
+
+	log.Info("Reconciling KeycloakRealmUserBatch done")
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, batch *keycloakApi.KeycloakRealmUserBatch) error {
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(batch, &batch.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	data, err := r.getSourceData(ctx, batch)
+	if err != nil {
+		return err
+	}
+
+	users, err := parseUsers(data, batch.GetFormat())
+	if err != nil {
+		return err
+	}
+
+	if batch.Spec.Source.SecretKeyRef == nil {
+		for i := range users {
+			if users[i].Password != "" {
+				return errors.Errorf("password of the user %s can be set only from a secret", users[i].Username)
+			}
+		}
+	}
+
+	hash := sourceHash(batch, data)
+	if batch.Status.SourceHash == hash && batch.Status.Value == helper.StatusOK {
+		r.log.Info("Users data is not changed, skipping import", "name", batch.Name)
+
+		return nil
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	if err := r.syncUsers(ctx, batch, kClient, realm.Spec.RealmName, users); err != nil {
+		return err
+	}
+
+	batch.Status.SourceHash = hash
+
+	return nil
+}
+
+// getSourceData reads the users data from the config map or the secret referenced by the batch.
+func (r *Reconcile) getSourceData(ctx context.Context, batch *keycloakApi.KeycloakRealmUserBatch) ([]byte, error) {
+	source := batch.Spec.Source
+
+	switch {
+	case source.ConfigMapKeyRef != nil && source.SecretKeyRef != nil:
+		return nil, errors.New("configMapKeyRef and secretKeyRef can not be used together")
+	case source.ConfigMapKeyRef != nil:
+		var cm coreV1.ConfigMap
+		if err := r.client.Get(ctx, types.NamespacedName{Name: source.ConfigMapKeyRef.Name, Namespace: batch.Namespace},
+			&cm); err != nil {
+			return nil, errors.Wrapf(err, "unable to get users config map %s", source.ConfigMapKeyRef.Name)
+		}
+
+		data, ok := cm.Data[source.ConfigMapKeyRef.Key]
+		if !ok {
+			return nil, errors.Errorf("users config map %s does not contain key %s",
+				source.ConfigMapKeyRef.Name, source.ConfigMapKeyRef.Key)
+		}
+
+		return []byte(data), nil
+	case source.SecretKeyRef != nil:
+		var secret coreV1.Secret
+		if err := r.client.Get(ctx, types.NamespacedName{Name: source.SecretKeyRef.Name, Namespace: batch.Namespace},
+			&secret); err != nil {
+			return nil, errors.Wrapf(err, "unable to get users secret %s", source.SecretKeyRef.Name)
+		}
+
+		data, ok := secret.Data[source.SecretKeyRef.Key]
+		if !ok {
+			return nil, errors.Errorf("users secret %s does not contain key %s",
+				source.SecretKeyRef.Name, source.SecretKeyRef.Key)
+		}
+
+		return data, nil
+	default:
+		return nil, errors.New("configMapKeyRef or secretKeyRef must be set")
+	}
+}
+
+// sourceHash is a hash of the users data and the settings which affect the import.
+func sourceHash(batch *keycloakApi.KeycloakRealmUserBatch, data []byte) string {
+	h := sha256.New()
+	h.Write([]byte(batch.Spec.Realm + "\n" + batch.GetFormat() + "\n" + batch.GetReconciliationStrategy() + "\n"))
+	h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// syncUsers syncs the batch users using a bounded number of workers.
+// Failure of a user doesn't stop processing of the other users, the result counts are set to the batch status.
+func (r *Reconcile) syncUsers(ctx context.Context, batch *keycloakApi.KeycloakRealmUserBatch, kClient keycloak.Client,
+	realmName string, users []batchUser) error {
+	log := r.log.WithValues("keycloak user batch cr", batch.Name)
+	log.Info("Start syncing keycloak user batch...", "users", len(users))
+
+	concurrency := batch.Spec.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	addOnly := batch.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly
+	created := make([]bool, len(users))
+	errs := make([]error, len(users))
+	workers := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i := range users {
+		wg.Add(1)
+
+		workers <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			created[i], errs[i] = syncUser(ctx, kClient, realmName, &users[i], addOnly)
+		}(i)
+	}
+
+	wg.Wait()
+
+	batch.Status.Created, batch.Status.Updated, batch.Status.Failed = 0, 0, 0
+	batch.Status.FailedUsers = nil
+
+	for i := range users {
+		switch {
+		case errs[i] != nil:
+			batch.Status.Failed++
+			batch.Status.FailedUsers = append(batch.Status.FailedUsers,
+				keycloakApi.BatchUserFailure{Username: users[i].Username, Error: errs[i].Error()})
+		case created[i]:
+			batch.Status.Created++
+		default:
+			batch.Status.Updated++
+		}
+	}
+
+	log.Info("Done syncing keycloak user batch", "created", batch.Status.Created,
+		"updated", batch.Status.Updated, "failed", batch.Status.Failed)
+
+	if batch.Status.Failed > 0 {
+		failed := make([]string, 0, len(batch.Status.FailedUsers))
+		for _, f := range batch.Status.FailedUsers {
+			failed = append(failed, f.Username)
+		}
+
+		sort.Strings(failed)
+
+		return errors.Errorf("unable to sync %d of %d users: %s", batch.Status.Failed, len(users),
+			strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// syncUser creates or updates the user in keycloak, it returns true if the user is created.
+func syncUser(ctx context.Context, kClient keycloak.Client, realmName string, user *batchUser,
+	addOnly bool) (bool, error) {
+	exists, err := kClient.ExistRealmUser(realmName, &dto.User{Username: user.Username})
+	if err != nil {
+		return false, errors.Wrap(err, "unable to check user")
+	}
+
+	if err := kClient.SyncRealmUser(ctx, realmName, &adapter.KeycloakUser{
+		Username:            user.Username,
+		Groups:              user.Groups,
+		Roles:               user.Roles,
+		RequiredUserActions: user.RequiredUserActions,
+		LastName:            user.LastName,
+		FirstName:           user.FirstName,
+		EmailVerified:       user.EmailVerified,
+		Enabled:             user.Enabled == nil || *user.Enabled,
+		Email:               user.Email,
+		Attributes:          user.Attributes,
+		Password:            user.Password,
+		PasswordTemporary:   user.TemporaryPassword == nil || *user.TemporaryPassword,
+	}, addOnly); err != nil {
+		return false, errors.Wrap(err, "unable to sync user")
+	}
+
+	return !exists, nil
+}
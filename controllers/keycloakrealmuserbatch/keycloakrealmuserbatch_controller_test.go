@@ -0,0 +1,246 @@
+package keycloakrealmuserbatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+const testUsersCSV = `username,email,enabled,groups,roles,attributes.department
+user1,user1@example.com,,/team/dev;admins,developer,
+user2,user2@example.com,false,,,sales
+`
+
+func getTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+	utilruntime.Must(coreV1.AddToScheme(scheme))
+
+	return scheme
+}
+
+func getTestBatch() *keycloakApi.KeycloakRealmUserBatch {
+	return &keycloakApi.KeycloakRealmUserBatch{
+		ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmUserBatchSpec{
+			Realm:  "test",
+			Format: keycloakApi.UserBatchFormatCSV,
+			Source: keycloakApi.UserBatchSource{
+				ConfigMapKeyRef: &keycloakApi.ConfigMapKeyRef{Name: "users", Key: "users.csv"},
+			},
+			Concurrency: 2,
+		},
+	}
+}
+
+func TestReconcile_Reconcile(t *testing.T) {
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "realm.test"}}
+	batch := getTestBatch()
+	cm := coreV1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: "ns"},
+		Data: map[string]string{"users.csv": testUsersCSV}}
+
+	client := fake.NewClientBuilder().WithScheme(getTestScheme()).WithRuntimeObjects(batch, &realm, &cm).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("ExistRealmUser", "realm.test", &dto.User{Username: "user1"}).Return(false, nil)
+	kClient.On("ExistRealmUser", "realm.test", &dto.User{Username: "user2"}).Return(true, nil)
+	kClient.On("SyncRealmUser", "realm.test", &adapter.KeycloakUser{
+		Username:          "user1",
+		Email:             "user1@example.com",
+		Enabled:           true,
+		Groups:            []string{"/team/dev", "admins"},
+		Roles:             []string{"developer"},
+		PasswordTemporary: true,
+	}, false).Return(nil)
+	kClient.On("SyncRealmUser", "realm.test", &adapter.KeycloakUser{
+		Username:          "user2",
+		Email:             "user2@example.com",
+		Attributes:        map[string]string{"department": "sales"},
+		PasswordTemporary: true,
+	}, false).Return(nil)
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := Reconcile{
+		client:                  client,
+		log:                     mock.NewLogr(),
+		helper:                  &h,
+		successReconcileTimeout: time.Hour,
+	}
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: batch.Name, Namespace: batch.Namespace},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakRealmUserBatch)
+	require.True(t, ok)
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	require.Equal(t, 1, updated.Status.Created)
+	require.Equal(t, 1, updated.Status.Updated)
+	require.Equal(t, 0, updated.Status.Failed)
+	require.NotEmpty(t, updated.Status.SourceHash)
+	kClient.AssertExpectations(t)
+
+	// the unchanged data is not imported again.
+	require.NoError(t, rec.tryReconcile(context.Background(), updated))
+	kClient.AssertNumberOfCalls(t, "SyncRealmUser", 2)
+}
+
+func TestReconcile_Reconcile_PartialFailure(t *testing.T) {
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRealmSpec{RealmName: "realm.test"}}
+	batch := getTestBatch()
+	batch.Spec.Format = keycloakApi.UserBatchFormatJSON
+	batch.Spec.ReconciliationStrategy = keycloakApi.ReconciliationStrategyAddOnly
+	batch.Spec.Source = keycloakApi.UserBatchSource{
+		SecretKeyRef: &keycloakApi.SecretKeyRef{Name: "users", Key: "users.json"},
+	}
+	secret := coreV1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: "ns"},
+		Data: map[string][]byte{"users.json": []byte(
+			`[{"username":"user1","password":"pass","temporaryPassword":false},{"username":"user2"}]`)}}
+
+	client := fake.NewClientBuilder().WithScheme(getTestScheme()).WithRuntimeObjects(batch, &realm, &secret).Build()
+
+	kClient := new(adapter.Mock)
+	kClient.On("ExistRealmUser", "realm.test", testifyMock.Anything).Return(false, nil)
+	kClient.On("SyncRealmUser", "realm.test", &adapter.KeycloakUser{
+		Username: "user1",
+		Enabled:  true,
+		Password: "pass",
+	}, true).Return(nil)
+	kClient.On("SyncRealmUser", "realm.test", &adapter.KeycloakUser{
+		Username:          "user2",
+		Enabled:           true,
+		PasswordTemporary: true,
+	}, true).Return(errors.New("fatal"))
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := Reconcile{
+		client: client,
+		log:    mock.NewLogr(),
+		helper: &h,
+	}
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: batch.Name, Namespace: batch.Namespace},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, res.RequeueAfter)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakRealmUserBatch)
+	require.True(t, ok)
+	require.Contains(t, updated.Status.Value, "unable to sync 1 of 2 users: user2")
+	require.Equal(t, 1, updated.Status.Created)
+	require.Equal(t, 1, updated.Status.Failed)
+	require.Equal(t, []keycloakApi.BatchUserFailure{{Username: "user2", Error: "unable to sync user: fatal"}},
+		updated.Status.FailedUsers)
+	require.Empty(t, updated.Status.SourceHash)
+}
+
+func TestReconcile_tryReconcile_PasswordFromConfigMap(t *testing.T) {
+	realm := keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"}}
+	batch := getTestBatch()
+	cm := coreV1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "users", Namespace: "ns"},
+		Data: map[string]string{"users.csv": "username,password\nuser1,pass\n"}}
+
+	client := fake.NewClientBuilder().WithScheme(getTestScheme()).WithRuntimeObjects(&cm).Build()
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+
+	rec := Reconcile{
+		client: client,
+		log:    mock.NewLogr(),
+		helper: &h,
+	}
+
+	err := rec.tryReconcile(context.Background(), batch)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "password of the user user1 can be set only from a secret")
+}
+
+func TestParseUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		format  string
+		want    []batchUser
+		wantErr string
+	}{
+		{
+			name:   "json",
+			data:   `[{"username":"user1","roles":["admin"],"attributes":{"a":"b"}}]`,
+			format: keycloakApi.UserBatchFormatJSON,
+			want:   []batchUser{{Username: "user1", Roles: []string{"admin"}, Attributes: map[string]string{"a": "b"}}},
+		},
+		{
+			name:   "csv",
+			data:   "username, emailVerified, requiredUserActions\nuser1, true, VERIFY_EMAIL;UPDATE_PASSWORD\n",
+			format: keycloakApi.UserBatchFormatCSV,
+			want: []batchUser{{Username: "user1", EmailVerified: true,
+				RequiredUserActions: []string{"VERIFY_EMAIL", "UPDATE_PASSWORD"}}},
+		},
+		{
+			name:    "unknown csv column",
+			data:    "username,phone\nuser1,123\n",
+			format:  keycloakApi.UserBatchFormatCSV,
+			wantErr: "unknown column phone",
+		},
+		{
+			name:    "duplicate username",
+			data:    `[{"username":"user1"},{"username":"user1"}]`,
+			format:  keycloakApi.UserBatchFormatJSON,
+			wantErr: "user user1 is declared more than once",
+		},
+		{
+			name:    "empty username",
+			data:    "username,email\n,user@example.com\n",
+			format:  keycloakApi.UserBatchFormatCSV,
+			wantErr: "username of the user #1 is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUsers([]byte(tt.data), tt.format)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package keycloakrealmuserbatch
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+)
+
+const (
+	csvListSeparator   = ";"
+	csvAttributePrefix = "attributes."
+)
+
+// batchUser is a user of the batch source.
+type batchUser struct {
+	Username            string            `json:"username"`
+	Email               string            `json:"email,omitempty"`
+	FirstName           string            `json:"firstName,omitempty"`
+	LastName            string            `json:"lastName,omitempty"`
+	Enabled             *bool             `json:"enabled,omitempty"`
+	EmailVerified       bool              `json:"emailVerified,omitempty"`
+	Groups              []string          `json:"groups,omitempty"`
+	Roles               []string          `json:"roles,omitempty"`
+	RequiredUserActions []string          `json:"requiredUserActions,omitempty"`
+	Attributes          map[string]string `json:"attributes,omitempty"`
+	Password            string            `json:"password,omitempty"`
+	TemporaryPassword   *bool             `json:"temporaryPassword,omitempty"`
+}
+
+// parseUsers parses the users data in the given format and validates the usernames.
+func parseUsers(data []byte, format string) ([]batchUser, error) {
+	var (
+		users []batchUser
+		err   error
+	)
+
+	switch format {
+	case keycloakApi.UserBatchFormatJSON:
+		err = json.Unmarshal(data, &users)
+	case keycloakApi.UserBatchFormatCSV:
+		users, err = parseCSVUsers(data)
+	default:
+		return nil, errors.Errorf("unsupported users format %s", format)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse %s users", format)
+	}
+
+	usernames := make(map[string]struct{}, len(users))
+
+	for i := range users {
+		if users[i].Username == "" {
+			return nil, errors.Errorf("username of the user #%d is empty", i+1)
+		}
+
+		if _, ok := usernames[users[i].Username]; ok {
+			return nil, errors.Errorf("user %s is declared more than once", users[i].Username)
+		}
+
+		usernames[users[i].Username] = struct{}{}
+	}
+
+	return users, nil
+}
+
+func parseCSVUsers(data []byte) ([]batchUser, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "unable to read header")
+	}
+
+	users := make([]batchUser, 0)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read record")
+		}
+
+		var user batchUser
+
+		for i, column := range header {
+			if err := setCSVColumn(&user, strings.TrimSpace(column), strings.TrimSpace(record[i])); err != nil {
+				return nil, errors.Wrapf(err, "invalid record on line %d", len(users)+2)
+			}
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func setCSVColumn(user *batchUser, column, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	var err error
+
+	switch column {
+	case "username":
+		user.Username = value
+	case "email":
+		user.Email = value
+	case "firstName":
+		user.FirstName = value
+	case "lastName":
+		user.LastName = value
+	case "enabled":
+		var enabled bool
+		enabled, err = strconv.ParseBool(value)
+		user.Enabled = &enabled
+	case "emailVerified":
+		user.EmailVerified, err = strconv.ParseBool(value)
+	case "groups":
+		user.Groups = strings.Split(value, csvListSeparator)
+	case "roles":
+		user.Roles = strings.Split(value, csvListSeparator)
+	case "requiredUserActions":
+		user.RequiredUserActions = strings.Split(value, csvListSeparator)
+	case "password":
+		user.Password = value
+	case "temporaryPassword":
+		var temporary bool
+		temporary, err = strconv.ParseBool(value)
+		user.TemporaryPassword = &temporary
+	default:
+		if !strings.HasPrefix(column, csvAttributePrefix) {
+			return errors.Errorf("unknown column %s", column)
+		}
+
+		if user.Attributes == nil {
+			user.Attributes = make(map[string]string)
+		}
+
+		user.Attributes[strings.TrimPrefix(column, csvAttributePrefix)] = value
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "invalid value of column %s", column)
+	}
+
+	return nil
+}
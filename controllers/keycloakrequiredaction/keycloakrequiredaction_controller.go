@@ -0,0 +1,190 @@
+package keycloakrequiredaction
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const finalizerName = "keycloak.requiredaction.operator.finalizer.name"
+
+type Helper interface {
+	SetFailureCount(fc helper.FailureCountable) time.Duration
+	UpdateStatus(obj client.Object) error
+	GetOrCreateRealmOwnerRef(object helper.RealmChild, objectMeta *v1.ObjectMeta) (*keycloakApi.KeycloakRealm, error)
+	CreateKeycloakClientForRealm(ctx context.Context, realm *keycloakApi.KeycloakRealm) (keycloak.Client, error)
+	TryToDelete(ctx context.Context, obj helper.Deletable, terminator helper.Terminator, finalizer string) (isDeleted bool, resultErr error)
+}
+
+type Reconcile struct {
+	client                  client.Client
+	log                     logr.Logger
+	helper                  Helper
+	successReconcileTimeout time.Duration
+}
+
+func NewReconcile(client client.Client, log logr.Logger, helper Helper) *Reconcile {
+	return &Reconcile{
+		client: client,
+		helper: helper,
+		log:    log.WithName("keycloak-required-action"),
+	}
+}
+
+func (r *Reconcile) SetupWithManager(mgr ctrl.Manager, successReconcileTimeout time.Duration) error {
+	r.successReconcileTimeout = successReconcileTimeout
+
+	pred := predicate.Funcs{
+		UpdateFunc: helper.IsFailuresUpdated,
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&keycloakApi.KeycloakRequiredAction{}, builder.WithPredicates(pred)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to setup KeycloakRequiredAction controller: %w", err)
+	}
+
+	return nil
+}
+
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrequiredactions,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrequiredactions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=v1.edp.epam.com,namespace=placeholder,resources=keycloakrequiredactions/finalizers,verbs=update
+
+// Reconcile is a loop for reconciling KeycloakRequiredAction object.
+func (r *Reconcile) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, resultErr error) {
+	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	log.Info("Reconciling KeycloakRequiredAction")
+
+	var instance keycloakApi.KeycloakRequiredAction
+	if err := r.client.Get(ctx, request.NamespacedName, &instance); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Info("instance not found")
+
+			return
+		}
+
+		resultErr = errors.Wrap(err, "unable to get keycloak required action from k8s")
+
+		return
+	}
+
+	if err := r.tryReconcile(ctx, &instance); err != nil {
+		helper.SetErrorStatus(&instance, err)
+		result.RequeueAfter = r.helper.SetFailureCount(&instance)
+
+		log.Error(err, "an error has occurred while handling keycloak required action", "name", request.Name)
+	} else {
+		helper.SetSuccessStatus(&instance)
+		result.RequeueAfter = r.successReconcileTimeout
+	}
+
+	if err := r.helper.UpdateStatus(&instance); err != nil {
+		resultErr = errors.Wrap(err, "unable to update status")
+	}
+
+	log.Info("Reconciling KeycloakRequiredAction done")
+
+	return
+}
+
+func (r *Reconcile) tryReconcile(ctx context.Context, requiredAction *keycloakApi.KeycloakRequiredAction) error {
+	realm, err := r.helper.GetOrCreateRealmOwnerRef(requiredAction, &requiredAction.ObjectMeta)
+	if err != nil {
+		return errors.Wrap(err, "unable to get realm owner ref")
+	}
+
+	kClient, err := r.helper.CreateKeycloakClientForRealm(ctx, realm)
+	if err != nil {
+		return errors.Wrap(err, "unable to create keycloak client")
+	}
+
+	realmName := realm.Spec.RealmName
+
+	deleted, err := r.helper.TryToDelete(ctx, requiredAction,
+		makeTerminator(realmName, requiredAction.Spec.Alias, kClient, r.log.WithName("required-action-term")),
+		finalizerName)
+	if err != nil {
+		return errors.Wrap(err, "unable to delete required action")
+	}
+
+	if deleted {
+		return nil
+	}
+
+	return putRequiredAction(ctx, kClient, realmName, &requiredAction.Spec)
+}
+
+// putRequiredAction registers the required action if it is not registered and updates it if it differs from the spec.
+func putRequiredAction(ctx context.Context, kClient keycloak.Client, realmName string,
+	spec *keycloakApi.KeycloakRequiredActionSpec) error {
+	enabled := spec.Enabled == nil || *spec.Enabled
+	if spec.DefaultAction && !enabled {
+		return errors.Errorf("required action %s can not be default while it is disabled", spec.Alias)
+	}
+
+	current, err := kClient.GetRequiredAction(ctx, realmName, spec.Alias)
+	if err != nil {
+		if !adapter.IsErrNotFound(err) {
+			return errors.Wrap(err, "unable to get required action")
+		}
+
+		name := spec.Name
+		if name == "" {
+			name = spec.Alias
+		}
+
+		if err := kClient.RegisterRequiredAction(ctx, realmName, spec.Alias, name); err != nil {
+			return errors.Wrapf(err, "unable to register required action %s, check that its provider is installed",
+				spec.Alias)
+		}
+
+		current, err = kClient.GetRequiredAction(ctx, realmName, spec.Alias)
+		if err != nil {
+			return errors.Wrap(err, "unable to get registered required action")
+		}
+	}
+
+	action := *current
+	action.Enabled = enabled
+	action.DefaultAction = spec.DefaultAction
+
+	if spec.Name != "" {
+		action.Name = spec.Name
+	}
+
+	if spec.Priority != nil {
+		action.Priority = *spec.Priority
+	}
+
+	if spec.Config != nil {
+		action.Config = spec.Config
+	}
+
+	if reflect.DeepEqual(&action, current) {
+		return nil
+	}
+
+	if err := kClient.UpdateRequiredAction(ctx, realmName, &action); err != nil {
+		return errors.Wrap(err, "unable to update required action")
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package keycloakrequiredaction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/controllers/helper"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func getTestRequiredAction() *keycloakApi.KeycloakRequiredAction {
+	priority := 5
+
+	return &keycloakApi.KeycloakRequiredAction{
+		ObjectMeta: metav1.ObjectMeta{Name: "configure-totp", Namespace: "ns"},
+		Spec: keycloakApi.KeycloakRequiredActionSpec{
+			Realm:         "realm",
+			Alias:         "CONFIGURE_TOTP",
+			DefaultAction: true,
+			Priority:      &priority,
+		},
+	}
+}
+
+func reconcileRequiredAction(t *testing.T, action *keycloakApi.KeycloakRequiredAction,
+	kClient *adapter.Mock) (*keycloakApi.KeycloakRequiredAction, reconcile.Result) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(scheme))
+
+	realm := keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "realm", Namespace: "ns"},
+		Spec:       keycloakApi.KeycloakRealmSpec{RealmName: "realm1"},
+	}
+
+	h := helper.Mock{}
+	h.On("GetOrCreateRealmOwnerRef", testifyMock.Anything, testifyMock.Anything).Return(&realm, nil)
+	h.On("CreateKeycloakClientForRealm", &realm).Return(kClient, nil)
+	h.On("TryToDelete", testifyMock.Anything, testifyMock.Anything, finalizerName).Return(false, nil)
+	h.On("SetFailureCount", testifyMock.Anything).Return(time.Minute)
+	h.On("UpdateStatus", testifyMock.Anything).Return(nil)
+
+	rec := NewReconcile(fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(action).Build(),
+		mock.NewLogr(), &h)
+	rec.successReconcileTimeout = time.Hour
+
+	res, err := rec.Reconcile(context.Background(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: action.Name, Namespace: action.Namespace},
+	})
+	require.NoError(t, err)
+
+	updated, ok := h.Calls[len(h.Calls)-1].Arguments.Get(0).(*keycloakApi.KeycloakRequiredAction)
+	require.True(t, ok)
+
+	return updated, res
+}
+
+func TestReconcile_Reconcile_Update(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("GetRequiredAction", "realm1", "CONFIGURE_TOTP").Return(&adapter.RequiredAction{
+		Alias:      "CONFIGURE_TOTP",
+		Name:       "Configure OTP",
+		ProviderID: "CONFIGURE_TOTP",
+		Enabled:    true,
+		Priority:   10,
+	}, nil)
+	kClient.On("UpdateRequiredAction", "realm1", &adapter.RequiredAction{
+		Alias:         "CONFIGURE_TOTP",
+		Name:          "Configure OTP",
+		ProviderID:    "CONFIGURE_TOTP",
+		Enabled:       true,
+		DefaultAction: true,
+		Priority:      5,
+	}).Return(nil)
+
+	updated, res := reconcileRequiredAction(t, getTestRequiredAction(), kClient)
+
+	require.Equal(t, time.Hour, res.RequeueAfter)
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	kClient.AssertExpectations(t)
+}
+
+func TestReconcile_Reconcile_NotChanged(t *testing.T) {
+	kClient := new(adapter.Mock)
+	kClient.On("GetRequiredAction", "realm1", "CONFIGURE_TOTP").Return(&adapter.RequiredAction{
+		Alias:         "CONFIGURE_TOTP",
+		Enabled:       true,
+		DefaultAction: true,
+		Priority:      5,
+	}, nil)
+
+	updated, _ := reconcileRequiredAction(t, getTestRequiredAction(), kClient)
+
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	kClient.AssertNotCalled(t, "UpdateRequiredAction", testifyMock.Anything, testifyMock.Anything)
+}
+
+func TestReconcile_Reconcile_Register(t *testing.T) {
+	action := getTestRequiredAction()
+	action.Spec.Alias = "custom-action"
+	action.Spec.Priority = nil
+	action.Spec.Config = map[string]string{"max_auth_age": "300"}
+
+	kClient := new(adapter.Mock)
+	kClient.On("GetRequiredAction", "realm1", "custom-action").
+		Return(nil, adapter.NotFoundError("required action not found")).Once()
+	kClient.On("RegisterRequiredAction", "realm1", "custom-action", "custom-action").Return(nil)
+	kClient.On("GetRequiredAction", "realm1", "custom-action").Return(&adapter.RequiredAction{
+		Alias:      "custom-action",
+		Name:       "custom-action",
+		ProviderID: "custom-action",
+		Priority:   100,
+	}, nil).Once()
+	kClient.On("UpdateRequiredAction", "realm1", &adapter.RequiredAction{
+		Alias:         "custom-action",
+		Name:          "custom-action",
+		ProviderID:    "custom-action",
+		Enabled:       true,
+		DefaultAction: true,
+		Priority:      100,
+		Config:        map[string]string{"max_auth_age": "300"},
+	}).Return(nil)
+
+	updated, _ := reconcileRequiredAction(t, action, kClient)
+
+	require.Equal(t, helper.StatusOK, updated.Status.Value)
+	kClient.AssertExpectations(t)
+}
+
+func TestReconcile_Reconcile_Errors(t *testing.T) {
+	disabled := false
+
+	tests := []struct {
+		name    string
+		action  func() *keycloakApi.KeycloakRequiredAction
+		prepare func(kClient *adapter.Mock)
+		wantErr string
+	}{
+		{
+			name: "default action is disabled",
+			action: func() *keycloakApi.KeycloakRequiredAction {
+				action := getTestRequiredAction()
+				action.Spec.Enabled = &disabled
+
+				return action
+			},
+			prepare: func(kClient *adapter.Mock) {},
+			wantErr: "required action CONFIGURE_TOTP can not be default while it is disabled",
+		},
+		{
+			name:   "provider is not installed",
+			action: getTestRequiredAction,
+			prepare: func(kClient *adapter.Mock) {
+				kClient.On("GetRequiredAction", "realm1", "CONFIGURE_TOTP").
+					Return(nil, adapter.NotFoundError("required action not found"))
+				kClient.On("RegisterRequiredAction", "realm1", "CONFIGURE_TOTP", "CONFIGURE_TOTP").
+					Return(errors.New("fatal"))
+			},
+			wantErr: "unable to register required action CONFIGURE_TOTP, check that its provider is installed: fatal",
+		},
+		{
+			name:   "unable to update required action",
+			action: getTestRequiredAction,
+			prepare: func(kClient *adapter.Mock) {
+				kClient.On("GetRequiredAction", "realm1", "CONFIGURE_TOTP").
+					Return(&adapter.RequiredAction{Alias: "CONFIGURE_TOTP"}, nil)
+				kClient.On("UpdateRequiredAction", "realm1", testifyMock.Anything).Return(errors.New("fatal"))
+			},
+			wantErr: "unable to update required action: fatal",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			kClient := new(adapter.Mock)
+			tt.prepare(kClient)
+
+			updated, res := reconcileRequiredAction(t, tt.action(), kClient)
+
+			require.Equal(t, time.Minute, res.RequeueAfter)
+			require.Equal(t, tt.wantErr, updated.Status.Value)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package keycloakrequiredaction
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// terminator disables the required action, the action is not unregistered
+// as the built-in actions are registered by keycloak.
+type terminator struct {
+	realmName string
+	alias     string
+	kClient   keycloak.Client
+	log       logr.Logger
+}
+
+func makeTerminator(realmName, alias string, kClient keycloak.Client, log logr.Logger) *terminator {
+	return &terminator{
+		realmName: realmName,
+		alias:     alias,
+		kClient:   kClient,
+		log:       log,
+	}
+}
+
+func (t *terminator) DeleteResource(ctx context.Context) error {
+	log := t.log.WithValues("keycloak required action", t.alias)
+	log.Info("Start disabling keycloak required action...")
+
+	action, err := t.kClient.GetRequiredAction(ctx, t.realmName, t.alias)
+	if err != nil {
+		if adapter.IsErrNotFound(err) {
+			log.Info("Required action is not registered in keycloak")
+
+			return nil
+		}
+
+		return errors.Wrap(err, "unable to get required action")
+	}
+
+	action.Enabled = false
+	action.DefaultAction = false
+
+	if err := t.kClient.UpdateRequiredAction(ctx, t.realmName, action); err != nil {
+		return errors.Wrap(err, "unable to disable required action")
+	}
+
+	log.Info("Required action disabling done")
+
+	return nil
+}
+
+func (t *terminator) GetLogger() logr.Logger {
+	return t.log
+}
@@ -0,0 +1,32 @@
+package keycloakrequiredaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestTerminator_DeleteResource(t *testing.T) {
+	kClient := new(adapter.Mock)
+	term := makeTerminator("realm1", "CONFIGURE_TOTP", kClient, mock.NewLogr())
+
+	kClient.On("GetRequiredAction", "realm1", "CONFIGURE_TOTP").
+		Return(&adapter.RequiredAction{Alias: "CONFIGURE_TOTP", Enabled: true, DefaultAction: true}, nil).Once()
+	kClient.On("UpdateRequiredAction", "realm1", &adapter.RequiredAction{Alias: "CONFIGURE_TOTP"}).
+		Return(nil).Once()
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("GetRequiredAction", "realm1", "CONFIGURE_TOTP").
+		Return(nil, adapter.NotFoundError("required action not found")).Once()
+	require.NoError(t, term.DeleteResource(context.Background()))
+
+	kClient.On("GetRequiredAction", "realm1", "CONFIGURE_TOTP").Return(nil, errors.New("fatal")).Once()
+	require.Error(t, term.DeleteResource(context.Background()))
+
+	kClient.AssertExpectations(t)
+}
@@ -60,3 +60,14 @@ Create the name of the service account to use
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Name of the secret with the serving certificate of the webhook
+*/}}
+{{- define "keycloak-operator.webhookCertSecret" -}}
+{{- if .Values.webhook.certManager.enabled }}
+{{- printf "%s-webhook-cert" .Values.name }}
+{{- else }}
+{{- required "webhook.certSecret is required if webhook.certManager.enabled is false" .Values.webhook.certSecret }}
+{{- end }}
+{{- end }}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -10,33 +12,53 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	buildInfo "github.com/epam/edp-common/pkg/config"
 
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 	keycloakApi1alpha1 "github.com/epam/edp-keycloak-operator/api/v1/v1alpha1"
+	"github.com/epam/edp-keycloak-operator/controllers/clusterkeycloakrealm"
+	"github.com/epam/edp-keycloak-operator/controllers/driftdetector"
 	"github.com/epam/edp-keycloak-operator/controllers/helper"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloak"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakauthflow"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakclient"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakclientrole"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakclientscope"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakconfigcliimport"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakidentityprovidermapper"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakldapfederation"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakorganization"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealm"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmcomponent"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmeventconfig"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmgroup"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmidentityprovider"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmimport"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmrole"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmrolebatch"
 	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmuser"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrealmuserbatch"
+	"github.com/epam/edp-keycloak-operator/controllers/keycloakrequiredaction"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/export"
+	"github.com/epam/edp-keycloak-operator/pkg/manager"
 	"github.com/epam/edp-keycloak-operator/pkg/util"
+	"github.com/epam/edp-keycloak-operator/pkg/vault"
+	"github.com/epam/edp-keycloak-operator/pkg/verify"
 )
 
 var (
@@ -45,16 +67,51 @@ var (
 )
 
 const (
-	keycloakOperatorLock    = "edp-keycloak-operator-lock"
 	successReconcileTimeout = "SUCCESS_RECONCILE_TIMEOUT"
 	managerPort             = 9443
+	enableWebhooks          = "ENABLE_WEBHOOKS"
+	driftDetectionInterval  = "DRIFT_DETECTION_INTERVAL"
+	driftPolicy             = "DRIFT_POLICY"
 )
 
+// subcommands of the operator binary which are run instead of the operator.
+var subcommands = map[string]func(ctx context.Context, args []string, out io.Writer) error{
+	export.Command: export.Run,
+	verify.Command: verify.NewRunner(newRealmResolver),
+}
+
+// newRealmResolver creates the realm resolver of the verify subcommand.
+func newRealmResolver(k8sClient client.Client) verify.RealmResolver {
+	return driftdetector.NewRealmResolver(k8sClient, helper.MakeHelper(k8sClient, k8sClient.Scheme(),
+		ctrl.Log.WithName("verify")))
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(context.Background(), os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+
+			return
+		}
+	}
+
 	var (
 		metricsAddr          string
 		probeAddr            string
 		enableLeaderElection bool
+		watchLabelSelector   string
+		disableControllers   string
+		controllerNamespaces string
+		inMemoryTokenCache   bool
+		keycloakRateLimit    float64
+		keycloakRateBurst    int
+		keycloakPageSize     int
+		vaultCfg             vault.Config
+		saTokenPath          string
+		saTokenAllowedURLs   string
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
@@ -62,6 +119,42 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&watchLabelSelector, "watch-label-selector", "",
+		"The label selector of the custom resources handled by the operator, e.g. tenant=a. "+
+			"It allows several operators to split the custom resources between them.")
+	flag.StringVar(&disableControllers, "disable-controllers", "",
+		"The comma separated kinds of the custom resources whose controllers are not started, "+
+			"e.g. KeycloakRealmUser,KeycloakRealmUserBatch.")
+	flag.StringVar(&controllerNamespaces, "controller-namespaces", "",
+		"The semicolon separated kinds of the custom resources with the comma separated namespaces "+
+			"their controllers watch, e.g. KeycloakRealmUser=team-a,team-b;KeycloakClient=team-c. "+
+			"The other controllers watch all namespaces of the operator.")
+	flag.BoolVar(&inMemoryTokenCache, "in-memory-token-cache", false,
+		"Keep the keycloak admin tokens in memory instead of the kc-token secrets, "+
+			"so the tokens are not stored at rest in etcd.")
+	flag.Float64Var(&keycloakRateLimit, "keycloak-rate-limit", 0,
+		"The number of the requests per second the operator sends to all keycloak instances together, "+
+			"0 means no limit. The rateLimit of the Keycloak is applied on top of it.")
+	flag.IntVar(&keycloakRateBurst, "keycloak-rate-burst", 0,
+		"The number of the requests which can be sent to keycloak at once above the keycloak-rate-limit, "+
+			"the rate limit is used if it is not set.")
+	flag.IntVar(&keycloakPageSize, "keycloak-page-size", adapter.DefaultPageSize,
+		"The number of the users, groups and clients requested from keycloak at once by the list operations.")
+	flag.StringVar(&vaultCfg.Address, "vault-address", "",
+		"The URL of Vault the admin credentials of the keycloaks are read from, Vault is not used if it is not set.")
+	flag.StringVar(&vaultCfg.Role, "vault-role", "", "The Kubernetes auth role the operator logs in to Vault with.")
+	flag.StringVar(&vaultCfg.AuthMount, "vault-auth-mount", vault.DefaultAuthMount,
+		"The path the Kubernetes auth method is mounted at in Vault.")
+	flag.StringVar(&vaultCfg.TokenPath, "vault-token-path", vault.DefaultTokenPath,
+		"The path of the projected ServiceAccount token with the Vault audience the operator logs in to Vault with.")
+	flag.StringVar(&vaultCfg.PathPrefix, "vault-secret-path-prefix", "",
+		"The API path the Vault secrets of the keycloaks must be under, e.g. secret/data/keycloak.")
+	flag.StringVar(&saTokenPath, "service-account-token-path", helper.DefaultServiceAccountTokenPath,
+		"The path of the projected ServiceAccount token the operator authenticates to keycloak with "+
+			"for the serviceAccountToken admin type.")
+	flag.StringVar(&saTokenAllowedURLs, "service-account-token-allowed-urls", "",
+		"The comma separated keycloak URLs the serviceAccountToken admin type is allowed for outside of "+
+			"the operator namespace.")
 
 	opts := zap.Options{
 		Development: true,
@@ -88,12 +181,23 @@ func main() {
 	utilruntime.Must(keycloakApi.AddToScheme(scheme))
 	utilruntime.Must(keycloakApi1alpha1.AddToScheme(scheme))
 
-	ns, err := util.GetWatchNamespace()
+	namespaces, err := util.GetWatchNamespaces()
 	if err != nil {
 		setupLog.Error(err, "unable to get watch namespace")
 		os.Exit(1)
 	}
 
+	ns := ""
+	if len(namespaces) == 1 {
+		ns = namespaces[0]
+	}
+
+	watchSelector, err := labels.Parse(watchLabelSelector)
+	if err != nil {
+		setupLog.Error(err, "unable to parse watch label selector")
+		os.Exit(1)
+	}
+
 	cfg := ctrl.GetConfigOrDie()
 
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
@@ -102,11 +206,12 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		Port:                   managerPort,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       keycloakOperatorLock,
+		LeaderElectionID:       manager.LeaderElectionID(watchSelector),
 		MapperProvider: func(c *rest.Config) (meta.RESTMapper, error) {
 			return apiutil.NewDynamicRESTMapper(cfg)
 		},
 		Namespace: ns,
+		NewCache:  manager.NewCache(namespaces, util.GetDenyNamespaces(), manager.WatchSelectors(scheme, watchSelector)),
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -121,71 +226,168 @@ func main() {
 
 	ctrlLog := ctrl.Log.WithName("controllers")
 	h := helper.MakeHelper(mgr.GetClient(), mgr.GetScheme(), ctrlLog)
+	h.SetWatchSelector(watchSelector)
 
-	keycloakCtrl := keycloak.NewReconcileKeycloak(mgr.GetClient(), mgr.GetScheme(), ctrlLog, h)
-	if err := keycloakCtrl.SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
-		setupLog.Error(err, "unable to create keycloak controller")
-		os.Exit(1)
+	if inMemoryTokenCache {
+		h.UseInMemoryTokenCache()
 	}
 
-	keycloakClientCtrl := keycloakclient.NewReconcileKeycloakClient(mgr.GetClient(), ctrlLog, h)
-	if err := keycloakClientCtrl.SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
-		setupLog.Error(err, "unable to create keycloak-client controller")
-		os.Exit(1)
+	h.SetRateLimit(keycloakRateLimit, keycloakRateBurst)
+	h.SetPageSize(keycloakPageSize)
+	h.SetServiceAccountToken(helper.ServiceAccountTokenConfig{
+		TokenPath:   saTokenPath,
+		Namespace:   util.GetOperatorNamespace(),
+		AllowedURLs: util.SplitList(saTokenAllowedURLs),
+	})
+
+	if vaultCfg.Address != "" {
+		if err = vaultCfg.Validate(); err != nil {
+			setupLog.Error(err, "unable to configure vault")
+			os.Exit(1)
+		}
+
+		h.SetVault(vaultCfg)
 	}
 
-	keycloakRealmCtrl := keycloakrealm.NewReconcileKeycloakRealm(mgr.GetClient(), mgr.GetScheme(), ctrlLog, h)
-	if err := keycloakRealmCtrl.SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
-		setupLog.Error(err, "unable to create keycloak-realm controller")
+	detector, err := makeDriftDetector(mgr, ctrlLog, h)
+	if err != nil {
+		setupLog.Error(err, "unable to create drift detector")
 		os.Exit(1)
 	}
 
-	krgCtrl := keycloakrealmgroup.NewReconcileKeycloakRealmGroup(mgr.GetClient(), ctrlLog, h)
-	if err := krgCtrl.SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
-		setupLog.Error(err, "unable to create keycloak-realm-group controller")
-		os.Exit(1)
+	// kindNamespaces are the namespaces watched by the controller kinds, they are parsed once the kinds are known
+	var kindNamespaces map[string][]string
+
+	driftTriggers := func(kind string) []source.Source {
+		if detector == nil {
+			return nil
+		}
+
+		return []source.Source{detector.Trigger(kind, kindNamespaces[kind]...)}
 	}
 
-	krrCtrl := keycloakrealmrole.NewReconcileKeycloakRealmRole(mgr.GetClient(), ctrlLog, h)
-	if err := krrCtrl.SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
-		setupLog.Error(err, "unable to create keycloak-realm-role controller")
-		os.Exit(1)
+	timeout := successReconcileTimeoutValue
+
+	controllers := []struct {
+		kind  string
+		name  string
+		setup func(mgr ctrl.Manager) error
+	}{
+		{"Keycloak", "keycloak", func(mgr ctrl.Manager) error {
+			return keycloak.NewReconcileKeycloak(mgr.GetClient(), mgr.GetScheme(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakClient", "keycloak-client", func(mgr ctrl.Manager) error {
+			return keycloakclient.NewReconcileKeycloakClient(mgr.GetClient(), ctrlLog, h,
+				mgr.GetEventRecorderFor("keycloakclient-controller")).
+				SetupWithManager(mgr, timeout, driftTriggers("KeycloakClient")...)
+		}},
+		{"KeycloakRealm", "keycloak-realm", func(mgr ctrl.Manager) error {
+			return keycloakrealm.NewReconcileKeycloakRealm(mgr.GetClient(), mgr.GetScheme(), ctrlLog, h).
+				SetupWithManager(mgr, timeout, driftTriggers("KeycloakRealm")...)
+		}},
+		{"ClusterKeycloakRealm", "cluster-keycloak-realm", func(mgr ctrl.Manager) error {
+			return clusterkeycloakrealm.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakRealmGroup", "keycloak-realm-group", func(mgr ctrl.Manager) error {
+			return keycloakrealmgroup.NewReconcileKeycloakRealmGroup(mgr.GetClient(), ctrlLog, h).
+				SetupWithManager(mgr, timeout, driftTriggers("KeycloakRealmGroup")...)
+		}},
+		{"KeycloakRealmRole", "keycloak-realm-role", func(mgr ctrl.Manager) error {
+			return keycloakrealmrole.NewReconcileKeycloakRealmRole(mgr.GetClient(), ctrlLog, h).
+				SetupWithManager(mgr, timeout, driftTriggers("KeycloakRealmRole")...)
+		}},
+		{"KeycloakRealmRoleBatch", "keycloak-realm-role-batch", func(mgr ctrl.Manager) error {
+			return keycloakrealmrolebatch.NewReconcileKeycloakRealmRoleBatch(mgr.GetClient(), ctrlLog, h).
+				SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakAuthFlow", "keycloak-auth-flow", func(mgr ctrl.Manager) error {
+			return keycloakauthflow.NewReconcile(mgr.GetClient(), ctrlLog, h).
+				SetupWithManager(mgr, timeout, driftTriggers("KeycloakAuthFlow")...)
+		}},
+		{"KeycloakRealmUser", "keycloak-realm-user", func(mgr ctrl.Manager) error {
+			return keycloakrealmuser.NewReconcile(mgr.GetClient(), ctrlLog, h,
+				mgr.GetEventRecorderFor("keycloakrealmuser-controller")).SetupWithManager(mgr)
+		}},
+		{"KeycloakRealmUserBatch", "keycloak-realm-user-batch", func(mgr ctrl.Manager) error {
+			return keycloakrealmuserbatch.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakRealmEventConfig", "keycloak-realm-event-config", func(mgr ctrl.Manager) error {
+			return keycloakrealmeventconfig.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakClientScope", "keycloak-client-scope", func(mgr ctrl.Manager) error {
+			return keycloakclientscope.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakClientRole", "keycloak-client-role", func(mgr ctrl.Manager) error {
+			return keycloakclientrole.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakRealmComponent", "keycloak-realm-component", func(mgr ctrl.Manager) error {
+			return keycloakrealmcomponent.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakRealmIdentityProvider", "keycloak-realm-identity-provider", func(mgr ctrl.Manager) error {
+			return keycloakrealmidentityprovider.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakLDAPFederation", "keycloak-ldap-federation", func(mgr ctrl.Manager) error {
+			return keycloakldapfederation.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakIdentityProviderMapper", "keycloak-identity-provider-mapper", func(mgr ctrl.Manager) error {
+			return keycloakidentityprovidermapper.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakOrganization", "keycloak-organization", func(mgr ctrl.Manager) error {
+			return keycloakorganization.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakRequiredAction", "keycloak-required-action", func(mgr ctrl.Manager) error {
+			return keycloakrequiredaction.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakRealmImport", "keycloak-realm-import", func(mgr ctrl.Manager) error {
+			return keycloakrealmimport.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
+		{"KeycloakConfigCliImport", "keycloak-config-cli-import", func(mgr ctrl.Manager) error {
+			return keycloakconfigcliimport.NewReconcile(mgr.GetClient(), ctrlLog, h).SetupWithManager(mgr, timeout)
+		}},
 	}
 
-	krrbCtrl := keycloakrealmrolebatch.NewReconcileKeycloakRealmRoleBatch(mgr.GetClient(), ctrlLog, h)
-	if err := krrbCtrl.SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
-		setupLog.Error(err, "unable to create keycloak-realm-role-batch controller")
-		os.Exit(1)
+	kinds := make([]string, 0, len(controllers))
+	for _, c := range controllers {
+		kinds = append(kinds, c.kind)
 	}
 
-	kafCtrl := keycloakauthflow.NewReconcile(mgr.GetClient(), ctrlLog, h)
-	if err := kafCtrl.SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
-		setupLog.Error(err, "unable to create keycloak-auth-flow controller")
+	disabled, err := manager.ParseDisabledControllers(disableControllers, kinds)
+	if err != nil {
+		setupLog.Error(err, "unable to parse disabled controllers")
 		os.Exit(1)
 	}
 
-	kruCtrl := keycloakrealmuser.NewReconcile(mgr.GetClient(), ctrlLog, h)
-	if err := kruCtrl.SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create keycloak-realm-user controller")
+	kindNamespaces, err = manager.ParseControllerNamespaces(controllerNamespaces, kinds, util.GetDenyNamespaces())
+	if err != nil {
+		setupLog.Error(err, "unable to parse controller namespaces")
 		os.Exit(1)
 	}
 
-	if err := keycloakclientscope.NewReconcile(mgr.GetClient(), ctrlLog, h).
-		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
-		setupLog.Error(err, "unable to create keycloak-client-scope controller")
-		os.Exit(1)
+	for _, c := range controllers {
+		if disabled[c.kind] {
+			setupLog.Info("Controller is disabled", "kind", c.kind)
+
+			continue
+		}
+
+		if err := c.setup(manager.NamespacedManager(mgr, kindNamespaces[c.kind])); err != nil {
+			setupLog.Error(err, fmt.Sprintf("unable to create %s controller", c.name))
+			os.Exit(1)
+		}
 	}
 
-	if err := keycloakrealmcomponent.NewReconcile(mgr.GetClient(), ctrlLog, h).
-		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
-		setupLog.Error(err, "unable to create keycloak-realm-component controller")
-		os.Exit(1)
+	if detector != nil {
+		if err := mgr.Add(detector); err != nil {
+			setupLog.Error(err, "unable to add drift detector")
+			os.Exit(1)
+		}
 	}
 
-	if err := keycloakrealmidentityprovider.NewReconcile(mgr.GetClient(), ctrlLog, h).
-		SetupWithManager(mgr, successReconcileTimeoutValue); err != nil {
-		setupLog.Error(err, "unable to create keycloak-realm-identity-provider controller")
-		os.Exit(1)
+	if os.Getenv(enableWebhooks) == "true" {
+		if err := setupWebhooks(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook")
+			os.Exit(1)
+		}
 	}
 	//+kubebuilder:scaffold:builder
 
@@ -207,6 +409,30 @@ func main() {
 	}
 }
 
+// setupWebhooks registers the validating webhooks. The webhooks of KeycloakClient, KeycloakRealmUser,
+// KeycloakRealmIdentityProvider, KeycloakLDAPFederation and KeycloakRealmComponent reject plaintext credentials,
+// the webhook of KeycloakAuthFlow rejects the executions keycloak can not apply and the undeclared child flows.
+func setupWebhooks(mgr ctrl.Manager) error {
+	webhooks := []interface {
+		SetupWebhookWithManager(mgr ctrl.Manager) error
+	}{
+		&keycloakApi.KeycloakClient{},
+		&keycloakApi.KeycloakRealmUser{},
+		&keycloakApi.KeycloakRealmIdentityProvider{},
+		&keycloakApi.KeycloakLDAPFederation{},
+		&keycloakApi.KeycloakRealmComponent{},
+		&keycloakApi.KeycloakAuthFlow{},
+	}
+
+	for _, w := range webhooks {
+		if err := w.SetupWebhookWithManager(mgr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func getSuccessReconcileTimeout() (time.Duration, error) {
 	val, exists := os.LookupEnv(successReconcileTimeout)
 	if !exists {
@@ -220,3 +446,32 @@ func getSuccessReconcileTimeout() (time.Duration, error) {
 
 	return d, nil
 }
+
+// makeDriftDetector creates the drift detector if the detection interval is set.
+func makeDriftDetector(mgr ctrl.Manager, log logr.Logger, h *helper.Helper) (*driftdetector.Detector, error) {
+	val, exists := os.LookupEnv(driftDetectionInterval)
+	if !exists || val == "" {
+		return nil, nil
+	}
+
+	interval, err := time.ParseDuration(val)
+	if err != nil {
+		return nil, fmt.Errorf("wrong drift detection interval format: %w", err)
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("drift detection interval must be positive, got %s", interval)
+	}
+
+	policy := os.Getenv(driftPolicy)
+	if policy == "" {
+		policy = keycloakApi.DriftPolicyAlert
+	}
+
+	if policy != keycloakApi.DriftPolicyAlert && policy != keycloakApi.DriftPolicyHeal {
+		return nil, fmt.Errorf("unknown drift policy %s, supported policies are %s and %s", policy,
+			keycloakApi.DriftPolicyAlert, keycloakApi.DriftPolicyHeal)
+	}
+
+	return driftdetector.NewDetector(mgr.GetClient(), log, h, interval, policy), nil
+}
@@ -0,0 +1,164 @@
+// Package artifact downloads files from HTTPS URLs and OCI registries.
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MaxSize is the maximum size of the downloaded file.
+const MaxSize = 32 << 20
+
+// Credentials are used to authenticate the download requests.
+// The token is sent as a bearer token, otherwise the username and the password are used for the basic auth.
+type Credentials struct {
+	Username string
+	Password string
+	Token    string
+}
+
+func (c *Credentials) empty() bool {
+	return c == nil || (c.Token == "" && c.Username == "" && c.Password == "")
+}
+
+func (c *Credentials) setAuth(req *http.Request) {
+	if c.empty() {
+		return
+	}
+
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+
+		return
+	}
+
+	req.SetBasicAuth(c.Username, c.Password)
+}
+
+// FetchURL downloads the file from the HTTPS URL.
+// The sha256 hex digest of the file is checked if it is not empty.
+func FetchURL(ctx context.Context, httpClient *http.Client, url, digest string, creds *Credentials) ([]byte, error) {
+	if !strings.HasPrefix(url, "https://") {
+		return nil, errors.Errorf("url %s must use https", url)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create request")
+	}
+
+	creds.setAuth(req)
+
+	data, err := doRequest(httpClient, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to download %s", url)
+	}
+
+	if digest != "" {
+		if err := checkDigest(data, "sha256:"+strings.TrimPrefix(digest, "sha256:")); err != nil {
+			return nil, errors.Wrapf(err, "invalid content of %s", url)
+		}
+	}
+
+	return data, nil
+}
+
+func doRequest(httpClient *http.Client, req *http.Request) ([]byte, error) {
+	rsp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "request failed")
+	}
+
+	defer rsp.Body.Close()
+
+	return readResponse(rsp)
+}
+
+func readResponse(rsp *http.Response) ([]byte, error) {
+	if rsp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s", rsp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(rsp.Body, MaxSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read response")
+	}
+
+	if len(data) > MaxSize {
+		return nil, errors.Errorf("response is larger than %d bytes", MaxSize)
+	}
+
+	return data, nil
+}
+
+// checkDigest checks that the data matches the digest in the sha256:<hex> format.
+func checkDigest(data []byte, digest string) error {
+	if !strings.HasPrefix(digest, "sha256:") {
+		return errors.Errorf("unsupported digest %s, only sha256 is supported", digest)
+	}
+
+	sum := sha256.Sum256(data)
+	actual := "sha256:" + hex.EncodeToString(sum[:])
+
+	if !strings.EqualFold(actual, digest) {
+		return errors.Errorf("digest mismatch, expected %s, got %s", digest, actual)
+	}
+
+	return nil
+}
+
+// CredentialsFromDockerConfig returns the credentials of the registry from the .dockerconfigjson secret data.
+func CredentialsFromDockerConfig(data []byte, registry string) (*Credentials, error) {
+	var cfg struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Wrap(err, "unable to decode docker config")
+	}
+
+	for key, auth := range cfg.Auths {
+		if !registryMatches(key, registry) {
+			continue
+		}
+
+		if auth.Auth == "" {
+			return &Credentials{Username: auth.Username, Password: auth.Password}, nil
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to decode auth of registry %s", key)
+		}
+
+		username, password, _ := strings.Cut(string(decoded), ":")
+
+		return &Credentials{Username: username, Password: password}, nil
+	}
+
+	return nil, errors.Errorf("docker config has no credentials of registry %s", registry)
+}
+
+// registryMatches checks whether the docker config key, which can be a host or a URL, refers to the registry.
+func registryMatches(key, registry string) bool {
+	host := strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+
+	if registry == dockerHubRegistry {
+		return host == dockerHubRegistry || host == "index.docker.io" || host == "docker.io"
+	}
+
+	return host == registry
+}
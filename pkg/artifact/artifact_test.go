@@ -0,0 +1,231 @@
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestFetchURL(t *testing.T) {
+	data := []byte(`{"realm":"main"}`)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	res, err := FetchURL(ctx, server.Client(), server.URL+"/realm.json", strings.TrimPrefix(digestOf(data), "sha256:"),
+		&Credentials{Token: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, data, res)
+
+	_, err = FetchURL(ctx, server.Client(), server.URL+"/realm.json", "", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 401")
+
+	_, err = FetchURL(ctx, server.Client(), server.URL+"/realm.json", strings.Repeat("0", 64),
+		&Credentials{Token: "secret"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "digest mismatch")
+
+	_, err = FetchURL(ctx, server.Client(), "http://example.com/realm.json", "", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must use https")
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    *Reference
+		wantErr string
+	}{
+		{
+			ref:  "ghcr.io/org/realms/main:v1",
+			want: &Reference{Registry: "ghcr.io", Repository: "org/realms/main", Tag: "v1"},
+		},
+		{
+			ref:  "localhost:5000/main",
+			want: &Reference{Registry: "localhost:5000", Repository: "main", Tag: "latest"},
+		},
+		{
+			ref:  "ghcr.io/org/main:v1@sha256:abc",
+			want: &Reference{Registry: "ghcr.io", Repository: "org/main", Tag: "v1", Digest: "sha256:abc"},
+		},
+		{
+			ref:  "docker.io/main@sha256:abc",
+			want: &Reference{Registry: "registry-1.docker.io", Repository: "library/main", Digest: "sha256:abc"},
+		},
+		{ref: "org/main:v1", wantErr: "must contain registry host"},
+		{ref: "ghcr.io/org/main@md5:abc", wantErr: "only sha256 is supported"},
+		{ref: "ghcr.io/org/main:", wantErr: "empty tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := ParseReference(tt.ref)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newTestRegistry(t *testing.T, layers map[string][]byte) (*httptest.Server, string) {
+	t.Helper()
+
+	manifest := ociManifest{}
+	for title, data := range layers {
+		manifest.Layers = append(manifest.Layers, ociDescriptor{
+			MediaType:   "application/json",
+			Digest:      digestOf(data),
+			Size:        int64(len(data)),
+			Annotations: map[string]string{TitleAnnotation: title},
+		})
+	}
+
+	manifestData, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	var server *httptest.Server
+
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			user, password, ok := r.BasicAuth()
+			if !ok || user != "robot" || password != "pass" || r.URL.Query().Get("scope") != "repository:org/main:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"token":"pull-token"}`))
+
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer pull-token" {
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org/main:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/org/main/manifests/v1", "/v2/org/main/manifests/" + digestOf(manifestData):
+			_, _ = w.Write(manifestData)
+
+			return
+		}
+
+		for _, data := range layers {
+			if r.URL.Path == "/v2/org/main/blobs/"+digestOf(data) {
+				_, _ = w.Write(data)
+
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	return server, digestOf(manifestData)
+}
+
+func TestFetchOCI(t *testing.T) {
+	realm := []byte(`{"realm":"main"}`)
+	server, manifestDigest := newTestRegistry(t, map[string][]byte{"realm.json": realm})
+
+	defer server.Close()
+
+	registry := strings.TrimPrefix(server.URL, "https://")
+	creds := &Credentials{Username: "robot", Password: "pass"}
+	ctx := context.Background()
+
+	res, err := FetchOCI(ctx, server.Client(), registry+"/org/main:v1", "", creds)
+	require.NoError(t, err)
+	assert.Equal(t, realm, res)
+
+	res, err = FetchOCI(ctx, server.Client(), registry+"/org/main@"+manifestDigest, "realm.json", creds)
+	require.NoError(t, err)
+	assert.Equal(t, realm, res)
+
+	_, err = FetchOCI(ctx, server.Client(), registry+"/org/main@"+digestOf([]byte("other")), "", creds)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get manifest")
+
+	_, err = FetchOCI(ctx, server.Client(), registry+"/org/main:v1", "users.json", creds)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no layer with org.opencontainers.image.title users.json")
+
+	_, err = FetchOCI(ctx, server.Client(), registry+"/org/main:v1", "", &Credentials{Username: "robot"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get registry token")
+}
+
+func TestFetchOCI_MultipleLayers(t *testing.T) {
+	server, _ := newTestRegistry(t, map[string][]byte{
+		"realm.json": []byte(`{"realm":"main"}`),
+		"users.json": []byte(`{"users":[]}`),
+	})
+
+	defer server.Close()
+
+	ref := strings.TrimPrefix(server.URL, "https://") + "/org/main:v1"
+	creds := &Credentials{Username: "robot", Password: "pass"}
+
+	_, err := FetchOCI(context.Background(), server.Client(), ref, "", creds)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "artifact has 2 layers, file must be set")
+
+	res, err := FetchOCI(context.Background(), server.Client(), ref, "users.json", creds)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"users":[]}`), res)
+}
+
+func TestCredentialsFromDockerConfig(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("robot:pass"))
+	cfg := []byte(`{"auths":{"https://index.docker.io/v1/":{"auth":"` + auth + `"},` +
+		`"ghcr.io":{"username":"user","password":"token"}}}`)
+
+	creds, err := CredentialsFromDockerConfig(cfg, "registry-1.docker.io")
+	require.NoError(t, err)
+	assert.Equal(t, &Credentials{Username: "robot", Password: "pass"}, creds)
+
+	creds, err = CredentialsFromDockerConfig(cfg, "ghcr.io")
+	require.NoError(t, err)
+	assert.Equal(t, &Credentials{Username: "user", Password: "token"}, creds)
+
+	_, err = CredentialsFromDockerConfig(cfg, "quay.io")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no credentials of registry quay.io")
+}
@@ -0,0 +1,298 @@
+package artifact
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// TitleAnnotation is the annotation of the OCI artifact layer with the file name.
+	TitleAnnotation = "org.opencontainers.image.title"
+
+	manifestMediaTypes = "application/vnd.oci.image.manifest.v1+json," +
+		"application/vnd.docker.distribution.manifest.v2+json"
+	dockerHubRegistry = "registry-1.docker.io"
+)
+
+// Reference is a parsed OCI artifact reference registry/repository[:tag][@digest].
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses the OCI artifact reference, the registry host must be set explicitly.
+func ParseReference(ref string) (*Reference, error) {
+	registry, rest, ok := strings.Cut(ref, "/")
+	if !ok || rest == "" || !(strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		return nil, errors.Errorf("reference %s must contain registry host and repository", ref)
+	}
+
+	res := Reference{Registry: registry}
+
+	if repo, digest, ok := strings.Cut(rest, "@"); ok {
+		if !strings.HasPrefix(digest, "sha256:") {
+			return nil, errors.Errorf("reference %s has unsupported digest, only sha256 is supported", ref)
+		}
+
+		res.Digest = digest
+		rest = repo
+	}
+
+	if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "/") {
+		res.Tag = rest[i+1:]
+		rest = rest[:i]
+
+		if res.Tag == "" {
+			return nil, errors.Errorf("reference %s has empty tag", ref)
+		}
+	}
+
+	if rest == "" {
+		return nil, errors.Errorf("reference %s has empty repository", ref)
+	}
+
+	res.Repository = rest
+
+	if res.Registry == "docker.io" {
+		res.Registry = dockerHubRegistry
+
+		if !strings.Contains(res.Repository, "/") {
+			res.Repository = "library/" + res.Repository
+		}
+	}
+
+	if res.Tag == "" && res.Digest == "" {
+		res.Tag = "latest"
+	}
+
+	return &res, nil
+}
+
+// manifestRef returns the digest if it is set or the tag which is used to get the manifest.
+func (r *Reference) manifestRef() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+
+	return r.Tag
+}
+
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// FetchOCI downloads the file from the OCI artifact.
+// The layer is selected by the title annotation if the file is set, otherwise the artifact must have a single layer.
+// The manifest is checked against the digest of the reference and the layer against its digest from the manifest.
+func FetchOCI(ctx context.Context, httpClient *http.Client, ref, file string, creds *Credentials) ([]byte, error) {
+	parsed, err := ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	reg := registryClient{httpClient: httpClient, ref: parsed, creds: creds}
+
+	manifestData, err := reg.get(ctx, "/manifests/"+parsed.manifestRef(), manifestMediaTypes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get manifest of %s", ref)
+	}
+
+	if parsed.Digest != "" {
+		if err = checkDigest(manifestData, parsed.Digest); err != nil {
+			return nil, errors.Wrapf(err, "invalid manifest of %s", ref)
+		}
+	}
+
+	var manifest ociManifest
+	if err = json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, errors.Wrapf(err, "unable to decode manifest of %s", ref)
+	}
+
+	layer, err := selectLayer(manifest.Layers, file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to find file in %s", ref)
+	}
+
+	if layer.Size > MaxSize {
+		return nil, errors.Errorf("layer %s of %s is larger than %d bytes", layer.Digest, ref, MaxSize)
+	}
+
+	data, err := reg.get(ctx, "/blobs/"+layer.Digest, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get layer %s of %s", layer.Digest, ref)
+	}
+
+	if err = checkDigest(data, layer.Digest); err != nil {
+		return nil, errors.Wrapf(err, "invalid layer of %s", ref)
+	}
+
+	return data, nil
+}
+
+func selectLayer(layers []ociDescriptor, file string) (*ociDescriptor, error) {
+	if file == "" {
+		if len(layers) != 1 {
+			return nil, errors.Errorf("artifact has %d layers, file must be set", len(layers))
+		}
+
+		return &layers[0], nil
+	}
+
+	for i := range layers {
+		if layers[i].Annotations[TitleAnnotation] == file {
+			return &layers[i], nil
+		}
+	}
+
+	return nil, errors.Errorf("artifact has no layer with %s %s", TitleAnnotation, file)
+}
+
+// registryClient makes the requests to the OCI distribution API of the repository.
+// The bearer token is requested from the token service the registry challenges with.
+type registryClient struct {
+	httpClient *http.Client
+	ref        *Reference
+	creds      *Credentials
+	token      string
+}
+
+func (c *registryClient) get(ctx context.Context, path, accept string) ([]byte, error) {
+	rsp, err := c.do(ctx, path, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode == http.StatusUnauthorized && c.token == "" {
+		challenge := rsp.Header.Get("WWW-Authenticate")
+		rsp.Body.Close()
+
+		if err = c.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+
+		if rsp, err = c.do(ctx, path, accept); err != nil {
+			return nil, err
+		}
+	}
+
+	defer rsp.Body.Close()
+
+	return readResponse(rsp)
+}
+
+func (c *registryClient) do(ctx context.Context, path, accept string) (*http.Response, error) {
+	u := "https://" + c.ref.Registry + "/v2/" + c.ref.Repository + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create request")
+	}
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else {
+		c.creds.setAuth(req)
+	}
+
+	rsp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "request failed")
+	}
+
+	return rsp, nil
+}
+
+// authenticate gets the pull token from the token service of the bearer challenge.
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return errors.New("registry rejected credentials")
+	}
+
+	values := parseChallengeParams(params)
+
+	realm := values["realm"]
+	if !strings.HasPrefix(realm, "https://") {
+		return errors.Errorf("registry token realm %q must use https", realm)
+	}
+
+	query := url.Values{}
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+
+	query.Set("scope", "repository:"+c.ref.Repository+":pull")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return errors.Wrap(err, "unable to create token request")
+	}
+
+	if c.creds != nil && c.creds.Token == "" {
+		c.creds.setAuth(req)
+	}
+
+	data, err := doRequest(c.httpClient, req)
+	if err != nil {
+		return errors.Wrap(err, "unable to get registry token")
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err = json.Unmarshal(data, &token); err != nil {
+		return errors.Wrap(err, "unable to decode registry token")
+	}
+
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+
+	if c.token == "" {
+		return errors.New("registry token service returned empty token")
+	}
+
+	return nil
+}
+
+// parseChallengeParams parses the key="value" pairs of the WWW-Authenticate header.
+func parseChallengeParams(params string) map[string]string {
+	values := make(map[string]string)
+
+	for params != "" {
+		var key, value string
+
+		key, params, _ = strings.Cut(strings.TrimLeft(params, ", "), "=")
+
+		if strings.HasPrefix(params, `"`) {
+			value, params, _ = strings.Cut(params[1:], `"`)
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+
+		values[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+
+	return values
+}
@@ -3,6 +3,7 @@ package adapter
 import (
 	"context"
 
+	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
 )
 
@@ -11,15 +12,13 @@ type Component struct {
 	ParentID     string              `json:"parentId,omitempty"`
 	ProviderID   string              `json:"providerId"`
 	ProviderType string              `json:"providerType"`
+	SubType      string              `json:"subType,omitempty"`
 	Config       map[string][]string `json:"config"`
 	ID           string              `json:"id,omitempty"`
 }
 
 func (a GoCloakAdapter) CreateComponent(ctx context.Context, realmName string, component *Component) error {
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-	}).SetBody(component).Post(a.basePath + realmComponent)
-	if err = a.checkError(err, rsp); err != nil {
+	if _, err := a.client.CreateComponent(ctx, a.token.AccessToken, realmName, component.toGoCloak()); err != nil {
 		return errors.Wrap(err, "error during request")
 	}
 
@@ -36,12 +35,7 @@ func (a GoCloakAdapter) UpdateComponent(ctx context.Context, realmName string, c
 		component.ID = _component.ID
 	}
 
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		keycloakApiParamId:    component.ID,
-	}).SetBody(component).Put(a.basePath + realmComponentEntity)
-
-	if err = a.checkError(err, rsp); err != nil {
+	if err := a.client.UpdateComponent(ctx, a.token.AccessToken, realmName, component.toGoCloak()); err != nil {
 		return errors.Wrap(err, "error during update component request")
 	}
 
@@ -54,12 +48,12 @@ func (a GoCloakAdapter) DeleteComponent(ctx context.Context, realmName, componen
 		return errors.Wrap(err, "unable to get component id")
 	}
 
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		keycloakApiParamId:    component.ID,
-	}).Delete(a.basePath + realmComponentEntity)
+	return a.DeleteComponentByID(ctx, realmName, component.ID)
+}
 
-	if err = a.checkError(err, rsp); err != nil {
+// DeleteComponentByID deletes the component by id, it should be used if component names are not unique.
+func (a GoCloakAdapter) DeleteComponentByID(ctx context.Context, realmName, componentID string) error {
+	if err := a.client.DeleteComponent(ctx, a.token.AccessToken, realmName, componentID); err != nil {
 		return errors.Wrap(err, "error during delete component request")
 	}
 
@@ -67,20 +61,111 @@ func (a GoCloakAdapter) DeleteComponent(ctx context.Context, realmName, componen
 }
 
 func (a GoCloakAdapter) GetComponent(ctx context.Context, realmName, componentName string) (*Component, error) {
-	var components []Component
-
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-	}).SetResult(&components).Get(a.basePath + realmComponent)
-	if err = a.checkError(err, rsp); err != nil {
+	components, err := a.client.GetComponentsWithParams(ctx, a.token.AccessToken, realmName,
+		gocloak.GetComponentsParams{Name: gocloak.StringP(componentName)})
+	if err != nil {
 		return nil, errors.Wrap(err, "error during get component request")
 	}
 
 	for _, c := range components {
-		if c.Name == componentName {
-			return &c, nil
+		if gocloak.PString(c.Name) == componentName {
+			return componentFromGoCloak(c), nil
 		}
 	}
 
 	return nil, NotFoundError("component not found")
 }
+
+// GetComponents returns components of the realm with the given provider type.
+// The components are filtered here, because gocloak sends the provider type in the query parameter keycloak ignores.
+func (a GoCloakAdapter) GetComponents(ctx context.Context, realmName, providerType string) ([]Component, error) {
+	components, err := a.client.GetComponents(ctx, a.token.AccessToken, realmName)
+	if err != nil {
+		return nil, errors.Wrap(err, "error during get components request")
+	}
+
+	result := make([]Component, 0, len(components))
+
+	for _, c := range components {
+		if gocloak.PString(c.ProviderType) == providerType {
+			result = append(result, *componentFromGoCloak(c))
+		}
+	}
+
+	return result, nil
+}
+
+func (c *Component) toGoCloak() gocloak.Component {
+	component := gocloak.Component{
+		ID:           optionalString(c.ID),
+		Name:         gocloak.StringP(c.Name),
+		ProviderID:   gocloak.StringP(c.ProviderID),
+		ProviderType: gocloak.StringP(c.ProviderType),
+		ParentID:     optionalString(c.ParentID),
+		SubType:      optionalString(c.SubType),
+	}
+
+	if c.Config != nil {
+		component.ComponentConfig = &c.Config
+	}
+
+	return component
+}
+
+func componentFromGoCloak(c *gocloak.Component) *Component {
+	component := Component{
+		ID:           gocloak.PString(c.ID),
+		Name:         gocloak.PString(c.Name),
+		ProviderID:   gocloak.PString(c.ProviderID),
+		ProviderType: gocloak.PString(c.ProviderType),
+		ParentID:     gocloak.PString(c.ParentID),
+		SubType:      gocloak.PString(c.SubType),
+	}
+
+	if c.ComponentConfig != nil {
+		component.Config = *c.ComponentConfig
+	}
+
+	return &component
+}
+
+// optionalString returns nil for the empty string, so the optional field is not sent to keycloak.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// UserStorageSyncResult is a result of the user storage provider synchronization.
+type UserStorageSyncResult struct {
+	Ignored bool   `json:"ignored"`
+	Added   int    `json:"added"`
+	Updated int    `json:"updated"`
+	Removed int    `json:"removed"`
+	Failed  int    `json:"failed"`
+	Status  string `json:"status"`
+}
+
+const (
+	UserStorageFullSync         = "triggerFullSync"
+	UserStorageChangedUsersSync = "triggerChangedUsersSync"
+)
+
+// SyncUserStorage triggers synchronization of users for the user storage provider component,
+// action should be UserStorageFullSync or UserStorageChangedUsersSync.
+func (a GoCloakAdapter) SyncUserStorage(ctx context.Context, realmName, componentID, action string) (*UserStorageSyncResult, error) {
+	var result UserStorageSyncResult
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    componentID,
+	}).SetQueryParam("action", action).SetResult(&result).Post(a.basePath + userStorageSync)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "error during user storage sync request")
+	}
+
+	return &result, nil
+}
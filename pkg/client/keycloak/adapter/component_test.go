@@ -2,6 +2,7 @@ package adapter
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/Nerzal/gocloak/v12"
@@ -38,109 +39,167 @@ func testComponent() *Component {
 	}
 }
 
+func testGoCloakComponent() gocloak.Component {
+	return gocloak.Component{
+		ID:              gocloak.StringP("test-id"),
+		Name:            gocloak.StringP("test-name"),
+		ProviderID:      gocloak.StringP(""),
+		ProviderType:    gocloak.StringP("test-provider-type"),
+		ComponentConfig: &map[string][]string{"foo": {"bar", "vaz"}},
+	}
+}
+
 func TestGoCloakAdapter_CreateComponent(t *testing.T) {
-	kcAdapter, _, _ := initAdapter()
+	kcAdapter, mockClient, _ := initAdapter()
 
-	httpmock.RegisterResponder("POST", "/admin/realms/realm-name/components",
-		httpmock.NewStringResponder(200, ""))
+	cmp := testGoCloakComponent()
+	cmp.ID = nil
+
+	mockClient.On("CreateComponent", "realm-name", cmp).Return("test-id", nil).Once()
 
 	err := kcAdapter.CreateComponent(context.Background(), "realm-name", testComponent())
 	require.NoError(t, err)
 
-	httpmock.RegisterResponder("POST", "/admin/realms/realm-name-error/components",
-		httpmock.NewStringResponder(500, "fatal"))
+	mockClient.On("CreateComponent", "realm-name-error", cmp).
+		Return("", &gocloak.APIError{Code: http.StatusInternalServerError, Message: "500 Internal Server Error"}).Once()
 
-	err = kcAdapter.CreateComponent(context.Background(), "realm-name-error",
-		testComponent())
+	err = kcAdapter.CreateComponent(context.Background(), "realm-name-error", testComponent())
 	require.Error(t, err)
-
-	if err.Error() != "error during request: status: 500, body: fatal" {
-		t.Fatalf("wrong error returned: %s", err.Error())
-	}
+	require.EqualError(t, err, "error during request: 500 Internal Server Error")
 }
 
 func TestMock_UpdateComponent(t *testing.T) {
-	kcAdapter, _, _ := initAdapter()
-	testCmp := testComponent()
-	testCmp.ID = "test-id"
+	kcAdapter, mockClient, _ := initAdapter()
+	cmp := testGoCloakComponent()
+	params := gocloak.GetComponentsParams{Name: gocloak.StringP("test-name")}
 
-	httpmock.RegisterResponder("GET", "/admin/realms/realm-name/components",
-		httpmock.NewJsonResponderOrPanic(200, []Component{*testCmp}))
-	httpmock.RegisterResponder("PUT", "/admin/realms/realm-name/components/test-id",
-		httpmock.NewStringResponder(200, ""))
+	mockClient.On("GetComponentsWithParams", "realm-name", params).Return([]*gocloak.Component{&cmp}, nil)
+	mockClient.On("UpdateComponent", "realm-name", cmp).Return(nil)
 
 	err := kcAdapter.UpdateComponent(context.Background(), "realm-name", testComponent())
 	require.NoError(t, err)
 
-	httpmock.RegisterResponder("GET", "/admin/realms/realm-name-no-components/components",
-		httpmock.NewJsonResponderOrPanic(200, []Component{}))
+	mockClient.On("GetComponentsWithParams", "realm-name-no-components", params).
+		Return([]*gocloak.Component{}, nil)
 
 	err = kcAdapter.UpdateComponent(context.Background(), "realm-name-no-components", testComponent())
 	require.Error(t, err)
+	require.EqualError(t, err, "unable to get component id: component not found")
 
-	if err.Error() != "unable to get component id: component not found" {
-		t.Fatalf("wrong error returned: %s", err.Error())
-	}
-
-	httpmock.RegisterResponder("GET", "/admin/realms/realm-name-update-failure/components",
-		httpmock.NewJsonResponderOrPanic(200, []Component{*testCmp}))
-	httpmock.RegisterResponder("PUT", "/admin/realms/realm-name-update-failure/components/test-id",
-		httpmock.NewStringResponder(404, "not found"))
+	mockClient.On("GetComponentsWithParams", "realm-name-update-failure", params).
+		Return([]*gocloak.Component{&cmp}, nil)
+	mockClient.On("UpdateComponent", "realm-name-update-failure", cmp).
+		Return(&gocloak.APIError{Code: http.StatusNotFound, Message: "404 Not Found"})
 
 	err = kcAdapter.UpdateComponent(context.Background(), "realm-name-update-failure", testComponent())
 	require.Error(t, err)
-
-	if err.Error() != "error during update component request: status: 404, body: not found" {
-		t.Fatalf("wrong error returned: %s", err.Error())
-	}
+	require.EqualError(t, err, "error during update component request: 404 Not Found")
+	require.True(t, IsErrNotFound(err))
 }
 
 func TestGoCloakAdapter_DeleteComponent(t *testing.T) {
-	kcAdapter, _, _ := initAdapter()
-	testCmp := testComponent()
-	testCmp.ID = "test-id"
+	kcAdapter, mockClient, _ := initAdapter()
+	cmp := testGoCloakComponent()
+	params := gocloak.GetComponentsParams{Name: gocloak.StringP("test-name")}
 
-	httpmock.RegisterResponder("GET", "/admin/realms/realm-name/components",
-		httpmock.NewJsonResponderOrPanic(200, []Component{*testCmp}))
-	httpmock.RegisterResponder("DELETE", "/admin/realms/realm-name/components/test-id",
-		httpmock.NewStringResponder(200, ""))
+	mockClient.On("GetComponentsWithParams", "realm-name", params).Return([]*gocloak.Component{&cmp}, nil)
+	mockClient.On("DeleteComponent", "realm-name", "test-id").Return(nil)
 
-	err := kcAdapter.DeleteComponent(context.Background(), "realm-name", testCmp.Name)
+	err := kcAdapter.DeleteComponent(context.Background(), "realm-name", "test-name")
 	require.NoError(t, err)
 
-	httpmock.RegisterResponder("GET", "/admin/realms/realm-name-no-components/components",
-		httpmock.NewJsonResponderOrPanic(200, []Component{}))
+	mockClient.On("GetComponentsWithParams", "realm-name-no-components", params).
+		Return([]*gocloak.Component{}, nil)
 
-	err = kcAdapter.DeleteComponent(context.Background(), "realm-name-no-components", testCmp.Name)
+	err = kcAdapter.DeleteComponent(context.Background(), "realm-name-no-components", "test-name")
 	require.Error(t, err)
+	require.EqualError(t, err, "unable to get component id: component not found")
 
-	if err.Error() != "unable to get component id: component not found" {
-		t.Fatalf("wrong error returned: %s", err.Error())
-	}
-
-	httpmock.RegisterResponder("GET", "/admin/realms/realm-name-delete-failure/components",
-		httpmock.NewJsonResponderOrPanic(200, []Component{*testCmp}))
-	httpmock.RegisterResponder("DELETE", "/admin/realms/realm-name-delete-failure/components/test-id",
-		httpmock.NewStringResponder(404, "delete not found"))
+	mockClient.On("GetComponentsWithParams", "realm-name-delete-failure", params).
+		Return([]*gocloak.Component{&cmp}, nil)
+	mockClient.On("DeleteComponent", "realm-name-delete-failure", "test-id").
+		Return(&gocloak.APIError{Code: http.StatusNotFound, Message: "404 Not Found"})
 
-	err = kcAdapter.DeleteComponent(context.Background(), "realm-name-delete-failure", testCmp.Name)
+	err = kcAdapter.DeleteComponent(context.Background(), "realm-name-delete-failure", "test-name")
 	require.Error(t, err)
+	require.EqualError(t, err, "error during delete component request: 404 Not Found")
+}
 
-	if err.Error() != "error during delete component request: status: 404, body: delete not found" {
-		t.Fatalf("wrong error returned: %s", err.Error())
-	}
+func TestGoCloakAdapter_GetComponent(t *testing.T) {
+	kcAdapter, mockClient, _ := initAdapter()
+	cmp := testGoCloakComponent()
+	params := gocloak.GetComponentsParams{Name: gocloak.StringP("test-name")}
+
+	mockClient.On("GetComponentsWithParams", "realm-name", params).Return([]*gocloak.Component{&cmp}, nil)
+
+	component, err := kcAdapter.GetComponent(context.Background(), "realm-name", "test-name")
+	require.NoError(t, err)
+
+	expected := testComponent()
+	expected.ID = "test-id"
+	require.Equal(t, expected, component)
 }
 
 func TestGoCloakAdapter_GetComponent_Failure(t *testing.T) {
-	kcAdapter, _, _ := initAdapter()
+	kcAdapter, mockClient, _ := initAdapter()
 
-	httpmock.RegisterResponder("GET", "/admin/realms/realm-name/components",
-		httpmock.NewStringResponder(422, "forbidden"))
+	mockClient.On("GetComponentsWithParams", "realm-name",
+		gocloak.GetComponentsParams{Name: gocloak.StringP("test-name")}).
+		Return(nil, &gocloak.APIError{Code: http.StatusForbidden, Message: "403 Forbidden"})
 
 	_, err := kcAdapter.GetComponent(context.Background(), "realm-name", "test-name")
 	require.Error(t, err)
+	require.EqualError(t, err, "error during get component request: 403 Forbidden")
+}
+
+func TestGoCloakAdapter_SyncUserStorage(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder("POST", "/admin/realms/realm-name/user-storage/comp-id/sync?action=triggerFullSync",
+		httpmock.NewJsonResponderOrPanic(200, UserStorageSyncResult{Added: 2, Updated: 1, Status: "2 imported users, 1 updated users"}))
+
+	res, err := kcAdapter.SyncUserStorage(context.Background(), "realm-name", "comp-id", UserStorageFullSync)
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Added)
+	require.Equal(t, 1, res.Updated)
+
+	httpmock.RegisterResponder("POST", "/admin/realms/realm-name/user-storage/comp-id/sync?action=triggerChangedUsersSync",
+		httpmock.NewStringResponder(500, "fatal"))
+
+	_, err = kcAdapter.SyncUserStorage(context.Background(), "realm-name", "comp-id", UserStorageChangedUsersSync)
+	require.Error(t, err)
 
-	if err.Error() != "error during get component request: status: 422, body: forbidden" {
+	if err.Error() != "error during user storage sync request: status: 500, body: fatal" {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
+
+func TestGoCloakAdapter_GetComponents(t *testing.T) {
+	kcAdapter, mockClient, _ := initAdapter()
+	cmp := testGoCloakComponent()
+	other := testGoCloakComponent()
+	other.ProviderType = gocloak.StringP("other-provider-type")
+
+	mockClient.On("GetComponents", "realm-name").Return([]*gocloak.Component{&cmp, &other}, nil)
+
+	components, err := kcAdapter.GetComponents(context.Background(), "realm-name", "test-provider-type")
+	require.NoError(t, err)
+	require.Len(t, components, 1)
+	require.Equal(t, "test-name", components[0].Name)
+	require.Equal(t, "test-id", components[0].ID)
+
+	mockClient.On("GetComponents", "realm-name-error").
+		Return(nil, &gocloak.APIError{Code: http.StatusInternalServerError, Message: "500 Internal Server Error"})
+
+	_, err = kcAdapter.GetComponents(context.Background(), "realm-name-error", "test-provider-type")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error during get components request")
+}
+
+func TestGoCloakAdapter_DeleteComponentByID(t *testing.T) {
+	kcAdapter, mockClient, _ := initAdapter()
+
+	mockClient.On("DeleteComponent", "realm-name", "comp-id").Return(nil)
+
+	require.NoError(t, kcAdapter.DeleteComponentByID(context.Background(), "realm-name", "comp-id"))
+}
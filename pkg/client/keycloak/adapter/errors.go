@@ -0,0 +1,136 @@
+package adapter
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+// The kinds of the errors returned by the adapter methods. The kind of the error is checked with errors.Is
+// or with the IsErr functions, which also recognize the errors of the gocloak client.
+var (
+	// ErrNotFound means that the keycloak resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict means that the keycloak resource already exists or is changed concurrently.
+	ErrConflict = errors.New("conflict")
+	// ErrUnauthorized means that keycloak rejects the token or the token is expired.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited means that keycloak or the reverse proxy in front of it limits the rate of the requests.
+	ErrRateLimited = errors.New("rate limited")
+)
+
+type NotFoundError string
+
+func (e NotFoundError) Error() string {
+	return string(e)
+}
+
+func (e NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+type DuplicatedError string
+
+func (e DuplicatedError) Error() string {
+	return string(e)
+}
+
+func (e DuplicatedError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+type TokenExpiredError string
+
+func (e TokenExpiredError) Error() string {
+	return string(e)
+}
+
+func (e TokenExpiredError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
+
+// StatusError is the error response of the keycloak API.
+type StatusError struct {
+	Code   int
+	Status string
+	Body   string
+}
+
+func newStatusError(rsp *resty.Response) *StatusError {
+	return &StatusError{Code: rsp.StatusCode(), Status: rsp.Status(), Body: rsp.String()}
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status: %s, body: %s", e.Status, e.Body)
+}
+
+func (e *StatusError) Is(target error) bool {
+	return target == statusKind(e.Code)
+}
+
+// statusKind returns the kind of the error response with the status code.
+func statusKind(code int) error {
+	switch code {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// errorKind returns the kind of the error or nil if the error has no kind.
+func errorKind(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, kind := range []error{ErrNotFound, ErrConflict, ErrUnauthorized, ErrRateLimited} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+
+	var apiErr *gocloak.APIError
+	if errors.As(err, &apiErr) {
+		return statusKind(apiErr.Code)
+	}
+
+	return nil
+}
+
+func IsErrNotFound(err error) bool {
+	return errorKind(err) == ErrNotFound
+}
+
+// IsErrDuplicated checks whether the resource already exists, it is the same as IsErrConflict.
+func IsErrDuplicated(err error) bool {
+	return IsErrConflict(err)
+}
+
+func IsErrConflict(err error) bool {
+	return errorKind(err) == ErrConflict
+}
+
+func IsErrUnauthorized(err error) bool {
+	return errorKind(err) == ErrUnauthorized
+}
+
+func IsErrRateLimited(err error) bool {
+	return errorKind(err) == ErrRateLimited
+}
+
+// IsErrTokenExpired checks whether the stored token can not be used and the operator must log in again.
+func IsErrTokenExpired(err error) bool {
+	errTokenExpired := TokenExpiredError("")
+
+	return errors.As(err, &errTokenExpired)
+}
@@ -0,0 +1,66 @@
+package adapter
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/jarcoal/httpmock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorKind(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+		// gocloak errors have the kind, but they are not matched by errors.Is
+		gocloak bool
+	}{
+		{name: "not found error", err: errors.Wrap(NotFoundError("user not found"), "wrapped"), kind: ErrNotFound},
+		{name: "duplicated error", err: DuplicatedError("role is duplicated"), kind: ErrConflict},
+		{name: "token expired error", err: TokenExpiredError("token is expired"), kind: ErrUnauthorized},
+		{name: "gocloak not found", err: errors.Wrap(&gocloak.APIError{Code: http.StatusNotFound}, "wrapped"),
+			kind: ErrNotFound, gocloak: true},
+		{name: "gocloak conflict", err: &gocloak.APIError{Code: http.StatusConflict}, kind: ErrConflict,
+			gocloak: true},
+		{name: "status unauthorized", err: &StatusError{Code: http.StatusUnauthorized}, kind: ErrUnauthorized},
+		{name: "status rate limited", err: errors.WithStack(&StatusError{Code: http.StatusTooManyRequests}),
+			kind: ErrRateLimited},
+		{name: "missing identity provider redirector", err: func() error {
+			_, err := getIdPRedirector(nil)
+			return err
+		}(), kind: ErrNotFound},
+		{name: "status server error", err: &StatusError{Code: http.StatusInternalServerError}},
+		{name: "untyped error", err: errors.New("404")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.kind, errorKind(tt.err))
+
+			if tt.kind != nil && !tt.gocloak {
+				assert.True(t, errors.Is(tt.err, tt.kind))
+			}
+		})
+	}
+
+	assert.True(t, IsErrDuplicated(&gocloak.APIError{Code: http.StatusConflict}))
+	assert.False(t, IsErrTokenExpired(&StatusError{Code: http.StatusUnauthorized}))
+	assert.Nil(t, errorKind(nil))
+}
+
+func TestGoCloakAdapter_checkError(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/test", httpmock.NewStringResponder(429, "slow down"))
+
+	rsp, err := a.startRestyRequest().Get("/admin/realms/test")
+	err = a.checkError(err, rsp)
+	require.Error(t, err)
+	assert.True(t, IsErrRateLimited(err))
+	assert.Contains(t, err.Error(), "slow down")
+}
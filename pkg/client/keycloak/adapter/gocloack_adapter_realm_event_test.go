@@ -36,3 +36,66 @@ func TestGoCloakAdapter_SetRealmEventConfig(t *testing.T) {
 		&RealmEventConfig{EventsListeners: []string{"foo", "bar"}})
 	require.NoError(t, err)
 }
+
+func TestGoCloakAdapter_SetRealmEventConfig_AdminEventsExpiration(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+	mockClient.On("RestyClient").Return(restyClient)
+
+	adapter := GoCloakAdapter{
+		client:   mockClient,
+		basePath: "",
+		token:    &gocloak.JWT{AccessToken: "token"},
+	}
+
+	httpmock.RegisterResponder("PUT", "/admin/realms/r1/events/config",
+		httpmock.NewStringResponder(200, ""))
+
+	mockClient.On("GetRealm", "token", "r1").Return(&gocloak.RealmRepresentation{
+		Realm: gocloak.StringP("r1"),
+	}, nil).Once()
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		Realm:      gocloak.StringP("r1"),
+		Attributes: &map[string]string{"adminEventsExpiration": "3600"},
+	}).Return(nil).Once()
+
+	err := adapter.SetRealmEventConfig("r1", &RealmEventConfig{
+		AdminEventsEnabled:    true,
+		AdminEventsExpiration: gocloak.IntP(3600),
+	})
+	require.NoError(t, err)
+
+	// the realm is not updated if the expiration is not changed.
+	mockClient.On("GetRealm", "token", "r1").Return(&gocloak.RealmRepresentation{
+		Realm:      gocloak.StringP("r1"),
+		Attributes: &map[string]string{"adminEventsExpiration": "3600"},
+	}, nil).Once()
+
+	err = adapter.SetRealmEventConfig("r1", &RealmEventConfig{
+		AdminEventsEnabled:    true,
+		AdminEventsExpiration: gocloak.IntP(3600),
+	})
+	require.NoError(t, err)
+
+	// 0 removes the expiration.
+	mockClient.On("GetRealm", "token", "r1").Return(&gocloak.RealmRepresentation{
+		Realm:      gocloak.StringP("r1"),
+		Attributes: &map[string]string{"adminEventsExpiration": "3600", "foo": "bar"},
+	}, nil).Once()
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		Realm:      gocloak.StringP("r1"),
+		Attributes: &map[string]string{"foo": "bar"},
+	}).Return(nil).Once()
+
+	err = adapter.SetRealmEventConfig("r1", &RealmEventConfig{
+		AdminEventsEnabled:    true,
+		AdminEventsExpiration: gocloak.IntP(0),
+	})
+	require.NoError(t, err)
+
+	// the realm is not read if the expiration is not managed.
+	err = adapter.SetRealmEventConfig("r1", &RealmEventConfig{AdminEventsEnabled: true})
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
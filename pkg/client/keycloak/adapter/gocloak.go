@@ -1,3 +1,7 @@
+// Package adapter implements the keycloak client of the operator.
+//
+// The adapter calls keycloak with the gocloak client where gocloak covers the endpoint and keeps the fields
+// the operator sets. The rest of the admin API is called with the resty client of gocloak.
 package adapter
 
 import (
@@ -17,6 +21,10 @@ type GoCloak interface {
 	GoCloakClientRoles
 	GoCloakRealmRoles
 	GoCloakGroups
+	GoCloakComponents
+	GoCloakRequiredActions
+	GoCloakAuthFlows
+	GoCloakIdentityProviders
 }
 
 type GoCloakRealms interface {
@@ -39,9 +47,21 @@ type GoCloakClients interface {
 	DeleteClientProtocolMapper(ctx context.Context, token, realm, clientID, mapperID string) error
 	GetClientServiceAccount(ctx context.Context, token, realm, clientID string) (*gocloak.User, error)
 	DeleteClientScope(ctx context.Context, accessToken, realm, scopeID string) error
+	DeleteClientScopeProtocolMapper(ctx context.Context, token, realm, scopeID, protocolMapperID string) error
 	GetClientScope(ctx context.Context, token, realm, scopeID string) (*gocloak.ClientScope, error)
 	GetClientsDefaultScopes(ctx context.Context, token, realm, clientID string) ([]*gocloak.ClientScope, error)
 	AddDefaultScopeToClient(ctx context.Context, token, realm, clientID, scopeID string) error
+	RemoveDefaultScopeFromClient(ctx context.Context, token, realm, clientID, scopeID string) error
+	GetClientsOptionalScopes(ctx context.Context, token, realm, clientID string) ([]*gocloak.ClientScope, error)
+	AddOptionalScopeToClient(ctx context.Context, token, realm, clientID, scopeID string) error
+	RemoveOptionalScopeFromClient(ctx context.Context, token, realm, clientID, scopeID string) error
+	GetClientScopeMappings(ctx context.Context, token, realm, idOfClient string) (*gocloak.MappingsRepresentation, error)
+	CreateClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string, roles []gocloak.Role) error
+	DeleteClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string, roles []gocloak.Role) error
+	CreateClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient, idOfSelectedClient string,
+		roles []gocloak.Role) error
+	DeleteClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient, idOfSelectedClient string,
+		roles []gocloak.Role) error
 }
 
 type GoCloakUsers interface {
@@ -50,16 +70,31 @@ type GoCloakUsers interface {
 	GetRoleMappingByUserID(ctx context.Context, accessToken, realm,
 		userID string) (*gocloak.MappingsRepresentation, error)
 	UpdateUser(ctx context.Context, accessToken, realm string, user gocloak.User) error
+	ExecuteActionsEmail(ctx context.Context, token, realm string, params gocloak.ExecuteActionsEmail) error
+	GetCredentials(ctx context.Context, token, realm, userID string) ([]*gocloak.CredentialRepresentation, error)
+	DeleteCredentials(ctx context.Context, token, realm, userID, credentialID string) error
+	DeleteUser(ctx context.Context, token, realm, userID string) error
+	SetPassword(ctx context.Context, token, userID, realm, password string, temporary bool) error
+	GetRealmRolesByUserID(ctx context.Context, token, realm, userID string) ([]*gocloak.Role, error)
+	GetUserGroups(ctx context.Context, token, realm, userID string, params gocloak.GetGroupsParams) ([]*gocloak.Group, error)
+	AddUserToGroup(ctx context.Context, token, realm, userID, groupID string) error
+	DeleteUserFromGroup(ctx context.Context, token, realm, userID, groupID string) error
 }
 
 type GoCloakClientRoles interface {
 	GetClientRoles(ctx context.Context, accessToken, realm, clientID string, params gocloak.GetRoleParams) ([]*gocloak.Role, error)
 	CreateClientRole(ctx context.Context, accessToken, realm, clientID string, role gocloak.Role) (string, error)
 	GetClientRole(ctx context.Context, token, realm, clientID, roleName string) (*gocloak.Role, error)
+	UpdateRole(ctx context.Context, token, realm, clientID string, role gocloak.Role) error
+	DeleteClientRole(ctx context.Context, token, realm, clientID, roleName string) error
+	AddClientRoleComposite(ctx context.Context, token, realm, roleID string, roles []gocloak.Role) error
+	DeleteClientRoleComposite(ctx context.Context, token, realm, roleID string, roles []gocloak.Role) error
 	AddClientRoleToUser(ctx context.Context, token, realm, clientID, userID string, roles []gocloak.Role) error
 	DeleteClientRoleFromUser(ctx context.Context, token, realm, clientID, userID string, roles []gocloak.Role) error
 	AddClientRoleToGroup(ctx context.Context, token, realm, clientID, groupID string, roles []gocloak.Role) error
 	DeleteClientRoleFromGroup(ctx context.Context, token, realm, clientID, groupID string, roles []gocloak.Role) error
+	GetUsersByClientRoleName(ctx context.Context, token, realm, idOfClient, roleName string,
+		params gocloak.GetUsersByRoleParams) ([]*gocloak.User, error)
 }
 
 type GoCloakRealmRoles interface {
@@ -70,10 +105,11 @@ type GoCloakRealmRoles interface {
 	DeleteRealmRole(ctx context.Context, token, realm, roleName string) error
 	AddRealmRoleComposite(ctx context.Context, token, realm, roleName string, roles []gocloak.Role) error
 	DeleteRealmRoleComposite(ctx context.Context, token, realm, roleName string, roles []gocloak.Role) error
-	GetCompositeRealmRolesByRoleID(ctx context.Context, token, realm, roleID string) ([]*gocloak.Role, error)
+	GetCompositeRolesByRoleID(ctx context.Context, token, realm, roleID string) ([]*gocloak.Role, error)
 	DeleteRealmRoleFromUser(ctx context.Context, token, realm, userID string, roles []gocloak.Role) error
 	AddRealmRoleToGroup(ctx context.Context, token, realm, groupID string, roles []gocloak.Role) error
 	DeleteRealmRoleFromGroup(ctx context.Context, token, realm, groupID string, roles []gocloak.Role) error
+	GetRealmRoles(ctx context.Context, token, realm string, params gocloak.GetRoleParams) ([]*gocloak.Role, error)
 }
 
 type GoCloakGroups interface {
@@ -82,6 +118,43 @@ type GoCloakGroups interface {
 	UpdateGroup(ctx context.Context, accessToken, realm string, updatedGroup gocloak.Group) error
 	DeleteGroup(ctx context.Context, accessToken, realm, groupID string) error
 	GetGroups(ctx context.Context, accessToken, realm string, params gocloak.GetGroupsParams) ([]*gocloak.Group, error)
+	GetGroup(ctx context.Context, token, realm, groupID string) (*gocloak.Group, error)
 	GetRoleMappingByGroupID(ctx context.Context, accessToken, realm,
 		groupID string) (*gocloak.MappingsRepresentation, error)
 }
+
+type GoCloakComponents interface {
+	CreateComponent(ctx context.Context, token, realm string, component gocloak.Component) (string, error)
+	GetComponents(ctx context.Context, token, realm string) ([]*gocloak.Component, error)
+	GetComponentsWithParams(ctx context.Context, token, realm string,
+		params gocloak.GetComponentsParams) ([]*gocloak.Component, error)
+	UpdateComponent(ctx context.Context, token, realm string, component gocloak.Component) error
+	DeleteComponent(ctx context.Context, token, realm, componentID string) error
+}
+
+type GoCloakRequiredActions interface {
+	GetRequiredAction(ctx context.Context, token, realm, alias string) (*gocloak.RequiredActionProviderRepresentation, error)
+	RegisterRequiredAction(ctx context.Context, token, realm string,
+		requiredAction gocloak.RequiredActionProviderRepresentation) error
+}
+
+type GoCloakAuthFlows interface {
+	GetAuthenticationFlows(ctx context.Context, token, realm string) ([]*gocloak.AuthenticationFlowRepresentation, error)
+	DeleteAuthenticationFlow(ctx context.Context, token, realm, flowID string) error
+	GetAuthenticationExecutions(ctx context.Context, token, realm,
+		flow string) ([]*gocloak.ModifyAuthenticationExecutionRepresentation, error)
+	UpdateAuthenticationExecution(ctx context.Context, token, realm, flow string,
+		execution gocloak.ModifyAuthenticationExecutionRepresentation) error
+	DeleteAuthenticationExecution(ctx context.Context, token, realm, executionID string) error
+}
+
+type GoCloakIdentityProviders interface {
+	DeleteIdentityProvider(ctx context.Context, token, realm, alias string) error
+	ImportIdentityProviderConfig(ctx context.Context, token, realm, fromURL, providerID string) (map[string]string, error)
+	CreateIdentityProviderMapper(ctx context.Context, token, realm, alias string,
+		mapper gocloak.IdentityProviderMapper) (string, error)
+	UpdateIdentityProviderMapper(ctx context.Context, token, realm, alias string,
+		mapper gocloak.IdentityProviderMapper) error
+	DeleteIdentityProviderMapper(ctx context.Context, token, realm, alias, mapperID string) error
+	GetIdentityProviderMappers(ctx context.Context, token, realm, alias string) ([]*gocloak.IdentityProviderMapper, error)
+}
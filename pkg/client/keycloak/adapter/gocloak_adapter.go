@@ -22,7 +22,6 @@ import (
 
 const (
 	idPResource                     = "/admin/realms/{realm}/identity-provider/instances"
-	idPMapperResource               = "/admin/realms/{realm}/identity-provider/instances/{alias}/mappers"
 	getOneIdP                       = idPResource + "/{alias}"
 	openIdConfig                    = "/realms/{realm}/.well-known/openid-configuration"
 	authExecutions                  = "/admin/realms/{realm}/authentication/flows/browser/executions"
@@ -32,36 +31,68 @@ const (
 	postClientScope                 = "/admin/realms/{realm}/client-scopes"
 	putClientScope                  = "/admin/realms/{realm}/client-scopes/{id}"
 	getClientProtocolMappers        = "/admin/realms/{realm}/clients/{id}/protocol-mappers/models"
-	mapperToIdentityProvider        = "/admin/realms/{realm}/identity-provider/instances/{alias}/mappers"
-	updateMapperToIdentityProvider  = "/admin/realms/{realm}/identity-provider/instances/{alias}/mappers/{id}"
 	authFlows                       = "/admin/realms/{realm}/authentication/flows"
-	authFlow                        = "/admin/realms/{realm}/authentication/flows/{id}"
 	authFlowExecutionCreate         = "/admin/realms/{realm}/authentication/executions"
-	authFlowExecutionGetUpdate      = "/admin/realms/{realm}/authentication/flows/{alias}/executions"
-	authFlowExecutionDelete         = "/admin/realms/{realm}/authentication/executions/{id}"
 	raiseExecutionPriority          = "/admin/realms/{realm}/authentication/executions/{id}/raise-priority"
 	lowerExecutionPriority          = "/admin/realms/{realm}/authentication/executions/{id}/lower-priority"
 	authFlowExecutionConfig         = "/admin/realms/{realm}/authentication/executions/{id}/config"
-	deleteClientScopeProtocolMapper = "/admin/realms/{realm}/client-scopes/{clientScopeID}/protocol-mappers/models/{protocolMapperID}"
+	authenticatorConfig             = "/admin/realms/{realm}/authentication/config/{id}"
 	createClientScopeProtocolMapper = "/admin/realms/{realm}/client-scopes/{clientScopeID}/protocol-mappers/models"
 	putDefaultClientScope           = "/admin/realms/{realm}/default-default-client-scopes/{clientScopeID}"
 	deleteDefaultClientScope        = "/admin/realms/{realm}/default-default-client-scopes/{clientScopeID}"
 	getDefaultClientScopes          = "/admin/realms/{realm}/default-default-client-scopes"
+	putOptionalClientScope          = "/admin/realms/{realm}/default-optional-client-scopes/{clientScopeID}"
+	deleteOptionalClientScope       = "/admin/realms/{realm}/default-optional-client-scopes/{clientScopeID}"
+	getOptionalClientScopes         = "/admin/realms/{realm}/default-optional-client-scopes"
 	realmEventConfigPut             = "/admin/realms/{realm}/events/config"
-	realmComponent                  = "/admin/realms/{realm}/components"
-	realmComponentEntity            = "/admin/realms/{realm}/components/{id}"
+	userStorageSync                 = "/admin/realms/{realm}/user-storage/{id}/sync"
+	authzResourceServer             = "/admin/realms/{realm}/clients/{id}/authz/resource-server"
+	authzSettingsImport             = "/admin/realms/{realm}/clients/{id}/authz/resource-server/import"
+	authzScopes                     = "/admin/realms/{realm}/clients/{id}/authz/resource-server/scope"
+	authzScope                      = "/admin/realms/{realm}/clients/{id}/authz/resource-server/scope/{entityId}"
+	authzResources                  = "/admin/realms/{realm}/clients/{id}/authz/resource-server/resource"
+	authzResource                   = "/admin/realms/{realm}/clients/{id}/authz/resource-server/resource/{entityId}"
+	authzPolicies                   = "/admin/realms/{realm}/clients/{id}/authz/resource-server/policy"
+	authzPolicy                     = "/admin/realms/{realm}/clients/{id}/authz/resource-server/policy/{entityId}"
+	authzPolicyType                 = "/admin/realms/{realm}/clients/{id}/authz/resource-server/policy/{type}"
+	authzPolicyTypeEntity           = "/admin/realms/{realm}/clients/{id}/authz/resource-server/policy/{type}/{entityId}"
+	authzPermissions                = "/admin/realms/{realm}/clients/{id}/authz/resource-server/permission"
+	authzPermission                 = "/admin/realms/{realm}/clients/{id}/authz/resource-server/permission/{entityId}"
+	authzPermissionType             = "/admin/realms/{realm}/clients/{id}/authz/resource-server/permission/{type}"
+	authzPermissionTypeEntity       = "/admin/realms/{realm}/clients/{id}/authz/resource-server/permission/{type}/{entityId}"
 	identityProviderEntity          = "/admin/realms/{realm}/identity-provider/instances/{alias}"
 	identityProviderCreateList      = "/admin/realms/{realm}/identity-provider/instances"
-	idpMapperCreateList             = "/admin/realms/{realm}/identity-provider/instances/{alias}/mappers"
-	idpMapperEntity                 = "/admin/realms/{realm}/identity-provider/instances/{alias}/mappers/{id}"
 	deleteRealmUser                 = "/admin/realms/{realm}/users/{id}"
-	setRealmUserPassword            = "/admin/realms/{realm}/users/{id}/reset-password"
-	getUserRealmRoleMappings        = "/admin/realms/{realm}/users/{id}/role-mappings/realm"
-	getUserGroupMappings            = "/admin/realms/{realm}/users/{id}/groups"
-	manageUserGroups                = "/admin/realms/{realm}/users/{userID}/groups/{groupID}"
+	serverInfoGet                   = "/admin/serverinfo"
+	realmUserProfile                = "/admin/realms/{realm}/users/profile"
+	realmClientProfiles             = "/admin/realms/{realm}/client-policies/profiles"
+	realmClientPolicies             = "/admin/realms/{realm}/client-policies/policies"
+	realmLocalization               = "/admin/realms/{realm}/localization/{locale}"
+	realmLocalizationText           = "/admin/realms/{realm}/localization/{locale}/{key}"
+	realmClients                    = "/admin/realms/{realm}/clients"
+	realmClientEntity               = "/admin/realms/{realm}/clients/{id}"
+	organizations                   = "/admin/realms/{realm}/organizations"
+	organizationEntity              = "/admin/realms/{realm}/organizations/{id}"
+	organizationIdentityProviders   = "/admin/realms/{realm}/organizations/{id}/identity-providers"
+	organizationIdentityProvider    = "/admin/realms/{realm}/organizations/{id}/identity-providers/{alias}"
+	organizationMembers             = "/admin/realms/{realm}/organizations/{id}/members"
+	organizationMemberEntity        = "/admin/realms/{realm}/organizations/{id}/members/{userID}"
+	requiredActionEntity            = "/admin/realms/{realm}/authentication/required-actions/{alias}"
+	realmPartialImport              = "/admin/realms/{realm}/partialImport"
+	realmPartialExport              = "/admin/realms/{realm}/partial-export"
+	realmGroupEntity                = "/admin/realms/{realm}/groups/{id}"
+	realmRoleByID                   = "/admin/realms/{realm}/roles-by-id/{id}"
+	realmRoleUsers                  = "/admin/realms/{realm}/roles/{role}/users"
+	realmRoleGroups                 = "/admin/realms/{realm}/roles/{role}/groups"
+	clientRoleGroups                = "/admin/realms/{realm}/clients/{id}/roles/{role}/groups"
 	logClientDTO                    = "client dto"
 )
 
+const (
+	redirectConfigAlias           = "edp-sso"
+	redirectConfigDefaultProvider = "defaultProvider"
+)
+
 const (
 	keycloakApiParamId            = "id"
 	keycloakApiParamRole          = "role"
@@ -75,27 +106,19 @@ const (
 	logKeyRealm = "realm"
 )
 
-type TokenExpiredError string
-
-func (e TokenExpiredError) Error() string {
-	return string(e)
-}
-
-func IsErrTokenExpired(err error) bool {
-	errTokenExpired := TokenExpiredError("")
-
-	return errors.As(err, &errTokenExpired)
-}
-
 type GoCloakAdapter struct {
 	client   GoCloak
 	token    *gocloak.JWT
 	log      logr.Logger
 	basePath string
+	// pageSize is the number of the items requested at once by the list operations, DefaultPageSize is used if it is not set.
+	pageSize int
 }
 
 type JWTPayload struct {
 	Exp int64 `json:"exp"`
+	// AuthorizedParty is a client id the token is issued to.
+	AuthorizedParty string `json:"azp,omitempty"`
 }
 
 func (a *GoCloakAdapter) GetGoCloak() GoCloak {
@@ -103,16 +126,108 @@ func (a *GoCloakAdapter) GetGoCloak() GoCloak {
 }
 
 func MakeFromToken(url string, tokenData []byte, log logr.Logger) (*GoCloakAdapter, error) {
+	return MakeFromTokenWithClient(url, tokenData, log, nil)
+}
+
+// MakeFromTokenWithClient makes the adapter from the token which sends the requests with the resty client,
+// the default client is used if it is nil.
+func MakeFromTokenWithClient(url string, tokenData []byte, log logr.Logger,
+	restyClient *resty.Client) (*GoCloakAdapter, error) {
 	kcCl := gocloak.NewClient(url)
 
+	if restyClient != nil {
+		kcCl.SetRestyClient(restyClient)
+	}
+
+	var token gocloak.JWT
+	if err := json.Unmarshal(tokenData, &token); err != nil {
+		return nil, errors.Wrapf(err, "unable decode json data")
+	}
+
+	tokenPayload, err := decodeJWTPayload(token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenPayload.Exp < time.Now().Add(tokenExpirySkew).Unix() {
+		return nil, TokenExpiredError("token is expired")
+	}
+
+	return &GoCloakAdapter{
+		client:   kcCl,
+		token:    &token,
+		log:      log,
+		basePath: url,
+	}, nil
+}
+
+// tokenExpirySkew is a time before the expiration of the access token when it is considered expired,
+// so the token is refreshed before it expires in the middle of the reconciliation.
+const tokenExpirySkew = 30 * time.Second
+
+// MakeFromRefreshToken makes the adapter with the access token issued by the refresh token of the previous one
+// in the realm, so the operator does not log in again while the keycloak session is active.
+// The TokenExpiredError is returned if the token has no refresh token, it is expired or keycloak rejects it.
+func MakeFromRefreshToken(ctx context.Context, url string, tokenData []byte, realm string, log logr.Logger,
+	restyClient *resty.Client) (*GoCloakAdapter, error) {
 	var token gocloak.JWT
 	if err := json.Unmarshal(tokenData, &token); err != nil {
 		return nil, errors.Wrapf(err, "unable decode json data")
 	}
 
+	if token.RefreshToken == "" {
+		return nil, TokenExpiredError("token is expired and has no refresh token")
+	}
+
+	accessPayload, err := decodeJWTPayload(token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshPayload, err := decodeJWTPayload(token.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// the offline refresh tokens have no expiration
+	if refreshPayload.Exp != 0 && refreshPayload.Exp < time.Now().Add(tokenExpirySkew).Unix() {
+		return nil, TokenExpiredError("refresh token is expired")
+	}
+
+	kcCl := gocloak.NewClient(url)
+
+	if restyClient == nil {
+		restyClient = newRestyClient()
+	}
+
+	kcCl.SetRestyClient(restyClient)
+
+	tok, err := kcCl.GetToken(ctx, realm, gocloak.TokenOptions{
+		ClientID:     gocloak.StringP(accessPayload.AuthorizedParty),
+		GrantType:    gocloak.StringP("refresh_token"),
+		RefreshToken: gocloak.StringP(token.RefreshToken),
+	})
+	if err != nil {
+		var apiErr *gocloak.APIError
+		if errors.As(err, &apiErr) && (apiErr.Code == http.StatusBadRequest || apiErr.Code == http.StatusUnauthorized) {
+			return nil, TokenExpiredError(fmt.Sprintf("refresh token is rejected: %s", apiErr.Message))
+		}
+
+		return nil, errors.Wrap(err, "unable to refresh token")
+	}
+
+	return &GoCloakAdapter{
+		client:   kcCl,
+		token:    tok,
+		log:      log,
+		basePath: url,
+	}, nil
+}
+
+func decodeJWTPayload(token string) (*JWTPayload, error) {
 	const requiredTokenParts = 3
 
-	tokenParts := strings.Split(token.AccessToken, ".")
+	tokenParts := strings.Split(token, ".")
 
 	if len(tokenParts) < requiredTokenParts {
 		return nil, errors.New("wrong JWT token structure")
@@ -123,35 +238,60 @@ func MakeFromToken(url string, tokenData []byte, log logr.Logger) (*GoCloakAdapt
 		return nil, errors.Wrap(err, "wrong JWT token base64 encoding")
 	}
 
-	var tokenPayloadDecoded JWTPayload
-	if err := json.Unmarshal(tokenPayload, &tokenPayloadDecoded); err != nil {
+	var payload JWTPayload
+	if err := json.Unmarshal(tokenPayload, &payload); err != nil {
 		return nil, errors.Wrap(err, "unable to decode JWT payload json")
 	}
 
-	if tokenPayloadDecoded.Exp < time.Now().Unix() {
-		return nil, TokenExpiredError("token is expired")
+	return &payload, nil
+}
+
+func MakeFromServiceAccount(ctx context.Context, url, clientID, clientSecret, realm string, log logr.Logger, restyClient *resty.Client) (*GoCloakAdapter, error) {
+	kcCl := gocloak.NewClient(url)
+
+	if restyClient == nil {
+		restyClient = newRestyClient()
+	}
+
+	kcCl.SetRestyClient(restyClient)
+
+	tok, err := kcCl.LoginClient(ctx, clientID, clientSecret, realm)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to login with client creds, clientID: %s, realm: %s", clientID,
+			realm)
 	}
 
 	return &GoCloakAdapter{
 		client:   kcCl,
-		token:    &token,
+		token:    tok,
 		log:      log,
 		basePath: url,
 	}, nil
 }
 
-func MakeFromServiceAccount(ctx context.Context, url, clientID, clientSecret, realm string, log logr.Logger, restyClient *resty.Client) (*GoCloakAdapter, error) {
+// clientAssertionTypeJWTBearer is a type of the client assertion signed by the client or the trusted issuer.
+const clientAssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// MakeFromServiceAccountToken logs in with the client_credentials grant authenticating the client
+// with the JWT assertion, e.g. the Kubernetes ServiceAccount token, instead of the client secret.
+func MakeFromServiceAccountToken(ctx context.Context, url, clientID, assertion, realm string, log logr.Logger,
+	restyClient *resty.Client) (*GoCloakAdapter, error) {
 	kcCl := gocloak.NewClient(url)
 
 	if restyClient == nil {
-		restyClient = resty.New()
+		restyClient = newRestyClient()
 	}
 
 	kcCl.SetRestyClient(restyClient)
 
-	tok, err := kcCl.LoginClient(ctx, clientID, clientSecret, realm)
+	tok, err := kcCl.GetToken(ctx, realm, gocloak.TokenOptions{
+		ClientID:            gocloak.StringP(clientID),
+		GrantType:           gocloak.StringP("client_credentials"),
+		ClientAssertionType: gocloak.StringP(clientAssertionTypeJWTBearer),
+		ClientAssertion:     gocloak.StringP(assertion),
+	})
 	if err != nil {
-		return nil, errors.Wrapf(err, "unable to login with client creds, clientID: %s, realm: %s", clientID,
+		return nil, errors.Wrapf(err, "unable to login with service account token, clientID: %s, realm: %s", clientID,
 			realm)
 	}
 
@@ -164,15 +304,22 @@ func MakeFromServiceAccount(ctx context.Context, url, clientID, clientSecret, re
 }
 
 func Make(ctx context.Context, url, user, password string, log logr.Logger, restyClient *resty.Client) (*GoCloakAdapter, error) {
+	return MakeInRealm(ctx, url, user, password, "master", log, restyClient)
+}
+
+// MakeInRealm logs in with the admin user of the realm, the user can manage only this realm
+// unless the realm is master.
+func MakeInRealm(ctx context.Context, url, user, password, realm string, log logr.Logger,
+	restyClient *resty.Client) (*GoCloakAdapter, error) {
 	kcCl := gocloak.NewClient(url)
 
 	if restyClient == nil {
-		restyClient = resty.New()
+		restyClient = newRestyClient()
 	}
 
 	kcCl.SetRestyClient(restyClient)
 
-	token, err := kcCl.LoginAdmin(ctx, user, password, "master")
+	token, err := kcCl.LoginAdmin(ctx, user, password, realm)
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot login to keycloak server with user: %s", user)
 	}
@@ -215,7 +362,7 @@ func (a GoCloakAdapter) ExistCentralIdentityProvider(realm *dto.Realm) (bool, er
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return false, errors.Errorf("errors in get idP, response: %s", resp.String())
+		return false, errors.Wrap(newStatusError(resp), "errors in get idP")
 	}
 
 	log.Info("End check central identity provider in realm")
@@ -244,7 +391,7 @@ func (a GoCloakAdapter) CreateCentralIdentityProvider(realm *dto.Realm, client *
 
 	if resp.StatusCode() != http.StatusCreated {
 		log.Info("requested url", "url", resp.Request.URL)
-		return fmt.Errorf("error in create IdP, responce status: %s", resp.Status())
+		return errors.Wrap(newStatusError(resp), "error in create IdP")
 	}
 
 	if !realm.DisableCentralIDPMappers {
@@ -302,23 +449,11 @@ func (a GoCloakAdapter) CreateCentralIdPMappers(realm *dto.Realm, client *dto.Cl
 }
 
 func (a GoCloakAdapter) createIdPMapper(realm *dto.Realm, externalRole string, role string) error {
-	body := getIdPMapper(externalRole, role, realm.SsoRealmName)
+	mapper := getIdPMapper(externalRole, role, realm.SsoRealmName)
 
-	resp, err := a.client.RestyClient().R().
-		SetAuthToken(a.token.AccessToken).
-		SetHeader(contentTypeHeader, contentTypeJson).
-		SetPathParams(map[string]string{
-			keycloakApiParamRealm: realm.Name,
-			keycloakApiParamAlias: realm.SsoRealmName,
-		}).
-		SetBody(body).
-		Post(a.basePath + idPMapperResource)
-	if err != nil {
-		return fmt.Errorf("request create idp mapper failed: %w", err)
-	}
-
-	if resp.StatusCode() != http.StatusCreated {
-		return fmt.Errorf("error in creation idP mapper by name %s", body.Name)
+	if _, err := a.client.CreateIdentityProviderMapper(context.Background(), a.token.AccessToken, realm.Name,
+		realm.SsoRealmName, mapper.toGoCloak()); err != nil {
+		return errors.Wrapf(err, "error in creation idP mapper by name %s", mapper.Name)
 	}
 
 	return nil
@@ -328,7 +463,7 @@ func (a GoCloakAdapter) ExistClient(clientID, realm string) (bool, error) {
 	log := a.log.WithValues("clientID", clientID, logKeyRealm, realm)
 	log.Info("Start check client in Keycloak...")
 
-	clns, err := a.client.GetClients(context.Background(), a.token.AccessToken, realm, gocloak.GetClientsParams{
+	clns, err := a.getClients(context.Background(), realm, gocloak.GetClientsParams{
 		ClientID: &clientID,
 	})
 
@@ -453,7 +588,11 @@ func (a GoCloakAdapter) UpdateClient(ctx context.Context, client *dto.Client) er
 	log := a.log.WithValues(logClientDTO, client)
 	log.Info("Start update client in Keycloak...")
 
-	if err := a.client.UpdateClient(ctx, a.token.AccessToken, client.RealmName, getGclCln(client)); err != nil {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: client.RealmName,
+		keycloakApiParamId:    client.ID,
+	}).SetBody(getClientRepresentation(client)).Put(a.basePath + realmClientEntity)
+	if err = a.checkError(err, rsp); err != nil {
 		return fmt.Errorf("unable to update keycloak client: %w", err)
 	}
 
@@ -466,8 +605,10 @@ func (a GoCloakAdapter) CreateClient(ctx context.Context, client *dto.Client) er
 	log := a.log.WithValues(logClientDTO, client)
 	log.Info("Start create client in Keycloak...")
 
-	_, err := a.client.CreateClient(ctx, a.token.AccessToken, client.RealmName, getGclCln(client))
-	if err != nil {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: client.RealmName,
+	}).SetBody(getClientRepresentation(client)).Post(a.basePath + realmClients)
+	if err = a.checkError(err, rsp); err != nil {
 		return fmt.Errorf("failed to create keycloak client: %w", err)
 	}
 
@@ -476,34 +617,92 @@ func (a GoCloakAdapter) CreateClient(ctx context.Context, client *dto.Client) er
 	return nil
 }
 
-func getGclCln(client *dto.Client) gocloak.Client {
-	//TODO: check collision with protocol mappers list in spec
-	protocolMappers := getProtocolMappers(client.AdvancedProtocolMappers)
+// clientRepresentation extends the gocloak client with the fields which are not supported by gocloak.
+type clientRepresentation struct {
+	gocloak.Client
+	AlwaysDisplayInConsole *bool `json:"alwaysDisplayInConsole,omitempty"`
+}
+
+// getClientRepresentation converts the client, optional fields which are not set are omitted,
+// so keycloak keeps their current values on update.
+func getClientRepresentation(client *dto.Client) clientRepresentation {
+	return clientRepresentation{
+		Client:                 getGclCln(client),
+		AlwaysDisplayInConsole: client.AlwaysDisplayInConsole,
+	}
+}
 
+func getGclCln(client *dto.Client) gocloak.Client {
 	cl := gocloak.Client{
 		ClientID:                  &client.ClientId,
 		Secret:                    &client.ClientSecret,
-		PublicClient:              &client.Public,
-		DirectAccessGrantsEnabled: &client.DirectAccess,
-		RootURL:                   &client.WebUrl,
+		PublicClient:              client.Public,
+		DirectAccessGrantsEnabled: client.DirectAccess,
 		Protocol:                  &client.Protocol,
 		Attributes:                &client.Attributes,
-		RedirectURIs: &[]string{
-			client.WebUrl + "/*",
-		},
-		WebOrigins: &[]string{
-			client.WebUrl,
-		},
-		AdminURL:               &client.WebUrl,
-		ProtocolMappers:        &protocolMappers,
-		ServiceAccountsEnabled: &client.ServiceAccountEnabled,
-		FrontChannelLogout:     &client.FrontChannelLogout,
+		ServiceAccountsEnabled:    client.ServiceAccountEnabled,
+		FrontChannelLogout:        client.FrontChannelLogout,
+		FullScopeAllowed:          client.FullScopeAllowed,
+		Name:                      client.Name,
+		Description:               client.Description,
+		Enabled:                   client.Enabled,
+		BaseURL:                   client.BaseURL,
+		ConsentRequired:           client.ConsentRequired,
+		StandardFlowEnabled:       client.StandardFlowEnabled,
+		ImplicitFlowEnabled:       client.ImplicitFlowEnabled,
+		BearerOnly:                client.BearerOnly,
+		SurrogateAuthRequired:     client.SurrogateAuthRequired,
+		NodeReRegistrationTimeout: client.NodeReRegistrationTimeout,
+	}
+
+	if client.WebUrl != "" {
+		cl.RootURL = &client.WebUrl
+		cl.AdminURL = &client.WebUrl
+		cl.RedirectURIs = &[]string{client.WebUrl + "/*"}
+		cl.WebOrigins = &[]string{client.WebUrl}
+	}
+
+	if client.AdvancedProtocolMappers {
+		//TODO: check collision with protocol mappers list in spec
+		protocolMappers := getProtocolMappers(client.AdvancedProtocolMappers)
+		cl.ProtocolMappers = &protocolMappers
+	}
+
+	if client.AdminURL != nil {
+		cl.AdminURL = client.AdminURL
 	}
 
 	if client.ID != "" {
 		cl.ID = &client.ID
 	}
 
+	if client.AuthorizationEnabled {
+		cl.AuthorizationServicesEnabled = gocloak.BoolP(true)
+	}
+
+	if client.RedirectURIs != nil {
+		cl.RedirectURIs = &client.RedirectURIs
+	}
+
+	if client.WebOrigins != nil {
+		cl.WebOrigins = &client.WebOrigins
+	}
+
+	if client.ClientAuthenticatorType != "" {
+		cl.ClientAuthenticatorType = &client.ClientAuthenticatorType
+	}
+
+	if client.ClientAuthenticatorType == dto.SignedJWTClientAuthenticator {
+		// Clients which use signed JWT are authenticated with keys instead of the secret.
+		cl.Secret = nil
+	}
+
+	if client.Protocol == dto.SAMLClientProtocol {
+		// SAML clients do not use secrets and openid-connect protocol mappers.
+		cl.Secret = nil
+		cl.ProtocolMappers = nil
+	}
+
 	return cl
 }
 
@@ -543,7 +742,7 @@ func getProtocolMappers(need bool) []gocloak.ProtocolMapperRepresentation {
 }
 
 func (a GoCloakAdapter) GetClientID(clientID, realm string) (string, error) {
-	clients, err := a.client.GetClients(context.Background(), a.token.AccessToken, realm,
+	clients, err := a.getClients(context.Background(), realm,
 		gocloak.GetClientsParams{
 			ClientID: &clientID,
 		})
@@ -560,8 +759,8 @@ func (a GoCloakAdapter) GetClientID(clientID, realm string) (string, error) {
 	return "", NotFoundError(fmt.Sprintf("unable to get Client ID. Client %v doesn't exist", clientID))
 }
 
-func getIdPMapper(externalRole, role, ssoRealmName string) api.IdentityProviderMapperRepresentation {
-	return api.IdentityProviderMapperRepresentation{
+func getIdPMapper(externalRole, role, ssoRealmName string) IdentityProviderMapper {
+	return IdentityProviderMapper{
 		Config: map[string]string{
 			"external.role":      externalRole,
 			keycloakApiParamRole: role,
@@ -596,7 +795,7 @@ func (a GoCloakAdapter) ExistRealmUser(realmName string, user *dto.User) (bool,
 	log := a.log.WithValues(logKeyUser, user, logKeyRealm, realmName)
 	log.Info("Start check user in Keycloak realm...")
 
-	usr, err := a.client.GetUsers(context.Background(), a.token.AccessToken, realmName, gocloak.GetUsersParams{
+	usr, err := a.getUsers(context.Background(), realmName, gocloak.GetUsersParams{
 		Username: &user.Username,
 	})
 
@@ -613,7 +812,7 @@ func (a GoCloakAdapter) ExistRealmUser(realmName string, user *dto.User) (bool,
 }
 
 func (a GoCloakAdapter) DeleteRealmUser(ctx context.Context, realmName, username string) error {
-	usrs, err := a.client.GetUsers(ctx, a.token.AccessToken, realmName, gocloak.GetUsersParams{
+	usrs, err := a.getUsers(ctx, realmName, gocloak.GetUsersParams{
 		Username: &username,
 	})
 
@@ -626,12 +825,7 @@ func (a GoCloakAdapter) DeleteRealmUser(ctx context.Context, realmName, username
 		return NotFoundError("user not found")
 	}
 
-	rsp, err := a.startRestyRequest().SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		keycloakApiParamId:    *usr.ID,
-	}).Delete(a.basePath + deleteRealmUser)
-
-	if err = a.checkError(err, rsp); err != nil {
+	if err = a.client.DeleteUser(ctx, a.token.AccessToken, realmName, *usr.ID); err != nil {
 		return errors.Wrap(err, "unable to delete user")
 	}
 
@@ -642,7 +836,7 @@ func (a GoCloakAdapter) HasUserRealmRole(realmName string, user *dto.User, role
 	log := a.log.WithValues(keycloakApiParamRole, role, logKeyRealm, realmName, logKeyUser, user)
 	log.Info("Start check user roles in Keycloak realm...")
 
-	users, err := a.client.GetUsers(context.Background(), a.token.AccessToken, realmName, gocloak.GetUsersParams{
+	users, err := a.getUsers(context.Background(), realmName, gocloak.GetUsersParams{
 		Username: &user.Username,
 	})
 	if err != nil {
@@ -650,7 +844,7 @@ func (a GoCloakAdapter) HasUserRealmRole(realmName string, user *dto.User, role
 	}
 
 	if len(users) == 0 {
-		return false, fmt.Errorf("no such user %v has been found", user.Username)
+		return false, NotFoundError(fmt.Sprintf("no such user %v has been found", user.Username))
 	}
 
 	rolesMapping, err := a.client.GetRoleMappingByUserID(context.Background(), a.token.AccessToken, realmName,
@@ -670,7 +864,7 @@ func (a GoCloakAdapter) HasUserClientRole(realmName string, clientId string, use
 	log := a.log.WithValues(keycloakApiParamRole, role, "client", clientId, logKeyRealm, realmName, logKeyUser, user)
 	log.Info("Start check user roles in Keycloak realm...")
 
-	users, err := a.client.GetUsers(context.Background(), a.token.AccessToken, realmName, gocloak.GetUsersParams{
+	users, err := a.getUsers(context.Background(), realmName, gocloak.GetUsersParams{
 		Username: &user.Username,
 	})
 	if err != nil {
@@ -678,7 +872,7 @@ func (a GoCloakAdapter) HasUserClientRole(realmName string, clientId string, use
 	}
 
 	if len(users) == 0 {
-		return false, errors.Errorf("no such user %v has been found", user.Username)
+		return false, NotFoundError(fmt.Sprintf("no such user %v has been found", user.Username))
 	}
 
 	rolesMapping, err := a.client.GetRoleMappingByUserID(context.Background(), a.token.AccessToken, realmName,
@@ -698,7 +892,7 @@ func (a GoCloakAdapter) HasUserClientRole(realmName string, clientId string, use
 }
 
 func (a GoCloakAdapter) AddRealmRoleToUser(ctx context.Context, realmName, username, roleName string) error {
-	users, err := a.client.GetUsers(ctx, a.token.AccessToken, realmName, gocloak.GetUsersParams{
+	users, err := a.getUsers(ctx, realmName, gocloak.GetUsersParams{
 		Username: &username,
 	})
 	if err != nil {
@@ -706,7 +900,7 @@ func (a GoCloakAdapter) AddRealmRoleToUser(ctx context.Context, realmName, usern
 	}
 
 	if len(users) == 0 {
-		return errors.Errorf("no users with username %s found", username)
+		return NotFoundError(fmt.Sprintf("no users with username %s found", username))
 	}
 
 	rl, err := a.client.GetRealmRole(ctx, a.token.AccessToken, realmName, roleName)
@@ -728,7 +922,7 @@ func (a GoCloakAdapter) AddClientRoleToUser(realmName string, clientId string, u
 	log := a.log.WithValues(keycloakApiParamRole, roleName, logKeyRealm, realmName, "user", user.Username)
 	log.Info("Start mapping realm role to user in Keycloak...")
 
-	client, err := a.client.GetClients(context.Background(), a.token.AccessToken, realmName, gocloak.GetClientsParams{
+	client, err := a.getClients(context.Background(), realmName, gocloak.GetClientsParams{
 		ClientID: &clientId,
 	})
 	if err != nil {
@@ -736,7 +930,7 @@ func (a GoCloakAdapter) AddClientRoleToUser(realmName string, clientId string, u
 	}
 
 	if len(client) == 0 {
-		return fmt.Errorf("no such client %v has been found", clientId)
+		return NotFoundError(fmt.Sprintf("no such client %v has been found", clientId))
 	}
 
 	role, err := a.client.GetClientRole(context.Background(), a.token.AccessToken, realmName, *client[0].ID, roleName)
@@ -745,10 +939,10 @@ func (a GoCloakAdapter) AddClientRoleToUser(realmName string, clientId string, u
 	}
 
 	if role == nil {
-		return errors.Errorf("no such client role %v has been found", roleName)
+		return NotFoundError(fmt.Sprintf("no such client role %v has been found", roleName))
 	}
 
-	users, err := a.client.GetUsers(context.Background(), a.token.AccessToken, realmName, gocloak.GetUsersParams{
+	users, err := a.getUsers(context.Background(), realmName, gocloak.GetUsersParams{
 		Username: &user.Username,
 	})
 	if err != nil {
@@ -756,7 +950,7 @@ func (a GoCloakAdapter) AddClientRoleToUser(realmName string, clientId string, u
 	}
 
 	if len(users) == 0 {
-		return fmt.Errorf("no such user %v has been found", user.Username)
+		return NotFoundError(fmt.Sprintf("no such user %v has been found", user.Username))
 	}
 
 	err = a.addClientRoleToUser(realmName, *users[0].ID, []gocloak.Role{*role})
@@ -804,7 +998,7 @@ func strip404(in error) (bool, error) {
 }
 
 func is404(e error) bool {
-	return strings.Contains(e.Error(), "404")
+	return IsErrNotFound(e)
 }
 
 func (a GoCloakAdapter) CreateIncludedRealmRole(realmName string, role *dto.IncludedRealmRole) error {
@@ -902,12 +1096,12 @@ func (a GoCloakAdapter) PutDefaultIdp(realm *dto.Realm) error {
 	log := a.log.WithValues("realm dto", realm)
 	log.Info("Start put default IdP...")
 
-	eId, err := a.getIdPRedirectExecutionId(realm)
+	ex, err := a.getIdPRedirectExecution(realm)
 	if err != nil {
 		return err
 	}
 
-	err = a.createRedirectConfig(realm, *eId)
+	err = a.createRedirectConfig(realm, ex)
 	if err != nil {
 		return err
 	}
@@ -917,18 +1111,13 @@ func (a GoCloakAdapter) PutDefaultIdp(realm *dto.Realm) error {
 	return nil
 }
 
-func (a GoCloakAdapter) getIdPRedirectExecutionId(realm *dto.Realm) (*string, error) {
+func (a GoCloakAdapter) getIdPRedirectExecution(realm *dto.Realm) (*api.SimpleAuthExecution, error) {
 	exs, err := a.getBrowserExecutions(realm)
 	if err != nil {
 		return nil, err
 	}
 
-	ex, err := getIdPRedirector(exs)
-	if err != nil {
-		return nil, err
-	}
-
-	return &ex.Id, nil
+	return getIdPRedirector(exs)
 }
 
 func getIdPRedirector(executions []api.SimpleAuthExecution) (*api.SimpleAuthExecution, error) {
@@ -938,25 +1127,34 @@ func getIdPRedirector(executions []api.SimpleAuthExecution) (*api.SimpleAuthExec
 		}
 	}
 
-	return nil, errors.New("identity provider not found")
+	return nil, NotFoundError("identity provider not found")
 }
 
-func (a GoCloakAdapter) createRedirectConfig(realm *dto.Realm, eId string) error {
-	resp, err := a.startRestyRequest().SetPathParams(map[string]string{
-		keycloakApiParamRealm: realm.Name,
-		keycloakApiParamId:    eId,
-	}).SetBody(map[string]interface{}{
-		keycloakApiParamAlias: "edp-sso",
-		"config": map[string]string{
-			"defaultProvider": realm.SsoRealmName,
-		},
-	}).Post(a.basePath + authExecutionConfig)
+func (a GoCloakAdapter) createRedirectConfig(realm *dto.Realm, ex *api.SimpleAuthExecution) error {
+	eId := ex.Id
+
+	updated, err := a.updateRedirectConfig(realm, ex)
 	if err != nil {
-		return errors.Wrap(err, "error during resty request")
+		return err
 	}
 
-	if resp.StatusCode() != http.StatusCreated {
-		return errors.Errorf("response is not ok by create redirect config: Status: %v", resp.Status())
+	if !updated {
+		resp, err := a.startRestyRequest().SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm.Name,
+			keycloakApiParamId:    eId,
+		}).SetBody(map[string]interface{}{
+			keycloakApiParamAlias: redirectConfigAlias,
+			"config": map[string]string{
+				redirectConfigDefaultProvider: realm.SsoRealmName,
+			},
+		}).Post(a.basePath + authExecutionConfig)
+		if err != nil {
+			return errors.Wrap(err, "error during resty request")
+		}
+
+		if resp.StatusCode() != http.StatusCreated {
+			return errors.Wrap(newStatusError(resp), "response is not ok by create redirect config")
+		}
 	}
 
 	if !realm.SsoAutoRedirectEnabled {
@@ -970,13 +1168,47 @@ func (a GoCloakAdapter) createRedirectConfig(realm *dto.Realm, eId string) error
 		}
 
 		if resp.StatusCode() != http.StatusAccepted {
-			return errors.Errorf("response is not ok by create redirect config: Status: %v", resp.Status())
+			return errors.Wrap(newStatusError(resp), "response is not ok by create redirect config")
 		}
 	}
 
 	return nil
 }
 
+// updateRedirectConfig updates the existing config of the redirector if its default provider differs.
+// It returns false if the execution has no config and it should be created.
+func (a GoCloakAdapter) updateRedirectConfig(realm *dto.Realm, ex *api.SimpleAuthExecution) (bool, error) {
+	if ex.AuthenticationConfig == "" {
+		return false, nil
+	}
+
+	cfg, err := a.getAuthenticatorConfig(realm.Name, ex.AuthenticationConfig)
+	if err != nil {
+		if IsErrNotFound(err) {
+			return false, nil
+		}
+
+		return false, errors.Wrap(err, "unable to get redirect config")
+	}
+
+	if cfg.Config[redirectConfigDefaultProvider] == realm.SsoRealmName {
+		return true, nil
+	}
+
+	if cfg.Config == nil {
+		cfg.Config = make(map[string]string)
+	}
+
+	cfg.ID = ex.AuthenticationConfig
+	cfg.Config[redirectConfigDefaultProvider] = realm.SsoRealmName
+
+	if err := a.updateAuthenticatorConfig(realm.Name, cfg); err != nil {
+		return false, errors.Wrap(err, "unable to update redirect config")
+	}
+
+	return true, nil
+}
+
 func (a GoCloakAdapter) getBrowserExecutions(realm *dto.Realm) ([]api.SimpleAuthExecution, error) {
 	res := make([]api.SimpleAuthExecution, 0)
 
@@ -991,7 +1223,7 @@ func (a GoCloakAdapter) getBrowserExecutions(realm *dto.Realm) ([]api.SimpleAuth
 	}
 
 	if resp.StatusCode() != http.StatusOK {
-		return res, fmt.Errorf("response is not ok by get browser executions: Status: %v", resp.Status())
+		return res, errors.Wrap(newStatusError(resp), "response is not ok by get browser executions")
 	}
 
 	return res, nil
@@ -1124,7 +1356,7 @@ func (a GoCloakAdapter) GetClientProtocolMappers(client *dto.Client,
 	}
 
 	if resp.IsError() {
-		return nil, errors.New(resp.String())
+		return nil, errors.WithStack(newStatusError(resp))
 	}
 
 	return mappers, nil
@@ -1140,7 +1372,7 @@ func (a GoCloakAdapter) checkError(err error, response *resty.Response) error {
 	}
 
 	if response.IsError() {
-		return errors.Errorf("status: %s, body: %s", response.Status(), response.String())
+		return errors.WithStack(newStatusError(response))
 	}
 
 	return nil
@@ -2,9 +2,12 @@ package adapter
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"net/http"
+	"net/url"
 	"path"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -21,6 +24,7 @@ type KeycloakAuthFlow struct {
 	BuiltIn                  bool                      `json:"builtIn"`
 	ParentName               string                    `json:"-"`
 	ChildType                string                    `json:"-"`
+	FallbackFlow             string                    `json:"-"`
 	AuthenticationExecutions []AuthenticationExecution `json:"-"`
 }
 
@@ -42,30 +46,43 @@ type AuthenticationExecution struct {
 	Alias               string               `json:"-"`
 }
 
+// maxExecutionOrderAttempts is the number of attempts to move the executions to the declared order.
+const maxExecutionOrderAttempts = 3
+
+// ExecutionOrderDriftError is returned if the live order of the flow executions does not match the declared one.
+type ExecutionOrderDriftError string
+
+func (e ExecutionOrderDriftError) Error() string {
+	return string(e)
+}
+
+func IsErrExecutionOrderDrift(err error) bool {
+	errDrift := ExecutionOrderDriftError("")
+
+	return errors.As(err, &errDrift)
+}
+
 type FlowExecution struct {
-	AuthenticationFlow bool     `json:"authenticationFlow"`
-	Configurable       bool     `json:"configurable"`
-	Description        string   `json:"description"`
-	DisplayName        string   `json:"displayName"`
-	FlowID             string   `json:"flowId"`
-	ID                 string   `json:"id"`
-	Index              int      `json:"index"`
-	Level              int      `json:"level"`
-	Requirement        string   `json:"requirement"`
-	RequirementChoices []string `json:"requirementChoices"`
+	AuthenticationFlow   bool     `json:"authenticationFlow"`
+	Configurable         bool     `json:"configurable"`
+	Description          string   `json:"description"`
+	DisplayName          string   `json:"displayName"`
+	FlowID               string   `json:"flowId"`
+	ID                   string   `json:"id"`
+	Index                int      `json:"index"`
+	Level                int      `json:"level"`
+	Requirement          string   `json:"requirement"`
+	RequirementChoices   []string `json:"requirementChoices"`
+	ProviderID           string   `json:"providerId,omitempty"`
+	AuthenticationConfig string   `json:"authenticationConfig,omitempty"`
 }
 
 type AuthenticatorConfig struct {
+	ID     string            `json:"id,omitempty"`
 	Alias  string            `json:"alias"`
 	Config map[string]string `json:"config"`
 }
 
-type orderByPriority []AuthenticationExecution
-
-func (a orderByPriority) Len() int           { return len(a) }
-func (a orderByPriority) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a orderByPriority) Less(i, j int) bool { return a[i].Priority < a[j].Priority }
-
 func (a GoCloakAdapter) DeleteAuthFlow(realmName string, flow *KeycloakAuthFlow) error {
 	if flow.ParentName != "" {
 		execID, err := a.getFlowExecutionID(realmName, flow)
@@ -85,8 +102,8 @@ func (a GoCloakAdapter) DeleteAuthFlow(realmName string, flow *KeycloakAuthFlow)
 		return errors.Wrap(err, "unable to get auth flow")
 	}
 
-	if _, _, err := a.unsetBrowserFlow(realmName, flow.Alias); err != nil {
-		return errors.Wrapf(err, "unable to unset browser flow for realm: %s, alias: %s", realmName, flow.Alias)
+	if err := a.detachAuthFlowBindings(realmName, flowID, flow); err != nil {
+		return errors.Wrapf(err, "unable to detach bindings of auth flow for realm: %s, alias: %s", realmName, flow.Alias)
 	}
 
 	if err := a.deleteAuthFlow(realmName, flowID); err != nil {
@@ -96,52 +113,323 @@ func (a GoCloakAdapter) DeleteAuthFlow(realmName string, flow *KeycloakAuthFlow)
 	return nil
 }
 
+// SyncAuthFlow creates the auth flow or updates it in place. The executions are diffed with the declared ones,
+// so the ids of the flow and of the kept executions are stable and the flow bindings of the clients keep working.
 func (a GoCloakAdapter) SyncAuthFlow(realmName string, flow *KeycloakAuthFlow) error {
 	id, err := a.syncBaseAuthFlow(realmName, flow)
 	if err != nil {
 		return errors.Wrap(err, "unable to sync base auth flow")
 	}
 
-	sort.Sort(orderByPriority(flow.AuthenticationExecutions))
+	if err := a.syncFlowExecutions(realmName, id, flow); err != nil {
+		return errors.Wrap(err, "unable to sync auth flow executions")
+	}
+
+	return nil
+}
+
+// syncFlowExecutions adds, updates, removes and reorders the top level executions of the flow.
+// The executions are matched by the authenticator and the child flows by the alias.
+// Child flows are managed by their own resources, so they are only updated and reordered.
+func (a GoCloakAdapter) syncFlowExecutions(realmName, flowID string, flow *KeycloakAuthFlow) error {
+	declared := make([]AuthenticationExecution, len(flow.AuthenticationExecutions))
+	copy(declared, flow.AuthenticationExecutions)
+
+	sort.SliceStable(declared, func(i, j int) bool {
+		return declared[i].Priority < declared[j].Priority
+	})
+
+	current, err := a.getTopLevelFlowExecutions(realmName, flow.Alias)
+	if err != nil {
+		return err
+	}
+
+	matched, unmatched, err := matchFlowExecutions(declared, current)
+	if err != nil {
+		return err
+	}
+
+	for i := range unmatched {
+		if err := a.deleteFlowExecution(realmName, unmatched[i].ID); err != nil {
+			return errors.Wrapf(err, "unable to delete flow execution %s", unmatched[i].ProviderID)
+		}
+	}
+
+	order := make([]string, len(declared))
+
+	for i := range declared {
+		exec := matched[i]
+		if exec == nil {
+			declared[i].ParentFlow = flowID
+			if err := a.addAuthFlowExecution(realmName, &declared[i]); err != nil {
+				return errors.Wrap(err, "unable to add auth execution")
+			}
+
+			order[i] = declared[i].ID
+
+			continue
+		}
+
+		order[i] = exec.ID
+
+		if err := a.updateMatchedFlowExecution(realmName, flow.Alias, &declared[i], exec); err != nil {
+			return err
+		}
+	}
+
+	return a.orderFlowExecutions(realmName, flow.Alias, order)
+}
+
+// matchFlowExecutions pairs the declared executions with the current ones. It returns the current execution
+// of each declared one, nil if it should be added, and the current executions which are not declared.
+func matchFlowExecutions(declared []AuthenticationExecution,
+	current []FlowExecution) ([]*FlowExecution, []FlowExecution, error) {
+	matched := make([]*FlowExecution, len(declared))
+	used := make([]bool, len(current))
+
+	for i := range declared {
+		for j := range current {
+			if used[j] || !isSameExecution(&declared[i], &current[j]) {
+				continue
+			}
+
+			used[j] = true
+			matched[i] = &current[j]
+
+			break
+		}
+
+		if matched[i] == nil && declared[i].AutheticatorFlow {
+			return nil, nil, errors.Errorf("child flow %s is not created", declared[i].Alias)
+		}
+	}
+
+	unmatched := make([]FlowExecution, 0)
 
-	for _, e := range flow.AuthenticationExecutions {
-		if e.AutheticatorFlow {
+	for j := range current {
+		if used[j] {
 			continue
 		}
 
-		e.ParentFlow = id
-		if err := a.addAuthFlowExecution(realmName, &e); err != nil {
-			return errors.Wrap(err, "unable to add auth execution")
+		if current[j].AuthenticationFlow {
+			return nil, nil, errors.Errorf("unable to find child flow with name: %s", current[j].DisplayName)
 		}
+
+		unmatched = append(unmatched, current[j])
 	}
 
-	if err := a.adjustChildFlowsPriority(realmName, flow); err != nil {
-		return errors.Wrap(err, "unable to adjust child flow priority")
+	return matched, unmatched, nil
+}
+
+func isSameExecution(declared *AuthenticationExecution, current *FlowExecution) bool {
+	if declared.AutheticatorFlow {
+		return current.AuthenticationFlow && current.DisplayName == declared.Alias
 	}
 
-	return nil
+	return !current.AuthenticationFlow && current.ProviderID == declared.Authenticator
 }
 
-func (a GoCloakAdapter) adjustChildFlowsPriority(realmName string, flow *KeycloakAuthFlow) error {
-	childFlows := a.makeChildFlows(flow)
-	if len(childFlows) == 0 {
+// updateMatchedFlowExecution updates the requirement and the config of the existing execution.
+func (a GoCloakAdapter) updateMatchedFlowExecution(realmName, flowAlias string, declared *AuthenticationExecution,
+	current *FlowExecution) error {
+	if declared.Requirement != "" && declared.Requirement != current.Requirement {
+		if _, ok := makeStringSet(current.RequirementChoices)[declared.Requirement]; !ok &&
+			len(current.RequirementChoices) > 0 {
+			return errors.Errorf("requirement %s is not available for flow execution %s, available requirements: %s",
+				declared.Requirement, current.DisplayName, strings.Join(current.RequirementChoices, ", "))
+		}
+
+		current.Requirement = declared.Requirement
+		if err := a.updateFlowExecution(realmName, flowAlias, current); err != nil {
+			return errors.Wrap(err, "unable to update flow execution")
+		}
+	}
+
+	if declared.AutheticatorFlow {
 		return nil
 	}
 
-	flowExecs, err := a.getFlowExecutions(realmName, flow.Alias)
+	if err := a.syncFlowExecutionConfig(realmName, declared, current); err != nil {
+		return errors.Wrapf(err, "unable to sync config of flow execution %s", current.ProviderID)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) syncFlowExecutionConfig(realmName string, declared *AuthenticationExecution,
+	current *FlowExecution) error {
+	if current.AuthenticationConfig == "" {
+		if declared.AuthenticatorConfig == nil {
+			return nil
+		}
+
+		declared.ID = current.ID
+
+		return a.createAuthFlowExecutionConfig(realmName, declared)
+	}
+
+	if declared.AuthenticatorConfig == nil {
+		return a.deleteAuthenticatorConfig(realmName, current.AuthenticationConfig)
+	}
+
+	currentConfig, err := a.getAuthenticatorConfig(realmName, current.AuthenticationConfig)
 	if err != nil {
-		return errors.Wrap(err, "unable to get flow executions")
+		if !IsErrNotFound(err) {
+			return err
+		}
+
+		// the config is removed while the execution still refers to it, so it is recreated.
+		declared.ID = current.ID
+
+		return a.createAuthFlowExecutionConfig(realmName, declared)
 	}
 
-	for i := range flowExecs {
-		if err := a.adjustFlowExecutionPriority(realmName, flow.Alias, &flowExecs[i], len(flowExecs), childFlows); err != nil {
+	if isSameAuthenticatorConfig(currentConfig, declared.AuthenticatorConfig) {
+		return nil
+	}
+
+	// the config is sent back as it is returned by keycloak, so the fields which are not managed are kept.
+	currentConfig.ID = current.AuthenticationConfig
+	currentConfig.Alias = declared.AuthenticatorConfig.Alias
+	currentConfig.Config = declared.AuthenticatorConfig.Config
+
+	return a.updateAuthenticatorConfig(realmName, currentConfig)
+}
+
+// isSameAuthenticatorConfig compares the configs, nil and empty config values are considered equal.
+func isSameAuthenticatorConfig(current, declared *AuthenticatorConfig) bool {
+	if current.Alias != declared.Alias {
+		return false
+	}
+
+	if len(current.Config) == 0 && len(declared.Config) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(current.Config, declared.Config)
+}
+
+// orderFlowExecutions moves the top level executions until they are in the given order.
+// Keycloak may not apply the priority changes, e.g. if the executions have the same priority,
+// so the order is read back after the moves and they are repeated until the live order matches.
+func (a GoCloakAdapter) orderFlowExecutions(realmName, flowAlias string, order []string) error {
+	var live []string
+
+	for attempt := 0; ; attempt++ {
+		current, err := a.getTopLevelFlowExecutions(realmName, flowAlias)
+		if err != nil {
+			return err
+		}
+
+		live = liveExecutionsOrder(current, order)
+		if reflect.DeepEqual(live, order) {
+			return nil
+		}
+
+		if attempt == maxExecutionOrderAttempts {
+			return ExecutionOrderDriftError(fmt.Sprintf(
+				"executions order does not match after %d attempts, expected: %s, actual: %s",
+				attempt, strings.Join(executionLabels(current, order), ", "),
+				strings.Join(executionLabels(current, live), ", ")))
+		}
+
+		if err := a.moveFlowExecutions(realmName, live, order); err != nil {
 			return err
 		}
 	}
+}
+
+// moveFlowExecutions raises or lowers the priority of the executions to move them from the live order
+// to the given one.
+func (a GoCloakAdapter) moveFlowExecutions(realmName string, live, order []string) error {
+	ids := make([]string, len(live))
+	copy(ids, live)
+
+	for i, id := range order {
+		index := -1
+
+		for j := range ids {
+			if ids[j] == id {
+				index = j
+				break
+			}
+		}
+
+		if index < 0 {
+			return NotFoundError(fmt.Sprintf("flow execution %s is not found", id))
+		}
+
+		if index == i {
+			continue
+		}
+
+		if err := a.adjustExecutionPriority(realmName, id, index-i); err != nil {
+			return errors.Wrap(err, "unable to adjust flow execution priority")
+		}
+
+		ids = append(ids[:index], ids[index+1:]...)
+		ids = append(ids[:i], append([]string{id}, ids[i:]...)...)
+	}
 
 	return nil
 }
 
+// liveExecutionsOrder returns the ids of the current executions which are in the given order.
+func liveExecutionsOrder(current []FlowExecution, order []string) []string {
+	ordered := makeStringSet(order)
+	live := make([]string, 0, len(order))
+
+	for i := range current {
+		if _, ok := ordered[current[i].ID]; ok {
+			live = append(live, current[i].ID)
+		}
+	}
+
+	return live
+}
+
+// executionLabels returns the provider ids of the executions and the names of the child flows with the given ids.
+func executionLabels(current []FlowExecution, ids []string) []string {
+	labels := make(map[string]string, len(current))
+
+	for i := range current {
+		labels[current[i].ID] = current[i].ProviderID
+		if current[i].AuthenticationFlow {
+			labels[current[i].ID] = current[i].DisplayName
+		}
+	}
+
+	result := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		label, ok := labels[id]
+		if !ok || label == "" {
+			label = id
+		}
+
+		result = append(result, label)
+	}
+
+	return result
+}
+
+func (a GoCloakAdapter) getTopLevelFlowExecutions(realmName, flowAlias string) ([]FlowExecution, error) {
+	execs, err := a.getFlowExecutions(realmName, flowAlias)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get flow executions")
+	}
+
+	topLevel := make([]FlowExecution, 0, len(execs))
+
+	for i := range execs {
+		if execs[i].Level == 0 {
+			topLevel = append(topLevel, execs[i])
+		}
+	}
+
+	return topLevel, nil
+}
+
 func (a GoCloakAdapter) SetRealmBrowserFlow(realmName string, flowAlias string) error {
 	realm, err := a.client.GetRealm(context.Background(), a.token.AccessToken, realmName)
 	if err != nil {
@@ -156,6 +444,22 @@ func (a GoCloakAdapter) SetRealmBrowserFlow(realmName string, flowAlias string)
 	return nil
 }
 
+// AuthFlowExists checks if the top level auth flow with the given alias exists in the realm.
+func (a GoCloakAdapter) AuthFlowExists(realmName, flowAlias string) (bool, error) {
+	flows, err := a.getRealmAuthFlows(realmName)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range flows {
+		if flows[i].Alias == flowAlias {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (a GoCloakAdapter) syncBaseAuthFlow(realmName string, flow *KeycloakAuthFlow) (string, error) {
 	authFlowID, err := a.getAuthFlowID(realmName, flow)
 	if err != nil {
@@ -169,10 +473,6 @@ func (a GoCloakAdapter) syncBaseAuthFlow(realmName string, flow *KeycloakAuthFlo
 		}
 
 		authFlowID = id
-	} else {
-		if err := a.clearFlowExecutions(realmName, flow.Alias); err != nil {
-			return "", errors.Wrap(err, "unable to clear flow executions")
-		}
 	}
 
 	if err := a.validateChildFlowsCreated(realmName, flow); err != nil {
@@ -213,32 +513,9 @@ func (a GoCloakAdapter) validateChildFlowsCreated(realmName string, flow *Keyclo
 	return errors.New("not all child flows created")
 }
 
-func (a GoCloakAdapter) clearFlowExecutions(realmName, flowAlias string) error {
-	execs, err := a.getFlowExecutions(realmName, flowAlias)
-	if err != nil {
-		return errors.Wrap(err, "unable to get flow executions")
-	}
-
-	for i := range execs {
-		if execs[i].AuthenticationFlow || execs[i].Level > 0 {
-			continue
-		}
-
-		if err := a.deleteFlowExecution(realmName, execs[i].ID); err != nil {
-			return errors.Wrap(err, "unable to delete flow execution")
-		}
-	}
-
-	return nil
-}
-
 func (a GoCloakAdapter) deleteFlowExecution(realmName, id string) error {
-	rsp, err := a.startRestyRequest().SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		keycloakApiParamId:    id,
-	}).Delete(a.basePath + authFlowExecutionDelete)
-
-	if err = a.checkError(err, rsp); err != nil {
+	if err := a.client.DeleteAuthenticationExecution(context.Background(), a.token.AccessToken, realmName,
+		id); err != nil {
 		return errors.Wrap(err, "unable to delete flow execution")
 	}
 
@@ -291,19 +568,23 @@ func (a GoCloakAdapter) getAuthFlowID(realmName string, flow *KeycloakAuthFlow)
 }
 
 func (a GoCloakAdapter) getRealmAuthFlows(realmName string) ([]KeycloakAuthFlow, error) {
-	var flows []KeycloakAuthFlow
-
-	resp, err := a.startRestyRequest().
-		SetPathParams(map[string]string{
-			keycloakApiParamRealm: realmName,
-		}).
-		SetResult(&flows).
-		Get(a.basePath + authFlows)
-
-	if err = a.checkError(err, resp); err != nil {
+	gcFlows, err := a.client.GetAuthenticationFlows(context.Background(), a.token.AccessToken, realmName)
+	if err != nil {
 		return nil, errors.Wrap(err, "unable to list auth flow by realm")
 	}
 
+	flows := make([]KeycloakAuthFlow, 0, len(gcFlows))
+	for _, f := range gcFlows {
+		flows = append(flows, KeycloakAuthFlow{
+			ID:          gocloak.PString(f.ID),
+			Alias:       gocloak.PString(f.Alias),
+			Description: gocloak.PString(f.Description),
+			ProviderID:  gocloak.PString(f.ProviderID),
+			TopLevel:    gocloak.PBool(f.TopLevel),
+			BuiltIn:     gocloak.PBool(f.BuiltIn),
+		})
+	}
+
 	return flows, nil
 }
 
@@ -357,12 +638,8 @@ func (a GoCloakAdapter) createChildAuthFlow(realmName string, flow *KeycloakAuth
 }
 
 func (a GoCloakAdapter) updateFlowExecution(realmName, parentFlowAlias string, flowExec *FlowExecution) error {
-	rsp, err := a.startRestyRequest().SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		keycloakApiParamAlias: parentFlowAlias,
-	}).SetBody(flowExec).Put(a.basePath + authFlowExecutionGetUpdate)
-
-	if err = a.checkError(err, rsp); err != nil {
+	if err := a.client.UpdateAuthenticationExecution(context.Background(), a.token.AccessToken, realmName,
+		url.PathEscape(parentFlowAlias), flowExec.toGoCloak()); err != nil {
 		return errors.Wrap(err, "unable to update flow execution")
 	}
 
@@ -394,29 +671,64 @@ func (a GoCloakAdapter) adjustExecutionPriority(realmName, executionID string, d
 	return nil
 }
 
+// getFlowExecutions returns the executions of the flow, the flow alias is escaped,
+// because gocloak puts it into the path as is.
 func (a GoCloakAdapter) getFlowExecutions(realmName, flowAlias string) ([]FlowExecution, error) {
-	var execs []FlowExecution
-	rsp, err := a.startRestyRequest().SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		keycloakApiParamAlias: flowAlias,
-	}).SetResult(&execs).Get(a.basePath + authFlowExecutionGetUpdate)
-
-	if err = a.checkError(err, rsp); err != nil {
+	gcExecs, err := a.client.GetAuthenticationExecutions(context.Background(), a.token.AccessToken, realmName,
+		url.PathEscape(flowAlias))
+	if err != nil {
 		return nil, errors.Wrap(err, "unable get flow executions")
 	}
 
+	execs := make([]FlowExecution, 0, len(gcExecs))
+	for _, e := range gcExecs {
+		execs = append(execs, flowExecutionFromGoCloak(e))
+	}
+
 	return execs, nil
 }
 
-func (a GoCloakAdapter) deleteAuthFlow(realmName, id string) error {
-	resp, err := a.startRestyRequest().
-		SetPathParams(map[string]string{
-			keycloakApiParamRealm: realmName,
-			keycloakApiParamId:    id,
-		}).
-		Delete(a.basePath + authFlow)
+func (e *FlowExecution) toGoCloak() gocloak.ModifyAuthenticationExecutionRepresentation {
+	return gocloak.ModifyAuthenticationExecutionRepresentation{
+		ID:                   gocloak.StringP(e.ID),
+		ProviderID:           optionalString(e.ProviderID),
+		AuthenticationConfig: optionalString(e.AuthenticationConfig),
+		AuthenticationFlow:   gocloak.BoolP(e.AuthenticationFlow),
+		Requirement:          gocloak.StringP(e.Requirement),
+		FlowID:               gocloak.StringP(e.FlowID),
+		DisplayName:          gocloak.StringP(e.DisplayName),
+		RequirementChoices:   &e.RequirementChoices,
+		Configurable:         gocloak.BoolP(e.Configurable),
+		Level:                gocloak.IntP(e.Level),
+		Index:                gocloak.IntP(e.Index),
+		Description:          gocloak.StringP(e.Description),
+	}
+}
 
-	if err = a.checkError(err, resp); err != nil {
+func flowExecutionFromGoCloak(e *gocloak.ModifyAuthenticationExecutionRepresentation) FlowExecution {
+	exec := FlowExecution{
+		AuthenticationFlow:   gocloak.PBool(e.AuthenticationFlow),
+		Configurable:         gocloak.PBool(e.Configurable),
+		Description:          gocloak.PString(e.Description),
+		DisplayName:          gocloak.PString(e.DisplayName),
+		FlowID:               gocloak.PString(e.FlowID),
+		ID:                   gocloak.PString(e.ID),
+		Index:                gocloak.PInt(e.Index),
+		Level:                gocloak.PInt(e.Level),
+		Requirement:          gocloak.PString(e.Requirement),
+		ProviderID:           gocloak.PString(e.ProviderID),
+		AuthenticationConfig: gocloak.PString(e.AuthenticationConfig),
+	}
+
+	if e.RequirementChoices != nil {
+		exec.RequirementChoices = *e.RequirementChoices
+	}
+
+	return exec
+}
+
+func (a GoCloakAdapter) deleteAuthFlow(realmName, id string) error {
+	if err := a.client.DeleteAuthenticationFlow(context.Background(), a.token.AccessToken, realmName, id); err != nil {
 		return errors.Wrap(err, "unable to delete auth flow")
 	}
 
@@ -465,6 +777,51 @@ func (a GoCloakAdapter) createAuthFlowExecutionConfig(realmName string, flowExec
 	return nil
 }
 
+func (a GoCloakAdapter) getAuthenticatorConfig(realmName, id string) (*AuthenticatorConfig, error) {
+	var cfg AuthenticatorConfig
+
+	rsp, err := a.startRestyRequest().SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    id,
+	}).SetResult(&cfg).Get(a.basePath + authenticatorConfig)
+
+	if err = a.checkError(err, rsp); err != nil {
+		if rsp != nil && rsp.StatusCode() == http.StatusNotFound {
+			return nil, NotFoundError("authenticator config not found")
+		}
+
+		return nil, errors.Wrap(err, "unable to get authenticator config")
+	}
+
+	return &cfg, nil
+}
+
+func (a GoCloakAdapter) updateAuthenticatorConfig(realmName string, cfg *AuthenticatorConfig) error {
+	rsp, err := a.startRestyRequest().SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    cfg.ID,
+	}).SetBody(cfg).Put(a.basePath + authenticatorConfig)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to update authenticator config")
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) deleteAuthenticatorConfig(realmName, id string) error {
+	rsp, err := a.startRestyRequest().SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    id,
+	}).Delete(a.basePath + authenticatorConfig)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to delete authenticator config")
+	}
+
+	return nil
+}
+
 func getIDFromResponseLocation(response *http.Response) (string, error) {
 	location := response.Header.Get("Location")
 	if location == "" {
@@ -479,88 +836,186 @@ func getIDFromResponseLocation(response *http.Response) (string, error) {
 	return locationParts[len(locationParts)-1], nil
 }
 
-func (a GoCloakAdapter) unsetBrowserFlow(realmName, flowAlias string) (realm *gocloak.RealmRepresentation, isBrowserFlowUnset bool, err error) {
-	realm, err = a.client.GetRealm(context.Background(), a.token.AccessToken, realmName)
-	if err != nil {
-		return nil, false, errors.Wrapf(err, "unable to get realm: %s", realmName)
+// detachAuthFlowBindings moves the realm and the client bindings of the flow to the fallback flow,
+// the built-in flows are used for the realm bindings and the client overrides are removed if it is not set.
+func (a GoCloakAdapter) detachAuthFlowBindings(realmName, flowID string, flow *KeycloakAuthFlow) error {
+	var fallbackID string
+
+	if flow.FallbackFlow != "" {
+		if flow.FallbackFlow == flow.Alias {
+			return errors.Errorf("fallback flow can not be the deleted flow %s", flow.Alias)
+		}
+
+		flows, err := a.getRealmAuthFlows(realmName)
+		if err != nil {
+			return errors.Wrap(err, "unable to get realm auth flows")
+		}
+
+		for i := range flows {
+			if flows[i].Alias == flow.FallbackFlow {
+				fallbackID = flows[i].ID
+				break
+			}
+		}
+
+		if fallbackID == "" {
+			return NotFoundError(fmt.Sprintf("fallback flow %s does not exist", flow.FallbackFlow))
+		}
 	}
 
-	if realm.BrowserFlow == nil || *realm.BrowserFlow != flowAlias {
-		return realm, false, nil
+	if err := a.rebindRealmFlows(realmName, flow.Alias, flow.FallbackFlow); err != nil {
+		return err
 	}
 
-	authFlows, err := a.getRealmAuthFlows(realmName)
+	if err := a.rebindIdentityProviderFlows(realmName, flow.Alias, flow.FallbackFlow); err != nil {
+		return err
+	}
+
+	return a.rebindClientFlows(realmName, flowID, fallbackID)
+}
+
+func (a GoCloakAdapter) rebindRealmFlows(realmName, flowAlias, fallbackAlias string) error {
+	realm, err := a.client.GetRealm(context.Background(), a.token.AccessToken, realmName)
 	if err != nil {
-		return nil, false, errors.Wrapf(err, "unable to get auth flows for realm: %s", realmName)
+		return errors.Wrapf(err, "unable to get realm: %s", realmName)
 	}
 
-	var replaceFlow *KeycloakAuthFlow
+	bindings := []struct {
+		name    string
+		flow    **string
+		builtIn string
+	}{
+		{name: "browser", flow: &realm.BrowserFlow, builtIn: "browser"},
+		{name: "registration", flow: &realm.RegistrationFlow, builtIn: "registration"},
+		{name: "direct grant", flow: &realm.DirectGrantFlow, builtIn: "direct grant"},
+		{name: "reset credentials", flow: &realm.ResetCredentialsFlow, builtIn: "reset credentials"},
+		{name: "client authentication", flow: &realm.ClientAuthenticationFlow, builtIn: "clients"},
+		{name: "docker authentication", flow: &realm.DockerAuthenticationFlow, builtIn: "docker auth"},
+	}
 
-	for i := range authFlows {
-		if authFlows[i].Alias != flowAlias {
-			replaceFlow = &authFlows[i]
-			break
+	log := a.log.WithValues(logKeyRealm, realmName, "flow alias", flowAlias)
+	rebound := false
+
+	for _, b := range bindings {
+		if *b.flow == nil || **b.flow != flowAlias {
+			continue
+		}
+
+		alias := b.builtIn
+		if fallbackAlias != "" {
+			alias = fallbackAlias
 		}
+
+		*b.flow = gocloak.StringP(alias)
+		rebound = true
+
+		log.Info("Rebinding realm flow", "binding", b.name, "new flow alias", alias)
 	}
 
-	if replaceFlow == nil {
-		return nil, false,
-			errors.Errorf("unable to delete auth flow: %s, no replacement for browser flow found", flowAlias)
+	if !rebound {
+		return nil
 	}
 
-	realm.BrowserFlow = &replaceFlow.Alias
 	if err := a.client.UpdateRealm(context.Background(), a.token.AccessToken, *realm); err != nil {
-		return nil, false, errors.Wrapf(err, "unable to update realm: %s", realmName)
+		return errors.Wrapf(err, "unable to rebind flows of realm: %s", realmName)
 	}
 
-	return realm, true, nil
+	return nil
 }
 
-func (a GoCloakAdapter) makeChildFlows(flow *KeycloakAuthFlow) map[string]AuthenticationExecution {
-	childFlows := make(map[string]AuthenticationExecution)
+// rebindIdentityProviderFlows moves the first broker login and the post broker login flows of the identity
+// providers to the fallback flow. Without the fallback flow, the built-in first broker login flow is used
+// and the post broker login flow is cleared. The identity providers are updated as they are returned by keycloak,
+// so their other settings are kept.
+func (a GoCloakAdapter) rebindIdentityProviderFlows(realmName, flowAlias, fallbackAlias string) error {
+	var idps []map[string]interface{}
 
-	for i, authExec := range flow.AuthenticationExecutions {
-		if authExec.AutheticatorFlow {
-			childFlows[authExec.Alias] = flow.AuthenticationExecutions[i]
+	rsp, err := a.startRestyRequest().SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetResult(&idps).Get(a.basePath + identityProviderCreateList)
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrapf(err, "unable to get identity providers of realm: %s", realmName)
+	}
+
+	bindings := []struct {
+		key     string
+		builtIn string
+	}{
+		{key: "firstBrokerLoginFlowAlias", builtIn: "first broker login"},
+		{key: "postBrokerLoginFlowAlias", builtIn: ""},
+	}
+
+	for _, idp := range idps {
+		rebound := false
+
+		for _, b := range bindings {
+			if alias, _ := idp[b.key].(string); alias != flowAlias {
+				continue
+			}
+
+			idp[b.key] = b.builtIn
+			if fallbackAlias != "" {
+				idp[b.key] = fallbackAlias
+			}
+
+			rebound = true
+		}
+
+		if !rebound {
+			continue
+		}
+
+		alias, _ := idp["alias"].(string)
+
+		a.log.Info("Rebinding identity provider flows", logKeyRealm, realmName, "identity provider", alias)
+
+		rsp, err := a.startRestyRequest().SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			keycloakApiParamAlias: alias,
+		}).SetBody(idp).Put(a.basePath + identityProviderEntity)
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to rebind flows of identity provider: %s", alias)
 		}
 	}
 
-	return childFlows
+	return nil
 }
 
-func (a GoCloakAdapter) adjustFlowExecutionPriority(
-	realmName,
-	flowAlias string,
-	flowExec *FlowExecution,
-	flowExecsCount int,
-	childFlows map[string]AuthenticationExecution,
-) error {
-	if flowExec.AuthenticationFlow && flowExec.Level != 0 {
-		return nil
+func (a GoCloakAdapter) rebindClientFlows(realmName, flowID, fallbackID string) error {
+	clients, err := a.getClients(context.Background(), realmName,
+		gocloak.GetClientsParams{})
+	if err != nil {
+		return errors.Wrapf(err, "unable to get clients of realm: %s", realmName)
 	}
 
-	childFlow, ok := childFlows[flowExec.DisplayName]
-	if !ok {
-		return errors.Errorf("unable to find child flow with name: %s", flowExec.DisplayName)
-	}
+	for _, cl := range clients {
+		if cl.AuthenticationFlowBindingOverrides == nil {
+			continue
+		}
 
-	if childFlow.Requirement != flowExec.Requirement {
-		flowExec.Requirement = childFlow.Requirement
-		if err := a.updateFlowExecution(realmName, flowAlias, flowExec); err != nil {
-			return errors.Wrap(err, "unable to update flow execution")
+		overrides := *cl.AuthenticationFlowBindingOverrides
+		rebound := false
+
+		for binding, id := range overrides {
+			if id != flowID {
+				continue
+			}
+
+			// keycloak keeps the overrides missing in the update, the empty override removes the binding
+			overrides[binding] = fallbackID
+
+			rebound = true
 		}
-	}
 
-	if childFlow.Priority == flowExec.Index {
-		return nil
-	}
+		if !rebound {
+			continue
+		}
 
-	if childFlow.Priority < 0 || childFlow.Priority > flowExecsCount {
-		return errors.Errorf("wrong flow priority, flow name: %s, priority: %d", childFlow.Alias, childFlow.Priority)
-	}
+		a.log.Info("Rebinding client flow overrides", logKeyRealm, realmName, "client", gocloak.PString(cl.ClientID))
 
-	if err := a.adjustExecutionPriority(realmName, flowExec.ID, flowExec.Index-childFlow.Priority); err != nil {
-		return errors.Wrap(err, "unable to adjust flow priority")
+		if err := a.client.UpdateClient(context.Background(), a.token.AccessToken, realmName, *cl); err != nil {
+			return errors.Wrapf(err, "unable to rebind flow overrides of client: %s", gocloak.PString(cl.ClientID))
+		}
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package adapter
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,8 +14,11 @@ import (
 	"github.com/jarcoal/httpmock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
 )
 
 type ExecFlowTestSuite struct {
@@ -28,6 +32,7 @@ type ExecFlowTestSuite struct {
 func (e *ExecFlowTestSuite) SetupTest() {
 	e.restyClient = resty.New()
 	httpmock.ActivateNonDefault(e.restyClient.GetClient())
+	httpmock.Reset()
 
 	e.goCloakMockClient = new(MockGoCloakClient)
 	e.goCloakMockClient.On("RestyClient").Return(e.restyClient)
@@ -35,10 +40,40 @@ func (e *ExecFlowTestSuite) SetupTest() {
 	e.adapter = &GoCloakAdapter{
 		client: e.goCloakMockClient,
 		token:  &gocloak.JWT{AccessToken: "token"},
+		log:    mock.NewLogr(),
 	}
 	e.realmName = "realm123"
 }
 
+// onFlows mocks the realm auth flows.
+func (e *ExecFlowTestSuite) onFlows(realmName string, flows ...KeycloakAuthFlow) {
+	gcFlows := make([]*gocloak.AuthenticationFlowRepresentation, 0, len(flows))
+	for i := range flows {
+		gcFlows = append(gcFlows, &gocloak.AuthenticationFlowRepresentation{
+			ID:    gocloak.StringP(flows[i].ID),
+			Alias: gocloak.StringP(flows[i].Alias),
+		})
+	}
+
+	e.goCloakMockClient.On("GetAuthenticationFlows", realmName).Return(gcFlows, nil)
+}
+
+// onExecutions mocks the executions of the flow, the responses are returned one by one and the last one is repeated.
+func (e *ExecFlowTestSuite) onExecutions(flowAlias string, responses ...[]FlowExecution) {
+	for i, execs := range responses {
+		gcExecs := make([]*gocloak.ModifyAuthenticationExecutionRepresentation, 0, len(execs))
+		for j := range execs {
+			gcExec := execs[j].toGoCloak()
+			gcExecs = append(gcExecs, &gcExec)
+		}
+
+		call := e.goCloakMockClient.On("GetAuthenticationExecutions", e.realmName, flowAlias).Return(gcExecs, nil)
+		if i < len(responses)-1 {
+			call.Once()
+		}
+	}
+}
+
 func (e *ExecFlowTestSuite) TestCreateAuthFlowParent() {
 	var (
 		parentName = "parent-name"
@@ -65,95 +100,277 @@ func (e *ExecFlowTestSuite) TestSyncAuthFlow() {
 	flow := KeycloakAuthFlow{
 		Alias:       "alias1",
 		Description: "test description",
-		TopLevel:    false,
-		BuiltIn:     false,
-		ProviderID:  "generic",
+		ProviderID:  "basic-flow",
+		TopLevel:    true,
 		AuthenticationExecutions: []AuthenticationExecution{
 			{
-				Authenticator:    "basic-auth",
+				Authenticator: "basic-auth",
+				Priority:      4,
+				Requirement:   "DISABLED",
+			},
+			{
+				Authenticator: "cookie",
+				Priority:      2,
+				Requirement:   "DISABLED",
+				AuthenticatorConfig: &AuthenticatorConfig{
+					Alias:  "config-12",
+					Config: map[string]string{"bar": "3"},
+				},
+			},
+			{
+				AutheticatorFlow: true,
+				Alias:            "sub",
 				Priority:         3,
-				AutheticatorFlow: false,
-				Requirement:      "DISABLED",
+				Requirement:      "REQUIRED",
 			},
+		},
+	}
+
+	e.onFlows(e.realmName, KeycloakAuthFlow{Alias: flow.Alias, ID: "flow-id-1"})
+
+	current := []FlowExecution{
+		{ID: "e1", ProviderID: "basic-auth", Requirement: "ALTERNATIVE"},
+		{ID: "e2", AuthenticationFlow: true, DisplayName: "sub", Requirement: "CONDITIONAL"},
+		{ID: "e21", ProviderID: "auth-cookie", Level: 1},
+		{ID: "e3", ProviderID: "old-auth"},
+		{ID: "e4", ProviderID: "cookie", Requirement: "DISABLED", AuthenticationConfig: "cfg1"},
+	}
+	afterDelete := []FlowExecution{current[0], current[1], current[2], current[4]}
+	ordered := []FlowExecution{current[4], current[1], current[2], current[0]}
+
+	e.onExecutions("alias1", current, current, afterDelete, ordered)
+	e.goCloakMockClient.On("DeleteAuthenticationExecution", e.realmName, "e3").Return(nil)
+	e.goCloakMockClient.On("UpdateAuthenticationExecution", e.realmName, "alias1", testifyMock.Anything).Return(nil)
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm123/authentication/config/cfg1",
+		httpmock.NewJsonResponderOrPanic(200, AuthenticatorConfig{
+			Alias:  "config-12",
+			Config: map[string]string{"bar": "2"},
+		}))
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm123/authentication/config/cfg1",
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm123/authentication/executions/e4/raise-priority",
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm123/authentication/executions/e2/raise-priority",
+		httpmock.NewStringResponder(204, ""))
+
+	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
+	require.NoError(e.T(), err)
+
+	info := httpmock.GetCallCountInfo()
+	e.goCloakMockClient.AssertNumberOfCalls(e.T(), "DeleteAuthenticationExecution", 1)
+	e.goCloakMockClient.AssertNumberOfCalls(e.T(), "UpdateAuthenticationExecution", 2)
+	assert.Equal(e.T(), 1, info["PUT /admin/realms/realm123/authentication/config/cfg1"])
+	assert.Equal(e.T(), 2, info["POST /admin/realms/realm123/authentication/executions/e4/raise-priority"])
+	assert.Equal(e.T(), 1, info["POST /admin/realms/realm123/authentication/executions/e2/raise-priority"])
+	e.goCloakMockClient.AssertNotCalled(e.T(), "DeleteAuthenticationFlow", e.realmName, "flow-id-1")
+}
+
+func (e *ExecFlowTestSuite) TestSyncAuthFlow_AddExecution() {
+	flow := KeycloakAuthFlow{
+		Alias: "alias1",
+		AuthenticationExecutions: []AuthenticationExecution{
 			{
-				Authenticator:    "cookie",
-				Priority:         2,
-				AutheticatorFlow: false,
-				Requirement:      "DISABLED",
+				Authenticator: "auth-cookie",
+				Requirement:   "ALTERNATIVE",
 				AuthenticatorConfig: &AuthenticatorConfig{
-					Alias: "config-12",
-					Config: map[string]string{
-						"bar": "3",
-					},
+					Alias: "cookie-config",
 				},
 			},
 		},
 	}
 
-	existFlowID := "flow-id-1"
+	e.onFlows(e.realmName, KeycloakAuthFlow{Alias: flow.Alias, ID: "flow-id-1"})
+	e.onExecutions("alias1", []FlowExecution{}, []FlowExecution{{ID: "new-exec-id", ProviderID: "auth-cookie"}})
 
-	httpmock.RegisterResponder("GET", strings.ReplaceAll(authFlows, "{realm}", e.realmName),
-		httpmock.NewJsonResponderOrPanic(200, []KeycloakAuthFlow{{Alias: flow.Alias, ID: existFlowID},
-			{Alias: "some-another-flow", ID: "321"}}))
+	createExecResponse := httpmock.NewStringResponse(201, "")
+	defer closeWithFailOnError(e.T(), createExecResponse.Body)
+	createExecResponse.Header.Set("Location", "id/new-exec-id")
 
-	deleteURL := strings.ReplaceAll(authFlow, "{realm}", e.realmName)
-	deleteURL = strings.ReplaceAll(deleteURL, "{id}", existFlowID)
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm123/authentication/executions",
+		httpmock.ResponderFromResponse(createExecResponse))
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm123/authentication/executions/new-exec-id/config",
+		httpmock.NewStringResponder(201, ""))
 
-	httpmock.RegisterResponder("DELETE", deleteURL, httpmock.NewStringResponder(200, ""))
+	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
+	require.NoError(e.T(), err)
 
-	e.goCloakMockClient.On("GetRealm", "token", e.realmName).Return(&gocloak.RealmRepresentation{
-		BrowserFlow: gocloak.StringP("alias1"),
-	}, nil)
-	e.goCloakMockClient.On("UpdateRealm",
-		gocloak.RealmRepresentation{BrowserFlow: gocloak.StringP("some-another-flow")}).
-		Return(nil).Once()
-	e.goCloakMockClient.On("UpdateRealm",
-		gocloak.RealmRepresentation{BrowserFlow: gocloak.StringP("alias1")}).
-		Return(nil).Once()
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(e.T(), 1, info["POST /admin/realms/realm123/authentication/executions"])
+	assert.Equal(e.T(), 1, info["POST /admin/realms/realm123/authentication/executions/new-exec-id/config"])
+}
 
-	createFlowResponse := httpmock.NewStringResponse(200, "")
-	defer closeWithFailOnError(e.T(), createFlowResponse.Body)
-	createFlowResponse.Header.Set("Location", "id/new-flow-id")
+func (e *ExecFlowTestSuite) TestSyncAuthFlow_ConditionalSubFlow() {
+	flow := KeycloakAuthFlow{
+		Alias:      "cond-otp",
+		ParentName: "browser-forms",
+		AuthenticationExecutions: []AuthenticationExecution{
+			{
+				Authenticator: "conditional-user-configured",
+				Requirement:   "REQUIRED",
+				AuthenticatorConfig: &AuthenticatorConfig{
+					Alias: "user-configured",
+				},
+			},
+			{
+				Authenticator: "conditional-user-role",
+				Priority:      1,
+				Requirement:   "REQUIRED",
+				AuthenticatorConfig: &AuthenticatorConfig{
+					Alias:  "otp-role",
+					Config: map[string]string{"condUserRole": "otp-users", "negate": "false"},
+				},
+			},
+		},
+	}
 
-	httpmock.RegisterResponder("POST", strings.ReplaceAll(authFlows, "{realm}", e.realmName),
-		httpmock.ResponderFromResponse(createFlowResponse))
+	e.onExecutions("browser-forms", []FlowExecution{{DisplayName: "cond-otp", FlowID: "cond-otp-id"}})
 
-	createExecResponse := httpmock.NewStringResponse(200, "")
+	current := []FlowExecution{
+		{ID: "e1", ProviderID: "conditional-user-configured", Requirement: "REQUIRED", AuthenticationConfig: "cfg1"},
+		{ID: "e2", ProviderID: "conditional-user-role", Requirement: "REQUIRED", AuthenticationConfig: "cfg2"},
+	}
 
-	defer closeWithFailOnError(e.T(), createFlowResponse.Body)
+	e.onExecutions("cond-otp", current)
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm123/authentication/config/cfg1",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"id":     "cfg1",
+			"alias":  "user-configured",
+			"config": map[string]string{},
+		}))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm123/authentication/config/cfg2",
+		httpmock.NewJsonResponderOrPanic(200, AuthenticatorConfig{
+			ID:     "cfg2",
+			Alias:  "otp-role",
+			Config: map[string]string{"condUserRole": "admins", "negate": "false"},
+		}))
 
-	newExecID := "new-exec-id"
-	createExecResponse.Header.Set("Location", fmt.Sprintf("id/%s", newExecID))
-	httpmock.RegisterResponder("POST", strings.ReplaceAll(authFlowExecutionCreate, "{realm}", e.realmName),
-		httpmock.ResponderFromResponse(createExecResponse))
+	var updated AuthenticatorConfig
 
-	createConfigURL := strings.ReplaceAll(authFlowExecutionConfig, "{realm}", e.realmName)
-	createConfigURL = strings.ReplaceAll(createConfigURL, "{id}", newExecID)
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm123/authentication/config/cfg2",
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&updated); err != nil {
+				return nil, err
+			}
 
-	httpmock.RegisterResponder("POST", createConfigURL, httpmock.NewStringResponder(200, ""))
+			return httpmock.NewStringResponse(204, ""), nil
+		})
 
-	childExecutionID := "child-exec-id1"
+	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
+	require.NoError(e.T(), err)
+
+	info := httpmock.GetCallCountInfo()
+	assert.Zero(e.T(), info["PUT /admin/realms/realm123/authentication/config/cfg1"])
+	assert.Equal(e.T(), 1, info["PUT /admin/realms/realm123/authentication/config/cfg2"])
+	assert.Equal(e.T(), AuthenticatorConfig{
+		ID:     "cfg2",
+		Alias:  "otp-role",
+		Config: map[string]string{"condUserRole": "otp-users", "negate": "false"},
+	}, updated)
+}
 
-	httpmock.RegisterResponder("GET",
-		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias),
-		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{{ID: childExecutionID}}))
-	httpmock.RegisterResponder("DELETE",
-		fmt.Sprintf("/admin/realms/%s/authentication/executions/%s", e.realmName, childExecutionID),
-		httpmock.NewStringResponder(200, ""))
+func (e *ExecFlowTestSuite) TestSyncAuthFlow_RecreateRemovedConfig() {
+	flow := KeycloakAuthFlow{
+		Alias: "alias1",
+		AuthenticationExecutions: []AuthenticationExecution{
+			{
+				Authenticator: "identity-provider-redirector",
+				Requirement:   "ALTERNATIVE",
+				AuthenticatorConfig: &AuthenticatorConfig{
+					Alias:  "redirector",
+					Config: map[string]string{"defaultProvider": "sso"},
+				},
+			},
+		},
+	}
+
+	e.onFlows(e.realmName, KeycloakAuthFlow{Alias: flow.Alias, ID: "flow-id-1"})
+	e.onExecutions("alias1", []FlowExecution{{
+		ID:                   "e1",
+		ProviderID:           "identity-provider-redirector",
+		Requirement:          "ALTERNATIVE",
+		AuthenticationConfig: "removed-cfg",
+	}})
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm123/authentication/config/removed-cfg",
+		httpmock.NewStringResponder(404, ""))
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm123/authentication/executions/e1/config",
+		httpmock.NewStringResponder(201, ""))
 
 	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
-	assert.NoError(e.T(), err)
+	require.NoError(e.T(), err)
+
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(e.T(), 1, info["POST /admin/realms/realm123/authentication/executions/e1/config"])
+	assert.Zero(e.T(), info["PUT /admin/realms/realm123/authentication/config/removed-cfg"])
+}
+
+func (e *ExecFlowTestSuite) TestSyncAuthFlow_RequirementNotAvailable() {
+	flow := KeycloakAuthFlow{
+		Alias: "alias1",
+		AuthenticationExecutions: []AuthenticationExecution{
+			{Authenticator: "conditional-user-role", Requirement: "CONDITIONAL"},
+		},
+	}
+
+	e.onFlows(e.realmName, KeycloakAuthFlow{Alias: flow.Alias, ID: "flow-id-1"})
+	e.onExecutions("alias1", []FlowExecution{{
+		ID:                 "e1",
+		DisplayName:        "Condition - user role",
+		ProviderID:         "conditional-user-role",
+		Requirement:        "DISABLED",
+		RequirementChoices: []string{"REQUIRED", "DISABLED"},
+	}})
+
+	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
+	require.Error(e.T(), err)
+	assert.Contains(e.T(), err.Error(),
+		"requirement CONDITIONAL is not available for flow execution Condition - user role")
+	e.goCloakMockClient.AssertNotCalled(e.T(), "UpdateAuthenticationExecution", e.realmName, "alias1",
+		testifyMock.Anything)
+}
+
+func (e *ExecFlowTestSuite) TestSyncAuthFlow_OrderDrift() {
+	flow := KeycloakAuthFlow{
+		Alias: "alias1",
+		AuthenticationExecutions: []AuthenticationExecution{
+			{Authenticator: "auth-cookie", Priority: 0},
+			{Authenticator: "auth-otp-form", Priority: 1},
+		},
+	}
+
+	e.onFlows(e.realmName, KeycloakAuthFlow{Alias: flow.Alias, ID: "flow-id-1"})
+	e.onExecutions("alias1", []FlowExecution{
+		{ID: "e2", ProviderID: "auth-otp-form"},
+		{ID: "e1", ProviderID: "auth-cookie"},
+	})
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm123/authentication/executions/e1/raise-priority",
+		httpmock.NewStringResponder(204, ""))
+
+	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
+	require.Error(e.T(), err)
+	assert.True(e.T(), IsErrExecutionOrderDrift(err))
+	assert.Contains(e.T(), err.Error(),
+		"executions order does not match after 3 attempts, expected: auth-cookie, auth-otp-form, "+
+			"actual: auth-otp-form, auth-cookie")
+	assert.Equal(e.T(), maxExecutionOrderAttempts,
+		httpmock.GetCallCountInfo()["POST /admin/realms/realm123/authentication/executions/e1/raise-priority"])
+}
+
+func (e *ExecFlowTestSuite) TestSyncAuthFlow_UndeclaredChildFlow() {
+	flow := KeycloakAuthFlow{Alias: "alias1"}
+
+	e.onFlows(e.realmName, KeycloakAuthFlow{Alias: flow.Alias, ID: "flow-id-1"})
+	e.onExecutions("alias1", []FlowExecution{{ID: "e1", AuthenticationFlow: true, DisplayName: "sub"}})
+
+	err := e.adapter.SyncAuthFlow(e.realmName, &flow)
+	require.Error(e.T(), err)
+	assert.Contains(e.T(), err.Error(), "unable to find child flow with name: sub")
 }
 
 func (e *ExecFlowTestSuite) TestDeleteAuthFlowWithParent() {
 	flow := KeycloakAuthFlow{Alias: "al", ParentName: "par"}
 
-	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm123/authentication/flows/par/executions",
-		httpmock.NewJsonResponderOrPanic(http.StatusOK, []FlowExecution{
-			{DisplayName: flow.Alias, ID: "id12"},
-		}))
-	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm123/authentication/executions/id12",
-		httpmock.NewStringResponder(http.StatusOK, ""))
+	e.onExecutions("par", []FlowExecution{{DisplayName: flow.Alias, ID: "id12"}})
+	e.goCloakMockClient.On("DeleteAuthenticationExecution", e.realmName, "id12").Return(nil)
 
 	err := e.adapter.DeleteAuthFlow(e.realmName, &flow)
 	assert.NoError(e.T(), err)
@@ -162,8 +379,8 @@ func (e *ExecFlowTestSuite) TestDeleteAuthFlowWithParent() {
 func (e *ExecFlowTestSuite) TestDeleteAuthFlowWithParentUnableGetFlow() {
 	flow := KeycloakAuthFlow{Alias: "al", ParentName: "par"}
 
-	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm123/authentication/flows/par/executions",
-		httpmock.NewJsonResponderOrPanic(http.StatusNotFound, nil))
+	e.goCloakMockClient.On("GetAuthenticationExecutions", e.realmName, "par").
+		Return(nil, &gocloak.APIError{Code: http.StatusNotFound})
 
 	err := e.adapter.DeleteAuthFlow(e.realmName, &flow)
 	assert.Error(e.T(), err)
@@ -172,12 +389,9 @@ func (e *ExecFlowTestSuite) TestDeleteAuthFlowWithParentUnableGetFlow() {
 func (e *ExecFlowTestSuite) TestDeleteAuthFlowWithParentUnableDelete() {
 	flow := KeycloakAuthFlow{Alias: "al", ParentName: "par"}
 
-	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm123/authentication/flows/par/executions",
-		httpmock.NewJsonResponderOrPanic(http.StatusOK, []FlowExecution{
-			{DisplayName: flow.Alias, ID: "id12"},
-		}))
-	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm123/authentication/executions/id12",
-		httpmock.NewStringResponder(http.StatusBadRequest, ""))
+	e.onExecutions("par", []FlowExecution{{DisplayName: flow.Alias, ID: "id12"}})
+	e.goCloakMockClient.On("DeleteAuthenticationExecution", e.realmName, "id12").
+		Return(&gocloak.APIError{Code: http.StatusBadRequest})
 
 	err := e.adapter.DeleteAuthFlow(e.realmName, &flow)
 	assert.Error(e.T(), err)
@@ -185,33 +399,106 @@ func (e *ExecFlowTestSuite) TestDeleteAuthFlowWithParentUnableDelete() {
 
 func (e *ExecFlowTestSuite) TestDeleteAuthFlow() {
 	var (
-		flowAlias           = "flow-alias"
-		existFlowID         = "id321"
-		newBrowserFlowAlias = "alias-br-1"
+		flowAlias   = "flow-alias"
+		existFlowID = "id321"
 	)
 
-	httpmock.RegisterResponder("GET", strings.ReplaceAll(authFlows, "{realm}", e.realmName),
-		httpmock.NewJsonResponderOrPanic(200, []KeycloakAuthFlow{
-			{Alias: flowAlias, ID: existFlowID},
-			{
-				Alias: newBrowserFlowAlias,
-			},
-		}))
-
-	deleteURL := strings.ReplaceAll(authFlow, "{realm}", e.realmName)
-	deleteURL = strings.ReplaceAll(deleteURL, "{id}", existFlowID)
-	httpmock.RegisterResponder("DELETE", deleteURL, httpmock.NewStringResponder(200, ""))
+	e.onFlows(e.realmName, KeycloakAuthFlow{Alias: flowAlias, ID: existFlowID}, KeycloakAuthFlow{Alias: "alias-br-1"})
+	e.goCloakMockClient.On("DeleteAuthenticationFlow", e.realmName, existFlowID).Return(nil)
 
 	e.goCloakMockClient.On("GetRealm", "token", e.realmName).
 		Return(&gocloak.RealmRepresentation{
-			BrowserFlow: gocloak.StringP(flowAlias),
+			BrowserFlow:      gocloak.StringP(flowAlias),
+			DirectGrantFlow:  gocloak.StringP(flowAlias),
+			RegistrationFlow: gocloak.StringP("registration"),
 		}, nil)
 	e.goCloakMockClient.On("UpdateRealm", gocloak.RealmRepresentation{
-		BrowserFlow: gocloak.StringP(newBrowserFlowAlias),
+		BrowserFlow:      gocloak.StringP("browser"),
+		DirectGrantFlow:  gocloak.StringP("direct grant"),
+		RegistrationFlow: gocloak.StringP("registration"),
 	}).Return(nil)
+	e.goCloakMockClient.On("GetClients", e.realmName, gocloak.GetClientsParams{}).
+		Return([]*gocloak.Client{
+			{ClientID: gocloak.StringP("app")},
+			{
+				ClientID: gocloak.StringP("web"),
+				AuthenticationFlowBindingOverrides: &map[string]string{
+					"browser":      existFlowID,
+					"direct_grant": "other-id",
+				},
+			},
+		}, nil)
+	e.goCloakMockClient.On("UpdateClient", "token", e.realmName, gocloak.Client{
+		ClientID:                           gocloak.StringP("web"),
+		AuthenticationFlowBindingOverrides: &map[string]string{"browser": "", "direct_grant": "other-id"},
+	}).Return(nil)
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm123/identity-provider/instances",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []map[string]interface{}{
+			{"alias": "github", "firstBrokerLoginFlowAlias": flowAlias, "postBrokerLoginFlowAlias": flowAlias,
+				"hideOnLogin": true},
+			{"alias": "google", "firstBrokerLoginFlowAlias": "first broker login"},
+		}))
+
+	var updatedIdP map[string]interface{}
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm123/identity-provider/instances/github",
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&updatedIdP); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
 
 	err := e.adapter.DeleteAuthFlow(e.realmName, &KeycloakAuthFlow{Alias: flowAlias})
 	assert.NoError(e.T(), err)
+	e.goCloakMockClient.AssertExpectations(e.T())
+	assert.Equal(e.T(), map[string]interface{}{"alias": "github", "firstBrokerLoginFlowAlias": "first broker login",
+		"postBrokerLoginFlowAlias": "", "hideOnLogin": true}, updatedIdP)
+	assert.Zero(e.T(),
+		httpmock.GetCallCountInfo()["PUT /admin/realms/realm123/identity-provider/instances/google"])
+}
+
+func (e *ExecFlowTestSuite) TestDeleteAuthFlow_Fallback() {
+	e.onFlows(e.realmName,
+		KeycloakAuthFlow{Alias: "flow-alias", ID: "id321"},
+		KeycloakAuthFlow{Alias: "fallback", ID: "fallback-id"})
+	e.goCloakMockClient.On("DeleteAuthenticationFlow", e.realmName, "id321").Return(nil)
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm123/identity-provider/instances",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []map[string]interface{}{
+			{"alias": "github", "postBrokerLoginFlowAlias": "flow-alias"},
+		}))
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm123/identity-provider/instances/github",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	e.goCloakMockClient.On("GetRealm", "token", e.realmName).
+		Return(&gocloak.RealmRepresentation{BrowserFlow: gocloak.StringP("flow-alias")}, nil)
+	e.goCloakMockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		BrowserFlow: gocloak.StringP("fallback"),
+	}).Return(nil)
+	e.goCloakMockClient.On("GetClients", e.realmName, gocloak.GetClientsParams{}).
+		Return([]*gocloak.Client{{
+			ClientID:                           gocloak.StringP("web"),
+			AuthenticationFlowBindingOverrides: &map[string]string{"browser": "id321"},
+		}}, nil)
+	e.goCloakMockClient.On("UpdateClient", "token", e.realmName, gocloak.Client{
+		ClientID:                           gocloak.StringP("web"),
+		AuthenticationFlowBindingOverrides: &map[string]string{"browser": "fallback-id"},
+	}).Return(nil)
+
+	err := e.adapter.DeleteAuthFlow(e.realmName, &KeycloakAuthFlow{Alias: "flow-alias", FallbackFlow: "fallback"})
+	require.NoError(e.T(), err)
+	e.goCloakMockClient.AssertNumberOfCalls(e.T(), "DeleteAuthenticationFlow", 1)
+}
+
+func (e *ExecFlowTestSuite) TestDeleteAuthFlow_FallbackNotFound() {
+	e.onFlows(e.realmName, KeycloakAuthFlow{Alias: "flow-alias", ID: "id321"})
+
+	err := e.adapter.DeleteAuthFlow(e.realmName, &KeycloakAuthFlow{Alias: "flow-alias", FallbackFlow: "fallback"})
+	require.Error(e.T(), err)
+	assert.Contains(e.T(), err.Error(), "fallback flow fallback does not exist")
+	e.goCloakMockClient.AssertNotCalled(e.T(), "DeleteAuthenticationFlow", e.realmName, "id321")
 }
 
 func (e *ExecFlowTestSuite) TestGetAuthFlowID() {
@@ -220,14 +507,12 @@ func (e *ExecFlowTestSuite) TestGetAuthFlowID() {
 		flowID = "id-122"
 	)
 
-	httpmock.RegisterResponder("GET",
-		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.ParentName),
-		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{
-			{
-				DisplayName: flow.Alias,
-				FlowID:      flowID,
-			},
-		}))
+	e.onExecutions(flow.ParentName, []FlowExecution{
+		{
+			DisplayName: flow.Alias,
+			FlowID:      flowID,
+		},
+	})
 
 	id, err := e.adapter.getAuthFlowID(e.realmName, &flow)
 
@@ -285,9 +570,7 @@ func (e *ExecFlowTestSuite) TestSyncBaseAuthFlow() {
 		flowID = "flow-id-1"
 	)
 
-	httpmock.RegisterResponder("GET",
-		fmt.Sprintf("/admin/realms/%s/authentication/flows", e.realmName),
-		httpmock.NewJsonResponderOrPanic(200, []KeycloakAuthFlow{}))
+	e.onFlows(e.realmName)
 
 	createFlowResponse := httpmock.NewStringResponse(200, "")
 	defer closeWithFailOnError(e.T(), createFlowResponse.Body)
@@ -297,10 +580,7 @@ func (e *ExecFlowTestSuite) TestSyncBaseAuthFlow() {
 		fmt.Sprintf("/admin/realms/%s/authentication/flows", e.realmName),
 		httpmock.ResponderFromResponse(createFlowResponse))
 
-	httpmock.RegisterResponder("GET",
-		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName,
-			flow.Alias),
-		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{{}}))
+	e.onExecutions(flow.Alias, []FlowExecution{{}})
 
 	_, err := e.adapter.syncBaseAuthFlow(e.realmName, &flow)
 
@@ -310,71 +590,46 @@ func (e *ExecFlowTestSuite) TestSyncBaseAuthFlow() {
 
 func (e *ExecFlowTestSuite) TestGetFlowExecutionID() {
 	flow := KeycloakAuthFlow{ParentName: "parent", Alias: "fff"}
+
+	e.goCloakMockClient.On("GetAuthenticationExecutions", e.realmName, "parent").
+		Return(nil, errors.New("connection refused")).Once()
+
 	_, err := e.adapter.getFlowExecutionID(e.realmName, &flow)
 
 	assert.Error(e.T(), err)
-	assert.Contains(e.T(), err.Error(), "no responder found")
+	assert.Contains(e.T(), err.Error(), "connection refused")
 
-	httpmock.RegisterResponder("GET", "/admin/realms/realm123/authentication/flows/parent/executions",
-		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{}))
+	e.onExecutions("parent", []FlowExecution{}, []FlowExecution{
+		{
+			DisplayName: flow.Alias,
+			ID:          "as12",
+		},
+	})
 
 	_, err = e.adapter.getFlowExecutionID(e.realmName, &flow)
 	assert.Error(e.T(), err)
 	assert.EqualError(e.T(), err, "auth flow not found")
 
-	httpmock.RegisterResponder("GET", "/admin/realms/realm123/authentication/flows/parent/executions",
-		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{
-			{
-				DisplayName: flow.Alias,
-				ID:          "as12",
-			},
-		}))
-
 	_, err = e.adapter.getFlowExecutionID(e.realmName, &flow)
 	assert.NoError(e.T(), err)
 }
 
-func (e *ExecFlowTestSuite) TestAdjustChildFlowsPriority() {
-	var (
-		flow = KeycloakAuthFlow{
-			Alias: "flow-1",
-			AuthenticationExecutions: []AuthenticationExecution{
-				{
-					AutheticatorFlow: true,
-					Alias:            "child-flow-1",
-					Priority:         1,
-					Requirement:      "REQUIRED",
-				},
-				{
-					Priority: 0,
-				},
-			},
-		}
-		flowExecutionID = "flow-exec-id-1"
-	)
+func (e *ExecFlowTestSuite) TestAuthFlowExists() {
+	e.onFlows(e.realmName, KeycloakAuthFlow{Alias: "first broker login"})
 
-	httpmock.RegisterResponder("GET",
-		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias),
-		httpmock.NewJsonResponderOrPanic(200, []FlowExecution{
-			{
-				AuthenticationFlow: true,
-				DisplayName:        flow.AuthenticationExecutions[0].Alias,
-				Index:              0,
-				ID:                 flowExecutionID,
-				Requirement:        "ALTERNATIVE",
-			},
-		}))
+	exists, err := e.adapter.AuthFlowExists(e.realmName, "first broker login")
+	require.NoError(e.T(), err)
+	assert.True(e.T(), exists)
 
-	httpmock.RegisterResponder("POST",
-		fmt.Sprintf("/admin/realms/%s/authentication/executions/%s/lower-priority", e.realmName, flowExecutionID),
-		httpmock.NewStringResponder(200, ""))
+	exists, err = e.adapter.AuthFlowExists(e.realmName, "missing")
+	require.NoError(e.T(), err)
+	assert.False(e.T(), exists)
 
-	httpmock.RegisterResponder("PUT",
-		fmt.Sprintf("/admin/realms/%s/authentication/flows/%s/executions", e.realmName, flow.Alias),
-		httpmock.NewStringResponder(200, ""))
+	e.goCloakMockClient.On("GetAuthenticationFlows", "realm-error").
+		Return(nil, &gocloak.APIError{Code: http.StatusInternalServerError, Message: "fatal"})
 
-	err := e.adapter.adjustChildFlowsPriority(e.realmName, &flow)
-	assert.NoError(e.T(), err)
+	_, err = e.adapter.AuthFlowExists("realm-error", "missing")
+	require.Error(e.T(), err)
 }
 
 func TestExecFlowTestSuite(t *testing.T) {
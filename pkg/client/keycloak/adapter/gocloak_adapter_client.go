@@ -8,16 +8,66 @@ import (
 	"github.com/pkg/errors"
 )
 
+type getClientScopesFunc func(ctx context.Context, token, realm, clientID string) ([]*gocloak.ClientScope, error)
+
+type clientScopeFunc func(ctx context.Context, token, realm, clientID, scopeID string) error
+
 func (a GoCloakAdapter) AddDefaultScopeToClient(ctx context.Context, realmName, clientName string, scopes []ClientScope) error {
 	log := a.log.WithValues("clientName", clientName, logKeyRealm, realmName)
 	log.Info("Start add Client Scopes to client...")
 
+	if err := a.addScopesToClient(ctx, realmName, clientName, scopes,
+		a.client.GetClientsDefaultScopes, a.client.AddDefaultScopeToClient); err != nil {
+		return err
+	}
+
+	log.Info("End add Client Scopes to client...")
+
+	return nil
+}
+
+func (a GoCloakAdapter) AddOptionalScopeToClient(ctx context.Context, realmName, clientName string, scopes []ClientScope) error {
+	log := a.log.WithValues("clientName", clientName, logKeyRealm, realmName)
+	log.Info("Start add optional Client Scopes to client...")
+
+	if err := a.addScopesToClient(ctx, realmName, clientName, scopes,
+		a.client.GetClientsOptionalScopes, a.client.AddOptionalScopeToClient); err != nil {
+		return err
+	}
+
+	log.Info("End add optional Client Scopes to client...")
+
+	return nil
+}
+
+// GetClientDefaultScopes returns default client scopes assigned to the client.
+func (a GoCloakAdapter) GetClientDefaultScopes(ctx context.Context, realmName, clientName string) ([]ClientScope, error) {
+	return a.getClientScopes(ctx, realmName, clientName, a.client.GetClientsDefaultScopes)
+}
+
+// GetClientOptionalScopes returns optional client scopes assigned to the client.
+func (a GoCloakAdapter) GetClientOptionalScopes(ctx context.Context, realmName, clientName string) ([]ClientScope, error) {
+	return a.getClientScopes(ctx, realmName, clientName, a.client.GetClientsOptionalScopes)
+}
+
+// RemoveDefaultScopeFromClient detaches default client scopes from the client.
+func (a GoCloakAdapter) RemoveDefaultScopeFromClient(ctx context.Context, realmName, clientName string, scopes []ClientScope) error {
+	return a.removeScopesFromClient(ctx, realmName, clientName, scopes, a.client.RemoveDefaultScopeFromClient)
+}
+
+// RemoveOptionalScopeFromClient detaches optional client scopes from the client.
+func (a GoCloakAdapter) RemoveOptionalScopeFromClient(ctx context.Context, realmName, clientName string, scopes []ClientScope) error {
+	return a.removeScopesFromClient(ctx, realmName, clientName, scopes, a.client.RemoveOptionalScopeFromClient)
+}
+
+func (a GoCloakAdapter) addScopesToClient(ctx context.Context, realmName, clientName string, scopes []ClientScope,
+	getScopes getClientScopesFunc, addScope clientScopeFunc) error {
 	clientID, err := a.GetClientID(clientName, realmName)
 	if err != nil {
 		return errors.Wrap(err, "error during GetClientId")
 	}
 
-	existingScopes, err := a.client.GetClientsDefaultScopes(ctx, a.token.AccessToken, realmName, clientID)
+	existingScopes, err := getScopes(ctx, a.token.AccessToken, realmName, clientID)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("failed to get existing client scope for client %s", clientName))
 	}
@@ -35,13 +85,56 @@ func (a GoCloakAdapter) AddDefaultScopeToClient(ctx context.Context, realmName,
 			continue
 		}
 
-		err := a.client.AddDefaultScopeToClient(ctx, a.token.AccessToken, realmName, clientID, scope.ID)
+		err := addScope(ctx, a.token.AccessToken, realmName, clientID, scope.ID)
 		if err != nil {
 			a.log.Error(err, fmt.Sprintf("failed link scope %s to client %s", scope.Name, clientName))
 		}
 	}
 
-	log.Info("End add Client Scopes to client...")
+	return nil
+}
+
+func (a GoCloakAdapter) getClientScopes(ctx context.Context, realmName, clientName string,
+	getScopes getClientScopesFunc) ([]ClientScope, error) {
+	clientID, err := a.GetClientID(clientName, realmName)
+	if err != nil {
+		return nil, errors.Wrap(err, "error during GetClientId")
+	}
+
+	existingScopes, err := getScopes(ctx, a.token.AccessToken, realmName, clientID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get client scopes for client %s", clientName)
+	}
+
+	scopes := make([]ClientScope, 0, len(existingScopes))
+
+	for _, s := range existingScopes {
+		if s == nil || s.ID == nil || s.Name == nil {
+			continue
+		}
+
+		scopes = append(scopes, ClientScope{ID: *s.ID, Name: *s.Name})
+	}
+
+	return scopes, nil
+}
+
+func (a GoCloakAdapter) removeScopesFromClient(ctx context.Context, realmName, clientName string, scopes []ClientScope,
+	removeScope clientScopeFunc) error {
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	clientID, err := a.GetClientID(clientName, realmName)
+	if err != nil {
+		return errors.Wrap(err, "error during GetClientId")
+	}
+
+	for _, scope := range scopes {
+		if err := removeScope(ctx, a.token.AccessToken, realmName, clientID, scope.ID); err != nil {
+			return errors.Wrapf(err, "failed to unlink scope %s from client %s", scope.Name, clientName)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,288 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	keycloakApiParamEntityID = "entityId"
+	keycloakApiParamType     = "type"
+)
+
+// ResourceServerSettings is a configuration of the client authorization services.
+type ResourceServerSettings struct {
+	PolicyEnforcementMode         string `json:"policyEnforcementMode,omitempty"`
+	DecisionStrategy              string `json:"decisionStrategy,omitempty"`
+	AllowRemoteResourceManagement bool   `json:"allowRemoteResourceManagement"`
+}
+
+// AuthzScope is an authorization scope of the client resource server.
+type AuthzScope struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// AuthzResource is a resource protected by the client resource server.
+type AuthzResource struct {
+	ID                 string              `json:"_id,omitempty"`
+	Name               string              `json:"name"`
+	DisplayName        string              `json:"displayName,omitempty"`
+	Type               string              `json:"type,omitempty"`
+	URIs               []string            `json:"uris,omitempty"`
+	Scopes             []AuthzScope        `json:"scopes,omitempty"`
+	OwnerManagedAccess bool                `json:"ownerManagedAccess"`
+	Attributes         map[string][]string `json:"attributes,omitempty"`
+}
+
+// AuthzPolicyRole is a role of the role based policy, ID can be a role name or clientId/roleName for client roles.
+type AuthzPolicyRole struct {
+	ID       string `json:"id"`
+	Required bool   `json:"required"`
+}
+
+// AuthzPolicyGroup is a group of the group based policy.
+type AuthzPolicyGroup struct {
+	Path           string `json:"path"`
+	ExtendChildren bool   `json:"extendChildren"`
+}
+
+// AuthzPolicy is a policy or a permission of the client resource server.
+// Roles, groups, users and clients are used by the corresponding policy types;
+// resources, resource type and scopes are used by permissions.
+type AuthzPolicy struct {
+	ID               string             `json:"id,omitempty"`
+	Name             string             `json:"name"`
+	Type             string             `json:"type"`
+	Description      string             `json:"description,omitempty"`
+	Logic            string             `json:"logic,omitempty"`
+	DecisionStrategy string             `json:"decisionStrategy,omitempty"`
+	Roles            []AuthzPolicyRole  `json:"roles,omitempty"`
+	Groups           []AuthzPolicyGroup `json:"groups,omitempty"`
+	Users            []string           `json:"users,omitempty"`
+	Clients          []string           `json:"clients,omitempty"`
+	Policies         []string           `json:"policies,omitempty"`
+	Resources        []string           `json:"resources,omitempty"`
+	ResourceType     string             `json:"resourceType,omitempty"`
+	Scopes           []string           `json:"scopes,omitempty"`
+}
+
+// UpdateResourceServerSettings updates authorization services settings of the client with the given id.
+func (a GoCloakAdapter) UpdateResourceServerSettings(ctx context.Context, realmName, clientID string,
+	settings *ResourceServerSettings) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    clientID,
+	}).SetBody(settings).Put(a.basePath + authzResourceServer)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to update resource server settings")
+	}
+
+	return nil
+}
+
+// ImportAuthzSettings imports authorization settings JSON in the keycloak export format into the client with the given id.
+func (a GoCloakAdapter) ImportAuthzSettings(ctx context.Context, realmName, clientID string, settings []byte) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    clientID,
+	}).SetHeader("Content-Type", "application/json").SetBody(settings).Post(a.basePath + authzSettingsImport)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to import authorization settings")
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) GetAuthzScopes(ctx context.Context, realmName, clientID string) ([]AuthzScope, error) {
+	var scopes []AuthzScope
+
+	if err := a.getAuthzEntities(ctx, realmName, clientID, authzScopes, nil, &scopes); err != nil {
+		return nil, errors.Wrap(err, "unable to get authorization scopes")
+	}
+
+	return scopes, nil
+}
+
+func (a GoCloakAdapter) CreateAuthzScope(ctx context.Context, realmName, clientID string, scope *AuthzScope) error {
+	if err := a.createAuthzEntity(ctx, realmName, clientID, "", authzScopes, scope); err != nil {
+		return errors.Wrapf(err, "unable to create authorization scope %s", scope.Name)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) UpdateAuthzScope(ctx context.Context, realmName, clientID string, scope *AuthzScope) error {
+	if err := a.updateAuthzEntity(ctx, realmName, clientID, "", scope.ID, authzScope, scope); err != nil {
+		return errors.Wrapf(err, "unable to update authorization scope %s", scope.Name)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) DeleteAuthzScope(ctx context.Context, realmName, clientID, scopeID string) error {
+	if err := a.deleteAuthzEntity(ctx, realmName, clientID, scopeID, authzScope); err != nil {
+		return errors.Wrapf(err, "unable to delete authorization scope %s", scopeID)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) GetAuthzResources(ctx context.Context, realmName, clientID string) ([]AuthzResource, error) {
+	var resources []AuthzResource
+
+	if err := a.getAuthzEntities(ctx, realmName, clientID, authzResources, nil, &resources); err != nil {
+		return nil, errors.Wrap(err, "unable to get authorization resources")
+	}
+
+	return resources, nil
+}
+
+func (a GoCloakAdapter) CreateAuthzResource(ctx context.Context, realmName, clientID string, resource *AuthzResource) error {
+	if err := a.createAuthzEntity(ctx, realmName, clientID, "", authzResources, resource); err != nil {
+		return errors.Wrapf(err, "unable to create authorization resource %s", resource.Name)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) UpdateAuthzResource(ctx context.Context, realmName, clientID string, resource *AuthzResource) error {
+	if err := a.updateAuthzEntity(ctx, realmName, clientID, "", resource.ID, authzResource, resource); err != nil {
+		return errors.Wrapf(err, "unable to update authorization resource %s", resource.Name)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) DeleteAuthzResource(ctx context.Context, realmName, clientID, resourceID string) error {
+	if err := a.deleteAuthzEntity(ctx, realmName, clientID, resourceID, authzResource); err != nil {
+		return errors.Wrapf(err, "unable to delete authorization resource %s", resourceID)
+	}
+
+	return nil
+}
+
+// GetAuthzPolicies returns policies of the client resource server, permissions are not included.
+func (a GoCloakAdapter) GetAuthzPolicies(ctx context.Context, realmName, clientID string) ([]AuthzPolicy, error) {
+	var policies []AuthzPolicy
+
+	if err := a.getAuthzEntities(ctx, realmName, clientID, authzPolicies,
+		map[string]string{"permission": "false"}, &policies); err != nil {
+		return nil, errors.Wrap(err, "unable to get authorization policies")
+	}
+
+	return policies, nil
+}
+
+func (a GoCloakAdapter) CreateAuthzPolicy(ctx context.Context, realmName, clientID string, policy *AuthzPolicy) error {
+	if err := a.createAuthzEntity(ctx, realmName, clientID, policy.Type, authzPolicyType, policy); err != nil {
+		return errors.Wrapf(err, "unable to create authorization policy %s", policy.Name)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) UpdateAuthzPolicy(ctx context.Context, realmName, clientID string, policy *AuthzPolicy) error {
+	if err := a.updateAuthzEntity(ctx, realmName, clientID, policy.Type, policy.ID, authzPolicyTypeEntity,
+		policy); err != nil {
+		return errors.Wrapf(err, "unable to update authorization policy %s", policy.Name)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) DeleteAuthzPolicy(ctx context.Context, realmName, clientID, policyID string) error {
+	if err := a.deleteAuthzEntity(ctx, realmName, clientID, policyID, authzPolicy); err != nil {
+		return errors.Wrapf(err, "unable to delete authorization policy %s", policyID)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) GetAuthzPermissions(ctx context.Context, realmName, clientID string) ([]AuthzPolicy, error) {
+	var permissions []AuthzPolicy
+
+	if err := a.getAuthzEntities(ctx, realmName, clientID, authzPermissions, nil, &permissions); err != nil {
+		return nil, errors.Wrap(err, "unable to get authorization permissions")
+	}
+
+	return permissions, nil
+}
+
+func (a GoCloakAdapter) CreateAuthzPermission(ctx context.Context, realmName, clientID string, permission *AuthzPolicy) error {
+	if err := a.createAuthzEntity(ctx, realmName, clientID, permission.Type, authzPermissionType,
+		permission); err != nil {
+		return errors.Wrapf(err, "unable to create authorization permission %s", permission.Name)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) UpdateAuthzPermission(ctx context.Context, realmName, clientID string, permission *AuthzPolicy) error {
+	if err := a.updateAuthzEntity(ctx, realmName, clientID, permission.Type, permission.ID, authzPermissionTypeEntity,
+		permission); err != nil {
+		return errors.Wrapf(err, "unable to update authorization permission %s", permission.Name)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) DeleteAuthzPermission(ctx context.Context, realmName, clientID, permissionID string) error {
+	if err := a.deleteAuthzEntity(ctx, realmName, clientID, permissionID, authzPermission); err != nil {
+		return errors.Wrapf(err, "unable to delete authorization permission %s", permissionID)
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) getAuthzEntities(ctx context.Context, realmName, clientID, path string,
+	query map[string]string, result interface{}) error {
+	params := map[string]string{"max": "-1"}
+	for k, v := range query {
+		params[k] = v
+	}
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    clientID,
+	}).SetQueryParams(params).SetResult(result).Get(a.basePath + path)
+
+	return a.checkError(err, rsp)
+}
+
+func (a GoCloakAdapter) createAuthzEntity(ctx context.Context, realmName, clientID, entityType, path string,
+	body interface{}) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		keycloakApiParamId:    clientID,
+		keycloakApiParamType:  entityType,
+	}).SetBody(body).Post(a.basePath + path)
+
+	return a.checkError(err, rsp)
+}
+
+func (a GoCloakAdapter) updateAuthzEntity(ctx context.Context, realmName, clientID, entityType, entityID, path string,
+	body interface{}) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm:    realmName,
+		keycloakApiParamId:       clientID,
+		keycloakApiParamType:     entityType,
+		keycloakApiParamEntityID: entityID,
+	}).SetBody(body).Put(a.basePath + path)
+
+	return a.checkError(err, rsp)
+}
+
+func (a GoCloakAdapter) deleteAuthzEntity(ctx context.Context, realmName, clientID, entityID, path string) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm:    realmName,
+		keycloakApiParamId:       clientID,
+		keycloakApiParamEntityID: entityID,
+	}).Delete(a.basePath + path)
+
+	return a.checkError(err, rsp)
+}
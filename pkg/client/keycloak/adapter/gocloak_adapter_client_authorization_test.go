@@ -0,0 +1,146 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAuthzPath = "/admin/realms/realm/clients/client-id/authz/resource-server"
+
+func TestGoCloakAdapter_UpdateResourceServerSettings(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodPut, testAuthzPath, httpmock.NewStringResponder(204, ""))
+
+	err := kcAdapter.UpdateResourceServerSettings(context.Background(), "realm", "client-id",
+		&ResourceServerSettings{PolicyEnforcementMode: "ENFORCING"})
+	require.NoError(t, err)
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm/clients/err/authz/resource-server",
+		httpmock.NewStringResponder(500, "fatal"))
+
+	err = kcAdapter.UpdateResourceServerSettings(context.Background(), "realm", "err", &ResourceServerSettings{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to update resource server settings")
+}
+
+func TestGoCloakAdapter_AuthzScopes(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, testAuthzPath+"/scope",
+		httpmock.NewJsonResponderOrPanic(200, []AuthzScope{{ID: "scope-id", Name: "view"}}))
+	httpmock.RegisterResponder(http.MethodPost, testAuthzPath+"/scope", httpmock.NewStringResponder(201, ""))
+	httpmock.RegisterResponder(http.MethodPut, testAuthzPath+"/scope/scope-id", httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder(http.MethodDelete, testAuthzPath+"/scope/scope-id", httpmock.NewStringResponder(204, ""))
+
+	scopes, err := kcAdapter.GetAuthzScopes(context.Background(), "realm", "client-id")
+	require.NoError(t, err)
+	assert.Equal(t, []AuthzScope{{ID: "scope-id", Name: "view"}}, scopes)
+
+	require.NoError(t, kcAdapter.CreateAuthzScope(context.Background(), "realm", "client-id", &AuthzScope{Name: "edit"}))
+	require.NoError(t, kcAdapter.UpdateAuthzScope(context.Background(), "realm", "client-id",
+		&AuthzScope{ID: "scope-id", Name: "view"}))
+	require.NoError(t, kcAdapter.DeleteAuthzScope(context.Background(), "realm", "client-id", "scope-id"))
+
+	err = kcAdapter.DeleteAuthzScope(context.Background(), "realm", "client-id", "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to delete authorization scope missing")
+}
+
+func TestGoCloakAdapter_AuthzResources(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, testAuthzPath+"/resource",
+		httpmock.NewJsonResponderOrPanic(200, []map[string]interface{}{
+			{"_id": "res-id", "name": "docs", "uris": []string{"/docs/*"}},
+		}))
+	httpmock.RegisterResponder(http.MethodPost, testAuthzPath+"/resource", httpmock.NewStringResponder(201, ""))
+	httpmock.RegisterResponder(http.MethodPut, testAuthzPath+"/resource/res-id", httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder(http.MethodDelete, testAuthzPath+"/resource/res-id", httpmock.NewStringResponder(204, ""))
+
+	resources, err := kcAdapter.GetAuthzResources(context.Background(), "realm", "client-id")
+	require.NoError(t, err)
+	assert.Equal(t, []AuthzResource{{ID: "res-id", Name: "docs", URIs: []string{"/docs/*"}}}, resources)
+
+	require.NoError(t, kcAdapter.CreateAuthzResource(context.Background(), "realm", "client-id",
+		&AuthzResource{Name: "docs"}))
+	require.NoError(t, kcAdapter.UpdateAuthzResource(context.Background(), "realm", "client-id",
+		&AuthzResource{ID: "res-id", Name: "docs"}))
+	require.NoError(t, kcAdapter.DeleteAuthzResource(context.Background(), "realm", "client-id", "res-id"))
+}
+
+func TestGoCloakAdapter_AuthzPolicies(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, testAuthzPath+"/policy",
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("permission") != "false" {
+				return httpmock.NewStringResponse(400, "permission filter is not set"), nil
+			}
+
+			return httpmock.NewJsonResponse(200, []AuthzPolicy{{ID: "policy-id", Name: "admins", Type: "role"}})
+		})
+	httpmock.RegisterResponder(http.MethodPost, testAuthzPath+"/policy/role", httpmock.NewStringResponder(201, ""))
+	httpmock.RegisterResponder(http.MethodPut, testAuthzPath+"/policy/role/policy-id", httpmock.NewStringResponder(201, ""))
+	httpmock.RegisterResponder(http.MethodDelete, testAuthzPath+"/policy/policy-id", httpmock.NewStringResponder(204, ""))
+
+	policies, err := kcAdapter.GetAuthzPolicies(context.Background(), "realm", "client-id")
+	require.NoError(t, err)
+	assert.Equal(t, []AuthzPolicy{{ID: "policy-id", Name: "admins", Type: "role"}}, policies)
+
+	require.NoError(t, kcAdapter.CreateAuthzPolicy(context.Background(), "realm", "client-id",
+		&AuthzPolicy{Name: "admins", Type: "role"}))
+	require.NoError(t, kcAdapter.UpdateAuthzPolicy(context.Background(), "realm", "client-id",
+		&AuthzPolicy{ID: "policy-id", Name: "admins", Type: "role"}))
+	require.NoError(t, kcAdapter.DeleteAuthzPolicy(context.Background(), "realm", "client-id", "policy-id"))
+
+	err = kcAdapter.CreateAuthzPolicy(context.Background(), "realm", "client-id", &AuthzPolicy{Name: "js", Type: "js"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to create authorization policy js")
+}
+
+func TestGoCloakAdapter_AuthzPermissions(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, testAuthzPath+"/permission",
+		httpmock.NewJsonResponderOrPanic(200, []AuthzPolicy{{ID: "perm-id", Name: "docs", Type: "resource"}}))
+	httpmock.RegisterResponder(http.MethodPost, testAuthzPath+"/permission/resource", httpmock.NewStringResponder(201, ""))
+	httpmock.RegisterResponder(http.MethodPut, testAuthzPath+"/permission/resource/perm-id",
+		httpmock.NewStringResponder(201, ""))
+	httpmock.RegisterResponder(http.MethodDelete, testAuthzPath+"/permission/perm-id", httpmock.NewStringResponder(204, ""))
+
+	permissions, err := kcAdapter.GetAuthzPermissions(context.Background(), "realm", "client-id")
+	require.NoError(t, err)
+	assert.Equal(t, []AuthzPolicy{{ID: "perm-id", Name: "docs", Type: "resource"}}, permissions)
+
+	require.NoError(t, kcAdapter.CreateAuthzPermission(context.Background(), "realm", "client-id",
+		&AuthzPolicy{Name: "docs", Type: "resource"}))
+	require.NoError(t, kcAdapter.UpdateAuthzPermission(context.Background(), "realm", "client-id",
+		&AuthzPolicy{ID: "perm-id", Name: "docs", Type: "resource"}))
+	require.NoError(t, kcAdapter.DeleteAuthzPermission(context.Background(), "realm", "client-id", "perm-id"))
+}
+
+func TestGoCloakAdapter_ImportAuthzSettings(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodPost, testAuthzPath+"/import",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Content-Type") != "application/json" {
+				return httpmock.NewStringResponse(415, "unsupported media type"), nil
+			}
+
+			return httpmock.NewStringResponse(204, ""), nil
+		})
+
+	settings := []byte(`{"policyEnforcementMode":"ENFORCING"}`)
+	require.NoError(t, kcAdapter.ImportAuthzSettings(context.Background(), "realm", "client-id", settings))
+
+	err := kcAdapter.ImportAuthzSettings(context.Background(), "realm", "missing", settings)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to import authorization settings")
+}
@@ -0,0 +1,97 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// ClientProfiles are the realm client profiles, the global profiles are not included.
+type ClientProfiles struct {
+	Profiles []ClientProfile `json:"profiles"`
+}
+
+type ClientProfile struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Executors   []ClientPolicyExecutor `json:"executors"`
+}
+
+type ClientPolicyExecutor struct {
+	Executor      string                 `json:"executor"`
+	Configuration map[string]interface{} `json:"configuration"`
+}
+
+// ClientPolicies are the realm client policies.
+type ClientPolicies struct {
+	Policies []ClientPolicy `json:"policies"`
+}
+
+type ClientPolicy struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description,omitempty"`
+	Enabled     bool                    `json:"enabled"`
+	Conditions  []ClientPolicyCondition `json:"conditions"`
+	Profiles    []string                `json:"profiles"`
+}
+
+type ClientPolicyCondition struct {
+	Condition     string                 `json:"condition"`
+	Configuration map[string]interface{} `json:"configuration"`
+}
+
+// GetClientProfiles returns the client profiles of the realm.
+func (a GoCloakAdapter) GetClientProfiles(ctx context.Context, realmName string) (*ClientProfiles, error) {
+	var profiles ClientProfiles
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetResult(&profiles).Get(a.basePath + realmClientProfiles)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to get client profiles")
+	}
+
+	return &profiles, nil
+}
+
+// UpdateClientProfiles replaces the client profiles of the realm.
+func (a GoCloakAdapter) UpdateClientProfiles(ctx context.Context, realmName string, profiles *ClientProfiles) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetBody(profiles).Put(a.basePath + realmClientProfiles)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to update client profiles")
+	}
+
+	return nil
+}
+
+// GetClientPolicies returns the client policies of the realm.
+func (a GoCloakAdapter) GetClientPolicies(ctx context.Context, realmName string) (*ClientPolicies, error) {
+	var policies ClientPolicies
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetResult(&policies).Get(a.basePath + realmClientPolicies)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to get client policies")
+	}
+
+	return &policies, nil
+}
+
+// UpdateClientPolicies replaces the client policies of the realm.
+func (a GoCloakAdapter) UpdateClientPolicies(ctx context.Context, realmName string, policies *ClientPolicies) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetBody(policies).Put(a.basePath + realmClientPolicies)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to update client policies")
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_ClientProfiles(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/client-policies/profiles",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]interface{}{
+			"profiles": []map[string]interface{}{{
+				"name": "fapi",
+				"executors": []map[string]interface{}{{
+					"executor":      "secure-client-authenticator",
+					"configuration": map[string]interface{}{"auto-configure": true},
+				}},
+			}},
+		}))
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/client-policies/profiles",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	profiles, err := a.GetClientProfiles(context.Background(), "realm1")
+	require.NoError(t, err)
+	require.Equal(t, &ClientProfiles{Profiles: []ClientProfile{{
+		Name: "fapi",
+		Executors: []ClientPolicyExecutor{{
+			Executor:      "secure-client-authenticator",
+			Configuration: map[string]interface{}{"auto-configure": true},
+		}},
+	}}}, profiles)
+
+	require.NoError(t, a.UpdateClientProfiles(context.Background(), "realm1", profiles))
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/client-policies/profiles",
+		httpmock.NewStringResponder(http.StatusBadRequest, `{"error":"invalid executor"}`))
+
+	err = a.UpdateClientProfiles(context.Background(), "realm1", profiles)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to update client profiles")
+}
+
+func TestGoCloakAdapter_ClientPolicies(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/client-policies/policies",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]interface{}{
+			"policies": []map[string]interface{}{{
+				"name":    "confidential",
+				"enabled": true,
+				"conditions": []map[string]interface{}{{
+					"condition":     "client-access-type",
+					"configuration": map[string]interface{}{"type": []string{"confidential"}},
+				}},
+				"profiles": []string{"fapi-1-baseline"},
+			}},
+		}))
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/client-policies/policies",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	policies, err := a.GetClientPolicies(context.Background(), "realm1")
+	require.NoError(t, err)
+	require.Equal(t, &ClientPolicies{Policies: []ClientPolicy{{
+		Name:    "confidential",
+		Enabled: true,
+		Conditions: []ClientPolicyCondition{{
+			Condition:     "client-access-type",
+			Configuration: map[string]interface{}{"type": []interface{}{"confidential"}},
+		}},
+		Profiles: []string{"fapi-1-baseline"},
+	}}}, policies)
+
+	require.NoError(t, a.UpdateClientPolicies(context.Background(), "realm1", policies))
+}
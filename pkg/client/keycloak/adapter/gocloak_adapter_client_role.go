@@ -0,0 +1,99 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+)
+
+// SyncClientRole creates or updates the client role and syncs its composites.
+// The role ID and the unresolved composites are stored in the role.
+func (a GoCloakAdapter) SyncClientRole(ctx context.Context, realmName string, role *dto.ClientRole) error {
+	log := a.log.WithValues(logKeyRealm, realmName, "clientId", role.ClientID, "role", role.Name)
+	log.Info("Start sync client role")
+
+	clientID, err := a.GetClientID(role.ClientID, realmName)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get client %s", role.ClientID)
+	}
+
+	currentRole, err := a.client.GetClientRole(ctx, a.token.AccessToken, realmName, clientID, role.Name)
+
+	exists, err := strip404(err)
+	if err != nil {
+		return errors.Wrap(err, "unable to get client role")
+	}
+
+	kcRole := gocloak.Role{
+		Name:        &role.Name,
+		Description: &role.Description,
+		Attributes:  &role.Attributes,
+		Composite:   &role.IsComposite,
+		ClientRole:  gocloak.BoolP(true),
+	}
+
+	if !exists {
+		if _, err = a.client.CreateClientRole(ctx, a.token.AccessToken, realmName, clientID, kcRole); err != nil {
+			return errors.Wrap(err, "unable to create client role")
+		}
+
+		currentRole, err = a.client.GetClientRole(ctx, a.token.AccessToken, realmName, clientID, role.Name)
+		if err != nil {
+			return errors.Wrap(err, "unable to get created client role")
+		}
+	} else {
+		kcRole.ID = currentRole.ID
+		kcRole.Attributes = roleAttributes(currentRole.Attributes, role.Attributes, role.AddOnly)
+
+		if err = a.client.UpdateRole(ctx, a.token.AccessToken, realmName, clientID, kcRole); err != nil {
+			return errors.Wrap(err, "unable to update client role")
+		}
+	}
+
+	role.ID = currentRole.ID
+
+	unresolved, err := a.syncCompositeMembers(ctx, realmName, *currentRole.ID,
+		roleComposites{
+			realmRoles:  role.Composites,
+			clientRoles: role.CompositesClientRoles,
+			addOnly:     role.AddOnly,
+		},
+		func(ctx context.Context, roles []gocloak.Role) error {
+			return a.client.AddClientRoleComposite(ctx, a.token.AccessToken, realmName, *currentRole.ID, roles)
+		},
+		func(ctx context.Context, roles []gocloak.Role) error {
+			return a.client.DeleteClientRoleComposite(ctx, a.token.AccessToken, realmName, *currentRole.ID, roles)
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to sync client role composites")
+	}
+
+	role.UnresolvedComposites = unresolved
+
+	log.Info("End sync client role")
+
+	return nil
+}
+
+// DeleteClientRole deletes the role of the client. It returns nil if the client or the role doesn't exist.
+func (a GoCloakAdapter) DeleteClientRole(ctx context.Context, realmName, clientID, roleName string) error {
+	id, err := a.GetClientID(clientID, realmName)
+	if err != nil {
+		if IsErrNotFound(err) {
+			return nil
+		}
+
+		return errors.Wrapf(err, "unable to get client %s", clientID)
+	}
+
+	err = a.client.DeleteClientRole(ctx, a.token.AccessToken, realmName, id, roleName)
+	if _, err = strip404(err); err != nil {
+		return errors.Wrap(err, "unable to delete client role")
+	}
+
+	return nil
+}
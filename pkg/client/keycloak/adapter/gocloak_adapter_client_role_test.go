@@ -0,0 +1,95 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+	testifyMock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestGoCloakAdapter_SyncClientRole_Create(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	a := GoCloakAdapter{client: &mockClient, token: &gocloak.JWT{AccessToken: "token"}, log: mock.NewLogr()}
+
+	created := gocloak.Role{Name: gocloak.StringP("viewer"), ID: gocloak.StringP("role-id")}
+	member := gocloak.Role{Name: gocloak.StringP("offline_access"), ID: gocloak.StringP("member-id")}
+
+	mockClient.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("app")}).
+		Return([]*gocloak.Client{{ClientID: gocloak.StringP("app"), ID: gocloak.StringP("app-uuid")}}, nil)
+	mockClient.On("GetClientRole", "realm", "app-uuid", "viewer").
+		Return(nil, &gocloak.APIError{Code: http.StatusNotFound, Message: "404 Not Found"}).Once()
+	mockClient.On("CreateClientRole", "realm", "app-uuid", testifyMock.Anything).Return("", nil)
+	mockClient.On("GetClientRole", "realm", "app-uuid", "viewer").Return(&created, nil)
+	mockClient.On("GetCompositeRolesByRoleID", "realm", "role-id").Return([]*gocloak.Role{}, nil)
+	mockClient.On("GetRealmRole", "realm", "offline_access").Return(&member, nil)
+	mockClient.On("AddClientRoleComposite", "realm", "role-id", []gocloak.Role{member}).Return(nil)
+
+	role := dto.ClientRole{Name: "viewer", ClientID: "app", IsComposite: true, Composites: []string{"offline_access"}}
+
+	require.NoError(t, a.SyncClientRole(context.Background(), "realm", &role))
+	require.Equal(t, "role-id", *role.ID)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_SyncClientRole_Update(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	a := GoCloakAdapter{client: &mockClient, token: &gocloak.JWT{AccessToken: "token"}, log: mock.NewLogr()}
+
+	current := gocloak.Role{Name: gocloak.StringP("viewer"), ID: gocloak.StringP("role-id")}
+	stale := gocloak.Role{Name: gocloak.StringP("stale"), ID: gocloak.StringP("stale-id")}
+	attributes := map[string][]string{"level": {"1"}}
+
+	mockClient.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("app")}).
+		Return([]*gocloak.Client{{ClientID: gocloak.StringP("app"), ID: gocloak.StringP("app-uuid")}}, nil)
+	mockClient.On("GetClientRole", "realm", "app-uuid", "viewer").Return(&current, nil)
+	mockClient.On("UpdateRole", "realm", "app-uuid", gocloak.Role{
+		ID:          gocloak.StringP("role-id"),
+		Name:        gocloak.StringP("viewer"),
+		Description: gocloak.StringP("desc"),
+		Attributes:  &attributes,
+		Composite:   gocloak.BoolP(false),
+		ClientRole:  gocloak.BoolP(true),
+	}).Return(nil)
+	mockClient.On("GetCompositeRolesByRoleID", "realm", "role-id").Return([]*gocloak.Role{&stale}, nil)
+	mockClient.On("DeleteClientRoleComposite", "realm", "role-id", []gocloak.Role{stale}).Return(nil)
+
+	role := dto.ClientRole{Name: "viewer", ClientID: "app", Description: "desc", Attributes: attributes}
+
+	require.NoError(t, a.SyncClientRole(context.Background(), "realm", &role))
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_SyncClientRole_ClientNotFound(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	a := GoCloakAdapter{client: &mockClient, token: &gocloak.JWT{AccessToken: "token"}, log: mock.NewLogr()}
+
+	mockClient.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("app")}).
+		Return([]*gocloak.Client{}, nil)
+
+	err := a.SyncClientRole(context.Background(), "realm", &dto.ClientRole{Name: "viewer", ClientID: "app"})
+	require.Error(t, err)
+	require.True(t, IsErrNotFound(err))
+}
+
+func TestGoCloakAdapter_DeleteClientRole(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	a := GoCloakAdapter{client: &mockClient, token: &gocloak.JWT{AccessToken: "token"}, log: mock.NewLogr()}
+
+	mockClient.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("app")}).
+		Return([]*gocloak.Client{{ClientID: gocloak.StringP("app"), ID: gocloak.StringP("app-uuid")}}, nil)
+	mockClient.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("deleted")}).
+		Return([]*gocloak.Client{}, nil)
+	mockClient.On("DeleteClientRole", "realm", "app-uuid", "viewer").Return(nil).Once()
+	mockClient.On("DeleteClientRole", "realm", "app-uuid", "viewer").Return(errors.New("fatal")).Once()
+
+	require.NoError(t, a.DeleteClientRole(context.Background(), "realm", "app", "viewer"))
+	require.Error(t, a.DeleteClientRole(context.Background(), "realm", "app", "viewer"))
+	require.NoError(t, a.DeleteClientRole(context.Background(), "realm", "deleted", "viewer"))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
 )
 
@@ -232,13 +233,8 @@ func (a GoCloakAdapter) syncClientScopeProtocolMappers(ctx context.Context, real
 
 	if scope.ProtocolMappers != nil {
 		for _, pm := range *scope.ProtocolMappers {
-			rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-				keycloakApiParamRealm:         realm,
-				keycloakApiParamClientScopeId: scopeID,
-				"protocolMapperID":            *pm.ID,
-			}).Delete(a.basePath + deleteClientScopeProtocolMapper)
-
-			if err = a.checkError(err, rsp); err != nil {
+			if err := a.client.DeleteClientScopeProtocolMapper(ctx, a.token.AccessToken, realm, scopeID,
+				gocloak.PString(pm.ID)); err != nil {
 				return errors.Wrap(err, "error during client scope protocol mapper deletion")
 			}
 		}
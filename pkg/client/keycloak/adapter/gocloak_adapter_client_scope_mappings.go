@@ -0,0 +1,48 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+)
+
+// SyncClientScopeMappings syncs realm and client roles which are included into the token scope of the client.
+// Client roles are passed as a map of the role client clientId to the role names.
+func (a GoCloakAdapter) SyncClientScopeMappings(ctx context.Context, realm, clientID string, realmRoles []string,
+	clientRoles map[string][]string, addOnly bool) error {
+	mappings, err := a.client.GetClientScopeMappings(ctx, a.token.AccessToken, realm, clientID)
+	if err != nil {
+		return errors.Wrap(err, "unable to get client scope mappings")
+	}
+
+	deleteRealmRoleFunc := a.client.DeleteClientScopeMappingsRealmRoles
+	if addOnly {
+		deleteRealmRoleFunc = doNotDeleteRealmRoleFromUser
+	}
+
+	if err := a.syncEntityRealmRoles(clientID, realm, realmRoles, mappings.RealmMappings,
+		a.client.CreateClientScopeMappingsRealmRoles, deleteRealmRoleFunc); err != nil {
+		return errors.Wrap(err, "unable to sync client scope mappings of realm roles")
+	}
+
+	// syncEntityClientRoles passes the id of the role client before the id of the entity.
+	addClientRoleFunc := func(ctx context.Context, token, realm, roleClientID, entityID string, roles []gocloak.Role) error {
+		return a.client.CreateClientScopeMappingsClientRoles(ctx, token, realm, entityID, roleClientID, roles)
+	}
+
+	deleteClientRoleFunc := func(ctx context.Context, token, realm, roleClientID, entityID string, roles []gocloak.Role) error {
+		return a.client.DeleteClientScopeMappingsClientRoles(ctx, token, realm, entityID, roleClientID, roles)
+	}
+
+	if addOnly {
+		deleteClientRoleFunc = doNotDeleteClientRoleFromUser
+	}
+
+	if err := a.syncEntityClientRoles(realm, clientID, clientRoles, mappings.ClientMappings,
+		addClientRoleFunc, deleteClientRoleFunc); err != nil {
+		return errors.Wrap(err, "unable to sync client scope mappings of client roles")
+	}
+
+	return nil
+}
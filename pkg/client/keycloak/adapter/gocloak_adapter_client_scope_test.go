@@ -182,10 +182,7 @@ func TestGoCloakAdapter_UpdateClientScope(t *testing.T) {
 	deleteDefaultClientScope = strings.ReplaceAll(deleteDefaultClientScope, "{clientScopeID}", scopeID)
 	httpmock.RegisterResponder("DELETE", deleteDefaultClientScope, httpmock.NewStringResponder(200, ""))
 
-	deleteClientScopeProtocolMapper := strings.ReplaceAll(deleteClientScopeProtocolMapper, "{realm}", realmName)
-	deleteClientScopeProtocolMapper = strings.ReplaceAll(deleteClientScopeProtocolMapper, "{clientScopeID}", scopeID)
-	deleteClientScopeProtocolMapper = strings.ReplaceAll(deleteClientScopeProtocolMapper, "{protocolMapperID}", "mp_id1")
-	httpmock.RegisterResponder("DELETE", deleteClientScopeProtocolMapper, httpmock.NewStringResponder(200, ""))
+	mockClient.On("DeleteClientScopeProtocolMapper", realmName, scopeID, "mp_id1").Return(nil)
 
 	createClientScopeProtocolMapper := strings.ReplaceAll(createClientScopeProtocolMapper, "{realm}", realmName)
 	createClientScopeProtocolMapper = strings.ReplaceAll(createClientScopeProtocolMapper, "{clientScopeID}", scopeID)
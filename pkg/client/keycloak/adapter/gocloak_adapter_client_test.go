@@ -138,3 +138,48 @@ func TestGoCloakAdapter_AddDefaultScopeToClient(t *testing.T) {
 		})
 	}
 }
+
+func TestGoCloakAdapter_AddOptionalScopeToClient(t *testing.T) {
+	t.Parallel()
+
+	adapter, mockClient, _ := initAdapter()
+	mockClient.On("GetClients", "rl", gocloak.GetClientsParams{ClientID: gocloak.StringP("cl")}).
+		Return([]*gocloak.Client{{ID: gocloak.StringP("clid1"), ClientID: gocloak.StringP("cl")}}, nil)
+	mockClient.On("GetClientsOptionalScopes", "rl", "clid1").
+		Return([]*gocloak.ClientScope{{ID: gocloak.StringP("scid2"), Name: gocloak.StringP("scn2")}}, nil)
+	mockClient.On("AddOptionalScopeToClient", "rl", "clid1", "scid1").Return(nil)
+
+	err := adapter.AddOptionalScopeToClient(context.Background(), "rl", "cl", []ClientScope{
+		{ID: "scid1", Name: "scn1"},
+		{ID: "scid2", Name: "scn2"},
+	})
+	assert.NoError(t, err)
+	mockClient.AssertNumberOfCalls(t, "AddOptionalScopeToClient", 1)
+}
+
+func TestGoCloakAdapter_GetClientDefaultScopes(t *testing.T) {
+	t.Parallel()
+
+	adapter, mockClient, _ := initAdapter()
+	mockClient.On("GetClients", "rl", gocloak.GetClientsParams{ClientID: gocloak.StringP("cl")}).
+		Return([]*gocloak.Client{{ID: gocloak.StringP("clid1"), ClientID: gocloak.StringP("cl")}}, nil)
+	mockClient.On("GetClientsDefaultScopes", "rl", "clid1").
+		Return([]*gocloak.ClientScope{{ID: gocloak.StringP("scid1"), Name: gocloak.StringP("scn1")}, nil}, nil)
+
+	scopes, err := adapter.GetClientDefaultScopes(context.Background(), "rl", "cl")
+	assert.NoError(t, err)
+	assert.Equal(t, []ClientScope{{ID: "scid1", Name: "scn1"}}, scopes)
+}
+
+func TestGoCloakAdapter_RemoveOptionalScopeFromClient(t *testing.T) {
+	t.Parallel()
+
+	adapter, mockClient, _ := initAdapter()
+	mockClient.On("GetClients", "rl", gocloak.GetClientsParams{ClientID: gocloak.StringP("cl")}).
+		Return([]*gocloak.Client{{ID: gocloak.StringP("clid1"), ClientID: gocloak.StringP("cl")}}, nil)
+	mockClient.On("RemoveOptionalScopeFromClient", "rl", "clid1", "scid1").Return(errors.New("failed"))
+
+	err := adapter.RemoveOptionalScopeFromClient(context.Background(), "rl", "cl", []ClientScope{{ID: "scid1", Name: "scn1"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to unlink scope scn1")
+}
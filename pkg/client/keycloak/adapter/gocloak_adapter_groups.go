@@ -2,6 +2,8 @@ package adapter
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
@@ -9,20 +11,8 @@ import (
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 )
 
-type NotFoundError string
-
-func (e NotFoundError) Error() string {
-	return string(e)
-}
-
-func IsErrNotFound(err error) bool {
-	errNotFound := NotFoundError("")
-
-	return errors.As(err, &errNotFound)
-}
-
 func (a GoCloakAdapter) getGroup(realm, groupName string) (*gocloak.Group, error) {
-	groups, err := a.client.GetGroups(context.Background(), a.token.AccessToken, realm, gocloak.GetGroupsParams{
+	groups, err := a.getGroups(context.Background(), realm, gocloak.GetGroupsParams{
 		Search: gocloak.StringP(groupName),
 	})
 	if err != nil {
@@ -49,9 +39,15 @@ func (a GoCloakAdapter) syncGroupRoles(realmName, groupID string, spec *keycloak
 		return errors.Wrapf(err, "unable to sync group realm roles, groupID: %s with spec %+v", groupID, spec)
 	}
 
-	claimedClientRoles := make(map[string][]string)
+	// Entries of the same client are merged, so the roles of the client can be split between several entries.
+	// The client roles are not managed if the list is not set.
+	var claimedClientRoles map[string][]string
+	if spec.ClientRoles != nil {
+		claimedClientRoles = make(map[string][]string)
+	}
+
 	for _, cr := range spec.ClientRoles {
-		claimedClientRoles[cr.ClientID] = cr.Roles
+		claimedClientRoles[cr.ClientID] = append(claimedClientRoles[cr.ClientID], cr.Roles...)
 	}
 
 	if err := a.syncEntityClientRoles(realmName, groupID, claimedClientRoles, roleMap.ClientMappings,
@@ -63,6 +59,11 @@ func (a GoCloakAdapter) syncGroupRoles(realmName, groupID string, spec *keycloak
 }
 
 func (a GoCloakAdapter) syncSubGroups(realm string, group *gocloak.Group, subGroups []string) error {
+	// subgroups can be nested with the path or the parent group of their own custom resources.
+	if len(subGroups) == 0 {
+		return nil
+	}
+
 	currentGroups := a.makeCurrentGroups(group)
 	claimedGroups := make(map[string]struct{})
 
@@ -96,23 +97,32 @@ func (a GoCloakAdapter) syncSubGroups(realm string, group *gocloak.Group, subGro
 	return nil
 }
 
-func (a GoCloakAdapter) SyncRealmGroup(realmName string, spec *keycloakApi.KeycloakRealmGroupSpec) (string, error) {
-	group, err := a.getGroup(realmName, spec.Name)
+// SyncRealmGroup creates or updates the group located at the path from the spec.
+// The group with groupID is moved to the path if it is located elsewhere, missing parent groups are created.
+func (a GoCloakAdapter) SyncRealmGroup(realmName string, spec *keycloakApi.KeycloakRealmGroupSpec, groupID string) (string, error) {
+	targetPath, err := GroupTargetPath(spec)
 	if err != nil {
-		if !IsErrNotFound(err) {
-			return "", errors.Wrapf(err, "unable to get group with spec %+v", spec)
-		}
+		return "", err
+	}
 
-		group = &gocloak.Group{Name: &spec.Name, Path: &spec.Path, Attributes: &spec.Attributes, Access: &spec.Access}
+	group, currentPath, err := a.findGroupForSync(realmName, targetPath, groupID)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to get group with spec %+v", spec)
+	}
 
-		groupID, err := a.client.CreateGroup(context.Background(), a.token.AccessToken, realmName, *group)
+	if group == nil {
+		group, err = a.createGroup(realmName, spec, targetPath)
 		if err != nil {
 			return "", errors.Wrapf(err, "unable to create group with spec %+v", spec)
 		}
-
-		group.ID = &groupID
 	} else {
-		group.Path, group.Access, group.Attributes = &spec.Path, &spec.Access, &spec.Attributes
+		if currentPath != targetPath {
+			if err = a.moveGroup(realmName, group, targetPath); err != nil {
+				return "", errors.Wrapf(err, "unable to move group from %s to %s", currentPath, targetPath)
+			}
+		}
+
+		group.Name, group.Path, group.Access, group.Attributes = &spec.Name, &targetPath, &spec.Access, groupAttributes(spec)
 		if err := a.client.UpdateGroup(context.Background(), a.token.AccessToken, realmName, *group); err != nil {
 			return "", errors.Wrapf(err, "unable to update group, realm: %s, group spec: %+v", realmName, spec)
 		}
@@ -129,8 +139,218 @@ func (a GoCloakAdapter) SyncRealmGroup(realmName string, spec *keycloakApi.Keycl
 	return *group.ID, nil
 }
 
+// findGroupForSync returns the group with groupID or the group located at the target path and its current path.
+// It returns nil group if the group doesn't exist.
+func (a GoCloakAdapter) findGroupForSync(realmName, targetPath, groupID string) (*gocloak.Group, string, error) {
+	if groupID != "" {
+		group, err := a.client.GetGroup(context.Background(), a.token.AccessToken, realmName, groupID)
+
+		exists, err := strip404(err)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "unable to get group by id %s", groupID)
+		}
+
+		if exists {
+			currentPath := targetPath
+			if group.Path != nil {
+				currentPath = *group.Path
+			}
+
+			return group, currentPath, nil
+		}
+	}
+
+	group, err := a.GetGroupByPath(realmName, targetPath)
+	if err != nil {
+		if IsErrNotFound(err) {
+			return nil, "", nil
+		}
+
+		return nil, "", err
+	}
+
+	return group, targetPath, nil
+}
+
+func (a GoCloakAdapter) createGroup(realmName string, spec *keycloakApi.KeycloakRealmGroupSpec,
+	targetPath string) (*gocloak.Group, error) {
+	parentID, err := a.ensureParentGroups(realmName, targetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &gocloak.Group{Name: &spec.Name, Path: &targetPath, Attributes: groupAttributes(spec), Access: &spec.Access}
+
+	var groupID string
+
+	if parentID == "" {
+		groupID, err = a.client.CreateGroup(context.Background(), a.token.AccessToken, realmName, *group)
+	} else {
+		groupID, err = a.client.CreateChildGroup(context.Background(), a.token.AccessToken, realmName, parentID, *group)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	group.ID = &groupID
+
+	return group, nil
+}
+
+// moveGroup moves the existing group to the target path.
+// Keycloak moves the group if it is created as a child of another group or as a top-level group.
+func (a GoCloakAdapter) moveGroup(realmName string, group *gocloak.Group, targetPath string) error {
+	parentID, err := a.ensureParentGroups(realmName, targetPath)
+	if err != nil {
+		return err
+	}
+
+	if parentID == "" {
+		_, err = a.client.CreateGroup(context.Background(), a.token.AccessToken, realmName, *group)
+	} else {
+		_, err = a.client.CreateChildGroup(context.Background(), a.token.AccessToken, realmName, parentID, *group)
+	}
+
+	return err
+}
+
+// ensureParentGroups creates missing parent groups of the path and returns the ID of the direct parent.
+// It returns an empty ID for the top-level path.
+func (a GoCloakAdapter) ensureParentGroups(realmName, groupPath string) (string, error) {
+	segments := splitGroupPath(groupPath)
+	parentID, currentPath := "", ""
+
+	for _, name := range segments[:len(segments)-1] {
+		currentPath += "/" + name
+
+		parent, err := a.GetGroupByPath(realmName, currentPath)
+		if err == nil {
+			parentID = *parent.ID
+			continue
+		}
+
+		if !IsErrNotFound(err) {
+			return "", err
+		}
+
+		newGroup := gocloak.Group{Name: gocloak.StringP(name)}
+
+		if parentID == "" {
+			parentID, err = a.client.CreateGroup(context.Background(), a.token.AccessToken, realmName, newGroup)
+		} else {
+			parentID, err = a.client.CreateChildGroup(context.Background(), a.token.AccessToken, realmName, parentID, newGroup)
+		}
+
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to create parent group %s", currentPath)
+		}
+	}
+
+	return parentID, nil
+}
+
+// GetGroupByPath searches the group by the last path segment and returns the group located at the path.
+func (a GoCloakAdapter) GetGroupByPath(realm, groupPath string) (*gocloak.Group, error) {
+	segments := splitGroupPath(groupPath)
+	if len(segments) == 0 {
+		return nil, errors.Errorf("invalid group path %q", groupPath)
+	}
+
+	groups, err := a.getGroups(context.Background(), realm, gocloak.GetGroupsParams{
+		Search: gocloak.StringP(segments[len(segments)-1]),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to search groups")
+	}
+
+	normalizedPath := "/" + strings.Join(segments, "/")
+
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+
+		if found := findGroupInTree(g, "", normalizedPath); found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, NotFoundError(fmt.Sprintf("group %s not found", normalizedPath))
+}
+
+func findGroupInTree(group *gocloak.Group, parentPath, groupPath string) *gocloak.Group {
+	if group.Name == nil {
+		return nil
+	}
+
+	currentPath := parentPath + "/" + *group.Name
+	if currentPath == groupPath {
+		return group
+	}
+
+	if group.SubGroups == nil || !strings.HasPrefix(groupPath, currentPath+"/") {
+		return nil
+	}
+
+	for i := range *group.SubGroups {
+		if found := findGroupInTree(&(*group.SubGroups)[i], currentPath, groupPath); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// groupAttributes returns the attributes of the group from the spec.
+// Keycloak keeps the current attributes if the attributes are null, so an empty map is sent to remove all of them.
+func groupAttributes(spec *keycloakApi.KeycloakRealmGroupSpec) *map[string][]string {
+	if spec.Attributes == nil {
+		return &map[string][]string{}
+	}
+
+	return &spec.Attributes
+}
+
+// GroupTargetPath returns the full path of the group from the spec.
+// Paths with a single segment are treated as top-level groups.
+func GroupTargetPath(spec *keycloakApi.KeycloakRealmGroupSpec) (string, error) {
+	segments := splitGroupPath(spec.Path)
+	if len(segments) < 2 {
+		return "/" + spec.Name, nil
+	}
+
+	if !strings.HasPrefix(spec.Path, "/") {
+		return "", errors.Errorf("group path %s must start with a slash", spec.Path)
+	}
+
+	if segments[len(segments)-1] != spec.Name {
+		return "", errors.Errorf("the last segment of the group path %s must be equal to the group name %s",
+			spec.Path, spec.Name)
+	}
+
+	return "/" + strings.Join(segments, "/"), nil
+}
+
+func splitGroupPath(groupPath string) []string {
+	return strings.FieldsFunc(groupPath, func(r rune) bool {
+		return r == '/'
+	})
+}
+
+// DeleteGroup deletes the group by the name of the top-level group or by the full path starting with a slash.
 func (a GoCloakAdapter) DeleteGroup(ctx context.Context, realm, groupName string) error {
-	group, err := a.getGroup(realm, groupName)
+	var (
+		group *gocloak.Group
+		err   error
+	)
+
+	if strings.HasPrefix(groupName, "/") {
+		group, err = a.GetGroupByPath(realm, groupName)
+	} else {
+		group, err = a.getGroup(realm, groupName)
+	}
+
 	if err != nil {
 		return errors.Wrapf(err, "unable to get group, realm: %s, group: %s", realm, groupName)
 	}
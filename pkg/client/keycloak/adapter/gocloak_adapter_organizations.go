@@ -0,0 +1,258 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+)
+
+// OrganizationsMinKCVersion is the first major version of keycloak which supports the organizations.
+const OrganizationsMinKCVersion = 26
+
+type Organization struct {
+	ID          string               `json:"id,omitempty"`
+	Name        string               `json:"name"`
+	Alias       string               `json:"alias,omitempty"`
+	Enabled     bool                 `json:"enabled"`
+	Description string               `json:"description,omitempty"`
+	RedirectURL string               `json:"redirectUrl,omitempty"`
+	Attributes  map[string][]string  `json:"attributes,omitempty"`
+	Domains     []OrganizationDomain `json:"domains"`
+}
+
+type OrganizationDomain struct {
+	Name     string `json:"name"`
+	Verified bool   `json:"verified"`
+}
+
+type organizationMember struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+func (a GoCloakAdapter) GetOrganization(ctx context.Context, realm, id string) (*Organization, error) {
+	var org Organization
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realm,
+		keycloakApiParamId:    id,
+	}).SetResult(&org).Get(a.basePath + organizationEntity)
+
+	if err = a.checkError(err, rsp); err != nil {
+		if rsp != nil && rsp.StatusCode() == http.StatusNotFound {
+			return nil, NotFoundError("organization not found")
+		}
+
+		return nil, errors.Wrap(err, "unable to get organization")
+	}
+
+	return &org, nil
+}
+
+// GetOrganizationByName returns the organization with the exact name, NotFoundError is returned if it does not exist.
+func (a GoCloakAdapter) GetOrganizationByName(ctx context.Context, realm, name string) (*Organization, error) {
+	var orgs []Organization
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realm,
+	}).SetQueryParams(map[string]string{
+		"search": name,
+		"exact":  "true",
+	}).SetResult(&orgs).Get(a.basePath + organizations)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to search organizations")
+	}
+
+	for i := range orgs {
+		if orgs[i].Name == name {
+			return &orgs[i], nil
+		}
+	}
+
+	return nil, NotFoundError("organization not found")
+}
+
+// CreateOrganization creates the organization and returns its id.
+func (a GoCloakAdapter) CreateOrganization(ctx context.Context, realm string, org *Organization) (string, error) {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realm,
+	}).SetBody(org).Post(a.basePath + organizations)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return "", errors.Wrap(err, "unable to create organization")
+	}
+
+	id, err := getIDFromResponseLocation(rsp.RawResponse)
+	if err != nil {
+		return "", errors.Wrap(err, "no id in response")
+	}
+
+	return id, nil
+}
+
+func (a GoCloakAdapter) UpdateOrganization(ctx context.Context, realm string, org *Organization) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realm,
+		keycloakApiParamId:    org.ID,
+	}).SetBody(org).Put(a.basePath + organizationEntity)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to update organization")
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) DeleteOrganization(ctx context.Context, realm, id string) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realm,
+		keycloakApiParamId:    id,
+	}).Delete(a.basePath + organizationEntity)
+
+	if err = a.checkError(err, rsp); err != nil {
+		if rsp != nil && rsp.StatusCode() == http.StatusNotFound {
+			return NotFoundError("organization not found")
+		}
+
+		return errors.Wrap(err, "unable to delete organization")
+	}
+
+	return nil
+}
+
+// SyncOrganizationIdentityProviders links the identity providers with the given aliases to the organization
+// and unlinks the ones which are not declared.
+func (a GoCloakAdapter) SyncOrganizationIdentityProviders(ctx context.Context, realm, orgID string,
+	aliases []string) error {
+	var current []IdentityProvider
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realm,
+		keycloakApiParamId:    orgID,
+	}).SetResult(&current).Get(a.basePath + organizationIdentityProviders)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to get organization identity providers")
+	}
+
+	declared := makeStringSet(aliases)
+	linked := make(map[string]struct{}, len(current))
+
+	for i := range current {
+		if _, ok := declared[current[i].Alias]; ok {
+			linked[current[i].Alias] = struct{}{}
+			continue
+		}
+
+		rsp, err = a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamId:    orgID,
+			keycloakApiParamAlias: current[i].Alias,
+		}).Delete(a.basePath + organizationIdentityProvider)
+
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to unlink identity provider %s", current[i].Alias)
+		}
+	}
+
+	for _, alias := range aliases {
+		if _, ok := linked[alias]; ok {
+			continue
+		}
+
+		rsp, err = a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamId:    orgID,
+		}).SetBody(alias).Post(a.basePath + organizationIdentityProviders)
+
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to link identity provider %s", alias)
+		}
+
+		linked[alias] = struct{}{}
+	}
+
+	return nil
+}
+
+// SyncOrganizationMembers adds the users with the given usernames to the organization
+// and removes the members which are not declared.
+func (a GoCloakAdapter) SyncOrganizationMembers(ctx context.Context, realm, orgID string, usernames []string) error {
+	current, err := a.getOrganizationMembers(ctx, realm, orgID)
+	if err != nil {
+		return err
+	}
+
+	declared := makeStringSet(usernames)
+	members := make(map[string]struct{}, len(current))
+
+	for _, m := range current {
+		if _, ok := declared[m.Username]; ok {
+			members[m.Username] = struct{}{}
+			continue
+		}
+
+		rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamId:    orgID,
+			"userID":              m.ID,
+		}).Delete(a.basePath + organizationMemberEntity)
+
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to remove organization member %s", m.Username)
+		}
+	}
+
+	for _, username := range usernames {
+		if _, ok := members[username]; ok {
+			continue
+		}
+
+		users, err := a.getUsers(ctx, realm, gocloak.GetUsersParams{
+			Username: gocloak.StringP(username),
+			Exact:    gocloak.BoolP(true),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "unable to get user %s", username)
+		}
+
+		user, ok := checkFullUsernameMatch(username, users)
+		if !ok {
+			return NotFoundError(fmt.Sprintf("user %s does not exist", username))
+		}
+
+		rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamId:    orgID,
+		}).SetBody(*user.ID).Post(a.basePath + organizationMembers)
+
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to add organization member %s", username)
+		}
+
+		members[username] = struct{}{}
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) getOrganizationMembers(ctx context.Context, realm, orgID string) ([]organizationMember, error) {
+	return listPages(a.listPageSize(), func(first, size int) ([]organizationMember, error) {
+		var page []organizationMember
+
+		rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamId:    orgID,
+		}).SetQueryParams(pageQuery(first, size)).SetResult(&page).Get(a.basePath + organizationMembers)
+
+		if err = a.checkError(err, rsp); err != nil {
+			return nil, errors.Wrap(err, "unable to get organization members")
+		}
+
+		return page, nil
+	})
+}
@@ -0,0 +1,133 @@
+package adapter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_GetOrganizationByName(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/organizations",
+		httpmock.NewJsonResponderOrPanic(200, []Organization{
+			{ID: "id1", Name: "acme-eu"},
+			{ID: "id2", Name: "acme"},
+		}))
+
+	org, err := kcAdapter.GetOrganizationByName(context.Background(), "realm1", "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "id2", org.ID)
+
+	_, err = kcAdapter.GetOrganizationByName(context.Background(), "realm1", "unknown")
+	require.Error(t, err)
+	assert.True(t, IsErrNotFound(err))
+}
+
+func TestGoCloakAdapter_GetOrganization_NotFound(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/organizations/id1",
+		httpmock.NewStringResponder(404, ""))
+
+	_, err := kcAdapter.GetOrganization(context.Background(), "realm1", "id1")
+	require.Error(t, err)
+	assert.True(t, IsErrNotFound(err))
+}
+
+func TestGoCloakAdapter_CreateOrganization(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	rsp := httpmock.NewStringResponse(201, "")
+	rsp.Header.Set("Location", "/admin/realms/realm1/organizations/new-id")
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/organizations",
+		httpmock.ResponderFromResponse(rsp))
+
+	id, err := kcAdapter.CreateOrganization(context.Background(), "realm1", &Organization{
+		Name:    "acme",
+		Domains: []OrganizationDomain{{Name: "acme.example.com"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "new-id", id)
+}
+
+func TestGoCloakAdapter_SyncOrganizationIdentityProviders(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	var linked []string
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/organizations/org1/identity-providers",
+		httpmock.NewJsonResponderOrPanic(200, []IdentityProvider{{Alias: "keep"}, {Alias: "old"}}))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/organizations/org1/identity-providers/old",
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/organizations/org1/identity-providers",
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			linked = append(linked, string(body))
+
+			return httpmock.NewStringResponse(204, ""), nil
+		})
+
+	err := kcAdapter.SyncOrganizationIdentityProviders(context.Background(), "realm1", "org1",
+		[]string{"keep", "new"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new"}, linked)
+
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info["DELETE /admin/realms/realm1/organizations/org1/identity-providers/old"])
+}
+
+func TestGoCloakAdapter_SyncOrganizationMembers(t *testing.T) {
+	kcAdapter, mockClient, _ := initAdapter()
+
+	var added []string
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/organizations/org1/members",
+		httpmock.NewJsonResponderOrPanic(200, []organizationMember{
+			{ID: "u1", Username: "keep"},
+			{ID: "u2", Username: "old"},
+		}))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/organizations/org1/members/u2",
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/organizations/org1/members",
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			added = append(added, string(body))
+
+			return httpmock.NewStringResponse(201, ""), nil
+		})
+
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{
+		Username: gocloak.StringP("new"),
+		Exact:    gocloak.BoolP(true),
+	}).Return([]*gocloak.User{{ID: gocloak.StringP("u3"), Username: gocloak.StringP("new")}}, nil)
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{
+		Username: gocloak.StringP("missing"),
+		Exact:    gocloak.BoolP(true),
+	}).Return([]*gocloak.User{}, nil)
+
+	err := kcAdapter.SyncOrganizationMembers(context.Background(), "realm1", "org1", []string{"keep", "new"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, added)
+
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info["DELETE /admin/realms/realm1/organizations/org1/members/u2"])
+
+	err = kcAdapter.SyncOrganizationMembers(context.Background(), "realm1", "org1", []string{"keep", "missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "user missing does not exist")
+}
@@ -0,0 +1,145 @@
+package adapter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+)
+
+// DefaultPageSize is the number of the items requested at once by the list operations,
+// it is the default page size of the keycloak API.
+const DefaultPageSize = 100
+
+// SetPageSize sets the number of the users, groups and clients requested from keycloak at once.
+// The list operations request the pages until the last one, so the size only changes the number of the requests.
+// The default page size is used if the size is not positive.
+func (a *GoCloakAdapter) SetPageSize(size int) {
+	a.pageSize = size
+}
+
+func (a GoCloakAdapter) listPageSize() int {
+	if a.pageSize <= 0 {
+		return DefaultPageSize
+	}
+
+	return a.pageSize
+}
+
+// listPages requests the pages of the list until the page is not full and returns the items of all pages.
+func listPages[T any](size int, list func(first, size int) ([]T, error)) ([]T, error) {
+	var items []T
+
+	for first := 0; ; first += size {
+		page, err := list(first, size)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+
+		if len(page) < size {
+			return items, nil
+		}
+	}
+}
+
+func pageQuery(first, size int) map[string]string {
+	return map[string]string{
+		"first": strconv.Itoa(first),
+		"max":   strconv.Itoa(size),
+	}
+}
+
+func (a GoCloakAdapter) getUsers(ctx context.Context, realm string, params gocloak.GetUsersParams) ([]*gocloak.User, error) {
+	return listPages(a.listPageSize(), func(first, size int) ([]*gocloak.User, error) {
+		params.First, params.Max = gocloak.IntP(first), gocloak.IntP(size)
+
+		return a.client.GetUsers(ctx, a.token.AccessToken, realm, params)
+	})
+}
+
+func (a GoCloakAdapter) getGroups(ctx context.Context, realm string,
+	params gocloak.GetGroupsParams) ([]*gocloak.Group, error) {
+	return listPages(a.listPageSize(), func(first, size int) ([]*gocloak.Group, error) {
+		params.First, params.Max = gocloak.IntP(first), gocloak.IntP(size)
+
+		return a.client.GetGroups(ctx, a.token.AccessToken, realm, params)
+	})
+}
+
+func (a GoCloakAdapter) getClients(ctx context.Context, realm string,
+	params gocloak.GetClientsParams) ([]*gocloak.Client, error) {
+	return listPages(a.listPageSize(), func(first, size int) ([]*gocloak.Client, error) {
+		params.First, params.Max = gocloak.IntP(first), gocloak.IntP(size)
+
+		return a.client.GetClients(ctx, a.token.AccessToken, realm, params)
+	})
+}
+
+func (a GoCloakAdapter) getUsersByClientRoleName(ctx context.Context, realm, clientID, roleName string,
+	params gocloak.GetUsersByRoleParams) ([]*gocloak.User, error) {
+	return listPages(a.listPageSize(), func(first, size int) ([]*gocloak.User, error) {
+		params.First, params.Max = gocloak.IntP(first), gocloak.IntP(size)
+
+		return a.client.GetUsersByClientRoleName(ctx, a.token.AccessToken, realm, clientID, roleName, params)
+	})
+}
+
+// getUsersByRoleName returns the users of the realm role. The role users and groups are requested without gocloak,
+// because it doesn't page the requests and keycloak returns only the first page.
+func (a GoCloakAdapter) getUsersByRoleName(ctx context.Context, realm, roleName string) ([]*gocloak.User, error) {
+	return listPages(a.listPageSize(), func(first, size int) ([]*gocloak.User, error) {
+		var page []*gocloak.User
+
+		rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamRole:  roleName,
+		}).SetQueryParams(pageQuery(first, size)).SetResult(&page).Get(a.basePath + realmRoleUsers)
+
+		if err = a.checkError(err, rsp); err != nil {
+			return nil, errors.Wrap(err, "unable to get users by role name")
+		}
+
+		return page, nil
+	})
+}
+
+// getGroupsByRole returns the groups of the realm role.
+func (a GoCloakAdapter) getGroupsByRole(ctx context.Context, realm, roleName string) ([]*gocloak.Group, error) {
+	return listPages(a.listPageSize(), func(first, size int) ([]*gocloak.Group, error) {
+		var page []*gocloak.Group
+
+		rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamRole:  roleName,
+		}).SetQueryParams(pageQuery(first, size)).SetResult(&page).Get(a.basePath + realmRoleGroups)
+
+		if err = a.checkError(err, rsp); err != nil {
+			return nil, errors.Wrap(err, "unable to get groups by role")
+		}
+
+		return page, nil
+	})
+}
+
+// getGroupsByClientRole returns the groups of the client role, clientID is the id of the client, not its clientId.
+func (a GoCloakAdapter) getGroupsByClientRole(ctx context.Context, realm, clientID,
+	roleName string) ([]*gocloak.Group, error) {
+	return listPages(a.listPageSize(), func(first, size int) ([]*gocloak.Group, error) {
+		var page []*gocloak.Group
+
+		rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamId:    clientID,
+			keycloakApiParamRole:  roleName,
+		}).SetQueryParams(pageQuery(first, size)).SetResult(&page).Get(a.basePath + clientRoleGroups)
+
+		if err = a.checkError(err, rsp); err != nil {
+			return nil, errors.Wrap(err, "unable to get groups by client role")
+		}
+
+		return page, nil
+	})
+}
@@ -0,0 +1,66 @@
+package adapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestGoCloakAdapter_ExistRealmUser_Pagination(t *testing.T) {
+	users := make([]*gocloak.User, 0, 5)
+	for i := 1; i <= 5; i++ {
+		users = append(users, &gocloak.User{ID: gocloak.StringP(strconv.Itoa(i)),
+			Username: gocloak.StringP(fmt.Sprintf("user%d", i))})
+	}
+
+	var pages []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		first, _ := strconv.Atoi(r.URL.Query().Get("first"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("max"))
+		pages = append(pages, r.URL.Query().Get("first"))
+
+		last := first + size
+		if last > len(users) {
+			last = len(users)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(users[first:last]))
+	}))
+	defer server.Close()
+
+	a := GoCloakAdapter{
+		client:   gocloak.NewClient(server.URL),
+		token:    &gocloak.JWT{AccessToken: "token"},
+		log:      mock.NewLogr(),
+		basePath: server.URL,
+		pageSize: 2,
+	}
+
+	exists, err := a.ExistRealmUser("realm1", &dto.User{Username: "user5"})
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, []string{"0", "2", "4"}, pages)
+}
+
+func TestGoCloakAdapter_SetPageSize(t *testing.T) {
+	a := GoCloakAdapter{}
+	assert.Equal(t, DefaultPageSize, a.listPageSize())
+
+	a.SetPageSize(500)
+	assert.Equal(t, 500, a.listPageSize())
+
+	a.SetPageSize(0)
+	assert.Equal(t, DefaultPageSize, a.listPageSize())
+}
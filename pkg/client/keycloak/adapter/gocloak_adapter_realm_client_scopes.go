@@ -0,0 +1,167 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+// realmClientScopeType is a type of the realm default client scopes, default or optional.
+type realmClientScopeType struct {
+	name   string
+	get    string
+	put    string
+	delete string
+}
+
+var (
+	realmDefaultClientScopeType = realmClientScopeType{
+		name:   "default",
+		get:    getDefaultClientScopes,
+		put:    putDefaultClientScope,
+		delete: deleteDefaultClientScope,
+	}
+	realmOptionalClientScopeType = realmClientScopeType{
+		name:   "optional",
+		get:    getOptionalClientScopes,
+		put:    putOptionalClientScope,
+		delete: deleteOptionalClientScope,
+	}
+)
+
+// SyncRealmDefaultClientScopes makes the realm default and optional client scopes of the new clients
+// match the declared ones, the scopes of the type are not managed if the list is nil.
+// Keycloak keeps a single type of the realm scope, so the scopes are unset before they are set with the other type.
+func (a GoCloakAdapter) SyncRealmDefaultClientScopes(ctx context.Context, realmName string, defaultScopes,
+	optionalScopes []string) error {
+	declaredDefault := makeStringSet(defaultScopes)
+	declaredOptional := makeStringSet(optionalScopes)
+
+	for name := range declaredDefault {
+		if _, ok := declaredOptional[name]; ok {
+			return errors.Errorf("client scope %s can not be both default and optional", name)
+		}
+	}
+
+	currentDefault, err := a.unsetUndeclaredRealmClientScopes(ctx, realmName, realmDefaultClientScopeType,
+		defaultScopes, declaredOptional)
+	if err != nil {
+		return err
+	}
+
+	currentOptional, err := a.unsetUndeclaredRealmClientScopes(ctx, realmName, realmOptionalClientScopeType,
+		optionalScopes, declaredDefault)
+	if err != nil {
+		return err
+	}
+
+	if err := a.setRealmClientScopes(ctx, realmName, realmDefaultClientScopeType, defaultScopes,
+		currentDefault); err != nil {
+		return err
+	}
+
+	return a.setRealmClientScopes(ctx, realmName, realmOptionalClientScopeType, optionalScopes, currentOptional)
+}
+
+// unsetUndeclaredRealmClientScopes unsets the realm scopes of the type which are not declared or are declared
+// with the other type. It returns the names of the scopes of the type which are kept.
+func (a GoCloakAdapter) unsetUndeclaredRealmClientScopes(ctx context.Context, realmName string,
+	scopeType realmClientScopeType, declared []string, declaredOther map[string]struct{}) (map[string]struct{}, error) {
+	kept := make(map[string]struct{})
+
+	if declared == nil && len(declaredOther) == 0 {
+		return kept, nil
+	}
+
+	current, err := a.getRealmClientScopes(ctx, realmName, scopeType)
+	if err != nil {
+		return nil, err
+	}
+
+	declaredSet := makeStringSet(declared)
+
+	for _, s := range current {
+		_, isDeclared := declaredSet[s.Name]
+		_, isOther := declaredOther[s.Name]
+
+		if isDeclared || (declared == nil && !isOther) {
+			kept[s.Name] = struct{}{}
+			continue
+		}
+
+		if err := a.changeRealmClientScope(ctx, realmName, scopeType, s.ID, false); err != nil {
+			return nil, errors.Wrapf(err, "unable to unset %s client scope %s", scopeType.name, s.Name)
+		}
+	}
+
+	return kept, nil
+}
+
+// setRealmClientScopes sets the declared realm scopes of the type which are not set yet.
+func (a GoCloakAdapter) setRealmClientScopes(ctx context.Context, realmName string, scopeType realmClientScopeType,
+	declared []string, current map[string]struct{}) error {
+	missing := make([]string, 0, len(declared))
+
+	for _, name := range declared {
+		if _, ok := current[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	scopes, err := a.GetClientScopesByNames(ctx, realmName, missing)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get %s client scopes", scopeType.name)
+	}
+
+	for _, s := range scopes {
+		if err := a.changeRealmClientScope(ctx, realmName, scopeType, s.ID, true); err != nil {
+			return errors.Wrapf(err, "unable to set %s client scope %s", scopeType.name, s.Name)
+		}
+	}
+
+	return nil
+}
+
+func (a GoCloakAdapter) getRealmClientScopes(ctx context.Context, realmName string,
+	scopeType realmClientScopeType) ([]ClientScope, error) {
+	var scopes []ClientScope
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetResult(&scopes).Get(a.basePath + scopeType.get)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrapf(err, "unable to get %s client scopes for realm", scopeType.name)
+	}
+
+	return scopes, nil
+}
+
+func (a GoCloakAdapter) changeRealmClientScope(ctx context.Context, realmName string, scopeType realmClientScopeType,
+	scopeID string, set bool) error {
+	method, path := resty.MethodDelete, scopeType.delete
+	if set {
+		method, path = resty.MethodPut, scopeType.put
+	}
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm:         realmName,
+		keycloakApiParamClientScopeId: scopeID,
+	}).Execute(method, a.basePath+path)
+
+	return a.checkError(err, rsp)
+}
+
+func makeStringSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+
+	return set
+}
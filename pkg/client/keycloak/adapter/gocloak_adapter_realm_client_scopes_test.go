@@ -0,0 +1,80 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_SyncRealmDefaultClientScopes(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/default-default-client-scopes",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []ClientScope{
+			{ID: "profile-id", Name: "profile"},
+			{ID: "roles-id", Name: "roles"},
+		}))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/default-optional-client-scopes",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []ClientScope{
+			{ID: "email-id", Name: "email"},
+			{ID: "phone-id", Name: "phone"},
+		}))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/client-scopes",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []ClientScope{
+			{ID: "profile-id", Name: "profile"},
+			{ID: "roles-id", Name: "roles"},
+			{ID: "email-id", Name: "email"},
+			{ID: "phone-id", Name: "phone"},
+			{ID: "address-id", Name: "address"},
+		}))
+
+	for _, path := range []string{
+		"/admin/realms/realm1/default-default-client-scopes/roles-id",
+		"/admin/realms/realm1/default-optional-client-scopes/email-id",
+	} {
+		httpmock.RegisterResponder(http.MethodDelete, path, httpmock.NewStringResponder(http.StatusNoContent, ""))
+	}
+
+	for _, path := range []string{
+		"/admin/realms/realm1/default-default-client-scopes/email-id",
+		"/admin/realms/realm1/default-optional-client-scopes/address-id",
+	} {
+		httpmock.RegisterResponder(http.MethodPut, path, httpmock.NewStringResponder(http.StatusNoContent, ""))
+	}
+
+	// roles is removed, email is moved from the optional scopes, address is added and phone is kept.
+	require.NoError(t, a.SyncRealmDefaultClientScopes(context.Background(), "realm1",
+		[]string{"profile", "email"}, []string{"phone", "address"}))
+
+	info := httpmock.GetCallCountInfo()
+	require.Equal(t, 1, info["DELETE /admin/realms/realm1/default-default-client-scopes/roles-id"])
+	require.Equal(t, 1, info["DELETE /admin/realms/realm1/default-optional-client-scopes/email-id"])
+	require.Equal(t, 1, info["PUT /admin/realms/realm1/default-default-client-scopes/email-id"])
+	require.Equal(t, 1, info["PUT /admin/realms/realm1/default-optional-client-scopes/address-id"])
+}
+
+func TestGoCloakAdapter_SyncRealmDefaultClientScopes_OnlyDefault(t *testing.T) {
+	a, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/default-default-client-scopes",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []ClientScope{{ID: "profile-id", Name: "profile"}}))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/default-optional-client-scopes",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []ClientScope{{ID: "phone-id", Name: "phone"}}))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/client-scopes",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []ClientScope{{ID: "profile-id", Name: "profile"}}))
+
+	// the undeclared optional scopes are not managed.
+	require.NoError(t, a.SyncRealmDefaultClientScopes(context.Background(), "realm1",
+		[]string{"profile"}, nil))
+
+	err := a.SyncRealmDefaultClientScopes(context.Background(), "realm1", []string{"profile", "email"}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to get 'email' keycloak client scopes")
+
+	err = a.SyncRealmDefaultClientScopes(context.Background(), "realm1", []string{"profile"}, []string{"profile"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "client scope profile can not be both default and optional")
+}
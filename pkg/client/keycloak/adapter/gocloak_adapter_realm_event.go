@@ -1,9 +1,16 @@
 package adapter
 
 import (
+	"context"
+	"strconv"
+
 	"github.com/pkg/errors"
 )
 
+// realmAdminEventsExpirationAttribute is a realm attribute with the admin events expiration,
+// keycloak doesn't accept it in the realm events config.
+const realmAdminEventsExpirationAttribute = "adminEventsExpiration"
+
 type RealmEventConfig struct {
 	AdminEventsDetailsEnabled bool     `json:"adminEventsDetailsEnabled"`
 	AdminEventsEnabled        bool     `json:"adminEventsEnabled"`
@@ -11,6 +18,7 @@ type RealmEventConfig struct {
 	EventsEnabled             bool     `json:"eventsEnabled"`
 	EventsExpiration          int      `json:"eventsExpiration"`
 	EventsListeners           []string `json:"eventsListeners"`
+	AdminEventsExpiration     *int     `json:"-"`
 }
 
 func (a GoCloakAdapter) SetRealmEventConfig(realmName string, eventConfig *RealmEventConfig) error {
@@ -23,5 +31,47 @@ func (a GoCloakAdapter) SetRealmEventConfig(realmName string, eventConfig *Realm
 		return errors.Wrap(err, "error during set realm event config request")
 	}
 
+	if eventConfig.AdminEventsExpiration != nil {
+		if err := a.setRealmAdminEventsExpiration(realmName, *eventConfig.AdminEventsExpiration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setRealmAdminEventsExpiration sets the admin events expiration of the realm, 0 removes the expiration.
+func (a GoCloakAdapter) setRealmAdminEventsExpiration(realmName string, expiration int) error {
+	realm, err := a.client.GetRealm(context.Background(), a.token.AccessToken, realmName)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get realm: %s", realmName)
+	}
+
+	current, ok := "", false
+	if realm.Attributes != nil {
+		current, ok = (*realm.Attributes)[realmAdminEventsExpirationAttribute]
+	}
+
+	value := strconv.Itoa(expiration)
+
+	if (expiration == 0 && !ok) || (expiration > 0 && current == value) {
+		return nil
+	}
+
+	if realm.Attributes == nil {
+		attributes := make(map[string]string)
+		realm.Attributes = &attributes
+	}
+
+	if expiration == 0 {
+		delete(*realm.Attributes, realmAdminEventsExpirationAttribute)
+	} else {
+		(*realm.Attributes)[realmAdminEventsExpirationAttribute] = value
+	}
+
+	if err := a.client.UpdateRealm(context.Background(), a.token.AccessToken, *realm); err != nil {
+		return errors.Wrap(err, "unable to set realm admin events expiration")
+	}
+
 	return nil
 }
@@ -0,0 +1,86 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+)
+
+// RealmExport is a realm representation returned by the keycloak partial export.
+// Only the parts of the representation which are managed by the operator are decoded.
+type RealmExport struct {
+	Realm               string                      `json:"realm"`
+	BrowserFlow         string                      `json:"browserFlow,omitempty"`
+	Clients             []gocloak.Client            `json:"clients,omitempty"`
+	Groups              []ExportGroup               `json:"groups,omitempty"`
+	Roles               ExportRoles                 `json:"roles"`
+	AuthenticationFlows []ExportAuthFlow            `json:"authenticationFlows,omitempty"`
+	AuthenticatorConfig []ExportAuthenticatorConfig `json:"authenticatorConfig,omitempty"`
+	ClientScopes        []ClientScope               `json:"clientScopes,omitempty"`
+	IdentityProviders   []IdentityProvider          `json:"identityProviders,omitempty"`
+	// Components are the components of the realm by the provider type.
+	Components map[string][]ExportComponent `json:"components,omitempty"`
+}
+
+type ExportGroup struct {
+	Name        string              `json:"name"`
+	Path        string              `json:"path,omitempty"`
+	Attributes  map[string][]string `json:"attributes,omitempty"`
+	RealmRoles  []string            `json:"realmRoles,omitempty"`
+	ClientRoles map[string][]string `json:"clientRoles,omitempty"`
+	SubGroups   []ExportGroup       `json:"subGroups,omitempty"`
+}
+
+type ExportRoles struct {
+	Realm  []gocloak.Role            `json:"realm,omitempty"`
+	Client map[string][]gocloak.Role `json:"client,omitempty"`
+}
+
+type ExportAuthFlow struct {
+	Alias                    string                `json:"alias"`
+	Description              string                `json:"description,omitempty"`
+	ProviderID               string                `json:"providerId"`
+	TopLevel                 bool                  `json:"topLevel"`
+	BuiltIn                  bool                  `json:"builtIn"`
+	AuthenticationExecutions []ExportAuthExecution `json:"authenticationExecutions,omitempty"`
+}
+
+type ExportAuthExecution struct {
+	Authenticator       string `json:"authenticator,omitempty"`
+	AuthenticatorConfig string `json:"authenticatorConfig,omitempty"`
+	AuthenticatorFlow   bool   `json:"authenticatorFlow,omitempty"`
+	FlowAlias           string `json:"flowAlias,omitempty"`
+	Requirement         string `json:"requirement,omitempty"`
+	Priority            int    `json:"priority,omitempty"`
+}
+
+type ExportComponent struct {
+	Name       string              `json:"name"`
+	ProviderID string              `json:"providerId"`
+	Config     map[string][]string `json:"config,omitempty"`
+}
+
+type ExportAuthenticatorConfig struct {
+	Alias  string            `json:"alias"`
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// ExportRealm exports the realm with the clients, groups and roles, the identity providers and the components,
+// the secrets are masked by keycloak.
+func (a GoCloakAdapter) ExportRealm(ctx context.Context, realm string) (*RealmExport, error) {
+	var export RealmExport
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realm,
+	}).SetQueryParams(map[string]string{
+		"exportClients":        "true",
+		"exportGroupsAndRoles": "true",
+	}).SetResult(&export).Post(a.basePath + realmPartialExport)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to export realm")
+	}
+
+	return &export, nil
+}
@@ -0,0 +1,44 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_ExportRealm(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodPost,
+		"/admin/realms/realm1/partial-export?exportClients=true&exportGroupsAndRoles=true",
+		httpmock.NewJsonResponderOrPanic(200, json.RawMessage(`{
+			"realm": "realm1",
+			"clients": [{"clientId": "app", "publicClient": true}],
+			"groups": [{"name": "team", "subGroups": [{"name": "dev", "realmRoles": ["developer"]}]}],
+			"roles": {"realm": [{"name": "developer"}], "client": {"app": [{"name": "viewer"}]}},
+			"authenticationFlows": [{"alias": "browser-otp", "providerId": "basic-flow", "topLevel": true,
+				"authenticationExecutions": [{"authenticator": "auth-cookie", "requirement": "ALTERNATIVE"}]}]
+		}`)))
+	httpmock.RegisterResponder(http.MethodPost,
+		"/admin/realms/realm2/partial-export?exportClients=true&exportGroupsAndRoles=true",
+		httpmock.NewStringResponder(403, ""))
+
+	export, err := kcAdapter.ExportRealm(context.Background(), "realm1")
+	require.NoError(t, err)
+	assert.Equal(t, "realm1", export.Realm)
+	require.Len(t, export.Clients, 1)
+	assert.Equal(t, "app", *export.Clients[0].ClientID)
+	require.Len(t, export.Groups, 1)
+	assert.Equal(t, []string{"developer"}, export.Groups[0].SubGroups[0].RealmRoles)
+	assert.Equal(t, "viewer", *export.Roles.Client["app"][0].Name)
+	require.Len(t, export.AuthenticationFlows, 1)
+	assert.Equal(t, "auth-cookie", export.AuthenticationFlows[0].AuthenticationExecutions[0].Authenticator)
+
+	_, err = kcAdapter.ExportRealm(context.Background(), "realm2")
+	require.Error(t, err)
+}
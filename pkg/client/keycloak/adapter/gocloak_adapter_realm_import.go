@@ -0,0 +1,117 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Policies of the partial import for the resources which already exist in the realm.
+const (
+	PartialImportIfExistsFail      = "FAIL"
+	PartialImportIfExistsSkip      = "SKIP"
+	PartialImportIfExistsOverwrite = "OVERWRITE"
+)
+
+// Types of the resources in the partial import results.
+const (
+	PartialImportResourceUser       = "USER"
+	PartialImportResourceGroup      = "GROUP"
+	PartialImportResourceClient     = "CLIENT"
+	PartialImportResourceIDP        = "IDP"
+	PartialImportResourceRealmRole  = "REALM_ROLE"
+	PartialImportResourceClientRole = "CLIENT_ROLE"
+)
+
+// Actions of the partial import results.
+const (
+	PartialImportActionAdded       = "ADDED"
+	PartialImportActionOverwritten = "OVERWRITTEN"
+	PartialImportActionSkipped     = "SKIPPED"
+)
+
+const partialImportIfExistsField = "ifResourceExists"
+
+// PartialImportResult is a summary of the realm partial import.
+type PartialImportResult struct {
+	Added       int                       `json:"added"`
+	Overwritten int                       `json:"overwritten"`
+	Skipped     int                       `json:"skipped"`
+	Results     []PartialImportResultItem `json:"results,omitempty"`
+}
+
+type PartialImportResultItem struct {
+	Action       string `json:"action"`
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	ID           string `json:"id,omitempty"`
+}
+
+// PartialImport imports the users, clients, groups, roles and identity providers
+// of the realm representation into the realm.
+// The ifResourceExists policy defines how the resources which already exist in the realm are handled.
+func (a GoCloakAdapter) PartialImport(ctx context.Context, realm string, representation map[string]interface{},
+	ifResourceExists string) (*PartialImportResult, error) {
+	body := make(map[string]interface{}, len(representation)+1)
+	for k, v := range representation {
+		body[k] = v
+	}
+
+	body[partialImportIfExistsField] = ifResourceExists
+
+	var result PartialImportResult
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realm,
+	}).SetBody(body).SetResult(&result).Post(a.basePath + realmPartialImport)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to import realm representation")
+	}
+
+	return &result, nil
+}
+
+// DeleteImportedResource deletes the resource from the partial import results.
+// Identity providers are deleted by the name, the other resources are deleted by the id.
+func (a GoCloakAdapter) DeleteImportedResource(ctx context.Context, realm string,
+	resource *PartialImportResultItem) error {
+	params := map[string]string{
+		keycloakApiParamRealm: realm,
+		keycloakApiParamId:    resource.ID,
+	}
+
+	var path string
+
+	switch resource.ResourceType {
+	case PartialImportResourceUser:
+		path = deleteRealmUser
+	case PartialImportResourceGroup:
+		path = realmGroupEntity
+	case PartialImportResourceClient:
+		path = realmClientEntity
+	case PartialImportResourceRealmRole, PartialImportResourceClientRole:
+		path = realmRoleByID
+	case PartialImportResourceIDP:
+		path = identityProviderEntity
+		params = map[string]string{
+			keycloakApiParamRealm: realm,
+			keycloakApiParamAlias: resource.ResourceName,
+		}
+	default:
+		return errors.Errorf("unsupported imported resource type %s", resource.ResourceType)
+	}
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(params).Delete(a.basePath + path)
+
+	if err = a.checkError(err, rsp); err != nil {
+		if rsp != nil && rsp.StatusCode() == http.StatusNotFound {
+			return NotFoundError("imported resource not found")
+		}
+
+		return errors.Wrapf(err, "unable to delete %s %s", resource.ResourceType, resource.ResourceName)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_PartialImport(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	var body map[string]interface{}
+
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm1/partialImport",
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewJsonResponse(200, PartialImportResult{
+				Added:   1,
+				Skipped: 1,
+				Results: []PartialImportResultItem{
+					{Action: "ADDED", ResourceType: "USER", ResourceName: "user1", ID: "id1"},
+					{Action: "SKIPPED", ResourceType: "CLIENT", ResourceName: "client1", ID: "id2"},
+				},
+			})
+		})
+
+	representation := map[string]interface{}{
+		"users":   []interface{}{map[string]interface{}{"username": "user1"}},
+		"clients": []interface{}{map[string]interface{}{"clientId": "client1"}},
+	}
+
+	result, err := kcAdapter.PartialImport(context.Background(), "realm1", representation, PartialImportIfExistsSkip)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Len(t, result.Results, 2)
+	assert.Equal(t, PartialImportIfExistsSkip, body["ifResourceExists"])
+	assert.Contains(t, body, "users")
+	assert.NotContains(t, representation, "ifResourceExists")
+
+	httpmock.RegisterResponder(http.MethodPost, "/admin/realms/realm2/partialImport",
+		httpmock.NewStringResponder(409, `{"errorMessage":"User exists with same username"}`))
+
+	_, err = kcAdapter.PartialImport(context.Background(), "realm2", representation, PartialImportIfExistsFail)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to import realm representation")
+}
+
+func TestGoCloakAdapter_DeleteImportedResource(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/clients/client-id",
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/roles-by-id/role-id",
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/identity-provider/instances/github",
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/groups/group-id",
+		httpmock.NewStringResponder(404, ""))
+
+	ctx := context.Background()
+
+	require.NoError(t, kcAdapter.DeleteImportedResource(ctx, "realm1", &PartialImportResultItem{
+		ResourceType: PartialImportResourceClient, ResourceName: "client1", ID: "client-id"}))
+	require.NoError(t, kcAdapter.DeleteImportedResource(ctx, "realm1", &PartialImportResultItem{
+		ResourceType: PartialImportResourceClientRole, ResourceName: "role1", ID: "role-id"}))
+	require.NoError(t, kcAdapter.DeleteImportedResource(ctx, "realm1", &PartialImportResultItem{
+		ResourceType: PartialImportResourceIDP, ResourceName: "github", ID: "idp-id"}))
+
+	err := kcAdapter.DeleteImportedResource(ctx, "realm1", &PartialImportResultItem{
+		ResourceType: PartialImportResourceGroup, ResourceName: "group1", ID: "group-id"})
+	require.Error(t, err)
+	assert.True(t, IsErrNotFound(err))
+
+	err = kcAdapter.DeleteImportedResource(ctx, "realm1", &PartialImportResultItem{ResourceType: "UNKNOWN"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported imported resource type UNKNOWN")
+}
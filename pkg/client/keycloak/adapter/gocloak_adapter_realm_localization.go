@@ -0,0 +1,59 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// SyncRealmLocalizationTexts syncs the message overrides of the realm locale,
+// the overrides which are not in the texts are removed.
+func (a GoCloakAdapter) SyncRealmLocalizationTexts(ctx context.Context, realmName, locale string,
+	texts map[string]string) error {
+	log := a.log.WithValues(logKeyRealm, realmName, "locale", locale)
+	log.Info("Start syncing realm localization texts")
+
+	current := make(map[string]string)
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+		"locale":              locale,
+	}).SetResult(&current).Get(a.basePath + realmLocalization)
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to get realm localization texts")
+	}
+
+	for key, text := range texts {
+		if currentText, ok := current[key]; ok && currentText == text {
+			continue
+		}
+
+		rsp, err = a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			"locale":              locale,
+			"key":                 key,
+		}).SetHeader("Content-Type", "text/plain").SetBody(text).Put(a.basePath + realmLocalizationText)
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to set realm localization text %s", key)
+		}
+	}
+
+	for key := range current {
+		if _, ok := texts[key]; ok {
+			continue
+		}
+
+		rsp, err = a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+			keycloakApiParamRealm: realmName,
+			"locale":              locale,
+			"key":                 key,
+		}).Delete(a.basePath + realmLocalizationText)
+		if err = a.checkError(err, rsp); err != nil {
+			return errors.Wrapf(err, "unable to delete realm localization text %s", key)
+		}
+	}
+
+	log.Info("Realm localization texts have been synced")
+
+	return nil
+}
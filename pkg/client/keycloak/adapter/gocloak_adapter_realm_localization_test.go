@@ -0,0 +1,60 @@
+package adapter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_SyncRealmLocalizationTexts(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/localization/de",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]string{
+			"loginTitle": "Anmelden",
+			"doLogIn":    "Login",
+			"obsolete":   "Alt",
+		}))
+
+	var putBody string
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/localization/de/doLogIn",
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			putBody = string(body)
+
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+	httpmock.RegisterResponder(http.MethodDelete, "/admin/realms/realm1/localization/de/obsolete",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	err := kcAdapter.SyncRealmLocalizationTexts(context.Background(), "realm1", "de", map[string]string{
+		"loginTitle": "Anmelden",
+		"doLogIn":    "Einloggen",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Einloggen", putBody)
+
+	info := httpmock.GetCallCountInfo()
+	require.Equal(t, 0, info["PUT /admin/realms/realm1/localization/de/loginTitle"])
+	require.Equal(t, 1, info["DELETE /admin/realms/realm1/localization/de/obsolete"])
+}
+
+func TestGoCloakAdapter_SyncRealmLocalizationTexts_GetError(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/localization/de",
+		httpmock.NewStringResponder(http.StatusInternalServerError, "fatal"))
+
+	err := kcAdapter.SyncRealmLocalizationTexts(context.Background(), "realm1", "de", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to get realm localization texts")
+}
@@ -3,7 +3,7 @@ package adapter
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/Nerzal/gocloak/v12"
@@ -16,6 +16,199 @@ type RealmSettings struct {
 	Themes                 *RealmThemes
 	BrowserSecurityHeaders *map[string]string
 	PasswordPolicies       []PasswordPolicy
+	SMTP                   *RealmSMTP
+	BruteForceProtection   *RealmBruteForceProtection
+	TokenSettings          *RealmTokenSettings
+	LoginSettings          *RealmLoginSettings
+	Localization           *RealmLocalization
+	// FrontendURL is the realm frontend URL, the empty value removes it.
+	FrontendURL *string
+	// AdminURL is the root URL of the realm admin console, the empty value restores the keycloak admin URL.
+	AdminURL   *string
+	CIBAPolicy *RealmCIBAPolicy
+}
+
+const (
+	// realmFrontendURLAttribute is a realm attribute with the realm frontend URL.
+	realmFrontendURLAttribute = "frontendUrl"
+	// adminConsoleClientID is the client of the realm admin console.
+	adminConsoleClientID = "security-admin-console"
+	// defaultAdminConsoleRootURL is the root URL of the admin console client which resolves to the keycloak admin URL.
+	defaultAdminConsoleRootURL = "${authAdminUrl}"
+)
+
+// RealmCIBAPolicy is the realm CIBA policy, keycloak keeps it in the realm attributes.
+type RealmCIBAPolicy struct {
+	BackchannelTokenDeliveryMode string
+	ExpiresIn                    int
+	Interval                     int
+	AuthRequestedUserHint        string
+}
+
+// apply sets the CIBA policy to the realm attributes, the empty values keep the realm settings.
+func (p *RealmCIBAPolicy) apply(realm *gocloak.RealmRepresentation) {
+	if p.BackchannelTokenDeliveryMode != "" {
+		setRealmAttribute(realm, "cibaBackchannelTokenDeliveryMode", p.BackchannelTokenDeliveryMode)
+	}
+
+	if p.ExpiresIn > 0 {
+		setRealmAttribute(realm, "cibaExpiresIn", strconv.Itoa(p.ExpiresIn))
+	}
+
+	setRealmAttribute(realm, "cibaInterval", strconv.Itoa(p.Interval))
+
+	if p.AuthRequestedUserHint != "" {
+		setRealmAttribute(realm, "cibaAuthRequestedUserHint", p.AuthRequestedUserHint)
+	}
+}
+
+func setRealmAttribute(realm *gocloak.RealmRepresentation, key, value string) {
+	if realm.Attributes == nil {
+		attributes := make(map[string]string)
+		realm.Attributes = &attributes
+	}
+
+	(*realm.Attributes)[key] = value
+}
+
+type RealmLocalization struct {
+	SupportedLocales []string
+	DefaultLocale    string
+}
+
+// RealmLoginSettings are the realm login page settings, the nil values keep the realm settings.
+type RealmLoginSettings struct {
+	RegistrationAllowed         *bool
+	RegistrationEmailAsUsername *bool
+	VerifyEmail                 *bool
+	LoginWithEmailAllowed       *bool
+	RememberMe                  *bool
+	ResetPasswordAllowed        *bool
+	EditUsernameAllowed         *bool
+}
+
+func (s *RealmLoginSettings) apply(realm *gocloak.RealmRepresentation) {
+	setBool := func(dst **bool, v *bool) {
+		if v != nil {
+			*dst = gocloak.BoolP(*v)
+		}
+	}
+
+	setBool(&realm.RegistrationAllowed, s.RegistrationAllowed)
+	setBool(&realm.RegistrationEmailAsUsername, s.RegistrationEmailAsUsername)
+	setBool(&realm.VerifyEmail, s.VerifyEmail)
+	setBool(&realm.LoginWithEmailAllowed, s.LoginWithEmailAllowed)
+	setBool(&realm.RememberMe, s.RememberMe)
+	setBool(&realm.ResetPasswordAllowed, s.ResetPasswordAllowed)
+	setBool(&realm.EditUsernameAllowed, s.EditUsernameAllowed)
+}
+
+type RealmTokenSettings struct {
+	AccessTokenLifespan              int
+	SsoSessionIdleTimeout            int
+	SsoSessionMaxLifespan            int
+	OfflineSessionIdleTimeout        int
+	OfflineSessionMaxLifespanEnabled bool
+	OfflineSessionMaxLifespan        int
+	RevokeRefreshToken               bool
+	RefreshTokenMaxReuse             int
+}
+
+// apply sets the token settings to the realm, the zero lifetimes keep the realm settings.
+func (s *RealmTokenSettings) apply(realm *gocloak.RealmRepresentation) {
+	setPositiveInt(&realm.AccessTokenLifespan, s.AccessTokenLifespan)
+	setPositiveInt(&realm.SsoSessionIdleTimeout, s.SsoSessionIdleTimeout)
+	setPositiveInt(&realm.SsoSessionMaxLifespan, s.SsoSessionMaxLifespan)
+	setPositiveInt(&realm.OfflineSessionIdleTimeout, s.OfflineSessionIdleTimeout)
+	setPositiveInt(&realm.OfflineSessionMaxLifespan, s.OfflineSessionMaxLifespan)
+
+	realm.OfflineSessionMaxLifespanEnabled = gocloak.BoolP(s.OfflineSessionMaxLifespanEnabled)
+	realm.RevokeRefreshToken = gocloak.BoolP(s.RevokeRefreshToken)
+	realm.RefreshTokenMaxReuse = gocloak.IntP(s.RefreshTokenMaxReuse)
+}
+
+// setPositiveInt sets the value to the destination only if it is positive.
+func setPositiveInt(dst **int, v int) {
+	if v > 0 {
+		*dst = gocloak.IntP(v)
+	}
+}
+
+type RealmBruteForceProtection struct {
+	Enabled                      bool
+	PermanentLockout             bool
+	MaxLoginFailures             int
+	WaitIncrementSeconds         int
+	MaxFailureWaitSeconds        int
+	FailureResetTimeSeconds      int
+	QuickLoginCheckMilliSeconds  int64
+	MinimumQuickLoginWaitSeconds int
+}
+
+// apply sets the brute force detection settings to the realm, the zero values keep the realm settings.
+func (b *RealmBruteForceProtection) apply(realm *gocloak.RealmRepresentation) {
+	realm.BruteForceProtected = gocloak.BoolP(b.Enabled)
+	realm.PermanentLockout = gocloak.BoolP(b.PermanentLockout)
+
+	setPositiveInt(&realm.FailureFactor, b.MaxLoginFailures)
+	setPositiveInt(&realm.WaitIncrementSeconds, b.WaitIncrementSeconds)
+	setPositiveInt(&realm.MaxFailureWaitSeconds, b.MaxFailureWaitSeconds)
+	setPositiveInt(&realm.MaxDeltaTimeSeconds, b.FailureResetTimeSeconds)
+	setPositiveInt(&realm.MinimumQuickLoginWaitSeconds, b.MinimumQuickLoginWaitSeconds)
+
+	if b.QuickLoginCheckMilliSeconds > 0 {
+		realm.QuickLoginCheckMilliSeconds = gocloak.Int64P(b.QuickLoginCheckMilliSeconds)
+	}
+}
+
+type RealmSMTP struct {
+	Host               string
+	Port               int
+	From               string
+	FromDisplayName    string
+	ReplyTo            string
+	ReplyToDisplayName string
+	EnvelopeFrom       string
+	SSL                bool
+	StartTLS           bool
+	Auth               bool
+	User               string
+	Password           string
+}
+
+// toServerConfig converts the smtp settings to the keycloak smtpServer representation.
+func (s *RealmSMTP) toServerConfig() map[string]string {
+	cfg := map[string]string{
+		"host":     s.Host,
+		"from":     s.From,
+		"ssl":      strconv.FormatBool(s.SSL),
+		"starttls": strconv.FormatBool(s.StartTLS),
+		"auth":     strconv.FormatBool(s.Auth),
+	}
+
+	optional := map[string]string{
+		"fromDisplayName":    s.FromDisplayName,
+		"replyTo":            s.ReplyTo,
+		"replyToDisplayName": s.ReplyToDisplayName,
+		"envelopeFrom":       s.EnvelopeFrom,
+	}
+
+	if s.Port > 0 {
+		optional["port"] = strconv.Itoa(s.Port)
+	}
+
+	if s.Auth {
+		optional["user"] = s.User
+		optional["password"] = s.Password
+	}
+
+	for k, v := range optional {
+		if v != "" {
+			cfg[k] = v
+		}
+	}
+
+	return cfg
 }
 
 type PasswordPolicy struct {
@@ -59,7 +252,7 @@ func (a GoCloakAdapter) UpdateRealmSettings(realmName string, realmSettings *Rea
 		realm.BrowserSecurityHeaders = &realmBrowserSecurityHeaders
 	}
 
-	if len(realmSettings.PasswordPolicies) > 0 {
+	if realmSettings.PasswordPolicies != nil {
 		policies := make([]string, len(realmSettings.PasswordPolicies))
 		for i, v := range realmSettings.PasswordPolicies {
 			policies[i] = fmt.Sprintf("%s(%s)", v.Type, v.Value)
@@ -68,10 +261,69 @@ func (a GoCloakAdapter) UpdateRealmSettings(realmName string, realmSettings *Rea
 		realm.PasswordPolicy = gocloak.StringP(strings.Join(policies, " and "))
 	}
 
+	if realmSettings.SMTP != nil {
+		smtp := realmSettings.SMTP.toServerConfig()
+		realm.SMTPServer = &smtp
+	}
+
+	if realmSettings.BruteForceProtection != nil {
+		realmSettings.BruteForceProtection.apply(realm)
+	}
+
+	if realmSettings.TokenSettings != nil {
+		realmSettings.TokenSettings.apply(realm)
+	}
+
+	if realmSettings.LoginSettings != nil {
+		realmSettings.LoginSettings.apply(realm)
+	}
+
+	if realmSettings.Localization != nil {
+		realm.InternationalizationEnabled = gocloak.BoolP(true)
+		realm.SupportedLocales = &realmSettings.Localization.SupportedLocales
+		realm.DefaultLocale = gocloak.StringP(realmSettings.Localization.DefaultLocale)
+	}
+
+	if realmSettings.FrontendURL != nil {
+		setRealmAttribute(realm, realmFrontendURLAttribute, *realmSettings.FrontendURL)
+	}
+
+	if realmSettings.CIBAPolicy != nil {
+		realmSettings.CIBAPolicy.apply(realm)
+	}
+
 	if err := a.client.UpdateRealm(context.Background(), a.token.AccessToken, *realm); err != nil {
 		return errors.Wrap(err, "unable to update realm")
 	}
 
+	if realmSettings.AdminURL != nil {
+		if err := a.setAdminConsoleURL(context.Background(), realmName, *realmSettings.AdminURL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setAdminConsoleURL sets the root URL of the realm admin console client, the empty URL restores the default one.
+// Only the root URL is sent, so keycloak keeps the other fields of the client.
+func (a GoCloakAdapter) setAdminConsoleURL(ctx context.Context, realmName, adminURL string) error {
+	id, err := a.GetClientID(adminConsoleClientID, realmName)
+	if err != nil {
+		return errors.Wrap(err, "unable to get admin console client")
+	}
+
+	if adminURL == "" {
+		adminURL = defaultAdminConsoleRootURL
+	}
+
+	if err := a.client.UpdateClient(ctx, a.token.AccessToken, realmName, gocloak.Client{
+		ID:      &id,
+		RootURL: &adminURL,
+	}); err != nil {
+		return errors.Wrap(err, "unable to update admin console client")
+	}
+
 	return nil
 }
 
@@ -200,33 +452,31 @@ func decodeIdentityProviderMapper(mp interface{}) (*dto.IdentityProviderMapper,
 }
 
 func (a GoCloakAdapter) createIdentityProviderMapper(realmName string, mapper dto.IdentityProviderMapper) error {
-	resp, err := a.startRestyRequest().SetPathParams(map[string]string{
-		keycloakApiParamAlias: mapper.IdentityProviderAlias,
-		keycloakApiParamRealm: realmName,
-	}).SetBody(mapper).Post(a.basePath + mapperToIdentityProvider)
-
-	if err != nil {
+	if _, err := a.client.CreateIdentityProviderMapper(context.Background(), a.token.AccessToken, realmName,
+		mapper.IdentityProviderAlias, identityProviderMapperToGoCloak(&mapper)); err != nil {
 		return errors.Wrapf(err, "unable to create identity provider mapper: %+v", mapper)
 	}
 
-	if resp.StatusCode() != http.StatusCreated {
-		return errors.Errorf("unable to create identity provider mapper: %+v, response: %s", mapper,
-			resp.String())
-	}
-
 	return nil
 }
 
 func (a GoCloakAdapter) updateIdentityProviderMapper(realmName string, mapper dto.IdentityProviderMapper) error {
-	resp, err := a.startRestyRequest().SetPathParams(map[string]string{
-		keycloakApiParamAlias: mapper.IdentityProviderAlias,
-		keycloakApiParamRealm: realmName,
-		keycloakApiParamId:    mapper.ID,
-	}).SetBody(mapper).Put(a.basePath + updateMapperToIdentityProvider)
-
-	if err = a.checkError(err, resp); err != nil {
+	if err := a.client.UpdateIdentityProviderMapper(context.Background(), a.token.AccessToken, realmName,
+		mapper.IdentityProviderAlias, identityProviderMapperToGoCloak(&mapper)); err != nil {
 		return errors.Wrapf(err, "unable to update identity provider mapper: %+v", mapper)
 	}
 
 	return nil
 }
+
+func identityProviderMapperToGoCloak(mapper *dto.IdentityProviderMapper) gocloak.IdentityProviderMapper {
+	m := IdentityProviderMapper{
+		ID:                     mapper.ID,
+		Name:                   mapper.Name,
+		IdentityProviderMapper: mapper.IdentityProviderMapper,
+		IdentityProviderAlias:  mapper.IdentityProviderAlias,
+		Config:                 mapper.Config,
+	}
+
+	return m.toGoCloak()
+}
@@ -3,8 +3,6 @@ package adapter
 import (
 	"context"
 	"errors"
-	"fmt"
-	"net/http"
 	"testing"
 
 	"github.com/Nerzal/gocloak/v12"
@@ -52,6 +50,192 @@ func TestGoCloakAdapter_UpdateRealmSettings(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGoCloakAdapter_UpdateRealmSettings_SMTP(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	settings := RealmSettings{
+		SMTP: &RealmSMTP{
+			Host:     "smtp.example.com",
+			Port:     465,
+			From:     "noreply@example.com",
+			ReplyTo:  "support@example.com",
+			SSL:      true,
+			Auth:     true,
+			User:     "mailer",
+			Password: "secret",
+		},
+	}
+
+	mockClient.On("GetRealm", adapter.token.AccessToken, "realm1").Return(&gocloak.RealmRepresentation{
+		SMTPServer: &map[string]string{"host": "old.example.com", "password": "**********"},
+	}, nil)
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		SMTPServer: &map[string]string{
+			"host":     "smtp.example.com",
+			"port":     "465",
+			"from":     "noreply@example.com",
+			"replyTo":  "support@example.com",
+			"ssl":      "true",
+			"starttls": "false",
+			"auth":     "true",
+			"user":     "mailer",
+			"password": "secret",
+		},
+	}).Return(nil)
+
+	require.NoError(t, adapter.UpdateRealmSettings("realm1", &settings))
+}
+
+func TestGoCloakAdapter_UpdateRealmSettings_FrontendURL(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	mockClient.On("GetRealm", adapter.token.AccessToken, "realm1").Return(&gocloak.RealmRepresentation{
+		Attributes: &map[string]string{"frontendUrl": "https://old.example.com", "foo": "bar"},
+	}, nil)
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		Attributes: &map[string]string{"frontendUrl": "https://sso.example.com", "foo": "bar"},
+	}).Return(nil)
+
+	require.NoError(t, adapter.UpdateRealmSettings("realm1", &RealmSettings{
+		FrontendURL: gocloak.StringP("https://sso.example.com"),
+	}))
+}
+
+func TestGoCloakAdapter_UpdateRealmSettings_RemoveFrontendURL(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	mockClient.On("GetRealm", adapter.token.AccessToken, "realm1").Return(&gocloak.RealmRepresentation{
+		Attributes: &map[string]string{"frontendUrl": "https://old.example.com"},
+	}, nil)
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		Attributes: &map[string]string{"frontendUrl": ""},
+	}).Return(nil)
+
+	require.NoError(t, adapter.UpdateRealmSettings("realm1", &RealmSettings{FrontendURL: gocloak.StringP("")}))
+}
+
+func TestGoCloakAdapter_UpdateRealmSettings_AdminURL(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	mockClient.On("GetRealm", adapter.token.AccessToken, "realm1").Return(&gocloak.RealmRepresentation{}, nil)
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{}).Return(nil)
+	mockClient.On("GetClients", "realm1", gocloak.GetClientsParams{ClientID: gocloak.StringP("security-admin-console")}).
+		Return([]*gocloak.Client{{ID: gocloak.StringP("console-id"), ClientID: gocloak.StringP("security-admin-console")}}, nil)
+	mockClient.On("UpdateClient", adapter.token.AccessToken, "realm1", gocloak.Client{
+		ID:      gocloak.StringP("console-id"),
+		RootURL: gocloak.StringP("https://admin.example.com"),
+	}).Return(nil).Once()
+	mockClient.On("UpdateClient", adapter.token.AccessToken, "realm1", gocloak.Client{
+		ID:      gocloak.StringP("console-id"),
+		RootURL: gocloak.StringP("${authAdminUrl}"),
+	}).Return(nil).Once()
+
+	require.NoError(t, adapter.UpdateRealmSettings("realm1", &RealmSettings{
+		AdminURL: gocloak.StringP("https://admin.example.com"),
+	}))
+	require.NoError(t, adapter.UpdateRealmSettings("realm1", &RealmSettings{AdminURL: gocloak.StringP("")}))
+	mockClient.AssertNumberOfCalls(t, "UpdateClient", 2)
+}
+
+func TestGoCloakAdapter_UpdateRealmSettings_CIBAPolicy(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	mockClient.On("GetRealm", adapter.token.AccessToken, "realm1").Return(&gocloak.RealmRepresentation{
+		Attributes: &map[string]string{"cibaBackchannelTokenDeliveryMode": "poll", "cibaInterval": "5"},
+	}, nil)
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		Attributes: &map[string]string{
+			"cibaBackchannelTokenDeliveryMode": "ping",
+			"cibaExpiresIn":                    "300",
+			"cibaInterval":                     "0",
+			"cibaAuthRequestedUserHint":        "login_hint",
+		},
+	}).Return(nil)
+
+	require.NoError(t, adapter.UpdateRealmSettings("realm1", &RealmSettings{CIBAPolicy: &RealmCIBAPolicy{
+		BackchannelTokenDeliveryMode: "ping",
+		ExpiresIn:                    300,
+		AuthRequestedUserHint:        "login_hint",
+	}}))
+}
+
+func TestGoCloakAdapter_UpdateRealmSettings_BruteForceProtection(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	settings := RealmSettings{
+		BruteForceProtection: &RealmBruteForceProtection{
+			Enabled:                 true,
+			MaxLoginFailures:        5,
+			FailureResetTimeSeconds: 600,
+		},
+	}
+
+	mockClient.On("GetRealm", adapter.token.AccessToken, "realm1").Return(&gocloak.RealmRepresentation{
+		PermanentLockout:     gocloak.BoolP(true),
+		WaitIncrementSeconds: gocloak.IntP(120),
+	}, nil)
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		BruteForceProtected:  gocloak.BoolP(true),
+		PermanentLockout:     gocloak.BoolP(false),
+		FailureFactor:        gocloak.IntP(5),
+		WaitIncrementSeconds: gocloak.IntP(120),
+		MaxDeltaTimeSeconds:  gocloak.IntP(600),
+	}).Return(nil)
+
+	require.NoError(t, adapter.UpdateRealmSettings("realm1", &settings))
+}
+
+func TestGoCloakAdapter_UpdateRealmSettings_TokenSettings(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	settings := RealmSettings{
+		TokenSettings: &RealmTokenSettings{
+			AccessTokenLifespan:   300,
+			SsoSessionIdleTimeout: 1800,
+			RevokeRefreshToken:    true,
+			RefreshTokenMaxReuse:  1,
+		},
+	}
+
+	mockClient.On("GetRealm", adapter.token.AccessToken, "realm1").Return(&gocloak.RealmRepresentation{
+		SsoSessionMaxLifespan:            gocloak.IntP(36000),
+		OfflineSessionMaxLifespanEnabled: gocloak.BoolP(true),
+	}, nil)
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		AccessTokenLifespan:              gocloak.IntP(300),
+		SsoSessionIdleTimeout:            gocloak.IntP(1800),
+		SsoSessionMaxLifespan:            gocloak.IntP(36000),
+		OfflineSessionMaxLifespanEnabled: gocloak.BoolP(false),
+		RevokeRefreshToken:               gocloak.BoolP(true),
+		RefreshTokenMaxReuse:             gocloak.IntP(1),
+	}).Return(nil)
+
+	require.NoError(t, adapter.UpdateRealmSettings("realm1", &settings))
+}
+
+func TestGoCloakAdapter_UpdateRealmSettings_LoginSettings(t *testing.T) {
+	adapter, mockClient, _ := initAdapter()
+
+	settings := RealmSettings{
+		LoginSettings: &RealmLoginSettings{
+			RegistrationAllowed: gocloak.BoolP(true),
+			VerifyEmail:         gocloak.BoolP(false),
+		},
+	}
+
+	mockClient.On("GetRealm", adapter.token.AccessToken, "realm1").Return(&gocloak.RealmRepresentation{
+		VerifyEmail: gocloak.BoolP(true),
+		RememberMe:  gocloak.BoolP(true),
+	}, nil)
+	mockClient.On("UpdateRealm", gocloak.RealmRepresentation{
+		RegistrationAllowed: gocloak.BoolP(true),
+		VerifyEmail:         gocloak.BoolP(false),
+		RememberMe:          gocloak.BoolP(true),
+	}).Return(nil)
+
+	require.NoError(t, adapter.UpdateRealmSettings("realm1", &settings))
+}
+
 func TestGoCloakAdapter_SyncRealmIdentityProviderMappers(t *testing.T) {
 	adapter, mockClient, restyClient := initAdapter()
 	httpmock.ActivateNonDefault(restyClient.GetClient())
@@ -74,16 +258,19 @@ func TestGoCloakAdapter_SyncRealmIdentityProviderMappers(t *testing.T) {
 
 	mockClient.On("GetRealm", adapter.token.AccessToken, *realm.Realm).Return(&realm, nil)
 
-	httpmock.RegisterResponder(
-		"POST",
-		fmt.Sprintf("/admin/realms/%s/identity-provider/instances/%s/mappers", *realm.Realm, idpAlias),
-		httpmock.NewStringResponder(http.StatusCreated, "ok"))
-
-	httpmock.RegisterResponder(
-		"PUT",
-		fmt.Sprintf("/admin/realms/%s/identity-provider/instances/%s/mappers/%s", *realm.Realm, idpAlias,
-			currentMapperID),
-		httpmock.NewStringResponder(http.StatusOK, "ok"))
+	mockClient.On("CreateIdentityProviderMapper", *realm.Realm, idpAlias, gocloak.IdentityProviderMapper{
+		Name:                   gocloak.StringP("tname1"),
+		IdentityProviderMapper: gocloak.StringP("mapper-1"),
+		IdentityProviderAlias:  gocloak.StringP(idpAlias),
+		Config:                 &map[string]string{"foo": "bar"},
+	}).Return("new-id", nil)
+	mockClient.On("UpdateIdentityProviderMapper", *realm.Realm, idpAlias, gocloak.IdentityProviderMapper{
+		ID:                     gocloak.StringP(currentMapperID),
+		Name:                   gocloak.StringP("mp1name"),
+		IdentityProviderMapper: gocloak.StringP("mapper-2"),
+		IdentityProviderAlias:  gocloak.StringP(idpAlias),
+		Config:                 &map[string]string{"foo": "bar"},
+	}).Return(nil)
 
 	if err := adapter.SyncRealmIdentityProviderMappers(*realm.Realm,
 		[]dto.IdentityProviderMapper{
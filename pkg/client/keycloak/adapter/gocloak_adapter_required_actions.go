@@ -0,0 +1,80 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+)
+
+type RequiredAction struct {
+	Alias         string            `json:"alias"`
+	Name          string            `json:"name"`
+	ProviderID    string            `json:"providerId"`
+	Enabled       bool              `json:"enabled"`
+	DefaultAction bool              `json:"defaultAction"`
+	Priority      int               `json:"priority"`
+	Config        map[string]string `json:"config,omitempty"`
+}
+
+// GetRequiredAction returns the registered required action, NotFoundError is returned if it is not registered.
+func (a GoCloakAdapter) GetRequiredAction(ctx context.Context, realm, alias string) (*RequiredAction, error) {
+	action, err := a.client.GetRequiredAction(ctx, a.token.AccessToken, realm, alias)
+	if err != nil {
+		if IsErrNotFound(err) {
+			return nil, NotFoundError("required action not found")
+		}
+
+		return nil, errors.Wrap(err, "unable to get required action")
+	}
+
+	return requiredActionFromGoCloak(action), nil
+}
+
+// RegisterRequiredAction registers the required action of the provider, the alias of the action is the provider id.
+func (a GoCloakAdapter) RegisterRequiredAction(ctx context.Context, realm, providerID, name string) error {
+	err := a.client.RegisterRequiredAction(ctx, a.token.AccessToken, realm, gocloak.RequiredActionProviderRepresentation{
+		ProviderID: gocloak.StringP(providerID),
+		Name:       gocloak.StringP(name),
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to register required action")
+	}
+
+	return nil
+}
+
+// UpdateRequiredAction updates the required action by the alias.
+// It is not backed by gocloak, which addresses the action by the provider id instead of the alias.
+func (a GoCloakAdapter) UpdateRequiredAction(ctx context.Context, realm string, action *RequiredAction) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realm,
+		keycloakApiParamAlias: action.Alias,
+	}).SetBody(action).Put(a.basePath + requiredActionEntity)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to update required action")
+	}
+
+	return nil
+}
+
+func requiredActionFromGoCloak(action *gocloak.RequiredActionProviderRepresentation) *RequiredAction {
+	result := RequiredAction{
+		Alias:         gocloak.PString(action.Alias),
+		Name:          gocloak.PString(action.Name),
+		ProviderID:    gocloak.PString(action.ProviderID),
+		Enabled:       gocloak.PBool(action.Enabled),
+		DefaultAction: gocloak.PBool(action.DefaultAction),
+	}
+
+	if action.Priority != nil {
+		result.Priority = int(*action.Priority)
+	}
+
+	if action.Config != nil {
+		result.Config = *action.Config
+	}
+
+	return &result
+}
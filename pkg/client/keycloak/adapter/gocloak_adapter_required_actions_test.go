@@ -0,0 +1,65 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_GetRequiredAction(t *testing.T) {
+	kcAdapter, mockClient, _ := initAdapter()
+
+	mockClient.On("GetRequiredAction", "realm1", "CONFIGURE_TOTP").
+		Return(&gocloak.RequiredActionProviderRepresentation{
+			Alias:      gocloak.StringP("CONFIGURE_TOTP"),
+			Name:       gocloak.StringP("Configure OTP"),
+			ProviderID: gocloak.StringP("CONFIGURE_TOTP"),
+			Enabled:    gocloak.BoolP(true),
+			Priority:   gocloak.Int32P(10),
+		}, nil)
+	mockClient.On("GetRequiredAction", "realm1", "custom").
+		Return(nil, &gocloak.APIError{Code: http.StatusNotFound, Message: "404 Not Found"})
+
+	action, err := kcAdapter.GetRequiredAction(context.Background(), "realm1", "CONFIGURE_TOTP")
+	require.NoError(t, err)
+	assert.Equal(t, "Configure OTP", action.Name)
+	assert.Equal(t, 10, action.Priority)
+	assert.True(t, action.Enabled)
+
+	_, err = kcAdapter.GetRequiredAction(context.Background(), "realm1", "custom")
+	require.Error(t, err)
+	assert.True(t, IsErrNotFound(err))
+}
+
+func TestGoCloakAdapter_RegisterRequiredAction(t *testing.T) {
+	kcAdapter, mockClient, _ := initAdapter()
+
+	mockClient.On("RegisterRequiredAction", "realm1", gocloak.RequiredActionProviderRepresentation{
+		ProviderID: gocloak.StringP("custom"),
+		Name:       gocloak.StringP("Custom action"),
+	}).Return(nil)
+
+	err := kcAdapter.RegisterRequiredAction(context.Background(), "realm1", "custom", "Custom action")
+	require.NoError(t, err)
+}
+
+func TestGoCloakAdapter_UpdateRequiredAction(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/authentication/required-actions/CONFIGURE_TOTP",
+		httpmock.NewStringResponder(204, ""))
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/authentication/required-actions/custom",
+		httpmock.NewStringResponder(500, "fatal"))
+
+	err := kcAdapter.UpdateRequiredAction(context.Background(), "realm1", &RequiredAction{Alias: "CONFIGURE_TOTP"})
+	require.NoError(t, err)
+
+	err = kcAdapter.UpdateRequiredAction(context.Background(), "realm1", &RequiredAction{Alias: "custom"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to update required action")
+}
@@ -0,0 +1,192 @@
+package adapter
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+)
+
+// roleComposites contains the declared members of the composite role.
+type roleComposites struct {
+	realmRoles []string
+	// clientRoles is a map of client roles keyed by clientId.
+	clientRoles map[string][]string
+	// addOnly disables removal of the members which are not declared.
+	addOnly bool
+}
+
+type compositesFunc func(ctx context.Context, roles []gocloak.Role) error
+
+// syncCompositeMembers makes the members of the composite role match the declared realm and client roles.
+// Declared roles which do not exist are skipped and returned in the clientId/role format for client roles.
+// Undeclared realm roles and undeclared roles of the declared clients are removed unless addOnly is set.
+func (a GoCloakAdapter) syncCompositeMembers(ctx context.Context, realmName, roleID string, composites roleComposites,
+	addComposites, deleteComposites compositesFunc) ([]string, error) {
+	currentComposites, err := a.client.GetCompositeRolesByRoleID(ctx, a.token.AccessToken, realmName, roleID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get role composites")
+	}
+
+	claimed, err := a.resolveRoleComposites(ctx, realmName, composites)
+	if err != nil {
+		return nil, err
+	}
+
+	currentIDs := make(map[string]struct{}, len(currentComposites))
+	rolesToDelete := make([]gocloak.Role, 0)
+
+	for _, current := range currentComposites {
+		if current == nil || current.ID == nil {
+			continue
+		}
+
+		currentIDs[*current.ID] = struct{}{}
+
+		if _, ok := claimed.roles[*current.ID]; ok || composites.addOnly {
+			continue
+		}
+
+		if !isClientRole(current) || claimed.hasClient(current.ContainerID) {
+			rolesToDelete = append(rolesToDelete, *current)
+		}
+	}
+
+	rolesToAdd := make([]gocloak.Role, 0, len(claimed.ordered))
+
+	for _, id := range claimed.ordered {
+		if _, ok := currentIDs[id]; !ok {
+			rolesToAdd = append(rolesToAdd, claimed.roles[id])
+		}
+	}
+
+	if len(rolesToAdd) > 0 {
+		if err := addComposites(ctx, rolesToAdd); err != nil {
+			return nil, errors.Wrap(err, "unable to add role composite")
+		}
+	}
+
+	if len(rolesToDelete) > 0 {
+		if err := deleteComposites(ctx, rolesToDelete); err != nil {
+			return nil, errors.Wrap(err, "unable to delete role composite")
+		}
+	}
+
+	return claimed.unresolved, nil
+}
+
+// claimedComposites contains the declared members of the composite role which exist in Keycloak.
+type claimedComposites struct {
+	roles      map[string]gocloak.Role
+	ordered    []string
+	clients    map[string]struct{}
+	unresolved []string
+}
+
+func (c *claimedComposites) add(r *gocloak.Role) {
+	if r == nil || r.ID == nil {
+		return
+	}
+
+	if _, ok := c.roles[*r.ID]; ok {
+		return
+	}
+
+	c.roles[*r.ID] = *r
+	c.ordered = append(c.ordered, *r.ID)
+}
+
+func (c *claimedComposites) hasClient(containerID *string) bool {
+	if containerID == nil {
+		return false
+	}
+
+	_, ok := c.clients[*containerID]
+
+	return ok
+}
+
+func (a GoCloakAdapter) resolveRoleComposites(ctx context.Context, realmName string,
+	composites roleComposites) (*claimedComposites, error) {
+	claimed := &claimedComposites{
+		roles:   make(map[string]gocloak.Role),
+		clients: make(map[string]struct{}),
+	}
+
+	for _, name := range composites.realmRoles {
+		compRole, err := a.client.GetRealmRole(ctx, a.token.AccessToken, realmName, name)
+
+		exists, err := strip404(err)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get realm role %s", name)
+		}
+
+		if !exists {
+			claimed.unresolved = append(claimed.unresolved, name)
+			continue
+		}
+
+		claimed.add(compRole)
+	}
+
+	for _, clientID := range sortedKeys(composites.clientRoles) {
+		if err := a.resolveClientRoleComposites(ctx, realmName, clientID, composites.clientRoles[clientID],
+			claimed); err != nil {
+			return nil, err
+		}
+	}
+
+	return claimed, nil
+}
+
+func (a GoCloakAdapter) resolveClientRoleComposites(ctx context.Context, realmName, clientID string, roleNames []string,
+	claimed *claimedComposites) error {
+	id, err := a.GetClientID(clientID, realmName)
+	if err != nil {
+		if !IsErrNotFound(err) {
+			return errors.Wrapf(err, "unable to get client %s", clientID)
+		}
+
+		for _, name := range roleNames {
+			claimed.unresolved = append(claimed.unresolved, clientID+"/"+name)
+		}
+
+		return nil
+	}
+
+	claimed.clients[id] = struct{}{}
+
+	for _, name := range roleNames {
+		compRole, err := a.client.GetClientRole(ctx, a.token.AccessToken, realmName, id, name)
+
+		exists, err := strip404(err)
+		if err != nil {
+			return errors.Wrapf(err, "unable to get client %s role %s", clientID, name)
+		}
+
+		if !exists {
+			claimed.unresolved = append(claimed.unresolved, clientID+"/"+name)
+			continue
+		}
+
+		claimed.add(compRole)
+	}
+
+	return nil
+}
+
+func isClientRole(r *gocloak.Role) bool {
+	return r.ClientRole != nil && *r.ClientRole
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,156 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+)
+
+// RoleUsage contains the entities which still refer to the role.
+type RoleUsage struct {
+	Users  []string
+	Groups []string
+	// CompositeRoles is a list of the realm composite roles which include the role.
+	CompositeRoles []string
+}
+
+// InUse checks if the role is referred by any entity.
+func (u *RoleUsage) InUse() bool {
+	return len(u.Users) > 0 || len(u.Groups) > 0 || len(u.CompositeRoles) > 0
+}
+
+func (u *RoleUsage) String() string {
+	parts := make([]string, 0, 3)
+
+	for _, p := range []struct {
+		kind  string
+		names []string
+	}{
+		{kind: "users", names: u.Users},
+		{kind: "groups", names: u.Groups},
+		{kind: "composite roles", names: u.CompositeRoles},
+	} {
+		if len(p.names) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s", p.kind, strings.Join(p.names, ", ")))
+		}
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// GetRealmRoleUsage returns users, groups and realm composite roles which the realm role is assigned to.
+// It returns empty usage if the role doesn't exist.
+func (a GoCloakAdapter) GetRealmRoleUsage(ctx context.Context, realmName, roleName string) (*RoleUsage, error) {
+	role, err := a.client.GetRealmRole(ctx, a.token.AccessToken, realmName, roleName)
+
+	exists, err := strip404(err)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get realm role %s", roleName)
+	}
+
+	if !exists {
+		return &RoleUsage{}, nil
+	}
+
+	users, err := a.getUsersByRoleName(ctx, realmName, roleName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get users of realm role %s", roleName)
+	}
+
+	groups, err := a.getGroupsByRole(ctx, realmName, roleName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get groups of realm role %s", roleName)
+	}
+
+	return a.makeRoleUsage(ctx, realmName, *role.ID, users, groups)
+}
+
+// GetClientRoleUsage returns users, groups and realm composite roles which the client role is assigned to.
+// It returns empty usage if the client or the role doesn't exist.
+func (a GoCloakAdapter) GetClientRoleUsage(ctx context.Context, realmName, clientID, roleName string) (*RoleUsage, error) {
+	id, err := a.GetClientID(clientID, realmName)
+	if err != nil {
+		if IsErrNotFound(err) {
+			return &RoleUsage{}, nil
+		}
+
+		return nil, errors.Wrapf(err, "unable to get client %s", clientID)
+	}
+
+	role, err := a.client.GetClientRole(ctx, a.token.AccessToken, realmName, id, roleName)
+
+	exists, err := strip404(err)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get client role %s", roleName)
+	}
+
+	if !exists {
+		return &RoleUsage{}, nil
+	}
+
+	users, err := a.getUsersByClientRoleName(ctx, realmName, id, roleName,
+		gocloak.GetUsersByRoleParams{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get users of client role %s", roleName)
+	}
+
+	groups, err := a.getGroupsByClientRole(ctx, realmName, id, roleName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get groups of client role %s", roleName)
+	}
+
+	return a.makeRoleUsage(ctx, realmName, *role.ID, users, groups)
+}
+
+func (a GoCloakAdapter) makeRoleUsage(ctx context.Context, realmName, roleID string, users []*gocloak.User,
+	groups []*gocloak.Group) (*RoleUsage, error) {
+	usage := &RoleUsage{}
+
+	for _, u := range users {
+		if u != nil && u.Username != nil {
+			usage.Users = append(usage.Users, *u.Username)
+		}
+	}
+
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+
+		if g.Path != nil {
+			usage.Groups = append(usage.Groups, *g.Path)
+		} else if g.Name != nil {
+			usage.Groups = append(usage.Groups, *g.Name)
+		}
+	}
+
+	// Keycloak has no endpoint for the parents of the role, so the members of all realm composite roles are checked.
+	realmRoles, err := a.client.GetRealmRoles(ctx, a.token.AccessToken, realmName,
+		gocloak.GetRoleParams{BriefRepresentation: gocloak.BoolP(false)})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get realm roles")
+	}
+
+	for _, r := range realmRoles {
+		if r == nil || r.ID == nil || r.Name == nil || r.Composite == nil || !*r.Composite || *r.ID == roleID {
+			continue
+		}
+
+		composites, err := a.client.GetCompositeRolesByRoleID(ctx, a.token.AccessToken, realmName, *r.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get composites of role %s", *r.Name)
+		}
+
+		for _, c := range composites {
+			if c != nil && c.ID != nil && *c.ID == roleID {
+				usage.CompositeRoles = append(usage.CompositeRoles, *r.Name)
+				break
+			}
+		}
+	}
+
+	return usage, nil
+}
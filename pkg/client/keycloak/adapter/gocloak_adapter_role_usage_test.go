@@ -0,0 +1,63 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/mock"
+)
+
+func TestGoCloakAdapter_GetRealmRoleUsage(t *testing.T) {
+	a, mockClient, _ := initAdapter()
+	a.SetPageSize(1)
+
+	role := gocloak.Role{Name: gocloak.StringP("viewer"), ID: gocloak.StringP("role-id")}
+	admin := gocloak.Role{Name: gocloak.StringP("admin"), ID: gocloak.StringP("admin-id"), Composite: gocloak.BoolP(true)}
+	editor := gocloak.Role{Name: gocloak.StringP("editor"), ID: gocloak.StringP("editor-id"), Composite: gocloak.BoolP(true)}
+
+	mockClient.On("GetRealmRole", "realm", "viewer").Return(&role, nil)
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm/roles/viewer/users?first=0&max=1",
+		httpmock.NewJsonResponderOrPanic(200, []*gocloak.User{{Username: gocloak.StringP("user1")}}))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm/roles/viewer/users?first=1&max=1",
+		httpmock.NewJsonResponderOrPanic(200, []*gocloak.User{{Username: gocloak.StringP("user2")}}))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm/roles/viewer/users?first=2&max=1",
+		httpmock.NewJsonResponderOrPanic(200, []*gocloak.User{}))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm/roles/viewer/groups?first=0&max=1",
+		httpmock.NewJsonResponderOrPanic(200, []*gocloak.Group{
+			{Name: gocloak.StringP("child"), Path: gocloak.StringP("/parent/child")},
+		}))
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm/roles/viewer/groups?first=1&max=1",
+		httpmock.NewJsonResponderOrPanic(200, []*gocloak.Group{}))
+	mockClient.On("GetRealmRoles", "realm", gocloak.GetRoleParams{BriefRepresentation: gocloak.BoolP(false)}).
+		Return([]*gocloak.Role{&role, &admin, &editor}, nil)
+	mockClient.On("GetCompositeRolesByRoleID", "realm", "admin-id").Return([]*gocloak.Role{&role}, nil)
+	mockClient.On("GetCompositeRolesByRoleID", "realm", "editor-id").Return([]*gocloak.Role{&admin}, nil)
+
+	usage, err := a.GetRealmRoleUsage(context.Background(), "realm", "viewer")
+	require.NoError(t, err)
+	require.True(t, usage.InUse())
+	require.Equal(t, &RoleUsage{
+		Users:          []string{"user1", "user2"},
+		Groups:         []string{"/parent/child"},
+		CompositeRoles: []string{"admin"},
+	}, usage)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_GetClientRoleUsage_RoleNotFound(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	a := GoCloakAdapter{client: &mockClient, token: &gocloak.JWT{AccessToken: "token"}, log: mock.NewLogr()}
+
+	mockClient.On("GetClients", "realm", gocloak.GetClientsParams{ClientID: gocloak.StringP("app")}).
+		Return([]*gocloak.Client{{ClientID: gocloak.StringP("app"), ID: gocloak.StringP("app-uuid")}}, nil)
+	mockClient.On("GetClientRole", "realm", "app-uuid", "viewer").Return(nil, &gocloak.APIError{Code: http.StatusNotFound, Message: "404 Not Found"})
+
+	usage, err := a.GetClientRoleUsage(context.Background(), "realm", "app", "viewer")
+	require.NoError(t, err)
+	require.False(t, usage.InUse())
+}
@@ -9,18 +9,6 @@ import (
 	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/dto"
 )
 
-type DuplicatedError string
-
-func (e DuplicatedError) Error() string {
-	return string(e)
-}
-
-func IsErrDuplicated(err error) bool {
-	errDuplicate := DuplicatedError("")
-
-	return errors.As(err, &errDuplicate)
-}
-
 func (a GoCloakAdapter) SyncRealmRole(realmName string, role *dto.PrimaryRealmRole) error {
 	if err := a.createOrUpdateRealmRole(realmName, role); err != nil {
 		return errors.Wrap(err, "error during createOrUpdateRealmRole")
@@ -42,7 +30,11 @@ func (a GoCloakAdapter) createOrUpdateRealmRole(realmName string, role *dto.Prim
 	}
 
 	if !exists {
-		_, err := a.CreatePrimaryRealmRole(realmName, role)
+		// Composites are synced after the creation, so missing member roles don't fail the role creation.
+		newRole := *role
+		newRole.Composites = nil
+
+		_, err := a.CreatePrimaryRealmRole(realmName, &newRole)
 		if err != nil {
 			return errors.Wrap(err, "unable to create realm role during sync")
 		}
@@ -54,11 +46,19 @@ func (a GoCloakAdapter) createOrUpdateRealmRole(realmName string, role *dto.Prim
 
 		role.ID = currentRealmRole.ID
 
+		if err := a.syncRoleComposites(realmName, role, currentRealmRole); err != nil {
+			return errors.Wrap(err, "error during syncRoleComposites")
+		}
+
 		return nil
 	}
 
 	if role.ID == nil {
-		return DuplicatedError("role is duplicated")
+		if !role.Adopt {
+			return DuplicatedError("role is duplicated")
+		}
+
+		role.ID = currentRealmRole.ID
 	}
 
 	if err := a.syncRoleComposites(realmName, role, currentRealmRole); err != nil {
@@ -66,7 +66,7 @@ func (a GoCloakAdapter) createOrUpdateRealmRole(realmName string, role *dto.Prim
 	}
 
 	currentRealmRole.Composite = &role.IsComposite
-	currentRealmRole.Attributes = &role.Attributes
+	currentRealmRole.Attributes = roleAttributes(currentRealmRole.Attributes, role.Attributes, role.AddOnly)
 	currentRealmRole.Description = &role.Description
 
 	if err := a.client.UpdateRealmRole(context.Background(), a.token.AccessToken, realmName, role.Name,
@@ -102,53 +102,75 @@ func (a GoCloakAdapter) DeleteRealmRole(ctx context.Context, realm, roleName str
 }
 
 func (a GoCloakAdapter) syncRoleComposites(realmName string, role *dto.PrimaryRealmRole, currentRealmRole *gocloak.Role) error {
-	currentComposites, err := a.client.GetCompositeRealmRolesByRoleID(context.Background(), a.token.AccessToken, realmName, *currentRealmRole.ID)
+	unresolved, err := a.syncCompositeMembers(context.Background(), realmName, *currentRealmRole.ID,
+		roleComposites{
+			realmRoles:  role.Composites,
+			clientRoles: role.CompositesClientRoles,
+			addOnly:     role.AddOnly,
+		},
+		func(ctx context.Context, roles []gocloak.Role) error {
+			return a.client.AddRealmRoleComposite(ctx, a.token.AccessToken, realmName, role.Name, roles)
+		},
+		func(ctx context.Context, roles []gocloak.Role) error {
+			return a.client.DeleteRealmRoleComposite(ctx, a.token.AccessToken, realmName, role.Name, roles)
+		},
+	)
 	if err != nil {
-		return errors.Wrap(err, "unable to get realm role composites")
-	}
-
-	if err := a.syncCreateNewComposites(realmName, role, currentComposites); err != nil {
-		return errors.Wrap(err, "error during SyncCreateNewComposites")
+		return err
 	}
 
-	// temporary disable deletion of old composites to remove conflict with keycloak client roles
-
-	// if err := a.syncDeleteOldComposites(realmName, role, currentComposites); err != nil {
-	//	return errors.Wrap(err, "error during SyncDeleteOldComposites")
-	//}
+	role.UnresolvedComposites = unresolved
 
 	return nil
 }
 
-func (a GoCloakAdapter) syncCreateNewComposites(realmName string, role *dto.PrimaryRealmRole, currentComposites []*gocloak.Role) error {
-	currentCompositesMap := make(map[string]string)
+// SyncRealmDefaultRoles makes the default-roles-<realm> composite role of the realm contain the declared roles.
+// It returns the declared roles which do not exist.
+func (a GoCloakAdapter) SyncRealmDefaultRoles(ctx context.Context, realmName string, realmRoles []string,
+	clientRoles map[string][]string) ([]string, error) {
+	realm, err := a.client.GetRealm(ctx, a.token.AccessToken, realmName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get realm: %s", realmName)
+	}
 
-	for _, currentComposite := range currentComposites {
-		currentCompositesMap[*currentComposite.Name] = *currentComposite.Name
+	if realm.DefaultRole == nil || realm.DefaultRole.ID == nil || realm.DefaultRole.Name == nil {
+		return nil, errors.Errorf("realm %s has no default role composite", realmName)
 	}
 
-	rolesToAdd := make([]gocloak.Role, 0, len(role.Composites))
+	defaultRole := *realm.DefaultRole.Name
+
+	return a.syncCompositeMembers(ctx, realmName, *realm.DefaultRole.ID,
+		roleComposites{
+			realmRoles:  realmRoles,
+			clientRoles: clientRoles,
+		},
+		func(ctx context.Context, roles []gocloak.Role) error {
+			return a.client.AddRealmRoleComposite(ctx, a.token.AccessToken, realmName, defaultRole, roles)
+		},
+		func(ctx context.Context, roles []gocloak.Role) error {
+			return a.client.DeleteRealmRoleComposite(ctx, a.token.AccessToken, realmName, defaultRole, roles)
+		},
+	)
+}
 
-	for _, claimedComposite := range role.Composites {
-		if _, ok := currentCompositesMap[claimedComposite]; !ok {
-			compRole, err := a.client.GetRealmRole(context.Background(), a.token.AccessToken, realmName,
-				claimedComposite)
-			if err != nil {
-				return errors.Wrap(err, "unable to get realm role")
-			}
+// roleAttributes returns the attributes which should be sent on the role update.
+// Keycloak keeps the current attributes if the attributes are null and removes the attributes
+// which are missing in the request, so an empty map is sent to clear all of them.
+// With addOnly the current attributes which are not declared are kept.
+func roleAttributes(current *map[string][]string, declared map[string][]string, addOnly bool) *map[string][]string {
+	attributes := make(map[string][]string, len(declared))
 
-			rolesToAdd = append(rolesToAdd, *compRole)
+	if addOnly && current != nil {
+		for k, v := range *current {
+			attributes[k] = v
 		}
 	}
 
-	if len(rolesToAdd) > 0 {
-		if err := a.client.AddRealmRoleComposite(context.Background(), a.token.AccessToken, realmName,
-			role.Name, rolesToAdd); err != nil {
-			return errors.Wrap(err, "unable to add role composite")
-		}
+	for k, v := range declared {
+		attributes[k] = v
 	}
 
-	return nil
+	return &attributes
 }
 
 func (a GoCloakAdapter) makeRoleDefault(realmName string, role *dto.PrimaryRealmRole) error {
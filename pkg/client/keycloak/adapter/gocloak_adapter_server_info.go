@@ -0,0 +1,120 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Keycloak theme types reported by the server info.
+const (
+	ThemeTypeLogin   = "login"
+	ThemeTypeAccount = "account"
+	ThemeTypeAdmin   = "admin"
+	ThemeTypeEmail   = "email"
+)
+
+type serverInfo struct {
+	SystemInfo struct {
+		Version string `json:"version"`
+	} `json:"systemInfo"`
+	Themes map[string][]struct {
+		Name string `json:"name"`
+	} `json:"themes"`
+	PasswordPolicies []struct {
+		ID string `json:"id"`
+	} `json:"passwordPolicies"`
+}
+
+// ServerThemes is a set of the theme names available in keycloak keyed by the theme type.
+type ServerThemes map[string]map[string]struct{}
+
+// Has checks if keycloak has the theme of the given type.
+func (t ServerThemes) Has(themeType, name string) bool {
+	_, ok := t[themeType][name]
+
+	return ok
+}
+
+// Names returns the sorted names of the themes of the given type.
+func (t ServerThemes) Names(themeType string) []string {
+	names := make([]string, 0, len(t[themeType]))
+	for name := range t[themeType] {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// GetServerVersion returns the version of the keycloak server, e.g. 21.1.1.
+func (a GoCloakAdapter) GetServerVersion(ctx context.Context) (string, error) {
+	var info serverInfo
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetResult(&info).Get(a.basePath + serverInfoGet)
+	if err = a.checkError(err, rsp); err != nil {
+		return "", errors.Wrap(err, "unable to get server info")
+	}
+
+	return info.SystemInfo.Version, nil
+}
+
+// GetServerThemes returns the themes available in keycloak, including the custom ones.
+func (a GoCloakAdapter) GetServerThemes(ctx context.Context) (ServerThemes, error) {
+	var info serverInfo
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetResult(&info).Get(a.basePath + serverInfoGet)
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to get server info")
+	}
+
+	themes := make(ServerThemes, len(info.Themes))
+
+	for themeType, list := range info.Themes {
+		names := make(map[string]struct{}, len(list))
+		for _, theme := range list {
+			names[theme.Name] = struct{}{}
+		}
+
+		themes[themeType] = names
+	}
+
+	return themes, nil
+}
+
+// GetServerPasswordPolicies returns the sorted ids of the password policies available in keycloak,
+// including the custom ones.
+func (a GoCloakAdapter) GetServerPasswordPolicies(ctx context.Context) ([]string, error) {
+	var info serverInfo
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetResult(&info).Get(a.basePath + serverInfoGet)
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to get server info")
+	}
+
+	ids := make([]string, 0, len(info.PasswordPolicies))
+	for _, p := range info.PasswordPolicies {
+		ids = append(ids, p.ID)
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// ServerMajorVersion returns the major part of the keycloak server version.
+func ServerMajorVersion(version string) (int, error) {
+	major, _, _ := strings.Cut(version, ".")
+
+	v, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse keycloak version %q: %w", version, err)
+	}
+
+	return v, nil
+}
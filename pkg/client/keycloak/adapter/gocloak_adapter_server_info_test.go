@@ -0,0 +1,77 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_GetServerVersion(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/serverinfo",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"systemInfo": map[string]string{"version": "21.1.2"},
+		}))
+
+	version, err := kcAdapter.GetServerVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "21.1.2", version)
+}
+
+func TestServerMajorVersion(t *testing.T) {
+	major, err := ServerMajorVersion("24.0.1.redhat-00001")
+	require.NoError(t, err)
+	assert.Equal(t, 24, major)
+
+	_, err = ServerMajorVersion("unknown")
+	require.Error(t, err)
+}
+
+func TestGoCloakAdapter_GetServerThemes(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/serverinfo",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"themes": map[string]interface{}{
+				"login": []map[string]interface{}{
+					{"name": "keycloak", "locales": []string{"en"}},
+					{"name": "custom"},
+				},
+				"email": []map[string]interface{}{{"name": "keycloak"}},
+			},
+		}))
+
+	themes, err := kcAdapter.GetServerThemes(context.Background())
+	require.NoError(t, err)
+	assert.True(t, themes.Has(ThemeTypeLogin, "custom"))
+	assert.False(t, themes.Has(ThemeTypeEmail, "custom"))
+	assert.False(t, themes.Has(ThemeTypeAdmin, "keycloak"))
+	assert.Equal(t, []string{"custom", "keycloak"}, themes.Names(ThemeTypeLogin))
+}
+
+func TestGoCloakAdapter_GetServerPasswordPolicies(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/serverinfo",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"passwordPolicies": []map[string]interface{}{
+				{"id": "length", "displayName": "Minimum length"},
+				{"id": "custom-policy"},
+			},
+		}))
+
+	policies, err := kcAdapter.GetServerPasswordPolicies(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"custom-policy", "length"}, policies)
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/serverinfo", httpmock.NewStringResponder(500, "fatal"))
+
+	_, err = kcAdapter.GetServerPasswordPolicies(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to get server info")
+}
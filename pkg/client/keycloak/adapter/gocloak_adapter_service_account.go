@@ -2,11 +2,15 @@ package adapter
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
 )
 
+// SyncServiceAccountRoles syncs realm roles and roles of other clients of the client service account.
+// Roles which are not declared are removed unless addOnly is set, the realm default role
+// which keycloak assigns to every service account is kept.
 func (a GoCloakAdapter) SyncServiceAccountRoles(realm, clientID string, realmRoles []string,
 	clientRoles map[string][]string, addOnly bool) error {
 	user, err := a.client.GetClientServiceAccount(context.Background(), a.token.AccessToken, realm, clientID)
@@ -24,7 +28,7 @@ func (a GoCloakAdapter) SyncServiceAccountRoles(realm, clientID string, realmRol
 		deleteRealmRoleFunc = doNotDeleteRealmRoleFromUser
 	}
 
-	if err := a.syncEntityRealmRoles(*user.ID, realm, realmRoles, roleMappings.RealmMappings,
+	if err := a.syncEntityRealmRoles(*user.ID, realm, realmRoles, withoutDefaultRealmRole(realm, roleMappings.RealmMappings),
 		a.client.AddRealmRoleToUser, deleteRealmRoleFunc); err != nil {
 		return errors.Wrap(err, "unable to sync service account realm roles")
 	}
@@ -42,6 +46,27 @@ func (a GoCloakAdapter) SyncServiceAccountRoles(realm, clientID string, realmRol
 	return nil
 }
 
+// withoutDefaultRealmRole excludes the default-roles-<realm> composite role from the current roles,
+// so it is not removed from the service account if it is not declared.
+func withoutDefaultRealmRole(realm string, roles *[]gocloak.Role) *[]gocloak.Role {
+	if roles == nil {
+		return nil
+	}
+
+	defaultRole := "default-roles-" + strings.ToLower(realm)
+	filtered := make([]gocloak.Role, 0, len(*roles))
+
+	for _, r := range *roles {
+		if r.Name != nil && *r.Name == defaultRole {
+			continue
+		}
+
+		filtered = append(filtered, r)
+	}
+
+	return &filtered
+}
+
 func doNotDeleteRealmRoleFromUser(ctx context.Context, token, realm, entityID string, roles []gocloak.Role) error {
 	return nil
 }
@@ -67,7 +67,7 @@ func (a GoCloakAdapter) syncOneEntityClientRole(
 	currentClientRoles := a.makeCurrentClientRoles(clientID, currentRoles)
 	claimedClientRoles := a.makeClaimedClientRoles(claimedRoles)
 
-	rolesToAdd, err := a.makeClientRolesToAdd(realm, CID, currentClientRoles, claimedClientRoles)
+	rolesToAdd, err := a.makeClientRolesToAdd(realm, CID, currentClientRoles, claimedRoles)
 	if err != nil {
 		return err
 	}
@@ -95,6 +95,9 @@ func (a GoCloakAdapter) syncOneEntityClientRole(
 	return nil
 }
 
+// syncEntityClientRoles syncs the client roles of the entity with the claimed roles keyed by the client id.
+// The client roles of the entity are not managed if the claimed roles are nil,
+// all of them are removed if the claimed roles are empty.
 func (a GoCloakAdapter) syncEntityClientRoles(
 	realm,
 	entityID string,
@@ -103,6 +106,10 @@ func (a GoCloakAdapter) syncEntityClientRoles(
 	addRoleFunc func(ctx context.Context, token, realm, clientID, entityID string, roles []gocloak.Role) error,
 	delRoleFunc func(ctx context.Context, token, realm, clientID, groupID string, roles []gocloak.Role) error,
 ) error {
+	if claimedRoles == nil {
+		return nil
+	}
+
 	for clientID, roles := range claimedRoles {
 		if err := a.syncOneEntityClientRole(realm, entityID, clientID, roles, currentRoles, addRoleFunc, delRoleFunc); err != nil {
 			return errors.Wrap(err, "error during syncOneEntityClientRole")
@@ -155,11 +162,16 @@ func (a GoCloakAdapter) makeClientRolesToAdd(
 	realm,
 	clientId string,
 	currentClientRoles map[string]*gocloak.Role,
-	claimedClientRoles map[string]struct{},
+	claimedRoles []string,
 ) ([]gocloak.Role, error) {
-	rolesToAdd := make([]gocloak.Role, 0, len(claimedClientRoles))
+	rolesToAdd := make([]gocloak.Role, 0, len(claimedRoles))
+	added := make(map[string]struct{}, len(claimedRoles))
+
+	for _, k := range claimedRoles {
+		if _, ok := added[k]; ok {
+			continue
+		}
 
-	for k := range claimedClientRoles {
 		if _, ok := currentClientRoles[k]; !ok {
 			role, err := a.client.GetClientRole(context.Background(), a.token.AccessToken, realm, clientId, k)
 			if err != nil {
@@ -167,6 +179,7 @@ func (a GoCloakAdapter) makeClientRolesToAdd(
 			}
 
 			rolesToAdd = append(rolesToAdd, *role)
+			added[k] = struct{}{}
 		}
 	}
 
@@ -201,8 +214,13 @@ func (a GoCloakAdapter) makeEntityRolesToAdd(
 	currentRealmRoleMap map[string]gocloak.Role,
 ) ([]gocloak.Role, error) {
 	realmRolesToAdd := make([]gocloak.Role, 0, len(claimedRealmRoles))
+	added := make(map[string]struct{}, len(claimedRealmRoles))
 
 	for _, r := range claimedRealmRoles {
+		if _, ok := added[r]; ok {
+			continue
+		}
+
 		if _, ok := currentRealmRoleMap[r]; !ok {
 			role, err := a.client.GetRealmRole(context.Background(), a.token.AccessToken, realm, r)
 			if err != nil {
@@ -210,6 +228,7 @@ func (a GoCloakAdapter) makeEntityRolesToAdd(
 			}
 
 			realmRolesToAdd = append(realmRolesToAdd, *role)
+			added[r] = struct{}{}
 		}
 	}
 
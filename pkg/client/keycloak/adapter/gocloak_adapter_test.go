@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/jarcoal/httpmock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
@@ -74,6 +76,97 @@ func (e *AdapterTestSuite) TestMakeFromServiceAccount() {
 	assert.EqualError(t, err, "unable to login with client creds, clientID: k-cl-id, realm: master: 400")
 }
 
+func (e *AdapterTestSuite) TestMakeFromServiceAccountToken() {
+	t := e.T()
+
+	httpmock.RegisterResponder("POST", "/k-url/realms/master/protocol/openid-connect/token",
+		func(req *http.Request) (*http.Response, error) {
+			if err := req.ParseForm(); err != nil {
+				return nil, err
+			}
+
+			assert.Equal(t, "client_credentials", req.PostForm.Get("grant_type"))
+			assert.Equal(t, "k-cl-id", req.PostForm.Get("client_id"))
+			assert.Equal(t, clientAssertionTypeJWTBearer, req.PostForm.Get("client_assertion_type"))
+			assert.Equal(t, "sa-token", req.PostForm.Get("client_assertion"))
+			assert.Empty(t, req.PostForm.Get("client_secret"))
+
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	_, err := MakeFromServiceAccountToken(context.Background(), "k-url", "k-cl-id", "sa-token",
+		"master", mock.NewLogr(), e.restyClient)
+	assert.NoError(t, err)
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", "/k-url/realms/master/protocol/openid-connect/token",
+		httpmock.NewStringResponder(401, "{}"))
+
+	_, err = MakeFromServiceAccountToken(context.Background(), "k-url", "k-cl-id", "sa-token",
+		"master", mock.NewLogr(), e.restyClient)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to login with service account token, clientID: k-cl-id")
+}
+
+func (e *AdapterTestSuite) TestMakeFromRefreshToken() {
+	t := e.T()
+
+	jwt := func(payload string) string {
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+	}
+
+	expired := time.Now().Add(-time.Minute).Unix()
+	valid := time.Now().Add(time.Hour).Unix()
+
+	token, err := json.Marshal(&gocloak.JWT{
+		AccessToken:  jwt(fmt.Sprintf(`{"exp":%d,"azp":"admin-cli"}`, expired)),
+		RefreshToken: jwt(fmt.Sprintf(`{"exp":%d}`, valid)),
+	})
+	require.NoError(t, err)
+
+	httpmock.RegisterResponder("POST", "/k-url/realms/master/protocol/openid-connect/token",
+		func(req *http.Request) (*http.Response, error) {
+			if err := req.ParseForm(); err != nil {
+				return nil, err
+			}
+
+			assert.Equal(t, "refresh_token", req.PostForm.Get("grant_type"))
+			assert.Equal(t, "admin-cli", req.PostForm.Get("client_id"))
+			assert.Empty(t, req.PostForm.Get("client_secret"))
+
+			return httpmock.NewJsonResponse(200, map[string]string{"access_token": "refreshed"})
+		})
+
+	cl, err := MakeFromRefreshToken(context.Background(), "k-url", token, "master", mock.NewLogr(), e.restyClient)
+	require.NoError(t, err)
+
+	exported, err := cl.ExportToken()
+	require.NoError(t, err)
+	assert.Contains(t, string(exported), `"access_token":"refreshed"`)
+
+	httpmock.Reset()
+	httpmock.RegisterResponder("POST", "/k-url/realms/master/protocol/openid-connect/token",
+		httpmock.NewStringResponder(400, `{"error":"invalid_grant"}`))
+
+	_, err = MakeFromRefreshToken(context.Background(), "k-url", token, "master", mock.NewLogr(), e.restyClient)
+	assert.True(t, IsErrTokenExpired(err), "rejected refresh token must require login")
+
+	token, err = json.Marshal(&gocloak.JWT{AccessToken: jwt(fmt.Sprintf(`{"exp":%d}`, expired))})
+	require.NoError(t, err)
+
+	_, err = MakeFromRefreshToken(context.Background(), "k-url", token, "master", mock.NewLogr(), e.restyClient)
+	assert.True(t, IsErrTokenExpired(err), "token without refresh token must require login")
+
+	token, err = json.Marshal(&gocloak.JWT{
+		AccessToken:  jwt(fmt.Sprintf(`{"exp":%d}`, expired)),
+		RefreshToken: jwt(fmt.Sprintf(`{"exp":%d}`, expired)),
+	})
+	require.NoError(t, err)
+
+	_, err = MakeFromRefreshToken(context.Background(), "k-url", token, "master", mock.NewLogr(), e.restyClient)
+	assert.True(t, IsErrTokenExpired(err), "expired refresh token must require login")
+}
+
 func (e *AdapterTestSuite) TestMake() {
 	httpmock.RegisterResponder("POST", "/foo/realms/master/protocol/openid-connect/token",
 		httpmock.NewStringResponder(200, "{}"))
@@ -126,7 +219,7 @@ func TestGoCloakAdapter_ExistRealm404(t *testing.T) {
 	// prepare
 	mockClient := new(MockGoCloakClient)
 	mockClient.On("GetRealm", "token", "realmName").
-		Return(nil, errors.New("404"))
+		Return(nil, &gocloak.APIError{Code: http.StatusNotFound, Message: "404"})
 
 	adapter := GoCloakAdapter{
 		client: mockClient,
@@ -195,9 +288,8 @@ func TestGoCloakAdapter_GetClientProtocolMappers_Failure2(t *testing.T) {
 	_, err := adapter.GetClientProtocolMappers(&client, clientID)
 	require.Error(t, err)
 
-	if err.Error() != messageBody {
-		t.Fatal("wrong error returned")
-	}
+	require.True(t, IsErrNotFound(err))
+	require.Contains(t, err.Error(), messageBody)
 }
 
 func TestGoCloakAdapter_GetClientProtocolMappers_Failure(t *testing.T) {
@@ -232,54 +324,130 @@ func TestGoCloakAdapter_GetClientProtocolMappers_Failure(t *testing.T) {
 }
 
 func TestGoCloakAdapter_CreateClient(t *testing.T) {
-	mockClient := new(MockGoCloakClient)
-	logger := mock.NewLogr()
+	a, _, _ := initAdapter()
 
-	cl := dto.Client{}
-	a := GoCloakAdapter{
-		client: mockClient,
-		token:  &gocloak.JWT{AccessToken: "token"},
-		log:    logger,
-	}
+	cl := dto.Client{RealmName: "realm", ClientId: "client", AlwaysDisplayInConsole: gocloak.BoolP(true)}
 
-	mockClient.On("CreateClient", "", getGclCln(&cl)).Return("id", nil).Once()
+	httpmock.RegisterResponder("POST", "/admin/realms/realm/clients",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			if body["clientId"] != "client" || body["alwaysDisplayInConsole"] != true {
+				return httpmock.NewStringResponse(400, "wrong body"), nil
+			}
+
+			return httpmock.NewStringResponse(201, ""), nil
+		})
 
 	err := a.CreateClient(context.Background(), &cl)
 	assert.NoError(t, err)
 
-	createErr := errors.New("create-err")
-	mockClient.On("CreateClient", "", getGclCln(&cl)).Return("", createErr).Once()
+	httpmock.RegisterResponder("POST", "/admin/realms/realm-error/clients",
+		httpmock.NewStringResponder(500, "create-err"))
+
+	cl.RealmName = "realm-error"
 	err = a.CreateClient(context.Background(), &cl)
 
-	assert.ErrorIs(t, err, createErr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "create-err")
 }
 
 func TestGoCloakAdapter_UpdateClient(t *testing.T) {
-	mockClient := new(MockGoCloakClient)
-	logger := mock.NewLogr()
+	a, _, _ := initAdapter()
+
+	cl := dto.Client{ID: "id1", RealmName: "realm", ClientId: "client", Description: gocloak.StringP("")}
+
+	httpmock.RegisterResponder("PUT", "/admin/realms/realm/clients/id1",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			// Fields which are not set must be omitted to keep the current values.
+			for _, f := range []string{
+				"name", "consentRequired", "alwaysDisplayInConsole", "baseUrl", "publicClient",
+				"directAccessGrantsEnabled", "serviceAccountsEnabled", "frontchannelLogout", "rootUrl", "adminUrl",
+				"redirectUris", "webOrigins", "protocolMappers",
+			} {
+				if _, ok := body[f]; ok {
+					return httpmock.NewStringResponse(400, "unexpected field "+f), nil
+				}
+			}
+
+			if _, ok := body["description"]; !ok {
+				return httpmock.NewStringResponse(400, "description is not set"), nil
+			}
+
+			return httpmock.NewStringResponse(204, ""), nil
+		})
 
-	cl := dto.Client{}
-	a := GoCloakAdapter{
-		client: mockClient,
-		token:  &gocloak.JWT{AccessToken: "token"},
-		log:    logger,
+	err := a.UpdateClient(context.Background(), &cl)
+	assert.NoError(t, err)
+
+	httpmock.RegisterResponder("PUT", "/admin/realms/realm/clients/id2",
+		httpmock.NewStringResponder(500, "update-error"))
+
+	cl.ID = "id2"
+	err = a.UpdateClient(context.Background(), &cl)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "update-error")
+}
+
+func TestGetGclCln_SAML(t *testing.T) {
+	cl := dto.Client{
+		ClientId:                "saml-client",
+		ClientSecret:            "secret",
+		Protocol:                dto.SAMLClientProtocol,
+		AdvancedProtocolMappers: true,
 	}
 
-	mockClient.On("UpdateClient", a.token.AccessToken, cl.RealmName,
-		getGclCln(&cl)).Return(nil).Once()
+	gcl := getGclCln(&cl)
+	assert.Equal(t, dto.SAMLClientProtocol, *gcl.Protocol)
+	assert.Nil(t, gcl.Secret)
+	assert.Nil(t, gcl.ProtocolMappers)
 
-	err := a.UpdateClient(context.Background(), &cl)
-	assert.NoError(t, err)
+	cl.Protocol = "openid-connect"
+	gcl = getGclCln(&cl)
+	assert.Equal(t, "secret", *gcl.Secret)
+	assert.NotEmpty(t, *gcl.ProtocolMappers)
+}
 
-	updErr := errors.New("update-error")
+func TestGetGclCln_WebURL(t *testing.T) {
+	cl := dto.Client{ClientId: "client", WebUrl: "https://app.example.com"}
 
-	mockClient.On("UpdateClient", a.token.AccessToken, cl.RealmName,
-		getGclCln(&cl)).Return(updErr).Once()
+	gcl := getGclCln(&cl)
+	assert.Equal(t, "https://app.example.com", *gcl.RootURL)
+	assert.Equal(t, "https://app.example.com", *gcl.AdminURL)
+	assert.Equal(t, []string{"https://app.example.com/*"}, *gcl.RedirectURIs)
+	assert.Equal(t, []string{"https://app.example.com"}, *gcl.WebOrigins)
 
-	err = a.UpdateClient(context.Background(), &cl)
-	assert.True(t, errors.Is(err, updErr))
+	cl.AdminURL = gocloak.StringP("https://admin.example.com")
+	cl.RedirectURIs = []string{"https://app.example.com/callback"}
+	gcl = getGclCln(&cl)
+	assert.Equal(t, "https://admin.example.com", *gcl.AdminURL)
+	assert.Equal(t, []string{"https://app.example.com/callback"}, *gcl.RedirectURIs)
+}
 
-	mockClient.AssertExpectations(t)
+func TestGetGclCln_SignedJWT(t *testing.T) {
+	cl := dto.Client{
+		ClientId:                "jwt-client",
+		ClientSecret:            "secret",
+		Protocol:                "openid-connect",
+		ClientAuthenticatorType: dto.SignedJWTClientAuthenticator,
+	}
+
+	gcl := getGclCln(&cl)
+	assert.Equal(t, dto.SignedJWTClientAuthenticator, *gcl.ClientAuthenticatorType)
+	assert.Nil(t, gcl.Secret)
+
+	cl.ClientAuthenticatorType = ""
+	gcl = getGclCln(&cl)
+	assert.Nil(t, gcl.ClientAuthenticatorType)
+	assert.Equal(t, "secret", *gcl.Secret)
 }
 
 func TestGoCloakAdapter_SyncClientProtocolMapper_Success(t *testing.T) {
@@ -449,15 +617,15 @@ func TestGoCloakAdapter_SyncRealmRole(t *testing.T) {
 		}}
 	mockClient.On("GetRealmRole", realmName, roleName).Return(&currentRole, nil)
 
-	composite1 := gocloak.Role{Name: gocloak.StringP("c1")}
-	mockClient.On("GetCompositeRealmRolesByRoleID", realmName, roleID).Return([]*gocloak.Role{
+	composite1 := gocloak.Role{Name: gocloak.StringP("c1"), ID: gocloak.StringP("c1-id")}
+	mockClient.On("GetCompositeRolesByRoleID", realmName, roleID).Return([]*gocloak.Role{
 		&composite1,
 	}, nil)
 
-	compositeFoo := gocloak.Role{Name: gocloak.StringP("foo")}
+	compositeFoo := gocloak.Role{Name: gocloak.StringP("foo"), ID: gocloak.StringP("foo-id")}
 	mockClient.On("GetRealmRole", realmName, *compositeFoo.Name).Return(&compositeFoo, nil)
 
-	compositeBar := gocloak.Role{Name: gocloak.StringP("bar")}
+	compositeBar := gocloak.Role{Name: gocloak.StringP("bar"), ID: gocloak.StringP("bar-id")}
 	mockClient.On("GetRealmRole", realmName, *compositeBar.Name).Return(&compositeBar, nil)
 	mockClient.On("AddRealmRoleComposite", realmName, roleName,
 		[]gocloak.Role{compositeFoo, compositeBar}).
@@ -490,6 +658,231 @@ func TestGoCloakAdapter_SyncRealmRole(t *testing.T) {
 	}
 }
 
+func TestGoCloakAdapter_SyncRealmRole_Composites(t *testing.T) {
+	realmName, roleName, roleID := "realm1", "role1", "id321"
+	currentRole := gocloak.Role{Name: &roleName, ID: &roleID}
+
+	realmMember := gocloak.Role{Name: gocloak.StringP("realm-member"), ID: gocloak.StringP("realm-member-id")}
+	staleRealmMember := gocloak.Role{Name: gocloak.StringP("stale"), ID: gocloak.StringP("stale-id")}
+	clientMember := gocloak.Role{Name: gocloak.StringP("viewer"), ID: gocloak.StringP("viewer-id"),
+		ClientRole: gocloak.BoolP(true), ContainerID: gocloak.StringP("client-uuid")}
+	staleClientMember := gocloak.Role{Name: gocloak.StringP("editor"), ID: gocloak.StringP("editor-id"),
+		ClientRole: gocloak.BoolP(true), ContainerID: gocloak.StringP("client-uuid")}
+	otherClientMember := gocloak.Role{Name: gocloak.StringP("other"), ID: gocloak.StringP("other-id"),
+		ClientRole: gocloak.BoolP(true), ContainerID: gocloak.StringP("other-client-uuid")}
+
+	tests := []struct {
+		name           string
+		addOnly        bool
+		wantDeleted    []gocloak.Role
+		wantUnresolved []string
+	}{
+		{
+			name:           "full sync",
+			wantDeleted:    []gocloak.Role{staleRealmMember, staleClientMember},
+			wantUnresolved: []string{"missing", "app/missing", "unknown-client/admin"},
+		},
+		{
+			name:           "add only",
+			addOnly:        true,
+			wantUnresolved: []string{"missing", "app/missing", "unknown-client/admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := MockGoCloakClient{}
+
+			mockClient.On("GetRealmRole", realmName, roleName).Return(&currentRole, nil)
+			mockClient.On("GetCompositeRolesByRoleID", realmName, roleID).Return([]*gocloak.Role{
+				&staleRealmMember, &clientMember, &staleClientMember, &otherClientMember,
+			}, nil)
+			mockClient.On("GetRealmRole", realmName, "realm-member").Return(&realmMember, nil)
+			mockClient.On("GetRealmRole", realmName, "missing").Return(nil, &gocloak.APIError{Code: http.StatusNotFound, Message: "404 Not Found"})
+			mockClient.On("GetClients", realmName, gocloak.GetClientsParams{ClientID: gocloak.StringP("app")}).
+				Return([]*gocloak.Client{{ClientID: gocloak.StringP("app"), ID: gocloak.StringP("client-uuid")}}, nil)
+			mockClient.On("GetClients", realmName, gocloak.GetClientsParams{ClientID: gocloak.StringP("unknown-client")}).
+				Return([]*gocloak.Client{}, nil)
+			mockClient.On("GetClientRole", realmName, "client-uuid", "viewer").Return(&clientMember, nil)
+			mockClient.On("GetClientRole", realmName, "client-uuid", "missing").Return(nil, &gocloak.APIError{Code: http.StatusNotFound, Message: "404 Not Found"})
+			mockClient.On("AddRealmRoleComposite", realmName, roleName, []gocloak.Role{realmMember}).Return(nil)
+			mockClient.On("DeleteRealmRoleComposite", realmName, roleName, tt.wantDeleted).Return(nil)
+			mockClient.On("UpdateRealmRole", realmName, roleName, testifyMock.Anything).Return(nil)
+
+			a := GoCloakAdapter{
+				client: &mockClient,
+				token:  &gocloak.JWT{AccessToken: "token"},
+				log:    mock.NewLogr(),
+			}
+
+			role := dto.PrimaryRealmRole{
+				ID:          &roleID,
+				Name:        roleName,
+				IsComposite: true,
+				Composites:  []string{"realm-member", "missing"},
+				CompositesClientRoles: map[string][]string{
+					"app":            {"viewer", "missing"},
+					"unknown-client": {"admin"},
+				},
+				AddOnly: tt.addOnly,
+			}
+
+			require.NoError(t, a.SyncRealmRole(realmName, &role))
+			assert.Equal(t, tt.wantUnresolved, role.UnresolvedComposites)
+
+			if tt.addOnly {
+				mockClient.AssertNotCalled(t, "DeleteRealmRoleComposite", testifyMock.Anything, testifyMock.Anything,
+					testifyMock.Anything)
+			} else {
+				mockClient.AssertCalled(t, "DeleteRealmRoleComposite", realmName, roleName, tt.wantDeleted)
+			}
+
+			mockClient.AssertCalled(t, "AddRealmRoleComposite", realmName, roleName, []gocloak.Role{realmMember})
+		})
+	}
+}
+
+func TestGoCloakAdapter_SyncRealmDefaultRoles(t *testing.T) {
+	realmName, defaultRole, defaultRoleID := "realm1", "default-roles-realm1", "default-id"
+
+	offlineAccess := gocloak.Role{Name: gocloak.StringP("offline_access"), ID: gocloak.StringP("offline-id")}
+	umaAuthorization := gocloak.Role{Name: gocloak.StringP("uma_authorization"), ID: gocloak.StringP("uma-id")}
+	developer := gocloak.Role{Name: gocloak.StringP("developer"), ID: gocloak.StringP("developer-id")}
+	viewProfile := gocloak.Role{Name: gocloak.StringP("view-profile"), ID: gocloak.StringP("view-profile-id"),
+		ClientRole: gocloak.BoolP(true), ContainerID: gocloak.StringP("account-id")}
+
+	mockClient := MockGoCloakClient{}
+	mockClient.On("GetRealm", "token", realmName).Return(&gocloak.RealmRepresentation{
+		DefaultRole: &gocloak.Role{Name: &defaultRole, ID: &defaultRoleID},
+	}, nil)
+	mockClient.On("GetCompositeRolesByRoleID", realmName, defaultRoleID).
+		Return([]*gocloak.Role{&offlineAccess, &umaAuthorization, &viewProfile}, nil)
+	mockClient.On("GetRealmRole", realmName, "offline_access").Return(&offlineAccess, nil)
+	mockClient.On("GetRealmRole", realmName, "developer").Return(&developer, nil)
+	mockClient.On("GetRealmRole", realmName, "missing").Return(nil, &gocloak.APIError{Code: http.StatusNotFound, Message: "404 Not Found"})
+	mockClient.On("AddRealmRoleComposite", realmName, defaultRole, []gocloak.Role{developer}).Return(nil)
+	mockClient.On("DeleteRealmRoleComposite", realmName, defaultRole, []gocloak.Role{umaAuthorization}).Return(nil)
+
+	a := GoCloakAdapter{
+		client: &mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+		log:    mock.NewLogr(),
+	}
+
+	unresolved, err := a.SyncRealmDefaultRoles(context.Background(), realmName,
+		[]string{"offline_access", "developer", "missing"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"missing"}, unresolved)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_SyncRealmDefaultRoles_NoDefaultRole(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	mockClient.On("GetRealm", "token", "realm1").Return(&gocloak.RealmRepresentation{}, nil)
+
+	a := GoCloakAdapter{client: &mockClient, token: &gocloak.JWT{AccessToken: "token"}}
+
+	_, err := a.SyncRealmDefaultRoles(context.Background(), "realm1", []string{"offline_access"}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "has no default role composite")
+}
+
+func TestGoCloakAdapter_SyncRealmRole_RemovesAttributes(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	realmName, roleName, roleID := "realm1", "role1", "id321"
+	currentRole := gocloak.Role{Name: &roleName, ID: &roleID, Attributes: &map[string][]string{
+		"dropped": {"value"},
+		"kept":    {"old"},
+	}}
+
+	mockClient.On("GetRealmRole", realmName, roleName).Return(&currentRole, nil)
+	mockClient.On("GetCompositeRolesByRoleID", realmName, roleID).Return([]*gocloak.Role{}, nil)
+	mockClient.On("UpdateRealmRole", realmName, roleName, testifyMock.Anything).Return(nil)
+
+	a := GoCloakAdapter{
+		client: &mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+		log:    mock.NewLogr(),
+	}
+
+	role := dto.PrimaryRealmRole{ID: &roleID, Name: roleName, Attributes: map[string][]string{"kept": {"new"}}}
+	require.NoError(t, a.SyncRealmRole(realmName, &role))
+
+	updated, ok := mockClient.Calls[len(mockClient.Calls)-1].Arguments.Get(2).(gocloak.Role)
+	require.True(t, ok)
+	require.Equal(t, map[string][]string{"kept": {"new"}}, *updated.Attributes)
+}
+
+func TestGoCloakAdapter_SyncRealmRole_Adopt(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	realmName, roleName, roleID := "realm1", "role1", "id321"
+	currentRole := gocloak.Role{Name: &roleName, ID: &roleID}
+
+	mockClient.On("GetRealmRole", realmName, roleName).Return(&currentRole, nil)
+	mockClient.On("GetCompositeRolesByRoleID", realmName, roleID).Return([]*gocloak.Role{}, nil)
+	mockClient.On("UpdateRealmRole", realmName, roleName, testifyMock.Anything).Return(nil)
+
+	a := GoCloakAdapter{
+		client: &mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+		log:    mock.NewLogr(),
+	}
+
+	role := dto.PrimaryRealmRole{Name: roleName, Description: "adopted", Adopt: true}
+	require.NoError(t, a.SyncRealmRole(realmName, &role))
+	require.Equal(t, roleID, *role.ID)
+
+	updated, ok := mockClient.Calls[len(mockClient.Calls)-1].Arguments.Get(2).(gocloak.Role)
+	require.True(t, ok)
+	require.Equal(t, "adopted", *updated.Description)
+}
+
+func TestRoleAttributes(t *testing.T) {
+	current := &map[string][]string{"foo": {"1"}, "bar": {"2"}}
+
+	tests := []struct {
+		name     string
+		current  *map[string][]string
+		declared map[string][]string
+		addOnly  bool
+		want     map[string][]string
+	}{
+		{
+			name:     "declared attributes replace current",
+			current:  current,
+			declared: map[string][]string{"foo": {"3"}},
+			want:     map[string][]string{"foo": {"3"}},
+		},
+		{
+			name:    "all attributes are removed",
+			current: current,
+			want:    map[string][]string{},
+		},
+		{
+			name:     "undeclared attributes are kept with addOnly",
+			current:  current,
+			declared: map[string][]string{"foo": {"3"}},
+			addOnly:  true,
+			want:     map[string][]string{"foo": {"3"}, "bar": {"2"}},
+		},
+		{
+			name:    "no current attributes",
+			addOnly: true,
+			want:    map[string][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := roleAttributes(tt.current, tt.declared, tt.addOnly)
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+}
+
 func TestGoCloakAdapter_SyncServiceAccountRoles_AddOnly(t *testing.T) {
 	mockClient := MockGoCloakClient{}
 	adapter := GoCloakAdapter{
@@ -540,6 +933,7 @@ func TestGoCloakAdapter_SyncServiceAccountRoles(t *testing.T) {
 	mockClient.On("GetRoleMappingByUserID", "realm", "id").
 		Return(&gocloak.MappingsRepresentation{RealmMappings: &[]gocloak.Role{
 			{Name: gocloak.StringP("exist_realm_role1")},
+			{Name: gocloak.StringP("default-roles-realm")},
 			{Name: gocloak.StringP("exist_realm_role2")},
 		}, ClientMappings: map[string]*gocloak.ClientMappingsRepresentation{
 			"zabrod": {Client: gocloak.StringP("zabrod"), ID: gocloak.StringP("iiss123"),
@@ -598,6 +992,34 @@ func TestGoCloakAdapter_SyncServiceAccountRoles(t *testing.T) {
 	}
 }
 
+func TestGoCloakAdapter_SyncServiceAccountRoles_NilClientRoles(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	adapter := GoCloakAdapter{
+		client: &mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+		log:    mock.NewLogr(),
+	}
+
+	mockClient.On("GetClientServiceAccount", "realm", "client").Return(&gocloak.User{
+		ID: gocloak.StringP("id"),
+	}, nil)
+	mockClient.On("GetRoleMappingByUserID", "realm", "id").
+		Return(&gocloak.MappingsRepresentation{ClientMappings: map[string]*gocloak.ClientMappingsRepresentation{
+			"foo": {Client: gocloak.StringP("foo"), ID: gocloak.StringP("foo321"),
+				Mappings: &[]gocloak.Role{{Name: gocloak.StringP("baz")}}},
+		}}, nil)
+
+	// the client roles are not managed, so they are not removed
+	require.NoError(t, adapter.SyncServiceAccountRoles("realm", "client", []string{}, nil, false))
+	mockClient.AssertNotCalled(t, "DeleteClientRoleFromUser")
+
+	mockClient.On("DeleteClientRoleFromUser", "realm", "foo321", "id",
+		[]gocloak.Role{{Name: gocloak.StringP("baz")}}).Return(nil)
+
+	require.NoError(t, adapter.SyncServiceAccountRoles("realm", "client", []string{}, map[string][]string{}, false))
+	mockClient.AssertExpectations(t)
+}
+
 func TestGoCloakAdapter_SyncRealmGroup_FailureGetGroupsFatal(t *testing.T) {
 	clMock := MockGoCloakClient{}
 
@@ -614,7 +1036,7 @@ func TestGoCloakAdapter_SyncRealmGroup_FailureGetGroupsFatal(t *testing.T) {
 		Search: &group.Name,
 	}).Return(nil, errors.New("fatal mock"))
 
-	_, err := adapter.SyncRealmGroup("realm1", &group)
+	_, err := adapter.SyncRealmGroup("realm1", &group, "")
 
 	if err == nil {
 		t.Fatal("error is not returned")
@@ -625,6 +1047,38 @@ func TestGoCloakAdapter_SyncRealmGroup_FailureGetGroupsFatal(t *testing.T) {
 	}
 }
 
+func TestGoCloakAdapter_SyncRealmGroup_MergesClientRoles(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	a := GoCloakAdapter{
+		client: &mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+		log:    mock.NewLogr(),
+	}
+
+	viewer, editor := gocloak.Role{Name: gocloak.StringP("viewer")}, gocloak.Role{Name: gocloak.StringP("editor")}
+
+	mockClient.On("GetGroups", "realm1", gocloak.GetGroupsParams{Search: gocloak.StringP("group1")}).
+		Return([]*gocloak.Group{{Name: gocloak.StringP("group1"), ID: gocloak.StringP("1")}}, nil)
+	mockClient.On("UpdateGroup", "realm1", testifyMock.Anything).Return(nil)
+	mockClient.On("GetRoleMappingByGroupID", "realm1", "1").Return(&gocloak.MappingsRepresentation{}, nil)
+	mockClient.On("GetClients", "realm1", gocloak.GetClientsParams{ClientID: gocloak.StringP("client1")}).
+		Return([]*gocloak.Client{{ID: gocloak.StringP("clid1"), ClientID: gocloak.StringP("client1")}}, nil)
+	mockClient.On("GetClientRole", "realm1", "clid1", "viewer").Return(&viewer, nil)
+	mockClient.On("GetClientRole", "realm1", "clid1", "editor").Return(&editor, nil)
+	// the mock sorts roles by name
+	mockClient.On("AddClientRoleToGroup", "realm1", "clid1", "1", []gocloak.Role{editor, viewer}).Return(nil)
+
+	_, err := a.SyncRealmGroup("realm1", &keycloakApi.KeycloakRealmGroupSpec{
+		Name: "group1",
+		ClientRoles: []keycloakApi.ClientRole{
+			{ClientID: "client1", Roles: []string{"viewer"}},
+			{ClientID: "client1", Roles: []string{"editor", "viewer"}},
+		},
+	}, "")
+	require.NoError(t, err)
+	mockClient.AssertNumberOfCalls(t, "AddClientRoleToGroup", 1)
+}
+
 func TestGoCloakAdapter_SyncRealmGroup(t *testing.T) {
 	mockClient := MockGoCloakClient{}
 	adapter := GoCloakAdapter{
@@ -642,7 +1096,7 @@ func TestGoCloakAdapter_SyncRealmGroup(t *testing.T) {
 			SubGroups: &[]gocloak.Group{oldChildGroup}}}, nil)
 	mockClient.On("UpdateGroup", "realm1", gocloak.Group{Name: gocloak.StringP("group1"),
 		Attributes: &map[string][]string{"foo": {"foo", "bar"}},
-		Path:       gocloak.StringP(""),
+		Path:       gocloak.StringP("/group1"),
 		Access:     &map[string]bool{}, ID: gocloak.StringP("1"),
 		SubGroups: &[]gocloak.Group{{Name: gocloak.StringP("old-group")}}}).Return(nil)
 
@@ -714,7 +1168,7 @@ func TestGoCloakAdapter_SyncRealmGroup(t *testing.T) {
 			{ClientID: "client1", Roles: []string{"client-role1", "client-role2"}},
 			{ClientID: "old-cl-3", Roles: []string{"client-role4"}},
 		},
-	})
+	}, "")
 	if err != nil {
 		t.Fatalf("%+v", err)
 	}
@@ -724,6 +1178,119 @@ func TestGoCloakAdapter_SyncRealmGroup(t *testing.T) {
 	}
 }
 
+func TestGoCloakAdapter_SyncRealmGroup_CreatesNestedGroup(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	a := GoCloakAdapter{
+		client: &mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+		log:    mock.NewLogr(),
+	}
+
+	mockClient.On("GetGroups", "realm1", gocloak.GetGroupsParams{Search: gocloak.StringP("admins")}).
+		Return([]*gocloak.Group{{Name: gocloak.StringP("admins"), ID: gocloak.StringP("top-admins")}}, nil)
+	mockClient.On("GetGroups", "realm1", gocloak.GetGroupsParams{Search: gocloak.StringP("platform")}).
+		Return([]*gocloak.Group{{Name: gocloak.StringP("platform"), ID: gocloak.StringP("platform-id")}}, nil)
+	mockClient.On("GetGroups", "realm1", gocloak.GetGroupsParams{Search: gocloak.StringP("team")}).
+		Return([]*gocloak.Group{}, nil)
+	mockClient.On("CreateChildGroup", "realm1", "platform-id", gocloak.Group{Name: gocloak.StringP("team")}).
+		Return("team-id", nil)
+	mockClient.On("CreateChildGroup", "realm1", "team-id", gocloak.Group{Name: gocloak.StringP("admins"),
+		Path: gocloak.StringP("/platform/team/admins"), Attributes: &map[string][]string{}, Access: &map[string]bool{}}).
+		Return("admins-id", nil)
+	mockClient.On("GetRoleMappingByGroupID", "realm1", "admins-id").Return(&gocloak.MappingsRepresentation{}, nil)
+
+	groupID, err := a.SyncRealmGroup("realm1", &keycloakApi.KeycloakRealmGroupSpec{
+		Name:       "admins",
+		Path:       "/platform/team/admins",
+		Attributes: map[string][]string{},
+		Access:     map[string]bool{},
+	}, "")
+	require.NoError(t, err)
+	require.Equal(t, "admins-id", groupID)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_SyncRealmGroup_MovesGroup(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	a := GoCloakAdapter{
+		client: &mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+		log:    mock.NewLogr(),
+	}
+
+	group := gocloak.Group{Name: gocloak.StringP("admins"), ID: gocloak.StringP("admins-id"),
+		Path: gocloak.StringP("/admins")}
+
+	mockClient.On("GetGroup", "realm1", "admins-id").Return(&group, nil)
+	mockClient.On("GetGroups", "realm1", gocloak.GetGroupsParams{Search: gocloak.StringP("platform")}).
+		Return([]*gocloak.Group{{Name: gocloak.StringP("platform"), ID: gocloak.StringP("platform-id")}}, nil)
+	mockClient.On("CreateChildGroup", "realm1", "platform-id", group).Return("", nil)
+	mockClient.On("UpdateGroup", "realm1", testifyMock.MatchedBy(func(g gocloak.Group) bool {
+		return *g.Path == "/platform/admins"
+	})).Return(nil)
+	mockClient.On("GetRoleMappingByGroupID", "realm1", "admins-id").Return(&gocloak.MappingsRepresentation{}, nil)
+
+	groupID, err := a.SyncRealmGroup("realm1", &keycloakApi.KeycloakRealmGroupSpec{
+		Name: "admins",
+		Path: "/platform/admins",
+	}, "admins-id")
+	require.NoError(t, err)
+	require.Equal(t, "admins-id", groupID)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_SyncRealmGroup_RemovesAttributes(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	a := GoCloakAdapter{
+		client: &mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+		log:    mock.NewLogr(),
+	}
+
+	mockClient.On("GetGroups", "realm1", gocloak.GetGroupsParams{Search: gocloak.StringP("group1")}).
+		Return([]*gocloak.Group{{Name: gocloak.StringP("group1"), ID: gocloak.StringP("1"),
+			Attributes: &map[string][]string{"ldap-hint": {"ou=people"}}}}, nil)
+	mockClient.On("UpdateGroup", "realm1", testifyMock.MatchedBy(func(g gocloak.Group) bool {
+		return g.Attributes != nil && len(*g.Attributes) == 0
+	})).Return(nil)
+	mockClient.On("GetRoleMappingByGroupID", "realm1", "1").Return(&gocloak.MappingsRepresentation{}, nil)
+
+	_, err := a.SyncRealmGroup("realm1", &keycloakApi.KeycloakRealmGroupSpec{Name: "group1"}, "")
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_SyncRealmGroup_WrongPath(t *testing.T) {
+	a := GoCloakAdapter{client: &MockGoCloakClient{}, token: &gocloak.JWT{AccessToken: "token"}}
+
+	_, err := a.SyncRealmGroup("realm1", &keycloakApi.KeycloakRealmGroupSpec{
+		Name: "admins",
+		Path: "/platform/users",
+	}, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be equal to the group name")
+}
+
+func TestGoCloakAdapter_DeleteGroup_ByPath(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	a := GoCloakAdapter{
+		client: &mockClient,
+		token:  &gocloak.JWT{AccessToken: "token"},
+		log:    mock.NewLogr(),
+	}
+
+	mockClient.On("GetGroups", "realm1", gocloak.GetGroupsParams{Search: gocloak.StringP("admins")}).
+		Return([]*gocloak.Group{
+			{Name: gocloak.StringP("admins"), ID: gocloak.StringP("top-admins")},
+			{Name: gocloak.StringP("platform"), ID: gocloak.StringP("platform-id"), SubGroups: &[]gocloak.Group{
+				{Name: gocloak.StringP("admins"), ID: gocloak.StringP("admins-id")},
+			}},
+		}, nil)
+	mockClient.On("DeleteGroup", "realm1", "admins-id").Return(nil)
+
+	require.NoError(t, a.DeleteGroup(context.Background(), "realm1", "/platform/admins"))
+}
+
 func TestGoCloakAdapter_DeleteGroup(t *testing.T) {
 	mockClient := MockGoCloakClient{}
 	adapter := GoCloakAdapter{
@@ -782,6 +1349,49 @@ func TestGoCloakAdapter_PutDefaultIdp(t *testing.T) {
 	}
 }
 
+func TestGoCloakAdapter_PutDefaultIdp_ExistingConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		defaultProvider string
+		wantUpdates     int
+	}{
+		{name: "config is changed", defaultProvider: "old-sso", wantUpdates: 1},
+		{name: "config is not changed", defaultProvider: "sso", wantUpdates: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			kcAdapter, _, _ := initAdapter()
+			httpmock.Reset()
+
+			realm := dto.Realm{Name: "realm1", SsoRealmName: "sso", SsoAutoRedirectEnabled: true}
+
+			httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/authentication/flows/browser/executions",
+				httpmock.NewJsonResponderOrPanic(200, []api.SimpleAuthExecution{{
+					Id:                   "id1",
+					ProviderId:           "identity-provider-redirector",
+					AuthenticationConfig: "cfg1",
+				}}))
+			httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/authentication/config/cfg1",
+				httpmock.NewJsonResponderOrPanic(200, AuthenticatorConfig{
+					ID:     "cfg1",
+					Alias:  "edp-sso",
+					Config: map[string]string{"defaultProvider": tt.defaultProvider},
+				}))
+			httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/authentication/config/cfg1",
+				httpmock.NewStringResponder(204, ""))
+
+			err := kcAdapter.PutDefaultIdp(&realm)
+			require.NoError(t, err)
+
+			info := httpmock.GetCallCountInfo()
+			assert.Equal(t, tt.wantUpdates, info["PUT /admin/realms/realm1/authentication/config/cfg1"])
+			assert.Zero(t, info["POST /admin/realms/realm1/authentication/executions/id1/config"])
+		})
+	}
+}
+
 func TestGoCloakAdapter_GetGoCloak(t *testing.T) {
 	gcl := GoCloakAdapter{}
 	if gcl.GetGoCloak() != nil {
@@ -898,28 +1508,25 @@ func TestGoCloakAdapter_CreateCentralIdentityProvider(t *testing.T) {
 		fmt.Sprintf("/admin/realms/%s/identity-provider/instances", realm.Name),
 		httpmock.NewStringResponder(201, ""))
 
-	httpmock.RegisterResponder("POST",
-		fmt.Sprintf("/admin/realms/%s/identity-provider/instances/%s/mappers", realm.Name, realm.SsoRealmName),
-		httpmock.NewStringResponder(201, ""))
+	mockClient.On("CreateIdentityProviderMapper", realm.Name, realm.SsoRealmName, testifyMock.Anything).
+		Return("new-id", nil).Times(3)
 
 	err := a.CreateCentralIdentityProvider(&realm, &dto.Client{})
 	assert.NoError(t, err)
+	mockClient.AssertNumberOfCalls(t, "CreateIdentityProviderMapper", 3)
 
-	httpmock.RegisterResponder("POST",
-		fmt.Sprintf("/admin/realms/%s/identity-provider/instances/%s/mappers", realm.Name, realm.SsoRealmName),
-		httpmock.NewStringResponder(500, "fatal"))
+	mockClient.On("CreateIdentityProviderMapper", realm.Name, realm.SsoRealmName, testifyMock.Anything).
+		Return("", errors.New("fatal"))
 
 	err = a.CreateCentralIdentityProvider(&realm, &dto.Client{})
 	assert.Error(t, err)
 	assert.EqualError(t, err,
-		"unable to create central idp mappers: unable to create central idp mapper: error in creation idP mapper by name administrator")
+		"unable to create central idp mappers: unable to create central idp mapper: error in creation idP mapper by name administrator: fatal")
 }
 
 func (e *AdapterTestSuite) TestGoCloakAdapter_DeleteRealmUser() {
 	username := "username"
-	httpmock.RegisterResponder("DELETE",
-		fmt.Sprintf("/admin/realms/%s/users/%s", e.realmName, username),
-		httpmock.NewStringResponder(200, ""))
+	e.goCloakMockClient.On("DeleteUser", e.realmName, username).Return(nil).Once()
 	e.goCloakMockClient.On("GetUsers", e.realmName, gocloak.GetUsersParams{Username: &username}).
 		Return([]*gocloak.User{
 			{Username: &username, ID: &username},
@@ -932,13 +1539,13 @@ func (e *AdapterTestSuite) TestGoCloakAdapter_DeleteRealmUser() {
 		Return([]*gocloak.User{
 			{Username: &username, ID: &username},
 		}, nil).Once()
-	httpmock.RegisterResponder("DELETE",
-		fmt.Sprintf("/admin/realms/%s/users/%s", e.realmName, username),
-		httpmock.NewStringResponder(404, ""))
+	e.goCloakMockClient.On("DeleteUser", e.realmName, username).
+		Return(&gocloak.APIError{Code: http.StatusNotFound, Message: "404 Not Found"}).Once()
 
 	err = e.adapter.DeleteRealmUser(context.Background(), e.realmName, username)
 	assert.Error(e.T(), err)
-	assert.EqualError(e.T(), err, "unable to delete user: status: 404, body: ")
+	assert.EqualError(e.T(), err, "unable to delete user: 404 Not Found")
+	assert.True(e.T(), IsErrNotFound(err))
 
 	e.goCloakMockClient.On("GetUsers", e.realmName, gocloak.GetUsersParams{Username: &username}).
 		Return([]*gocloak.User{
@@ -956,3 +1563,44 @@ func (e *AdapterTestSuite) TestGoCloakAdapter_DeleteRealmUser() {
 	assert.Error(e.T(), err)
 	assert.EqualError(e.T(), err, "unable to get users: fatal get users")
 }
+
+func TestGoCloakAdapter_SyncClientScopeMappings(t *testing.T) {
+	mockClient := MockGoCloakClient{}
+	adapter := GoCloakAdapter{
+		client:   &mockClient,
+		token:    &gocloak.JWT{AccessToken: "token"},
+		basePath: "",
+		log:      mock.NewLogr(),
+	}
+
+	mockClient.On("GetClientScopeMappings", "realm", "client").
+		Return(&gocloak.MappingsRepresentation{RealmMappings: &[]gocloak.Role{
+			{Name: gocloak.StringP("old_realm_role")},
+		}, ClientMappings: map[string]*gocloak.ClientMappingsRepresentation{
+			"old": {Client: gocloak.StringP("old"), ID: gocloak.StringP("old321"),
+				Mappings: &[]gocloak.Role{
+					{Name: gocloak.StringP("old_client_role")},
+				}},
+		}}, nil)
+	mockClient.On("GetRealmRole", "realm", "foo").
+		Return(&gocloak.Role{Name: gocloak.StringP("foo")}, nil)
+	mockClient.On("CreateClientScopeMappingsRealmRoles", "realm", "client",
+		[]gocloak.Role{{Name: gocloak.StringP("foo")}}).Return(nil)
+	mockClient.On("DeleteClientScopeMappingsRealmRoles", "realm", "client",
+		[]gocloak.Role{{Name: gocloak.StringP("old_realm_role")}}).Return(nil)
+	mockClient.On("GetClients", "realm",
+		gocloak.GetClientsParams{ClientID: gocloak.StringP("bar")}).Return([]*gocloak.Client{
+		{ClientID: gocloak.StringP("bar"), ID: gocloak.StringP("bar321")},
+	}, nil)
+	mockClient.On("GetClientRole", "realm", "bar321", "john").
+		Return(&gocloak.Role{Name: gocloak.StringP("john")}, nil)
+	mockClient.On("CreateClientScopeMappingsClientRoles", "realm", "client", "bar321",
+		[]gocloak.Role{{Name: gocloak.StringP("john")}}).Return(nil)
+	mockClient.On("DeleteClientScopeMappingsClientRoles", "realm", "client", "old321",
+		[]gocloak.Role{{Name: gocloak.StringP("old_client_role")}}).Return(nil)
+
+	err := adapter.SyncClientScopeMappings(context.Background(), "realm", "client", []string{"foo"},
+		map[string][]string{"bar": {"john"}}, false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
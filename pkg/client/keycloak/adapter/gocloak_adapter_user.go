@@ -2,23 +2,35 @@ package adapter
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
 )
 
 type KeycloakUser struct {
-	Username            string
-	Enabled             bool
-	EmailVerified       bool
-	Email               string
-	FirstName           string
-	LastName            string
+	Username      string
+	Enabled       bool
+	EmailVerified bool
+	Email         string
+	FirstName     string
+	LastName      string
+	// RequiredUserActions are required from the user when the user is created.
 	RequiredUserActions []string
-	Roles               []string
-	Groups              []string
-	Attributes          map[string]string
-	Password            string
+	// AddedRequiredUserActions are added to the required actions of the existing user,
+	// so the actions which the user has already completed are not required again.
+	AddedRequiredUserActions []string
+	Roles                    []string
+	ClientRoles              map[string][]string
+	Groups                   []string
+	PruneGroups              bool
+	Attributes               map[string]string
+	Password                 string
+	// ResetPassword sets the password of the existing user too, otherwise the password is set only on create.
+	ResetPassword bool
+	// PasswordTemporary requires the user to change the password on the first login.
+	PasswordTemporary bool
 }
 
 type UserRealmRoleMapping struct {
@@ -29,10 +41,11 @@ type UserRealmRoleMapping struct {
 type UserGroupMapping struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
+	Path string `json:"path"`
 }
 
 func (a GoCloakAdapter) SyncRealmUser(ctx context.Context, realmName string, user *KeycloakUser, addOnly bool) error {
-	users, err := a.client.GetUsers(ctx, a.token.AccessToken, realmName, gocloak.GetUsersParams{
+	users, err := a.getUsers(ctx, realmName, gocloak.GetUsersParams{
 		Username: gocloak.StringP(user.Username),
 	})
 	if err != nil {
@@ -68,21 +81,182 @@ func (a GoCloakAdapter) SyncRealmUser(ctx context.Context, realmName string, use
 		return errors.Wrap(err, "unable to sync user roles")
 	}
 
-	if err := a.syncUserGroups(ctx, realmName, *keycloakUser.ID, user, addOnly); err != nil {
+	if err := a.syncUserGroups(ctx, realmName, *keycloakUser.ID, user); err != nil {
 		return errors.Wrap(err, "unable to sync user group")
 	}
 
 	return nil
 }
 
-func (a GoCloakAdapter) syncUserGroups(ctx context.Context, realmName string, userID string, user *KeycloakUser, addOnly bool) error {
-	if !addOnly {
-		if err := a.clearUserGroups(ctx, realmName, userID); err != nil {
-			return errors.Wrap(err, "unable to clear user groups")
+// ExecuteActionsEmail sends the email with the links to execute the actions, e.g. UPDATE_PASSWORD, VERIFY_EMAIL, to the user.
+func (a GoCloakAdapter) ExecuteActionsEmail(ctx context.Context, realmName, username string, actions []string) error {
+	users, err := a.getUsers(ctx, realmName, gocloak.GetUsersParams{
+		Username: &username,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to get users")
+	}
+
+	usr, exists := checkFullUsernameMatch(username, users)
+	if !exists {
+		return NotFoundError("user not found")
+	}
+
+	if err := a.client.ExecuteActionsEmail(ctx, a.token.AccessToken, realmName, gocloak.ExecuteActionsEmail{
+		UserID:  usr.ID,
+		Actions: &actions,
+	}); err != nil {
+		return errors.Wrap(err, "unable to execute actions email")
+	}
+
+	return nil
+}
+
+// credentialRequiredActions are the required actions which set up the credentials of the types again.
+var credentialRequiredActions = map[string]string{
+	"password":              "UPDATE_PASSWORD",
+	"otp":                   "CONFIGURE_TOTP",
+	"webauthn":              "webauthn-register",
+	"webauthn-passwordless": "webauthn-register-passwordless",
+}
+
+// ListUserCredentials returns the descriptions of the credentials of the user, e.g. otp (phone).
+func (a GoCloakAdapter) ListUserCredentials(ctx context.Context, realmName, username string) ([]string, error) {
+	usr, err := a.findUser(ctx, realmName, username)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := a.client.GetCredentials(ctx, a.token.AccessToken, realmName, *usr.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get user credentials")
+	}
+
+	descriptions := make([]string, 0, len(credentials))
+
+	for _, c := range credentials {
+		if c.Type != nil {
+			descriptions = append(descriptions, credentialDescription(c))
+		}
+	}
+
+	return descriptions, nil
+}
+
+// RemoveUserCredentials removes the credentials of the given types from the user,
+// e.g. otp or webauthn. It returns the descriptions of the removed credentials.
+func (a GoCloakAdapter) RemoveUserCredentials(ctx context.Context, realmName, username string,
+	credentialTypes []string) ([]string, error) {
+	usr, err := a.findUser(ctx, realmName, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.removeUserCredentials(ctx, realmName, *usr.ID, credentialTypes)
+}
+
+// ResetUserCredentials removes the credentials of the given types from the user and requires the user
+// to set them up again on the next login. It returns the descriptions of the removed credentials.
+func (a GoCloakAdapter) ResetUserCredentials(ctx context.Context, realmName, username string,
+	credentialTypes []string) ([]string, error) {
+	usr, err := a.findUser(ctx, realmName, username)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := a.removeUserCredentials(ctx, realmName, *usr.ID, credentialTypes)
+	if err != nil {
+		return removed, err
+	}
+
+	actions := make([]string, 0, len(credentialTypes))
+
+	for _, t := range credentialTypes {
+		if action, ok := credentialRequiredActions[t]; ok {
+			actions = append(actions, action)
+		}
+	}
+
+	if len(actions) == 0 {
+		return removed, nil
+	}
+
+	usr.RequiredActions = mergeRequiredActions(usr.RequiredActions, actions)
+
+	if err := a.client.UpdateUser(ctx, a.token.AccessToken, realmName, *usr); err != nil {
+		return removed, errors.Wrap(err, "unable to update user required actions")
+	}
+
+	return removed, nil
+}
+
+func (a GoCloakAdapter) findUser(ctx context.Context, realmName, username string) (*gocloak.User, error) {
+	users, err := a.client.GetUsers(ctx, a.token.AccessToken, realmName, gocloak.GetUsersParams{
+		Username: &username,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get users")
+	}
+
+	usr, exists := checkFullUsernameMatch(username, users)
+	if !exists {
+		return nil, NotFoundError("user not found")
+	}
+
+	return usr, nil
+}
+
+func (a GoCloakAdapter) removeUserCredentials(ctx context.Context, realmName, userID string,
+	credentialTypes []string) ([]string, error) {
+	credentials, err := a.client.GetCredentials(ctx, a.token.AccessToken, realmName, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get user credentials")
+	}
+
+	types := make(map[string]struct{}, len(credentialTypes))
+	for _, t := range credentialTypes {
+		types[t] = struct{}{}
+	}
+
+	removed := make([]string, 0)
+
+	for _, c := range credentials {
+		if c.Type == nil || c.ID == nil {
+			continue
+		}
+
+		if _, ok := types[*c.Type]; !ok {
+			continue
 		}
+
+		if err := a.client.DeleteCredentials(ctx, a.token.AccessToken, realmName, userID, *c.ID); err != nil {
+			return removed, errors.Wrapf(err, "unable to delete %s credential", *c.Type)
+		}
+
+		removed = append(removed, credentialDescription(c))
+	}
+
+	return removed, nil
+}
+
+func credentialDescription(c *gocloak.CredentialRepresentation) string {
+	if c.UserLabel != nil && *c.UserLabel != "" {
+		return fmt.Sprintf("%s (%s)", *c.Type, *c.UserLabel)
+	}
+
+	return *c.Type
+}
+
+// syncUserGroups adds the user to the groups from the spec which the user is not a member of yet.
+// If PruneGroups is set, the user is removed from the groups which are not declared in the spec.
+// Groups are referred by the name of the top-level group or by the full path starting with a slash.
+func (a GoCloakAdapter) syncUserGroups(ctx context.Context, realmName string, userID string, user *KeycloakUser) error {
+	currentGroups, err := a.GetUserGroupMappings(ctx, realmName, userID)
+	if err != nil {
+		return errors.Wrap(err, "unable to get user groups")
 	}
 
-	groups, err := a.client.GetGroups(ctx, a.token.AccessToken, realmName, gocloak.GetGroupsParams{})
+	groups, err := a.getGroups(ctx, realmName, gocloak.GetGroupsParams{})
 	if err != nil {
 		return errors.Wrap(err, "unable to get realm groups")
 	}
@@ -92,10 +266,23 @@ func (a GoCloakAdapter) syncUserGroups(ctx context.Context, realmName string, us
 		groupDict[*gr.Name] = *gr.ID
 	}
 
+	currentGroupIDs := make(map[string]struct{}, len(currentGroups))
+	for _, gr := range currentGroups {
+		currentGroupIDs[gr.ID] = struct{}{}
+	}
+
+	declaredGroupIDs := make(map[string]struct{}, len(user.Groups))
+
 	for _, gr := range user.Groups {
-		groupID, ok := groupDict[gr]
-		if !ok {
-			return errors.Errorf("group %s not found", gr)
+		groupID, err := a.resolveUserGroupID(realmName, gr, groupDict)
+		if err != nil {
+			return err
+		}
+
+		declaredGroupIDs[groupID] = struct{}{}
+
+		if _, ok := currentGroupIDs[groupID]; ok {
+			continue
 		}
 
 		if err := a.AddUserToGroup(ctx, realmName, userID, groupID); err != nil {
@@ -103,115 +290,118 @@ func (a GoCloakAdapter) syncUserGroups(ctx context.Context, realmName string, us
 		}
 	}
 
-	return nil
-}
+	if !user.PruneGroups {
+		return nil
+	}
 
-func (a GoCloakAdapter) syncUserRoles(ctx context.Context, realmName string, userID string, user *KeycloakUser, addOnly bool) error {
-	if !addOnly {
-		if err := a.clearUserRealmRoles(ctx, realmName, userID); err != nil {
-			return errors.Wrap(err, "unable to clear realm roles")
+	for _, gr := range currentGroups {
+		if _, ok := declaredGroupIDs[gr.ID]; ok {
+			continue
 		}
-	}
 
-	for _, roleName := range user.Roles {
-		if err := a.AddRealmRoleToUser(ctx, realmName, user.Username, roleName); err != nil {
-			return errors.Wrap(err, "unable to add realm role to user")
+		if err := a.RemoveUserFromGroup(ctx, realmName, userID, gr.ID); err != nil {
+			return errors.Wrap(err, "unable to remove user from group")
 		}
 	}
 
 	return nil
 }
 
-func (a GoCloakAdapter) GetUserRealmRoleMappings(ctx context.Context, realmName string, userID string) ([]UserRealmRoleMapping, error) {
-	var roles []UserRealmRoleMapping
+func (a GoCloakAdapter) resolveUserGroupID(realmName, group string, topLevelGroups map[string]string) (string, error) {
+	if !strings.Contains(group, "/") {
+		groupID, ok := topLevelGroups[group]
+		if !ok {
+			return "", NotFoundError(fmt.Sprintf("group %s not found", group))
+		}
 
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		keycloakApiParamId:    userID,
-	}).SetResult(&roles).Get(a.basePath + getUserRealmRoleMappings)
+		return groupID, nil
+	}
 
-	if err = a.checkError(err, rsp); err != nil {
-		return nil, errors.Wrap(err, "unable to get realm role mappings")
+	gr, err := a.GetGroupByPath(realmName, group)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to get group %s", group)
 	}
 
-	return roles, nil
+	return *gr.ID, nil
 }
 
-func (a GoCloakAdapter) GetUserGroupMappings(ctx context.Context, realmName string, userID string) ([]UserGroupMapping, error) {
-	var groups []UserGroupMapping
-
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		keycloakApiParamId:    userID,
-	}).SetResult(&groups).Get(a.basePath + getUserGroupMappings)
+// syncUserRoles syncs realm and client roles of the user.
+// Roles which are not declared are removed unless addOnly is set, the realm default role is kept.
+func (a GoCloakAdapter) syncUserRoles(ctx context.Context, realmName string, userID string, user *KeycloakUser, addOnly bool) error {
+	roleMappings, err := a.client.GetRoleMappingByUserID(ctx, a.token.AccessToken, realmName, userID)
+	if err != nil {
+		return errors.Wrap(err, "unable to get user role mappings")
+	}
 
-	if err = a.checkError(err, rsp); err != nil {
-		return nil, errors.Wrap(err, "unable to get group mappings")
+	deleteRealmRoleFunc := a.client.DeleteRealmRoleFromUser
+	if addOnly {
+		deleteRealmRoleFunc = doNotDeleteRealmRoleFromUser
 	}
 
-	return groups, nil
-}
+	if err := a.syncEntityRealmRoles(userID, realmName, user.Roles, withoutDefaultRealmRole(realmName, roleMappings.RealmMappings),
+		a.client.AddRealmRoleToUser, deleteRealmRoleFunc); err != nil {
+		return errors.Wrap(err, "unable to sync user realm roles")
+	}
 
-func (a GoCloakAdapter) RemoveUserFromGroup(ctx context.Context, realmName, userID, groupID string) error {
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		"userID":              userID,
-		"groupID":             groupID,
-	}).Delete(a.basePath + manageUserGroups)
+	deleteClientRoleFunc := a.client.DeleteClientRoleFromUser
+	if addOnly {
+		deleteClientRoleFunc = doNotDeleteClientRoleFromUser
+	}
 
-	if err = a.checkError(err, rsp); err != nil {
-		return errors.Wrap(err, "unable to remove user from group")
+	if err := a.syncEntityClientRoles(realmName, userID, user.ClientRoles, roleMappings.ClientMappings,
+		a.client.AddClientRoleToUser, deleteClientRoleFunc); err != nil {
+		return errors.Wrap(err, "unable to sync user client roles")
 	}
 
 	return nil
 }
 
-func (a GoCloakAdapter) AddUserToGroup(ctx context.Context, realmName, userID, groupID string) error {
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		"userID":              userID,
-		"groupID":             groupID,
-	}).SetBody(map[string]string{
-		"groupId":             groupID,
-		keycloakApiParamRealm: realmName,
-		"userId":              userID,
-	}).Put(a.basePath + manageUserGroups)
-
-	if err = a.checkError(err, rsp); err != nil {
-		return errors.Wrap(err, "unable to add user to group")
+func (a GoCloakAdapter) GetUserRealmRoleMappings(ctx context.Context, realmName string, userID string) ([]UserRealmRoleMapping, error) {
+	roles, err := a.client.GetRealmRolesByUserID(ctx, a.token.AccessToken, realmName, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get realm role mappings")
 	}
 
-	return nil
+	mappings := make([]UserRealmRoleMapping, 0, len(roles))
+	for _, r := range roles {
+		mappings = append(mappings, UserRealmRoleMapping{ID: gocloak.PString(r.ID), Name: gocloak.PString(r.Name)})
+	}
+
+	return mappings, nil
 }
 
-func (a GoCloakAdapter) clearUserGroups(ctx context.Context, realmName, userID string) error {
-	groups, err := a.GetUserGroupMappings(ctx, realmName, userID)
+func (a GoCloakAdapter) GetUserGroupMappings(ctx context.Context, realmName string, userID string) ([]UserGroupMapping, error) {
+	groups, err := listPages(a.listPageSize(), func(first, size int) ([]*gocloak.Group, error) {
+		return a.client.GetUserGroups(ctx, a.token.AccessToken, realmName, userID,
+			gocloak.GetGroupsParams{First: gocloak.IntP(first), Max: gocloak.IntP(size)})
+	})
 	if err != nil {
-		return errors.Wrap(err, "unable to get user groups")
+		return nil, errors.Wrap(err, "unable to get group mappings")
 	}
 
-	for _, gr := range groups {
-		if err := a.RemoveUserFromGroup(ctx, realmName, userID, gr.ID); err != nil {
-			return errors.Wrap(err, "unable to remove user from group")
-		}
+	mappings := make([]UserGroupMapping, 0, len(groups))
+	for _, g := range groups {
+		mappings = append(mappings, UserGroupMapping{
+			ID:   gocloak.PString(g.ID),
+			Name: gocloak.PString(g.Name),
+			Path: gocloak.PString(g.Path),
+		})
 	}
 
-	return nil
+	return mappings, nil
 }
 
-func (a GoCloakAdapter) clearUserRealmRoles(ctx context.Context, realmName string, userID string) error {
-	roles, err := a.GetUserRealmRoleMappings(ctx, realmName, userID)
-	if err != nil {
-		return errors.Wrap(err, "unable to get user realm role map")
+func (a GoCloakAdapter) RemoveUserFromGroup(ctx context.Context, realmName, userID, groupID string) error {
+	if err := a.client.DeleteUserFromGroup(ctx, a.token.AccessToken, realmName, userID, groupID); err != nil {
+		return errors.Wrap(err, "unable to remove user from group")
 	}
 
-	goRoles := make([]gocloak.Role, 0, len(roles))
-	for i := range roles {
-		goRoles = append(goRoles, gocloak.Role{ID: &roles[i].ID, Name: &roles[i].Name})
-	}
+	return nil
+}
 
-	if err := a.client.DeleteRealmRoleFromUser(ctx, a.token.AccessToken, realmName, userID, goRoles); err != nil {
-		return errors.Wrap(err, "unable to delete realm role from user")
+func (a GoCloakAdapter) AddUserToGroup(ctx context.Context, realmName, userID, groupID string) error {
+	if err := a.client.AddUserToGroup(ctx, a.token.AccessToken, realmName, userID, groupID); err != nil {
+		return errors.Wrap(err, "unable to add user to group")
 	}
 
 	return nil
@@ -224,15 +414,27 @@ func (a GoCloakAdapter) setUserParams(
 	userCR *KeycloakUser,
 	addOnly bool,
 ) error {
-	if userCR.Attributes != nil && len(userCR.Attributes) > 0 {
+	// Keycloak keeps the current attributes if the attributes are null,
+	// so the attributes are always sent to remove the ones which are dropped from the spec.
+	if len(userCR.Attributes) > 0 || !addOnly {
 		keycloakUser.Attributes = a.makeUserAttributes(keycloakUser, userCR, addOnly)
 	}
 
 	if keycloakUser.ID != nil {
+		if len(userCR.AddedRequiredUserActions) > 0 {
+			keycloakUser.RequiredActions = mergeRequiredActions(keycloakUser.RequiredActions, userCR.AddedRequiredUserActions)
+		}
+
 		if err := a.client.UpdateUser(ctx, a.token.AccessToken, realmName, *keycloakUser); err != nil {
 			return errors.Wrap(err, "unable to update user")
 		}
 
+		if userCR.ResetPassword && userCR.Password != "" {
+			if err := a.setUserPassword(ctx, realmName, *keycloakUser.ID, userCR.Password, userCR.PasswordTemporary); err != nil {
+				return errors.Wrapf(err, "unable to set user password, user id: %s", *keycloakUser.ID)
+			}
+		}
+
 		return nil
 	}
 
@@ -242,7 +444,7 @@ func (a GoCloakAdapter) setUserParams(
 	}
 
 	if userCR.Password != "" {
-		if err := a.setUserPassword(realmName, userID, userCR.Password); err != nil {
+		if err := a.setUserPassword(ctx, realmName, userID, userCR.Password, userCR.PasswordTemporary); err != nil {
 			return errors.Wrapf(err, "unable to set user password, user id: %s", userID)
 		}
 	}
@@ -252,23 +454,37 @@ func (a GoCloakAdapter) setUserParams(
 	return nil
 }
 
-func (a GoCloakAdapter) setUserPassword(realmName, userID, password string) error {
-	rsp, err := a.startRestyRequest().SetPathParams(map[string]string{
-		keycloakApiParamRealm: realmName,
-		keycloakApiParamId:    userID,
-	}).SetBody(map[string]interface{}{
-		"temporary": true,
-		"type":      "password",
-		"value":     password,
-	}).Put(a.basePath + setRealmUserPassword)
-
-	if err = a.checkError(err, rsp); err != nil {
+func (a GoCloakAdapter) setUserPassword(ctx context.Context, realmName, userID, password string, temporary bool) error {
+	if err := a.client.SetPassword(ctx, a.token.AccessToken, userID, realmName, password, temporary); err != nil {
 		return errors.Wrap(err, "unable to set user password")
 	}
 
 	return nil
 }
 
+// mergeRequiredActions adds the declared required actions to the current actions of the user.
+// Actions are not removed, keycloak removes them once the user completes them.
+func mergeRequiredActions(current *[]string, declared []string) *[]string {
+	actions := make([]string, 0, len(declared))
+	exists := make(map[string]struct{}, len(declared))
+
+	if current != nil {
+		for _, a := range *current {
+			actions = append(actions, a)
+			exists[a] = struct{}{}
+		}
+	}
+
+	for _, a := range declared {
+		if _, ok := exists[a]; !ok {
+			actions = append(actions, a)
+			exists[a] = struct{}{}
+		}
+	}
+
+	return &actions
+}
+
 func (a GoCloakAdapter) makeUserAttributes(keycloakUser *gocloak.User, userCR *KeycloakUser, addOnly bool) *map[string][]string {
 	attrs := make(map[string][]string)
 	for k, v := range userCR.Attributes {
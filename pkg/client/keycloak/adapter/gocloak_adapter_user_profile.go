@@ -0,0 +1,110 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// UserProfileMinKCVersion is the first major version of keycloak which always uses the declarative user profile.
+const UserProfileMinKCVersion = 24
+
+// UnmanagedAttributePolicyDisabled is the policy of the user profile which drops
+// the attributes that are not declared in the profile. It is used if the policy is not set.
+const UnmanagedAttributePolicyDisabled = ""
+
+// UnmanagedAttributePolicyAdminView is the policy of the user profile which shows the attributes
+// that are not declared in the profile to the admins, but does not allow to change them.
+const UnmanagedAttributePolicyAdminView = "ADMIN_VIEW"
+
+// UserProfileConfig is the declarative user profile of the realm, available since keycloak 24.
+type UserProfileConfig struct {
+	Attributes               []UserProfileAttribute `json:"attributes,omitempty"`
+	Groups                   []UserProfileGroup     `json:"groups,omitempty"`
+	UnmanagedAttributePolicy string                 `json:"unmanagedAttributePolicy,omitempty"`
+}
+
+type UserProfileAttribute struct {
+	Name        string                            `json:"name"`
+	DisplayName string                            `json:"displayName,omitempty"`
+	Group       string                            `json:"group,omitempty"`
+	Multivalued bool                              `json:"multivalued,omitempty"`
+	Validations map[string]map[string]interface{} `json:"validations,omitempty"`
+	Annotations map[string]interface{}            `json:"annotations,omitempty"`
+	Required    *UserProfileAttributeRequired     `json:"required,omitempty"`
+	Permissions *UserProfileAttributePermissions  `json:"permissions,omitempty"`
+	Selector    *UserProfileAttributeSelector     `json:"selector,omitempty"`
+}
+
+type UserProfileAttributeRequired struct {
+	Roles  []string `json:"roles,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type UserProfileAttributePermissions struct {
+	View []string `json:"view,omitempty"`
+	Edit []string `json:"edit,omitempty"`
+}
+
+type UserProfileAttributeSelector struct {
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type UserProfileGroup struct {
+	Name               string                 `json:"name"`
+	DisplayHeader      string                 `json:"displayHeader,omitempty"`
+	DisplayDescription string                 `json:"displayDescription,omitempty"`
+	Annotations        map[string]interface{} `json:"annotations,omitempty"`
+}
+
+// GetUserProfileConfig returns the user profile config of the realm.
+func (a GoCloakAdapter) GetUserProfileConfig(ctx context.Context, realmName string) (*UserProfileConfig, error) {
+	var cfg UserProfileConfig
+
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetResult(&cfg).Get(a.basePath + realmUserProfile)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return nil, errors.Wrap(err, "unable to get user profile config")
+	}
+
+	return &cfg, nil
+}
+
+// UpdateUserProfileConfig replaces the user profile config of the realm.
+func (a GoCloakAdapter) UpdateUserProfileConfig(ctx context.Context, realmName string, cfg *UserProfileConfig) error {
+	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
+		keycloakApiParamRealm: realmName,
+	}).SetBody(cfg).Put(a.basePath + realmUserProfile)
+
+	if err = a.checkError(err, rsp); err != nil {
+		return errors.Wrap(err, "unable to update user profile config")
+	}
+
+	return nil
+}
+
+// UndeclaredAttributes returns the attributes which are not declared in the user profile
+// and can not be set because unmanaged attributes are disabled or read-only for the admins.
+func (c *UserProfileConfig) UndeclaredAttributes(attributes []string) []string {
+	if c.UnmanagedAttributePolicy != UnmanagedAttributePolicyDisabled &&
+		c.UnmanagedAttributePolicy != UnmanagedAttributePolicyAdminView {
+		return nil
+	}
+
+	declared := make(map[string]struct{}, len(c.Attributes))
+	for _, a := range c.Attributes {
+		declared[a.Name] = struct{}{}
+	}
+
+	var undeclared []string
+
+	for _, a := range attributes {
+		if _, ok := declared[a]; !ok {
+			undeclared = append(undeclared, a)
+		}
+	}
+
+	return undeclared
+}
@@ -0,0 +1,52 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoCloakAdapter_GetUserProfileConfig(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodGet, "/admin/realms/realm1/users/profile",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"attributes": []map[string]interface{}{
+				{"name": "username"},
+				{"name": "email"},
+				{"name": "department"},
+			},
+		}))
+
+	cfg, err := kcAdapter.GetUserProfileConfig(context.Background(), "realm1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team"}, cfg.UndeclaredAttributes([]string{"department", "team"}))
+
+	cfg.UnmanagedAttributePolicy = "ADMIN_VIEW"
+	assert.Equal(t, []string{"team"}, cfg.UndeclaredAttributes([]string{"department", "team"}))
+
+	cfg.UnmanagedAttributePolicy = "ENABLED"
+	assert.Empty(t, cfg.UndeclaredAttributes([]string{"department", "team"}))
+}
+
+func TestGoCloakAdapter_UpdateUserProfileConfig(t *testing.T) {
+	kcAdapter, _, _ := initAdapter()
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm1/users/profile",
+		httpmock.NewStringResponder(http.StatusOK, "{}"))
+
+	require.NoError(t, kcAdapter.UpdateUserProfileConfig(context.Background(), "realm1", &UserProfileConfig{
+		Attributes: []UserProfileAttribute{{Name: "username"}, {Name: "email"}},
+	}))
+
+	httpmock.RegisterResponder(http.MethodPut, "/admin/realms/realm2/users/profile",
+		httpmock.NewStringResponder(http.StatusBadRequest, "invalid"))
+
+	err := kcAdapter.UpdateUserProfileConfig(context.Background(), "realm2", &UserProfileConfig{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to update user profile config")
+}
@@ -2,11 +2,10 @@ package adapter
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/Nerzal/gocloak/v12"
-	"github.com/go-resty/resty/v2"
-	"github.com/jarcoal/httpmock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -21,15 +20,6 @@ func TestGoCloakAdapter_SyncRealmUser(t *testing.T) {
 		token:    &gocloak.JWT{AccessToken: "token"},
 	}
 
-	restyClient := resty.New()
-
-	httpmock.Reset()
-	httpmock.ActivateNonDefault(restyClient.GetClient())
-	mockClient.On("RestyClient").Return(restyClient)
-
-	httpmock.RegisterResponder("PUT", "/admin/realms/realm1/users/user-id1/reset-password",
-		httpmock.NewStringResponder(200, ""))
-
 	usr := KeycloakUser{
 		Username: "vasia",
 		Attributes: map[string]string{
@@ -37,6 +27,7 @@ func TestGoCloakAdapter_SyncRealmUser(t *testing.T) {
 		},
 		RequiredUserActions: []string{"FOO"},
 		Groups:              []string{"group1"},
+		PruneGroups:         true,
 		Password:            "123",
 	}
 
@@ -45,23 +36,23 @@ func TestGoCloakAdapter_SyncRealmUser(t *testing.T) {
 	mockClient.On("GetUsers", realmName, gocloak.GetUsersParams{Username: gocloak.StringP(usr.Username)}).
 		Return([]*gocloak.User{}, nil)
 
-	httpmock.RegisterResponder("GET", "/admin/realms/realm1/users/user-id1/role-mappings/realm",
-		httpmock.NewJsonResponderOrPanic(200, []UserRealmRoleMapping{
-			{
-				ID:   "role-id-1",
-				Name: "role-name-1",
-			},
-		}))
-	mockClient.On("DeleteRealmRoleFromUser", realmName, "user-id1", mock.Anything).Return(nil)
-	httpmock.RegisterResponder("GET", "/admin/realms/realm1/users/user-id1/groups",
-		httpmock.NewJsonResponderOrPanic(200, []UserGroupMapping{
-			{
-				ID:   "group-id-1",
-				Name: "group-name-1",
-			},
-		}))
-	httpmock.RegisterResponder("DELETE", "/admin/realms/realm1/users/user-id1/groups/group-id-1",
-		httpmock.NewStringResponder(200, ""))
+	mockClient.On("GetRoleMappingByUserID", realmName, "user-id1").Return(&gocloak.MappingsRepresentation{
+		RealmMappings: &[]gocloak.Role{
+			{ID: gocloak.StringP("role-id-1"), Name: gocloak.StringP("role-name-1")},
+			{ID: gocloak.StringP("default-id"), Name: gocloak.StringP("default-roles-realm1")},
+		},
+	}, nil)
+	mockClient.On("DeleteRealmRoleFromUser", realmName, "user-id1", []gocloak.Role{
+		{ID: gocloak.StringP("role-id-1"), Name: gocloak.StringP("role-name-1")},
+	}).Return(nil)
+	mockClient.On("GetUserGroups", realmName, "user-id1", gocloak.GetGroupsParams{}).Return([]*gocloak.Group{
+		{
+			ID:   gocloak.StringP("group-id-1"),
+			Name: gocloak.StringP("group-name-1"),
+		},
+	}, nil)
+	mockClient.On("DeleteUserFromGroup", realmName, "user-id1", "group-id-1").Return(nil)
+	mockClient.On("SetPassword", "user-id1", realmName, "123", false).Return(nil)
 
 	goClUser := gocloak.User{
 		Username:        &usr.Username,
@@ -84,8 +75,7 @@ func TestGoCloakAdapter_SyncRealmUser(t *testing.T) {
 			ID:   gocloak.StringP("group-id-2"),
 		},
 	}, nil)
-	httpmock.RegisterResponder("PUT", "/admin/realms/realm1/users/user-id1/groups/group-id-2",
-		httpmock.NewStringResponder(200, ""))
+	mockClient.On("AddUserToGroup", realmName, "user-id1", "group-id-2").Return(nil)
 
 	err := adapter.SyncRealmUser(context.Background(), realmName, &usr, false)
 	require.NoError(t, err)
@@ -130,23 +120,20 @@ func TestGoCloakAdapter_SyncRealmUser_UserExists(t *testing.T) {
 		Groups:     &[]string{"g1", "g2"},
 	}).Return(nil)
 
+	mockClient.On("GetRoleMappingByUserID", realmName, "id1").Return(&gocloak.MappingsRepresentation{
+		RealmMappings: &[]gocloak.Role{{Name: gocloak.StringP("r1")}},
+	}, nil)
 	mockClient.On("GetRealmRole", realmName, "r3").Return(&gocloak.Role{}, nil)
 	mockClient.On("GetRealmRole", realmName, "r4").Return(&gocloak.Role{}, nil)
-	mockClient.On("AddRealmRoleToUser", realmName, "id1", []gocloak.Role{{}}).Return(nil)
+	mockClient.On("AddRealmRoleToUser", realmName, "id1", []gocloak.Role{{}, {}}).Return(nil)
 	mockClient.On("GetGroups", realmName, mock.Anything).Return([]*gocloak.Group{
 		{
 			ID:   gocloak.StringP("foo1"),
 			Name: gocloak.StringP("foo"),
 		},
 	}, nil)
-
-	restyClient := resty.New()
-
-	httpmock.Reset()
-	httpmock.ActivateNonDefault(restyClient.GetClient())
-	mockClient.On("RestyClient").Return(restyClient)
-	httpmock.RegisterResponder("PUT", "/admin/realms/realm1/users/id1/groups/foo1",
-		httpmock.NewStringResponder(200, ""))
+	mockClient.On("GetUserGroups", realmName, "id1", gocloak.GetGroupsParams{}).Return([]*gocloak.Group{}, nil)
+	mockClient.On("AddUserToGroup", realmName, "id1", "foo1").Return(nil)
 
 	err := adapter.SyncRealmUser(context.Background(), realmName, &usr, true)
 	require.NoError(t, err)
@@ -191,15 +178,318 @@ func TestGoCloakAdapter_SyncRealmUser_UserExists_Failure(t *testing.T) {
 		Groups:     &[]string{"g1", "g2"},
 	}).Return(nil)
 
+	mockClient.On("GetRoleMappingByUserID", realmName, "id1").Return(&gocloak.MappingsRepresentation{}, nil)
 	mockClient.On("GetRealmRole", realmName, "r3").Return(&gocloak.Role{}, nil)
 	mockClient.On("GetRealmRole", realmName, "r4").Return(&gocloak.Role{}, nil)
-	mockClient.On("AddRealmRoleToUser", realmName, "id1", []gocloak.Role{{}}).
+	mockClient.On("AddRealmRoleToUser", realmName, "id1", []gocloak.Role{{}, {}}).
 		Return(errors.New("add realm role fatal"))
 
 	err := adapter.SyncRealmUser(context.Background(), realmName, &usr, true)
 	require.Error(t, err)
 
-	if err.Error() != "unable to sync user roles: unable to add realm role to user: unable to add realm role to user: add realm role fatal" {
-		t.Fatalf("wrong error returned: %s", err.Error())
+	require.Contains(t, err.Error(), "unable to sync user roles: unable to sync user realm roles")
+	require.Contains(t, err.Error(), "add realm role fatal")
+}
+
+func TestGoCloakAdapter_SyncRealmUser_Groups(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+
+	adapter := GoCloakAdapter{
+		client:   mockClient,
+		basePath: "",
+		token:    &gocloak.JWT{AccessToken: "token"},
+	}
+
+	usr := KeycloakUser{
+		Username: "vasia",
+		Groups:   []string{"top", "/parent/child"},
+	}
+
+	realmName := "realm1"
+
+	mockClient.On("GetUsers", realmName, gocloak.GetUsersParams{Username: gocloak.StringP(usr.Username)}).
+		Return([]*gocloak.User{{Username: &usr.Username, ID: gocloak.StringP("id1")}}, nil)
+	mockClient.On("UpdateUser", realmName, mock.Anything).Return(nil)
+	mockClient.On("GetRoleMappingByUserID", realmName, "id1").Return(&gocloak.MappingsRepresentation{}, nil)
+
+	mockClient.On("GetUserGroups", realmName, "id1", gocloak.GetGroupsParams{}).Return([]*gocloak.Group{
+		{ID: gocloak.StringP("top-id"), Name: gocloak.StringP("top"), Path: gocloak.StringP("/top")},
+		{ID: gocloak.StringP("stale-id"), Name: gocloak.StringP("stale"), Path: gocloak.StringP("/stale")},
+	}, nil)
+	mockClient.On("GetGroups", realmName, gocloak.GetGroupsParams{}).Return([]*gocloak.Group{
+		{Name: gocloak.StringP("top"), ID: gocloak.StringP("top-id")},
+	}, nil)
+	mockClient.On("GetGroups", realmName, gocloak.GetGroupsParams{Search: gocloak.StringP("child")}).
+		Return([]*gocloak.Group{
+			{
+				Name: gocloak.StringP("parent"),
+				ID:   gocloak.StringP("parent-id"),
+				SubGroups: &[]gocloak.Group{
+					{Name: gocloak.StringP("child"), ID: gocloak.StringP("child-id")},
+				},
+			},
+		}, nil)
+	mockClient.On("AddUserToGroup", realmName, "id1", "child-id").Return(nil)
+
+	err := adapter.SyncRealmUser(context.Background(), realmName, &usr, false)
+	require.NoError(t, err)
+
+	mockClient.AssertNumberOfCalls(t, "AddUserToGroup", 1)
+	mockClient.AssertNotCalled(t, "AddUserToGroup", realmName, "id1", "top-id")
+	mockClient.AssertNotCalled(t, "DeleteUserFromGroup", realmName, "id1", "stale-id")
+
+	usr.PruneGroups = true
+
+	mockClient.On("DeleteUserFromGroup", realmName, "id1", "stale-id").Return(nil).Once()
+
+	err = adapter.SyncRealmUser(context.Background(), realmName, &usr, false)
+	require.NoError(t, err)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_SyncRealmUser_ClientRoles(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+
+	adapter := GoCloakAdapter{
+		client:   mockClient,
+		basePath: "",
+		token:    &gocloak.JWT{AccessToken: "token"},
+	}
+
+	usr := KeycloakUser{
+		Username:    "vasia",
+		ClientRoles: map[string][]string{"app": {"viewer"}},
+	}
+
+	realmName := "realm1"
+	viewer := gocloak.Role{ID: gocloak.StringP("viewer-id"), Name: gocloak.StringP("viewer")}
+	stale := gocloak.Role{ID: gocloak.StringP("stale-id"), Name: gocloak.StringP("stale")}
+	other := gocloak.Role{ID: gocloak.StringP("other-id"), Name: gocloak.StringP("other")}
+
+	mockClient.On("GetUsers", realmName, gocloak.GetUsersParams{Username: gocloak.StringP(usr.Username)}).
+		Return([]*gocloak.User{{Username: &usr.Username, ID: gocloak.StringP("id1")}}, nil)
+	mockClient.On("UpdateUser", realmName, mock.Anything).Return(nil)
+	mockClient.On("GetRoleMappingByUserID", realmName, "id1").Return(&gocloak.MappingsRepresentation{
+		ClientMappings: map[string]*gocloak.ClientMappingsRepresentation{
+			"app":   {ID: gocloak.StringP("app-uuid"), Client: gocloak.StringP("app"), Mappings: &[]gocloak.Role{stale}},
+			"other": {ID: gocloak.StringP("other-uuid"), Client: gocloak.StringP("other"), Mappings: &[]gocloak.Role{other}},
+		},
+	}, nil)
+	mockClient.On("GetClients", realmName, gocloak.GetClientsParams{ClientID: gocloak.StringP("app")}).
+		Return([]*gocloak.Client{{ClientID: gocloak.StringP("app"), ID: gocloak.StringP("app-uuid")}}, nil)
+	mockClient.On("GetClientRole", realmName, "app-uuid", "viewer").Return(&viewer, nil)
+	mockClient.On("AddClientRoleToUser", realmName, "app-uuid", "id1", []gocloak.Role{viewer}).Return(nil)
+	mockClient.On("DeleteClientRoleFromUser", realmName, "app-uuid", "id1", []gocloak.Role{stale}).Return(nil)
+	mockClient.On("DeleteClientRoleFromUser", realmName, "other-uuid", "id1", []gocloak.Role{other}).Return(nil)
+	mockClient.On("GetGroups", realmName, gocloak.GetGroupsParams{}).Return([]*gocloak.Group{}, nil)
+	mockClient.On("GetUserGroups", realmName, "id1", gocloak.GetGroupsParams{}).Return([]*gocloak.Group{}, nil)
+
+	err := adapter.SyncRealmUser(context.Background(), realmName, &usr, false)
+	require.NoError(t, err)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_SyncRealmUser_RequiredActions(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+
+	adapter := GoCloakAdapter{
+		client:   mockClient,
+		basePath: "",
+		token:    &gocloak.JWT{AccessToken: "token"},
+	}
+
+	usr := KeycloakUser{
+		Username:                 "vasia",
+		RequiredUserActions:      []string{"VERIFY_EMAIL", "CONFIGURE_TOTP", "UPDATE_PROFILE"},
+		AddedRequiredUserActions: []string{"VERIFY_EMAIL", "CONFIGURE_TOTP"},
 	}
+
+	realmName := "realm1"
+
+	mockClient.On("GetUsers", realmName, gocloak.GetUsersParams{Username: gocloak.StringP(usr.Username)}).
+		Return([]*gocloak.User{{
+			Username:        &usr.Username,
+			ID:              gocloak.StringP("id1"),
+			RequiredActions: &[]string{"UPDATE_PASSWORD", "VERIFY_EMAIL"},
+			Attributes:      &map[string][]string{"dropped": {"value"}},
+		}}, nil)
+	mockClient.On("UpdateUser", realmName, gocloak.User{
+		Username:        &usr.Username,
+		ID:              gocloak.StringP("id1"),
+		RequiredActions: &[]string{"UPDATE_PASSWORD", "VERIFY_EMAIL", "CONFIGURE_TOTP"},
+		Attributes:      &map[string][]string{},
+	}).Return(nil)
+	mockClient.On("GetRoleMappingByUserID", realmName, "id1").Return(&gocloak.MappingsRepresentation{}, nil)
+	mockClient.On("GetGroups", realmName, gocloak.GetGroupsParams{}).Return([]*gocloak.Group{}, nil)
+	mockClient.On("GetUserGroups", realmName, "id1", gocloak.GetGroupsParams{}).Return([]*gocloak.Group{}, nil)
+
+	err := adapter.SyncRealmUser(context.Background(), realmName, &usr, false)
+	require.NoError(t, err)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestGoCloakAdapter_setUserPassword(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	adapter := GoCloakAdapter{client: mockClient, token: &gocloak.JWT{AccessToken: "token"}}
+
+	mockClient.On("SetPassword", "id1", "realm1", "pass", false).Return(nil).Once()
+	require.NoError(t, adapter.setUserPassword(context.Background(), "realm1", "id1", "pass", false))
+
+	mockClient.On("SetPassword", "id1", "realm1", "pass", true).
+		Return(&gocloak.APIError{Code: http.StatusBadRequest, Message: "400 Bad Request"}).Once()
+	require.EqualError(t, adapter.setUserPassword(context.Background(), "realm1", "id1", "pass", true),
+		"unable to set user password: 400 Bad Request")
+}
+
+func TestGoCloakAdapter_SyncRealmUser_ResetPassword(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	adapter := GoCloakAdapter{client: mockClient, token: &gocloak.JWT{AccessToken: "token"}}
+
+	usr := KeycloakUser{Username: "vasia", Password: "generated", ResetPassword: true, PasswordTemporary: true}
+
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP(usr.Username)}).
+		Return([]*gocloak.User{{Username: &usr.Username, ID: gocloak.StringP("id1")}}, nil)
+	mockClient.On("UpdateUser", "realm1", mock.Anything).Return(nil)
+	mockClient.On("GetRoleMappingByUserID", "realm1", "id1").Return(&gocloak.MappingsRepresentation{}, nil)
+	mockClient.On("GetGroups", "realm1", gocloak.GetGroupsParams{}).Return([]*gocloak.Group{}, nil)
+	mockClient.On("GetUserGroups", "realm1", "id1", gocloak.GetGroupsParams{}).Return([]*gocloak.Group{}, nil)
+	mockClient.On("SetPassword", "id1", "realm1", "generated", true).Return(nil)
+
+	require.NoError(t, adapter.SyncRealmUser(context.Background(), "realm1", &usr, false))
+	mockClient.AssertCalled(t, "SetPassword", "id1", "realm1", "generated", true)
+}
+
+func TestGoCloakAdapter_ExecuteActionsEmail(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	adapter := GoCloakAdapter{client: mockClient, token: &gocloak.JWT{AccessToken: "token"}}
+
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP("vasia")}).
+		Return([]*gocloak.User{{Username: gocloak.StringP("vasia"), ID: gocloak.StringP("id1")}}, nil)
+	mockClient.On("ExecuteActionsEmail", "realm1", gocloak.ExecuteActionsEmail{
+		UserID:  gocloak.StringP("id1"),
+		Actions: &[]string{"UPDATE_PASSWORD"},
+	}).Return(nil)
+
+	err := adapter.ExecuteActionsEmail(context.Background(), "realm1", "vasia", []string{"UPDATE_PASSWORD"})
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP("petia")}).
+		Return([]*gocloak.User{}, nil)
+
+	err = adapter.ExecuteActionsEmail(context.Background(), "realm1", "petia", []string{"UPDATE_PASSWORD"})
+	require.Error(t, err)
+	require.True(t, IsErrNotFound(err))
+}
+
+func TestGoCloakAdapter_RemoveUserCredentials(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	adapter := GoCloakAdapter{client: mockClient, token: &gocloak.JWT{AccessToken: "token"}}
+
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP("vasia")}).
+		Return([]*gocloak.User{{Username: gocloak.StringP("vasia"), ID: gocloak.StringP("id1")}}, nil)
+	mockClient.On("GetCredentials", "realm1", "id1").Return([]*gocloak.CredentialRepresentation{
+		{ID: gocloak.StringP("c1"), Type: gocloak.StringP("password")},
+		{ID: gocloak.StringP("c2"), Type: gocloak.StringP("otp"), UserLabel: gocloak.StringP("phone")},
+		{ID: gocloak.StringP("c3"), Type: gocloak.StringP("webauthn")},
+	}, nil)
+	mockClient.On("DeleteCredentials", "realm1", "id1", "c2").Return(nil)
+	mockClient.On("DeleteCredentials", "realm1", "id1", "c3").Return(nil)
+
+	removed, err := adapter.RemoveUserCredentials(context.Background(), "realm1", "vasia",
+		[]string{"otp", "webauthn"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"otp (phone)", "webauthn"}, removed)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "DeleteCredentials", "realm1", "id1", "c1")
+}
+
+func TestGoCloakAdapter_ListUserCredentials(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	adapter := GoCloakAdapter{client: mockClient, token: &gocloak.JWT{AccessToken: "token"}}
+
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP("vasia")}).
+		Return([]*gocloak.User{{Username: gocloak.StringP("vasia"), ID: gocloak.StringP("id1")}}, nil)
+	mockClient.On("GetCredentials", "realm1", "id1").Return([]*gocloak.CredentialRepresentation{
+		{ID: gocloak.StringP("c1"), Type: gocloak.StringP("password")},
+		{ID: gocloak.StringP("c2"), Type: gocloak.StringP("otp"), UserLabel: gocloak.StringP("phone")},
+	}, nil)
+
+	credentials, err := adapter.ListUserCredentials(context.Background(), "realm1", "vasia")
+	require.NoError(t, err)
+	require.Equal(t, []string{"password", "otp (phone)"}, credentials)
+
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP("petia")}).
+		Return([]*gocloak.User{}, nil)
+
+	_, err = adapter.ListUserCredentials(context.Background(), "realm1", "petia")
+	require.Error(t, err)
+	require.True(t, IsErrNotFound(err))
+}
+
+func TestGoCloakAdapter_ResetUserCredentials(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	adapter := GoCloakAdapter{client: mockClient, token: &gocloak.JWT{AccessToken: "token"}}
+
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP("vasia")}).
+		Return([]*gocloak.User{{
+			Username:        gocloak.StringP("vasia"),
+			ID:              gocloak.StringP("id1"),
+			RequiredActions: &[]string{"VERIFY_EMAIL"},
+		}}, nil)
+	mockClient.On("GetCredentials", "realm1", "id1").Return([]*gocloak.CredentialRepresentation{
+		{ID: gocloak.StringP("c1"), Type: gocloak.StringP("password")},
+		{ID: gocloak.StringP("c2"), Type: gocloak.StringP("otp")},
+	}, nil)
+	mockClient.On("DeleteCredentials", "realm1", "id1", "c2").Return(nil)
+	mockClient.On("UpdateUser", "realm1", gocloak.User{
+		Username:        gocloak.StringP("vasia"),
+		ID:              gocloak.StringP("id1"),
+		RequiredActions: &[]string{"VERIFY_EMAIL", "CONFIGURE_TOTP"},
+	}).Return(nil)
+
+	removed, err := adapter.ResetUserCredentials(context.Background(), "realm1", "vasia", []string{"otp"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"otp"}, removed)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "DeleteCredentials", "realm1", "id1", "c1")
+}
+
+func TestGoCloakAdapter_RemoveUserCredentials_Failure(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	adapter := GoCloakAdapter{client: mockClient, token: &gocloak.JWT{AccessToken: "token"}}
+
+	mockClient.On("GetUsers", "realm1", gocloak.GetUsersParams{Username: gocloak.StringP("vasia")}).
+		Return([]*gocloak.User{{Username: gocloak.StringP("vasia"), ID: gocloak.StringP("id1")}}, nil)
+	mockClient.On("GetCredentials", "realm1", "id1").Return(nil, errors.New("fatal"))
+
+	_, err := adapter.RemoveUserCredentials(context.Background(), "realm1", "vasia", []string{"otp"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to get user credentials")
+}
+
+func TestGoCloakAdapter_GetUserMappings(t *testing.T) {
+	mockClient := new(MockGoCloakClient)
+	adapter := GoCloakAdapter{client: mockClient, token: &gocloak.JWT{AccessToken: "token"}}
+
+	mockClient.On("GetRealmRolesByUserID", "realm1", "id1").
+		Return([]*gocloak.Role{{ID: gocloak.StringP("role-id"), Name: gocloak.StringP("role")}}, nil)
+	mockClient.On("GetUserGroups", "realm1", "id1", gocloak.GetGroupsParams{}).
+		Return([]*gocloak.Group{{ID: gocloak.StringP("group-id"), Name: gocloak.StringP("group"), Path: gocloak.StringP("/group")}}, nil)
+
+	roles, err := adapter.GetUserRealmRoleMappings(context.Background(), "realm1", "id1")
+	require.NoError(t, err)
+	require.Equal(t, []UserRealmRoleMapping{{ID: "role-id", Name: "role"}}, roles)
+
+	groups, err := adapter.GetUserGroupMappings(context.Background(), "realm1", "id1")
+	require.NoError(t, err)
+	require.Equal(t, []UserGroupMapping{{ID: "group-id", Name: "group", Path: "/group"}}, groups)
+
+	mockClient.On("GetUserGroups", "realm1", "id2", gocloak.GetGroupsParams{}).Return(nil, errors.New("fatal"))
+
+	_, err = adapter.GetUserGroupMappings(context.Background(), "realm1", "id2")
+	require.EqualError(t, err, "unable to get group mappings: fatal")
 }
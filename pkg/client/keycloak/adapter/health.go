@@ -0,0 +1,119 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+)
+
+const (
+	healthCheckPath    = "/realms/master"
+	healthCheckTimeout = 5 * time.Second
+
+	// legacyContextPath is a context path of the keycloak versions before 17.
+	legacyContextPath        = "/auth"
+	defaultTokenEndpointPath = "protocol/openid-connect/token"
+)
+
+type healthCheckKey struct{}
+
+// IsHealthCheck reports whether the request with the context is the health check of keycloak.
+func IsHealthCheck(ctx context.Context) bool {
+	healthCheck, _ := ctx.Value(healthCheckKey{}).(bool)
+
+	return healthCheck
+}
+
+// SelectHealthyURL returns the first keycloak URL which passes the health check.
+func SelectHealthyURL(ctx context.Context, restyClient *resty.Client, urls []string) (string, error) {
+	if restyClient == nil {
+		restyClient = resty.New()
+	}
+
+	failures := make([]string, 0, len(urls))
+
+	for _, url := range urls {
+		err := CheckHealth(ctx, restyClient, url)
+		if err == nil {
+			return url, nil
+		}
+
+		failures = append(failures, err.Error())
+	}
+
+	return "", errors.Errorf("no healthy keycloak url: %s", strings.Join(failures, "; "))
+}
+
+// CheckHealth checks that keycloak at the URL is reachable and serves the master realm.
+func CheckHealth(ctx context.Context, restyClient *resty.Client, url string) error {
+	_, err := ResolveBaseURL(ctx, restyClient, url)
+
+	return err
+}
+
+// ResolveBaseURL checks the health of keycloak at the URL and returns the base URL of its API.
+// It tolerates the legacy /auth context path: if the master realm is not found at the URL,
+// the /auth suffix is added to the URL or removed from it.
+func ResolveBaseURL(ctx context.Context, restyClient *resty.Client, url string) (string, error) {
+	if restyClient == nil {
+		restyClient = resty.New()
+	}
+
+	url = strings.TrimSuffix(url, "/")
+
+	status, err := masterRealmStatus(ctx, restyClient, url)
+	if err != nil {
+		return "", errors.Wrapf(err, "keycloak %s is not reachable", url)
+	}
+
+	if status == http.StatusNotFound {
+		alt := url + legacyContextPath
+		if strings.HasSuffix(url, legacyContextPath) {
+			alt = strings.TrimSuffix(url, legacyContextPath)
+		}
+
+		if altStatus, altErr := masterRealmStatus(ctx, restyClient, alt); altErr == nil && altStatus < http.StatusBadRequest {
+			return alt, nil
+		}
+	}
+
+	if status >= http.StatusBadRequest {
+		return "", errors.Errorf("keycloak %s health check failed with status %d", url, status)
+	}
+
+	return url, nil
+}
+
+func masterRealmStatus(ctx context.Context, restyClient *resty.Client, url string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	rsp, err := restyClient.R().SetContext(context.WithValue(ctx, healthCheckKey{}, true)).Get(url + healthCheckPath)
+	if err != nil {
+		return 0, err
+	}
+
+	return rsp.StatusCode(), nil
+}
+
+// SetTokenEndpointPath makes the adapters which use the resty client request the tokens from the path
+// relative to the realm URL instead of the default protocol/openid-connect/token path,
+// e.g. if the token endpoint is exposed by the reverse proxy at another path.
+func SetTokenEndpointPath(restyClient *resty.Client, path string) {
+	path = strings.Trim(path, "/")
+	if path == "" || path == defaultTokenEndpointPath {
+		return
+	}
+
+	restyClient.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if strings.HasSuffix(req.URL, "/"+defaultTokenEndpointPath) {
+			req.URL = strings.TrimSuffix(req.URL, defaultTokenEndpointPath) + path
+		}
+
+		return nil
+	})
+}
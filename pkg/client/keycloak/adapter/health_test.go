@@ -0,0 +1,74 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectHealthyURL(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	httpmock.RegisterResponder(http.MethodGet, "https://site-a.example.com/realms/master",
+		httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+	httpmock.RegisterResponder(http.MethodGet, "https://site-b.example.com/realms/master",
+		httpmock.NewStringResponder(http.StatusOK, "{}"))
+
+	url, err := SelectHealthyURL(context.Background(), restyClient,
+		[]string{"https://site-a.example.com", "https://site-b.example.com/"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://site-b.example.com/", url)
+
+	_, err = SelectHealthyURL(context.Background(), restyClient, []string{"https://site-a.example.com"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "keycloak https://site-a.example.com health check failed with status 503")
+}
+
+func TestResolveBaseURL(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	httpmock.RegisterResponder(http.MethodGet, "https://legacy.example.com/realms/master",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+	httpmock.RegisterResponder(http.MethodGet, "https://legacy.example.com/auth/realms/master",
+		httpmock.NewStringResponder(http.StatusOK, "{}"))
+	httpmock.RegisterResponder(http.MethodGet, "https://root.example.com/auth/realms/master",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+	httpmock.RegisterResponder(http.MethodGet, "https://root.example.com/realms/master",
+		httpmock.NewStringResponder(http.StatusOK, "{}"))
+
+	url, err := ResolveBaseURL(context.Background(), restyClient, "https://legacy.example.com/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://legacy.example.com/auth", url)
+
+	url, err = ResolveBaseURL(context.Background(), restyClient, "https://root.example.com/auth")
+	require.NoError(t, err)
+	assert.Equal(t, "https://root.example.com", url)
+
+	httpmock.RegisterResponder(http.MethodGet, "https://legacy.example.com/auth/realms/master",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+	_, err = ResolveBaseURL(context.Background(), restyClient, "https://legacy.example.com")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "health check failed with status 404")
+}
+
+func TestSetTokenEndpointPath(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	httpmock.RegisterResponder(http.MethodPost, "https://keycloak.example.com/realms/master/oauth/token",
+		httpmock.NewStringResponder(http.StatusOK, "{}"))
+
+	SetTokenEndpointPath(restyClient, "/oauth/token")
+
+	rsp, err := restyClient.R().Post("https://keycloak.example.com/realms/master/protocol/openid-connect/token")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode())
+}
@@ -4,9 +4,17 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/Nerzal/gocloak/v12"
 	"github.com/pkg/errors"
 )
 
+// Identity provider config keys of the brokered login settings.
+const (
+	IdentityProviderSyncModeKey        = "syncMode"
+	IdentityProviderHideOnLoginPageKey = "hideOnLoginPage"
+	IdentityProviderGUIOrderKey        = "guiOrder"
+)
+
 type IdentityProvider struct {
 	ProviderID                string            `json:"providerId"`
 	Config                    map[string]string `json:"config"`
@@ -16,6 +24,7 @@ type IdentityProvider struct {
 	DisplayName               string            `json:"displayName"`
 	Enabled                   bool              `json:"enabled"`
 	FirstBrokerLoginFlowAlias string            `json:"firstBrokerLoginFlowAlias"`
+	PostBrokerLoginFlowAlias  *string           `json:"postBrokerLoginFlowAlias,omitempty"`
 	LinkOnly                  bool              `json:"linkOnly"`
 	StoreToken                bool              `json:"storeToken"`
 	TrustEmail                bool              `json:"trustEmail"`
@@ -72,6 +81,18 @@ func (a GoCloakAdapter) GetIdentityProvider(ctx context.Context, realm, alias st
 	return &idp, nil
 }
 
+// ImportIdentityProviderConfig imports identity provider config from the descriptor located by the given URL,
+// e.g. SAML entity descriptor or OpenID Connect discovery endpoint.
+func (a GoCloakAdapter) ImportIdentityProviderConfig(ctx context.Context, realm, providerID,
+	fromURL string) (map[string]string, error) {
+	config, err := a.client.ImportIdentityProviderConfig(ctx, a.token.AccessToken, realm, fromURL, providerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to import idp config")
+	}
+
+	return config, nil
+}
+
 func (a GoCloakAdapter) IdentityProviderExists(ctx context.Context, realm, alias string) (bool, error) {
 	_, err := a.GetIdentityProvider(ctx, realm, alias)
 	if err != nil {
@@ -86,12 +107,7 @@ func (a GoCloakAdapter) IdentityProviderExists(ctx context.Context, realm, alias
 }
 
 func (a GoCloakAdapter) DeleteIdentityProvider(ctx context.Context, realm, alias string) error {
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realm,
-		keycloakApiParamAlias: alias,
-	}).Delete(a.basePath + identityProviderEntity)
-
-	if err = a.checkError(err, rsp); err != nil {
+	if err := a.client.DeleteIdentityProvider(ctx, a.token.AccessToken, realm, alias); err != nil {
 		return errors.Wrap(err, "unable to delete idp")
 	}
 
@@ -100,31 +116,17 @@ func (a GoCloakAdapter) DeleteIdentityProvider(ctx context.Context, realm, alias
 
 func (a GoCloakAdapter) CreateIDPMapper(ctx context.Context, realm, idpAlias string,
 	mapper *IdentityProviderMapper) (string, error) {
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realm,
-		keycloakApiParamAlias: idpAlias,
-	}).SetBody(mapper).Post(a.basePath + idpMapperCreateList)
-
-	if err = a.checkError(err, rsp); err != nil {
-		return "", errors.Wrap(err, "unable to create idp mapper")
-	}
-
-	id, err := getIDFromResponseLocation(rsp.RawResponse)
+	id, err := a.client.CreateIdentityProviderMapper(ctx, a.token.AccessToken, realm, idpAlias, mapper.toGoCloak())
 	if err != nil {
-		return "", errors.Wrap(err, "no id in response")
+		return "", errors.Wrap(err, "unable to create idp mapper")
 	}
 
 	return id, nil
 }
 
 func (a GoCloakAdapter) UpdateIDPMapper(ctx context.Context, realm, idpAlias string, mapper *IdentityProviderMapper) error {
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realm,
-		keycloakApiParamAlias: idpAlias,
-		keycloakApiParamId:    mapper.ID,
-	}).SetBody(mapper).Put(a.basePath + idpMapperEntity)
-
-	if err = a.checkError(err, rsp); err != nil {
+	if err := a.client.UpdateIdentityProviderMapper(ctx, a.token.AccessToken, realm, idpAlias,
+		mapper.toGoCloak()); err != nil {
 		return errors.Wrap(err, "unable to update idp mapper")
 	}
 
@@ -132,13 +134,7 @@ func (a GoCloakAdapter) UpdateIDPMapper(ctx context.Context, realm, idpAlias str
 }
 
 func (a GoCloakAdapter) DeleteIDPMapper(ctx context.Context, realm, idpAlias, mapperID string) error {
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realm,
-		keycloakApiParamAlias: idpAlias,
-		keycloakApiParamId:    mapperID,
-	}).Delete(a.basePath + idpMapperEntity)
-
-	if err = a.checkError(err, rsp); err != nil {
+	if err := a.client.DeleteIdentityProviderMapper(ctx, a.token.AccessToken, realm, idpAlias, mapperID); err != nil {
 		return errors.Wrap(err, "unable to delete idp mapper")
 	}
 
@@ -146,15 +142,40 @@ func (a GoCloakAdapter) DeleteIDPMapper(ctx context.Context, realm, idpAlias, ma
 }
 
 func (a GoCloakAdapter) GetIDPMappers(ctx context.Context, realm, idpAlias string) ([]IdentityProviderMapper, error) {
-	var res []IdentityProviderMapper
-	rsp, err := a.startRestyRequest().SetContext(ctx).SetPathParams(map[string]string{
-		keycloakApiParamRealm: realm,
-		keycloakApiParamAlias: idpAlias,
-	}).SetResult(&res).Get(a.basePath + idpMapperCreateList)
-
-	if err = a.checkError(err, rsp); err != nil {
+	mappers, err := a.client.GetIdentityProviderMappers(ctx, a.token.AccessToken, realm, idpAlias)
+	if err != nil {
 		return nil, errors.Wrap(err, "unable to get idp mappers")
 	}
 
+	res := make([]IdentityProviderMapper, 0, len(mappers))
+	for _, m := range mappers {
+		res = append(res, identityProviderMapperFromGoCloak(m))
+	}
+
 	return res, nil
 }
+
+func (m *IdentityProviderMapper) toGoCloak() gocloak.IdentityProviderMapper {
+	return gocloak.IdentityProviderMapper{
+		ID:                     optionalString(m.ID),
+		Name:                   gocloak.StringP(m.Name),
+		IdentityProviderMapper: gocloak.StringP(m.IdentityProviderMapper),
+		IdentityProviderAlias:  gocloak.StringP(m.IdentityProviderAlias),
+		Config:                 &m.Config,
+	}
+}
+
+func identityProviderMapperFromGoCloak(m *gocloak.IdentityProviderMapper) IdentityProviderMapper {
+	mapper := IdentityProviderMapper{
+		ID:                     gocloak.PString(m.ID),
+		Name:                   gocloak.PString(m.Name),
+		IdentityProviderMapper: gocloak.PString(m.IdentityProviderMapper),
+		IdentityProviderAlias:  gocloak.PString(m.IdentityProviderAlias),
+	}
+
+	if m.Config != nil {
+		mapper.Config = *m.Config
+	}
+
+	return mapper
+}
@@ -2,9 +2,12 @@ package adapter
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/Nerzal/gocloak/v12"
 	"github.com/jarcoal/httpmock"
+	testifyMock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -56,6 +59,27 @@ func TestGoCloakAdapter_CreateIdentityProvider(t *testing.T) {
 	}
 }
 
+func TestGoCloakAdapter_ImportIdentityProviderConfig(t *testing.T) {
+	kc, mockClient, _ := initAdapter()
+
+	mockClient.On("ImportIdentityProviderConfig", "realm1", "https://idp/metadata", "saml").
+		Return(map[string]string{"singleSignOnServiceUrl": "https://idp/sso"}, nil)
+
+	config, err := kc.ImportIdentityProviderConfig(context.Background(), "realm1", "saml", "https://idp/metadata")
+	require.NoError(t, err)
+	require.Equal(t, "https://idp/sso", config["singleSignOnServiceUrl"])
+
+	mockClient.On("ImportIdentityProviderConfig", "realm2", "https://idp/metadata", "saml").
+		Return(nil, errors.New("fatal"))
+
+	_, err = kc.ImportIdentityProviderConfig(context.Background(), "realm2", "saml", "https://idp/metadata")
+	require.Error(t, err)
+
+	if err.Error() != "unable to import idp config: fatal" {
+		t.Fatalf("wrong error returned: %s", err.Error())
+	}
+}
+
 func TestGoCloakAdapter_UpdateIdentityProvider(t *testing.T) {
 	kc, _, _ := initAdapter()
 
@@ -77,117 +101,121 @@ func TestGoCloakAdapter_UpdateIdentityProvider(t *testing.T) {
 }
 
 func TestGoCloakAdapter_DeleteIdentityProvider(t *testing.T) {
-	kc, _, _ := initAdapter()
+	kc, mockClient, _ := initAdapter()
 
-	httpmock.RegisterResponder("DELETE", "/admin/realms/realm1/identity-provider/instances/alias1",
-		httpmock.NewStringResponder(200, ""))
+	mockClient.On("DeleteIdentityProvider", "realm1", "alias1").Return(nil)
 
 	err := kc.DeleteIdentityProvider(context.Background(), "realm1", "alias1")
 	require.NoError(t, err)
 
-	httpmock.RegisterResponder("DELETE", "/admin/realms/realm1/identity-provider/instances/alias2",
-		httpmock.NewStringResponder(500, "fatal"))
+	mockClient.On("DeleteIdentityProvider", "realm1", "alias2").Return(errors.New("fatal"))
 
 	err = kc.DeleteIdentityProvider(context.Background(), "realm1", "alias2")
 	require.Error(t, err)
 
-	if err.Error() != "unable to delete idp: status: 500, body: fatal" {
+	if err.Error() != "unable to delete idp: fatal" {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
 
 func TestGoCloakAdapter_CreateIDPMapper(t *testing.T) {
-	kc, _, _ := initAdapter()
-
-	rsp := httpmock.NewStringResponse(200, "")
-	defer closeWithFailOnError(t, rsp.Body)
-	rsp.Header.Set("Location", "id/new-id")
-
-	httpmock.RegisterResponder("POST",
-		"/admin/realms/realm1/identity-provider/instances/alias1/mappers",
-		httpmock.ResponderFromResponse(rsp))
-
-	_, err := kc.CreateIDPMapper(context.Background(), "realm1", "alias1", &IdentityProviderMapper{})
+	kc, mockClient, _ := initAdapter()
+
+	mockClient.On("CreateIdentityProviderMapper", "realm1", "alias1", gocloak.IdentityProviderMapper{
+		Name:                   gocloak.StringP("mapper1"),
+		IdentityProviderMapper: gocloak.StringP("hardcoded-attribute-idp-mapper"),
+		IdentityProviderAlias:  gocloak.StringP("alias1"),
+		Config:                 &map[string]string{"attribute": "foo"},
+	}).Return("new-id", nil)
+
+	id, err := kc.CreateIDPMapper(context.Background(), "realm1", "alias1", &IdentityProviderMapper{
+		Name:                   "mapper1",
+		IdentityProviderMapper: "hardcoded-attribute-idp-mapper",
+		IdentityProviderAlias:  "alias1",
+		Config:                 map[string]string{"attribute": "foo"},
+	})
 	require.NoError(t, err)
+	require.Equal(t, "new-id", id)
 
-	httpmock.RegisterResponder("POST",
-		"/admin/realms/realm1/identity-provider/instances/alias2/mappers",
-		httpmock.NewStringResponder(500, "fatal"))
+	mockClient.On("CreateIdentityProviderMapper", "realm1", "alias2", testifyMock.Anything).
+		Return("", errors.New("fatal"))
 
 	_, err = kc.CreateIDPMapper(context.Background(), "realm1", "alias2",
 		&IdentityProviderMapper{})
 
 	require.Error(t, err)
 
-	if err.Error() != "unable to create idp mapper: status: 500, body: fatal" {
+	if err.Error() != "unable to create idp mapper: fatal" {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
 
 func TestGoCloakAdapter_UpdateIDPMapper(t *testing.T) {
-	kc, _, _ := initAdapter()
+	kc, mockClient, _ := initAdapter()
 
-	httpmock.RegisterResponder("PUT",
-		"/admin/realms/realm1/identity-provider/instances/alias1/mappers/id11",
-		httpmock.NewStringResponder(200, ""))
+	mockClient.On("UpdateIdentityProviderMapper", "realm1", "alias1", testifyMock.MatchedBy(
+		func(m gocloak.IdentityProviderMapper) bool {
+			return gocloak.PString(m.ID) == "id11"
+		})).Return(nil)
 
 	err := kc.UpdateIDPMapper(context.Background(), "realm1", "alias1",
 		&IdentityProviderMapper{ID: "id11"})
 	require.NoError(t, err)
 
-	httpmock.RegisterResponder("PUT",
-		"/admin/realms/realm1/identity-provider/instances/alias2/mappers/id11",
-		httpmock.NewStringResponder(500, "fatal"))
+	mockClient.On("UpdateIdentityProviderMapper", "realm1", "alias2", testifyMock.Anything).
+		Return(errors.New("fatal"))
 
 	err = kc.UpdateIDPMapper(context.Background(), "realm1", "alias2",
 		&IdentityProviderMapper{ID: "id11"})
 	require.Error(t, err)
 
-	if err.Error() != "unable to update idp mapper: status: 500, body: fatal" {
+	if err.Error() != "unable to update idp mapper: fatal" {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
 
 func TestGoCloakAdapter_DeleteIDPMapper(t *testing.T) {
-	kc, _, _ := initAdapter()
+	kc, mockClient, _ := initAdapter()
 
-	httpmock.RegisterResponder("DELETE",
-		"/admin/realms/realm1/identity-provider/instances/alias1/mappers/mapper1",
-		httpmock.NewStringResponder(200, ""))
+	mockClient.On("DeleteIdentityProviderMapper", "realm1", "alias1", "mapper1").Return(nil)
 
 	err := kc.DeleteIDPMapper(context.Background(), "realm1", "alias1", "mapper1")
 	require.NoError(t, err)
 
-	httpmock.RegisterResponder("DELETE",
-		"/admin/realms/realm1/identity-provider/instances/alias1/mappers/mapper2",
-		httpmock.NewStringResponder(500, "fatal"))
+	mockClient.On("DeleteIdentityProviderMapper", "realm1", "alias1", "mapper2").Return(errors.New("fatal"))
 
 	err = kc.DeleteIDPMapper(context.Background(), "realm1", "alias1", "mapper2")
 	require.Error(t, err)
 
-	if err.Error() != "unable to delete idp mapper: status: 500, body: fatal" {
+	if err.Error() != "unable to delete idp mapper: fatal" {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
 
 func TestGoCloakAdapter_GetIDPMappers(t *testing.T) {
-	kc, _, _ := initAdapter()
+	kc, mockClient, _ := initAdapter()
 
-	httpmock.RegisterResponder("GET",
-		"/admin/realms/realm1/identity-provider/instances/alias1/mappers",
-		httpmock.NewStringResponder(200, ""))
+	mockClient.On("GetIdentityProviderMappers", "realm1", "alias1").
+		Return([]*gocloak.IdentityProviderMapper{{
+			ID:     gocloak.StringP("mapper1"),
+			Name:   gocloak.StringP("name1"),
+			Config: &map[string]string{"attribute": "foo"},
+		}}, nil)
 
-	_, err := kc.GetIDPMappers(context.Background(), "realm1", "alias1")
+	mappers, err := kc.GetIDPMappers(context.Background(), "realm1", "alias1")
 	require.NoError(t, err)
+	require.Equal(t, []IdentityProviderMapper{{
+		ID:     "mapper1",
+		Name:   "name1",
+		Config: map[string]string{"attribute": "foo"},
+	}}, mappers)
 
-	httpmock.RegisterResponder("GET",
-		"/admin/realms/realm1/identity-provider/instances/alias2/mappers",
-		httpmock.NewStringResponder(500, "fatal"))
+	mockClient.On("GetIdentityProviderMappers", "realm1", "alias2").Return(nil, errors.New("fatal"))
 
 	_, err = kc.GetIDPMappers(context.Background(), "realm1", "alias2")
 	require.Error(t, err)
 
-	if err.Error() != "unable to get idp mappers: status: 500, body: fatal" {
+	if err.Error() != "unable to get idp mappers: fatal" {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
@@ -74,7 +74,9 @@ func (m *Mock) UpdateClient(ctx context.Context, client *dto.Client) error {
 }
 
 func (m *Mock) ExistClientRole(role *dto.Client, clientRole string) (bool, error) {
-	panic("implement me")
+	called := m.Called(role, clientRole)
+
+	return called.Bool(0), called.Error(1)
 }
 
 func (m *Mock) CreateClientRole(role *dto.Client, clientRole string) error {
@@ -176,6 +178,64 @@ func (m *Mock) AddDefaultScopeToClient(ctx context.Context, realmName, clientNam
 	return m.Called(ctx, realmName, clientName, scopes).Error(0)
 }
 
+func (m *Mock) AddOptionalScopeToClient(ctx context.Context, realmName, clientName string, scopes []ClientScope) error {
+	return m.Called(ctx, realmName, clientName, scopes).Error(0)
+}
+
+func (m *Mock) GetClientDefaultScopes(ctx context.Context, realmName, clientName string) ([]ClientScope, error) {
+	called := m.Called(ctx, realmName, clientName)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]ClientScope), nil
+}
+
+func (m *Mock) GetClientOptionalScopes(ctx context.Context, realmName, clientName string) ([]ClientScope, error) {
+	called := m.Called(ctx, realmName, clientName)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]ClientScope), nil
+}
+
+func (m *Mock) RemoveDefaultScopeFromClient(ctx context.Context, realmName, clientName string, scopes []ClientScope) error {
+	return m.Called(ctx, realmName, clientName, scopes).Error(0)
+}
+
+func (m *Mock) RemoveOptionalScopeFromClient(ctx context.Context, realmName, clientName string, scopes []ClientScope) error {
+	return m.Called(ctx, realmName, clientName, scopes).Error(0)
+}
+
+func (m *Mock) GetServerVersion(ctx context.Context) (string, error) {
+	called := m.Called()
+	return called.String(0), called.Error(1)
+}
+
+func (m *Mock) GetServerThemes(ctx context.Context) (ServerThemes, error) {
+	called := m.Called()
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(ServerThemes), nil
+}
+
+func (m *Mock) GetServerPasswordPolicies(ctx context.Context) ([]string, error) {
+	called := m.Called()
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]string), nil
+}
+
+func (m *Mock) SyncClientScopeMappings(ctx context.Context, realm, clientID string, realmRoles []string,
+	clientRoles map[string][]string, addOnly bool) error {
+	return m.Called(realm, clientID, realmRoles, clientRoles, addOnly).Error(0)
+}
+
 func (m *Mock) PutClientScopeMapper(realmName, scopeID string, protocolMapper *ProtocolMapper) error {
 	return m.Called(realmName, scopeID, protocolMapper).Error(0)
 }
@@ -185,20 +245,65 @@ func (m *Mock) SyncClientProtocolMapper(
 	return m.Called(client, crMappers, addOnly).Error(0)
 }
 
+func (m *Mock) SyncClientRole(ctx context.Context, realmName string, role *dto.ClientRole) error {
+	return m.Called(realmName, role).Error(0)
+}
+
+func (m *Mock) DeleteClientRole(ctx context.Context, realmName, clientID, roleName string) error {
+	return m.Called(realmName, clientID, roleName).Error(0)
+}
+
 func (m *Mock) SyncRealmRole(realmName string, role *dto.PrimaryRealmRole) error {
 	return m.Called(realmName, role).Error(0)
 }
 
+func (m *Mock) SyncRealmDefaultRoles(ctx context.Context, realmName string, realmRoles []string,
+	clientRoles map[string][]string) ([]string, error) {
+	called := m.Called(realmName, realmRoles, clientRoles)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]string), nil
+}
+
+func (m *Mock) GetRealmRoleUsage(ctx context.Context, realmName, roleName string) (*RoleUsage, error) {
+	called := m.Called(realmName, roleName)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*RoleUsage), nil
+}
+
+func (m *Mock) GetClientRoleUsage(ctx context.Context, realmName, clientID, roleName string) (*RoleUsage, error) {
+	called := m.Called(realmName, clientID, roleName)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*RoleUsage), nil
+}
+
 func (m *Mock) SyncServiceAccountRoles(realm, clientID string, realmRoles []string,
 	clientRoles map[string][]string, addOnly bool) error {
 	return m.Called(realm, clientID, realmRoles, clientRoles, addOnly).Error(0)
 }
 
-func (m *Mock) SyncRealmGroup(realmName string, spec *keycloakApi.KeycloakRealmGroupSpec) (string, error) {
-	called := m.Called(realmName, spec)
+func (m *Mock) SyncRealmGroup(realmName string, spec *keycloakApi.KeycloakRealmGroupSpec, groupID string) (string, error) {
+	called := m.Called(realmName, spec, groupID)
 	return called.String(0), called.Error(1)
 }
 
+func (m *Mock) GetGroupByPath(realm, groupPath string) (*gocloak.Group, error) {
+	called := m.Called(realm, groupPath)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*gocloak.Group), nil
+}
+
 func (m *Mock) DeleteGroup(ctx context.Context, realm, groupName string) error {
 	return m.Called(realm, groupName).Error(0)
 }
@@ -219,6 +324,13 @@ func (m *Mock) SyncAuthFlow(realmName string, flow *KeycloakAuthFlow) error {
 func (m *Mock) SetRealmBrowserFlow(realmName string, flowAlias string) error {
 	return m.Called(realmName, flowAlias).Error(0)
 }
+
+func (m *Mock) AuthFlowExists(realmName, flowAlias string) (bool, error) {
+	called := m.Called(realmName, flowAlias)
+
+	return called.Bool(0), called.Error(1)
+}
+
 func (m *Mock) UpdateRealmSettings(realmName string, realmSettings *RealmSettings) error {
 	return m.Called(realmName, realmSettings).Error(0)
 }
@@ -227,6 +339,101 @@ func (m *Mock) SyncRealmUser(ctx context.Context, realmName string, user *Keyclo
 	return m.Called(realmName, user, addOnly).Error(0)
 }
 
+func (m *Mock) ExecuteActionsEmail(ctx context.Context, realmName, username string, actions []string) error {
+	return m.Called(realmName, username, actions).Error(0)
+}
+
+func (m *Mock) ListUserCredentials(ctx context.Context, realmName, username string) ([]string, error) {
+	called := m.Called(realmName, username)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]string), nil
+}
+
+func (m *Mock) ResetUserCredentials(ctx context.Context, realmName, username string,
+	credentialTypes []string) ([]string, error) {
+	called := m.Called(realmName, username, credentialTypes)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]string), nil
+}
+
+func (m *Mock) RemoveUserCredentials(ctx context.Context, realmName, username string,
+	credentialTypes []string) ([]string, error) {
+	called := m.Called(realmName, username, credentialTypes)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]string), nil
+}
+
+func (m *Mock) GetUserProfileConfig(ctx context.Context, realmName string) (*UserProfileConfig, error) {
+	called := m.Called(realmName)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*UserProfileConfig), nil
+}
+
+func (m *Mock) UpdateUserProfileConfig(ctx context.Context, realmName string, cfg *UserProfileConfig) error {
+	return m.Called(realmName, cfg).Error(0)
+}
+
+func (m *Mock) GetClientProfiles(ctx context.Context, realmName string) (*ClientProfiles, error) {
+	called := m.Called(realmName)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*ClientProfiles), nil
+}
+
+func (m *Mock) UpdateClientProfiles(ctx context.Context, realmName string, profiles *ClientProfiles) error {
+	return m.Called(realmName, profiles).Error(0)
+}
+
+func (m *Mock) GetClientPolicies(ctx context.Context, realmName string) (*ClientPolicies, error) {
+	called := m.Called(realmName)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*ClientPolicies), nil
+}
+
+func (m *Mock) UpdateClientPolicies(ctx context.Context, realmName string, policies *ClientPolicies) error {
+	return m.Called(realmName, policies).Error(0)
+}
+
+func (m *Mock) PartialImport(ctx context.Context, realm string, representation map[string]interface{},
+	ifResourceExists string) (*PartialImportResult, error) {
+	called := m.Called(realm, representation, ifResourceExists)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*PartialImportResult), nil
+}
+
+func (m *Mock) DeleteImportedResource(ctx context.Context, realm string, resource *PartialImportResultItem) error {
+	return m.Called(realm, resource).Error(0)
+}
+
+func (m *Mock) ExportRealm(ctx context.Context, realm string) (*RealmExport, error) {
+	called := m.Called(realm)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*RealmExport), nil
+}
+
 func (m *Mock) SetServiceAccountAttributes(realm, clientID string, attributes map[string]string, addOnly bool) error {
 	return m.Called(realm, clientID, attributes, addOnly).Error(0)
 }
@@ -252,6 +459,11 @@ func (m *Mock) SetRealmEventConfig(realmName string, eventConfig *RealmEventConf
 	return m.Called(realmName, eventConfig).Error(0)
 }
 
+func (m *Mock) SyncRealmLocalizationTexts(ctx context.Context, realmName, locale string,
+	texts map[string]string) error {
+	return m.Called(realmName, locale, texts).Error(0)
+}
+
 func (m *Mock) ExportToken() ([]byte, error) {
 	return m.ExportTokenResult, m.ExportTokenErr
 }
@@ -277,6 +489,28 @@ func (m *Mock) GetComponent(ctx context.Context, realmName, componentName string
 	return called.Get(0).(*Component), nil
 }
 
+func (m *Mock) GetComponents(ctx context.Context, realmName, providerType string) ([]Component, error) {
+	called := m.Called(realmName, providerType)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]Component), nil
+}
+
+func (m *Mock) DeleteComponentByID(ctx context.Context, realmName, componentID string) error {
+	return m.Called(realmName, componentID).Error(0)
+}
+
+func (m *Mock) SyncUserStorage(ctx context.Context, realmName, componentID, action string) (*UserStorageSyncResult, error) {
+	called := m.Called(realmName, componentID, action)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*UserStorageSyncResult), nil
+}
+
 func (m *Mock) GetDefaultClientScopesForRealm(ctx context.Context, realm string) ([]ClientScope, error) {
 	called := m.Called(realm)
 	if err := called.Error(1); err != nil {
@@ -286,6 +520,11 @@ func (m *Mock) GetDefaultClientScopesForRealm(ctx context.Context, realm string)
 	return called.Get(0).([]ClientScope), nil
 }
 
+func (m *Mock) SyncRealmDefaultClientScopes(ctx context.Context, realmName string, defaultScopes,
+	optionalScopes []string) error {
+	return m.Called(realmName, defaultScopes, optionalScopes).Error(0)
+}
+
 func (m *Mock) GetClientScopeMappers(ctx context.Context, realmName, scopeID string) ([]ProtocolMapper, error) {
 	called := m.Called(realmName, scopeID)
 	if err := called.Error(1); err != nil {
@@ -321,6 +560,15 @@ func (m *Mock) DeleteIdentityProvider(ctx context.Context, realm, alias string)
 	return m.Called(realm, alias).Error(0)
 }
 
+func (m *Mock) ImportIdentityProviderConfig(ctx context.Context, realm, providerID, fromURL string) (map[string]string, error) {
+	called := m.Called(realm, providerID, fromURL)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(map[string]string), nil
+}
+
 func (m *Mock) GetIdentityProvider(ctx context.Context, realm, alias string) (*IdentityProvider, error) {
 	called := m.Called(realm, alias)
 	if err := called.Error(1); err != nil {
@@ -356,3 +604,152 @@ func (m *Mock) GetClientScopesByNames(ctx context.Context, realmName string, sco
 
 	return called.Get(0).([]ClientScope), nil
 }
+
+func (m *Mock) UpdateResourceServerSettings(ctx context.Context, realmName, clientID string,
+	settings *ResourceServerSettings) error {
+	return m.Called(realmName, clientID, settings).Error(0)
+}
+
+func (m *Mock) ImportAuthzSettings(ctx context.Context, realmName, clientID string, settings []byte) error {
+	return m.Called(realmName, clientID, settings).Error(0)
+}
+
+func (m *Mock) GetAuthzScopes(ctx context.Context, realmName, clientID string) ([]AuthzScope, error) {
+	called := m.Called(realmName, clientID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]AuthzScope), nil
+}
+
+func (m *Mock) CreateAuthzScope(ctx context.Context, realmName, clientID string, scope *AuthzScope) error {
+	return m.Called(realmName, clientID, scope).Error(0)
+}
+
+func (m *Mock) UpdateAuthzScope(ctx context.Context, realmName, clientID string, scope *AuthzScope) error {
+	return m.Called(realmName, clientID, scope).Error(0)
+}
+
+func (m *Mock) DeleteAuthzScope(ctx context.Context, realmName, clientID, scopeID string) error {
+	return m.Called(realmName, clientID, scopeID).Error(0)
+}
+
+func (m *Mock) GetAuthzResources(ctx context.Context, realmName, clientID string) ([]AuthzResource, error) {
+	called := m.Called(realmName, clientID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]AuthzResource), nil
+}
+
+func (m *Mock) CreateAuthzResource(ctx context.Context, realmName, clientID string, resource *AuthzResource) error {
+	return m.Called(realmName, clientID, resource).Error(0)
+}
+
+func (m *Mock) UpdateAuthzResource(ctx context.Context, realmName, clientID string, resource *AuthzResource) error {
+	return m.Called(realmName, clientID, resource).Error(0)
+}
+
+func (m *Mock) DeleteAuthzResource(ctx context.Context, realmName, clientID, resourceID string) error {
+	return m.Called(realmName, clientID, resourceID).Error(0)
+}
+
+func (m *Mock) GetAuthzPolicies(ctx context.Context, realmName, clientID string) ([]AuthzPolicy, error) {
+	called := m.Called(realmName, clientID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]AuthzPolicy), nil
+}
+
+func (m *Mock) CreateAuthzPolicy(ctx context.Context, realmName, clientID string, policy *AuthzPolicy) error {
+	return m.Called(realmName, clientID, policy).Error(0)
+}
+
+func (m *Mock) UpdateAuthzPolicy(ctx context.Context, realmName, clientID string, policy *AuthzPolicy) error {
+	return m.Called(realmName, clientID, policy).Error(0)
+}
+
+func (m *Mock) DeleteAuthzPolicy(ctx context.Context, realmName, clientID, policyID string) error {
+	return m.Called(realmName, clientID, policyID).Error(0)
+}
+
+func (m *Mock) GetAuthzPermissions(ctx context.Context, realmName, clientID string) ([]AuthzPolicy, error) {
+	called := m.Called(realmName, clientID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]AuthzPolicy), nil
+}
+
+func (m *Mock) CreateAuthzPermission(ctx context.Context, realmName, clientID string, permission *AuthzPolicy) error {
+	return m.Called(realmName, clientID, permission).Error(0)
+}
+
+func (m *Mock) UpdateAuthzPermission(ctx context.Context, realmName, clientID string, permission *AuthzPolicy) error {
+	return m.Called(realmName, clientID, permission).Error(0)
+}
+
+func (m *Mock) DeleteAuthzPermission(ctx context.Context, realmName, clientID, permissionID string) error {
+	return m.Called(realmName, clientID, permissionID).Error(0)
+}
+
+func (m *Mock) GetOrganization(ctx context.Context, realm, id string) (*Organization, error) {
+	called := m.Called(realm, id)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*Organization), nil
+}
+
+func (m *Mock) GetOrganizationByName(ctx context.Context, realm, name string) (*Organization, error) {
+	called := m.Called(realm, name)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*Organization), nil
+}
+
+func (m *Mock) CreateOrganization(ctx context.Context, realm string, org *Organization) (string, error) {
+	called := m.Called(realm, org)
+	return called.String(0), called.Error(1)
+}
+
+func (m *Mock) UpdateOrganization(ctx context.Context, realm string, org *Organization) error {
+	return m.Called(realm, org).Error(0)
+}
+
+func (m *Mock) DeleteOrganization(ctx context.Context, realm, id string) error {
+	return m.Called(realm, id).Error(0)
+}
+
+func (m *Mock) SyncOrganizationIdentityProviders(ctx context.Context, realm, orgID string, aliases []string) error {
+	return m.Called(realm, orgID, aliases).Error(0)
+}
+
+func (m *Mock) SyncOrganizationMembers(ctx context.Context, realm, orgID string, usernames []string) error {
+	return m.Called(realm, orgID, usernames).Error(0)
+}
+
+func (m *Mock) GetRequiredAction(ctx context.Context, realm, alias string) (*RequiredAction, error) {
+	called := m.Called(realm, alias)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*RequiredAction), nil
+}
+
+func (m *Mock) RegisterRequiredAction(ctx context.Context, realm, providerID, name string) error {
+	return m.Called(realm, providerID, name).Error(0)
+}
+
+func (m *Mock) UpdateRequiredAction(ctx context.Context, realm string, action *RequiredAction) error {
+	return m.Called(realm, action).Error(0)
+}
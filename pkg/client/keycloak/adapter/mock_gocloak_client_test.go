@@ -70,7 +70,8 @@ func (m *MockGoCloakClient) CreateClient(ctx context.Context, accessToken, realm
 
 func (m *MockGoCloakClient) CreateClientRole(ctx context.Context, accessToken, realm, clientID string,
 	role gocloak.Role) (string, error) {
-	panic("implement me")
+	called := m.Called(realm, clientID, role)
+	return called.String(0), called.Error(1)
 }
 
 func (m *MockGoCloakClient) CreateRealmRole(ctx context.Context, token, realm string,
@@ -111,6 +112,7 @@ func (m *MockGoCloakClient) GetClientRoles(ctx context.Context, accessToken, rea
 
 func (m *MockGoCloakClient) GetClients(ctx context.Context, accessToken, realm string,
 	params gocloak.GetClientsParams) ([]*gocloak.Client, error) {
+	params.First, params.Max = nil, nil
 	args := m.Called(realm, params)
 	if err := args.Error(1); err != nil {
 		return nil, err
@@ -140,6 +142,7 @@ func (m *MockGoCloakClient) GetRoleMappingByUserID(ctx context.Context, accessTo
 
 func (m *MockGoCloakClient) GetUsers(ctx context.Context, accessToken, realm string,
 	params gocloak.GetUsersParams) ([]*gocloak.User, error) {
+	params.First, params.Max = nil, nil
 	called := m.Called(realm, params)
 	if err := called.Error(1); err != nil {
 		return nil, err
@@ -178,12 +181,30 @@ func (m *MockGoCloakClient) DeleteRealmRoleComposite(ctx context.Context, token,
 	return m.Called(realm, roleName, roles).Error(0)
 }
 
-func (m *MockGoCloakClient) GetCompositeRealmRolesByRoleID(ctx context.Context, token, realm,
+func (m *MockGoCloakClient) GetCompositeRolesByRoleID(ctx context.Context, token, realm,
 	roleID string) ([]*gocloak.Role, error) {
 	called := m.Called(realm, roleID)
 	return called.Get(0).([]*gocloak.Role), called.Error(1)
 }
 
+func (m *MockGoCloakClient) UpdateRole(ctx context.Context, token, realm, clientID string, role gocloak.Role) error {
+	return m.Called(realm, clientID, role).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientRole(ctx context.Context, token, realm, clientID, roleName string) error {
+	return m.Called(realm, clientID, roleName).Error(0)
+}
+
+func (m *MockGoCloakClient) AddClientRoleComposite(ctx context.Context, token, realm, roleID string,
+	roles []gocloak.Role) error {
+	return m.Called(realm, roleID, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientRoleComposite(ctx context.Context, token, realm, roleID string,
+	roles []gocloak.Role) error {
+	return m.Called(realm, roleID, roles).Error(0)
+}
+
 func (m *MockGoCloakClient) UpdateRealmRole(ctx context.Context, token, realm, roleName string,
 	role gocloak.Role) error {
 	return m.Called(realm, roleName, role).Error(0)
@@ -257,6 +278,7 @@ func (m *MockGoCloakClient) GetRoleMappingByGroupID(ctx context.Context, accessT
 
 func (m *MockGoCloakClient) GetGroups(ctx context.Context, accessToken, realm string,
 	params gocloak.GetGroupsParams) ([]*gocloak.Group, error) {
+	params.First, params.Max = nil, nil
 	called := m.Called(realm, params)
 	if err := called.Error(1); err != nil {
 		return nil, err
@@ -265,6 +287,15 @@ func (m *MockGoCloakClient) GetGroups(ctx context.Context, accessToken, realm st
 	return called.Get(0).([]*gocloak.Group), nil
 }
 
+func (m *MockGoCloakClient) GetGroup(ctx context.Context, token, realm, groupID string) (*gocloak.Group, error) {
+	called := m.Called(realm, groupID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*gocloak.Group), nil
+}
+
 func (m *MockGoCloakClient) DeleteGroup(ctx context.Context, accessToken, realm, groupID string) error {
 	return m.Called(realm, groupID).Error(0)
 }
@@ -315,3 +346,260 @@ func (m *MockGoCloakClient) GetClientsDefaultScopes(ctx context.Context, token,
 func (m *MockGoCloakClient) AddDefaultScopeToClient(ctx context.Context, token, realm, clientID, scopeID string) error {
 	return m.Called(realm, clientID, scopeID).Error(0)
 }
+
+func (m *MockGoCloakClient) RemoveDefaultScopeFromClient(ctx context.Context, token, realm, clientID, scopeID string) error {
+	return m.Called(realm, clientID, scopeID).Error(0)
+}
+
+func (m *MockGoCloakClient) GetClientsOptionalScopes(ctx context.Context, token, realm, clientID string) ([]*gocloak.ClientScope, error) {
+	called := m.Called(realm, clientID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.ClientScope), nil
+}
+
+func (m *MockGoCloakClient) AddOptionalScopeToClient(ctx context.Context, token, realm, clientID, scopeID string) error {
+	return m.Called(realm, clientID, scopeID).Error(0)
+}
+
+func (m *MockGoCloakClient) RemoveOptionalScopeFromClient(ctx context.Context, token, realm, clientID, scopeID string) error {
+	return m.Called(realm, clientID, scopeID).Error(0)
+}
+
+func (m *MockGoCloakClient) GetClientScopeMappings(ctx context.Context, token, realm, idOfClient string) (*gocloak.MappingsRepresentation, error) {
+	called := m.Called(realm, idOfClient)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*gocloak.MappingsRepresentation), nil
+}
+
+func (m *MockGoCloakClient) CreateClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string, roles []gocloak.Role) error {
+	return m.Called(realm, idOfClient, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientScopeMappingsRealmRoles(ctx context.Context, token, realm, idOfClient string, roles []gocloak.Role) error {
+	return m.Called(realm, idOfClient, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) CreateClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient, idOfSelectedClient string,
+	roles []gocloak.Role) error {
+	return m.Called(realm, idOfClient, idOfSelectedClient, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClient, idOfSelectedClient string,
+	roles []gocloak.Role) error {
+	return m.Called(realm, idOfClient, idOfSelectedClient, roles).Error(0)
+}
+
+func (m *MockGoCloakClient) GetUsersByClientRoleName(ctx context.Context, token, realm, idOfClient, roleName string,
+	params gocloak.GetUsersByRoleParams) ([]*gocloak.User, error) {
+	called := m.Called(realm, idOfClient, roleName)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.User), nil
+}
+
+func (m *MockGoCloakClient) GetRealmRoles(ctx context.Context, token, realm string,
+	params gocloak.GetRoleParams) ([]*gocloak.Role, error) {
+	called := m.Called(realm, params)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.Role), nil
+}
+
+func (m *MockGoCloakClient) ExecuteActionsEmail(ctx context.Context, token, realm string,
+	params gocloak.ExecuteActionsEmail) error {
+	return m.Called(realm, params).Error(0)
+}
+
+func (m *MockGoCloakClient) GetCredentials(ctx context.Context, token, realm,
+	userID string) ([]*gocloak.CredentialRepresentation, error) {
+	called := m.Called(realm, userID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.CredentialRepresentation), nil
+}
+
+func (m *MockGoCloakClient) DeleteCredentials(ctx context.Context, token, realm, userID, credentialID string) error {
+	return m.Called(realm, userID, credentialID).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteUser(ctx context.Context, token, realm, userID string) error {
+	return m.Called(realm, userID).Error(0)
+}
+
+func (m *MockGoCloakClient) SetPassword(ctx context.Context, token, userID, realm, password string, temporary bool) error {
+	return m.Called(userID, realm, password, temporary).Error(0)
+}
+
+func (m *MockGoCloakClient) GetRealmRolesByUserID(ctx context.Context, token, realm, userID string) ([]*gocloak.Role, error) {
+	called := m.Called(realm, userID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.Role), nil
+}
+
+func (m *MockGoCloakClient) GetUserGroups(ctx context.Context, token, realm, userID string,
+	params gocloak.GetGroupsParams) ([]*gocloak.Group, error) {
+	params.First, params.Max = nil, nil
+	called := m.Called(realm, userID, params)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.Group), nil
+}
+
+func (m *MockGoCloakClient) AddUserToGroup(ctx context.Context, token, realm, userID, groupID string) error {
+	return m.Called(realm, userID, groupID).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteUserFromGroup(ctx context.Context, token, realm, userID, groupID string) error {
+	return m.Called(realm, userID, groupID).Error(0)
+}
+
+func (m *MockGoCloakClient) CreateComponent(ctx context.Context, token, realm string,
+	component gocloak.Component) (string, error) {
+	called := m.Called(realm, component)
+	if err := called.Error(1); err != nil {
+		return "", err
+	}
+
+	return called.String(0), nil
+}
+
+func (m *MockGoCloakClient) GetComponents(ctx context.Context, token, realm string) ([]*gocloak.Component, error) {
+	called := m.Called(realm)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.Component), nil
+}
+
+func (m *MockGoCloakClient) GetComponentsWithParams(ctx context.Context, token, realm string,
+	params gocloak.GetComponentsParams) ([]*gocloak.Component, error) {
+	called := m.Called(realm, params)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.Component), nil
+}
+
+func (m *MockGoCloakClient) UpdateComponent(ctx context.Context, token, realm string, component gocloak.Component) error {
+	return m.Called(realm, component).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteComponent(ctx context.Context, token, realm, componentID string) error {
+	return m.Called(realm, componentID).Error(0)
+}
+
+func (m *MockGoCloakClient) GetRequiredAction(ctx context.Context, token, realm,
+	alias string) (*gocloak.RequiredActionProviderRepresentation, error) {
+	called := m.Called(realm, alias)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(*gocloak.RequiredActionProviderRepresentation), nil
+}
+
+func (m *MockGoCloakClient) RegisterRequiredAction(ctx context.Context, token, realm string,
+	requiredAction gocloak.RequiredActionProviderRepresentation) error {
+	return m.Called(realm, requiredAction).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteClientScopeProtocolMapper(ctx context.Context, token, realm, scopeID,
+	protocolMapperID string) error {
+	return m.Called(realm, scopeID, protocolMapperID).Error(0)
+}
+
+func (m *MockGoCloakClient) GetAuthenticationFlows(ctx context.Context, token,
+	realm string) ([]*gocloak.AuthenticationFlowRepresentation, error) {
+	called := m.Called(realm)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.AuthenticationFlowRepresentation), nil
+}
+
+func (m *MockGoCloakClient) DeleteAuthenticationFlow(ctx context.Context, token, realm, flowID string) error {
+	return m.Called(realm, flowID).Error(0)
+}
+
+func (m *MockGoCloakClient) GetAuthenticationExecutions(ctx context.Context, token, realm,
+	flow string) ([]*gocloak.ModifyAuthenticationExecutionRepresentation, error) {
+	called := m.Called(realm, flow)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.ModifyAuthenticationExecutionRepresentation), nil
+}
+
+func (m *MockGoCloakClient) UpdateAuthenticationExecution(ctx context.Context, token, realm, flow string,
+	execution gocloak.ModifyAuthenticationExecutionRepresentation) error {
+	return m.Called(realm, flow, execution).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteAuthenticationExecution(ctx context.Context, token, realm, executionID string) error {
+	return m.Called(realm, executionID).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteIdentityProvider(ctx context.Context, token, realm, alias string) error {
+	return m.Called(realm, alias).Error(0)
+}
+
+func (m *MockGoCloakClient) ImportIdentityProviderConfig(ctx context.Context, token, realm, fromURL,
+	providerID string) (map[string]string, error) {
+	called := m.Called(realm, fromURL, providerID)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).(map[string]string), nil
+}
+
+func (m *MockGoCloakClient) CreateIdentityProviderMapper(ctx context.Context, token, realm, alias string,
+	mapper gocloak.IdentityProviderMapper) (string, error) {
+	called := m.Called(realm, alias, mapper)
+	if err := called.Error(1); err != nil {
+		return "", err
+	}
+
+	return called.String(0), nil
+}
+
+func (m *MockGoCloakClient) UpdateIdentityProviderMapper(ctx context.Context, token, realm, alias string,
+	mapper gocloak.IdentityProviderMapper) error {
+	return m.Called(realm, alias, mapper).Error(0)
+}
+
+func (m *MockGoCloakClient) DeleteIdentityProviderMapper(ctx context.Context, token, realm, alias,
+	mapperID string) error {
+	return m.Called(realm, alias, mapperID).Error(0)
+}
+
+func (m *MockGoCloakClient) GetIdentityProviderMappers(ctx context.Context, token, realm,
+	alias string) ([]*gocloak.IdentityProviderMapper, error) {
+	called := m.Called(realm, alias)
+	if err := called.Error(1); err != nil {
+		return nil, err
+	}
+
+	return called.Get(0).([]*gocloak.IdentityProviderMapper), nil
+}
@@ -0,0 +1,85 @@
+package adapter
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	retryCount       = 3
+	retryWaitTime    = 500 * time.Millisecond
+	retryMaxWaitTime = 10 * time.Second
+)
+
+// SetRetries makes the resty client retry the requests failed with the transient 429, 502, 503 and 504 responses,
+// e.g. while keycloak restarts, with the exponential backoff. The Retry-After header of the response is honored
+// up to the max wait time. The non-idempotent requests are retried only on the 429 and 503 responses,
+// which mean that keycloak has not handled the request.
+func SetRetries(restyClient *resty.Client) {
+	restyClient.
+		SetRetryCount(retryCount).
+		SetRetryWaitTime(retryWaitTime).
+		SetRetryMaxWaitTime(retryMaxWaitTime).
+		SetRetryAfter(retryAfter).
+		AddRetryCondition(isTransientFailure)
+}
+
+func newRestyClient() *resty.Client {
+	c := resty.New()
+	SetRetries(c)
+
+	return c
+}
+
+func isTransientFailure(rsp *resty.Response, err error) bool {
+	if err != nil || rsp == nil || rsp.Request == nil {
+		return false
+	}
+
+	// the health checks are not retried to fail over quickly
+	if IsHealthCheck(rsp.Request.Context()) {
+		return false
+	}
+
+	switch rsp.StatusCode() {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(rsp.Request.Method)
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// retryAfter returns the wait time from the Retry-After header in seconds or as the HTTP date,
+// the zero duration means the exponential backoff.
+func retryAfter(_ *resty.Client, rsp *resty.Response) (time.Duration, error) {
+	header := rsp.Header().Get("Retry-After")
+	if header == "" {
+		return 0, nil
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, nil
+		}
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,80 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRetries(t *testing.T) {
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		switch r.URL.Path {
+		case "/restarting":
+			if calls == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusServiceUnavailable)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	restyClient := resty.New()
+	SetRetries(restyClient)
+
+	started := time.Now()
+
+	rsp, err := restyClient.R().Get(server.URL + "/restarting")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode())
+	assert.Equal(t, 2, calls)
+	assert.GreaterOrEqual(t, time.Since(started), time.Second, "Retry-After must be honored")
+
+	calls = 0
+
+	rsp, err = restyClient.R().Post(server.URL + "/create")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, rsp.StatusCode())
+	assert.Equal(t, 1, calls, "non-idempotent request must not be retried on 502")
+
+	calls = 0
+
+	err = CheckHealth(context.Background(), restyClient, server.URL)
+	require.Error(t, err)
+	assert.Equal(t, 1, calls, "health check must not be retried")
+}
+
+func TestRetryAfter(t *testing.T) {
+	rsp := &resty.Response{RawResponse: &http.Response{Header: http.Header{}}}
+
+	wait, err := retryAfter(nil, rsp)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+
+	rsp.RawResponse.Header.Set("Retry-After", "5")
+
+	wait, err = retryAfter(nil, rsp)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, wait)
+
+	rsp.RawResponse.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+
+	wait, err = retryAfter(nil, rsp)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Minute, wait, float64(2*time.Second))
+}
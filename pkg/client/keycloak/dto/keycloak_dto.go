@@ -4,7 +4,15 @@ import (
 	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
 )
 
-const defaultClientProtocol = "openid-connect"
+const (
+	defaultClientProtocol = "openid-connect"
+
+	// SAMLClientProtocol is the protocol of the SAML clients.
+	SAMLClientProtocol = "saml"
+
+	// SignedJWTClientAuthenticator is the authenticator of the clients which use JWT signed by the client private key.
+	SignedJWTClientAuthenticator = "client-jwt"
+)
 
 type Keycloak struct {
 	Url  string
@@ -35,12 +43,15 @@ func ConvertSpecToRole(roleInstance *keycloakApi.KeycloakRealmRole) *PrimaryReal
 		Attributes:  roleInstance.Spec.Attributes,
 		Composites:  make([]string, 0, len(roleInstance.Spec.Composites)),
 		IsDefault:   roleInstance.Spec.IsDefault,
+		AddOnly:     roleInstance.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly,
 	}
 
 	for _, comp := range roleInstance.Spec.Composites {
 		rr.Composites = append(rr.Composites, comp.Name)
 	}
 
+	rr.CompositesClientRoles = convertCompositesClientRoles(roleInstance.Spec.CompositesClientRoles)
+
 	if roleInstance.Status.ID != "" {
 		rr.ID = &roleInstance.Status.ID
 	}
@@ -66,30 +77,113 @@ func ConvertSpecToRealm(spec *keycloakApi.KeycloakRealmSpec) *Realm {
 }
 
 type Client struct {
-	ID                      string
-	ClientId                string
-	ClientSecret            string `json:"-"`
-	RealmName               string
-	Roles                   []string
-	RealmRole               IncludedRealmRole // what this for ? does not used anywhere
-	Public                  bool
-	DirectAccess            bool
+	ID           string
+	ClientId     string
+	ClientSecret string `json:"-"`
+	RealmName    string
+	Roles        []string
+	RealmRole    IncludedRealmRole // what this for ? does not used anywhere
+	// WebUrl is sent as the root and admin URL and as the default redirect URI and web origin if it is set.
 	WebUrl                  string
 	Protocol                string
 	Attributes              map[string]string
 	AdvancedProtocolMappers bool
-	ServiceAccountEnabled   bool
-	FrontChannelLogout      bool
+	AuthorizationEnabled    bool
+	ClientAuthenticatorType string
+	RedirectURIs            []string
+	WebOrigins              []string
+	// The following fields are sent to keycloak only if they are set.
+	Public                    *bool
+	DirectAccess              *bool
+	ServiceAccountEnabled     *bool
+	FrontChannelLogout        *bool
+	FullScopeAllowed          *bool
+	Name                      *string
+	Description               *string
+	Enabled                   *bool
+	BaseURL                   *string
+	AdminURL                  *string
+	ConsentRequired           *bool
+	AlwaysDisplayInConsole    *bool
+	StandardFlowEnabled       *bool
+	ImplicitFlowEnabled       *bool
+	BearerOnly                *bool
+	SurrogateAuthRequired     *bool
+	NodeReRegistrationTimeout *int32
 }
 
 type PrimaryRealmRole struct {
-	ID          *string
-	Name        string
-	Composites  []string
-	IsComposite bool
-	Description string
-	Attributes  map[string][]string
-	IsDefault   bool
+	ID                    *string
+	Name                  string
+	Composites            []string
+	CompositesClientRoles map[string][]string
+	IsComposite           bool
+	Description           string
+	Attributes            map[string][]string
+	IsDefault             bool
+	// AddOnly disables removal of the member roles and the attributes which are not declared.
+	AddOnly bool
+	// Adopt allows taking over the existing role which has no id, otherwise DuplicatedError is returned.
+	Adopt bool
+	// UnresolvedComposites is filled during the sync with the declared member roles which do not exist.
+	UnresolvedComposites []string
+}
+
+type ClientRole struct {
+	ID                    *string
+	Name                  string
+	ClientID              string
+	Composites            []string
+	CompositesClientRoles map[string][]string
+	IsComposite           bool
+	Description           string
+	Attributes            map[string][]string
+	// AddOnly disables removal of the member roles and the attributes which are not declared.
+	AddOnly bool
+	// Adopt allows taking over the existing role which has no id, otherwise DuplicatedError is returned.
+	Adopt bool
+	// UnresolvedComposites is filled during the sync with the declared member roles which do not exist.
+	UnresolvedComposites []string
+}
+
+func ConvertSpecToClientRole(roleInstance *keycloakApi.KeycloakClientRole) *ClientRole {
+	cr := ClientRole{
+		Name:        roleInstance.Spec.Name,
+		ClientID:    roleInstance.Spec.ClientID,
+		Description: roleInstance.Spec.Description,
+		IsComposite: roleInstance.Spec.Composite,
+		Attributes:  roleInstance.Spec.Attributes,
+		Composites:  make([]string, 0, len(roleInstance.Spec.Composites)),
+		AddOnly:     roleInstance.GetReconciliationStrategy() == keycloakApi.ReconciliationStrategyAddOnly,
+	}
+
+	for _, comp := range roleInstance.Spec.Composites {
+		cr.Composites = append(cr.Composites, comp.Name)
+	}
+
+	cr.CompositesClientRoles = convertCompositesClientRoles(roleInstance.Spec.CompositesClientRoles)
+
+	if roleInstance.Status.ID != "" {
+		cr.ID = &roleInstance.Status.ID
+	}
+
+	return &cr
+}
+
+func convertCompositesClientRoles(in map[string][]keycloakApi.Composite) map[string][]string {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make(map[string][]string, len(in))
+
+	for clientID, composites := range in {
+		for _, comp := range composites {
+			out[clientID] = append(out[clientID], comp.Name)
+		}
+	}
+
+	return out
 }
 
 type IncludedRealmRole struct {
@@ -99,21 +193,44 @@ type IncludedRealmRole struct {
 
 func ConvertSpecToClient(spec *keycloakApi.KeycloakClientSpec, clientSecret string) *Client {
 	return &Client{
-		RealmName:               spec.TargetRealm,
-		ClientId:                spec.ClientId,
-		ClientSecret:            clientSecret,
-		Roles:                   spec.ClientRoles,
-		Public:                  spec.Public,
-		DirectAccess:            spec.DirectAccess,
-		WebUrl:                  spec.WebUrl,
-		Protocol:                getValueOrDefault(spec.Protocol),
-		Attributes:              spec.Attributes,
-		AdvancedProtocolMappers: spec.AdvancedProtocolMappers,
-		ServiceAccountEnabled:   spec.ServiceAccount != nil && spec.ServiceAccount.Enabled,
-		FrontChannelLogout:      spec.FrontChannelLogout,
+		RealmName:                 spec.TargetRealm,
+		ClientId:                  spec.ClientId,
+		ClientSecret:              clientSecret,
+		Roles:                     spec.ClientRoles,
+		Public:                    spec.Public,
+		DirectAccess:              spec.DirectAccess,
+		WebUrl:                    spec.WebUrl,
+		Protocol:                  getValueOrDefault(spec.Protocol),
+		Attributes:                spec.Attributes,
+		AdvancedProtocolMappers:   spec.AdvancedProtocolMappers,
+		ServiceAccountEnabled:     serviceAccountEnabled(spec.ServiceAccount),
+		FrontChannelLogout:        spec.FrontChannelLogout,
+		AuthorizationEnabled:      spec.Authorization != nil || spec.AuthorizationSettingsRef != nil,
+		FullScopeAllowed:          spec.FullScopeAllowed,
+		ClientAuthenticatorType:   spec.ClientAuthenticatorType,
+		Name:                      spec.Name,
+		Description:               spec.Description,
+		Enabled:                   spec.Enabled,
+		BaseURL:                   spec.BaseURL,
+		AdminURL:                  spec.AdminURL,
+		ConsentRequired:           spec.ConsentRequired,
+		AlwaysDisplayInConsole:    spec.AlwaysDisplayInConsole,
+		StandardFlowEnabled:       spec.StandardFlowEnabled,
+		ImplicitFlowEnabled:       spec.ImplicitFlowEnabled,
+		BearerOnly:                spec.BearerOnly,
+		SurrogateAuthRequired:     spec.SurrogateAuthRequired,
+		NodeReRegistrationTimeout: spec.NodeReRegistrationTimeout,
 	}
 }
 
+func serviceAccountEnabled(sa *keycloakApi.ServiceAccount) *bool {
+	if sa == nil {
+		return nil
+	}
+
+	return &sa.Enabled
+}
+
 func getValueOrDefault(protocol *string) string {
 	if protocol == nil {
 		return defaultClientProtocol
@@ -35,3 +35,44 @@ func TestConvertSpecToClient(t *testing.T) {
 		t.Fatal("sso realm enabled must be false when in spec is false")
 	}
 }
+
+func TestConvertSpecToClient_OptionalFields(t *testing.T) {
+	c := ConvertSpecToClient(&keycloakApi.KeycloakClientSpec{ClientId: "client"}, "")
+	require.Nil(t, c.Name)
+	require.Nil(t, c.ConsentRequired)
+	require.Nil(t, c.AlwaysDisplayInConsole)
+	require.Nil(t, c.Public)
+	require.Nil(t, c.DirectAccess)
+	require.Nil(t, c.ServiceAccountEnabled)
+	require.Nil(t, c.FrontChannelLogout)
+
+	description := "description"
+	consent := true
+	c = ConvertSpecToClient(&keycloakApi.KeycloakClientSpec{
+		ClientId:        "client",
+		Description:     &description,
+		ConsentRequired: &consent,
+		ServiceAccount:  &keycloakApi.ServiceAccount{},
+	}, "")
+	require.Equal(t, &description, c.Description)
+	require.Equal(t, &consent, c.ConsentRequired)
+	require.False(t, *c.ServiceAccountEnabled)
+}
+
+func TestConvertSpecToRole_Composites(t *testing.T) {
+	role := ConvertSpecToRole(&keycloakApi.KeycloakRealmRole{
+		Spec: keycloakApi.KeycloakRealmRoleSpec{
+			Name:       "composite",
+			Composite:  true,
+			Composites: []keycloakApi.Composite{{Name: "realm-role"}},
+			CompositesClientRoles: map[string][]keycloakApi.Composite{
+				"app": {{Name: "viewer"}, {Name: "editor"}},
+			},
+			ReconciliationStrategy: keycloakApi.ReconciliationStrategyAddOnly,
+		},
+	})
+
+	require.Equal(t, []string{"realm-role"}, role.Composites)
+	require.Equal(t, map[string][]string{"app": {"viewer", "editor"}}, role.CompositesClientRoles)
+	require.True(t, role.AddOnly)
+}
@@ -15,12 +15,15 @@ type Client interface {
 	KCloakUsers
 	KCloakRealms
 	KCloakClients
+	KCloakClientAuthorization
 	KCloakRealmRoles
 	KCloakClientRoles
 	KAuthFlow
 	KCloakComponents
 	KCloakClientScope
 	KIdentityProvider
+	KOrganization
+	KRequiredAction
 
 	ExistCentralIdentityProvider(realm *dto.Realm) (bool, error)
 	CreateCentralIdentityProvider(realm *dto.Realm, client *dto.Client) error
@@ -30,6 +33,25 @@ type Client interface {
 		clientRoles map[string][]string, addOnly bool) error
 	SetServiceAccountAttributes(realm, clientID string, attributes map[string]string, addOnly bool) error
 	ExportToken() ([]byte, error)
+	GetServerVersion(ctx context.Context) (string, error)
+	GetServerThemes(ctx context.Context) (adapter.ServerThemes, error)
+	GetServerPasswordPolicies(ctx context.Context) ([]string, error)
+}
+
+type KRequiredAction interface {
+	GetRequiredAction(ctx context.Context, realm, alias string) (*adapter.RequiredAction, error)
+	RegisterRequiredAction(ctx context.Context, realm, providerID, name string) error
+	UpdateRequiredAction(ctx context.Context, realm string, action *adapter.RequiredAction) error
+}
+
+type KOrganization interface {
+	GetOrganization(ctx context.Context, realm, id string) (*adapter.Organization, error)
+	GetOrganizationByName(ctx context.Context, realm, name string) (*adapter.Organization, error)
+	CreateOrganization(ctx context.Context, realm string, org *adapter.Organization) (string, error)
+	UpdateOrganization(ctx context.Context, realm string, org *adapter.Organization) error
+	DeleteOrganization(ctx context.Context, realm, id string) error
+	SyncOrganizationIdentityProviders(ctx context.Context, realm, orgID string, aliases []string) error
+	SyncOrganizationMembers(ctx context.Context, realm, orgID string, usernames []string) error
 }
 
 type KIdentityProvider interface {
@@ -38,6 +60,7 @@ type KIdentityProvider interface {
 	GetIdentityProvider(ctx context.Context, realm, alias string) (*adapter.IdentityProvider, error)
 	IdentityProviderExists(ctx context.Context, realm, alias string) (bool, error)
 	DeleteIdentityProvider(ctx context.Context, realm, alias string) error
+	ImportIdentityProviderConfig(ctx context.Context, realm, providerID, fromURL string) (map[string]string, error)
 
 	CreateIDPMapper(ctx context.Context, realm, idpAlias string, mapper *adapter.IdentityProviderMapper) (string, error)
 	UpdateIDPMapper(ctx context.Context, realm, idpAlias string, mapper *adapter.IdentityProviderMapper) error
@@ -49,10 +72,12 @@ type KAuthFlow interface {
 	SyncAuthFlow(realmName string, flow *adapter.KeycloakAuthFlow) error
 	DeleteAuthFlow(realmName string, flow *adapter.KeycloakAuthFlow) error
 	SetRealmBrowserFlow(realmName string, flowAlias string) error
+	AuthFlowExists(realmName, flowAlias string) (bool, error)
 }
 
 type KCloakGroups interface {
-	SyncRealmGroup(realm string, spec *keycloakApi.KeycloakRealmGroupSpec) (string, error)
+	SyncRealmGroup(realm string, spec *keycloakApi.KeycloakRealmGroupSpec, groupID string) (string, error)
+	GetGroupByPath(realm, groupPath string) (*gocloak.Group, error)
 	DeleteGroup(ctx context.Context, realm, groupName string) error
 }
 
@@ -61,6 +86,12 @@ type KCloakUsers interface {
 	CreateRealmUser(realmName string, user *dto.User) error
 	SyncRealmUser(ctx context.Context, realmName string, user *adapter.KeycloakUser, addOnly bool) error
 	DeleteRealmUser(ctx context.Context, realmName, username string) error
+	ExecuteActionsEmail(ctx context.Context, realmName, username string, actions []string) error
+	ListUserCredentials(ctx context.Context, realmName, username string) ([]string, error)
+	RemoveUserCredentials(ctx context.Context, realmName, username string, credentialTypes []string) ([]string, error)
+	ResetUserCredentials(ctx context.Context, realmName, username string, credentialTypes []string) ([]string, error)
+	GetUserProfileConfig(ctx context.Context, realmName string) (*adapter.UserProfileConfig, error)
+	UpdateUserProfileConfig(ctx context.Context, realmName string, cfg *adapter.UserProfileConfig) error
 }
 
 type KCloakRealms interface {
@@ -70,6 +101,15 @@ type KCloakRealms interface {
 	SyncRealmIdentityProviderMappers(realmName string, mappers []dto.IdentityProviderMapper) error
 	UpdateRealmSettings(realmName string, realmSettings *adapter.RealmSettings) error
 	SetRealmEventConfig(realmName string, eventConfig *adapter.RealmEventConfig) error
+	SyncRealmLocalizationTexts(ctx context.Context, realmName, locale string, texts map[string]string) error
+	GetClientProfiles(ctx context.Context, realmName string) (*adapter.ClientProfiles, error)
+	UpdateClientProfiles(ctx context.Context, realmName string, profiles *adapter.ClientProfiles) error
+	GetClientPolicies(ctx context.Context, realmName string) (*adapter.ClientPolicies, error)
+	UpdateClientPolicies(ctx context.Context, realmName string, policies *adapter.ClientPolicies) error
+	PartialImport(ctx context.Context, realm string, representation map[string]interface{},
+		ifResourceExists string) (*adapter.PartialImportResult, error)
+	DeleteImportedResource(ctx context.Context, realm string, resource *adapter.PartialImportResultItem) error
+	ExportRealm(ctx context.Context, realm string) (*adapter.RealmExport, error)
 }
 
 type KCloakClients interface {
@@ -81,6 +121,39 @@ type KCloakClients interface {
 		client *dto.Client, crMappers []gocloak.ProtocolMapperRepresentation, addOnly bool) error
 	GetClientID(clientID, realm string) (string, error)
 	AddDefaultScopeToClient(ctx context.Context, realmName, clientName string, scopes []adapter.ClientScope) error
+	AddOptionalScopeToClient(ctx context.Context, realmName, clientName string, scopes []adapter.ClientScope) error
+	GetClientDefaultScopes(ctx context.Context, realmName, clientName string) ([]adapter.ClientScope, error)
+	GetClientOptionalScopes(ctx context.Context, realmName, clientName string) ([]adapter.ClientScope, error)
+	RemoveDefaultScopeFromClient(ctx context.Context, realmName, clientName string, scopes []adapter.ClientScope) error
+	RemoveOptionalScopeFromClient(ctx context.Context, realmName, clientName string, scopes []adapter.ClientScope) error
+	SyncClientScopeMappings(ctx context.Context, realm, clientID string, realmRoles []string,
+		clientRoles map[string][]string, addOnly bool) error
+}
+
+type KCloakClientAuthorization interface {
+	UpdateResourceServerSettings(ctx context.Context, realmName, clientID string,
+		settings *adapter.ResourceServerSettings) error
+	ImportAuthzSettings(ctx context.Context, realmName, clientID string, settings []byte) error
+
+	GetAuthzScopes(ctx context.Context, realmName, clientID string) ([]adapter.AuthzScope, error)
+	CreateAuthzScope(ctx context.Context, realmName, clientID string, scope *adapter.AuthzScope) error
+	UpdateAuthzScope(ctx context.Context, realmName, clientID string, scope *adapter.AuthzScope) error
+	DeleteAuthzScope(ctx context.Context, realmName, clientID, scopeID string) error
+
+	GetAuthzResources(ctx context.Context, realmName, clientID string) ([]adapter.AuthzResource, error)
+	CreateAuthzResource(ctx context.Context, realmName, clientID string, resource *adapter.AuthzResource) error
+	UpdateAuthzResource(ctx context.Context, realmName, clientID string, resource *adapter.AuthzResource) error
+	DeleteAuthzResource(ctx context.Context, realmName, clientID, resourceID string) error
+
+	GetAuthzPolicies(ctx context.Context, realmName, clientID string) ([]adapter.AuthzPolicy, error)
+	CreateAuthzPolicy(ctx context.Context, realmName, clientID string, policy *adapter.AuthzPolicy) error
+	UpdateAuthzPolicy(ctx context.Context, realmName, clientID string, policy *adapter.AuthzPolicy) error
+	DeleteAuthzPolicy(ctx context.Context, realmName, clientID, policyID string) error
+
+	GetAuthzPermissions(ctx context.Context, realmName, clientID string) ([]adapter.AuthzPolicy, error)
+	CreateAuthzPermission(ctx context.Context, realmName, clientID string, permission *adapter.AuthzPolicy) error
+	UpdateAuthzPermission(ctx context.Context, realmName, clientID string, permission *adapter.AuthzPolicy) error
+	DeleteAuthzPermission(ctx context.Context, realmName, clientID, permissionID string) error
 }
 
 type KCloakClientScope interface {
@@ -90,6 +163,7 @@ type KCloakClientScope interface {
 	UpdateClientScope(ctx context.Context, realmName, scopeID string, scope *adapter.ClientScope) error
 	DeleteClientScope(ctx context.Context, realmName, scopeID string) error
 	GetDefaultClientScopesForRealm(ctx context.Context, realm string) ([]adapter.ClientScope, error)
+	SyncRealmDefaultClientScopes(ctx context.Context, realmName string, defaultScopes, optionalScopes []string) error
 	CreateClientScope(ctx context.Context, realmName string, scope *adapter.ClientScope) (string, error)
 	GetClientScopeMappers(ctx context.Context, realmName, scopeID string) ([]adapter.ProtocolMapper, error)
 }
@@ -102,6 +176,9 @@ type KCloakRealmRoles interface {
 	AddRealmRoleToUser(ctx context.Context, realmName, username, roleName string) error
 	SyncRealmRole(realmName string, role *dto.PrimaryRealmRole) error
 	DeleteRealmRole(ctx context.Context, realm, roleName string) error
+	SyncRealmDefaultRoles(ctx context.Context, realmName string, realmRoles []string,
+		clientRoles map[string][]string) ([]string, error)
+	GetRealmRoleUsage(ctx context.Context, realmName, roleName string) (*adapter.RoleUsage, error)
 }
 
 type KCloakClientRoles interface {
@@ -109,6 +186,9 @@ type KCloakClientRoles interface {
 	CreateClientRole(role *dto.Client, clientRole string) error
 	HasUserClientRole(realmName string, clientId string, user *dto.User, role string) (bool, error)
 	AddClientRoleToUser(realmName string, clientId string, user *dto.User, role string) error
+	SyncClientRole(ctx context.Context, realmName string, role *dto.ClientRole) error
+	GetClientRoleUsage(ctx context.Context, realmName, clientID, roleName string) (*adapter.RoleUsage, error)
+	DeleteClientRole(ctx context.Context, realmName, clientID, roleName string) error
 }
 
 type KCloakComponents interface {
@@ -116,4 +196,7 @@ type KCloakComponents interface {
 	UpdateComponent(ctx context.Context, realmName string, component *adapter.Component) error
 	DeleteComponent(ctx context.Context, realmName, componentName string) error
 	GetComponent(ctx context.Context, realmName, componentName string) (*adapter.Component, error)
+	GetComponents(ctx context.Context, realmName, providerType string) ([]adapter.Component, error)
+	DeleteComponentByID(ctx context.Context, realmName, componentID string) error
+	SyncUserStorage(ctx context.Context, realmName, componentID, action string) (*adapter.UserStorageSyncResult, error)
 }
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// Command is a name of the export subcommand of the operator binary.
+const Command = "export"
+
+// passwordEnv is an environment variable with the password or the client secret used if the flag is not set.
+const passwordEnv = "KEYCLOAK_PASSWORD"
+
+// RealmExporter exports the realm from keycloak.
+type RealmExporter interface {
+	ExportRealm(ctx context.Context, realm string) (*adapter.RealmExport, error)
+}
+
+// Connection defines the connection to keycloak of the subcommands.
+type Connection struct {
+	URL       string
+	User      string
+	Password  string
+	ClientID  string
+	AuthRealm string
+	Realm     string
+}
+
+// AddFlags adds the connection flags to the flag set.
+func (c *Connection) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.URL, "url", "", "Keycloak URL.")
+	fs.StringVar(&c.User, "user", "", "Keycloak admin user of the master realm.")
+	fs.StringVar(&c.Password, "password", "",
+		"Password of the admin user or secret of the client, "+passwordEnv+" environment variable is used by default.")
+	fs.StringVar(&c.ClientID, "client-id", "", "Client ID of the service account used instead of the admin user.")
+	fs.StringVar(&c.AuthRealm, "auth-realm", "master", "Realm of the service account client.")
+	fs.StringVar(&c.Realm, "realm", "", "Name of the keycloak realm.")
+}
+
+// Validate checks the connection flags and sets the password from the environment if it is not set.
+func (c *Connection) Validate() error {
+	if c.Password == "" {
+		c.Password = os.Getenv(passwordEnv)
+	}
+
+	switch {
+	case c.URL == "":
+		return errors.New("url is required")
+	case c.Realm == "":
+		return errors.New("realm is required")
+	case c.User == "" && c.ClientID == "":
+		return errors.New("user or client-id is required")
+	case c.User != "" && c.ClientID != "":
+		return errors.New("user and client-id can not be used together")
+	}
+
+	return nil
+}
+
+// Connect logs in to keycloak with the admin user or the service account.
+func (c *Connection) Connect(ctx context.Context) (*adapter.GoCloakAdapter, error) {
+	var (
+		kClient *adapter.GoCloakAdapter
+		err     error
+	)
+
+	if c.ClientID != "" {
+		kClient, err = adapter.MakeFromServiceAccount(ctx, c.URL, c.ClientID, c.Password, c.AuthRealm, logr.Discard(), nil)
+	} else {
+		kClient, err = adapter.Make(ctx, c.URL, c.User, c.Password, logr.Discard(), nil)
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to connect to keycloak")
+	}
+
+	return kClient, nil
+}
+
+type commandArgs struct {
+	conn Connection
+	opts Options
+}
+
+// Run runs the export subcommand with the given arguments and writes the custom resources to the out.
+func Run(ctx context.Context, args []string, out io.Writer) error {
+	cmdArgs, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	kClient, err := cmdArgs.conn.Connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	return export(ctx, kClient, cmdArgs.conn.Realm, cmdArgs.opts, out)
+}
+
+func export(ctx context.Context, kClient RealmExporter, realmName string, opts Options, out io.Writer) error {
+	realm, err := kClient.ExportRealm(ctx, realmName)
+	if err != nil {
+		return errors.Wrapf(err, "unable to export realm %s", realmName)
+	}
+
+	return WriteManifests(out, MakeManifests(realm, opts))
+}
+
+func parseArgs(args []string) (*commandArgs, error) {
+	var cmdArgs commandArgs
+
+	fs := flag.NewFlagSet(Command, flag.ContinueOnError)
+	cmdArgs.conn.AddFlags(fs)
+	fs.StringVar(&cmdArgs.opts.Namespace, "namespace", "", "Namespace of the custom resources.")
+	fs.StringVar(&cmdArgs.opts.RealmCRName, "realm-cr-name", "",
+		"Name of the KeycloakRealm custom resource, the realm name is used if it is not set.")
+	fs.StringVar(&cmdArgs.opts.KeycloakOwner, "keycloak-owner", "", "Name of the Keycloak custom resource.")
+	fs.BoolVar(&cmdArgs.opts.IncludeBuiltIn, "include-builtin", false,
+		"Export the built-in clients, roles and authentication flows.")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, errors.Wrap(err, "unable to parse arguments")
+	}
+
+	if err := cmdArgs.conn.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cmdArgs, nil
+}
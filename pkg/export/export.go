@@ -0,0 +1,487 @@
+// Package export converts a live keycloak realm to the operator custom resources.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+// maxResourceNameLength is the max length of the kubernetes resource name.
+const maxResourceNameLength = 253
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// builtInClients are the clients created by keycloak for every realm.
+var builtInClients = map[string]struct{}{
+	"account":                {},
+	"account-console":        {},
+	"admin-cli":              {},
+	"broker":                 {},
+	"realm-management":       {},
+	"security-admin-console": {},
+}
+
+// Options defines the exported custom resources.
+type Options struct {
+	// Namespace is a namespace of the custom resources.
+	Namespace string
+
+	// RealmCRName is a name of the KeycloakRealm custom resource, it is made from the realm name if it is empty.
+	RealmCRName string
+
+	// KeycloakOwner is a name of the Keycloak custom resource which owns the realm.
+	KeycloakOwner string
+
+	// IncludeBuiltIn defines whether the built-in clients, roles and authentication flows are exported.
+	IncludeBuiltIn bool
+}
+
+// MakeManifests makes the KeycloakRealm, KeycloakClient, KeycloakRealmGroup, KeycloakRealmRole
+// and KeycloakAuthFlow custom resources from the realm export.
+// The client secrets are not exported, the confidential clients reference secrets which must be created manually.
+func MakeManifests(realm *adapter.RealmExport, opts Options) []client.Object {
+	e := exporter{
+		realm: realm,
+		opts:  opts,
+		names: make(map[string]struct{}),
+	}
+
+	if e.opts.RealmCRName == "" {
+		e.opts.RealmCRName = resourceName(realm.Realm)
+	}
+
+	objects := []client.Object{e.makeRealm()}
+	objects = append(objects, e.makeRoles()...)
+	objects = append(objects, e.makeClients()...)
+	objects = append(objects, e.makeGroups()...)
+	objects = append(objects, e.makeAuthFlows()...)
+
+	return objects
+}
+
+// WriteManifests writes the custom resources as a multi-document YAML.
+func WriteManifests(w io.Writer, objects []client.Object) error {
+	for i, obj := range objects {
+		data, err := marshalManifest(obj)
+		if err != nil {
+			return errors.Wrapf(err, "unable to marshal %s", obj.GetName())
+		}
+
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return errors.Wrap(err, "unable to write manifest")
+			}
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return errors.Wrap(err, "unable to write manifest")
+		}
+	}
+
+	return nil
+}
+
+// marshalManifest marshals the custom resource to YAML without the status, the empty creation timestamp
+// and the unset lists.
+func marshalManifest(obj client.Object) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal to json")
+	}
+
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal json")
+	}
+
+	delete(manifest, "status")
+	dropNulls(manifest)
+
+	if meta, ok := manifest["metadata"].(map[string]interface{}); ok {
+		delete(meta, "creationTimestamp")
+	}
+
+	out, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to marshal to yaml")
+	}
+
+	return out, nil
+}
+
+// dropNulls removes the null fields, e.g. the unset lists which are not managed by the operator.
+func dropNulls(m map[string]interface{}) {
+	for k, v := range m {
+		switch val := v.(type) {
+		case nil:
+			delete(m, k)
+		case map[string]interface{}:
+			dropNulls(val)
+		case []interface{}:
+			for _, item := range val {
+				if im, ok := item.(map[string]interface{}); ok {
+					dropNulls(im)
+				}
+			}
+		}
+	}
+}
+
+type exporter struct {
+	realm *adapter.RealmExport
+	opts  Options
+	names map[string]struct{}
+}
+
+func (e *exporter) objectMeta(kind string, parts ...string) metav1.ObjectMeta {
+	name := resourceName(append([]string{e.opts.RealmCRName}, parts...)...)
+	unique := name
+
+	for i := 2; ; i++ {
+		if _, ok := e.names[kind+"/"+unique]; !ok {
+			break
+		}
+
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+
+	e.names[kind+"/"+unique] = struct{}{}
+
+	return metav1.ObjectMeta{Name: unique, Namespace: e.opts.Namespace}
+}
+
+func typeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{APIVersion: keycloakApi.SchemeGroupVersion.String(), Kind: kind}
+}
+
+func (e *exporter) makeRealm() client.Object {
+	realm := keycloakApi.KeycloakRealm{
+		TypeMeta:   typeMeta("KeycloakRealm"),
+		ObjectMeta: metav1.ObjectMeta{Name: e.opts.RealmCRName, Namespace: e.opts.Namespace},
+		Spec: keycloakApi.KeycloakRealmSpec{
+			RealmName:     e.realm.Realm,
+			KeycloakOwner: e.opts.KeycloakOwner,
+		},
+	}
+
+	if e.realm.BrowserFlow != "" && e.realm.BrowserFlow != "browser" {
+		realm.Spec.BrowserFlow = gocloak.StringP(e.realm.BrowserFlow)
+	}
+
+	return &realm
+}
+
+func (e *exporter) isBuiltInRole(name string) bool {
+	return name == "offline_access" || name == "uma_authorization" ||
+		name == "default-roles-"+strings.ToLower(e.realm.Realm)
+}
+
+func (e *exporter) makeRoles() []client.Object {
+	objects := make([]client.Object, 0, len(e.realm.Roles.Realm))
+
+	for i := range e.realm.Roles.Realm {
+		r := &e.realm.Roles.Realm[i]
+		name := gocloak.PString(r.Name)
+
+		if !e.opts.IncludeBuiltIn && e.isBuiltInRole(name) {
+			continue
+		}
+
+		role := keycloakApi.KeycloakRealmRole{
+			TypeMeta:   typeMeta("KeycloakRealmRole"),
+			ObjectMeta: e.objectMeta("KeycloakRealmRole", name),
+			Spec: keycloakApi.KeycloakRealmRoleSpec{
+				Name:        name,
+				Realm:       e.opts.RealmCRName,
+				Description: gocloak.PString(r.Description),
+				Composite:   gocloak.PBool(r.Composite),
+			},
+		}
+
+		if r.Attributes != nil {
+			role.Spec.Attributes = *r.Attributes
+		}
+
+		if r.Composites != nil {
+			if r.Composites.Realm != nil {
+				for _, c := range *r.Composites.Realm {
+					role.Spec.Composites = append(role.Spec.Composites, keycloakApi.Composite{Name: c})
+				}
+			}
+
+			if r.Composites.Client != nil {
+				role.Spec.CompositesClientRoles = make(map[string][]keycloakApi.Composite, len(*r.Composites.Client))
+
+				for clientID, names := range *r.Composites.Client {
+					for _, c := range names {
+						role.Spec.CompositesClientRoles[clientID] = append(role.Spec.CompositesClientRoles[clientID],
+							keycloakApi.Composite{Name: c})
+					}
+				}
+			}
+		}
+
+		objects = append(objects, &role)
+	}
+
+	return objects
+}
+
+func (e *exporter) makeClients() []client.Object {
+	objects := make([]client.Object, 0, len(e.realm.Clients))
+
+	for i := range e.realm.Clients {
+		c := &e.realm.Clients[i]
+		clientID := gocloak.PString(c.ClientID)
+
+		if _, ok := builtInClients[clientID]; ok && !e.opts.IncludeBuiltIn {
+			continue
+		}
+
+		kc := keycloakApi.KeycloakClient{
+			TypeMeta:   typeMeta("KeycloakClient"),
+			ObjectMeta: e.objectMeta("KeycloakClient", clientID),
+			Spec: keycloakApi.KeycloakClientSpec{
+				ClientId:                clientID,
+				Name:                    c.Name,
+				Description:             c.Description,
+				Enabled:                 c.Enabled,
+				TargetRealm:             e.realm.Realm,
+				Public:                  c.PublicClient,
+				WebUrl:                  gocloak.PString(c.RootURL),
+				BaseURL:                 c.BaseURL,
+				AdminURL:                c.AdminURL,
+				ConsentRequired:         c.ConsentRequired,
+				StandardFlowEnabled:     c.StandardFlowEnabled,
+				ImplicitFlowEnabled:     c.ImplicitFlowEnabled,
+				BearerOnly:              c.BearerOnly,
+				Protocol:                c.Protocol,
+				ClientAuthenticatorType: gocloak.PString(c.ClientAuthenticatorType),
+				DirectAccess:            c.DirectAccessGrantsEnabled,
+				FrontChannelLogout:      c.FrontChannelLogout,
+				FullScopeAllowed:        c.FullScopeAllowed,
+			},
+		}
+
+		if !kc.IsPublic() && !gocloak.PBool(c.BearerOnly) {
+			kc.Spec.Secret = resourceName(kc.Name, "secret")
+		}
+
+		if c.Attributes != nil {
+			kc.Spec.Attributes = *c.Attributes
+		}
+
+		if c.DefaultClientScopes != nil {
+			kc.Spec.DefaultClientScopes = *c.DefaultClientScopes
+		}
+
+		if c.OptionalClientScopes != nil {
+			kc.Spec.OptionalClientScopes = *c.OptionalClientScopes
+		}
+
+		if gocloak.PBool(c.ServiceAccountsEnabled) {
+			kc.Spec.ServiceAccount = &keycloakApi.ServiceAccount{Enabled: true}
+		}
+
+		if c.ProtocolMappers != nil {
+			mappers := make([]keycloakApi.ProtocolMapper, 0, len(*c.ProtocolMappers))
+
+			for _, m := range *c.ProtocolMappers {
+				mapper := keycloakApi.ProtocolMapper{
+					Name:           gocloak.PString(m.Name),
+					Protocol:       gocloak.PString(m.Protocol),
+					ProtocolMapper: gocloak.PString(m.ProtocolMapper),
+				}
+
+				if m.Config != nil {
+					mapper.Config = *m.Config
+				}
+
+				mappers = append(mappers, mapper)
+			}
+
+			kc.Spec.ProtocolMappers = &mappers
+		}
+
+		for _, r := range e.realm.Roles.Client[clientID] {
+			kc.Spec.ClientRoles = append(kc.Spec.ClientRoles, gocloak.PString(r.Name))
+		}
+
+		objects = append(objects, &kc)
+	}
+
+	return objects
+}
+
+// makeGroups makes the groups with their subgroups, the subgroups reference the parent group custom resources.
+func (e *exporter) makeGroups() []client.Object {
+	objects := make([]client.Object, 0, len(e.realm.Groups))
+
+	var walk func(groups []adapter.ExportGroup, parent string)
+
+	walk = func(groups []adapter.ExportGroup, parent string) {
+		for i := range groups {
+			g := &groups[i]
+
+			path := g.Path
+			if path == "" {
+				path = g.Name
+			}
+
+			group := keycloakApi.KeycloakRealmGroup{
+				TypeMeta:   typeMeta("KeycloakRealmGroup"),
+				ObjectMeta: e.objectMeta("KeycloakRealmGroup", path),
+				Spec: keycloakApi.KeycloakRealmGroupSpec{
+					Name:       g.Name,
+					Realm:      e.opts.RealmCRName,
+					Attributes: g.Attributes,
+					RealmRoles: g.RealmRoles,
+				},
+			}
+
+			if parent != "" {
+				group.Spec.ParentGroup = &keycloakApi.ParentGroup{Name: parent}
+			}
+
+			clientIDs := make([]string, 0, len(g.ClientRoles))
+			for clientID := range g.ClientRoles {
+				clientIDs = append(clientIDs, clientID)
+			}
+
+			sort.Strings(clientIDs)
+
+			for _, clientID := range clientIDs {
+				group.Spec.ClientRoles = append(group.Spec.ClientRoles, keycloakApi.ClientRole{
+					ClientID: clientID,
+					Roles:    g.ClientRoles[clientID],
+				})
+			}
+
+			objects = append(objects, &group)
+
+			walk(g.SubGroups, group.Name)
+		}
+	}
+
+	walk(e.realm.Groups, "")
+
+	return objects
+}
+
+// makeAuthFlows makes the flows, the child flows reference the parent flows by the alias.
+func (e *exporter) makeAuthFlows() []client.Object {
+	configs := make(map[string]map[string]string, len(e.realm.AuthenticatorConfig))
+	for _, c := range e.realm.AuthenticatorConfig {
+		configs[c.Alias] = c.Config
+	}
+
+	flows := make(map[string]*adapter.ExportAuthFlow, len(e.realm.AuthenticationFlows))
+	for i := range e.realm.AuthenticationFlows {
+		flows[e.realm.AuthenticationFlows[i].Alias] = &e.realm.AuthenticationFlows[i]
+	}
+
+	objects := make([]client.Object, 0, len(e.realm.AuthenticationFlows))
+
+	var walk func(flow *adapter.ExportAuthFlow, parent string, parentExecution *adapter.ExportAuthExecution)
+
+	walk = func(flow *adapter.ExportAuthFlow, parent string, parentExecution *adapter.ExportAuthExecution) {
+		af := keycloakApi.KeycloakAuthFlow{
+			TypeMeta:   typeMeta("KeycloakAuthFlow"),
+			ObjectMeta: e.objectMeta("KeycloakAuthFlow", flow.Alias),
+			Spec: keycloakApi.KeycloakAuthFlowSpec{
+				Realm:       e.opts.RealmCRName,
+				Alias:       flow.Alias,
+				Description: flow.Description,
+				ProviderID:  flow.ProviderID,
+				TopLevel:    flow.TopLevel,
+				BuiltIn:     flow.BuiltIn,
+				ParentName:  parent,
+			},
+		}
+
+		if parentExecution != nil {
+			af.Spec.ChildType = flow.ProviderID
+			if parentExecution.Authenticator != "" {
+				af.Spec.ProviderID = parentExecution.Authenticator
+			}
+		}
+
+		for i := range flow.AuthenticationExecutions {
+			ex := &flow.AuthenticationExecutions[i]
+
+			execution := keycloakApi.AuthenticationExecution{
+				Authenticator:     ex.Authenticator,
+				AuthenticatorFlow: ex.AuthenticatorFlow,
+				Priority:          ex.Priority,
+				Requirement:       ex.Requirement,
+			}
+
+			if ex.AuthenticatorFlow {
+				execution.Authenticator = ""
+				execution.Alias = ex.FlowAlias
+			}
+
+			if ex.AuthenticatorConfig != "" {
+				execution.AuthenticatorConfig = &keycloakApi.AuthenticatorConfig{
+					Alias:  ex.AuthenticatorConfig,
+					Config: configs[ex.AuthenticatorConfig],
+				}
+			}
+
+			af.Spec.AuthenticationExecutions = append(af.Spec.AuthenticationExecutions, execution)
+		}
+
+		objects = append(objects, &af)
+
+		for i := range flow.AuthenticationExecutions {
+			ex := &flow.AuthenticationExecutions[i]
+			if child, ok := flows[ex.FlowAlias]; ok && ex.AuthenticatorFlow {
+				walk(child, flow.Alias, ex)
+			}
+		}
+	}
+
+	for i := range e.realm.AuthenticationFlows {
+		flow := &e.realm.AuthenticationFlows[i]
+		if !flow.TopLevel || (flow.BuiltIn && !e.opts.IncludeBuiltIn) {
+			continue
+		}
+
+		walk(flow, "", nil)
+	}
+
+	return objects
+}
+
+// resourceName makes a valid kubernetes resource name from the parts.
+func resourceName(parts ...string) string {
+	valid := make([]string, 0, len(parts))
+
+	for _, p := range parts {
+		p = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(p), "-"), "-.")
+		if p != "" {
+			valid = append(valid, p)
+		}
+	}
+
+	name := strings.Join(valid, "-")
+
+	if len(name) > maxResourceNameLength {
+		name = strings.Trim(name[:maxResourceNameLength], "-.")
+	}
+
+	return name
+}
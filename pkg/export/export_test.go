@@ -0,0 +1,227 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+type fakeExporter struct {
+	realm *adapter.RealmExport
+	err   error
+}
+
+func (f *fakeExporter) ExportRealm(_ context.Context, _ string) (*adapter.RealmExport, error) {
+	return f.realm, f.err
+}
+
+func getTestRealmExport() *adapter.RealmExport {
+	return &adapter.RealmExport{
+		Realm:       "My_Realm",
+		BrowserFlow: "browser-otp",
+		Clients: []gocloak.Client{
+			{ClientID: gocloak.StringP("account")},
+			{
+				ClientID:                  gocloak.StringP("app"),
+				PublicClient:              gocloak.BoolP(false),
+				RootURL:                   gocloak.StringP("https://app.example.com"),
+				DirectAccessGrantsEnabled: gocloak.BoolP(true),
+				ServiceAccountsEnabled:    gocloak.BoolP(true),
+				DefaultClientScopes:       &[]string{"profile"},
+			},
+		},
+		Groups: []adapter.ExportGroup{
+			{
+				Name:        "team",
+				Path:        "/team",
+				ClientRoles: map[string][]string{"app": {"viewer"}},
+				SubGroups:   []adapter.ExportGroup{{Name: "dev", Path: "/team/dev", RealmRoles: []string{"developer"}}},
+			},
+		},
+		Roles: adapter.ExportRoles{
+			Realm: []gocloak.Role{
+				{Name: gocloak.StringP("offline_access")},
+				{Name: gocloak.StringP("default-roles-my_realm")},
+				{
+					Name:      gocloak.StringP("developer"),
+					Composite: gocloak.BoolP(true),
+					Composites: &gocloak.CompositesRepresentation{
+						Realm:  &[]string{"viewer"},
+						Client: &map[string][]string{"app": {"viewer"}},
+					},
+				},
+			},
+			Client: map[string][]gocloak.Role{"app": {{Name: gocloak.StringP("viewer")}}},
+		},
+		AuthenticationFlows: []adapter.ExportAuthFlow{
+			{Alias: "browser", ProviderID: "basic-flow", TopLevel: true, BuiltIn: true},
+			{
+				Alias:      "browser-otp",
+				ProviderID: "basic-flow",
+				TopLevel:   true,
+				AuthenticationExecutions: []adapter.ExportAuthExecution{
+					{Authenticator: "auth-cookie", Requirement: "ALTERNATIVE"},
+					{AuthenticatorFlow: true, FlowAlias: "otp forms", Requirement: "ALTERNATIVE", Priority: 1},
+				},
+			},
+			{
+				Alias:      "otp forms",
+				ProviderID: "basic-flow",
+				AuthenticationExecutions: []adapter.ExportAuthExecution{
+					{Authenticator: "conditional-user-role", AuthenticatorConfig: "otp-role", Requirement: "REQUIRED"},
+				},
+			},
+		},
+		AuthenticatorConfig: []adapter.ExportAuthenticatorConfig{
+			{Alias: "otp-role", Config: map[string]string{"condUserRole": "otp-users"}},
+		},
+	}
+}
+
+func TestMakeManifests(t *testing.T) {
+	objects := MakeManifests(getTestRealmExport(), Options{Namespace: "ns", KeycloakOwner: "main"})
+
+	names := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		assert.Equal(t, "ns", obj.GetNamespace())
+		names = append(names, obj.GetName())
+	}
+
+	assert.Equal(t, []string{"my-realm", "my-realm-developer", "my-realm-app", "my-realm-team", "my-realm-team-dev",
+		"my-realm-browser-otp", "my-realm-otp-forms"}, names)
+
+	realm, ok := objects[0].(*keycloakApi.KeycloakRealm)
+	require.True(t, ok)
+	assert.Equal(t, "My_Realm", realm.Spec.RealmName)
+	assert.Equal(t, "main", realm.Spec.KeycloakOwner)
+	assert.Equal(t, "browser-otp", *realm.Spec.BrowserFlow)
+
+	role, ok := objects[1].(*keycloakApi.KeycloakRealmRole)
+	require.True(t, ok)
+	assert.Equal(t, "my-realm", role.Spec.Realm)
+	assert.Equal(t, []keycloakApi.Composite{{Name: "viewer"}}, role.Spec.Composites)
+	assert.Equal(t, map[string][]keycloakApi.Composite{"app": {{Name: "viewer"}}}, role.Spec.CompositesClientRoles)
+
+	kc, ok := objects[2].(*keycloakApi.KeycloakClient)
+	require.True(t, ok)
+	assert.Equal(t, "My_Realm", kc.Spec.TargetRealm)
+	assert.Equal(t, "my-realm-app-secret", kc.Spec.Secret)
+	assert.Equal(t, "https://app.example.com", kc.Spec.WebUrl)
+	assert.True(t, *kc.Spec.DirectAccess)
+	assert.True(t, kc.Spec.ServiceAccount.Enabled)
+	assert.Equal(t, []string{"viewer"}, kc.Spec.ClientRoles)
+
+	subGroup, ok := objects[4].(*keycloakApi.KeycloakRealmGroup)
+	require.True(t, ok)
+	assert.Equal(t, "dev", subGroup.Spec.Name)
+	assert.Equal(t, "my-realm-team", subGroup.Spec.ParentGroup.Name)
+
+	child, ok := objects[6].(*keycloakApi.KeycloakAuthFlow)
+	require.True(t, ok)
+	assert.Equal(t, "browser-otp", child.Spec.ParentName)
+	assert.Equal(t, "basic-flow", child.Spec.ChildType)
+	assert.Equal(t, map[string]string{"condUserRole": "otp-users"},
+		child.Spec.AuthenticationExecutions[0].AuthenticatorConfig.Config)
+
+	parent, ok := objects[5].(*keycloakApi.KeycloakAuthFlow)
+	require.True(t, ok)
+	assert.Equal(t, keycloakApi.AuthenticationExecution{
+		AuthenticatorFlow: true,
+		Alias:             "otp forms",
+		Requirement:       "ALTERNATIVE",
+		Priority:          1,
+	}, parent.Spec.AuthenticationExecutions[1])
+}
+
+func TestMakeManifests_IncludeBuiltIn(t *testing.T) {
+	objects := MakeManifests(getTestRealmExport(), Options{RealmCRName: "main-realm", IncludeBuiltIn: true})
+
+	kinds := make(map[string]int)
+	for _, obj := range objects {
+		kinds[obj.GetObjectKind().GroupVersionKind().Kind]++
+	}
+
+	assert.Equal(t, 3, kinds["KeycloakRealmRole"])
+	assert.Equal(t, 2, kinds["KeycloakClient"])
+	assert.Equal(t, 3, kinds["KeycloakAuthFlow"])
+	assert.Equal(t, "main-realm", objects[0].GetName())
+}
+
+func TestExport(t *testing.T) {
+	var out bytes.Buffer
+
+	err := export(context.Background(), &fakeExporter{realm: &adapter.RealmExport{
+		Realm:  "realm1",
+		Groups: []adapter.ExportGroup{{Name: "team"}},
+	}}, "realm1", Options{}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1.edp.epam.com/v1
+kind: KeycloakRealm
+metadata:
+  name: realm1
+spec:
+  realmName: realm1
+---
+apiVersion: v1.edp.epam.com/v1
+kind: KeycloakRealmGroup
+metadata:
+  name: realm1-team
+spec:
+  name: team
+  realm: realm1
+`, out.String())
+
+	err = export(context.Background(), &fakeExporter{err: errors.New("forbidden")}, "realm1", Options{}, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to export realm realm1: forbidden")
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name: "admin user",
+			args: []string{"--url", "https://kc", "--user", "admin", "--password", "pass", "--realm", "realm1"},
+		},
+		{
+			name: "service account",
+			args: []string{"--url", "https://kc", "--client-id", "exporter", "--realm", "realm1"},
+		},
+		{
+			name:    "no realm",
+			args:    []string{"--url", "https://kc", "--user", "admin"},
+			wantErr: "realm is required",
+		},
+		{
+			name:    "user and client",
+			args:    []string{"--url", "https://kc", "--user", "admin", "--client-id", "exporter", "--realm", "realm1"},
+			wantErr: "user and client-id can not be used together",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseArgs(tt.args)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Package manager configures the controller manager of the operator.
+package manager
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	keycloakApi1alpha1 "github.com/epam/edp-keycloak-operator/api/v1/v1alpha1"
+)
+
+// LeaderElectionLock is the leader election lock of the operator without the watch label selector.
+const LeaderElectionLock = "edp-keycloak-operator-lock"
+
+// ParseDisabledControllers returns the set of the disabled controller kinds, the unknown kinds are rejected.
+func ParseDisabledControllers(value string, kinds []string) (map[string]bool, error) {
+	known := make(map[string]bool, len(kinds))
+	for _, k := range kinds {
+		known[k] = true
+	}
+
+	disabled := make(map[string]bool)
+
+	for _, k := range strings.Split(value, ",") {
+		k = strings.TrimSpace(k)
+		if k == "" {
+			continue
+		}
+
+		if !known[k] {
+			return nil, fmt.Errorf("unknown controller kind %s, supported kinds are %s", k, strings.Join(kinds, ", "))
+		}
+
+		disabled[k] = true
+	}
+
+	return disabled, nil
+}
+
+// ParseControllerNamespaces returns the namespaces watched by the controller kinds. The value is a semicolon
+// separated list of the kinds with the comma separated namespaces,
+// e.g. KeycloakRealmUser=team-a,team-b;KeycloakClient=team-c.
+// The denied namespaces are removed from the lists, the unknown kinds are rejected.
+func ParseControllerNamespaces(value string, kinds, denyNamespaces []string) (map[string][]string, error) {
+	known := make(map[string]bool, len(kinds))
+	for _, k := range kinds {
+		known[k] = true
+	}
+
+	denied := make(map[string]bool, len(denyNamespaces))
+	for _, n := range denyNamespaces {
+		denied[n] = true
+	}
+
+	controllerNamespaces := make(map[string][]string)
+
+	for _, item := range strings.Split(value, ";") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+
+		kind, list, ok := strings.Cut(item, "=")
+		kind = strings.TrimSpace(kind)
+
+		if !ok {
+			return nil, fmt.Errorf("namespaces of the controller kind %s must be set as %s=namespace,...", kind, kind)
+		}
+
+		if !known[kind] {
+			return nil, fmt.Errorf("unknown controller kind %s, supported kinds are %s", kind, strings.Join(kinds, ", "))
+		}
+
+		var namespaces []string
+
+		for _, n := range strings.Split(list, ",") {
+			if n = strings.TrimSpace(n); n != "" && !denied[n] {
+				namespaces = append(namespaces, n)
+			}
+		}
+
+		if len(namespaces) == 0 {
+			return nil, fmt.Errorf("controller kind %s has no allowed namespaces", kind)
+		}
+
+		controllerNamespaces[kind] = namespaces
+	}
+
+	return controllerNamespaces, nil
+}
+
+// LeaderElectionID returns the distinct leader election lock for every watch label selector,
+// so the operator instances handling the different custom resources are not blocked by each other.
+func LeaderElectionID(selector labels.Selector) string {
+	if selector.Empty() {
+		return LeaderElectionLock
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(selector.String()))
+
+	return fmt.Sprintf("%s-%x", LeaderElectionLock, h.Sum32())
+}
+
+// NewCache creates the cache of the watched namespaces, all namespaces are watched if the list is empty.
+// The objects from the denied namespaces are filtered out of the cache of all namespaces.
+func NewCache(namespaces, denyNamespaces []string, selectors cache.SelectorsByObject) cache.NewCacheFunc {
+	return func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		opts = cacheOptions(opts, namespaces, denyNamespaces, selectors)
+
+		if len(namespaces) > 1 {
+			return cache.MultiNamespacedCacheBuilder(namespaces)(config, opts)
+		}
+
+		return cache.New(config, opts)
+	}
+}
+
+// cacheOptions sets the selectors of the cache and adds the field selector of the denied namespaces to them
+// if all namespaces are watched.
+func cacheOptions(opts cache.Options, namespaces, denyNamespaces []string,
+	selectors cache.SelectorsByObject) cache.Options {
+	opts.SelectorsByObject = make(cache.SelectorsByObject, len(selectors))
+	for obj, s := range selectors {
+		opts.SelectorsByObject[obj] = s
+	}
+
+	if len(namespaces) > 0 || len(denyNamespaces) == 0 {
+		return opts
+	}
+
+	terms := make([]fields.Selector, 0, len(denyNamespaces))
+	for _, n := range denyNamespaces {
+		terms = append(terms, fields.OneTermNotEqualSelector("metadata.namespace", n))
+	}
+
+	denySelector := fields.AndSelectors(terms...)
+	opts.DefaultSelector = cache.ObjectSelector{Field: denySelector}
+
+	for obj, s := range opts.SelectorsByObject {
+		s.Field = denySelector
+		opts.SelectorsByObject[obj] = s
+	}
+
+	return opts
+}
+
+// WatchSelectors restricts the cache of the operator custom resources to the watch label selector,
+// the other resources, e.g. secrets, are not restricted.
+func WatchSelectors(s *runtime.Scheme, selector labels.Selector) cache.SelectorsByObject {
+	if selector.Empty() {
+		return nil
+	}
+
+	selectors := make(cache.SelectorsByObject)
+
+	for _, gv := range []schema.GroupVersion{keycloakApi.SchemeGroupVersion, keycloakApi1alpha1.SchemeGroupVersion} {
+		kinds := s.KnownTypes(gv)
+
+		for kind := range kinds {
+			// only the kinds with the list are the resources
+			if _, ok := kinds[kind+"List"]; !ok {
+				continue
+			}
+
+			obj, err := s.New(gv.WithKind(kind))
+			if err != nil {
+				continue
+			}
+
+			if o, ok := obj.(client.Object); ok {
+				selectors[o] = cache.ObjectSelector{Label: selector}
+			}
+		}
+	}
+
+	return selectors
+}
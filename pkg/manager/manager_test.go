@@ -0,0 +1,322 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	ctrlmanager "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	keycloakApi1alpha1 "github.com/epam/edp-keycloak-operator/api/v1/v1alpha1"
+)
+
+func TestParseDisabledControllers(t *testing.T) {
+	kinds := []string{"KeycloakRealmUser", "KeycloakRealmUserBatch", "KeycloakClient"}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]bool
+		wantErr string
+	}{
+		{name: "empty", value: "", want: map[string]bool{}},
+		{name: "single kind", value: "KeycloakClient", want: map[string]bool{"KeycloakClient": true}},
+		{
+			name:  "several kinds with spaces",
+			value: " KeycloakRealmUser , KeycloakRealmUserBatch ",
+			want:  map[string]bool{"KeycloakRealmUser": true, "KeycloakRealmUserBatch": true},
+		},
+		{name: "empty items", value: ",KeycloakClient,,", want: map[string]bool{"KeycloakClient": true}},
+		{
+			name:    "unknown kind",
+			value:   "KeycloakClient,KeycloakUser",
+			wantErr: "unknown controller kind KeycloakUser, supported kinds are KeycloakRealmUser, KeycloakRealmUserBatch, KeycloakClient",
+		},
+		{
+			name:    "kind is case sensitive",
+			value:   "keycloakclient",
+			wantErr: "unknown controller kind keycloakclient",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDisabledControllers(tt.value, kinds)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLeaderElectionID(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     string
+	}{
+		{name: "no selector", selector: "", want: LeaderElectionLock},
+		// the lock must not change between the operator versions, so the upgraded operator waits for the old one
+		{name: "selector", selector: "tenant=a", want: "edp-keycloak-operator-lock-735a0a8b"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := labels.Parse(tt.selector)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, LeaderElectionID(selector))
+		})
+	}
+
+	a, err := labels.Parse("tenant=a")
+	require.NoError(t, err)
+
+	b, err := labels.Parse("tenant=b")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, LeaderElectionID(a), LeaderElectionID(b), "selectors must not share the lock")
+}
+
+func TestWatchSelectors(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(keycloakApi.AddToScheme(s))
+	utilruntime.Must(keycloakApi1alpha1.AddToScheme(s))
+
+	assert.Nil(t, WatchSelectors(s, labels.Everything()))
+
+	selector, err := labels.Parse("tenant=a")
+	require.NoError(t, err)
+
+	selectors := WatchSelectors(s, selector)
+	require.NotEmpty(t, selectors)
+
+	kinds := make(map[string]bool, len(selectors))
+
+	for obj, sel := range selectors {
+		gvk, _, err := s.ObjectKinds(obj)
+		require.NoError(t, err)
+
+		kinds[gvk[0].Kind] = true
+
+		assert.Equal(t, selector, sel.Label)
+		assert.Nil(t, sel.Field)
+	}
+
+	assert.True(t, kinds["Keycloak"])
+	assert.True(t, kinds["KeycloakRealmUser"])
+	assert.False(t, kinds["KeycloakList"], "lists are not the resources")
+	assert.False(t, kinds["Secret"], "the resources of other groups are not restricted")
+}
+
+func TestCacheOptions(t *testing.T) {
+	selector, err := labels.Parse("tenant=a")
+	require.NoError(t, err)
+
+	realm := &keycloakApi.KeycloakRealm{}
+
+	tests := []struct {
+		name            string
+		namespaces      []string
+		denyNamespaces  []string
+		wantField       string
+		wantObjectField string
+	}{
+		{name: "all namespaces", namespaces: nil, denyNamespaces: nil},
+		{
+			name:            "all namespaces with denied namespaces",
+			denyNamespaces:  []string{"kube-system", "team-b"},
+			wantField:       "metadata.namespace!=kube-system,metadata.namespace!=team-b",
+			wantObjectField: "metadata.namespace!=kube-system,metadata.namespace!=team-b",
+		},
+		{
+			name:           "denied namespaces are already removed from the watched namespaces",
+			namespaces:     []string{"team-a", "team-c"},
+			denyNamespaces: []string{"team-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			selectors := cache.SelectorsByObject{realm: {Label: selector}}
+
+			opts := cacheOptions(cache.Options{}, tt.namespaces, tt.denyNamespaces, selectors)
+
+			if tt.wantField == "" {
+				assert.Nil(t, opts.DefaultSelector.Field)
+			} else {
+				require.NotNil(t, opts.DefaultSelector.Field)
+				assert.Equal(t, tt.wantField, opts.DefaultSelector.Field.String())
+			}
+
+			objSelector := opts.SelectorsByObject[realm]
+			assert.Equal(t, selector, objSelector.Label, "the watch label selector must be kept")
+
+			if tt.wantObjectField == "" {
+				assert.Nil(t, objSelector.Field)
+			} else {
+				require.NotNil(t, objSelector.Field)
+				assert.Equal(t, tt.wantObjectField, objSelector.Field.String())
+			}
+
+			assert.Nil(t, selectors[realm].Field, "the passed selectors must not be changed")
+		})
+	}
+
+	opts := cacheOptions(cache.Options{}, nil, []string{"kube-system"}, nil)
+	assert.Empty(t, opts.SelectorsByObject)
+	assert.True(t, opts.DefaultSelector.Field.Matches(fields.Set{"metadata.namespace": "default"}))
+	assert.False(t, opts.DefaultSelector.Field.Matches(fields.Set{"metadata.namespace": "kube-system"}))
+}
+
+func TestParseControllerNamespaces(t *testing.T) {
+	kinds := []string{"KeycloakRealmUser", "KeycloakClient"}
+
+	tests := []struct {
+		name    string
+		value   string
+		deny    []string
+		want    map[string][]string
+		wantErr string
+	}{
+		{name: "empty", value: "", want: map[string][]string{}},
+		{
+			name:  "several kinds",
+			value: " KeycloakRealmUser = team-a, team-b ;KeycloakClient=team-c;",
+			want:  map[string][]string{"KeycloakRealmUser": {"team-a", "team-b"}, "KeycloakClient": {"team-c"}},
+		},
+		{
+			name:  "denied namespaces are removed",
+			value: "KeycloakRealmUser=team-a,kube-system",
+			deny:  []string{"kube-system"},
+			want:  map[string][]string{"KeycloakRealmUser": {"team-a"}},
+		},
+		{
+			name:    "all namespaces are denied",
+			value:   "KeycloakRealmUser=kube-system",
+			deny:    []string{"kube-system"},
+			wantErr: "controller kind KeycloakRealmUser has no allowed namespaces",
+		},
+		{name: "no namespaces", value: "KeycloakClient", wantErr: "must be set as KeycloakClient=namespace"},
+		{name: "unknown kind", value: "KeycloakUser=team-a", wantErr: "unknown controller kind KeycloakUser"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseControllerNamespaces(tt.value, kinds, tt.deny)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// stubManager injects its cache and its SetFields like the manager of controller-runtime.
+type stubManager struct {
+	ctrl.Manager
+	cache cache.Cache
+}
+
+func (m *stubManager) GetCache() cache.Cache {
+	return m.cache
+}
+
+func (m *stubManager) SetFields(i interface{}) error {
+	if _, err := inject.CacheInto(m.cache, i); err != nil {
+		return err
+	}
+
+	_, err := inject.InjectorInto(m.SetFields, i)
+
+	return err
+}
+
+func (m *stubManager) Add(r ctrlmanager.Runnable) error {
+	return m.SetFields(r)
+}
+
+// stubController keeps the injected SetFields like the controller of controller-runtime.
+type stubController struct {
+	setFields func(i interface{}) error
+}
+
+func (c *stubController) Start(context.Context) error {
+	return nil
+}
+
+func (c *stubController) InjectFunc(f inject.Func) error {
+	c.setFields = f
+
+	return nil
+}
+
+func TestNamespacedManager(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(keycloakApi.AddToScheme(s))
+
+	informers := &informertest.FakeInformers{Scheme: s}
+	base := &stubManager{cache: informers}
+
+	assert.Same(t, base, NamespacedManager(base, nil), "the manager of all namespaces is not wrapped")
+
+	mgr := NamespacedManager(base, []string{"team-a"})
+
+	// the watches are added to the controller after it is added to the manager
+	c := &stubController{}
+	require.NoError(t, mgr.Add(c))
+
+	src := &source.Kind{Type: &keycloakApi.KeycloakRealm{}}
+	require.NoError(t, c.setFields(src))
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+
+	require.NoError(t, src.Start(context.Background(), &handler.EnqueueRequestForObject{}, queue))
+	require.NoError(t, src.WaitForSync(context.Background()))
+
+	informer, err := informers.FakeInformerFor(&keycloakApi.KeycloakRealm{})
+	require.NoError(t, err)
+
+	informer.Add(&keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "b"}})
+	informer.Add(&keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "a"}})
+	informer.Delete(&keycloakApi.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "deleted"}})
+
+	require.Equal(t, 1, queue.Len())
+
+	item, _ := queue.Get()
+	assert.Equal(t, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: "a"}}, item)
+}
@@ -0,0 +1,110 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+// NamespacedManager returns the manager whose controllers get the events of the objects only from the given
+// namespaces, the objects are still read from the cache of the whole operator. The manager is returned as is
+// if the list is empty.
+func NamespacedManager(mgr ctrl.Manager, namespaces []string) ctrl.Manager {
+	if len(namespaces) == 0 {
+		return mgr
+	}
+
+	allowed := make(map[string]bool, len(namespaces))
+	for _, n := range namespaces {
+		allowed[n] = true
+	}
+
+	return &namespacedManager{Manager: mgr, cache: &namespacedCache{Cache: mgr.GetCache(), namespaces: allowed}}
+}
+
+type namespacedManager struct {
+	ctrl.Manager
+	cache *namespacedCache
+}
+
+// SetFields injects the namespaced cache into the watch sources of the controllers before the cache
+// of the manager, the sources keep the first injected cache.
+func (m *namespacedManager) SetFields(i interface{}) error {
+	if _, err := inject.CacheInto(m.cache, i); err != nil {
+		return err
+	}
+
+	return m.Manager.SetFields(i)
+}
+
+// Add adds the runnable to the manager and injects the SetFields of the namespaced manager into it again,
+// so the watches added to the controller after it is created get the namespaced cache too.
+func (m *namespacedManager) Add(r manager.Runnable) error {
+	if err := m.Manager.Add(r); err != nil {
+		return err
+	}
+
+	_, err := inject.InjectorInto(m.SetFields, r)
+
+	return err
+}
+
+// namespacedCache filters the events of the informers by the namespace of the objects.
+type namespacedCache struct {
+	cache.Cache
+	namespaces map[string]bool
+}
+
+func (c *namespacedCache) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	informer, err := c.Cache.GetInformer(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &namespacedInformer{Informer: informer, namespaces: c.namespaces}, nil
+}
+
+func (c *namespacedCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	informer, err := c.Cache.GetInformerForKind(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &namespacedInformer{Informer: informer, namespaces: c.namespaces}, nil
+}
+
+type namespacedInformer struct {
+	cache.Informer
+	namespaces map[string]bool
+}
+
+func (i *namespacedInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	i.Informer.AddEventHandler(i.filter(handler))
+}
+
+func (i *namespacedInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler,
+	resyncPeriod time.Duration) {
+	i.Informer.AddEventHandlerWithResyncPeriod(i.filter(handler), resyncPeriod)
+}
+
+func (i *namespacedInformer) filter(handler toolscache.ResourceEventHandler) toolscache.ResourceEventHandler {
+	return toolscache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+
+			o, ok := obj.(client.Object)
+
+			return ok && i.namespaces[o.GetNamespace()]
+		},
+		Handler: handler,
+	}
+}
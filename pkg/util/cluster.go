@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
 const (
 	watchNamespaceEnvVar   = "WATCH_NAMESPACE"
+	denyNamespacesEnvVar   = "DENY_NAMESPACES"
 	debugModeEnvVar        = "DEBUG_MODE"
+	podNamespaceEnvVar     = "POD_NAMESPACE"
 	inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 )
 
@@ -22,6 +25,78 @@ func GetWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+// GetWatchNamespaces returns the comma separated namespaces the operator should be watching for changes
+// without the denied namespaces. The operator watches all namespaces if the list is empty.
+func GetWatchNamespaces() ([]string, error) {
+	ns, err := GetWatchNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	watch := SplitList(ns)
+	if len(watch) == 0 {
+		return nil, nil
+	}
+
+	deny := GetDenyNamespaces()
+	allowed := make([]string, 0, len(watch))
+
+	for _, n := range watch {
+		if !containsString(deny, n) {
+			allowed = append(allowed, n)
+		}
+	}
+
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("all namespaces from %s are denied by %s", watchNamespaceEnvVar, denyNamespacesEnvVar)
+	}
+
+	return allowed, nil
+}
+
+// GetDenyNamespaces returns the comma separated namespaces the operator must not watch.
+func GetDenyNamespaces() []string {
+	return SplitList(os.Getenv(denyNamespacesEnvVar))
+}
+
+// SplitList returns the unique non-empty items of the comma separated list.
+func SplitList(value string) []string {
+	var items []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" && !containsString(items, item) {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetOperatorNamespace returns the namespace the operator is running in,
+// it is empty if the operator is running locally without the POD_NAMESPACE.
+func GetOperatorNamespace() string {
+	if ns := os.Getenv(podNamespaceEnvVar); ns != "" {
+		return ns
+	}
+
+	ns, err := os.ReadFile(inClusterNamespacePath)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(ns))
+}
+
 // GetDebugMode returns the debug mode value.
 func GetDebugMode() (bool, error) {
 	mode, found := os.LookupEnv(debugModeEnvVar)
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWatchNamespaces(t *testing.T) {
+	tests := []struct {
+		name    string
+		watch   string
+		deny    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "all namespaces", watch: "", deny: "kube-system", want: nil},
+		{name: "single namespace", watch: "team-a", want: []string{"team-a"}},
+		{name: "namespaces list", watch: "team-a, team-b,,team-a", want: []string{"team-a", "team-b"}},
+		{name: "denied namespace", watch: "team-a,team-b", deny: "team-b", want: []string{"team-a"}},
+		{name: "all namespaces denied", watch: "team-a", deny: "team-a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(watchNamespaceEnvVar, tt.watch)
+			t.Setenv(denyNamespacesEnvVar, tt.deny)
+
+			got, err := GetWatchNamespaces()
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetDenyNamespaces(t *testing.T) {
+	t.Setenv(denyNamespacesEnvVar, "kube-system, kube-public")
+
+	assert.Equal(t, []string{"kube-system", "kube-public"}, GetDenyNamespaces())
+}
+
+func TestGetOperatorNamespace(t *testing.T) {
+	t.Setenv(podNamespaceEnvVar, "keycloak-operator")
+
+	assert.Equal(t, "keycloak-operator", GetOperatorNamespace())
+}
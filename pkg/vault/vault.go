@@ -0,0 +1,296 @@
+// Package vault reads secrets from HashiCorp Vault with the Kubernetes auth method.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultAuthMount is a default path the Kubernetes auth method is mounted at.
+	DefaultAuthMount = "kubernetes"
+	// DefaultTokenPath is a default path of the projected ServiceAccount token with the Vault audience
+	// the operator logs in to Vault with.
+	DefaultTokenPath = "/var/run/secrets/vault/token"
+	// DefaultTTL is a time the secrets and the tokens without the lease, e.g. the KV secrets, are cached for.
+	DefaultTTL = time.Minute
+
+	// leaseRefreshRatio is a part of the lease after which the token or the secret is refreshed.
+	leaseRefreshRatio = 0.8
+)
+
+// Config is the Vault the secrets are read from and the Kubernetes auth role the operator logs in with.
+// It is the configuration of the operator, so the custom resources can not send the token of the operator
+// to another server or read the secrets outside of the PathPrefix.
+type Config struct {
+	Address   string
+	AuthMount string
+	Role      string
+	TokenPath string
+	// PathPrefix is the API path the secrets read by the custom resources must be under, e.g. secret/data/keycloak.
+	PathPrefix string
+}
+
+// Validate checks that the Vault is fully configured.
+func (c *Config) Validate() error {
+	if c.Address == "" || c.Role == "" || strings.Trim(c.PathPrefix, "/") == "" {
+		return errors.New("vault address, role and secret path prefix are required")
+	}
+
+	return nil
+}
+
+// secretPath returns the clean API path of the secret, the path outside of the PathPrefix is rejected.
+func (c *Config) secretPath(secret string) (string, error) {
+	prefix := strings.Trim(c.PathPrefix, "/")
+	clean := strings.TrimPrefix(path.Clean("/"+secret), "/")
+
+	if prefix == "" || !strings.HasPrefix(clean+"/", prefix+"/") {
+		return "", errors.Errorf("vault secret %s is not under the allowed path %s", secret, prefix)
+	}
+
+	return clean, nil
+}
+
+type cachedToken struct {
+	value   string
+	refresh time.Time
+}
+
+type cachedSecret struct {
+	data    map[string]string
+	refresh time.Time
+}
+
+// CredentialProvider reads the secrets from Vault. The Vault tokens and the secrets are cached
+// and refreshed before their leases expire, so the callers always get the valid values.
+type CredentialProvider struct {
+	httpClient *http.Client
+	cfg        Config
+	now        func() time.Time
+
+	// mu guards the cache only, it is not held during the requests to Vault.
+	mu      sync.Mutex
+	token   cachedToken
+	secrets map[string]cachedSecret
+}
+
+func NewCredentialProvider(httpClient *http.Client, cfg Config) *CredentialProvider {
+	return &CredentialProvider{
+		httpClient: httpClient,
+		cfg:        cfg,
+		now:        time.Now,
+		secrets:    make(map[string]cachedSecret),
+	}
+}
+
+// ReadSecret returns the string values of the secret at the API path, e.g. secret/data/keycloak.
+// The path must be under the PathPrefix of the configuration. The data of the KV v2 secrets is unwrapped.
+func (p *CredentialProvider) ReadSecret(ctx context.Context, secret string) (map[string]string, error) {
+	path, err := p.cfg.secretPath(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	s, ok := p.secrets[path]
+	p.mu.Unlock()
+
+	if ok && p.now().Before(s.refresh) {
+		return s.data, nil
+	}
+
+	data, lease, err := p.readSecret(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.secrets[path] = cachedSecret{data: data, refresh: p.refreshTime(lease)}
+	p.mu.Unlock()
+
+	return data, nil
+}
+
+func (p *CredentialProvider) readSecret(ctx context.Context, path string) (map[string]string, time.Duration, error) {
+	token, err := p.getToken(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, status, err := p.do(ctx, http.MethodGet, p.cfg.Address+"/v1/"+path, token, nil)
+	if err == nil && status == http.StatusForbidden {
+		// the token is revoked before its lease expires, log in again
+		p.mu.Lock()
+		if p.token.value == token {
+			p.token = cachedToken{}
+		}
+		p.mu.Unlock()
+
+		if token, err = p.getToken(ctx); err != nil {
+			return nil, 0, err
+		}
+
+		resp, status, err = p.do(ctx, http.MethodGet, p.cfg.Address+"/v1/"+path, token, nil)
+	}
+
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "unable to read vault secret %s", path)
+	}
+
+	if status != http.StatusOK {
+		return nil, 0, errors.Errorf("unable to read vault secret %s, status: %d", path, status)
+	}
+
+	data, err := secretData(resp.Data)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "unable to decode vault secret %s", path)
+	}
+
+	return data, time.Duration(resp.LeaseDuration) * time.Second, nil
+}
+
+// getToken returns the cached Vault token or logs in with the Kubernetes auth method.
+func (p *CredentialProvider) getToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	t := p.token
+	p.mu.Unlock()
+
+	if t.value != "" && p.now().Before(t.refresh) {
+		return t.value, nil
+	}
+
+	cfg := &p.cfg
+
+	tokenPath := cfg.TokenPath
+	if tokenPath == "" {
+		tokenPath = DefaultTokenPath
+	}
+
+	jwt, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read service account token")
+	}
+
+	mount := cfg.AuthMount
+	if mount == "" {
+		mount = DefaultAuthMount
+	}
+
+	resp, status, err := p.do(ctx, http.MethodPost, fmt.Sprintf("%s/v1/auth/%s/login", cfg.Address, mount), "",
+		map[string]string{"role": cfg.Role, "jwt": strings.TrimSpace(string(jwt))})
+	if err != nil {
+		return "", errors.Wrap(err, "unable to login to vault")
+	}
+
+	if status != http.StatusOK || resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return "", errors.Errorf("unable to login to vault with role %s, status: %d", cfg.Role, status)
+	}
+
+	p.mu.Lock()
+	p.token = cachedToken{
+		value:   resp.Auth.ClientToken,
+		refresh: p.refreshTime(time.Duration(resp.Auth.LeaseDuration) * time.Second),
+	}
+	p.mu.Unlock()
+
+	return resp.Auth.ClientToken, nil
+}
+
+// refreshTime returns the time the value with the lease is refreshed at.
+func (p *CredentialProvider) refreshTime(lease time.Duration) time.Time {
+	if lease <= 0 {
+		return p.now().Add(DefaultTTL)
+	}
+
+	return p.now().Add(time.Duration(float64(lease) * leaseRefreshRatio))
+}
+
+type response struct {
+	LeaseDuration int                    `json:"lease_duration"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+func (p *CredentialProvider) do(ctx context.Context, method, url, token string, body interface{}) (*response, int, error) {
+	var reqBody io.Reader = http.NoBody
+
+	if body != nil {
+		bts, err := json.Marshal(body)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "unable to encode request")
+		}
+
+		reqBody = bytes.NewReader(bts)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "unable to create request")
+	}
+
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	rsp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "unable to send request")
+	}
+	defer rsp.Body.Close()
+
+	var resp response
+
+	if rsp.StatusCode != http.StatusOK {
+		return &resp, rsp.StatusCode, nil
+	}
+
+	if err := json.NewDecoder(rsp.Body).Decode(&resp); err != nil {
+		return nil, 0, errors.Wrap(err, "unable to decode response")
+	}
+
+	return &resp, rsp.StatusCode, nil
+}
+
+// secretData converts the secret data to the strings, the data of the KV v2 secret is nested with its metadata.
+func secretData(data map[string]interface{}) (map[string]string, error) {
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	res := make(map[string]string, len(data))
+
+	for k, v := range data {
+		switch val := v.(type) {
+		case string:
+			res[k] = val
+		case nil:
+			res[k] = ""
+		default:
+			bts, err := json.Marshal(val)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to encode value of %s", k)
+			}
+
+			res[k] = string(bts)
+		}
+	}
+
+	return res, nil
+}
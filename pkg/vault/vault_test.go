@@ -0,0 +1,213 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeVault struct {
+	logins   int
+	reads    int
+	token    string
+	password string
+}
+
+func (v *fakeVault) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/auth/kubernetes/login", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "keycloak-operator", body["role"])
+		assert.Equal(t, "sa-jwt", body["jwt"])
+
+		v.logins++
+
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"` + v.token + `","lease_duration":3600}}`))
+	})
+
+	mux.HandleFunc("/v1/secret/data/keycloak", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != v.token {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		v.reads++
+
+		_, _ = w.Write([]byte(`{"lease_duration":0,"data":{"data":{"username":"admin","password":"` + v.password +
+			`"},"metadata":{"version":1}}}`))
+	})
+
+	return mux
+}
+
+func makeConfig(t *testing.T, address string) Config {
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("sa-jwt\n"), 0o600))
+
+	return Config{Address: address, Role: "keycloak-operator", TokenPath: tokenPath, PathPrefix: "secret/data/keycloak"}
+}
+
+func TestCredentialProvider_ReadSecret(t *testing.T) {
+	v := &fakeVault{token: "token-1", password: "pass-1"}
+	server := httptest.NewServer(v.handler(t))
+	defer server.Close()
+
+	now := time.Now()
+	p := NewCredentialProvider(server.Client(), makeConfig(t, server.URL))
+	p.now = func() time.Time { return now }
+
+	data, err := p.ReadSecret(context.Background(), "secret/data/keycloak")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"username": "admin", "password": "pass-1"}, data)
+
+	v.password = "pass-2"
+
+	_, err = p.ReadSecret(context.Background(), "secret/data/keycloak")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v.reads, "secret must be cached")
+
+	now = now.Add(DefaultTTL)
+
+	data, err = p.ReadSecret(context.Background(), "secret/data/keycloak")
+	require.NoError(t, err)
+	assert.Equal(t, "pass-2", data["password"])
+	assert.Equal(t, 1, v.logins, "token must be cached")
+
+	// the token is revoked
+	v.token = "token-2"
+	now = now.Add(DefaultTTL)
+
+	data, err = p.ReadSecret(context.Background(), "secret/data/keycloak")
+	require.NoError(t, err)
+	assert.Equal(t, "pass-2", data["password"])
+	assert.Equal(t, 2, v.logins)
+
+	// the token lease expires
+	now = now.Add(time.Hour)
+
+	_, err = p.ReadSecret(context.Background(), "secret/data/keycloak")
+	require.NoError(t, err)
+	assert.Equal(t, 3, v.logins)
+}
+
+func TestCredentialProvider_ReadSecret_Failures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	p := NewCredentialProvider(server.Client(), makeConfig(t, server.URL))
+
+	_, err := p.ReadSecret(context.Background(), "secret/data/keycloak")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to login to vault with role keycloak-operator")
+
+	p = NewCredentialProvider(server.Client(), Config{
+		Address:    server.URL,
+		TokenPath:  filepath.Join(t.TempDir(), "none"),
+		PathPrefix: "secret/data/keycloak",
+	})
+
+	_, err = p.ReadSecret(context.Background(), "secret/data/keycloak")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to read service account token")
+}
+
+func TestCredentialProvider_ReadSecret_Concurrent(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"token","lease_duration":3600}}`))
+		case "/v1/secret/data/keycloak/slow":
+			<-release
+
+			_, _ = w.Write([]byte(`{"data":{"password":"slow"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"password":"fast"}}`))
+		}
+	}))
+	defer server.Close()
+
+	p := NewCredentialProvider(server.Client(), makeConfig(t, server.URL))
+
+	slow := make(chan error)
+
+	go func() {
+		_, err := p.ReadSecret(context.Background(), "secret/data/keycloak/slow")
+		slow <- err
+	}()
+
+	// the slow request must not block the other secrets
+	data, err := p.ReadSecret(context.Background(), "secret/data/keycloak/fast")
+	require.NoError(t, err)
+	assert.Equal(t, "fast", data["password"])
+
+	close(release)
+	require.NoError(t, <-slow)
+}
+
+func TestConfig_SecretPath(t *testing.T) {
+	cfg := Config{PathPrefix: "/secret/data/keycloak/"}
+
+	tests := []struct {
+		name    string
+		secret  string
+		want    string
+		wantErr bool
+	}{
+		{name: "prefix", secret: "secret/data/keycloak", want: "secret/data/keycloak"},
+		{name: "under prefix", secret: "/secret/data/keycloak/team-a/", want: "secret/data/keycloak/team-a"},
+		{name: "outside prefix", secret: "secret/data/other", wantErr: true},
+		{name: "same beginning", secret: "secret/data/keycloak-admin", wantErr: true},
+		{name: "parent path", secret: "secret/data/keycloak/../other", wantErr: true},
+		{name: "auth path", secret: "auth/token/create", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfg.secretPath(tt.secret)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "is not under the allowed path secret/data/keycloak")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := (&Config{}).secretPath("secret/data/keycloak")
+	assert.Error(t, err, "no secret is allowed without the prefix")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&Config{Address: "https://vault", Role: "role", PathPrefix: "secret/data/keycloak"}).Validate())
+	assert.Error(t, (&Config{Address: "https://vault", Role: "role"}).Validate())
+	assert.Error(t, (&Config{Address: "https://vault", PathPrefix: "secret"}).Validate())
+}
+
+func TestSecretData(t *testing.T) {
+	data, err := secretData(map[string]interface{}{"username": "admin", "port": float64(8080), "empty": nil})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"username": "admin", "port": "8080", "empty": ""}, data)
+
+	data, err = secretData(map[string]interface{}{"data": map[string]interface{}{"password": "pass"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"data": `{"password":"pass"}`}, data, "kv v1 secret with the data key")
+}
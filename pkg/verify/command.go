@@ -0,0 +1,220 @@
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+
+	"github.com/pkg/errors"
+	coreV1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/export"
+)
+
+// Command is a name of the verify subcommand of the operator binary.
+const Command = "verify"
+
+// Report output formats.
+const (
+	OutputYAML = "yaml"
+	OutputJSON = "json"
+)
+
+type commandArgs struct {
+	conn          export.Connection
+	namespace     string
+	allNamespaces bool
+	output        string
+	configMap     string
+	failOnDrift   bool
+}
+
+// ResolverFactory creates the realm resolver which uses the kubernetes client.
+type ResolverFactory func(k8sClient client.Client) RealmResolver
+
+// NewRunner returns the verify subcommand which resolves the realms of the custom resources with the resolver.
+// The subcommand writes the report to the out, the report is also stored in the config map if it is set.
+func NewRunner(newResolver ResolverFactory) func(ctx context.Context, args []string, out io.Writer) error {
+	return func(ctx context.Context, args []string, out io.Writer) error {
+		cmdArgs, err := parseArgs(args)
+		if err != nil {
+			return err
+		}
+
+		k8sClient, err := makeK8sClient()
+		if err != nil {
+			return err
+		}
+
+		kClient, err := cmdArgs.conn.Connect(ctx)
+		if err != nil {
+			return err
+		}
+
+		return verify(ctx, k8sClient, newResolver(k8sClient), kClient, cmdArgs, out)
+	}
+}
+
+func verify(ctx context.Context, k8sClient client.Client, resolver RealmResolver, kClient export.RealmExporter,
+	cmdArgs *commandArgs, out io.Writer) error {
+	realmCR, err := GetRealmCR(ctx, k8sClient, cmdArgs.namespace, cmdArgs.conn.Realm)
+	if err != nil {
+		return err
+	}
+
+	childNamespace := cmdArgs.namespace
+	if cmdArgs.allNamespaces {
+		childNamespace = ""
+	}
+
+	report, err := Verify(ctx, k8sClient, resolver, kClient, realmCR, childNamespace)
+	if err != nil {
+		return err
+	}
+
+	data, err := EncodeReport(report, cmdArgs.output)
+	if err != nil {
+		return err
+	}
+
+	if _, err := out.Write(data); err != nil {
+		return errors.Wrap(err, "unable to write report")
+	}
+
+	if cmdArgs.configMap != "" {
+		if err := StoreReport(ctx, k8sClient, cmdArgs.namespace, cmdArgs.configMap, ReportKey(cmdArgs.output),
+			data); err != nil {
+			return err
+		}
+	}
+
+	if cmdArgs.failOnDrift && report.HasDrift() {
+		return errors.Errorf("realm %s has drifted, %d drifted and %d missing resources",
+			report.Realm, report.Drifted, report.Missing)
+	}
+
+	return nil
+}
+
+// ReportKey returns the config map key of the report in the output format.
+func ReportKey(output string) string {
+	return "report." + output
+}
+
+// EncodeReport encodes the report in the output format, yaml or json.
+func EncodeReport(report *Report, output string) ([]byte, error) {
+	if output == OutputJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to encode report")
+		}
+
+		return append(data, '\n'), nil
+	}
+
+	data, err := yaml.Marshal(report)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode report")
+	}
+
+	return data, nil
+}
+
+// StoreReport creates or updates the config map with the report.
+func StoreReport(ctx context.Context, k8sClient client.Client, namespace, name, key string, data []byte) error {
+	var cm coreV1.ConfigMap
+
+	err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &cm)
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return errors.Wrapf(err, "unable to get config map %s", name)
+		}
+
+		cm = coreV1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string]string{key: string(data)},
+		}
+
+		if err := k8sClient.Create(ctx, &cm); err != nil {
+			return errors.Wrapf(err, "unable to create config map %s", name)
+		}
+
+		return nil
+	}
+
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+
+	cm.Data[key] = string(data)
+
+	if err := k8sClient.Update(ctx, &cm); err != nil {
+		return errors.Wrapf(err, "unable to update config map %s", name)
+	}
+
+	return nil
+}
+
+func makeK8sClient() (client.Client, error) {
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get kubernetes config")
+	}
+
+	scheme := runtime.NewScheme()
+
+	if err := coreV1.AddToScheme(scheme); err != nil {
+		return nil, errors.Wrap(err, "unable to add core api to scheme")
+	}
+
+	if err := keycloakApi.AddToScheme(scheme); err != nil {
+		return nil, errors.Wrap(err, "unable to add keycloak api to scheme")
+	}
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create kubernetes client")
+	}
+
+	return k8sClient, nil
+}
+
+func parseArgs(args []string) (*commandArgs, error) {
+	var cmdArgs commandArgs
+
+	fs := flag.NewFlagSet(Command, flag.ContinueOnError)
+	cmdArgs.conn.AddFlags(fs)
+	fs.StringVar(&cmdArgs.namespace, "namespace", "", "Namespace of the realm custom resource.")
+	fs.BoolVar(&cmdArgs.allNamespaces, "all-namespaces", false,
+		"Verify the custom resources of the realm from all namespaces, e.g. the ones with the realmNamespace, "+
+			"only the namespace of the realm is verified by default.")
+	fs.StringVar(&cmdArgs.output, "output", OutputYAML, "Output format of the report, yaml or json.")
+	fs.StringVar(&cmdArgs.configMap, "configmap", "", "Name of the config map the report is stored in.")
+	fs.BoolVar(&cmdArgs.failOnDrift, "fail-on-drift", false,
+		"Exit with an error if any custom resource differs from keycloak.")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, errors.Wrap(err, "unable to parse arguments")
+	}
+
+	if err := cmdArgs.conn.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case cmdArgs.namespace == "":
+		return nil, errors.New("namespace is required")
+	case cmdArgs.output != OutputYAML && cmdArgs.output != OutputJSON:
+		return nil, errors.Errorf("unsupported output %s", cmdArgs.output)
+	}
+
+	return &cmdArgs, nil
+}
@@ -0,0 +1,114 @@
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/export"
+)
+
+// VerifyObject compares the single custom resource of the realm with the realm exported from keycloak.
+// Only the KeycloakRealm, KeycloakRealmRole, KeycloakClient, KeycloakClientRole, KeycloakClientScope,
+// KeycloakRealmGroup, KeycloakAuthFlow, KeycloakRealmIdentityProvider and KeycloakRealmComponent
+// custom resources are supported.
+func VerifyObject(ctx context.Context, kClient export.RealmExporter, realm *keycloakApi.KeycloakRealm,
+	obj client.Object) (*ResourceReport, error) {
+	realmExport, err := kClient.ExportRealm(ctx, realm.Spec.RealmName)
+	if err != nil {
+		// the realm which does not exist yet would be created
+		if _, ok := obj.(*keycloakApi.KeycloakRealm); ok && adapter.IsErrNotFound(err) {
+			return &ResourceReport{Kind: "KeycloakRealm", Name: obj.GetName(), Status: StatusMissing}, nil
+		}
+
+		return nil, errors.Wrapf(err, "unable to export realm %s", realm.Spec.RealmName)
+	}
+
+	live := makeLive(realmExport, realm.Name)
+
+	var res ResourceReport
+
+	switch o := obj.(type) {
+	case *keycloakApi.KeycloakRealm:
+		res = compare("KeycloakRealm", o.Name, o.Spec, live.realm, realmFields)
+	case *keycloakApi.KeycloakRealmRole:
+		liveSpec, ok := live.roles[o.Spec.Name]
+		res = compareOrMissing("KeycloakRealmRole", o.Name, o.Spec, liveSpec, ok, roleFields)
+	case *keycloakApi.KeycloakClient:
+		liveSpec, ok := live.clients[o.Spec.ClientId]
+		res = compareOrMissing("KeycloakClient", o.Name, o.Spec, liveSpec, ok, clientFields)
+	case *keycloakApi.KeycloakClientRole:
+		liveSpec, ok := live.clientRoles[clientRoleKey(o.Spec.ClientID, o.Spec.Name)]
+		res = compareOrMissing("KeycloakClientRole", o.Name, o.Spec, liveSpec, ok, roleFields)
+	case *keycloakApi.KeycloakClientScope:
+		liveSpec, ok := live.clientScopes[o.Spec.Name]
+		res = compareOrMissing("KeycloakClientScope", o.Name, o.Spec, liveSpec, ok, clientScopeFields)
+	case *keycloakApi.KeycloakRealmGroup:
+		liveSpec, ok := live.groups[declaredGroupPath(o)]
+		res = compareOrMissing("KeycloakRealmGroup", o.Name, o.Spec, liveSpec, ok, groupFields)
+	case *keycloakApi.KeycloakAuthFlow:
+		liveSpec, ok := live.flows[o.Spec.Alias]
+		res = compareOrMissing("KeycloakAuthFlow", o.Name, o.Spec, liveSpec, ok, flowFields)
+	case *keycloakApi.KeycloakRealmIdentityProvider:
+		liveSpec, ok := live.idps[o.Spec.Alias]
+		res = compareOrMissing("KeycloakRealmIdentityProvider", o.Name, o.Spec, liveSpec, ok, idpFields)
+	case *keycloakApi.KeycloakRealmComponent:
+		liveSpec, ok := live.components[componentKey(o.Spec.ProviderType, o.Spec.Name)]
+		res = compareOrMissing("KeycloakRealmComponent", o.Name, o.Spec, liveSpec, ok, componentFields)
+	default:
+		return nil, errors.Errorf("verification of %T is not supported", obj)
+	}
+
+	res.Namespace = obj.GetNamespace()
+
+	return &res, nil
+}
+
+// declaredGroupPath returns the path of the group from the status, the path is set after the first sync.
+func declaredGroupPath(group *keycloakApi.KeycloakRealmGroup) string {
+	if group.Status.Path != "" {
+		return group.Status.Path
+	}
+
+	if group.Spec.Path != "" {
+		return "/" + strings.Trim(group.Spec.Path, "/")
+	}
+
+	return "/" + group.Spec.Name
+}
+
+// Summary returns a human-readable description of the changes required to bring keycloak to the declared state.
+func (r *ResourceReport) Summary() string {
+	switch r.Status {
+	case StatusMissing:
+		return fmt.Sprintf("%s %s would be created", r.Kind, r.Name)
+	case StatusDrifted:
+		changes := make([]string, 0, len(r.Diffs))
+		for _, d := range r.Diffs {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", d.Field, formatValue(d.Live), formatValue(d.Desired)))
+		}
+
+		return fmt.Sprintf("%s %s would be updated: %s", r.Kind, r.Name, strings.Join(changes, "; "))
+	default:
+		return fmt.Sprintf("%s %s is in sync, no changes", r.Kind, r.Name)
+	}
+}
+
+func formatValue(value interface{}) string {
+	if value == nil {
+		return "<unset>"
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
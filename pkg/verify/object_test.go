@@ -0,0 +1,116 @@
+package verify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+func TestVerifyObject(t *testing.T) {
+	realm := &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "main"},
+		Spec:       keycloakApi.KeycloakRealmSpec{RealmName: "realm"},
+	}
+
+	tests := []struct {
+		name    string
+		obj     client.Object
+		want    string
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "in sync",
+			obj: &keycloakApi.KeycloakRealmRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "developer"},
+				Spec:       keycloakApi.KeycloakRealmRoleSpec{Realm: "main", Name: "developer", Description: "Developers"},
+			},
+			want:    "KeycloakRealmRole developer is in sync, no changes",
+			wantErr: require.NoError,
+		},
+		{
+			name: "drifted",
+			obj: &keycloakApi.KeycloakClient{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec: keycloakApi.KeycloakClientSpec{TargetRealm: "realm", ClientId: "app", Public: gocloak.BoolP(true),
+					Description: gocloak.StringP("Application")},
+			},
+			want:    `KeycloakClient app would be updated: description: <unset> -> "Application"`,
+			wantErr: require.NoError,
+		},
+		{
+			name: "missing",
+			obj: &keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "ops"},
+				Spec:       keycloakApi.KeycloakRealmGroupSpec{Realm: "main", Name: "ops"},
+			},
+			want:    "KeycloakRealmGroup ops would be created",
+			wantErr: require.NoError,
+		},
+		{
+			name: "nested group by status path",
+			obj: &keycloakApi.KeycloakRealmGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-dev"},
+				Spec:       keycloakApi.KeycloakRealmGroupSpec{Realm: "main", Name: "dev", RealmRoles: []string{"developer"}},
+				Status:     keycloakApi.KeycloakRealmGroupStatus{Path: "/team/dev"},
+			},
+			want:    "KeycloakRealmGroup team-dev is in sync, no changes",
+			wantErr: require.NoError,
+		},
+		{
+			name: "client role",
+			obj: &keycloakApi.KeycloakClientRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-editor"},
+				Spec:       keycloakApi.KeycloakClientRoleSpec{ClientID: "app", Name: "editor", Description: "Editors"},
+			},
+			want:    "KeycloakClientRole app-editor is in sync, no changes",
+			wantErr: require.NoError,
+		},
+		{
+			name: "unsupported kind",
+			obj:  &keycloakApi.KeycloakRealmUser{ObjectMeta: metav1.ObjectMeta{Name: "user"}},
+			wantErr: func(t require.TestingT, err error, i ...interface{}) {
+				require.ErrorContains(t, err, "verification of *v1.KeycloakRealmUser is not supported")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := VerifyObject(context.Background(), &fakeExporter{realm: getTestRealmExport()}, realm, tt.obj)
+			tt.wantErr(t, err)
+
+			if err == nil {
+				assert.Equal(t, tt.want, res.Summary())
+			}
+		})
+	}
+}
+
+func TestVerifyObject_MissingRealm(t *testing.T) {
+	realm := &keycloakApi.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "main"},
+		Spec:       keycloakApi.KeycloakRealmSpec{RealmName: "realm"},
+	}
+
+	exporter := &fakeExporter{err: adapter.NotFoundError("realm not found")}
+
+	res, err := VerifyObject(context.Background(), exporter, realm, realm)
+	require.NoError(t, err)
+	assert.Equal(t, "KeycloakRealm main would be created", res.Summary())
+
+	_, err = VerifyObject(context.Background(), exporter, realm, &keycloakApi.KeycloakRealmRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "developer"},
+	})
+	require.Error(t, err, "the children of the missing realm can not be created")
+}
@@ -0,0 +1,728 @@
+// Package verify compares the operator custom resources with the live keycloak state.
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+	"github.com/epam/edp-keycloak-operator/pkg/export"
+)
+
+// Statuses of the verified resources.
+const (
+	StatusInSync  = "InSync"
+	StatusDrifted = "Drifted"
+	StatusMissing = "Missing"
+)
+
+// Compared fields of the custom resource specs, only the fields declared in the custom resources are compared.
+// The fields of the nested objects are compared by the declared keys, the lists are compared regardless of the order.
+var (
+	realmFields       = []string{"browserFlow"}
+	roleFields        = []string{"description", "attributes", "composite", "composites", "compositesClientRoles"}
+	groupFields       = []string{"attributes", "realmRoles", "clientRoles"}
+	flowFields        = []string{"description", "providerId", "topLevel", "authenticationExecutions"}
+	clientScopeFields = []string{"description", "protocol", "attributes"}
+	componentFields   = []string{"providerId", "config"}
+
+	clientFields = []string{"name", "description", "enabled", "public", "webUrl", "baseUrl", "adminUrl",
+		"consentRequired", "standardFlowEnabled", "implicitFlowEnabled", "bearerOnly", "protocol",
+		"clientAuthenticatorType", "directAccess", "frontChannelLogout", "fullScopeAllowed", "attributes",
+		"defaultClientScopes", "optionalClientScopes", "clientRoles", "serviceAccount.enabled"}
+
+	idpFields = []string{"providerId", "enabled", "displayName", "trustEmail", "storeToken", "linkOnly",
+		"addReadTokenRoleOnCreate", "authenticateByDefault", "firstBrokerLoginFlowAlias", "postBrokerLoginFlowAlias",
+		"config"}
+)
+
+// maskedSecret is the value keycloak exports instead of the secrets, it is equal to any declared value.
+const maskedSecret = "**********"
+
+// Report is a result of the verification of the realm custom resources.
+type Report struct {
+	Realm     string           `json:"realm"`
+	InSync    int              `json:"inSync"`
+	Drifted   int              `json:"drifted"`
+	Missing   int              `json:"missing"`
+	Resources []ResourceReport `json:"resources"`
+}
+
+// HasDrift returns true if any of the custom resources differs from keycloak.
+func (r *Report) HasDrift() bool {
+	return r.Drifted > 0 || r.Missing > 0
+}
+
+func (r *Report) add(res ResourceReport) {
+	switch res.Status {
+	case StatusInSync:
+		r.InSync++
+	case StatusDrifted:
+		r.Drifted++
+	case StatusMissing:
+		r.Missing++
+	}
+
+	r.Resources = append(r.Resources, res)
+}
+
+// ResourceReport is a result of the verification of the custom resource.
+type ResourceReport struct {
+	Kind      string      `json:"kind"`
+	Namespace string      `json:"namespace,omitempty"`
+	Name      string      `json:"name"`
+	Status    string      `json:"status"`
+	Diffs     []FieldDiff `json:"diffs,omitempty"`
+}
+
+// FieldDiff is a difference of the custom resource spec field and the live keycloak value.
+type FieldDiff struct {
+	Field   string      `json:"field"`
+	Desired interface{} `json:"desired"`
+	Live    interface{} `json:"live"`
+}
+
+// RealmResolver returns the realm of the custom resource the same way its controller does,
+// e.g. by the realm name, the realm selector or from the realm namespace.
+type RealmResolver interface {
+	ResolveRealm(obj client.Object) (*keycloakApi.KeycloakRealm, error)
+}
+
+// Verify compares the custom resources of the realm with the realm exported from keycloak.
+// The custom resources are listed in the namespace or in all namespaces if it is empty, the resources
+// whose realm can not be resolved are skipped. The users are not compared, because keycloak does not export them.
+// Nothing is changed in keycloak or in the cluster.
+func Verify(ctx context.Context, k8sClient client.Client, resolver RealmResolver, kClient export.RealmExporter,
+	realmCR *keycloakApi.KeycloakRealm, namespace string) (*Report, error) {
+	realmName := realmCR.Spec.RealmName
+
+	realmExport, err := kClient.ExportRealm(ctx, realmName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to export realm %s", realmName)
+	}
+
+	v := verifier{
+		client:    k8sClient,
+		resolver:  resolver,
+		realm:     realmCR,
+		namespace: namespace,
+		live:      makeLive(realmExport, realmCR.Name),
+		report:    &Report{Realm: realmName, Resources: make([]ResourceReport, 0)},
+	}
+
+	realmReport := compare("KeycloakRealm", realmCR.Name, realmCR.Spec, v.live.realm, realmFields)
+	realmReport.Namespace = realmCR.Namespace
+	v.report.add(realmReport)
+
+	for _, verifyKind := range []func(context.Context) error{
+		v.verifyRoles, v.verifyClients, v.verifyClientRoles, v.verifyClientScopes, v.verifyGroups,
+		v.verifyAuthFlows, v.verifyIdentityProviders, v.verifyComponents,
+	} {
+		if err := verifyKind(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return v.report, nil
+}
+
+// GetRealmCR returns the KeycloakRealm of the realm from the namespace.
+func GetRealmCR(ctx context.Context, k8sClient client.Client, namespace,
+	realmName string) (*keycloakApi.KeycloakRealm, error) {
+	var realms keycloakApi.KeycloakRealmList
+	if err := k8sClient.List(ctx, &realms, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "unable to list keycloak realms")
+	}
+
+	for i := range realms.Items {
+		if realms.Items[i].Spec.RealmName == realmName {
+			return &realms.Items[i], nil
+		}
+	}
+
+	return nil, errors.Errorf("KeycloakRealm with realm name %s not found in namespace %s", realmName, namespace)
+}
+
+// liveResources are the live keycloak resources indexed by their keycloak identifiers.
+type liveResources struct {
+	realm        interface{}
+	roles        map[string]keycloakApi.KeycloakRealmRoleSpec
+	clients      map[string]keycloakApi.KeycloakClientSpec
+	groups       map[string]keycloakApi.KeycloakRealmGroupSpec
+	flows        map[string]keycloakApi.KeycloakAuthFlowSpec
+	clientRoles  map[string]keycloakApi.KeycloakClientRoleSpec
+	clientScopes map[string]keycloakApi.KeycloakClientScopeSpec
+	idps         map[string]keycloakApi.KeycloakRealmIdentityProviderSpec
+	components   map[string]keycloakApi.KeycloakComponentSpec
+}
+
+// makeLive indexes the exported realm, the kinds which are not exported as the manifests are converted here.
+func makeLive(realmExport *adapter.RealmExport, realmCRName string) *liveResources {
+	live := indexLive(export.MakeManifests(realmExport, export.Options{RealmCRName: realmCRName, IncludeBuiltIn: true}))
+
+	live.clientRoles = make(map[string]keycloakApi.KeycloakClientRoleSpec)
+
+	for clientID, roles := range realmExport.Roles.Client {
+		for i := range roles {
+			spec := clientRoleSpec(clientID, &roles[i])
+			live.clientRoles[clientRoleKey(clientID, spec.Name)] = spec
+		}
+	}
+
+	live.clientScopes = make(map[string]keycloakApi.KeycloakClientScopeSpec, len(realmExport.ClientScopes))
+
+	for i := range realmExport.ClientScopes {
+		s := &realmExport.ClientScopes[i]
+		live.clientScopes[s.Name] = keycloakApi.KeycloakClientScopeSpec{
+			Name:        s.Name,
+			Protocol:    s.Protocol,
+			Description: s.Description,
+			Attributes:  s.Attributes,
+		}
+	}
+
+	live.idps = make(map[string]keycloakApi.KeycloakRealmIdentityProviderSpec, len(realmExport.IdentityProviders))
+
+	for i := range realmExport.IdentityProviders {
+		idp := &realmExport.IdentityProviders[i]
+		live.idps[idp.Alias] = keycloakApi.KeycloakRealmIdentityProviderSpec{
+			Alias:                     idp.Alias,
+			ProviderID:                idp.ProviderID,
+			Enabled:                   idp.Enabled,
+			Config:                    idp.Config,
+			AddReadTokenRoleOnCreate:  idp.AddReadTokenRoleOnCreate,
+			AuthenticateByDefault:     idp.AuthenticateByDefault,
+			DisplayName:               idp.DisplayName,
+			FirstBrokerLoginFlowAlias: idp.FirstBrokerLoginFlowAlias,
+			PostBrokerLoginFlowAlias:  idp.PostBrokerLoginFlowAlias,
+			LinkOnly:                  idp.LinkOnly,
+			StoreToken:                idp.StoreToken,
+			TrustEmail:                idp.TrustEmail,
+		}
+	}
+
+	live.components = make(map[string]keycloakApi.KeycloakComponentSpec)
+
+	for providerType, components := range realmExport.Components {
+		for i := range components {
+			c := &components[i]
+			live.components[componentKey(providerType, c.Name)] = keycloakApi.KeycloakComponentSpec{
+				Name:         c.Name,
+				ProviderID:   c.ProviderID,
+				ProviderType: providerType,
+				Config:       c.Config,
+			}
+		}
+	}
+
+	return live
+}
+
+func clientRoleSpec(clientID string, r *gocloak.Role) keycloakApi.KeycloakClientRoleSpec {
+	spec := keycloakApi.KeycloakClientRoleSpec{
+		ClientID:    clientID,
+		Name:        gocloak.PString(r.Name),
+		Description: gocloak.PString(r.Description),
+		Composite:   gocloak.PBool(r.Composite),
+	}
+
+	if r.Attributes != nil {
+		spec.Attributes = *r.Attributes
+	}
+
+	if r.Composites == nil {
+		return spec
+	}
+
+	if r.Composites.Realm != nil {
+		for _, c := range *r.Composites.Realm {
+			spec.Composites = append(spec.Composites, keycloakApi.Composite{Name: c})
+		}
+	}
+
+	if r.Composites.Client != nil {
+		spec.CompositesClientRoles = make(map[string][]keycloakApi.Composite, len(*r.Composites.Client))
+
+		for compositeClientID, names := range *r.Composites.Client {
+			for _, c := range names {
+				spec.CompositesClientRoles[compositeClientID] = append(spec.CompositesClientRoles[compositeClientID],
+					keycloakApi.Composite{Name: c})
+			}
+		}
+	}
+
+	return spec
+}
+
+func clientRoleKey(clientID, name string) string {
+	return clientID + "/" + name
+}
+
+func componentKey(providerType, name string) string {
+	return providerType + "/" + name
+}
+
+func indexLive(objects []client.Object) *liveResources {
+	live := liveResources{
+		roles:   make(map[string]keycloakApi.KeycloakRealmRoleSpec),
+		clients: make(map[string]keycloakApi.KeycloakClientSpec),
+		groups:  make(map[string]keycloakApi.KeycloakRealmGroupSpec),
+		flows:   make(map[string]keycloakApi.KeycloakAuthFlowSpec),
+	}
+
+	groups := make(map[string]*keycloakApi.KeycloakRealmGroup)
+
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *keycloakApi.KeycloakRealm:
+			live.realm = o.Spec
+		case *keycloakApi.KeycloakRealmRole:
+			live.roles[o.Spec.Name] = o.Spec
+		case *keycloakApi.KeycloakClient:
+			live.clients[o.Spec.ClientId] = o.Spec
+		case *keycloakApi.KeycloakRealmGroup:
+			groups[groupKey(o.Namespace, o.Name)] = o
+		case *keycloakApi.KeycloakAuthFlow:
+			live.flows[o.Spec.Alias] = o.Spec
+		}
+	}
+
+	for _, g := range groups {
+		live.groups[groupPath(g, groups)] = g.Spec
+	}
+
+	return &live
+}
+
+func groupKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// groupPath returns the path of the group made from the names of the parent group custom resources.
+// The groups are indexed by the namespace and the name, the parent group is in the namespace of the child group.
+func groupPath(group *keycloakApi.KeycloakRealmGroup, groups map[string]*keycloakApi.KeycloakRealmGroup) string {
+	path := "/" + group.Spec.Name
+	visited := map[string]struct{}{group.Name: {}}
+
+	for parent := group.Spec.ParentGroup; parent != nil; {
+		p, ok := groups[groupKey(group.Namespace, parent.Name)]
+		if !ok {
+			return "/" + parent.Name + path
+		}
+
+		if _, ok := visited[p.Name]; ok {
+			break
+		}
+
+		visited[p.Name] = struct{}{}
+		path = "/" + p.Spec.Name + path
+		parent = p.Spec.ParentGroup
+	}
+
+	return path
+}
+
+// verifier compares the custom resources of the realm with the live keycloak resources.
+type verifier struct {
+	client    client.Client
+	resolver  RealmResolver
+	realm     *keycloakApi.KeycloakRealm
+	namespace string
+	live      *liveResources
+	report    *Report
+}
+
+// children lists the custom resources and returns the ones which belong to the verified realm.
+func (v *verifier) children(ctx context.Context, list client.ObjectList) ([]client.Object, error) {
+	if err := v.client.List(ctx, list, client.InNamespace(v.namespace)); err != nil {
+		return nil, errors.Wrapf(err, "unable to list %T", list)
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to extract %T items", list)
+	}
+
+	children := make([]client.Object, 0, len(items))
+
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+
+		realm, err := v.resolver.ResolveRealm(obj)
+		if err != nil || !v.isVerifiedRealm(realm) {
+			continue
+		}
+
+		children = append(children, obj)
+	}
+
+	return children, nil
+}
+
+// isVerifiedRealm returns true if the realm is the verified one in the same keycloak.
+func (v *verifier) isVerifiedRealm(realm *keycloakApi.KeycloakRealm) bool {
+	if realm.Namespace != v.realm.Namespace || realm.Name != v.realm.Name {
+		return false
+	}
+
+	return keycloakRefKey(realm) == keycloakRefKey(v.realm)
+}
+
+// keycloakRefKey returns the explicit keycloak reference of the realm, it is empty if the realm uses its owner.
+func keycloakRefKey(realm *keycloakApi.KeycloakRealm) string {
+	ref := realm.Spec.KeycloakRef
+	if ref == nil {
+		return ""
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = realm.Namespace
+	}
+
+	return namespace + "/" + ref.Name
+}
+
+func (v *verifier) add(obj client.Object, res ResourceReport) {
+	res.Namespace = obj.GetNamespace()
+	v.report.add(res)
+}
+
+func (v *verifier) verifyRoles(ctx context.Context) error {
+	roles, err := v.children(ctx, &keycloakApi.KeycloakRealmRoleList{})
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range roles {
+		r := obj.(*keycloakApi.KeycloakRealmRole)
+		liveSpec, ok := v.live.roles[r.Spec.Name]
+		v.add(r, compareOrMissing("KeycloakRealmRole", r.Name, r.Spec, liveSpec, ok, roleFields))
+	}
+
+	return nil
+}
+
+func (v *verifier) verifyClients(ctx context.Context) error {
+	clients, err := v.children(ctx, &keycloakApi.KeycloakClientList{})
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range clients {
+		c := obj.(*keycloakApi.KeycloakClient)
+		liveSpec, ok := v.live.clients[c.Spec.ClientId]
+		v.add(c, compareOrMissing("KeycloakClient", c.Name, c.Spec, liveSpec, ok, clientFields))
+	}
+
+	return nil
+}
+
+func (v *verifier) verifyClientRoles(ctx context.Context) error {
+	roles, err := v.children(ctx, &keycloakApi.KeycloakClientRoleList{})
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range roles {
+		r := obj.(*keycloakApi.KeycloakClientRole)
+		liveSpec, ok := v.live.clientRoles[clientRoleKey(r.Spec.ClientID, r.Spec.Name)]
+		v.add(r, compareOrMissing("KeycloakClientRole", r.Name, r.Spec, liveSpec, ok, roleFields))
+	}
+
+	return nil
+}
+
+func (v *verifier) verifyClientScopes(ctx context.Context) error {
+	scopes, err := v.children(ctx, &keycloakApi.KeycloakClientScopeList{})
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range scopes {
+		s := obj.(*keycloakApi.KeycloakClientScope)
+		liveSpec, ok := v.live.clientScopes[s.Spec.Name]
+		v.add(s, compareOrMissing("KeycloakClientScope", s.Name, s.Spec, liveSpec, ok, clientScopeFields))
+	}
+
+	return nil
+}
+
+func (v *verifier) verifyGroups(ctx context.Context) error {
+	objects, err := v.children(ctx, &keycloakApi.KeycloakRealmGroupList{})
+	if err != nil {
+		return err
+	}
+
+	groups := make(map[string]*keycloakApi.KeycloakRealmGroup, len(objects))
+
+	for _, obj := range objects {
+		groups[groupKey(obj.GetNamespace(), obj.GetName())] = obj.(*keycloakApi.KeycloakRealmGroup)
+	}
+
+	for _, obj := range objects {
+		g := obj.(*keycloakApi.KeycloakRealmGroup)
+		liveSpec, ok := v.live.groups[groupPath(g, groups)]
+		v.add(g, compareOrMissing("KeycloakRealmGroup", g.Name, g.Spec, liveSpec, ok, groupFields))
+	}
+
+	return nil
+}
+
+func (v *verifier) verifyAuthFlows(ctx context.Context) error {
+	flows, err := v.children(ctx, &keycloakApi.KeycloakAuthFlowList{})
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range flows {
+		f := obj.(*keycloakApi.KeycloakAuthFlow)
+		liveSpec, ok := v.live.flows[f.Spec.Alias]
+		v.add(f, compareOrMissing("KeycloakAuthFlow", f.Name, f.Spec, liveSpec, ok, flowFields))
+	}
+
+	return nil
+}
+
+func (v *verifier) verifyIdentityProviders(ctx context.Context) error {
+	idps, err := v.children(ctx, &keycloakApi.KeycloakRealmIdentityProviderList{})
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range idps {
+		idp := obj.(*keycloakApi.KeycloakRealmIdentityProvider)
+		liveSpec, ok := v.live.idps[idp.Spec.Alias]
+		v.add(idp, compareOrMissing("KeycloakRealmIdentityProvider", idp.Name, idp.Spec, liveSpec, ok, idpFields))
+	}
+
+	return nil
+}
+
+func (v *verifier) verifyComponents(ctx context.Context) error {
+	components, err := v.children(ctx, &keycloakApi.KeycloakRealmComponentList{})
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range components {
+		c := obj.(*keycloakApi.KeycloakRealmComponent)
+		liveSpec, ok := v.live.components[componentKey(c.Spec.ProviderType, c.Spec.Name)]
+		v.add(c, compareOrMissing("KeycloakRealmComponent", c.Name, c.Spec, liveSpec, ok, componentFields))
+	}
+
+	return nil
+}
+
+func compareOrMissing(kind, name string, desired, live interface{}, found bool, fields []string) ResourceReport {
+	if !found {
+		return ResourceReport{Kind: kind, Name: name, Status: StatusMissing}
+	}
+
+	return compare(kind, name, desired, live, fields)
+}
+
+func compare(kind, name string, desired, live interface{}, fields []string) ResourceReport {
+	res := ResourceReport{Kind: kind, Name: name, Status: StatusInSync}
+
+	desiredMap := toMap(desired)
+	liveMap := toMap(live)
+
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+
+		desiredValue := lookup(desiredMap, path)
+		if desiredValue == nil {
+			continue
+		}
+
+		res.Diffs = append(res.Diffs, diffValues(field, desiredValue, lookup(liveMap, path))...)
+	}
+
+	if len(res.Diffs) > 0 {
+		res.Status = StatusDrifted
+	}
+
+	return res
+}
+
+// diffValues compares the declared keys of the objects and the other values as a whole.
+func diffValues(field string, desired, live interface{}) []FieldDiff {
+	desiredMap, ok := desired.(map[string]interface{})
+	if !ok {
+		if equalValues(field, desired, live) {
+			return nil
+		}
+
+		return []FieldDiff{{Field: field, Desired: desired, Live: live}}
+	}
+
+	liveMap, _ := live.(map[string]interface{})
+
+	keys := make([]string, 0, len(desiredMap))
+	for k := range desiredMap {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	var diffs []FieldDiff
+
+	for _, k := range keys {
+		diffs = append(diffs, diffValues(field+"."+k, desiredMap[k], liveMap[k])...)
+	}
+
+	return diffs
+}
+
+// equalValues compares the values, the zero values are equal to the missing ones
+// and the masked secrets are equal to any value.
+// The authentication executions are compared by their order, the other lists regardless of the order.
+func equalValues(field string, desired, live interface{}) bool {
+	if isZero(desired) && isZero(live) {
+		return true
+	}
+
+	if live == maskedSecret {
+		return true
+	}
+
+	if field == "authenticationExecutions" {
+		return reflect.DeepEqual(executionsOrder(desired), executionsOrder(live))
+	}
+
+	return reflect.DeepEqual(normalize(desired), normalize(live))
+}
+
+// executionsOrder returns the executions sorted by the priority without the priority.
+func executionsOrder(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	executions := make([]map[string]interface{}, 0, len(list))
+
+	for _, item := range list {
+		if ex, ok := item.(map[string]interface{}); ok {
+			executions = append(executions, ex)
+		}
+	}
+
+	sort.SliceStable(executions, func(i, j int) bool {
+		pi, _ := executions[i]["priority"].(float64)
+		pj, _ := executions[j]["priority"].(float64)
+
+		return pi < pj
+	})
+
+	result := make([]interface{}, 0, len(executions))
+
+	for _, ex := range executions {
+		withoutPriority := make(map[string]interface{}, len(ex))
+
+		for k, v := range ex {
+			if k != "priority" {
+				withoutPriority[k] = v
+			}
+		}
+
+		result = append(result, withoutPriority)
+	}
+
+	return result
+}
+
+// normalize sorts the lists by the json representation of the items.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]interface{}, 0, len(v))
+		keys := make(map[int]string, len(v))
+
+		for i, item := range v {
+			items = append(items, normalize(item))
+			data, _ := json.Marshal(items[i])
+			keys[i] = string(data)
+		}
+
+		idx := make([]int, len(items))
+		for i := range idx {
+			idx[i] = i
+		}
+
+		sort.Slice(idx, func(i, j int) bool { return keys[idx[i]] < keys[idx[j]] })
+
+		sorted := make([]interface{}, 0, len(items))
+		for _, i := range idx {
+			sorted = append(sorted, items[i])
+		}
+
+		return sorted
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			m[k] = normalize(item)
+		}
+
+		return m
+	default:
+		return value
+	}
+}
+
+func isZero(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func toMap(value interface{}) map[string]interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+
+	return m
+}
+
+func lookup(m map[string]interface{}, path []string) interface{} {
+	var value interface{} = m
+
+	for _, key := range path {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		value = obj[key]
+	}
+
+	return value
+}
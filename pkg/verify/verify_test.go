@@ -0,0 +1,397 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v12"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	keycloakApi "github.com/epam/edp-keycloak-operator/api/v1/v1"
+	"github.com/epam/edp-keycloak-operator/pkg/client/keycloak/adapter"
+)
+
+const testNamespace = "ns"
+
+type fakeExporter struct {
+	realm *adapter.RealmExport
+	err   error
+}
+
+func (f *fakeExporter) ExportRealm(_ context.Context, _ string) (*adapter.RealmExport, error) {
+	return f.realm, f.err
+}
+
+// fakeResolver resolves the realm by the realm name, the target realm of the clients
+// or by the realm label standing in for the realm selector.
+type fakeResolver struct {
+	k8sClient client.Client
+}
+
+func (r *fakeResolver) ResolveRealm(obj client.Object) (*keycloakApi.KeycloakRealm, error) {
+	name := obj.GetLabels()["realm"]
+
+	switch o := obj.(type) {
+	case *keycloakApi.KeycloakClient:
+		if o.Spec.TargetRealm == "realm" {
+			name = "main"
+		}
+	case interface{ K8SParentRealmName() (string, error) }:
+		if realmName, err := o.K8SParentRealmName(); err == nil {
+			name = realmName
+		}
+	}
+
+	namespace := obj.GetNamespace()
+	if o, ok := obj.(interface{ GetRealmNamespace() string }); ok && o.GetRealmNamespace() != "" {
+		namespace = o.GetRealmNamespace()
+	}
+
+	var realm keycloakApi.KeycloakRealm
+	if err := r.k8sClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: name},
+		&realm); err != nil {
+		return nil, err
+	}
+
+	return &realm, nil
+}
+
+func getTestScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+
+	if err := coreV1.AddToScheme(s); err != nil {
+		panic(err)
+	}
+
+	if err := keycloakApi.AddToScheme(s); err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+func getTestRealmExport() *adapter.RealmExport {
+	return &adapter.RealmExport{
+		Realm:       "realm",
+		BrowserFlow: "browser-otp",
+		Clients: []gocloak.Client{
+			{
+				ClientID:            gocloak.StringP("app"),
+				Enabled:             gocloak.BoolP(true),
+				PublicClient:        gocloak.BoolP(true),
+				DefaultClientScopes: &[]string{"profile", "email"},
+				Attributes:          &map[string]string{"post.logout.redirect.uris": "+", "pkce.code.challenge.method": "S256"},
+			},
+		},
+		Groups: []adapter.ExportGroup{
+			{
+				Name:      "team",
+				Path:      "/team",
+				SubGroups: []adapter.ExportGroup{{Name: "dev", Path: "/team/dev", RealmRoles: []string{"developer"}}},
+			},
+		},
+		Roles: adapter.ExportRoles{
+			Realm: []gocloak.Role{
+				{Name: gocloak.StringP("developer"), Description: gocloak.StringP("Developers")},
+				{Name: gocloak.StringP("viewer")},
+			},
+			Client: map[string][]gocloak.Role{
+				"app": {{Name: gocloak.StringP("editor"), Description: gocloak.StringP("Editors")}},
+			},
+		},
+		AuthenticationFlows: []adapter.ExportAuthFlow{
+			{
+				Alias:      "browser-otp",
+				ProviderID: "basic-flow",
+				TopLevel:   true,
+				AuthenticationExecutions: []adapter.ExportAuthExecution{
+					{Authenticator: "auth-cookie", Requirement: "ALTERNATIVE", Priority: 10},
+					{Authenticator: "auth-otp-form", Requirement: "REQUIRED", Priority: 20},
+				},
+			},
+		},
+		ClientScopes: []adapter.ClientScope{
+			{Name: "audience", Protocol: "openid-connect", Attributes: map[string]string{"include.in.token.scope": "true"}},
+		},
+		IdentityProviders: []adapter.IdentityProvider{
+			{
+				Alias:      "github",
+				ProviderID: "github",
+				Enabled:    true,
+				Config:     map[string]string{"clientId": "app", "clientSecret": "**********"},
+			},
+		},
+		Components: map[string][]adapter.ExportComponent{
+			"org.keycloak.keys.KeyProvider": {
+				{Name: "rsa", ProviderID: "rsa-generated", Config: map[string][]string{"priority": {"100"}}},
+			},
+		},
+	}
+}
+
+func getTestObjects() []client.Object {
+	return []client.Object{
+		&keycloakApi.KeycloakRealm{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "main"},
+			Spec:       keycloakApi.KeycloakRealmSpec{RealmName: "realm", BrowserFlow: gocloak.StringP("browser-otp")},
+		},
+		&keycloakApi.KeycloakRealmRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "developer"},
+			Spec:       keycloakApi.KeycloakRealmRoleSpec{Realm: "main", Name: "developer", Description: "Developers"},
+		},
+		&keycloakApi.KeycloakRealmRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "viewer"},
+			Spec:       keycloakApi.KeycloakRealmRoleSpec{Realm: "main", Name: "viewer", Description: "Viewers"},
+		},
+		&keycloakApi.KeycloakRealmRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "admin"},
+			Spec:       keycloakApi.KeycloakRealmRoleSpec{Realm: "main", Name: "admin"},
+		},
+		&keycloakApi.KeycloakRealmRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "other-realm-role"},
+			Spec:       keycloakApi.KeycloakRealmRoleSpec{Realm: "other", Name: "other"},
+		},
+		&keycloakApi.KeycloakRealmRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "selected-role",
+				Labels: map[string]string{"realm": "main"}},
+			Spec: keycloakApi.KeycloakRealmRoleSpec{Name: "developer", Description: "Developers",
+				RealmSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+		},
+		&keycloakApi.KeycloakRealmRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "team-viewer"},
+			Spec: keycloakApi.KeycloakRealmRoleSpec{Realm: "main", RealmNamespace: testNamespace, Name: "viewer",
+				Description: "Viewers"},
+		},
+		&keycloakApi.KeycloakClientRole{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "app-editor"},
+			Spec: keycloakApi.KeycloakClientRoleSpec{Realm: "main", ClientID: "app", Name: "editor",
+				Description: "Editors"},
+		},
+		&keycloakApi.KeycloakClientScope{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "audience"},
+			Spec: keycloakApi.KeycloakClientScopeSpec{Realm: "main", Name: "audience", Protocol: "openid-connect",
+				Attributes: map[string]string{"include.in.token.scope": "false"}},
+		},
+		&keycloakApi.KeycloakRealmIdentityProvider{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "github"},
+			Spec: keycloakApi.KeycloakRealmIdentityProviderSpec{Realm: "main", Alias: "github", ProviderID: "github",
+				Enabled: true, Config: map[string]string{"clientId": "app", "clientSecret": "secret"}},
+		},
+		&keycloakApi.KeycloakRealmComponent{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "rsa"},
+			Spec: keycloakApi.KeycloakComponentSpec{Realm: "main", Name: "rsa", ProviderID: "rsa-generated",
+				ProviderType: "org.keycloak.keys.KeyProvider"},
+		},
+		&keycloakApi.KeycloakClient{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "app"},
+			Spec: keycloakApi.KeycloakClientSpec{
+				TargetRealm:         "realm",
+				ClientId:            "app",
+				Public:              gocloak.BoolP(true),
+				DefaultClientScopes: []string{"email", "profile"},
+				Attributes:          map[string]string{"post.logout.redirect.uris": "+"},
+			},
+		},
+		&keycloakApi.KeycloakRealmGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "team"},
+			Spec:       keycloakApi.KeycloakRealmGroupSpec{Realm: "main", Name: "team"},
+		},
+		&keycloakApi.KeycloakRealmGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "team-dev"},
+			Spec: keycloakApi.KeycloakRealmGroupSpec{
+				Realm:       "main",
+				Name:        "dev",
+				ParentGroup: &keycloakApi.ParentGroup{Name: "team"},
+				RealmRoles:  []string{"developer"},
+			},
+		},
+		&keycloakApi.KeycloakAuthFlow{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "browser-otp"},
+			Spec: keycloakApi.KeycloakAuthFlowSpec{
+				Realm:      "main",
+				Alias:      "browser-otp",
+				ProviderID: "basic-flow",
+				TopLevel:   true,
+				AuthenticationExecutions: []keycloakApi.AuthenticationExecution{
+					{Authenticator: "auth-cookie", Requirement: "ALTERNATIVE", Priority: 0},
+					{Authenticator: "auth-otp-form", Requirement: "REQUIRED", Priority: 1},
+				},
+			},
+		},
+	}
+}
+
+func getTestRealmCR(t *testing.T, k8sClient client.Client) *keycloakApi.KeycloakRealm {
+	t.Helper()
+
+	realm, err := GetRealmCR(context.Background(), k8sClient, testNamespace, "realm")
+	require.NoError(t, err)
+
+	return realm
+}
+
+func TestVerify(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme()).WithObjects(getTestObjects()...).Build()
+
+	report, err := Verify(context.Background(), k8sClient, &fakeResolver{k8sClient: k8sClient},
+		&fakeExporter{realm: getTestRealmExport()}, getTestRealmCR(t, k8sClient), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "realm", report.Realm)
+	assert.Equal(t, 10, report.InSync)
+	assert.Equal(t, 3, report.Drifted)
+	assert.Equal(t, 1, report.Missing)
+	assert.True(t, report.HasDrift())
+
+	statuses := make(map[string]ResourceReport)
+	for _, r := range report.Resources {
+		statuses[r.Kind+"/"+r.Namespace+"/"+r.Name] = r
+	}
+
+	assert.Len(t, statuses, 14)
+	assert.Equal(t, StatusInSync, statuses["KeycloakRealm/ns/main"].Status)
+	assert.Equal(t, StatusInSync, statuses["KeycloakRealmRole/ns/developer"].Status)
+	assert.Equal(t, StatusInSync, statuses["KeycloakRealmRole/ns/selected-role"].Status)
+	assert.Equal(t, StatusMissing, statuses["KeycloakRealmRole/ns/admin"].Status)
+	assert.Equal(t, StatusInSync, statuses["KeycloakClient/ns/app"].Status)
+	assert.Equal(t, StatusInSync, statuses["KeycloakClientRole/ns/app-editor"].Status)
+	assert.Equal(t, StatusInSync, statuses["KeycloakRealmGroup/ns/team-dev"].Status)
+	assert.Equal(t, StatusInSync, statuses["KeycloakAuthFlow/ns/browser-otp"].Status)
+	assert.Equal(t, StatusInSync, statuses["KeycloakRealmIdentityProvider/ns/github"].Status,
+		"the masked secret is equal to the declared one")
+	assert.Equal(t, StatusInSync, statuses["KeycloakRealmComponent/ns/rsa"].Status)
+	assert.NotContains(t, statuses, "KeycloakRealmRole/ns/other-realm-role")
+
+	for _, name := range []string{"KeycloakRealmRole/ns/viewer", "KeycloakRealmRole/team/team-viewer"} {
+		viewer := statuses[name]
+		assert.Equal(t, StatusDrifted, viewer.Status)
+		assert.Equal(t, []FieldDiff{{Field: "description", Desired: "Viewers", Live: nil}}, viewer.Diffs)
+	}
+
+	scope := statuses["KeycloakClientScope/ns/audience"]
+	assert.Equal(t, StatusDrifted, scope.Status)
+	assert.Equal(t, []FieldDiff{{Field: "attributes.include.in.token.scope", Desired: "false", Live: "true"}},
+		scope.Diffs)
+}
+
+func TestVerify_Namespace(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme()).WithObjects(getTestObjects()...).Build()
+
+	report, err := Verify(context.Background(), k8sClient, &fakeResolver{k8sClient: k8sClient},
+		&fakeExporter{realm: getTestRealmExport()}, getTestRealmCR(t, k8sClient), testNamespace)
+	require.NoError(t, err)
+
+	for _, r := range report.Resources {
+		assert.Equal(t, testNamespace, r.Namespace)
+	}
+
+	assert.Len(t, report.Resources, 13)
+}
+
+func TestVerify_RealmNotFound(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme()).Build()
+
+	_, err := GetRealmCR(context.Background(), k8sClient, testNamespace, "realm")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "KeycloakRealm with realm name realm not found")
+}
+
+func TestVerify_ExportError(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme()).WithObjects(getTestObjects()...).Build()
+
+	_, err := Verify(context.Background(), k8sClient, &fakeResolver{k8sClient: k8sClient},
+		&fakeExporter{err: errors.New("fatal")}, getTestRealmCR(t, k8sClient), testNamespace)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to export realm realm")
+}
+
+func TestCompare(t *testing.T) {
+	desired := keycloakApi.KeycloakClientSpec{
+		ClientId:     "app",
+		Attributes:   map[string]string{"a": "1", "b": "2"},
+		DirectAccess: gocloak.BoolP(true),
+		ClientRoles:  []string{"viewer", "admin"},
+	}
+	live := keycloakApi.KeycloakClientSpec{
+		ClientId:    "app",
+		Attributes:  map[string]string{"a": "1", "b": "3", "c": "4"},
+		ClientRoles: []string{"admin"},
+		WebUrl:      "https://example.com",
+	}
+
+	res := compare("KeycloakClient", "app", desired, live, clientFields)
+
+	assert.Equal(t, StatusDrifted, res.Status)
+	assert.Equal(t, []FieldDiff{
+		{Field: "directAccess", Desired: true, Live: nil},
+		{Field: "attributes.b", Desired: "2", Live: "3"},
+		{Field: "clientRoles", Desired: []interface{}{"viewer", "admin"}, Live: []interface{}{"admin"}},
+	}, res.Diffs)
+}
+
+func TestVerifyCommand(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().WithScheme(getTestScheme()).WithObjects(getTestObjects()...).Build()
+	cmdArgs := &commandArgs{namespace: testNamespace, output: OutputJSON, configMap: "report", failOnDrift: true}
+	cmdArgs.conn.Realm = "realm"
+
+	var out bytes.Buffer
+
+	resolver := &fakeResolver{k8sClient: k8sClient}
+
+	err := verify(context.Background(), k8sClient, resolver, &fakeExporter{realm: getTestRealmExport()}, cmdArgs, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 drifted and 1 missing resources")
+	assert.Contains(t, out.String(), `"status": "Missing"`)
+
+	var cm coreV1.ConfigMap
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: "report"}, &cm))
+	assert.Equal(t, out.String(), cm.Data["report.json"])
+
+	cmdArgs.failOnDrift = false
+	cmdArgs.output = OutputYAML
+	out.Reset()
+
+	require.NoError(t, verify(context.Background(), k8sClient, resolver, &fakeExporter{realm: getTestRealmExport()},
+		cmdArgs, &out))
+	assert.Contains(t, out.String(), "status: Missing")
+	assert.NotContains(t, out.String(), "team-viewer", "only the namespace of the realm is verified by default")
+
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: "report"}, &cm))
+	assert.Equal(t, out.String(), cm.Data["report.yaml"])
+	assert.Contains(t, cm.Data, "report.json")
+
+	cmdArgs.allNamespaces = true
+	out.Reset()
+
+	require.NoError(t, verify(context.Background(), k8sClient, resolver, &fakeExporter{realm: getTestRealmExport()},
+		cmdArgs, &out))
+	assert.Contains(t, out.String(), "team-viewer")
+}
+
+func TestParseArgs(t *testing.T) {
+	t.Setenv("KEYCLOAK_PASSWORD", "secret")
+
+	cmdArgs, err := parseArgs([]string{"--url", "https://kc", "--user", "admin", "--realm", "realm",
+		"--namespace", testNamespace, "--configmap", "report", "--fail-on-drift", "--all-namespaces"})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", cmdArgs.conn.Password)
+	assert.Equal(t, OutputYAML, cmdArgs.output)
+	assert.Equal(t, "report", cmdArgs.configMap)
+	assert.True(t, cmdArgs.failOnDrift)
+	assert.True(t, cmdArgs.allNamespaces)
+
+	_, err = parseArgs([]string{"--url", "https://kc", "--user", "admin", "--realm", "realm"})
+	assert.EqualError(t, err, "namespace is required")
+
+	_, err = parseArgs([]string{"--url", "https://kc", "--user", "admin", "--realm", "realm",
+		"--namespace", testNamespace, "--output", "xml"})
+	assert.EqualError(t, err, "unsupported output xml")
+}